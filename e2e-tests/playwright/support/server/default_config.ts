@@ -110,6 +110,8 @@ const defaultServerConfig: AdminConfig = {
         EnableOutgoingOAuthConnections: false,
         EnableCommands: true,
         OutgoingIntegrationRequestsTimeout: 30,
+        OutgoingWebhookMaxRetries: 5,
+        OutgoingWebhookDisableAfterFailures: 50,
         EnablePostUsernameOverride: false,
         EnablePostIconOverride: false,
         GoogleDeveloperKey: '',
@@ -248,6 +250,8 @@ const defaultServerConfig: AdminConfig = {
         MigrationsStatementTimeoutSeconds: 100000,
         ReplicaLagSettings: [],
         ReplicaMonitorIntervalSeconds: 5,
+        ReplicaMaxLagSeconds: 0,
+        ReadYourWritesWindowSeconds: 0,
     },
     LogSettings: {
         EnableConsole: true,
@@ -273,6 +277,7 @@ const defaultServerConfig: AdminConfig = {
         FileMaxBackups: 0,
         FileCompress: false,
         FileMaxQueueSize: 1000,
+        DatabaseEnabled: false,
         AdvancedLoggingJSON: {},
     },
     NotificationLogSettings: {
@@ -381,6 +386,8 @@ const defaultServerConfig: AdminConfig = {
         VaryByRemoteAddr: true,
         VaryByUser: false,
         VaryByHeader: '',
+        Store: 'memory',
+        Policies: [],
     },
     PrivacySettings: {
         ShowEmailAddress: true,
@@ -647,6 +654,8 @@ const defaultServerConfig: AdminConfig = {
         BatchSize: 3000,
         TimeBetweenBatchesMilliseconds: 100,
         RetentionIdsBatchSize: 100,
+        EnableAuditLogDeletion: false,
+        AuditLogRetentionDays: 365,
     },
     MessageExportSettings: {
         EnableExport: false,
@@ -670,6 +679,7 @@ const defaultServerConfig: AdminConfig = {
         RunScheduler: true,
         CleanupJobsThresholdDays: -1,
         CleanupConfigThresholdDays: -1,
+        ConfigFileHistorySize: 10,
     },
     PluginSettings: {
         Enable: true,
@@ -717,6 +727,8 @@ const defaultServerConfig: AdminConfig = {
         ImageProxyType: 'local',
         RemoteImageProxyURL: '',
         RemoteImageProxyOptions: '',
+        LocalImageProxyCacheSizeMB: 1024,
+        LocalImageProxyMaxImageResolution: 33177600,
     },
     CloudSettings: {
         CWSURL: 'https://customers.mattermost.com',
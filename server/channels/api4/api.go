@@ -311,10 +311,12 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitJob()
 	api.InitCommand()
 	api.InitStatus()
+	api.InitOutOfOffice()
 	api.InitWebSocket()
 	api.InitEmoji()
 	api.InitOAuth()
 	api.InitReaction()
+	api.InitPoll()
 	api.InitPlugin()
 	api.InitRole()
 	api.InitScheme()
@@ -338,6 +340,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitOutgoingOAuthConnection()
 	api.InitClientPerformanceMetrics()
 	api.InitScheduledPost()
+	api.InitWebAuthn()
 
 	// If we allow testing then listen for manual testing URL hits
 	if *srv.Config().ServiceSettings.EnableTesting {
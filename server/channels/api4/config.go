@@ -37,6 +37,9 @@ func (api *API) InitConfig() {
 	api.BaseRoutes.APIRoot.Handle("/config/reload", api.APISessionRequired(configReload)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/config/client", api.APIHandler(getClientConfig)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/config/environment", api.APISessionRequired(getEnvironmentConfig)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history", api.APISessionRequired(getConfigHistory)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history/diff", api.APISessionRequired(getConfigVersionDiff)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history/rollback", api.APISessionRequired(rollbackConfig)).Methods(http.MethodPost)
 }
 
 func init() {
@@ -196,7 +199,7 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldCfg, newCfg, appErr := c.App.SaveConfig(cfg, true)
+	oldCfg, newCfg, appErr := c.App.SaveConfigWithAuthor(cfg, true, c.AppContext.Session().UserId)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -354,7 +357,7 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldCfg, newCfg, appErr := c.App.SaveConfig(updatedCfg, true)
+	oldCfg, newCfg, appErr := c.App.SaveConfigWithAuthor(updatedCfg, true, c.AppContext.Session().UserId)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -398,6 +401,122 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getConfigHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	versions, appErr := c.App.GetConfigHistory(c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(versions); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getConfigVersionDiff(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	query := r.URL.Query()
+	from := query.Get("from")
+	if !model.IsValidId(from) {
+		c.SetInvalidURLParam("from")
+		return
+	}
+	to := query.Get("to")
+	if to != "" && !model.IsValidId(to) {
+		c.SetInvalidURLParam("to")
+		return
+	}
+
+	diffs, appErr := c.App.GetConfigVersionDiff(from, to)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(diffs); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func rollbackConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+	versionID := props["version_id"]
+	if !model.IsValidId(versionID) {
+		c.SetInvalidParam("version_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("rollbackConfig", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "version_id", versionID)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	if !c.AppContext.Session().IsUnrestricted() && *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("rollbackConfig", "api.restricted_system_admin", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	oldCfg, newCfg, appErr := c.App.RollbackConfig(versionID, c.AppContext.Session().UserId, func(cfg *model.Config) *model.AppError {
+		// The settings that can't be changed through the API are kept as they are.
+		appCfg := c.App.Config()
+		*cfg.PluginSettings.EnableUploads = *appCfg.PluginSettings.EnableUploads
+		cfg.PluginSettings.SignaturePublicKeyFiles = appCfg.PluginSettings.SignaturePublicKeyFiles
+		if !*appCfg.PluginSettings.EnableUploads {
+			*cfg.PluginSettings.MarketplaceURL = *appCfg.PluginSettings.MarketplaceURL
+		}
+		if c.App.Channels().License().IsCloud() {
+			*cfg.ComplianceSettings.Directory = *appCfg.ComplianceSettings.Directory
+		}
+
+		return cfg.IsValid()
+	})
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	diffs, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		c.Err = model.NewAppError("rollbackConfig", "api.config.update_config.diff.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.AddEventPriorState(&diffs)
+
+	c.App.SanitizedConfig(newCfg)
+
+	cfg, err := config.Merge(&model.Config{}, newCfg, &utils.MergeConfig{
+		StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
+			return readFilter(c, structField)
+		},
+	})
+	if err != nil {
+		c.Err = model.NewAppError("rollbackConfig", "api.config.update_config.restricted_merge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	auditRec.AddEventObjectType("config")
+	auditRec.Success()
+	c.LogAudit("version_id=" + versionID)
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := json.NewEncoder(w).Encode(cfg); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func makeFilterConfigByPermission(accessType filterType) func(c *Context, structField reflect.StructField) bool {
 	return func(c *Context, structField reflect.StructField) bool {
 		if structField.Type.Kind() == reflect.Struct {
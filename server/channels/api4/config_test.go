@@ -150,6 +150,57 @@ func TestReloadConfig(t *testing.T) {
 	})
 }
 
+func TestConfigHistory(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	client := th.Client
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := client.GetConfigHistory(context.Background(), 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.GetConfigVersionDiff(context.Background(), model.NewId(), "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.RollbackConfig(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	// The test server uses a memory store, which doesn't keep the history.
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.GetConfigHistory(context.Background(), 0, 10)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = client.GetConfigVersionDiff(context.Background(), model.NewId(), "")
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = client.GetConfigVersionDiff(context.Background(), "invalid", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.RollbackConfig(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = client.RollbackConfig(context.Background(), "invalid")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	}, "as system admin and local mode")
+
+	t.Run("rollback as restricted system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+
+		_, resp, err := th.SystemAdminClient.RollbackConfig(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestUpdateConfig(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -21,7 +21,8 @@ type handlerFunc func(*Context, http.ResponseWriter, *http.Request)
 type APIHandlerOption string
 
 const (
-	handlerParamFileAPI = APIHandlerOption("fileAPI")
+	handlerParamFileAPI  = APIHandlerOption("fileAPI")
+	handlerParamReadOnly = APIHandlerOption("readOnly")
 )
 
 // APIHandler provides a handler for API endpoints which do not require the user to be logged in order for access to be
@@ -251,6 +252,8 @@ func setHandlerOpts(handler *web.Handler, opts ...APIHandlerOption) {
 		switch option {
 		case handlerParamFileAPI:
 			handler.FileAPI = true
+		case handlerParamReadOnly:
+			handler.ReadOnly = true
 		}
 	}
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOutOfOffice(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("update, get and delete own settings", func(t *testing.T) {
+		now := model.GetMillis()
+		ooo, _, err := th.Client.UpdateOutOfOffice(context.Background(), th.BasicUser.Id, &model.OutOfOffice{
+			Message:         "Away",
+			ReplyToMentions: true,
+			StartAt:         now + 60000,
+			EndAt:           now + 120000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Away", ooo.Message)
+		assert.False(t, ooo.Active)
+
+		ooo, _, err = th.Client.GetOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.True(t, ooo.ReplyToMentions)
+		assert.Equal(t, now+60000, ooo.StartAt)
+
+		_, err = th.Client.DeleteOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+
+		ooo, _, err = th.Client.GetOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.False(t, ooo.IsScheduled())
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateOutOfOffice(context.Background(), th.BasicUser.Id, &model.OutOfOffice{Active: true})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other users' settings", func(t *testing.T) {
+		_, resp, err := th.Client.GetOutOfOffice(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.UpdateOutOfOffice(context.Background(), th.BasicUser2.Id, &model.OutOfOffice{Active: true, Message: "Away"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		ooo, _, err := th.SystemAdminClient.UpdateOutOfOffice(context.Background(), th.BasicUser2.Id, &model.OutOfOffice{Active: true, Message: "Away"})
+		require.NoError(t, err)
+		assert.True(t, ooo.Active)
+
+		_, err = th.SystemAdminClient.DeleteOutOfOffice(context.Background(), th.BasicUser2.Id)
+		require.NoError(t, err)
+	})
+}
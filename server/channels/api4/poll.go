@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitPoll() {
+	api.BaseRoutes.Posts.Handle("/polls", api.APISessionRequired(createPoll)).Methods(http.MethodPost)
+	api.BaseRoutes.Post.Handle("/poll", api.APISessionRequired(getPollResults)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/poll/votes", api.APISessionRequired(votePoll)).Methods(http.MethodPost)
+	api.BaseRoutes.Post.Handle("/poll/close", api.APISessionRequired(closePoll)).Methods(http.MethodPost)
+}
+
+func createPoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	var pollCreate model.PollCreate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&pollCreate); jsonErr != nil {
+		c.SetInvalidParamWithErr("poll", jsonErr)
+		return
+	}
+
+	if !model.IsValidId(pollCreate.ChannelId) {
+		c.SetInvalidParam("channel_id")
+		return
+	}
+
+	if pollCreate.RootId != "" && !model.IsValidId(pollCreate.RootId) {
+		c.SetInvalidParam("root_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createPoll", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameter(auditRec, "channel_id", pollCreate.ChannelId)
+
+	userCreatePostPermissionCheckWithContext(c, pollCreate.ChannelId)
+	if c.Err != nil {
+		return
+	}
+
+	post, appErr := c.App.CreatePoll(c.AppContext, c.AppContext.Session().UserId, &pollCreate)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.Success()
+	auditRec.AddEventResultState(post)
+	auditRec.AddEventObjectType("post")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := post.EncodeJSON(w); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPollResults(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	results, appErr := c.App.GetPollResults(c.AppContext, c.Params.PostId, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writePollResults(c, w, "getPollResults", results)
+}
+
+func votePoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	var vote model.PollVoteRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&vote); jsonErr != nil {
+		c.SetInvalidParamWithErr("vote", jsonErr)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	results, appErr := c.App.VotePoll(c.AppContext, c.Params.PostId, c.AppContext.Session().UserId, vote.Options)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writePollResults(c, w, "votePoll", results)
+}
+
+func closePoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("closePoll", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "post_id", c.Params.PostId)
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	post, appErr := c.App.GetSinglePost(c.AppContext, c.Params.PostId, false)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// Only the author of the poll or someone allowed to edit their posts can close it.
+	if c.AppContext.Session().UserId != post.UserId {
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), post.ChannelId, model.PermissionEditOthersPosts) {
+			c.SetPermissionError(model.PermissionEditOthersPosts)
+			return
+		}
+	}
+
+	results, appErr := c.App.ClosePoll(c.AppContext, c.Params.PostId, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.Success()
+
+	writePollResults(c, w, "closePoll", results)
+}
+
+func writePollResults(c *Context, w http.ResponseWriter, where string, results *model.PollResults) {
+	js, err := json.Marshal(results)
+	if err != nil {
+		c.Err = model.NewAppError(where, "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
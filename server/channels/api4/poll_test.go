@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post, resp, err := th.Client.CreatePoll(context.Background(), &model.PollCreate{
+		ChannelId: th.BasicChannel.Id,
+		Question:  "Lunch?",
+		Options:   []string{"Pizza", "Sushi"},
+	})
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, model.PostTypePoll, post.Type)
+
+	t.Run("poll posts can't be created directly", func(t *testing.T) {
+		_, resp, err := th.Client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, Message: "Lunch?", Type: model.PostTypePoll})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("vote and read results", func(t *testing.T) {
+		results, _, err := th.Client.VotePoll(context.Background(), post.Id, []int{1})
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, results.Counts)
+		assert.Equal(t, []int{1}, results.MyVotes)
+
+		_, resp, err := th.Client.VotePoll(context.Background(), post.Id, []int{2})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		results, _, err = th.Client.GetPollResults(context.Background(), post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{}, {th.BasicUser.Id}}, results.Voters)
+	})
+
+	t.Run("non members can't vote", func(t *testing.T) {
+		channel := th.CreatePrivateChannel()
+		privatePost, _, err := th.Client.CreatePoll(context.Background(), &model.PollCreate{
+			ChannelId: channel.Id,
+			Question:  "Secret?",
+			Options:   []string{"Yes", "No"},
+		})
+		require.NoError(t, err)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.VotePoll(context.Background(), privatePost.Id, []int{0})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("only the author closes the poll", func(t *testing.T) {
+		th.LoginBasic2()
+		_, resp, err := th.Client.ClosePoll(context.Background(), post.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		th.LoginBasic()
+
+		results, _, err := th.Client.ClosePoll(context.Background(), post.Id)
+		require.NoError(t, err)
+		assert.True(t, results.Closed)
+
+		_, resp, err = th.Client.VotePoll(context.Background(), post.Id, []int{0})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
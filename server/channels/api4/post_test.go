@@ -4418,6 +4418,52 @@ func TestPostReminder(t *testing.T) {
 	require.Truef(t, caught, "User should have received %s event", model.WebsocketEventEphemeralMessage)
 }
 
+func TestListAndDeletePostReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+	targetTime := time.Now().Add(time.Hour).UTC().Unix()
+
+	_, err := client.SetPostReminder(context.Background(), &model.PostReminder{
+		TargetTime: targetTime,
+		PostId:     th.BasicPost.Id,
+		UserId:     th.BasicUser.Id,
+	})
+	require.NoError(t, err)
+
+	reminders, _, err := client.GetPostReminders(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, &model.PostReminder{TargetTime: targetTime, PostId: th.BasicPost.Id, UserId: th.BasicUser.Id}, reminders[0])
+
+	t.Run("other users' reminders", func(t *testing.T) {
+		_, resp, err := th.Client.GetPostReminders(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeletePostReminder(context.Background(), th.BasicUser2.Id, th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		reminders, _, err := th.SystemAdminClient.GetPostReminders(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+	})
+
+	resp, err := client.DeletePostReminder(context.Background(), th.BasicUser.Id, th.BasicPost.Id)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+
+	reminders, _, err = client.GetPostReminders(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	resp, err = client.DeletePostReminder(context.Background(), th.BasicUser.Id, th.BasicPost.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
+
 func TestPostGetInfo(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
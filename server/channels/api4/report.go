@@ -8,8 +8,11 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
@@ -18,6 +21,7 @@ func (api *API) InitReports() {
 	api.BaseRoutes.Reports.Handle("/users", api.APISessionRequired(getUsersForReporting)).Methods(http.MethodGet)
 	api.BaseRoutes.Reports.Handle("/users/count", api.APISessionRequired(getUserCountForReporting)).Methods(http.MethodGet)
 	api.BaseRoutes.Reports.Handle("/users/export", api.APISessionRequired(startUsersBatchExport)).Methods(http.MethodPost)
+	api.BaseRoutes.Reports.Handle("/{report_type:channel_activity|post_volume|guest_access}/export", api.APISessionRequired(startBatchReportExport)).Methods(http.MethodPost)
 }
 
 func getUsersForReporting(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -94,8 +98,14 @@ func startUsersBatchExport(c *Context, w http.ResponseWriter, r *http.Request) {
 		dateRange = "all_time"
 	}
 
+	format, err := getReportExportFormat(r.URL.Query())
+	if err != nil {
+		c.Err = err
+		return
+	}
+
 	startAt, endAt := model.GetReportDateRange(dateRange, time.Now())
-	if err := c.App.StartUsersBatchExport(c.AppContext, options, startAt, endAt); err != nil {
+	if err := c.App.StartUsersBatchExport(c.AppContext, options, startAt, endAt, format); err != nil {
 		c.Err = err
 		return
 	}
@@ -103,6 +113,52 @@ func startUsersBatchExport(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func startBatchReportExport(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !(c.IsSystemAdmin()) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	reportType := mux.Vars(r)["report_type"]
+	format, err := getReportExportFormat(r.URL.Query())
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	options := &model.ReportExportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{
+			DateRange: r.URL.Query().Get("date_range"),
+		},
+		Team:   r.URL.Query().Get("team_filter"),
+		Format: format,
+	}
+	if options.DateRange == "" {
+		options.DateRange = model.ReportDurationAllTime
+	}
+	options.PopulateDateRange(time.Now())
+
+	if err := c.App.StartBatchReportExport(c.AppContext, reportType, options); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func getReportExportFormat(values url.Values) (string, *model.AppError) {
+	format := values.Get("format")
+	if format == "" {
+		return model.ReportExportFormatCSV, nil
+	}
+
+	if !model.IsValidReportExportFormat(format) {
+		return "", model.NewAppError("getReportExportFormat", "api.report.export.invalid_format", map[string]any{"Formats": strings.Join(model.ReportExportFormats, ", ")}, "", http.StatusBadRequest)
+	}
+
+	return format, nil
+}
+
 func fillReportingBaseOptions(values url.Values) model.ReportingBaseOptions {
 	sortColumn := "Username"
 	if values.Get("sort_column") != "" {
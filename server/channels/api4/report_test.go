@@ -152,3 +152,64 @@ func TestFillUserReportOptions(t *testing.T) {
 		require.Equal(t, validTeamID, options.Team)
 	})
 }
+
+func TestStartBatchReportExport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("should return forbidden error when the user isn't a system admin", func(t *testing.T) {
+		resp, err := th.Client.StartBatchReportExport(context.Background(), model.ReportTypePostVolume, &model.ReportExportOptions{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("should return not found error on an unknown report type", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.StartBatchReportExport(context.Background(), "unknown", &model.ReportExportOptions{})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("should return bad request on an invalid format", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.StartBatchReportExport(context.Background(), model.ReportTypePostVolume, &model.ReportExportOptions{Format: "pdf"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should return bad request without a license", func(t *testing.T) {
+		th.App.Srv().SetLicense(nil)
+
+		resp, err := th.SystemAdminClient.StartBatchReportExport(context.Background(), model.ReportTypePostVolume, &model.ReportExportOptions{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should start the export job", func(t *testing.T) {
+		th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuEnterprise))
+		defer th.App.Srv().SetLicense(nil)
+
+		resp, err := th.SystemAdminClient.StartBatchReportExport(context.Background(), model.ReportTypeChannelActivity, &model.ReportExportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{DateRange: model.ReportDurationLast30Days},
+			Team:                 th.BasicTeam.Id,
+			Format:               model.ReportExportFormatXLSX,
+		})
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		jobs, jobErr := th.App.Srv().Store().Job().GetAllByType(th.Context, model.JobTypeExportChannelActivityReport)
+		require.NoError(t, jobErr)
+		require.Len(t, jobs, 1)
+		require.Equal(t, th.SystemAdminUser.Id, jobs[0].Data["requesting_user_id"])
+		require.Equal(t, model.ReportDurationLast30Days, jobs[0].Data["date_range"])
+		require.Equal(t, th.BasicTeam.Id, jobs[0].Data["team"])
+		require.Equal(t, model.ReportExportFormatXLSX, jobs[0].Data["format"])
+
+		// A second export with the same options is refused while the first one is pending.
+		resp, err = th.SystemAdminClient.StartBatchReportExport(context.Background(), model.ReportTypeChannelActivity, &model.ReportExportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{DateRange: model.ReportDurationLast30Days},
+			Team:                 th.BasicTeam.Id,
+			Format:               model.ReportExportFormatXLSX,
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
@@ -6,6 +6,7 @@ package api4
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -21,8 +22,16 @@ func (api *API) InitScheduledPost() {
 	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}", api.APISessionRequired(updateScheduledPost)).Methods(http.MethodPut)
 	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteScheduledPost)).Methods(http.MethodDelete)
 	api.BaseRoutes.Posts.Handle("/scheduled/team/{team_id:[A-Za-z0-9]+}", api.APISessionRequired(getTeamScheduledPosts)).Methods(http.MethodGet)
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}/pause", api.APISessionRequired(pauseScheduledPost)).Methods(http.MethodPost)
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}/resume", api.APISessionRequired(resumeScheduledPost)).Methods(http.MethodPost)
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}/occurrences", api.APISessionRequired(getScheduledPostOccurrences)).Methods(http.MethodGet)
 }
 
+const (
+	scheduledPostOccurrencesDefaultCount = 5
+	scheduledPostOccurrencesMaxCount     = 50
+)
+
 func scheduledPostChecks(where string, c *Context, scheduledPost *model.ScheduledPost) {
 	// ***************************************************************
 	// NOTE - if you make any change here, please make sure to apply the
@@ -227,3 +236,84 @@ func deleteScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func pauseScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	setScheduledPostPaused(c, w, r, true)
+}
+
+func resumeScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	setScheduledPostPaused(c, w, r, false)
+}
+
+func setScheduledPostPaused(c *Context, w http.ResponseWriter, r *http.Request, paused bool) {
+	requireScheduledPostsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	scheduledPostId := mux.Vars(r)["scheduled_post_id"]
+	if scheduledPostId == "" {
+		c.SetInvalidURLParam("scheduled_post_id")
+		return
+	}
+
+	event := "resumeScheduledPost"
+	if paused {
+		event = "pauseScheduledPost"
+	}
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "scheduledPostId", scheduledPostId)
+
+	userId := c.AppContext.Session().UserId
+	connectionID := r.Header.Get(model.ConnectionId)
+	scheduledPost, appErr := c.App.SetScheduledPostPaused(c.AppContext, userId, scheduledPostId, paused, connectionID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(scheduledPost)
+	auditRec.AddEventObjectType("scheduledPost")
+
+	if err := json.NewEncoder(w).Encode(scheduledPost); err != nil {
+		mlog.Error("failed to encode scheduled post to return API response", mlog.Err(err))
+		return
+	}
+}
+
+func getScheduledPostOccurrences(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireScheduledPostsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	scheduledPostId := mux.Vars(r)["scheduled_post_id"]
+	if scheduledPostId == "" {
+		c.SetInvalidURLParam("scheduled_post_id")
+		return
+	}
+
+	count := scheduledPostOccurrencesDefaultCount
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		var err error
+		count, err = strconv.Atoi(countStr)
+		if err != nil || count < 1 || count > scheduledPostOccurrencesMaxCount {
+			c.SetInvalidURLParam("count")
+			return
+		}
+	}
+
+	userId := c.AppContext.Session().UserId
+	occurrences, appErr := c.App.GetScheduledPostOccurrences(c.AppContext, userId, scheduledPostId, count)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(occurrences); err != nil {
+		mlog.Error("failed to encode scheduled post occurrences to return API response", mlog.Err(err))
+		return
+	}
+}
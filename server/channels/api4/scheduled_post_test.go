@@ -71,3 +71,78 @@ func TestCreateScheduledPost(t *testing.T) {
 		require.Nil(t, createdScheduledPost)
 	})
 }
+
+func TestRecurringScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuProfessional))
+
+	client := th.Client
+
+	scheduledPost := &model.ScheduledPost{
+		Draft: model.Draft{
+			CreateAt:  model.GetMillis(),
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "what did you do yesterday?",
+		},
+		ScheduledAt:    model.GetMillis() + 100000, // 100 seconds in the future
+		RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		Timezone:       "Europe/Paris",
+	}
+	createdScheduledPost, _, err := client.CreateScheduledPost(context.Background(), scheduledPost)
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR", createdScheduledPost.RecurrenceRule)
+	require.Equal(t, "Europe/Paris", createdScheduledPost.Timezone)
+
+	t.Run("should list upcoming occurrences", func(t *testing.T) {
+		occurrences, _, err := client.GetScheduledPostOccurrences(context.Background(), createdScheduledPost.Id, 4)
+		require.NoError(t, err)
+		require.Len(t, occurrences, 4)
+		require.Equal(t, createdScheduledPost.ScheduledAt, occurrences[0])
+		for i := 1; i < len(occurrences); i++ {
+			require.Greater(t, occurrences[i], occurrences[i-1])
+		}
+
+		_, resp, err := client.GetScheduledPostOccurrences(context.Background(), createdScheduledPost.Id, 1000)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should pause and resume", func(t *testing.T) {
+		paused, _, err := client.PauseScheduledPost(context.Background(), createdScheduledPost.Id)
+		require.NoError(t, err)
+		require.NotZero(t, paused.PausedAt)
+
+		resumed, _, err := client.ResumeScheduledPost(context.Background(), createdScheduledPost.Id)
+		require.NoError(t, err)
+		require.Zero(t, resumed.PausedAt)
+		require.Equal(t, createdScheduledPost.ScheduledAt, resumed.ScheduledAt)
+	})
+
+	t.Run("should not allow other users to pause", func(t *testing.T) {
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+
+		_, resp, err := client2.PauseScheduledPost(context.Background(), createdScheduledPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("should reject invalid recurrence rules", func(t *testing.T) {
+		invalid := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a scheduled post",
+			},
+			ScheduledAt:    model.GetMillis() + 100000,
+			RecurrenceRule: "FREQ=HOURLY",
+		}
+		_, resp, err := client.CreateScheduledPost(context.Background(), invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
@@ -45,6 +45,7 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/timezones", api.APISessionRequired(getSupportedTimezones)).Methods(http.MethodGet)
 
 	api.BaseRoutes.APIRoot.Handle("/audits", api.APISessionRequired(getAudits)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/audit_logs", api.APISessionRequired(searchAuditLogs)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/notifications/test", api.APISessionRequired(testNotifications)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/site_url/test", api.APISessionRequired(testSiteURL)).Methods(http.MethodPost)
@@ -54,7 +55,7 @@ func (api *API) InitSystem() {
 
 	api.BaseRoutes.APIRoot.Handle("/logs", api.APISessionRequired(getLogs)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/logs/download", api.APISessionRequired(downloadLogs)).Methods(http.MethodGet)
-	api.BaseRoutes.APIRoot.Handle("/logs/query", api.APISessionRequired(queryLogs)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/logs/query", api.APISessionRequired(queryLogs, handlerParamReadOnly)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/logs", api.APIHandler(postLog)).Methods(http.MethodPost)
 
 	api.BaseRoutes.APIRoot.Handle("/analytics/old", api.APISessionRequired(getAnalytics)).Methods(http.MethodGet)
@@ -328,6 +329,56 @@ func getAudits(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func searchAuditLogs(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	query := r.URL.Query()
+	opts := model.AuditLogSearchOptions{
+		ActorId:    query.Get("actor_id"),
+		EventName:  query.Get("event_name"),
+		ObjectType: query.Get("object_type"),
+		ObjectId:   query.Get("object_id"),
+		Page:       c.Params.Page,
+		PerPage:    c.Params.PerPage,
+	}
+
+	for param, value := range map[string]*int64{"start_time": &opts.StartTime, "end_time": &opts.EndTime} {
+		if query.Get(param) == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(query.Get(param), 10, 64)
+		if err != nil {
+			c.SetInvalidURLParam(param)
+			return
+		}
+		*value = parsed
+	}
+
+	auditRec := c.MakeAuditRecord("searchAuditLogs", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "actor_id", opts.ActorId)
+	audit.AddEventParameter(auditRec, "event_name", opts.EventName)
+	audit.AddEventParameter(auditRec, "object_type", opts.ObjectType)
+	audit.AddEventParameter(auditRec, "object_id", opts.ObjectId)
+	audit.AddEventParameter(auditRec, "start_time", opts.StartTime)
+	audit.AddEventParameter(auditRec, "end_time", opts.EndTime)
+
+	logs, appErr := c.App.SearchAuditLogs(c.AppContext, opts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(logs); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func databaseRecycle(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionRecycleDatabaseConnections) {
 		c.SetPermissionError(model.PermissionRecycleDatabaseConnections)
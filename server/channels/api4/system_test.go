@@ -142,6 +142,72 @@ func TestGetAudits(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestSearchAuditLogs(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	client := th.Client
+
+	channelID := model.NewId()
+	logs := []*model.AuditLog{
+		{EventName: "patchChannel", ActorId: th.BasicUser.Id, ObjectType: "channel", ObjectId: channelID, CreateAt: 1000},
+		{EventName: "patchChannel", ActorId: th.SystemAdminUser.Id, ObjectType: "channel", ObjectId: channelID, CreateAt: 2000},
+		{EventName: "updateUser", ActorId: th.BasicUser.Id, ObjectType: "user", ObjectId: th.BasicUser.Id, CreateAt: 3000},
+	}
+	for _, log := range logs {
+		_, err := th.App.Srv().Store().AuditLog().Save(log)
+		require.NoError(t, err)
+	}
+
+	t.Run("filter by object", func(t *testing.T) {
+		found, _, err := th.SystemAdminClient.SearchAuditLogs(context.Background(), model.AuditLogSearchOptions{
+			ObjectType: "channel",
+			ObjectId:   channelID,
+			PerPage:    10,
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		require.Equal(t, logs[1].Id, found[0].Id)
+		require.Equal(t, logs[0].Id, found[1].Id)
+	})
+
+	t.Run("filter by actor and time range", func(t *testing.T) {
+		found, _, err := th.SystemAdminClient.SearchAuditLogs(context.Background(), model.AuditLogSearchOptions{
+			ActorId:   th.BasicUser.Id,
+			StartTime: 2000,
+			EndTime:   3000,
+			PerPage:   10,
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, logs[2].Id, found[0].Id)
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.SearchAuditLogs(context.Background(), model.AuditLogSearchOptions{
+			StartTime: 3000,
+			EndTime:   2000,
+			PerPage:   10,
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("local mode", func(t *testing.T) {
+		found, _, err := th.LocalClient.SearchAuditLogs(context.Background(), model.AuditLogSearchOptions{
+			EventName: "updateUser",
+			PerPage:   10,
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		_, resp, err := client.SearchAuditLogs(context.Background(), model.AuditLogSearchOptions{PerPage: 10})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestEmailTest(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
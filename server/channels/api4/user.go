@@ -26,14 +26,14 @@ import (
 func (api *API) InitUser() {
 	api.BaseRoutes.Users.Handle("", api.APIHandler(createUser)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("", api.APISessionRequired(getUsers)).Methods(http.MethodGet)
-	api.BaseRoutes.Users.Handle("/ids", api.APISessionRequired(getUsersByIds)).Methods(http.MethodPost)
-	api.BaseRoutes.Users.Handle("/usernames", api.APISessionRequired(getUsersByNames)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/ids", api.APISessionRequired(getUsersByIds, handlerParamReadOnly)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/usernames", api.APISessionRequired(getUsersByNames, handlerParamReadOnly)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/known", api.APISessionRequired(getKnownUsers)).Methods(http.MethodGet)
-	api.BaseRoutes.Users.Handle("/search", api.APISessionRequiredDisableWhenBusy(searchUsers)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/search", api.APISessionRequiredDisableWhenBusy(searchUsers, handlerParamReadOnly)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/autocomplete", api.APISessionRequired(autocompleteUsers)).Methods(http.MethodGet)
 	api.BaseRoutes.Users.Handle("/stats", api.APISessionRequired(getTotalUsersStats)).Methods(http.MethodGet)
 	api.BaseRoutes.Users.Handle("/stats/filtered", api.APISessionRequired(getFilteredUsersStats)).Methods(http.MethodGet)
-	api.BaseRoutes.Users.Handle("/group_channels", api.APISessionRequired(getUsersByGroupChannelIds)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/group_channels", api.APISessionRequired(getUsersByGroupChannelIds, handlerParamReadOnly)).Methods(http.MethodPost)
 
 	api.BaseRoutes.User.Handle("", api.APISessionRequired(getUser)).Methods(http.MethodGet)
 	api.BaseRoutes.User.Handle("/image/default", api.APISessionRequiredTrustRequester(getDefaultProfileImage)).Methods(http.MethodGet)
@@ -61,6 +61,7 @@ func (api *API) InitUser() {
 
 	api.BaseRoutes.User.Handle("/mfa", api.APISessionRequiredMfa(updateUserMfa)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/mfa/generate", api.APISessionRequiredMfa(generateMfaSecret)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/mfa/recovery_codes", api.APISessionRequired(regenerateMfaRecoveryCodes)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Users.Handle("/login", api.APIHandler(login)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/desktop_token", api.RateLimitedHandler(api.APIHandler(loginWithDesktopToken), model.RateLimitSettings{PerSec: model.NewPointer(2), MaxBurst: model.NewPointer(1)})).Methods(http.MethodPost)
@@ -81,7 +82,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/tokens", api.APISessionRequired(createUserAccessToken)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/tokens", api.APISessionRequired(getUserAccessTokensForUser)).Methods(http.MethodGet)
 	api.BaseRoutes.Users.Handle("/tokens", api.APISessionRequired(getUserAccessTokens)).Methods(http.MethodGet)
-	api.BaseRoutes.Users.Handle("/tokens/search", api.APISessionRequired(searchUserAccessTokens)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/tokens/search", api.APISessionRequired(searchUserAccessTokens, handlerParamReadOnly)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/tokens/{token_id:[A-Za-z0-9]+}", api.APISessionRequired(getUserAccessToken)).Methods(http.MethodGet)
 	api.BaseRoutes.Users.Handle("/tokens/revoke", api.APISessionRequired(revokeUserAccessToken)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/tokens/disable", api.APISessionRequired(disableUserAccessToken)).Methods(http.MethodPost)
@@ -1672,7 +1673,8 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	c.LogAudit("attempt")
 
-	if err := c.App.UpdateMfa(c.AppContext, activate, c.Params.UserId, code); err != nil {
+	recoveryCodes, err := c.App.UpdateMfa(c.AppContext, activate, c.Params.UserId, code)
+	if err != nil {
 		c.Err = err
 		return
 	}
@@ -1681,7 +1683,21 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("activate", activate)
 	c.LogAudit("success - mfa updated")
 
-	ReturnStatusOK(w)
+	if !activate {
+		ReturnStatusOK(w)
+		return
+	}
+
+	// The recovery codes can only be shown once, so they are returned along with the status.
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		model.STATUS:     model.StatusOk,
+		"recovery_codes": recoveryCodes,
+	}); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
 }
 
 func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -1715,6 +1731,58 @@ func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func regenerateMfaRecoveryCodes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("regenerateMfaRecoveryCodes", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	// Recovery codes are as good as the second factor itself, so only the user can see them,
+	// and only after confirming their identity.
+	if c.Params.UserId != c.AppContext.Session().UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	user, appErr := c.App.GetUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	props := model.MapFromJSON(r.Body)
+	if appErr = c.App.DoubleCheckPasswordOrMfa(c.AppContext, user, props["password"], props["code"]); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	recoveryCodes, appErr := c.App.RegenerateMfaRecoveryCodes(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success - mfa recovery codes regenerated")
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(&model.MfaRecoveryCodes{RecoveryCodes: recoveryCodes}); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func updatePassword(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -1837,65 +1905,69 @@ func sendPasswordReset(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
-func login(c *Context, w http.ResponseWriter, r *http.Request) {
-	// Mask all sensitive errors, with the exception of the following
-	defer func() {
-		if c.Err == nil {
-			return
-		}
+// maskLoginError hides the errors of a login attempt that would tell whether an account exists.
+func maskLoginError(c *Context) {
+	if c.Err == nil {
+		return
+	}
 
-		unmaskedErrors := []string{
-			"mfa.validate_token.authenticate.app_error",
-			"api.user.check_user_mfa.bad_code.app_error",
-			"api.user.login.blank_pwd.app_error",
-			"api.user.login.bot_login_forbidden.app_error",
-			"api.user.login.remote_users.login.error",
-			"api.user.login.client_side_cert.certificate.app_error",
-			"api.user.login.inactive.app_error",
-			"api.user.login.not_verified.app_error",
-			"api.user.check_user_login_attempts.too_many.app_error",
-			"app.team.join_user_to_team.max_accounts.app_error",
-			"store.sql_user.save.max_accounts.app_error",
-		}
+	// Mask all sensitive errors, with the exception of the following
+	unmaskedErrors := []string{
+		"mfa.validate_token.authenticate.app_error",
+		"api.user.check_user_mfa.bad_code.app_error",
+		"api.user.login.blank_pwd.app_error",
+		"api.user.login.bot_login_forbidden.app_error",
+		"api.user.login.remote_users.login.error",
+		"api.user.login.client_side_cert.certificate.app_error",
+		"api.user.login.inactive.app_error",
+		"api.user.login.not_verified.app_error",
+		"api.user.check_user_login_attempts.too_many.app_error",
+		"app.team.join_user_to_team.max_accounts.app_error",
+		"store.sql_user.save.max_accounts.app_error",
+		"app.webauthn.no_credentials.app_error",
+	}
 
-		maskError := true
+	maskError := true
 
-		for _, unmaskedError := range unmaskedErrors {
-			if c.Err.Id == unmaskedError {
-				maskError = false
-			}
+	for _, unmaskedError := range unmaskedErrors {
+		if c.Err.Id == unmaskedError {
+			maskError = false
 		}
+	}
 
-		if !maskError {
-			return
-		}
+	if !maskError {
+		return
+	}
 
-		config := c.App.Config()
-		enableUsername := *config.EmailSettings.EnableSignInWithUsername
-		enableEmail := *config.EmailSettings.EnableSignInWithEmail
-		samlEnabled := *config.SamlSettings.Enable
-		gitlabEnabled := *config.GitLabSettings.Enable
-		openidEnabled := *config.OpenIdSettings.Enable
-		googleEnabled := *config.GoogleSettings.Enable
-		office365Enabled := *config.Office365Settings.Enable
+	config := c.App.Config()
+	enableUsername := *config.EmailSettings.EnableSignInWithUsername
+	enableEmail := *config.EmailSettings.EnableSignInWithEmail
+	samlEnabled := *config.SamlSettings.Enable
+	gitlabEnabled := *config.GitLabSettings.Enable
+	openidEnabled := *config.OpenIdSettings.Enable
+	googleEnabled := *config.GoogleSettings.Enable
+	office365Enabled := *config.Office365Settings.Enable
 
-		if samlEnabled || gitlabEnabled || googleEnabled || office365Enabled || openidEnabled {
-			c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_sso", nil, "", http.StatusUnauthorized)
-			return
-		}
+	if samlEnabled || gitlabEnabled || googleEnabled || office365Enabled || openidEnabled {
+		c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_sso", nil, "", http.StatusUnauthorized)
+		return
+	}
 
-		if enableUsername && !enableEmail {
-			c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_username", nil, "", http.StatusUnauthorized)
-			return
-		}
+	if enableUsername && !enableEmail {
+		c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_username", nil, "", http.StatusUnauthorized)
+		return
+	}
 
-		if !enableUsername && enableEmail {
-			c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_email", nil, "", http.StatusUnauthorized)
-			return
-		}
+	if !enableUsername && enableEmail {
+		c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_email", nil, "", http.StatusUnauthorized)
+		return
+	}
 
-		c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_email_username", nil, "", http.StatusUnauthorized)
-	}()
+	c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_email_username", nil, "", http.StatusUnauthorized)
+}
+
+func login(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer maskLoginError(c)
 
 	props := model.MapFromJSON(r.Body)
 	id := props["id"]
@@ -29,6 +29,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
 	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa"
 
 	_ "github.com/mattermost/mattermost/server/v8/channels/app/oauthproviders/gitlab"
 )
@@ -3581,6 +3582,116 @@ func TestGenerateMfaSecret(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestMfaRecoveryCodes(t *testing.T) {
+	logFile, err := os.CreateTemp("", "adv.log")
+	require.NoError(t, err)
+	defer os.Remove(logFile.Name())
+
+	os.Setenv("MM_EXPERIMENTALAUDITSETTINGS_FILEENABLED", "true")
+	os.Setenv("MM_EXPERIMENTALAUDITSETTINGS_FILENAME", logFile.Name())
+	defer os.Unsetenv("MM_EXPERIMENTALAUDITSETTINGS_FILEENABLED")
+	defer os.Unsetenv("MM_EXPERIMENTALAUDITSETTINGS_FILENAME")
+
+	options := []app.Option{app.WithLicense(model.NewTestLicense("mfa", "advanced_logging"))}
+	th := SetupWithServerOptions(t, options)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	_, resp, err := th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, th.BasicUser.Password, "")
+	CheckErrorID(t, err, "app.user.mfa_recovery_codes.mfa_inactive.app_error")
+	CheckBadRequestStatus(t, resp)
+
+	secret, _, err := th.Client.GenerateMfaSecret(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+
+	code := dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30)
+	r, err := th.Client.DoAPIPut(context.Background(), "/users/"+th.BasicUser.Id+"/mfa", fmt.Sprintf(`{"activate": true, "code": "%06d"}`, code))
+	require.NoError(t, err)
+	defer r.Body.Close()
+
+	var activated struct {
+		Status        string   `json:"status"`
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&activated))
+	assert.Equal(t, model.StatusOk, activated.Status)
+	require.Len(t, activated.RecoveryCodes, mfa.RecoveryCodeCount)
+
+	t.Run("login with a recovery code", func(t *testing.T) {
+		user, _, err := th.CreateClient().LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, activated.RecoveryCodes[0])
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, user.Id)
+
+		// A recovery code can only be used once.
+		_, _, err = th.CreateClient().LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, activated.RecoveryCodes[0])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+
+		// Forcing a flush before attempting to read log's content.
+		require.NoError(t, th.Server.Audit.Flush())
+		require.NoError(t, logFile.Sync())
+
+		data, err := io.ReadAll(logFile)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"event_name":"useMfaRecoveryCode"`)
+		require.Contains(t, string(data), fmt.Sprintf(`"recovery_codes_left":%d`, mfa.RecoveryCodeCount-1))
+		require.NotContains(t, string(data), activated.RecoveryCodes[0])
+	})
+
+	t.Run("only the user can regenerate their recovery codes", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, th.SystemAdminUser.Password, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser2.Id, th.BasicUser.Password, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires the password or an MFA code", func(t *testing.T) {
+		_, resp, err := th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, "", "")
+		CheckErrorID(t, err, "api.user.double_check_password_or_mfa.missing.app_error")
+		CheckUnauthorizedStatus(t, resp)
+
+		_, resp, err = th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, "wrong", "")
+		CheckErrorID(t, err, "api.user.check_user_password.invalid.app_error")
+		CheckUnauthorizedStatus(t, resp)
+
+		_, resp, err = th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, "", "000000")
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("regenerate with an MFA code", func(t *testing.T) {
+		code := dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30)
+		codes, _, err := th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, "", fmt.Sprintf("%06d", code))
+		require.NoError(t, err)
+		require.Len(t, codes.RecoveryCodes, mfa.RecoveryCodeCount)
+	})
+
+	t.Run("regenerate", func(t *testing.T) {
+		codes, _, err := th.Client.RegenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id, th.BasicUser.Password, "")
+		require.NoError(t, err)
+		require.Len(t, codes.RecoveryCodes, mfa.RecoveryCodeCount)
+
+		// The old codes no longer work.
+		_, _, err = th.CreateClient().LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, activated.RecoveryCodes[1])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+
+		_, _, err = th.CreateClient().LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.RecoveryCodes[1])
+		require.NoError(t, err)
+	})
+
+	t.Run("deactivating MFA removes the recovery codes", func(t *testing.T) {
+		_, err := th.SystemAdminClient.UpdateUserMfa(context.Background(), th.BasicUser.Id, "", false)
+		require.NoError(t, err)
+
+		count, err := th.App.Srv().Store().User().CountMfaRecoveryCodes(th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
 func TestUpdateUserPassword(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitWebAuthn() {
+	api.BaseRoutes.Users.Handle("/login/webauthn", api.APIHandler(beginWebAuthnLogin)).Methods(http.MethodPost)
+
+	api.BaseRoutes.User.Handle("/webauthn/registration", api.APISessionRequiredMfa(beginWebAuthnRegistration)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/webauthn/credentials", api.APISessionRequiredMfa(registerWebAuthnCredential)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/webauthn/credentials", api.APISessionRequiredMfa(getWebAuthnCredentials)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/webauthn/credentials/{credential_id:[A-Za-z0-9]+}", api.APISessionRequiredMfa(revokeWebAuthnCredential)).Methods(http.MethodDelete)
+}
+
+// requireWebAuthnSelf only lets users register security keys for themselves, from a regular session.
+func requireWebAuthnSelf(c *Context) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	if c.Params.UserId != c.AppContext.Session().UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+}
+
+func beginWebAuthnLogin(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer maskLoginError(c)
+
+	props := model.MapFromJSON(r.Body)
+	id := props["id"]
+	loginId := props["login_id"]
+	password := props["password"]
+
+	options, appErr := c.App.BeginWebAuthnLogin(c.AppContext, id, loginId, password)
+	if appErr != nil {
+		c.LogAuditWithUserId(id, "failure - webauthn login_id="+loginId)
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func beginWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireWebAuthnSelf(c)
+	if c.Err != nil {
+		return
+	}
+
+	options, appErr := c.App.BeginWebAuthnRegistration(c.AppContext, c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func registerWebAuthnCredential(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireWebAuthnSelf(c)
+	if c.Err != nil {
+		return
+	}
+
+	var registration model.WebAuthnRegistration
+	if jsonErr := json.NewDecoder(r.Body).Decode(&registration); jsonErr != nil {
+		c.SetInvalidParamWithErr("registration", jsonErr)
+		return
+	}
+	if registration.Credential == nil {
+		c.SetInvalidParam("credential")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("registerWebAuthnCredential", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+	audit.AddEventParameter(auditRec, "name", registration.Name)
+
+	// Adding a key adds a way to sign in, so a hijacked session is not enough.
+	user, appErr := c.App.GetUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if appErr = c.App.DoubleCheckPasswordOrMfa(c.AppContext, user, registration.Password, registration.MfaToken); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	credential, appErr := c.App.FinishWebAuthnRegistration(c.AppContext, c.Params.UserId, &registration)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	audit.AddEventParameter(auditRec, "credential_id", credential.Id)
+	c.LogAudit("success - webauthn credential registered")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(credential); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getWebAuthnCredentials(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	credentials, appErr := c.App.GetWebAuthnCredentials(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(credentials); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func revokeWebAuthnCredential(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	credentialID := mux.Vars(r)["credential_id"]
+	if !model.IsValidId(credentialID) {
+		c.SetInvalidURLParam("credential_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("revokeWebAuthnCredential", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+	audit.AddEventParameter(auditRec, "credential_id", credentialID)
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if appErr := c.App.RevokeWebAuthnCredential(c.AppContext, c.Params.UserId, credentialID); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success - webauthn credential revoked")
+
+	ReturnStatusOK(w)
+}
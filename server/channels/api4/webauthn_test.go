@@ -0,0 +1,321 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn/webauthntest"
+)
+
+func setupWebAuthn(th *TestHelper) *webauthntest.SoftwareAuthenticator {
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+	})
+
+	return webauthntest.NewSoftwareAuthenticator("http://localhost:8065")
+}
+
+func registerWebAuthnKey(t *testing.T, client *model.Client4, user *model.User, authenticator *webauthntest.SoftwareAuthenticator) *model.WebAuthnCredential {
+	t.Helper()
+
+	options, _, err := client.BeginWebAuthnRegistration(context.Background(), user.Id)
+	require.NoError(t, err)
+
+	attestation, err := authenticator.Register(options)
+	require.NoError(t, err)
+
+	return registerWebAuthnKeyWith(t, client, user, attestation)
+}
+
+func registerWebAuthnKeyWith(t *testing.T, client *model.Client4, user *model.User, attestation *model.WebAuthnAttestationResponse) *model.WebAuthnCredential {
+	t.Helper()
+
+	credential, resp, err := client.RegisterWebAuthnCredential(context.Background(), user.Id, &model.WebAuthnRegistration{
+		Name:       "YubiKey",
+		Credential: attestation,
+		Password:   user.Password,
+	})
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+
+	return credential
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("MFA disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = false })
+
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	authenticator := setupWebAuthn(th)
+
+	t.Run("register", func(t *testing.T) {
+		credential := registerWebAuthnKey(t, th.Client, th.BasicUser, authenticator)
+		assert.Equal(t, th.BasicUser.Id, credential.UserId)
+		assert.Equal(t, "YubiKey", credential.Name)
+		assert.Empty(t, credential.PublicKey)
+
+		user, _, err := th.SystemAdminClient.GetUser(context.Background(), th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.True(t, user.MfaActive)
+	})
+
+	t.Run("excluded key", func(t *testing.T) {
+		options := mustBeginRegistration(t, th.Client, th.BasicUser.Id)
+		require.Len(t, options.ExcludeCredentials, 1)
+
+		_, err := authenticator.Register(options)
+		require.Error(t, err)
+	})
+
+	t.Run("challenge can only be answered once", func(t *testing.T) {
+		options := mustBeginRegistration(t, th.Client, th.BasicUser.Id)
+
+		attestation, err := webauthntest.NewSoftwareAuthenticator("http://localhost:8065").Register(options)
+		require.NoError(t, err)
+
+		registerWebAuthnKeyWith(t, th.Client, th.BasicUser, attestation)
+
+		_, resp, err := th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Replay", Credential: attestation, Password: th.BasicUser.Password})
+		CheckErrorID(t, err, "app.webauthn.invalid_challenge.app_error")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("answer to another challenge", func(t *testing.T) {
+		options := mustBeginRegistration(t, th.Client, th.BasicUser.Id)
+		options.Challenge = webauthn.EncodeID([]byte(model.NewRandomString(64)))
+
+		attestation, err := webauthntest.NewSoftwareAuthenticator("http://localhost:8065").Register(options)
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Other", Credential: attestation, Password: th.BasicUser.Password})
+		CheckErrorID(t, err, "app.webauthn.invalid_challenge.app_error")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires the password or an MFA token", func(t *testing.T) {
+		options := mustBeginRegistration(t, th.Client, th.BasicUser.Id)
+		attestation, err := webauthntest.NewSoftwareAuthenticator("http://localhost:8065").Register(options)
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Stolen session", Credential: attestation})
+		CheckErrorID(t, err, "api.user.double_check_password_or_mfa.missing.app_error")
+		CheckUnauthorizedStatus(t, resp)
+
+		_, resp, err = th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Stolen session", Credential: attestation, Password: "wrong"})
+		CheckErrorID(t, err, "api.user.check_user_password.invalid.app_error")
+		CheckUnauthorizedStatus(t, resp)
+
+		_, resp, err = th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Stolen session", Credential: attestation, MfaToken: "123456"})
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("with an MFA token", func(t *testing.T) {
+		loginOptions, _, err := th.CreateClient().BeginWebAuthnLogin(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		require.NoError(t, err)
+		assertion, err := authenticator.Login(loginOptions)
+		require.NoError(t, err)
+		token, err := json.Marshal(assertion)
+		require.NoError(t, err)
+
+		options := mustBeginRegistration(t, th.Client, th.BasicUser.Id)
+		attestation, err := webauthntest.NewSoftwareAuthenticator("http://localhost:8065").Register(options)
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Backup", Credential: attestation, MfaToken: string(token)})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		options := mustBeginRegistration(t, th.Client, th.BasicUser.Id)
+
+		attestation, err := webauthntest.NewSoftwareAuthenticator("http://evil.example.com").Register(options)
+		require.NoError(t, err)
+
+		_, _, err = th.Client.RegisterWebAuthnCredential(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Phished", Credential: attestation, Password: th.BasicUser.Password})
+		CheckErrorID(t, err, "app.webauthn.verify_registration.app_error")
+	})
+
+	t.Run("for another user", func(t *testing.T) {
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func mustBeginRegistration(t *testing.T, client *model.Client4, userID string) *model.WebAuthnCredentialCreationOptions {
+	t.Helper()
+
+	options, _, err := client.BeginWebAuthnRegistration(context.Background(), userID)
+	require.NoError(t, err)
+	return options
+}
+
+func TestWebAuthnLogin(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	authenticator := setupWebAuthn(th)
+	registerWebAuthnKey(t, th.Client, th.BasicUser, authenticator)
+
+	client := th.CreateClient()
+
+	t.Run("second factor is required", func(t *testing.T) {
+		_, _, err := client.Login(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, _, err := client.BeginWebAuthnLogin(context.Background(), th.BasicUser.Email, "wrong")
+		CheckErrorID(t, err, "api.user.login.invalid_credentials_email_username")
+	})
+
+	t.Run("user without security keys", func(t *testing.T) {
+		_, _, err := client.BeginWebAuthnLogin(context.Background(), th.BasicUser2.Email, th.BasicUser2.Password)
+		CheckErrorID(t, err, "app.webauthn.no_credentials.app_error")
+	})
+
+	t.Run("TOTP code without a TOTP secret", func(t *testing.T) {
+		_, _, err := client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, "123456")
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+
+	t.Run("login", func(t *testing.T) {
+		options, _, err := client.BeginWebAuthnLogin(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		require.NoError(t, err)
+		require.Len(t, options.AllowCredentials, 1)
+
+		assertion, err := authenticator.Login(options)
+		require.NoError(t, err)
+
+		user, _, err := client.LoginWithWebAuthn(context.Background(), th.BasicUser.Email, th.BasicUser.Password, assertion)
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, user.Id)
+
+		// A challenge can only be answered once.
+		_, _, err = th.CreateClient().LoginWithWebAuthn(context.Background(), th.BasicUser.Email, th.BasicUser.Password, assertion)
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+
+		credentials, _, err := client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		assert.NotZero(t, credentials[0].LastUsedAt)
+		assert.NotZero(t, credentials[0].SignCount)
+	})
+
+	t.Run("cloned key", func(t *testing.T) {
+		clone := authenticator.Clone()
+
+		options, _, err := th.CreateClient().BeginWebAuthnLogin(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		require.NoError(t, err)
+		assertion, err := authenticator.Login(options)
+		require.NoError(t, err)
+		_, _, err = th.CreateClient().LoginWithWebAuthn(context.Background(), th.BasicUser.Email, th.BasicUser.Password, assertion)
+		require.NoError(t, err)
+
+		// The clone is behind the original, so its signature counter went backwards.
+		options, _, err = th.CreateClient().BeginWebAuthnLogin(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		require.NoError(t, err)
+		assertion, err = clone.Login(options)
+		require.NoError(t, err)
+		_, _, err = th.CreateClient().LoginWithWebAuthn(context.Background(), th.BasicUser.Email, th.BasicUser.Password, assertion)
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+
+	t.Run("assertion for another user", func(t *testing.T) {
+		other := webauthntest.NewSoftwareAuthenticator("http://localhost:8065")
+		th.LoginBasic2()
+		registerWebAuthnKey(t, th.Client, th.BasicUser2, other)
+
+		options, _, err := th.CreateClient().BeginWebAuthnLogin(context.Background(), th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+		assertion, err := other.Login(options)
+		require.NoError(t, err)
+
+		_, _, err = th.CreateClient().LoginWithWebAuthn(context.Background(), th.BasicUser.Email, th.BasicUser.Password, assertion)
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+}
+
+func TestGetAndRevokeWebAuthnCredentials(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	authenticator := setupWebAuthn(th)
+	first := registerWebAuthnKey(t, th.Client, th.BasicUser, authenticator)
+	second := registerWebAuthnKey(t, th.Client, th.BasicUser, authenticator)
+
+	t.Run("list own credentials", func(t *testing.T) {
+		credentials, _, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		assert.Equal(t, first.Id, credentials[0].Id)
+		assert.Equal(t, second.Id, credentials[1].Id)
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.RevokeWebAuthnCredential(context.Background(), th.BasicUser.Id, first.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("credential of another user", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.RevokeWebAuthnCredential(context.Background(), th.BasicUser2.Id, first.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		credentials, _, err := client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.NotEmpty(t, credentials)
+
+		_, err = client.RevokeWebAuthnCredential(context.Background(), th.BasicUser.Id, credentials[0].Id)
+		require.NoError(t, err)
+	}, "admin revokes a credential")
+
+	t.Run("revoking the last credential deactivates MFA", func(t *testing.T) {
+		credentials, _, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		for _, credential := range credentials {
+			_, err = th.Client.RevokeWebAuthnCredential(context.Background(), th.BasicUser.Id, credential.Id)
+			require.NoError(t, err)
+		}
+
+		user, _, err := th.SystemAdminClient.GetUser(context.Background(), th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.False(t, user.MfaActive)
+
+		_, _, err = th.CreateClient().Login(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		require.NoError(t, err)
+	})
+}
@@ -18,12 +18,18 @@ func (api *API) InitWebhookLocal() {
 	api.BaseRoutes.IncomingHook.Handle("", api.APILocal(getIncomingHook)).Methods(http.MethodGet)
 	api.BaseRoutes.IncomingHook.Handle("", api.APILocal(updateIncomingHook)).Methods(http.MethodPut)
 	api.BaseRoutes.IncomingHook.Handle("", api.APILocal(deleteIncomingHook)).Methods(http.MethodDelete)
+	api.BaseRoutes.IncomingHook.Handle("/template/dry_run", api.APILocal(dryRunIncomingHookTemplate)).Methods(http.MethodPost)
 
 	api.BaseRoutes.OutgoingHooks.Handle("", api.APILocal(localCreateOutgoingHook)).Methods(http.MethodPost)
 	api.BaseRoutes.OutgoingHooks.Handle("", api.APILocal(getOutgoingHooks)).Methods(http.MethodGet)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APILocal(getOutgoingHook)).Methods(http.MethodGet)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APILocal(updateOutgoingHook)).Methods(http.MethodPut)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APILocal(deleteOutgoingHook)).Methods(http.MethodDelete)
+	api.BaseRoutes.OutgoingHook.Handle("/signing_secret/regen", api.APILocal(regenOutgoingHookSigningSecret)).Methods(http.MethodPost)
+	api.BaseRoutes.OutgoingHook.Handle("/signing_secret", api.APILocal(removeOutgoingHookSigningSecret)).Methods(http.MethodDelete)
+	api.BaseRoutes.OutgoingHook.Handle("/enable", api.APILocal(enableOutgoingHook)).Methods(http.MethodPost)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries", api.APILocal(getOutgoingHookDeliveries)).Methods(http.MethodGet)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries/{delivery_id:[A-Za-z0-9]+}/replay", api.APILocal(replayOutgoingHookDelivery)).Methods(http.MethodPost)
 }
 
 func localCreateIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -5,6 +5,9 @@ package api4
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -397,6 +400,56 @@ func TestGetIncomingWebhook(t *testing.T) {
 	})
 }
 
+func TestDryRunIncomingWebhookTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook := &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, Template: "{{ .alert.title }}"}
+	rhook, _, err := th.SystemAdminClient.CreateIncomingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+	require.Equal(t, hook.Template, rhook.Template)
+
+	payload := []byte(`{"alert": {"title": "Disk full", "severity": "critical"}}`)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		rendered, resp, err := client.DryRunIncomingWebhookTemplate(context.Background(), rhook.Id, &model.IncomingWebhookTemplateDryRunRequest{Payload: payload})
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, "Disk full", rendered.Text)
+	}, "WithStoredTemplate")
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		rendered, _, err := client.DryRunIncomingWebhookTemplate(context.Background(), rhook.Id, &model.IncomingWebhookTemplateDryRunRequest{
+			Template: `{"text": {{ json .alert.title }}, "props": {"severity": {{ json .alert.severity }}}}`,
+			Payload:  payload,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Disk full", rendered.Text)
+		require.Equal(t, "critical", rendered.Props["severity"])
+	}, "WithTemplateOverride")
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.DryRunIncomingWebhookTemplate(context.Background(), rhook.Id, &model.IncomingWebhookTemplateDryRunRequest{Template: "{{ .alert", Payload: payload})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	}, "WithInvalidTemplate")
+
+	t.Run("WhenUserDoesNotHavePermissions", func(t *testing.T) {
+		th.LoginBasic()
+		_, resp, err := th.Client.DryRunIncomingWebhookTemplate(context.Background(), rhook.Id, &model.IncomingWebhookTemplateDryRunRequest{Payload: payload})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("CreateWithInvalidTemplate", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateIncomingWebhook(context.Background(), &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, Template: "{{ if }}"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestDeleteIncomingWebhook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -1353,3 +1406,184 @@ func TestDeleteOutgoingHook(t *testing.T) {
 		CheckForbiddenStatus(t, resp)
 	})
 }
+
+func TestOutgoingHookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	var statusCode atomic.Int32
+	statusCode.Store(http.StatusNotFound)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(statusCode.Load()))
+	}))
+	defer server.Close()
+
+	hook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), &model.OutgoingWebhook{
+		ChannelId:    th.BasicChannel.Id,
+		TeamId:       th.BasicChannel.TeamId,
+		CallbackURLs: []string{server.URL},
+		TriggerWords: []string{"cats"},
+	})
+	require.NoError(t, err)
+
+	th.App.TriggerWebhook(th.Context, &model.OutgoingWebhookPayload{Token: hook.Token}, hook, th.BasicPost, th.BasicChannel)
+
+	t.Run("list deliveries", func(t *testing.T) {
+		deliveries, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, "", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, deliveries[0].Status)
+		assert.Equal(t, http.StatusNotFound, deliveries[0].StatusCode)
+
+		deliveries, _, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, model.OutgoingWebhookDeliveryStatusSuccess, 0, 10)
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+
+		_, resp, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, "unknown", 0, 10)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, "", 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("replay delivery", func(t *testing.T) {
+		failed, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, model.OutgoingWebhookDeliveryStatusFailed, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, failed, 1)
+
+		_, resp, err := th.Client.ReplayOutgoingWebhookDelivery(context.Background(), hook.Id, failed[0].Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), hook.Id, model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		statusCode.Store(http.StatusOK)
+		replayed, _, err := th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), hook.Id, failed[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, replayed.Status)
+
+		// Neither the replayed delivery nor the successful replay are kept in the log.
+		_, resp, err = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), hook.Id, failed[0].Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), hook.Id, replayed.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		deliveries, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, "", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("outgoing webhooks disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+		_, resp, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), hook.Id, "", 0, 10)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestOutgoingHookSigningSecret(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+	require.Empty(t, rhook.SigningSecret)
+
+	_, resp, err := th.SystemAdminClient.RegenOutgoingHookSigningSecret(context.Background(), "junk")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	signedHook, _, err := th.SystemAdminClient.RegenOutgoingHookSigningSecret(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.True(t, signedHook.IsSigned())
+
+	rotatedHook, _, err := th.SystemAdminClient.RegenOutgoingHookSigningSecret(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.NotEqual(t, signedHook.SigningSecret, rotatedHook.SigningSecret)
+
+	fetchedHook, _, err := th.SystemAdminClient.GetOutgoingWebhook(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.Equal(t, rotatedHook.SigningSecret, fetchedHook.SigningSecret)
+
+	_, resp, err = client.RegenOutgoingHookSigningSecret(context.Background(), rhook.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = client.RemoveOutgoingHookSigningSecret(context.Background(), rhook.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	unsignedHook, _, err := th.SystemAdminClient.RemoveOutgoingHookSigningSecret(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.False(t, unsignedHook.IsSigned())
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+	_, resp, err = th.SystemAdminClient.RegenOutgoingHookSigningSecret(context.Background(), rhook.Id)
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+}
+
+func TestEnableOutgoingHook(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}, TriggerWords: []string{"enable"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+
+	// Simulate a hook disabled after failing too many deliveries.
+	rhook.DisabledAt = model.GetMillis()
+	_, err = th.App.Srv().Store().Webhook().UpdateOutgoing(rhook)
+	require.NoError(t, err)
+
+	t.Run("updates don't re-enable the hook", func(t *testing.T) {
+		rhook.DisplayName = "renamed"
+		rhook.DisabledAt = 0
+		updatedHook, _, err := th.SystemAdminClient.UpdateOutgoingWebhook(context.Background(), rhook)
+		require.NoError(t, err)
+		require.True(t, updatedHook.IsDisabled())
+	})
+
+	t.Run("junk id", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.EnableOutgoingHook(context.Background(), "junk")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		_, resp, err := client.EnableOutgoingHook(context.Background(), rhook.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("enable", func(t *testing.T) {
+		enabledHook, _, err := th.SystemAdminClient.EnableOutgoingHook(context.Background(), rhook.Id)
+		require.NoError(t, err)
+		require.False(t, enabledHook.IsDisabled())
+
+		fetchedHook, _, err := th.SystemAdminClient.GetOutgoingWebhook(context.Background(), rhook.Id)
+		require.NoError(t, err)
+		require.False(t, fetchedHook.IsDisabled())
+	})
+}
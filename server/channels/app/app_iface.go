@@ -25,6 +25,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/public/shared/timezones"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
 	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -44,6 +45,8 @@ type AppIface interface {
 	ListAutocompleteCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
 	// @openTracingParams teamID, skipSlackParsing
 	CreateCommandPost(c request.CTX, post *model.Post, teamID string, response *model.CommandResponse, skipSlackParsing bool) (*model.Post, *model.AppError)
+	// ActivateMfa turns on MFA for a user and returns their recovery codes.
+	ActivateMfa(userID, token string) ([]string, *model.AppError)
 	// AddChannelMember adds a user to a channel. It is a wrapper over AddUserToChannel.
 	AddChannelMember(c request.CTX, userID string, channel *model.Channel, opts ChannelMemberOpts) (*model.ChannelMember, *model.AppError)
 	// AddCursorIdsForPostList adds NextPostId and PrevPostId as cursor to the PostList.
@@ -54,6 +57,18 @@ type AppIface interface {
 	AddPublicKey(name string, key io.Reader) *model.AppError
 	// AddUserToChannel adds a user to a given channel.
 	AddUserToChannel(c request.CTX, user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError)
+	// BeginWebAuthnLogin checks the password of a user and returns the options to sign in with one
+	// of their security keys.
+	BeginWebAuthnLogin(c request.CTX, id, loginId, password string) (*model.WebAuthnCredentialRequestOptions, *model.AppError)
+	// BeginWebAuthnRegistration returns the options to register a new security key for a user.
+	BeginWebAuthnRegistration(c request.CTX, userID string) (*model.WebAuthnCredentialCreationOptions, *model.AppError)
+	// BulkImportDryRun reports what importing the JSONL data would create, update or skip, and the
+	// lines that would fail or conflict with the data of the server. Unlike a validating dry run of
+	// BulkImport, it doesn't stop at the first error.
+	BulkImportDryRun(c request.CTX, jsonlReader io.Reader) (*imports.DryRunReport, *model.AppError)
+	// BulkImportWithOpts imports the JSONL data like BulkImportWithPath, with support for resuming
+	// an import from a checkpoint and for skipping the lines that fail.
+	BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*model.AppError, int)
 	// Caller must close the first return value
 	ExportFileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
 	// Caller must close the first return value
@@ -75,6 +90,8 @@ type AppIface interface {
 	// overriding attributes set by the user's login provider; otherwise, the name of the offending
 	// field is returned.
 	CheckProviderAttributes(c request.CTX, user *model.User, patch *model.UserPatch) string
+	// ClosePoll stops a poll from accepting votes.
+	ClosePoll(rctx request.CTX, postID, userID string) (*model.PollResults, *model.AppError)
 	// CommandsForTeam returns all the plugin commands for the given team.
 	CommandsForTeam(teamID string) []*model.Command
 	// ComputeLastAccessibleFileTime updates cache with CreateAt time of the last accessible file as per the cloud plan's limit.
@@ -102,11 +119,18 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(c request.CTX, user *model.User) (*model.User, *model.AppError)
+	// CreatePoll creates a poll post on behalf of the user. The question becomes
+	// the message of the post so that it is searchable like any other post.
+	CreatePoll(rctx request.CTX, userID string, pollCreate *model.PollCreate) (*model.Post, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(c request.CTX, user *model.User) (*model.User, *model.AppError)
 	// Creates and stores FileInfos for a post created before the FileInfos table existed.
 	MigrateFilenamesToFileInfos(rctx request.CTX, post *model.Post) []*model.FileInfo
+	// DecodeIncomingWebhookPayload turns the body of an incoming webhook request
+	// into an IncomingWebhookRequest, applying the webhook's payload template when
+	// one is configured.
+	DecodeIncomingWebhookPayload(hookID string, payload io.Reader) (*model.IncomingWebhookRequest, *model.AppError)
 	// DefaultChannelNames returns the list of system-wide default channel names.
 	//
 	// By default the list will be (not necessarily in this order):
@@ -120,11 +144,19 @@ type AppIface interface {
 	DefaultChannelNames(c request.CTX) []string
 	// DeleteChannelScheme deletes a channels scheme and sets its SchemeId to nil.
 	DeleteChannelScheme(c request.CTX, channel *model.Channel) (*model.Channel, *model.AppError)
+	// DeleteExpiredAuditLogs permanently deletes the audit records saved by the database audit target
+	// that are older than the audit log retention period, returning how many were deleted.
+	DeleteExpiredAuditLogs(rctx request.CTX) (int64, *model.AppError)
 	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
 	// groups of all group-constrained teams and channels.
 	DeleteGroupConstrainedMemberships(rctx request.CTX) error
+	// DeleteOutOfOffice switches the auto-responder off and cancels any pending
+	// out of office period of the user.
+	DeleteOutOfOffice(rctx request.CTX, userID string, asAdmin bool) *model.AppError
 	// DeletePersistentNotification stops the persistent notifications.
 	DeletePersistentNotification(c request.CTX, post *model.Post) *model.AppError
+	// DeletePostReminder cancels a pending reminder of a user about a post.
+	DeletePostReminder(postID, userID string) *model.AppError
 	// DeletePublicKey will delete plugin public key from the config.
 	DeletePublicKey(name string) *model.AppError
 	// DemoteUserToGuest Convert user's roles and all his membership's roles from
@@ -137,6 +169,12 @@ type AppIface interface {
 	DisablePlugin(id string) *model.AppError
 	// DoPermissionsMigrations execute all the permissions migrations need by the current version.
 	DoPermissionsMigrations() error
+	// DryRunIncomingWebhookTemplate renders a sample payload through a template
+	// without creating a post. An empty template falls back to the one stored on
+	// the webhook.
+	DryRunIncomingWebhookTemplate(hook *model.IncomingWebhook, req *model.IncomingWebhookTemplateDryRunRequest) (*model.IncomingWebhookRequest, *model.AppError)
+	// EnableOutgoingWebhook re-enables a hook that was disabled after failing too many deliveries.
+	EnableOutgoingWebhook(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
 	// EnablePlugin will set the config for an installed plugin to enabled, triggering asynchronous
 	// activation if inactive anywhere in the cluster.
 	// Notifies cluster peers through config change.
@@ -164,6 +202,9 @@ type AppIface interface {
 	// FilterNonGroupTeamMembers returns the subset of the given user IDs of the users who are not members of groups
 	// associated to the team excluding bots.
 	FilterNonGroupTeamMembers(userIDs []string, team *model.Team) ([]string, error)
+	// FinishWebAuthnRegistration verifies the response of the security key to the registration
+	// options and saves the new credential. Registering the first credential of a user activates MFA.
+	FinishWebAuthnRegistration(c request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError)
 	// GetAllLdapGroupsPage retrieves all LDAP groups under the configured base DN using the default or configured group
 	// filter.
 	GetAllLdapGroupsPage(rctx request.CTX, page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError)
@@ -179,6 +220,14 @@ type AppIface interface {
 	GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError)
 	// GetConfigFile proxies access to the given configuration file to the underlying config store.
 	GetConfigFile(name string) ([]byte, error)
+	// GetConfigHistory returns a page of the stored versions of the configuration, newest first.
+	GetConfigHistory(page, perPage int) ([]*model.ConfigVersion, *model.AppError)
+	// GetConfigVersion returns the configuration of the given version, without the environment
+	// overrides.
+	GetConfigVersion(versionID string) (*model.Config, *model.AppError)
+	// GetConfigVersionDiff returns the settings that changed between two versions of the configuration,
+	// with the sensitive values masked. An empty toVersionID compares against the configuration in use.
+	GetConfigVersionDiff(fromVersionID, toVersionID string) ([]*model.ConfigVersionDiff, *model.AppError)
 	// GetEmojiStaticURL returns a relative static URL for system default emojis,
 	// and the API route for custom ones. Errors if not found or if custom and deleted.
 	GetEmojiStaticURL(c request.CTX, emojiName string) (string, *model.AppError)
@@ -207,6 +256,9 @@ type AppIface interface {
 	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
 	// and plugins that are installed locally.
 	GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError)
+	// GetOutOfOffice returns the auto-responder settings of a user along with
+	// their pending out of office period, if any.
+	GetOutOfOffice(userID string) (*model.OutOfOffice, *model.AppError)
 	// GetPluginStatus returns the status for a plugin installed on this server.
 	GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
 	// GetPluginStatuses returns the status for plugins installed on this server.
@@ -217,6 +269,11 @@ type AppIface interface {
 	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
 	// lock instead.
 	GetPluginsEnvironment() *plugin.Environment
+	// GetPollResults returns the tally of the poll attached to the post, with the
+	// votes of userID filled in.
+	GetPollResults(rctx request.CTX, postID, userID string) (*model.PollResults, *model.AppError)
+	// GetPostRemindersForUser returns the pending reminders of a user, soonest first.
+	GetPostRemindersForUser(userID string) ([]*model.PostReminder, *model.AppError)
 	// GetPostsByIds response bool value indicates, if the post is inaccessible due to cloud plan's limit.
 	GetPostsByIds(postIDs []string) ([]*model.Post, int64, *model.AppError)
 	// GetPostsUsage returns the total posts count rounded down to the most
@@ -230,6 +287,9 @@ type AppIface interface {
 	GetPublicKey(name string) ([]byte, *model.AppError)
 	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
 	GetSanitizedConfig() *model.Config
+	// GetScheduledPostOccurrences returns the times, in milliseconds, of the next
+	// count occurrences of a scheduled post.
+	GetScheduledPostOccurrences(rctx request.CTX, userId, scheduledPostId string, count int) ([]int64, *model.AppError)
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
 	GetSchemeRolesForChannel(c request.CTX, channelID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
@@ -299,31 +359,67 @@ type AppIface interface {
 	// PopulateWebConnConfig checks if the connection id already exists in the hub,
 	// and if so, accordingly populates the other fields of the webconn.
 	PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error)
+	// ProcessOutOfOfficeSchedules starts and ends the out of office periods that
+	// are due.
+	ProcessOutOfOfficeSchedules(rctx request.CTX) error
+	// ProcessOutgoingWebhookRetries retries the outgoing webhook deliveries whose retry time
+	// is due, and deletes the deliveries older than the retention period from the log.
+	ProcessOutgoingWebhookRetries(c request.CTX) error
 	// PromoteGuestToUser Convert user's roles and all his membership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(c request.CTX, user *model.User, requestorId string) *model.AppError
 	// ReattachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
 	ReattachPlugin(manifest *model.Manifest, pluginReattachConfig *model.PluginReattachConfig) *model.AppError
+	// RegenOutgoingWebhookSigningSecret generates a new secret to sign the requests of the hook with,
+	// enabling request signing if the hook wasn't signed yet.
+	RegenOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
+	// RegenerateMfaRecoveryCodes replaces the recovery codes of a user who has MFA turned on.
+	RegenerateMfaRecoveryCodes(userID string) ([]string, *model.AppError)
+	// RegisterMentionSource registers a source of custom mention keywords, replacing
+	// any source previously registered with the same name.
+	RegisterMentionSource(source MentionSource)
+	// RegisterPluginFileContentExtractor registers a plugin as the content extractor for
+	// the given file extensions, replacing any previous registration of the plugin.
+	RegisterPluginFileContentExtractor(c request.CTX, pluginID string, extensions []string) error
+	// RegisterPluginMentionKeywords registers a plugin as the source of the given mention
+	// keywords, replacing any previous registration of the plugin.
+	RegisterPluginMentionKeywords(pluginID string, keywords []string) error
+	// RemoveOutgoingWebhookSigningSecret stops signing the requests of the hook.
+	RemoveOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
 	// Removes a listener function by the unique ID returned when AddConfigListener was called
 	RemoveConfigListener(id string)
 	// RenameChannel is used to rename the channel Name and the DisplayName fields
 	RenameChannel(c request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// ReplayOutgoingWebhookDelivery sends the payload of a failed delivery of the hook again,
+	// returning the delivery recording the outcome of the replay.
+	ReplayOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError)
 	// ResolvePersistentNotification stops the persistent notifications, if a loggedInUserID(except the post owner) reacts, reply or ack on the post.
 	// Post-owner can only delete the original post to stop the notifications.
 	ResolvePersistentNotification(c request.CTX, post *model.Post, loggedInUserID string) *model.AppError
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
+	// RevokeWebAuthnCredential removes a security key of a user. MFA is turned off once the user has
+	// no second factor left.
+	RevokeWebAuthnCredential(c request.CTX, userID, credentialID string) *model.AppError
+	// RollbackConfig replaces the configuration with the given version of it, recording the given user
+	// as the author of the change. The version is passed to prepare before being validated and saved.
+	RollbackConfig(versionID string, authorID string, prepare func(*model.Config) *model.AppError) (*model.Config, *model.Config, *model.AppError)
 	// SanitizedConfig sanitizes a given configuration for a system admin without any secrets.
 	SanitizedConfig(cfg *model.Config)
 	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
 	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError)
+	// SaveConfigWithAuthor is like SaveConfig, but records the given user as the author of the change
+	// in the configuration history.
+	SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, authorID string) (*model.Config, *model.Config, *model.AppError)
 	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
 	SearchAllChannels(c request.CTX, term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
 	SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError)
+	// SearchAuditLogs returns the audit records saved by the database audit target that match the options.
+	SearchAuditLogs(rctx request.CTX, opts model.AuditLogSearchOptions) ([]*model.AuditLog, *model.AppError)
 	// SessionHasPermissionToChannels returns true only if user has access to all channels.
 	SessionHasPermissionToChannels(c request.CTX, session model.Session, channelIDs []string, permission *model.Permission) bool
 	// SessionHasPermissionToManageBot returns nil if the session has access to manage the given bot.
@@ -334,6 +430,10 @@ type AppIface interface {
 	SessionHasPermissionToTeams(c request.CTX, session model.Session, teamIDs []string, permission *model.Permission) bool
 	// SessionIsRegistered determines if a specific session has been registered
 	SessionIsRegistered(session model.Session) bool
+	// SetScheduledPostPaused pauses or resumes a scheduled post. Paused posts are
+	// skipped by the scheduled posts job. When a recurring post is resumed after
+	// its next occurrence has passed, it moves on to the first upcoming one.
+	SetScheduledPostPaused(rctx request.CTX, userId, scheduledPostId string, paused bool, connectionId string) (*model.ScheduledPost, *model.AppError)
 	// SetSessionExpireInHours sets the session's expiry the specified number of hours
 	// relative to either the session creation date or the current time, depending
 	// on the `ExtendSessionOnActivity` config setting.
@@ -345,6 +445,12 @@ type AppIface interface {
 	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
 	// while an 'away' device is still connected
 	SetStatusLastActivityAt(userID string, activityAt int64)
+	// SnoozePostReminder schedules again the reminder about a post that was
+	// delivered to the user, so they are reminded about the post again.
+	SnoozePostReminder(postID, userID string, targetTime int64) *model.AppError
+	// StartBatchReportExport starts the batch export of one of the reports that aren't about users.
+	// The report is delivered to the requesting user through a direct message from the system bot.
+	StartBatchReportExport(rctx request.CTX, reportType string, ro *model.ReportExportOptions) *model.AppError
 	// SyncLdap starts an LDAP sync job.
 	// If includeRemovedMembers is true, then members who left or were removed from a team/channel will
 	// be re-added; otherwise, they will not be re-added.
@@ -381,6 +487,15 @@ type AppIface interface {
 	CreateZipFileAndAddFiles(fileBackend filestore.FileBackend, fileDatas []model.FileData, zipFileName, directory string) error
 	// This to be used for places we check the users password when they are already logged in
 	DoubleCheckPassword(rctx request.CTX, user *model.User, password string) *model.AppError
+	// DoubleCheckPasswordOrMfa checks that a logged in user knows either their password or a
+	// current MFA token, before they change their second factors.
+	DoubleCheckPasswordOrMfa(rctx request.CTX, user *model.User, password, mfaToken string) *model.AppError
+	// UnregisterMentionSource removes the source of custom mention keywords with the given name.
+	UnregisterMentionSource(name string)
+	// UnregisterPluginFileContentExtractor removes the content extractor registered by a plugin.
+	UnregisterPluginFileContentExtractor(pluginID string)
+	// UnregisterPluginMentionKeywords removes the mention keywords registered by a plugin.
+	UnregisterPluginMentionKeywords(pluginID string)
 	// UpdateBotActive marks a bot as active or inactive, along with its corresponding user.
 	UpdateBotActive(rctx request.CTX, botUserId string, active bool) (*model.Bot, *model.AppError)
 	// UpdateBotOwner changes a bot's owner to the given value.
@@ -392,6 +507,13 @@ type AppIface interface {
 	// UpdateDNDStatusOfUsers is a recurring task which is started when server starts
 	// which unsets dnd status of users if needed and saves and broadcasts it
 	UpdateDNDStatusOfUsers()
+	// UpdateMfa turns MFA on or off for a user. The recovery codes of the user are returned when
+	// MFA is turned on.
+	UpdateMfa(c request.CTX, activate bool, userID, token string) ([]string, *model.AppError)
+	// UpdateOutOfOffice stores the auto-responder settings of a user. When a
+	// period is given the auto-responder is switched on now if the period has
+	// already started, and the out of office job takes care of the rest.
+	UpdateOutOfOffice(rctx request.CTX, userID string, ooo *model.OutOfOffice, asAdmin bool) (*model.OutOfOffice, *model.AppError)
 	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
 	UpdateProductNotices() *model.AppError
 	// UpdateSharedChannelCursor updates the cursor for the specified channelID and remoteID.
@@ -422,12 +544,14 @@ type AppIface interface {
 	ValidateUserPermissionsOnChannels(c request.CTX, userId string, channelIds []string) []string
 	// VerifyPlugin checks that the given signature corresponds to the given plugin and matches a trusted certificate.
 	VerifyPlugin(plugin, signature io.ReadSeeker) *model.AppError
+	// VotePoll replaces the user's vote on a poll. An empty choice withdraws the
+	// vote. Members of the channel are sent the updated tally.
+	VotePoll(rctx request.CTX, postID, userID string, options []int) (*model.PollResults, *model.AppError)
 	// validateMoveOrCopy performs validation on a provided post list to determine
 	// if all permissions are in place to allow the for the posts to be moved or
 	// copied.
 	ValidateMoveOrCopy(c request.CTX, wpl *model.WranglerPostList, originalChannel *model.Channel, targetChannel *model.Channel, user *model.User) error
 	AccountMigration() einterfaces.AccountMigrationInterface
-	ActivateMfa(userID, token string) *model.AppError
 	ActiveSearchBackend() string
 	AddChannelsToRetentionPolicy(policyID string, channelIDs []string) *model.AppError
 	AddConfigListener(listener func(*model.Config, *model.Config)) string
@@ -644,6 +768,7 @@ type AppIface interface {
 	GetBrandImage(rctx request.CTX) ([]byte, *model.AppError)
 	GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError)
 	GetChannel(c request.CTX, channelID string) (*model.Channel, *model.AppError)
+	GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, *model.AppError)
 	GetChannelBookmarks(channelId string, since int64) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError)
 	GetChannelByName(c request.CTX, channelName, teamID string, includeDeleted bool) (*model.Channel, *model.AppError)
 	GetChannelByNameForTeamName(c request.CTX, channelName, teamName string, includeDeleted bool) (*model.Channel, *model.AppError)
@@ -714,6 +839,7 @@ type AppIface interface {
 	GetGroupsByIDs(groupIDs []string) ([]*model.Group, *model.AppError)
 	GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError)
 	GetGroupsByUserId(userID string) ([]*model.Group, *model.AppError)
+	GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, *model.AppError)
 	GetHubForUserId(userID string) *platform.Hub
 	GetIncomingWebhook(hookID string) (*model.IncomingWebhook, *model.AppError)
 	GetIncomingWebhooksCount(teamID string, userID string) (int64, *model.AppError)
@@ -752,6 +878,8 @@ type AppIface interface {
 	GetOpenGraphMetadata(requestURL string) ([]byte, error)
 	GetOrCreateDirectChannel(c request.CTX, userID, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
 	GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhookDeliveries(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, *model.AppError)
+	GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError)
 	GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPage(teamID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPageByUser(teamID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
@@ -768,6 +896,7 @@ type AppIface interface {
 	GetPostIfAuthorized(c request.CTX, postID string, session *model.Session, includeDeleted bool) (*model.Post, *model.AppError)
 	GetPostInfo(c request.CTX, postID string) (*model.PostInfo, *model.AppError)
 	GetPostThread(postID string, opts model.GetPostsOptions, userID string) (*model.PostList, *model.AppError)
+	GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, *model.AppError)
 	GetPosts(channelID string, offset int, limit int) (*model.PostList, *model.AppError)
 	GetPostsAfterPost(options model.GetPostsOptions) (*model.PostList, *model.AppError)
 	GetPostsAroundPost(before bool, options model.GetPostsOptions) (*model.PostList, *model.AppError)
@@ -899,6 +1028,7 @@ type AppIface interface {
 	GetUsersWithoutTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
 	GetVerifyEmailToken(token string) (*model.Token, *model.AppError)
 	GetViewUsersRestrictions(c request.CTX, userID string) (*model.ViewUsersRestrictions, *model.AppError)
+	GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError)
 	HTTPService() httpservice.HTTPService
 	HandleCommandResponse(c request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.CommandResponse, *model.AppError)
 	HandleCommandResponsePost(c request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.Post, *model.AppError)
@@ -1151,7 +1281,7 @@ type AppIface interface {
 	SlackImport(c request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer)
 	SoftDeleteTeam(teamID string) *model.AppError
 	Srv() *Server
-	StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOptions, startAt int64, endAt int64) *model.AppError
+	StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOptions, startAt int64, endAt int64, format string) *model.AppError
 	SubmitInteractiveDialog(c request.CTX, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError)
 	SwitchEmailToLdap(c request.CTX, email, password, code, ldapLoginId, ldapPassword string) (string, *model.AppError)
 	SwitchEmailToOAuth(c request.CTX, w http.ResponseWriter, r *http.Request, email, password, code, service string) (string, *model.AppError)
@@ -1191,7 +1321,6 @@ type AppIface interface {
 	UpdateHashedPasswordByUserId(userID, newHashedPassword string) *model.AppError
 	UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError)
 	UpdateJobStatus(c request.CTX, job *model.Job, newStatus string) *model.AppError
-	UpdateMfa(c request.CTX, activate bool, userID, token string) *model.AppError
 	UpdateMobileAppBadge(userID string)
 	UpdateOAuthApp(oldApp, updatedApp *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	UpdateOAuthUserAttrs(c request.CTX, userData io.Reader, user *model.User, provider einterfaces.OAuthProvider, service string, tokenUser *model.User) *model.AppError
@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/user"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -56,6 +57,47 @@ func (a *App) GetAuditsPage(rctx request.CTX, userID string, page int, perPage i
 	return audits, nil
 }
 
+// SearchAuditLogs returns the audit records saved by the database audit target that match the options.
+func (a *App) SearchAuditLogs(rctx request.CTX, opts model.AuditLogSearchOptions) ([]*model.AuditLog, *model.AppError) {
+	if appErr := opts.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	logs, err := a.Srv().Store().AuditLog().Search(opts)
+	if err != nil {
+		return nil, model.NewAppError("SearchAuditLogs", "app.audit_log.search.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return logs, nil
+}
+
+// DeleteExpiredAuditLogs permanently deletes the audit records saved by the database audit target
+// that are older than the audit log retention period, returning how many were deleted.
+func (a *App) DeleteExpiredAuditLogs(rctx request.CTX) (int64, *model.AppError) {
+	settings := a.Config().DataRetentionSettings
+	if !*settings.EnableAuditLogDeletion {
+		return 0, nil
+	}
+
+	endTime := model.GetMillis() - (time.Duration(*settings.AuditLogRetentionDays) * 24 * time.Hour).Milliseconds()
+	batchSize := int64(*settings.BatchSize)
+
+	var total int64
+	for {
+		deleted, err := a.Srv().Store().AuditLog().PermanentDeleteBatch(endTime, batchSize)
+		if err != nil {
+			return total, model.NewAppError("DeleteExpiredAuditLogs", "app.audit_log.permanent_delete_batch.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		total += deleted
+		if deleted < batchSize {
+			break
+		}
+		time.Sleep(time.Duration(*settings.TimeBetweenBatchesMilliseconds) * time.Millisecond)
+	}
+
+	rctx.Logger().Info("Deleted expired audit logs", mlog.Int("count", total))
+	return total, nil
+}
+
 // LogAuditRec logs an audit record using default LvlAuditCLI.
 func (a *App) LogAuditRec(rctx request.CTX, rec *audit.Record, err error) {
 	a.LogAuditRecWithLevel(rctx, rec, mlog.LvlAuditCLI, err)
@@ -12,6 +12,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app/users"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa"
 )
 
@@ -212,6 +213,22 @@ func (a *App) CheckUserPostflightAuthenticationCriteria(rctx request.CTX, user *
 	return nil
 }
 
+// DoubleCheckPasswordOrMfa checks that a logged in user knows either their password or a
+// current MFA token, before they change their second factors.
+func (a *App) DoubleCheckPasswordOrMfa(rctx request.CTX, user *model.User, password, mfaToken string) *model.AppError {
+	if password != "" {
+		return a.DoubleCheckPassword(rctx, user, password)
+	}
+
+	if mfaToken != "" && user.MfaActive && *a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return a.CheckUserMfa(rctx, user, mfaToken)
+	}
+
+	return model.NewAppError("DoubleCheckPasswordOrMfa", "api.user.double_check_password_or_mfa.missing.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
+}
+
+// CheckUserMfa checks the second factor of a user, which is either a TOTP code or the JSON of a
+// WebAuthn assertion signed by one of their security keys.
 func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *model.AppError {
 	if !user.MfaActive || !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
 		return nil
@@ -221,7 +238,16 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 		return model.NewAppError("CheckUserMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	ok, err := mfa.New(a.Srv().Store().User()).ValidateToken(user, token)
+	if isWebAuthnAssertion(token) {
+		return a.checkUserWebAuthn(rctx, user, token)
+	}
+
+	// Users who only registered security keys have no TOTP secret to check the code against.
+	if user.MfaSecret == "" && !mfa.IsRecoveryCode(token) {
+		return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	ok, recoveryCodesLeft, err := mfa.New(a.Srv().Store().User()).ValidateToken(user, token)
 	if err != nil {
 		return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 	}
@@ -230,9 +256,39 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 		return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
 	}
 
+	if recoveryCodesLeft != mfa.NoRecoveryCodeUsed {
+		a.onMfaRecoveryCodeUsed(rctx, user, recoveryCodesLeft)
+	}
+
 	return nil
 }
 
+// onMfaRecoveryCodeUsed records the use of a recovery code, and warns the user when they are
+// about to run out of them.
+func (a *App) onMfaRecoveryCodeUsed(rctx request.CTX, user *model.User, recoveryCodesLeft int) {
+	// The code is used while logging in, so the session may not belong to the user yet.
+	auditRec := a.MakeAuditRecord(rctx, "useMfaRecoveryCode", audit.Success)
+	auditRec.Actor.UserId = user.Id
+	auditRec.Actor.SessionId = rctx.Session().Id
+	auditRec.Actor.Client = rctx.UserAgent()
+	auditRec.Actor.IpAddress = rctx.IPAddress()
+	auditRec.Actor.XForwardedFor = rctx.XForwardedFor()
+	auditRec.AddMeta(audit.KeyAPIPath, rctx.Path())
+	audit.AddEventParameter(auditRec, "user_id", user.Id)
+	audit.AddEventParameter(auditRec, "recovery_codes_left", recoveryCodesLeft)
+	a.LogAuditRec(rctx, auditRec, nil)
+
+	if recoveryCodesLeft > mfa.RecoveryCodesLowThreshold {
+		return
+	}
+
+	a.Srv().Go(func() {
+		if err := a.Srv().EmailService.SendMfaRecoveryCodesLowEmail(user.Email, recoveryCodesLeft, user.Locale, a.GetSiteURL()); err != nil {
+			rctx.Logger().Error("Failed to send mfa recovery codes low email", mlog.Err(err))
+		}
+	})
+}
+
 func checkUserLoginAttempts(user *model.User, max int) *model.AppError {
 	if user.FailedAttempts >= max {
 		return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
@@ -5,12 +5,21 @@ package app
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
 
+const (
+	autoResponderCacheSize = 10000
+
+	autoResponderMentionUsernamesKey    = "mention_usernames"
+	autoResponderMentionUsernamesExpiry = time.Minute
+)
+
 // check if there is any auto_response type post in channel by the user in a calender day
 func (a *App) checkIfRespondedToday(createdAt int64, channelId, userId string) (bool, error) {
 	y, m, d := model.GetTimeForMillis(createdAt).Date()
@@ -22,12 +31,12 @@ func (a *App) checkIfRespondedToday(createdAt int64, channelId, userId string) (
 }
 
 func (a *App) SendAutoResponseIfNecessary(rctx request.CTX, channel *model.Channel, sender *model.User, post *model.Post) (bool, *model.AppError) {
-	if channel.Type != model.ChannelTypeDirect {
+	if sender.IsBot {
 		return false, nil
 	}
 
-	if sender.IsBot {
-		return false, nil
+	if channel.Type != model.ChannelTypeDirect {
+		return a.sendAutoResponsesToMentions(rctx, channel, sender, post)
 	}
 
 	receiverId := channel.GetOtherUserIdForDM(sender.Id)
@@ -52,16 +61,114 @@ func (a *App) SendAutoResponseIfNecessary(rctx request.CTX, channel *model.Chann
 	return a.SendAutoResponse(rctx, channel, receiver, post)
 }
 
-func (a *App) SendAutoResponse(rctx request.CTX, channel *model.Channel, receiver *model.User, post *model.Post) (bool, *model.AppError) {
-	if receiver == nil || receiver.NotifyProps == nil {
+// getAutoResponderMentionUsernames returns the usernames of the users whose
+// auto-responder answers mentions, keeping them in a cache for a while.
+func (a *App) getAutoResponderMentionUsernames() (map[string]bool, error) {
+	var usernames []string
+	if err := a.Srv().autoResponderCache.Get(autoResponderMentionUsernamesKey, &usernames); err != nil {
+		usernames, err = a.Srv().Store().User().GetAutoResponderMentionUsernames()
+		if err != nil {
+			return nil, err
+		}
+		if err := a.Srv().autoResponderCache.SetWithExpiry(autoResponderMentionUsernamesKey, usernames, autoResponderMentionUsernamesExpiry); err != nil {
+			a.Log().Warn("Failed to cache the usernames of the auto-responders answering mentions", mlog.Err(err))
+		}
+	}
+
+	set := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		set[username] = true
+	}
+	return set, nil
+}
+
+// invalidateAutoResponderMentionUsernames is called when the auto-responder
+// settings of a user change.
+func (a *App) invalidateAutoResponderMentionUsernames() {
+	if err := a.Srv().autoResponderCache.Remove(autoResponderMentionUsernamesKey); err != nil {
+		a.Log().Warn("Failed to invalidate the usernames of the auto-responders answering mentions", mlog.Err(err))
+	}
+}
+
+// sendAutoResponsesToMentions replies on behalf of the channel members
+// mentioned in post whose auto-responder is set to answer mentions. The
+// replies are ephemeral posts only shown to the sender, once a day.
+func (a *App) sendAutoResponsesToMentions(rctx request.CTX, channel *model.Channel, sender *model.User, post *model.Post) (bool, *model.AppError) {
+	mentions := possibleAtMentions(post.Message)
+	if len(mentions) == 0 {
+		return false, nil
+	}
+
+	autoResponders, err := a.getAutoResponderMentionUsernames()
+	if err != nil {
+		return false, model.NewAppError("SendAutoResponseIfNecessary", "app.user.send_auto_response.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var usernames []string
+	for _, name := range mentions {
+		if autoResponders[name] {
+			usernames = append(usernames, name)
+		}
+		if trimmed, ok := trimUsernameSpecialChar(name); ok && autoResponders[trimmed] {
+			usernames = append(usernames, trimmed)
+		}
+	}
+	if len(usernames) == 0 {
 		return false, nil
 	}
 
+	receivers, err := a.Srv().Store().User().GetProfilesByUsernames(usernames, &model.ViewUsersRestrictions{Channels: []string{channel.Id}})
+	if err != nil {
+		return false, model.NewAppError("SendAutoResponseIfNecessary", "app.user.send_auto_response.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	y, m, d := model.GetTimeForMillis(post.CreateAt).Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Format("20060102")
+
+	sent := false
+	for _, receiver := range receivers {
+		if receiver.Id == sender.Id || receiver.NotifyProps[model.AutoResponderMentionsNotifyProp] != "true" {
+			continue
+		}
+
+		repliedKey := strings.Join([]string{"replied", receiver.Id, sender.Id, channel.Id, day}, ":")
+		var replied bool
+		if err := a.Srv().autoResponderCache.Get(repliedKey, &replied); err == nil && replied {
+			continue
+		}
+
+		autoResponderPost := newAutoResponsePost(channel, receiver, post)
+		if autoResponderPost == nil {
+			continue
+		}
+
+		a.SendEphemeralPost(rctx, sender.Id, autoResponderPost)
+		if err := a.Srv().autoResponderCache.SetWithExpiry(repliedKey, true, 24*time.Hour); err != nil {
+			rctx.Logger().Warn("Failed to remember the auto-response to a mention", mlog.Err(err))
+		}
+		sent = true
+	}
+
+	return sent, nil
+}
+
+// newAutoResponsePost returns the post answering post on behalf of receiver,
+// or nil when the auto-responder of receiver is off.
+func newAutoResponsePost(channel *model.Channel, receiver *model.User, post *model.Post) *model.Post {
+	if receiver == nil || receiver.NotifyProps == nil {
+		return nil
+	}
+
 	active := receiver.NotifyProps[model.AutoResponderActiveNotifyProp] == "true"
 	message := receiver.NotifyProps[model.AutoResponderMessageNotifyProp]
 
+	// Senders from remote clusters of a shared channel get their own message.
+	if remoteMessage := receiver.NotifyProps[model.AutoResponderRemoteMessageNotifyProp]; post.GetRemoteID() != "" && remoteMessage != "" {
+		message = remoteMessage
+	}
+
 	if !active || message == "" {
-		return false, nil
+		return nil
 	}
 
 	rootID := post.Id
@@ -69,13 +176,20 @@ func (a *App) SendAutoResponse(rctx request.CTX, channel *model.Channel, receive
 		rootID = post.RootId
 	}
 
-	autoResponderPost := &model.Post{
+	return &model.Post{
 		ChannelId: channel.Id,
 		Message:   message,
 		RootId:    rootID,
 		Type:      model.PostTypeAutoResponder,
 		UserId:    receiver.Id,
 	}
+}
+
+func (a *App) SendAutoResponse(rctx request.CTX, channel *model.Channel, receiver *model.User, post *model.Post) (bool, *model.AppError) {
+	autoResponderPost := newAutoResponsePost(channel, receiver, post)
+	if autoResponderPost == nil {
+		return false, nil
+	}
 
 	if _, err := a.CreatePost(rctx, autoResponderPost, channel, model.CreatePostFlags{}); err != nil {
 		return false, err
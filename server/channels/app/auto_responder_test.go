@@ -246,6 +246,134 @@ func TestSendAutoResponseIfNecessary(t *testing.T) {
 		require.Nil(t, err)
 		assert.True(t, sent)
 	})
+
+	t.Run("should send auto response to a mention when enabled for mentions", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				model.AutoResponderActiveNotifyProp:   "true",
+				model.AutoResponderMessageNotifyProp:  "Hello, I'm unavailable today.",
+				model.AutoResponderMentionsNotifyProp: "true",
+			},
+		}
+		_, err := th.App.PatchUser(th.Context, th.BasicUser2.Id, patch, true)
+		require.Nil(t, err)
+
+		savedPost, err := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			Message:   "hey @" + th.BasicUser2.Username + ", got a minute?",
+			UserId:    th.BasicUser.Id},
+			th.BasicChannel,
+			model.CreatePostFlags{SetOnline: true})
+		require.Nil(t, err)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.Context, th.BasicChannel, th.BasicUser, savedPost)
+		require.Nil(t, err)
+		assert.True(t, sent)
+
+		// The response is ephemeral, so nothing is posted in the channel.
+		posts, err := th.App.GetPostsSince(model.GetPostsSinceOptions{ChannelId: th.BasicChannel.Id, Time: savedPost.CreateAt})
+		require.Nil(t, err)
+		for _, post := range posts.Posts {
+			assert.NotEqual(t, model.PostTypeAutoResponder, post.Type)
+		}
+
+		// Only one response per sender, channel and day.
+		sent, err = th.App.SendAutoResponseIfNecessary(th.Context, th.BasicChannel, th.BasicUser, savedPost)
+		require.Nil(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("should pick up auto-responders enabled for mentions after the first lookup", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		savedPost, err := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			Message:   "hey @" + th.BasicUser2.Username,
+			UserId:    th.BasicUser.Id},
+			th.BasicChannel,
+			model.CreatePostFlags{SetOnline: true})
+		require.Nil(t, err)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.Context, th.BasicChannel, th.BasicUser, savedPost)
+		require.Nil(t, err)
+		assert.False(t, sent)
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				model.AutoResponderActiveNotifyProp:   "true",
+				model.AutoResponderMessageNotifyProp:  "Hello, I'm unavailable today.",
+				model.AutoResponderMentionsNotifyProp: "true",
+			},
+		}
+		_, err = th.App.PatchUser(th.Context, th.BasicUser2.Id, patch, true)
+		require.Nil(t, err)
+
+		sent, err = th.App.SendAutoResponseIfNecessary(th.Context, th.BasicChannel, th.BasicUser, savedPost)
+		require.Nil(t, err)
+		assert.True(t, sent)
+	})
+
+	t.Run("should not send auto response to a mention when not enabled for mentions", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				model.AutoResponderActiveNotifyProp:  "true",
+				model.AutoResponderMessageNotifyProp: "Hello, I'm unavailable today.",
+			},
+		}
+		_, err := th.App.PatchUser(th.Context, th.BasicUser2.Id, patch, true)
+		require.Nil(t, err)
+
+		savedPost, err := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			Message:   "hey @" + th.BasicUser2.Username,
+			UserId:    th.BasicUser.Id},
+			th.BasicChannel,
+			model.CreatePostFlags{SetOnline: true})
+		require.Nil(t, err)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.Context, th.BasicChannel, th.BasicUser, savedPost)
+		require.Nil(t, err)
+		assert.False(t, sent)
+	})
+}
+
+func TestSendAutoResponseRemoteMessage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	patch := &model.UserPatch{
+		NotifyProps: map[string]string{
+			model.AutoResponderActiveNotifyProp:        "true",
+			model.AutoResponderMessageNotifyProp:       "Hello, I'm unavailable today.",
+			model.AutoResponderRemoteMessageNotifyProp: "Hello partner, I'm unavailable today.",
+		},
+	}
+	receiver, err := th.App.PatchUser(th.Context, th.BasicUser2.Id, patch, true)
+	require.Nil(t, err)
+
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: th.BasicChannel.Id,
+		Message:   NewTestId(),
+		UserId:    th.BasicUser.Id,
+		RemoteId:  model.NewPointer(model.NewId()),
+	}
+
+	sent, err := th.App.SendAutoResponse(th.Context, th.BasicChannel, receiver, post)
+	require.Nil(t, err)
+	require.True(t, sent)
+
+	list, err := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+	require.Nil(t, err)
+	require.Len(t, list.Order, 1)
+	assert.Equal(t, "Hello partner, I'm unavailable today.", list.Posts[list.Order[0]].Message)
 }
 
 func TestSendAutoResponseSuccess(t *testing.T) {
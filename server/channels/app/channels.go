@@ -17,6 +17,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
 	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
@@ -47,6 +48,13 @@ type Channels struct {
 
 	imageProxy *imageproxy.ImageProxy
 
+	// docExtractors holds the file content extractors registered by plugins.
+	docExtractors *docextractor.Registry
+
+	// mentionSources holds the custom mention sources, keyed by name.
+	mentionSourcesLock sync.RWMutex
+	mentionSources     map[string]MentionSource
+
 	// cached counts that are used during notice condition validation
 	cachedPostCount   int64
 	cachedUserCount   int64
@@ -74,18 +82,22 @@ type Channels struct {
 	dndTaskMut sync.Mutex
 	dndTask    *model.ScheduledTask
 
-	postReminderMut  sync.Mutex
-	postReminderTask *model.ScheduledTask
-
 	scheduledPostMut  sync.Mutex
 	scheduledPostTask *model.ScheduledTask
 	loginAttemptsMut  sync.Mutex
 }
 
 func NewChannels(s *Server) (*Channels, error) {
+	imageProxyCache, err := imageproxy.NewLocalCacheFileBackend()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the image proxy cache")
+	}
+
 	ch := &Channels{
 		srv:             s,
-		imageProxy:      imageproxy.MakeImageProxy(s.platform, s.httpService, s.Log()),
+		imageProxy:      imageproxy.MakeImageProxy(s.platform, s.httpService, imageProxyCache, s.GetMetrics(), s.Log()),
+		docExtractors:   docextractor.NewRegistry(),
+		mentionSources:  map[string]MentionSource{},
 		uploadLockMap:   map[string]bool{},
 		filestore:       s.FileBackend(),
 		exportFilestore: s.ExportFileBackend(),
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/config"
+)
+
+func configHistoryAppError(where string, err error) *model.AppError {
+	switch {
+	case errors.Is(err, config.ErrHistoryNotSupported):
+		return model.NewAppError(where, "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	case errors.Is(err, config.ErrVersionNotFound):
+		return model.NewAppError(where, "app.config.history.version_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	default:
+		return model.NewAppError(where, "app.config.history.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
+
+// GetConfigHistory returns a page of the stored versions of the configuration, newest first.
+func (a *App) GetConfigHistory(page, perPage int) ([]*model.ConfigVersion, *model.AppError) {
+	versions, err := a.Srv().platform.GetConfigHistory(page*perPage, perPage)
+	if err != nil {
+		return nil, configHistoryAppError("GetConfigHistory", err)
+	}
+
+	return versions, nil
+}
+
+// GetConfigVersion returns the configuration of the given version, without the environment
+// overrides.
+func (a *App) GetConfigVersion(versionID string) (*model.Config, *model.AppError) {
+	cfg, err := a.Srv().platform.GetConfigVersion(versionID)
+	if err != nil {
+		return nil, configHistoryAppError("GetConfigVersion", err)
+	}
+
+	return cfg, nil
+}
+
+// RollbackConfig replaces the configuration with the given version of it, recording the given user
+// as the author of the change. The version is passed to prepare before being validated and saved.
+func (a *App) RollbackConfig(versionID string, authorID string, prepare func(*model.Config) *model.AppError) (*model.Config, *model.Config, *model.AppError) {
+	oldCfg, newCfg, err := a.Srv().platform.RollbackConfig(versionID, authorID, prepare)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, nil, appErr
+		case errors.Is(err, config.ErrReadOnlyConfiguration):
+			return nil, nil, model.NewAppError("RollbackConfig", "ent.cluster.save_config.error", nil, "", http.StatusForbidden).Wrap(err)
+		case errors.Is(err, config.ErrHistoryNotSupported), errors.Is(err, config.ErrVersionNotFound):
+			return nil, nil, configHistoryAppError("RollbackConfig", err)
+		default:
+			return nil, nil, model.NewAppError("RollbackConfig", "app.save_config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return oldCfg, newCfg, nil
+}
+
+// GetConfigVersionDiff returns the settings that changed between two versions of the configuration,
+// with the sensitive values masked. An empty toVersionID compares against the configuration in use.
+func (a *App) GetConfigVersionDiff(fromVersionID, toVersionID string) ([]*model.ConfigVersionDiff, *model.AppError) {
+	fromCfg, appErr := a.GetConfigVersion(fromVersionID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var toCfg *model.Config
+	if toVersionID == "" {
+		toCfg = a.Srv().platform.GetConfigStore().RemoveEnvironmentOverrides(a.Config())
+	} else if toCfg, appErr = a.GetConfigVersion(toVersionID); appErr != nil {
+		return nil, appErr
+	}
+
+	diffs, err := config.Diff(fromCfg, toCfg)
+	if err != nil {
+		return nil, model.NewAppError("GetConfigVersionDiff", "app.config.history.diff.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	result := make([]*model.ConfigVersionDiff, 0, len(diffs))
+	for _, diff := range diffs.Sanitize() {
+		result = append(result, &model.ConfigVersionDiff{
+			Path:      diff.Path,
+			BaseVal:   diff.BaseVal,
+			ActualVal: diff.ActualVal,
+		})
+	}
+
+	return result, nil
+}
@@ -394,6 +394,30 @@ func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, site
 	return nil
 }
 
+func (es *Service) SendMfaRecoveryCodesLowEmail(email string, recoveryCodesLeft int, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T("api.templates.mfa_recovery_codes_low_subject",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName})
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.mfa_recovery_codes_low_body.title")
+	data.Props["Info"] = T("api.templates.mfa_recovery_codes_low_body.info", recoveryCodesLeft, map[string]any{"Count": recoveryCodesLeft, "SiteURL": siteURL})
+	data.Props["Warning"] = T("api.templates.email_warning")
+
+	body, err := es.templatesContainer.RenderToString("mfa_change_body", data)
+	if err != nil {
+		return err
+	}
+
+	if err := es.sendMail(email, subject, body, "MfaRecoveryCodesLowEmail"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *Service) SendInviteEmails(
 	team *model.Team,
 	senderName string,
@@ -293,6 +293,36 @@ func TestSendCloudWelcomeEmail(t *testing.T) {
 	})
 }
 
+func TestSendMfaRecoveryCodesLowEmail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.ConfigureInbucketMail()
+
+	emailTo := th.BasicUser.Email
+
+	err := mail.DeleteMailBox(emailTo)
+	require.NoError(t, err, "Failed to delete mailbox")
+
+	err = th.service.SendMfaRecoveryCodesLowEmail(emailTo, 2, th.BasicUser.Locale, "https://example.com")
+	require.NoError(t, err)
+
+	var resultsMailbox mail.JSONMessageHeaderInbucket
+	err = mail.RetryInbucket(5, func() error {
+		var err error
+		resultsMailbox, err = mail.GetMailBox(emailTo)
+		return err
+	})
+	if err != nil {
+		t.Skipf("No email was received, maybe due load on the server: %v", err)
+	}
+
+	require.Len(t, resultsMailbox, 1)
+	resultsEmail, err := mail.GetMessageFromMailbox(emailTo, resultsMailbox[0].ID)
+	require.NoError(t, err, "Could not get message from mailbox")
+	require.Contains(t, resultsEmail.Subject, "running out of MFA recovery codes", "Wrong subject message %s", resultsEmail.Subject)
+	require.Contains(t, resultsEmail.Body.Text, "You have 2 recovery codes left", "Wrong body %s", resultsEmail.Body.Text)
+}
+
 func TestMailServiceConfig(t *testing.T) {
 	configuredReplyTo := "feedbackexample@test.com"
 	customReplyTo := "customreplyto@test.com"
@@ -392,6 +392,24 @@ func (_m *ServiceInterface) SendMfaChangeEmail(_a0 string, activated bool, local
 	return r0
 }
 
+// SendMfaRecoveryCodesLowEmail provides a mock function with given fields: _a0, recoveryCodesLeft, locale, siteURL
+func (_m *ServiceInterface) SendMfaRecoveryCodesLowEmail(_a0 string, recoveryCodesLeft int, locale string, siteURL string) error {
+	ret := _m.Called(_a0, recoveryCodesLeft, locale, siteURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMfaRecoveryCodesLowEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, string, string) error); ok {
+		r0 = rf(_a0, recoveryCodesLeft, locale, siteURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendNotificationMail provides a mock function with given fields: to, subject, htmlBody
 func (_m *ServiceInterface) SendNotificationMail(to string, subject string, htmlBody string) error {
 	ret := _m.Called(to, subject, htmlBody)
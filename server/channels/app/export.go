@@ -133,8 +133,23 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 		job.Data = make(model.StringMap)
 	}
 
-	ctx.Logger().Info("Bulk export: exporting version")
-	if err := a.exportVersion(writer); err != nil {
+	filter, err := a.newBulkExportFilter(opts.BulkExportFilter)
+	if err != nil {
+		return err
+	}
+
+	// The checkpoint is where the next incremental export continues from.
+	checkpoint := model.GetMillis()
+	if filter.IsIncremental() {
+		if filter.Until == 0 {
+			filter.Until = checkpoint
+		}
+		checkpoint = filter.Until
+	}
+	job.Data["checkpoint"] = strconv.FormatInt(checkpoint, 10)
+
+	ctx.Logger().Info("Bulk export: exporting version", mlog.Int("since", filter.Since), mlog.Int("checkpoint", checkpoint))
+	if err := a.exportVersion(writer, filter.Since, checkpoint); err != nil {
 		return err
 	}
 
@@ -145,48 +160,48 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	}
 
 	ctx.Logger().Info("Bulk export: exporting teams")
-	teamNames, err := a.exportAllTeams(ctx, job, writer)
+	teamNames, err := a.exportAllTeams(ctx, job, writer, filter)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting channels")
-	if err = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels); err != nil {
+	if err = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels, filter); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting users")
-	profilePictures, err := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures)
+	profilePictures, err := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures, filter)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting bots")
-	botPPs, err := a.exportAllBots(ctx, job, writer, opts.IncludeProfilePictures)
+	botPPs, err := a.exportAllBots(ctx, job, writer, opts.IncludeProfilePictures, filter)
 	if err != nil {
 		return err
 	}
 	profilePictures = append(profilePictures, botPPs...)
 
 	ctx.Logger().Info("Bulk export: exporting posts")
-	attachments, err := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	attachments, err := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, filter)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting emoji")
-	emojiPaths, err := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive)
+	emojiPaths, err := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive, filter)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct channels")
-	if err = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels); err != nil {
+	if err = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels, filter); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct posts")
-	directAttachments, err := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	directAttachments, err := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, filter)
 	if err != nil {
 		return err
 	}
@@ -231,6 +246,64 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	return nil
 }
 
+// bulkExportFilter applies a model.BulkExportFilter to the exported data. A nil
+// filter exports everything.
+type bulkExportFilter struct {
+	model.BulkExportFilter
+
+	// teamIDs are the teams in scope, including the teams of the channels in scope.
+	teamIDs map[string]bool
+}
+
+func (a *App) newBulkExportFilter(filter model.BulkExportFilter) (*bulkExportFilter, *model.AppError) {
+	f := &bulkExportFilter{BulkExportFilter: filter}
+	if !filter.HasScope() {
+		return f, nil
+	}
+
+	f.teamIDs = make(map[string]bool, len(filter.TeamIds))
+	for _, teamID := range filter.TeamIds {
+		f.teamIDs[teamID] = true
+	}
+
+	if len(filter.ChannelIds) > 0 {
+		channels, err := a.Srv().Store().Channel().GetChannelsByIds(filter.ChannelIds, true)
+		if err != nil {
+			return nil, model.NewAppError("BulkExport", "app.channel.get_channels_by_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, channel := range channels {
+			if channel.TeamId != "" {
+				f.teamIDs[channel.TeamId] = true
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// isEmpty returns true if the store queries don't need to be filtered.
+func (f *bulkExportFilter) isEmpty() bool {
+	return f == nil || f.IsEmpty()
+}
+
+// changed returns true if an object last updated at the given time is exported.
+func (f *bulkExportFilter) changed(updateAt int64) bool {
+	return f == nil || !f.IsIncremental() || f.InWindow(updateAt)
+}
+
+func (f *bulkExportFilter) includesTeam(teamID string) bool {
+	return f == nil || !f.HasScope() || f.teamIDs[teamID]
+}
+
+func (f *bulkExportFilter) includesChannel(teamID, channelID string) bool {
+	return f == nil || f.IncludesChannel(teamID, channelID)
+}
+
+// includesDeleted returns true if deleted posts are exported to carry over their deletion.
+func (f *bulkExportFilter) includesDeleted() bool {
+	return f != nil && f.IsIncremental()
+}
+
 func (a *App) exportAttachments(ctx request.CTX, attachments []imports.AttachmentImportData, outPath string, zipWr *zip.Writer) *model.AppError {
 	totalExportedFiles := 0
 	attachmentsLen := len(attachments)
@@ -259,13 +332,15 @@ func (a *App) exportWriteLine(w io.Writer, line *imports.LineImportData) *model.
 	return nil
 }
 
-func (a *App) exportVersion(writer io.Writer) *model.AppError {
+func (a *App) exportVersion(writer io.Writer, since, checkpoint int64) *model.AppError {
 	version := 1
 
 	info := &imports.VersionInfoImportData{
-		Generator: "mattermost-server",
-		Version:   fmt.Sprintf("%s (%s, enterprise: %s)", model.CurrentVersion, model.BuildHash, model.BuildEnterpriseReady),
-		Created:   time.Now().Format(time.RFC3339Nano),
+		Generator:  "mattermost-server",
+		Version:    fmt.Sprintf("%s (%s, enterprise: %s)", model.CurrentVersion, model.BuildHash, model.BuildEnterpriseReady),
+		Created:    time.Now().Format(time.RFC3339Nano),
+		Since:      since,
+		Checkpoint: checkpoint,
 	}
 
 	versionLine := &imports.LineImportData{
@@ -377,7 +452,7 @@ func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, s
 	}
 }
 
-func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer) (map[string]bool, *model.AppError) {
+func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer, filter *bulkExportFilter) (map[string]bool, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	teamNames := make(map[string]bool)
 	cnt := 0
@@ -402,6 +477,10 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 			}
 			teamNames[team.Name] = true
 
+			if !filter.includesTeam(team.Id) || !filter.changed(team.UpdateAt) {
+				continue
+			}
+
 			teamLine := importLineFromTeam(team)
 			if err := a.exportWriteLine(writer, teamLine); err != nil {
 				return nil, err
@@ -412,7 +491,7 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 	return teamNames, nil
 }
 
-func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool) *model.AppError {
+func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool, filter *bulkExportFilter) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -439,6 +518,10 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 				continue
 			}
 
+			if !filter.includesChannel(channel.TeamId, channel.Id) || !filter.changed(channel.UpdateAt) {
+				continue
+			}
+
 			channelLine := importLineFromChannel(channel)
 			if err := a.exportWriteLine(writer, channelLine); err != nil {
 				return err
@@ -449,12 +532,18 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 	return nil
 }
 
-func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool) ([]string, *model.AppError) {
+func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool, filter *bulkExportFilter) ([]string, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	profilePictures := []string{}
 	for {
-		users, err := a.Srv().Store().User().GetAllAfter(1000, afterId)
+		var users []*model.User
+		var err error
+		if filter.isEmpty() {
+			users, err = a.Srv().Store().User().GetAllAfter(1000, afterId)
+		} else {
+			users, err = a.Srv().Store().User().GetAllForFilteredExportAfter(1000, afterId, filter.BulkExportFilter)
+		}
 		if err != nil {
 			return profilePictures, model.NewAppError("exportAllUsers", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -533,7 +622,7 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 			}
 
 			// Do the Team Memberships.
-			members, err := a.buildUserTeamAndChannelMemberships(ctx, user.Id, includeArchivedChannels)
+			members, err := a.buildUserTeamAndChannelMemberships(ctx, user.Id, includeArchivedChannels, filter)
 			if err != nil {
 				return profilePictures, err
 			}
@@ -549,7 +638,7 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 	return profilePictures, nil
 }
 
-func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, includeProfilePictures bool) ([]string, *model.AppError) {
+func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, includeProfilePictures bool, filter *bulkExportFilter) ([]string, *model.AppError) {
 	afterId := ""
 	cnt := 0
 	profilePictures := []string{}
@@ -568,6 +657,10 @@ func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, i
 		for _, bot := range bots {
 			afterId = bot.UserId
 
+			if !filter.changed(bot.UpdateAt) {
+				continue
+			}
+
 			var ownerUsername string
 			owner, err := a.Srv().Store().User().Get(ctx.Context(), bot.OwnerId)
 			if err != nil {
@@ -607,7 +700,7 @@ func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, i
 	return profilePictures, nil
 }
 
-func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, includeArchivedChannels bool) (*[]imports.UserTeamImportData, *model.AppError) {
+func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, includeArchivedChannels bool, filter *bulkExportFilter) (*[]imports.UserTeamImportData, *model.AppError) {
 	var memberships []imports.UserTeamImportData
 
 	members, err := a.Srv().Store().Team().GetTeamMembersForExport(userID)
@@ -616,15 +709,25 @@ func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, i
 	}
 
 	for _, member := range members {
-		// Skip deleted.
+		if !filter.includesTeam(member.TeamId) {
+			continue
+		}
+
+		// Skip deleted, unless the removal is carried over by an incremental export.
 		if member.DeleteAt != 0 {
+			if filter.includesDeleted() && filter.changed(member.DeleteAt) {
+				memberships = append(memberships, imports.UserTeamImportData{
+					Name:     &member.TeamName,
+					DeleteAt: &member.DeleteAt,
+				})
+			}
 			continue
 		}
 
 		memberData := importUserTeamDataFromTeamMember(member)
 
 		// Do the Channel Memberships.
-		channelMembers, err := a.buildUserChannelMemberships(c, userID, member.TeamId, includeArchivedChannels)
+		channelMembers, err := a.buildFilteredUserChannelMemberships(c, userID, member.TeamId, includeArchivedChannels, filter)
 		if err != nil {
 			return nil, err
 		}
@@ -644,11 +747,22 @@ func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, i
 }
 
 func (a *App) buildUserChannelMemberships(c request.CTX, userID string, teamID string, includeArchivedChannels bool) (*[]imports.UserChannelImportData, *model.AppError) {
-	members, nErr := a.Srv().Store().Channel().GetChannelMembersForExport(userID, teamID, includeArchivedChannels)
+	return a.buildFilteredUserChannelMemberships(c, userID, teamID, includeArchivedChannels, nil)
+}
+
+func (a *App) buildFilteredUserChannelMemberships(c request.CTX, userID string, teamID string, includeArchivedChannels bool, filter *bulkExportFilter) (*[]imports.UserChannelImportData, *model.AppError) {
+	allMembers, nErr := a.Srv().Store().Channel().GetChannelMembersForExport(userID, teamID, includeArchivedChannels)
 	if nErr != nil {
 		return nil, model.NewAppError("buildUserChannelMemberships", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
+	members := make([]*model.ChannelMemberForExport, 0, len(allMembers))
+	for _, member := range allMembers {
+		if filter.includesChannel(teamID, member.ChannelId) {
+			members = append(members, member)
+		}
+	}
+
 	category := model.PreferenceCategoryFavoriteChannel
 	preferences, err := a.GetPreferenceByCategoryForUser(c, userID, category)
 	if err != nil && err.StatusCode != http.StatusNotFound {
@@ -659,6 +773,24 @@ func (a *App) buildUserChannelMemberships(c request.CTX, userID string, teamID s
 	for i, member := range members {
 		memberships[i] = *importUserChannelDataFromChannelMemberAndPreferences(member, &preferences)
 	}
+
+	// Channel members are deleted when they leave, so incremental exports
+	// carry the leaves over from the membership history.
+	if filter.includesDeleted() {
+		leftChannels, nErr := a.Srv().Store().ChannelMemberHistory().GetChannelsLeftForExport(userID, teamID, filter.BulkExportFilter)
+		if nErr != nil {
+			return nil, model.NewAppError("buildUserChannelMemberships", "app.channel_member_history.get_channels_left.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+		for _, left := range leftChannels {
+			if filter.includesChannel(teamID, left.ChannelId) {
+				memberships = append(memberships, imports.UserChannelImportData{
+					Name:     &left.ChannelName,
+					DeleteAt: &left.LeaveTime,
+				})
+			}
+		}
+	}
+
 	return &memberships, nil
 }
 
@@ -682,7 +814,7 @@ func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNot
 	}
 }
 
-func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool, filter *bulkExportFilter) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -695,7 +827,13 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 			logCheckpoint = time.Now()
 		}
 
-		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		var posts []*model.PostForExport
+		var nErr error
+		if filter.isEmpty() {
+			posts, nErr = a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		} else {
+			posts, nErr = a.Srv().Store().Post().GetParentsForFilteredExportAfter(1000, afterId, includeArchivedChannels, filter.BulkExportFilter)
+		}
 		if nErr != nil {
 			return nil, model.NewAppError("exportAllPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
@@ -710,14 +848,20 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 			afterId = post.Id
 			postProcessCount++
 
-			// Skip deleted.
-			if post.DeleteAt != 0 {
+			// Skip deleted, unless the deletion is carried over by an incremental export.
+			if post.DeleteAt != 0 && !filter.includesDeleted() {
 				continue
 			}
 
 			postLine := importLineForPost(post)
 
-			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments)
+			previousMessage, err := a.buildPreviousPostMessage(post.Id, post.EditAt, filter)
+			if err != nil {
+				return nil, err
+			}
+			postLine.Post.PreviousMessage = previousMessage
+
+			replies, replyAttachments, err := a.buildFilteredPostReplies(ctx, post.Id, withAttachments, filter)
 			if err != nil {
 				return nil, err
 			}
@@ -737,8 +881,16 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 
 			postLine.Post.Replies = &replies
 			postLine.Post.Reactions = &[]imports.ReactionImportData{}
-			if post.HasReactions {
-				postLine.Post.Reactions, err = a.BuildPostReactions(ctx, post.Id)
+			// The last reaction removed from a post is carried over by incremental exports too.
+			if post.HasReactions || filter.includesDeleted() {
+				postLine.Post.Reactions, err = a.buildFilteredPostReactions(ctx, post.Id, filter)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if post.Type == model.PostTypePoll {
+				postLine.Post.Poll, err = a.buildPostPoll(ctx, post.Id)
 				if err != nil {
 					return nil, err
 				}
@@ -764,19 +916,43 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 }
 
 func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments bool) ([]imports.ReplyImportData, []imports.AttachmentImportData, *model.AppError) {
+	return a.buildFilteredPostReplies(ctx, postID, withAttachments, nil)
+}
+
+func (a *App) buildFilteredPostReplies(ctx request.CTX, postID string, withAttachments bool, filter *bulkExportFilter) ([]imports.ReplyImportData, []imports.AttachmentImportData, *model.AppError) {
 	var replies []imports.ReplyImportData
 	var attachments []imports.AttachmentImportData
 
-	replyPosts, nErr := a.Srv().Store().Post().GetRepliesForExport(postID)
+	var replyPosts []*model.ReplyForExport
+	var nErr error
+	if filter.includesDeleted() {
+		replyPosts, nErr = a.Srv().Store().Post().GetAllRepliesForExport(postID)
+	} else {
+		replyPosts, nErr = a.Srv().Store().Post().GetRepliesForExport(postID)
+	}
 	if nErr != nil {
 		return nil, nil, model.NewAppError("buildPostReplies", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
 	for _, reply := range replyPosts {
 		replyImportObject := importReplyFromPost(reply)
-		if reply.HasReactions {
+
+		previousMessage, appErr := a.buildPreviousPostMessage(reply.Id, reply.EditAt, filter)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		replyImportObject.PreviousMessage = previousMessage
+
+		if reply.HasReactions || filter.includesDeleted() {
+			var appErr *model.AppError
+			replyImportObject.Reactions, appErr = a.buildFilteredPostReactions(ctx, reply.Id, filter)
+			if appErr != nil {
+				return nil, nil, appErr
+			}
+		}
+		if reply.Type == model.PostTypePoll {
 			var appErr *model.AppError
-			replyImportObject.Reactions, appErr = a.BuildPostReactions(ctx, reply.Id)
+			replyImportObject.Poll, appErr = a.buildPostPoll(ctx, reply.Id)
 			if appErr != nil {
 				return nil, nil, appErr
 			}
@@ -798,6 +974,41 @@ func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments b
 	return replies, attachments, nil
 }
 
+// buildPreviousPostMessage returns the message an edited post had at the start of the export
+// window, which is the message of the post on the servers the previous exports were imported
+// into. A full export starts before any edit, so it returns the original message.
+func (a *App) buildPreviousPostMessage(postID string, editAt int64, filter *bulkExportFilter) (*string, *model.AppError) {
+	var since int64
+	if filter != nil && filter.IsIncremental() {
+		since = filter.Since
+	}
+	if editAt <= since {
+		return nil, nil
+	}
+
+	history, err := a.Srv().Store().Post().GetEditHistoryForPost(postID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil
+		}
+		return nil, model.NewAppError("buildPreviousPostMessage", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// Each version in the edit history was replaced by the next one at its DeleteAt.
+	var previous *model.Post
+	for _, version := range history {
+		if version.DeleteAt > since && (previous == nil || version.DeleteAt < previous.DeleteAt) {
+			previous = version
+		}
+	}
+	if previous == nil {
+		return nil, nil
+	}
+
+	return &previous.Message, nil
+}
+
 func (a *App) buildThreadFollowers(_ request.CTX, postID string) ([]imports.ThreadFollowerImportData, *model.AppError) {
 	var followers []imports.ThreadFollowerImportData
 
@@ -814,6 +1025,10 @@ func (a *App) buildThreadFollowers(_ request.CTX, postID string) ([]imports.Thre
 }
 
 func (a *App) BuildPostReactions(ctx request.CTX, postID string) (*[]ReactionImportData, *model.AppError) {
+	return a.buildFilteredPostReactions(ctx, postID, nil)
+}
+
+func (a *App) buildFilteredPostReactions(ctx request.CTX, postID string, filter *bulkExportFilter) (*[]ReactionImportData, *model.AppError) {
 	var reactionsOfPost []imports.ReactionImportData
 
 	reactions, nErr := a.Srv().Store().Reaction().GetForPost(postID, true)
@@ -821,6 +1036,19 @@ func (a *App) BuildPostReactions(ctx request.CTX, postID string) (*[]ReactionImp
 		return nil, model.NewAppError("BuildPostReactions", "app.reaction.get_for_post.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
+	// Reactions removed in the window are carried over by incremental exports.
+	if filter.includesDeleted() {
+		changedReactions, nErr := a.Srv().Store().Reaction().GetForPostSince(postID, filter.Since, "", true)
+		if nErr != nil {
+			return nil, model.NewAppError("BuildPostReactions", "app.reaction.get_for_post.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+		for _, reaction := range changedReactions {
+			if reaction.DeleteAt != 0 {
+				reactions = append(reactions, reaction)
+			}
+		}
+	}
+
 	for _, reaction := range reactions {
 		user, err := a.Srv().Store().User().Get(context.Background(), reaction.UserId)
 		if err != nil {
@@ -837,6 +1065,61 @@ func (a *App) BuildPostReactions(ctx request.CTX, postID string) (*[]ReactionImp
 	return &reactionsOfPost, nil
 }
 
+// buildPostPoll returns the poll attached to a poll post, or nil if the poll
+// is missing.
+func (a *App) buildPostPoll(ctx request.CTX, postID string) (*imports.PollImportData, *model.AppError) {
+	poll, err := a.Srv().Store().Poll().Get(postID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			ctx.Logger().Warn("Skipping poll of post since the poll doesn't exist", mlog.String("post_id", postID))
+			return nil, nil
+		}
+		return nil, model.NewAppError("buildPostPoll", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	votes, err := a.Srv().Store().Poll().GetVotes(postID)
+	if err != nil {
+		return nil, model.NewAppError("buildPostPoll", "app.poll.get_votes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var userIDs []string
+	optionsByUser := map[string][]int{}
+	for _, vote := range votes {
+		if _, ok := optionsByUser[vote.UserId]; !ok {
+			userIDs = append(userIDs, vote.UserId)
+		}
+		optionsByUser[vote.UserId] = append(optionsByUser[vote.UserId], vote.OptionIndex)
+	}
+
+	pollVotes := []imports.PollVoteImportData{}
+	for _, userID := range userIDs {
+		user, err := a.Srv().Store().User().Get(context.Background(), userID)
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			if errors.As(err, &nfErr) { // the user that voted might've been deleted by now
+				ctx.Logger().Info("Skipping poll votes by user since the entity doesn't exist anymore", mlog.String("user_id", userID))
+				continue
+			}
+			return nil, model.NewAppError("buildPostPoll", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		options := optionsByUser[userID]
+		pollVotes = append(pollVotes, imports.PollVoteImportData{
+			User:    &user.Username,
+			Options: &options,
+		})
+	}
+
+	options := []string(poll.Options)
+	return &imports.PollImportData{
+		Options:        &options,
+		Anonymous:      &poll.Anonymous,
+		MultipleChoice: &poll.MultipleChoice,
+		CloseAt:        &poll.CloseAt,
+		Votes:          &pollVotes,
+	}, nil
+}
+
 func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportData, *model.AppError) {
 	infos, nErr := a.Srv().Store().FileInfo().GetForPost(postID, false, false, false)
 	if nErr != nil {
@@ -851,7 +1134,7 @@ func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportDat
 	return attachments, nil
 }
 
-func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool) ([]string, *model.AppError) {
+func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool, filter *bulkExportFilter) ([]string, *model.AppError) {
 	var emojiPaths []string
 	pageNumber := 0
 	cnt := 0
@@ -880,6 +1163,10 @@ func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writ
 			}
 
 			for _, emoji := range customEmojiList {
+				if !filter.changed(emoji.UpdateAt) {
+					continue
+				}
+
 				emojiImagePath := filepath.Join(emojiPath, emoji.Id, "image")
 				filePath := filepath.Join(exportDir, emoji.Id, "image")
 				if exportFiles {
@@ -943,7 +1230,7 @@ func (a *App) copyEmojiImages(rctx request.CTX, emojiId string, emojiImagePath s
 	return nil
 }
 
-func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool) *model.AppError {
+func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool, filter *bulkExportFilter) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -995,6 +1282,10 @@ func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io
 				}
 			}
 
+			if !filter.includesChannel("", channel.Id) || !filter.changed(channel.UpdateAt) {
+				continue
+			}
+
 			favoritedBy, err := a.buildFavoritedByList(channel.Id)
 			if err != nil {
 				return err
@@ -1087,7 +1378,7 @@ func (a *App) buildShownByList(channel *model.DirectChannelForExport) ([]string,
 	return shownBy, nil
 }
 
-func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool, filter *bulkExportFilter) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -1100,7 +1391,13 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			logCheckpoint = time.Now()
 		}
 
-		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		var posts []*model.DirectPostForExport
+		var err error
+		if filter.isEmpty() {
+			posts, err = a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		} else {
+			posts, err = a.Srv().Store().Post().GetDirectPostParentsForFilteredExportAfter(1000, afterId, includeArchivedChannels, filter.BulkExportFilter)
+		}
 		if err != nil {
 			return nil, model.NewAppError("exportAllDirectPosts", "app.post.get_direct_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -1116,8 +1413,8 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			afterId = post.Id
 			postProcessCount++
 
-			// Skip deleted.
-			if post.DeleteAt != 0 {
+			// Skip deleted, unless the deletion is carried over by an incremental export.
+			if post.DeleteAt != 0 && !filter.includesDeleted() {
 				continue
 			}
 
@@ -1140,7 +1437,7 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			}
 
 			// Do the Replies.
-			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments)
+			replies, replyAttachments, err := a.buildFilteredPostReplies(ctx, post.Id, withAttachments, filter)
 			if err != nil {
 				return nil, err
 			}
@@ -1150,11 +1447,22 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			}
 
 			postLine := importLineForDirectPost(post)
+			postLine.DirectPost.PreviousMessage, err = a.buildPreviousPostMessage(post.Id, post.EditAt, filter)
+			if err != nil {
+				return nil, err
+			}
 			postLine.DirectPost.Replies = &replies
 			if len(postAttachments) > 0 {
 				postLine.DirectPost.Attachments = &postAttachments
 			}
 
+			if post.Type == model.PostTypePoll {
+				postLine.DirectPost.Poll, err = a.buildPostPoll(ctx, post.Id)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			followers, err := a.buildThreadFollowers(ctx, post.Id)
 			if err != nil {
 				return nil, err
@@ -259,7 +259,7 @@ func importUserChannelDataFromChannelMemberAndPreferences(member *model.ChannelM
 
 func importLineForPost(post *model.PostForExport) *imports.LineImportData {
 	f := []string(post.FlaggedBy)
-	return &imports.LineImportData{
+	line := &imports.LineImportData{
 		Type: "post",
 		Post: &imports.PostImportData{
 			Team:      &post.TeamName,
@@ -274,6 +274,12 @@ func importLineForPost(post *model.PostForExport) *imports.LineImportData {
 			FlaggedBy: &f,
 		},
 	}
+
+	if post.DeleteAt != 0 {
+		line.Post.DeleteAt = &post.DeleteAt
+	}
+
+	return line
 }
 
 func importLineForDirectPost(post *model.DirectPostForExport) *imports.LineImportData {
@@ -282,7 +288,7 @@ func importLineForDirectPost(post *model.DirectPostForExport) *imports.LineImpor
 		channelMembers = []string{channelMembers[0], channelMembers[0]}
 	}
 	f := []string(post.FlaggedBy)
-	return &imports.LineImportData{
+	line := &imports.LineImportData{
 		Type: "direct_post",
 		DirectPost: &imports.DirectPostImportData{
 			ChannelMembers: &channelMembers,
@@ -296,11 +302,17 @@ func importLineForDirectPost(post *model.DirectPostForExport) *imports.LineImpor
 			FlaggedBy:      &f,
 		},
 	}
+
+	if post.DeleteAt != 0 {
+		line.DirectPost.DeleteAt = &post.DeleteAt
+	}
+
+	return line
 }
 
 func importReplyFromPost(post *model.ReplyForExport) *imports.ReplyImportData {
 	f := []string(post.FlaggedBy)
-	return &imports.ReplyImportData{
+	reply := &imports.ReplyImportData{
 		User:      &post.Username,
 		Type:      &post.Type,
 		Message:   &post.Message,
@@ -310,14 +322,26 @@ func importReplyFromPost(post *model.ReplyForExport) *imports.ReplyImportData {
 		FlaggedBy: &f,
 		Props:     &post.Props,
 	}
+
+	if post.DeleteAt != 0 {
+		reply.DeleteAt = &post.DeleteAt
+	}
+
+	return reply
 }
 
 func importReactionFromPost(user *model.User, reaction *model.Reaction) *imports.ReactionImportData {
-	return &imports.ReactionImportData{
+	data := &imports.ReactionImportData{
 		User:      &user.Username,
 		EmojiName: &reaction.EmojiName,
 		CreateAt:  &reaction.CreateAt,
 	}
+
+	if reaction.DeleteAt != 0 {
+		data.DeleteAt = &reaction.DeleteAt
+	}
+
+	return data
 }
 
 func importLineFromEmoji(emoji *model.Emoji, filePath string) *imports.LineImportData {
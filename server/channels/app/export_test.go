@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
@@ -183,7 +185,7 @@ func TestExportCustomEmoji(t *testing.T) {
 	outPath, err := filepath.Abs(filePath)
 	require.NoError(t, err)
 
-	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false)
+	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false, nil)
 	require.Nil(t, appErr, "should not have failed")
 }
 
@@ -673,6 +675,56 @@ func TestExportPostWithProps(t *testing.T) {
 	assert.Contains(t, posts[1].Props["attachments"].([]any)[0], "footer")
 }
 
+func TestExportPoll(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	post, appErr := th1.App.CreatePoll(th1.Context, th1.BasicUser.Id, &model.PollCreate{
+		ChannelId:      th1.BasicChannel.Id,
+		Question:       "Lunch?",
+		Options:        []string{"Pizza", "Sushi", "Salad"},
+		MultipleChoice: true,
+	})
+	require.Nil(t, appErr)
+	_, appErr = th1.App.VotePoll(th1.Context, post.Id, th1.BasicUser.Id, []int{0, 2})
+	require.Nil(t, appErr)
+	_, appErr = th1.App.VotePoll(th1.Context, post.Id, th1.BasicUser2.Id, []int{1})
+	require.Nil(t, appErr)
+
+	var b bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	appErr, i := th2.App.BulkImport(th2.Context, &b, nil, false, 5)
+	require.Nil(t, appErr)
+	assert.Equal(t, 0, i)
+
+	posts, err := th2.App.Srv().Store().Post().GetParentsForExportAfter(1000, strings.Repeat("0", 26), false)
+	require.NoError(t, err)
+
+	var imported *model.PostForExport
+	for _, p := range posts {
+		if p.Type == model.PostTypePoll {
+			imported = p
+		}
+	}
+	require.NotNil(t, imported)
+
+	poll, err := th2.App.Srv().Store().Poll().Get(imported.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pizza", "Sushi", "Salad"}, []string(poll.Options))
+	assert.True(t, poll.MultipleChoice)
+
+	results, appErr := th2.App.GetPollResults(th2.Context, imported.Id, "")
+	require.Nil(t, appErr)
+	assert.Equal(t, []int{1, 1, 1}, results.Counts)
+	assert.Equal(t, 2, results.VoterCount)
+}
+
 func TestExportUserCustomStatus(t *testing.T) {
 	th1 := Setup(t).InitBasic()
 
@@ -897,6 +949,212 @@ func TestBulkExport(t *testing.T) {
 	require.Nil(t, appErr)
 }
 
+func TestIncrementalBulkExport(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	readVersion := func(t *testing.T, b *bytes.Buffer) *imports.VersionInfoImportData {
+		t.Helper()
+		line, err := bytes.NewBuffer(b.Bytes()).ReadBytes('\n')
+		require.NoError(t, err)
+
+		var versionLine imports.LineImportData
+		require.NoError(t, json.Unmarshal(line, &versionLine))
+		require.Equal(t, "version", versionLine.Type)
+		require.NotNil(t, versionLine.Info)
+		return versionLine.Info
+	}
+
+	edited := th1.CreatePost(th1.BasicChannel)
+	deleted := th1.CreatePost(th1.BasicChannel)
+	unchanged := th1.CreatePost(th1.BasicChannel)
+
+	var full bytes.Buffer
+	appErr := th1.App.BulkExport(th1.Context, &full, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	checkpoint := readVersion(t, &full).Checkpoint
+	require.NotZero(t, checkpoint)
+	time.Sleep(10 * time.Millisecond)
+
+	previousMessage := edited.Message
+	edited.Message = "edited " + model.NewId()
+	_, appErr = th1.App.UpdatePost(th1.Context, edited, false)
+	require.Nil(t, appErr)
+	_, appErr = th1.App.DeletePost(th1.Context, deleted.Id, th1.BasicUser.Id)
+	require.Nil(t, appErr)
+	added := th1.CreatePost(th1.BasicChannel)
+
+	var delta bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &delta, "somePath", nil, model.BulkExportOpts{
+		BulkExportFilter: model.BulkExportFilter{Since: checkpoint},
+	})
+	require.Nil(t, appErr)
+
+	info := readVersion(t, &delta)
+	assert.Equal(t, checkpoint, info.Since)
+	assert.Greater(t, info.Checkpoint, checkpoint)
+	assert.NotContains(t, delta.String(), unchanged.Message)
+	assert.Contains(t, delta.String(), `"previous_message":"`+previousMessage+`"`)
+
+	teamName := th1.BasicTeam.Name
+	channelName := th1.BasicChannel.Name
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	appErr, _ = th2.App.BulkImport(th2.Context, &full, nil, false, 1)
+	require.Nil(t, appErr)
+	appErr, _ = th2.App.BulkImport(th2.Context, &delta, nil, false, 1)
+	require.Nil(t, appErr)
+
+	team, appErr := th2.App.GetTeamByName(teamName)
+	require.Nil(t, appErr)
+	channel, appErr := th2.App.GetChannelByName(th2.Context, channelName, team.Id, false)
+	require.Nil(t, appErr)
+
+	getPost := func(t *testing.T, createAt int64) *model.Post {
+		t.Helper()
+		posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, createAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		return posts[0]
+	}
+
+	assert.Equal(t, edited.Message, getPost(t, edited.CreateAt).Message)
+	assert.NotZero(t, getPost(t, deleted.CreateAt).DeleteAt)
+	assert.Equal(t, unchanged.Message, getPost(t, unchanged.CreateAt).Message)
+	assert.Equal(t, added.Message, getPost(t, added.CreateAt).Message)
+}
+
+func TestIncrementalBulkExportRemovals(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	readCheckpoint := func(t *testing.T, b *bytes.Buffer) int64 {
+		t.Helper()
+		line, err := bytes.NewBuffer(b.Bytes()).ReadBytes('\n')
+		require.NoError(t, err)
+
+		var versionLine imports.LineImportData
+		require.NoError(t, json.Unmarshal(line, &versionLine))
+		require.NotNil(t, versionLine.Info)
+		return versionLine.Info.Checkpoint
+	}
+
+	leftChannel := th1.CreateChannel(th1.Context, th1.BasicTeam)
+	th1.AddUserToChannel(th1.BasicUser, leftChannel)
+
+	post := th1.CreatePost(th1.BasicChannel)
+	_, appErr := th1.App.SaveReactionForPost(th1.Context, &model.Reaction{
+		UserId:    th1.BasicUser.Id,
+		PostId:    post.Id,
+		EmojiName: "smile",
+	})
+	require.Nil(t, appErr)
+
+	appErr = th1.App.UpdatePreferences(th1.Context, th1.BasicUser.Id, model.Preferences{
+		{UserId: th1.BasicUser.Id, Category: model.PreferenceCategoryFavoriteChannel, Name: th1.BasicChannel.Id, Value: "true"},
+		{UserId: th1.BasicUser.Id, Category: model.PreferenceCategoryTheme, Name: th1.BasicTeam.Id, Value: `{"type":"Denim"}`},
+	})
+	require.Nil(t, appErr)
+
+	var full bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &full, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+	checkpoint := readCheckpoint(t, &full)
+	time.Sleep(10 * time.Millisecond)
+
+	appErr = th1.App.DeleteReactionForPost(th1.Context, &model.Reaction{
+		UserId:    th1.BasicUser.Id,
+		PostId:    post.Id,
+		EmojiName: "smile",
+	})
+	require.Nil(t, appErr)
+	appErr = th1.App.LeaveChannel(th1.Context, leftChannel.Id, th1.BasicUser.Id)
+	require.Nil(t, appErr)
+	appErr = th1.App.RemoveUserFromTeam(th1.Context, th1.BasicTeam.Id, th1.BasicUser2.Id, th1.SystemAdminUser.Id)
+	require.Nil(t, appErr)
+	appErr = th1.App.DeletePreferences(th1.Context, th1.BasicUser.Id, model.Preferences{
+		{UserId: th1.BasicUser.Id, Category: model.PreferenceCategoryFavoriteChannel, Name: th1.BasicChannel.Id},
+	})
+	require.Nil(t, appErr)
+	appErr = th1.App.UpdatePreferences(th1.Context, th1.BasicUser.Id, model.Preferences{
+		{UserId: th1.BasicUser.Id, Category: model.PreferenceCategoryTheme, Name: th1.BasicTeam.Id, Value: `{"type":"Onyx"}`},
+	})
+	require.Nil(t, appErr)
+
+	var delta bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &delta, "somePath", nil, model.BulkExportOpts{
+		BulkExportFilter: model.BulkExportFilter{Since: checkpoint},
+	})
+	require.Nil(t, appErr)
+
+	teamName := th1.BasicTeam.Name
+	channelName := th1.BasicChannel.Name
+	leftChannelName := leftChannel.Name
+	username := th1.BasicUser.Username
+	username2 := th1.BasicUser2.Username
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	appErr, _ = th2.App.BulkImport(th2.Context, &full, nil, false, 1)
+	require.Nil(t, appErr)
+	appErr, _ = th2.App.BulkImport(th2.Context, &delta, nil, false, 1)
+	require.Nil(t, appErr)
+
+	team, appErr := th2.App.GetTeamByName(teamName)
+	require.Nil(t, appErr)
+	channel, appErr := th2.App.GetChannelByName(th2.Context, channelName, team.Id, false)
+	require.Nil(t, appErr)
+	left, appErr := th2.App.GetChannelByName(th2.Context, leftChannelName, team.Id, false)
+	require.Nil(t, appErr)
+	user, appErr := th2.App.GetUserByUsername(username)
+	require.Nil(t, appErr)
+	user2, appErr := th2.App.GetUserByUsername(username2)
+	require.Nil(t, appErr)
+
+	t.Run("removed reactions", func(t *testing.T) {
+		posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, post.CreateAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+
+		reactions, err := th2.App.Srv().Store().Reaction().GetForPost(posts[0].Id, false)
+		require.NoError(t, err)
+		assert.Empty(t, reactions)
+	})
+
+	t.Run("left channels", func(t *testing.T) {
+		_, err := th2.App.Srv().Store().Channel().GetMember(th2.Context.Context(), left.Id, user.Id)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+
+		_, err = th2.App.Srv().Store().Channel().GetMember(th2.Context.Context(), channel.Id, user.Id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("removed team members", func(t *testing.T) {
+		member, err := th2.App.Srv().Store().Team().GetMember(th2.Context, team.Id, user2.Id)
+		require.NoError(t, err)
+		assert.NotZero(t, member.DeleteAt)
+
+		_, err = th2.App.Srv().Store().Channel().GetMember(th2.Context.Context(), channel.Id, user2.Id)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("preferences", func(t *testing.T) {
+		_, err := th2.App.Srv().Store().Preference().Get(user.Id, model.PreferenceCategoryFavoriteChannel, channel.Id)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+
+		theme, err := th2.App.Srv().Store().Preference().Get(user.Id, model.PreferenceCategoryTheme, team.Id)
+		require.NoError(t, err)
+		assert.Equal(t, `{"type":"Onyx"}`, theme.Value)
+	})
+}
+
 func TestBuildPostReplies(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
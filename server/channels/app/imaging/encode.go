@@ -11,6 +11,8 @@ import (
 
 	"image/jpeg"
 	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
 )
 
 // EncoderOptions holds configuration options for an image encoder.
@@ -86,3 +88,20 @@ func (e *Encoder) EncodePNG(wr io.Writer, img image.Image) error {
 
 	return nil
 }
+
+// EncodeWebP encodes the given image in lossless WebP format and writes the
+// data to the passed writer.
+func (e *Encoder) EncodeWebP(wr io.Writer, img image.Image) error {
+	if e.opts.ConcurrencyLevel > 0 {
+		e.sem <- struct{}{}
+		defer func() {
+			<-e.sem
+		}()
+	}
+
+	if err := nativewebp.Encode(wr, img, nil); err != nil {
+		return fmt.Errorf("imaging: failed to encode webp: %w", err)
+	}
+
+	return nil
+}
@@ -54,6 +54,13 @@ func TestEncoderEncode(t *testing.T) {
 		err = e.EncodeJPEG(&buf, rawImg, 50)
 		require.NoError(t, err)
 		require.NotEmpty(t, buf)
+
+		buf.Reset()
+		err = e.EncodeWebP(&buf, rawImg)
+		require.NoError(t, err)
+		_, format, err := image.DecodeConfig(&buf)
+		require.NoError(t, err)
+		require.Equal(t, "webp", format)
 	})
 
 	t.Run("concurrency bounded", func(t *testing.T) {
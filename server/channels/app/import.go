@@ -11,6 +11,9 @@ import (
 	"io"
 	"net/http"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -26,6 +29,7 @@ const (
 	importMultiplePostsThreshold = 1000
 	maxScanTokenSize             = 16 * 1024 * 1024 // Need to set a higher limit than default because some customers cross the limit. See MM-22314
 	statusUpdateAfterLines       = 8192
+	importCheckpointAfterLines   = 64 * 1024
 )
 
 func stopOnError(c request.CTX, err imports.LineImportWorkerError) bool {
@@ -121,7 +125,31 @@ func processAttachments(c request.CTX, line *imports.LineImportData, basePath st
 	return nil
 }
 
-func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *sync.WaitGroup, lines <-chan imports.LineImportWorkerData, errors chan<- imports.LineImportWorkerError) {
+// skippedLines collects the lines that failed while importing with imports.BulkImportOpts.SkipErrors.
+type skippedLines struct {
+	mut   sync.Mutex
+	lines []imports.SkippedLine
+}
+
+func (s *skippedLines) add(lineNumber int, raw json.RawMessage, err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.lines = append(s.lines, imports.SkippedLine{LineNumber: lineNumber, Error: err.Error(), Line: raw})
+}
+
+// take returns the lines skipped since the previous call, in the order of the import file.
+func (s *skippedLines) take() []imports.SkippedLine {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	lines := s.lines
+	s.lines = nil
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].LineNumber < lines[j].LineNumber
+	})
+	return lines
+}
+
+func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *sync.WaitGroup, lines <-chan imports.LineImportWorkerData, errors chan<- imports.LineImportWorkerError, skipped *skippedLines) {
 	workerID := model.NewId()
 	processedLines := uint64(0)
 
@@ -131,35 +159,63 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 		c.Logger().Info("Bulk import worker finished", mlog.String("bulk_import_worker_id", workerID), mlog.Uint("processed_lines", processedLines))
 	}()
 
+	reportError := func(line imports.LineImportWorkerData, err *model.AppError) {
+		workerErr := imports.LineImportWorkerError{Error: err, LineNumber: line.LineNumber}
+		if skipped == nil {
+			errors <- workerErr
+			return
+		}
+		if stopOnError(c, workerErr) {
+			skipped.add(line.LineNumber, line.Raw, err)
+		}
+	}
+
+	importBatch := func(batch []imports.LineImportWorkerData, importLines func(request.CTX, []imports.LineImportWorkerData, bool, bool) (int, *model.AppError)) {
+		errLine, err := importLines(c, batch, dryRun, extractContent)
+		if err == nil {
+			return
+		}
+		if skipped == nil {
+			errors <- imports.LineImportWorkerError{Error: err, LineNumber: errLine}
+			return
+		}
+
+		// Import the lines of the batch one by one to only skip the ones that fail. The lines
+		// already imported with the batch are left unchanged.
+		for i := range batch {
+			if _, err := importLines(c, batch[i:i+1], dryRun, extractContent); err != nil {
+				reportError(batch[i], err)
+			}
+		}
+	}
+
 	postLines := []imports.LineImportWorkerData{}
 	directPostLines := []imports.LineImportWorkerData{}
 	for line := range lines {
 		switch {
 		case line.LineImportData.Type == "post":
-			postLines = append(postLines, line)
 			if line.Post == nil {
-				errors <- imports.LineImportWorkerError{Error: model.NewAppError("BulkImport", "app.import.import_line.null_post.error", nil, "", http.StatusBadRequest), LineNumber: line.LineNumber}
+				reportError(line, model.NewAppError("BulkImport", "app.import.import_line.null_post.error", nil, "", http.StatusBadRequest))
+				break
 			}
+			postLines = append(postLines, line)
 			if len(postLines) >= importMultiplePostsThreshold {
-				if errLine, err := a.importMultiplePostLines(c, postLines, dryRun, extractContent); err != nil {
-					errors <- imports.LineImportWorkerError{Error: err, LineNumber: errLine}
-				}
+				importBatch(postLines, a.importMultiplePostLines)
 				postLines = []imports.LineImportWorkerData{}
 			}
 		case line.LineImportData.Type == "direct_post":
-			directPostLines = append(directPostLines, line)
 			if line.DirectPost == nil {
-				errors <- imports.LineImportWorkerError{Error: model.NewAppError("BulkImport", "app.import.import_line.null_direct_post.error", nil, "", http.StatusBadRequest), LineNumber: line.LineNumber}
+				reportError(line, model.NewAppError("BulkImport", "app.import.import_line.null_direct_post.error", nil, "", http.StatusBadRequest))
+				break
 			}
+			directPostLines = append(directPostLines, line)
 			if len(directPostLines) >= importMultiplePostsThreshold {
-				if errLine, err := a.importMultipleDirectPostLines(c, directPostLines, dryRun, extractContent); err != nil {
-					errors <- imports.LineImportWorkerError{Error: err, LineNumber: errLine}
-				}
+				importBatch(directPostLines, a.importMultipleDirectPostLines)
 				directPostLines = []imports.LineImportWorkerData{}
 			}
 		default:
 			if err := a.importLine(c, line.LineImportData, dryRun); err != nil {
-				errors <- imports.LineImportWorkerError{Error: err, LineNumber: line.LineNumber}
+				reportError(line, err)
 			}
 		}
 
@@ -170,30 +226,41 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 	}
 
 	if len(postLines) > 0 {
-		if errLine, err := a.importMultiplePostLines(c, postLines, dryRun, extractContent); err != nil {
-			errors <- imports.LineImportWorkerError{Error: err, LineNumber: errLine}
-		}
+		importBatch(postLines, a.importMultiplePostLines)
 	}
 	if len(directPostLines) > 0 {
-		if errLine, err := a.importMultipleDirectPostLines(c, directPostLines, dryRun, extractContent); err != nil {
-			errors <- imports.LineImportWorkerError{Error: err, LineNumber: errLine}
-		}
+		importBatch(directPostLines, a.importMultipleDirectPostLines)
 	}
 }
 
 func (a *App) BulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, workers int) (*model.AppError, int) {
-	return a.bulkImport(c, jsonlReader, attachmentsReader, dryRun, true, workers, "")
+	return a.bulkImport(c, jsonlReader, attachmentsReader, imports.BulkImportOpts{
+		DryRun:         dryRun,
+		ExtractContent: true,
+		Workers:        workers,
+	})
 }
 
 func (a *App) BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int) {
-	return a.bulkImport(c, jsonlReader, attachmentsReader, dryRun, extractContent, workers, importPath)
+	return a.bulkImport(c, jsonlReader, attachmentsReader, imports.BulkImportOpts{
+		DryRun:         dryRun,
+		ExtractContent: extractContent,
+		Workers:        workers,
+		ImportPath:     importPath,
+	})
+}
+
+// BulkImportWithOpts imports the JSONL data like BulkImportWithPath, with support for resuming
+// an import from a checkpoint and for skipping the lines that fail.
+func (a *App) BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*model.AppError, int) {
+	return a.bulkImport(c, jsonlReader, attachmentsReader, opts)
 }
 
 // bulkImport will extract attachments from attachmentsReader if it is
 // not nil. If it is nil, it will look for attachments on the
 // filesystem in the locations specified by the JSONL file according
 // to the older behavior
-func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int) {
+func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*model.AppError, int) {
 	scanner := bufio.NewScanner(jsonlReader)
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, maxScanTokenSize)
@@ -203,11 +270,22 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 	a.Srv().Store().LockToMaster()
 	defer a.Srv().Store().UnlockFromMaster()
 
+	workers := opts.Workers
 	errorsChan := make(chan imports.LineImportWorkerError, (2*workers)+1) // size chosen to ensure it never gets filled up completely.
 	var wg sync.WaitGroup
 	var linesChan chan imports.LineImportWorkerData
 	lastLineType := ""
 
+	var skipped *skippedLines
+	if opts.SkipErrors {
+		skipped = &skippedLines{}
+	}
+
+	checkpointLines := opts.CheckpointLines
+	if checkpointLines <= 0 {
+		checkpointLines = importCheckpointAfterLines
+	}
+
 	var attachedFiles map[string]*zip.File
 	if attachmentsReader != nil {
 		attachedFiles = make(map[string]*zip.File, len(attachmentsReader.File))
@@ -216,18 +294,70 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 		}
 	}
 
+	startWorkers := func() {
+		linesChan = make(chan imports.LineImportWorkerData, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go a.bulkImportWorker(c, opts.DryRun, opts.ExtractContent, &wg, linesChan, errorsChan, skipped)
+		}
+	}
+
+	// waitForWorkers clears out the worker queue, and returns the error that stops the import,
+	// if any occurred while waiting for the queue to empty.
+	waitForWorkers := func() *imports.LineImportWorkerError {
+		if linesChan != nil {
+			close(linesChan)
+			linesChan = nil
+		}
+		wg.Wait()
+
+		if len(errorsChan) != 0 {
+			err := <-errorsChan
+			if stopOnError(c, err) {
+				return &err
+			}
+		}
+		return nil
+	}
+
+	checkpoint := func(lineNumber int) *model.AppError {
+		if opts.Checkpoint == nil {
+			return nil
+		}
+		var lines []imports.SkippedLine
+		if skipped != nil {
+			lines = skipped.take()
+		}
+		return opts.Checkpoint(lineNumber, lines)
+	}
+
+	if opts.StartLine > 0 {
+		c.Logger().Info("Resuming bulk import", mlog.Int("start_line", opts.StartLine))
+	}
+
 	for scanner.Scan() {
 		lineNumber++
 		if lineNumber%statusUpdateAfterLines == 0 {
 			c.Logger().Info("Reader progress", mlog.Int("processed_lines", lineNumber))
 		}
 
+		// The lines committed by a previous run are not imported again.
+		if lineNumber > 1 && lineNumber <= opts.StartLine {
+			continue
+		}
+
 		var line imports.LineImportData
 		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
-			return model.NewAppError("BulkImport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err), lineNumber
+			appErr := model.NewAppError("BulkImport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err)
+			if skipped == nil || lineNumber == 1 {
+				waitForWorkers()
+				return appErr, lineNumber
+			}
+			skipped.add(lineNumber, json.RawMessage(strconv.Quote(scanner.Text())), appErr)
+			continue
 		}
 
-		if err := processAttachments(c, &line, importPath, attachedFiles); err != nil {
+		if err := processAttachments(c, &line, opts.ImportPath, attachedFiles); err != nil {
 			c.Logger().Warn("Error while processing import attachments. Objects might be broken.", mlog.Err(err))
 		}
 
@@ -244,9 +374,9 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 			continue
 		}
 
-		if line.Type != lastLineType {
+		if line.Type != lastLineType || linesChan == nil {
 			// Only clear the worker queue if is not the first data entry
-			if lineNumber != 2 {
+			if linesChan != nil {
 				c.Logger().Info(
 					"Finished parsing segment, waiting for workers to finish",
 					mlog.String("old_segment", lastLineType),
@@ -254,15 +384,8 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 				)
 
 				// Changing type. Clear out the worker queue before continuing.
-				close(linesChan)
-				wg.Wait()
-
-				// Check no errors occurred while waiting for the queue to empty.
-				if len(errorsChan) != 0 {
-					err := <-errorsChan
-					if stopOnError(c, err) {
-						return err.Error, err.LineNumber
-					}
+				if err := waitForWorkers(); err != nil {
+					return err.Error, err.LineNumber
 				}
 			}
 
@@ -275,42 +398,48 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 
 			// Set up the workers and channel for this type.
 			lastLineType = line.Type
-			linesChan = make(chan imports.LineImportWorkerData, workers)
-			for i := 0; i < workers; i++ {
-				wg.Add(1)
-				go a.bulkImportWorker(c, dryRun, extractContent, &wg, linesChan, errorsChan)
-			}
+			startWorkers()
+		}
+
+		workerData := imports.LineImportWorkerData{LineImportData: line, LineNumber: lineNumber}
+		if skipped != nil {
+			workerData.Raw = json.RawMessage(slices.Clone(scanner.Bytes()))
 		}
 
 		select {
-		case linesChan <- imports.LineImportWorkerData{LineImportData: line, LineNumber: lineNumber}:
+		case linesChan <- workerData:
 		case err := <-errorsChan:
 			if stopOnError(c, err) {
-				close(linesChan)
-				wg.Wait()
+				waitForWorkers()
 				return err.Error, err.LineNumber
 			}
 		}
-	}
 
-	// No more lines. Clear out the worker queue before continuing.
-	if linesChan != nil {
-		close(linesChan)
+		// Wait for the lines read so far to be committed before recording a checkpoint. The
+		// workers are started again for the rest of the segment with the next line.
+		if opts.Checkpoint != nil && lineNumber%checkpointLines == 0 {
+			if err := waitForWorkers(); err != nil {
+				return err.Error, err.LineNumber
+			}
+			if appErr := checkpoint(lineNumber); appErr != nil {
+				return appErr, lineNumber
+			}
+		}
 	}
-	wg.Wait()
 
-	// Check no errors occurred while waiting for the queue to empty.
-	if len(errorsChan) != 0 {
-		err := <-errorsChan
-		if stopOnError(c, err) {
-			return err.Error, err.LineNumber
-		}
+	// No more lines. Clear out the worker queue before continuing.
+	if err := waitForWorkers(); err != nil {
+		return err.Error, err.LineNumber
 	}
 
 	if err := scanner.Err(); err != nil {
 		return model.NewAppError("BulkImport", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err), 0
 	}
 
+	if appErr := checkpoint(lineNumber); appErr != nil {
+		return appErr, lineNumber
+	}
+
 	return nil, 0
 }
 
@@ -364,6 +493,11 @@ func (a *App) importLine(c request.CTX, line imports.LineImportData, dryRun bool
 			return model.NewAppError("BulkImport", "app.import.import_line.null_emoji.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importEmoji(c, line.Emoji, dryRun)
+	case line.Type == "group":
+		if line.Group == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_group.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importGroup(c, line.Group, dryRun)
 	default:
 		return model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest)
 	}
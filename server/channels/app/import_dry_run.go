@@ -0,0 +1,503 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// importDryRun resolves the lines of an import against the data of the server, keeping track of
+// the objects created by earlier lines, without writing anything.
+type importDryRun struct {
+	a      *App
+	report *imports.DryRunReport
+
+	// teams, channels and users cache the lookups, with nil values for objects that don't
+	// exist on the server.
+	teams    map[string]*model.Team
+	channels map[string]*model.Channel
+	users    map[string]*model.User
+	emails   map[string]*model.User
+
+	newTeams          map[string]bool
+	newChannels       map[string]bool
+	newUsers          map[string]bool
+	newEmails         map[string]string
+	newDirectChannels map[string]bool
+}
+
+// BulkImportDryRun reports what importing the JSONL data would create, update or skip, and the
+// lines that would fail or conflict with the data of the server. Unlike a validating dry run of
+// BulkImport, it doesn't stop at the first error.
+func (a *App) BulkImportDryRun(c request.CTX, jsonlReader io.Reader) (*imports.DryRunReport, *model.AppError) {
+	scanner := bufio.NewScanner(jsonlReader)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, maxScanTokenSize)
+
+	d := &importDryRun{
+		a:                 a,
+		report:            &imports.DryRunReport{Conflicts: []imports.DryRunConflict{}, Errors: []imports.DryRunError{}},
+		teams:             make(map[string]*model.Team),
+		channels:          make(map[string]*model.Channel),
+		users:             make(map[string]*model.User),
+		emails:            make(map[string]*model.User),
+		newTeams:          make(map[string]bool),
+		newChannels:       make(map[string]bool),
+		newUsers:          make(map[string]bool),
+		newEmails:         make(map[string]string),
+		newDirectChannels: make(map[string]bool),
+	}
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if lineNumber%statusUpdateAfterLines == 0 {
+			c.Logger().Info("Dry run progress", mlog.Int("processed_lines", lineNumber))
+		}
+
+		var line imports.LineImportData
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, model.NewAppError("BulkImportDryRun", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+
+		if lineNumber == 1 {
+			importDataFileVersion, appErr := processImportDataFileVersionLine(line)
+			if appErr != nil {
+				return nil, appErr
+			}
+			if importDataFileVersion != 1 {
+				return nil, model.NewAppError("BulkImportDryRun", "app.import.bulk_import.unsupported_version.error", nil, "", http.StatusBadRequest)
+			}
+			continue
+		}
+
+		d.resolveLine(c, lineNumber, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, model.NewAppError("BulkImportDryRun", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return d.report, nil
+}
+
+func (d *importDryRun) resolveLine(c request.CTX, lineNumber int, line imports.LineImportData) {
+	var err *model.AppError
+	switch {
+	case line.Type == "team" && line.Team != nil:
+		err = d.resolveTeam(lineNumber, line.Team)
+	case line.Type == "channel" && line.Channel != nil:
+		err = d.resolveChannel(lineNumber, line.Channel)
+	case line.Type == "user" && line.User != nil:
+		err = d.resolveUser(lineNumber, line.User)
+	case line.Type == "post" && line.Post != nil:
+		err = d.resolvePost(lineNumber, line.Post)
+	case line.Type == "direct_channel" && line.DirectChannel != nil:
+		err = d.resolveDirectChannel(lineNumber, line.DirectChannel)
+	case line.Type == "direct_post" && line.DirectPost != nil:
+		err = d.resolveDirectPost(lineNumber, line.DirectPost)
+	default:
+		// The other lines are only validated.
+		err = d.a.importLine(c, line, true)
+	}
+
+	if err != nil {
+		d.report.Errors = append(d.report.Errors, imports.DryRunError{LineNumber: lineNumber, Error: err.Error()})
+	}
+}
+
+func (d *importDryRun) conflict(lineNumber int, kind, name, detail string, args ...any) {
+	d.report.Conflicts = append(d.report.Conflicts, imports.DryRunConflict{
+		LineNumber: lineNumber,
+		Kind:       kind,
+		Name:       name,
+		Detail:     fmt.Sprintf(detail, args...),
+	})
+}
+
+func (d *importDryRun) team(name string) *model.Team {
+	name = strings.ToLower(name)
+	if team, ok := d.teams[name]; ok {
+		return team
+	}
+
+	team, err := d.a.Srv().Store().Team().GetByName(name)
+	if err != nil {
+		team = nil
+	}
+	d.teams[name] = team
+	return team
+}
+
+func (d *importDryRun) channel(team *model.Team, name string) *model.Channel {
+	if team == nil {
+		return nil
+	}
+
+	key := team.Name + "/" + strings.ToLower(name)
+	if channel, ok := d.channels[key]; ok {
+		return channel
+	}
+
+	channel, err := d.a.Srv().Store().Channel().GetByNameIncludeDeleted(team.Id, strings.ToLower(name), true)
+	if err != nil {
+		channel = nil
+	}
+	d.channels[key] = channel
+	return channel
+}
+
+func (d *importDryRun) user(username string) *model.User {
+	username = strings.ToLower(username)
+	if user, ok := d.users[username]; ok {
+		return user
+	}
+
+	user, err := d.a.Srv().Store().User().GetByUsername(username)
+	if err != nil {
+		user = nil
+	}
+	d.users[username] = user
+	return user
+}
+
+func (d *importDryRun) userByEmail(email string) *model.User {
+	email = strings.ToLower(email)
+	if user, ok := d.emails[email]; ok {
+		return user
+	}
+
+	user, err := d.a.Srv().Store().User().GetByEmail(email)
+	if err != nil {
+		user = nil
+	}
+	d.emails[email] = user
+	return user
+}
+
+// teamExists reports whether a team exists on the server or is created by the import, flagging
+// archived teams.
+func (d *importDryRun) teamExists(lineNumber int, name string) bool {
+	if d.newTeams[strings.ToLower(name)] {
+		return true
+	}
+
+	team := d.team(name)
+	if team == nil {
+		d.conflict(lineNumber, imports.DryRunConflictMissingTarget, name, "team %q doesn't exist", name)
+		return false
+	}
+	if team.DeleteAt != 0 {
+		d.conflict(lineNumber, imports.DryRunConflictArchivedTarget, name, "team %q is archived", name)
+	}
+	return true
+}
+
+// channelExists reports whether a channel exists on the server or is created by the import,
+// flagging archived channels.
+func (d *importDryRun) channelExists(lineNumber int, teamName, channelName string) bool {
+	if d.newChannels[strings.ToLower(teamName)+"/"+strings.ToLower(channelName)] {
+		return true
+	}
+
+	channel := d.channel(d.team(teamName), channelName)
+	if channel == nil {
+		d.conflict(lineNumber, imports.DryRunConflictMissingTarget, channelName, "channel %q of team %q doesn't exist", channelName, teamName)
+		return false
+	}
+	if channel.DeleteAt != 0 {
+		d.conflict(lineNumber, imports.DryRunConflictArchivedTarget, channelName, "channel %q of team %q is archived", channelName, teamName)
+	}
+	return true
+}
+
+// userExists reports whether a user exists on the server or is created by the import.
+func (d *importDryRun) userExists(lineNumber int, username string) bool {
+	if d.newUsers[strings.ToLower(username)] || d.user(username) != nil {
+		return true
+	}
+
+	d.conflict(lineNumber, imports.DryRunConflictMissingTarget, username, "user %q doesn't exist", username)
+	return false
+}
+
+func (d *importDryRun) resolveTeam(lineNumber int, data *imports.TeamImportData) *model.AppError {
+	if err := imports.ValidateTeamImportData(data); err != nil {
+		d.report.Teams.Skip++
+		return err
+	}
+
+	name := strings.ToLower(*data.Name)
+	team := d.team(name)
+	switch {
+	case team == nil && !d.newTeams[name]:
+		d.newTeams[name] = true
+		d.report.Teams.Create++
+	case team == nil:
+		d.report.Teams.Update++
+	case team.DisplayName == *data.DisplayName && team.Type == *data.Type &&
+		(data.Description == nil || team.Description == *data.Description) &&
+		(data.AllowOpenInvite == nil || team.AllowOpenInvite == *data.AllowOpenInvite):
+		d.report.Teams.Skip++
+	default:
+		d.report.Teams.Update++
+	}
+
+	if team != nil && team.DeleteAt != 0 {
+		d.conflict(lineNumber, imports.DryRunConflictArchivedTarget, name, "team %q is archived and would be updated without being restored", name)
+	}
+
+	return nil
+}
+
+func (d *importDryRun) resolveChannel(lineNumber int, data *imports.ChannelImportData) *model.AppError {
+	if err := imports.ValidateChannelImportData(data); err != nil {
+		d.report.Channels.Skip++
+		return err
+	}
+
+	if !d.teamExists(lineNumber, *data.Team) {
+		d.report.Channels.Skip++
+		return nil
+	}
+
+	key := strings.ToLower(*data.Team) + "/" + strings.ToLower(*data.Name)
+	channel := d.channel(d.team(*data.Team), *data.Name)
+	switch {
+	case channel == nil && !d.newChannels[key]:
+		d.newChannels[key] = true
+		d.report.Channels.Create++
+	case channel == nil:
+		d.report.Channels.Update++
+	case channel.DisplayName == *data.DisplayName && channel.Type == *data.Type &&
+		(data.Header == nil || channel.Header == *data.Header) &&
+		(data.Purpose == nil || channel.Purpose == *data.Purpose) &&
+		data.Bookmarks == nil && (data.DeletedAt == nil || channel.DeleteAt != 0):
+		d.report.Channels.Skip++
+	default:
+		d.report.Channels.Update++
+	}
+
+	if channel != nil && channel.DeleteAt != 0 {
+		d.conflict(lineNumber, imports.DryRunConflictArchivedTarget, *data.Name, "channel %q of team %q is archived and would be updated without being restored", *data.Name, *data.Team)
+	}
+
+	return nil
+}
+
+func (d *importDryRun) resolveUser(lineNumber int, data *imports.UserImportData) *model.AppError {
+	if err := imports.ValidateUserImportData(data); err != nil {
+		d.report.Users.Skip++
+		return err
+	}
+
+	username := strings.ToLower(*data.Username)
+	email := strings.ToLower(*data.Email)
+	if username != *data.Username {
+		d.conflict(lineNumber, imports.DryRunConflictUsernameRemap, *data.Username, "the username would be imported as %q", username)
+	}
+
+	// The email address must not belong to another user, either on the server or earlier in
+	// the import.
+	owner := ""
+	if existing := d.userByEmail(email); existing != nil {
+		owner = existing.Username
+	} else if newOwner, ok := d.newEmails[email]; ok {
+		owner = newOwner
+	}
+	if owner != "" && owner != username {
+		d.conflict(lineNumber, imports.DryRunConflictEmailCollision, username, "email %q already belongs to user %q", email, owner)
+		d.report.Users.Skip++
+		return nil
+	}
+
+	user := d.user(username)
+	switch {
+	case user == nil && !d.newUsers[username]:
+		d.newUsers[username] = true
+		d.newEmails[email] = username
+		d.report.Users.Create++
+	case user == nil:
+		d.report.Users.Update++
+	case user.Email != email:
+		d.conflict(lineNumber, imports.DryRunConflictUsernameRemap, username, "existing user %q with email %q would be updated to email %q", username, user.Email, email)
+		d.newEmails[email] = username
+		d.report.Users.Update++
+	case (data.FirstName == nil || user.FirstName == *data.FirstName) &&
+		(data.LastName == nil || user.LastName == *data.LastName) &&
+		(data.Nickname == nil || user.Nickname == *data.Nickname) &&
+		(data.Position == nil || user.Position == *data.Position) &&
+		(data.Roles == nil || user.Roles == *data.Roles) &&
+		(data.DeleteAt == nil || user.DeleteAt == *data.DeleteAt) &&
+		data.Teams == nil:
+		d.report.Users.Skip++
+	default:
+		d.report.Users.Update++
+	}
+
+	if data.Teams != nil {
+		for _, teamData := range *data.Teams {
+			if teamData.Name == nil || !d.teamExists(lineNumber, *teamData.Name) || teamData.Channels == nil {
+				continue
+			}
+			for _, channelData := range *teamData.Channels {
+				if channelData.Name != nil {
+					d.channelExists(lineNumber, *teamData.Name, *channelData.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// postExists reports whether a post with the same message was already imported into a channel
+// of the server at the same time.
+func (d *importDryRun) postExists(channel *model.Channel, createAt int64, message string) (bool, *model.AppError) {
+	if channel == nil {
+		return false, nil
+	}
+
+	posts, err := d.a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, createAt)
+	if err != nil {
+		return false, model.NewAppError("BulkImportDryRun", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, post := range posts {
+		if post.Message == message {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d *importDryRun) resolvePost(lineNumber int, data *imports.PostImportData) *model.AppError {
+	if err := imports.ValidatePostImportData(data, d.a.MaxPostSize()); err != nil {
+		d.report.Posts.Skip++
+		return err
+	}
+
+	if !d.teamExists(lineNumber, *data.Team) || !d.channelExists(lineNumber, *data.Team, *data.Channel) || !d.userExists(lineNumber, *data.User) {
+		d.report.Posts.Skip++
+		return nil
+	}
+
+	exists, err := d.postExists(d.channel(d.team(*data.Team), *data.Channel), *data.CreateAt, *data.Message)
+	if err != nil {
+		return err
+	}
+	if exists {
+		d.report.Posts.Skip++
+	} else {
+		d.report.Posts.Create++
+	}
+	return nil
+}
+
+// directChannel returns the direct or group channel of the given members if it exists on the
+// server, and whether all the members could be resolved.
+func (d *importDryRun) directChannel(lineNumber int, members []string) (*model.Channel, string, bool) {
+	userIDs := make([]string, 0, len(members))
+	resolved := true
+	for _, username := range members {
+		if !d.userExists(lineNumber, username) {
+			resolved = false
+			continue
+		}
+		if user := d.user(username); user != nil {
+			userIDs = append(userIDs, user.Id)
+		}
+	}
+
+	sortedMembers := make([]string, len(members))
+	for i, member := range members {
+		sortedMembers[i] = strings.ToLower(member)
+	}
+	sort.Strings(sortedMembers)
+	key := strings.Join(sortedMembers, ",")
+
+	if !resolved || len(userIDs) != len(members) {
+		return nil, key, resolved
+	}
+
+	var name string
+	if len(userIDs) == 2 {
+		name = model.GetDMNameFromIds(userIDs[0], userIDs[1])
+	} else {
+		name = model.GetGroupNameFromUserIds(userIDs)
+	}
+
+	channel, err := d.a.Srv().Store().Channel().GetByName("", name, true)
+	if err != nil {
+		return nil, key, true
+	}
+	return channel, key, true
+}
+
+func (d *importDryRun) resolveDirectChannel(lineNumber int, data *imports.DirectChannelImportData) *model.AppError {
+	if err := imports.ValidateDirectChannelImportData(data); err != nil {
+		d.report.DirectChannels.Skip++
+		return err
+	}
+
+	var members []string
+	if data.Members != nil {
+		members = *data.Members
+	} else {
+		for _, participant := range data.Participants {
+			if participant.Username != nil {
+				members = append(members, *participant.Username)
+			}
+		}
+	}
+
+	channel, key, resolved := d.directChannel(lineNumber, members)
+	switch {
+	case !resolved:
+		d.report.DirectChannels.Skip++
+	case channel == nil && !d.newDirectChannels[key]:
+		d.newDirectChannels[key] = true
+		d.report.DirectChannels.Create++
+	case channel != nil && (data.Header == nil || channel.Header == *data.Header):
+		d.report.DirectChannels.Skip++
+	default:
+		d.report.DirectChannels.Update++
+	}
+
+	return nil
+}
+
+func (d *importDryRun) resolveDirectPost(lineNumber int, data *imports.DirectPostImportData) *model.AppError {
+	if err := imports.ValidateDirectPostImportData(data, d.a.MaxPostSize()); err != nil {
+		d.report.DirectPosts.Skip++
+		return err
+	}
+
+	channel, _, resolved := d.directChannel(lineNumber, *data.ChannelMembers)
+	if !resolved || !d.userExists(lineNumber, *data.User) {
+		d.report.DirectPosts.Skip++
+		return nil
+	}
+
+	exists, err := d.postExists(channel, *data.CreateAt, *data.Message)
+	if err != nil {
+		return err
+	}
+	if exists {
+		d.report.DirectPosts.Skip++
+	} else {
+		d.report.DirectPosts.Create++
+	}
+	return nil
+}
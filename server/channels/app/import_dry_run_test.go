@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+func TestBulkImportDryRun(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newTeam := model.NewRandomTeamName()
+	newChannel := model.NewId()
+	newUser := model.NewUsername()
+	missingTeam := model.NewRandomTeamName()
+
+	archivedChannel := th.CreateChannel(th.Context, th.BasicTeam)
+	appErr := th.App.DeleteChannel(th.Context, archivedChannel, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	data := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "New team", "name": "` + newTeam + `"}}
+{"type": "team", "team": {"type": "` + th.BasicTeam.Type + `", "display_name": "Renamed", "name": "` + th.BasicTeam.Name + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "New channel", "team": "` + newTeam + `", "name": "` + newChannel + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "Orphan", "team": "` + missingTeam + `", "name": "orphan"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "Archived", "team": "` + th.BasicTeam.Name + `", "name": "` + archivedChannel.Name + `"}}
+{"type": "user", "user": {"username": "` + newUser + `", "email": "` + th.BasicUser.Email + `"}}
+{"type": "user", "user": {"username": "` + th.BasicUser2.Username + `", "email": "` + newUser + `@example.com"}}
+{"type": "post", "post": {"team": "` + th.BasicTeam.Name + `", "channel": "` + th.BasicChannel.Name + `", "user": "` + th.BasicUser.Username + `", "message": "Hello", "create_at": 123456789012}}
+{"type": "post", "post": {"team": "` + th.BasicTeam.Name + `", "channel": "` + th.BasicChannel.Name + `", "user": "` + th.BasicUser.Username + `"}}
+{"type": "direct_channel", "direct_channel": {"members": ["` + th.BasicUser.Username + `", "` + th.BasicUser2.Username + `"]}}`
+
+	report, appErr := th.App.BulkImportDryRun(th.Context, strings.NewReader(data))
+	require.Nil(t, appErr)
+
+	assert.Equal(t, imports.DryRunCounts{Create: 1, Update: 1}, report.Teams)
+	assert.Equal(t, imports.DryRunCounts{Create: 1, Update: 1, Skip: 1}, report.Channels)
+	assert.Equal(t, imports.DryRunCounts{Update: 1, Skip: 1}, report.Users)
+	assert.Equal(t, imports.DryRunCounts{Create: 1, Skip: 1}, report.Posts)
+	assert.Equal(t, imports.DryRunCounts{Create: 1}, report.DirectChannels)
+
+	kinds := make(map[string]int)
+	for _, conflict := range report.Conflicts {
+		kinds[conflict.Kind]++
+	}
+	assert.Equal(t, map[string]int{
+		imports.DryRunConflictMissingTarget:  1,
+		imports.DryRunConflictArchivedTarget: 1,
+		imports.DryRunConflictEmailCollision: 1,
+		imports.DryRunConflictUsernameRemap:  1,
+	}, kinds)
+
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, 10, report.Errors[0].LineNumber)
+
+	// Nothing was imported.
+	_, err := th.App.Srv().Store().Team().GetByName(newTeam)
+	assert.Error(t, err)
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, appErr := th.App.BulkImportDryRun(th.Context, strings.NewReader(`{"type": "version", "version": 2}`))
+		require.NotNil(t, appErr)
+	})
+}
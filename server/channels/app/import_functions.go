@@ -325,6 +325,12 @@ func (a *App) importChannel(rctx request.CTX, data *imports.ChannelImportData, d
 		}
 	}
 
+	if data.Bookmarks != nil {
+		if err := a.importChannelBookmarks(rctx, channel, *data.Bookmarks); err != nil {
+			return err
+		}
+	}
+
 	if data.DeletedAt != nil && *data.DeletedAt > 0 {
 		if err := a.Srv().Store().Channel().Delete(channel.Id, *data.DeletedAt); err != nil {
 			return model.NewAppError("BulkImport", "app.import.import_channel.deleting.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -334,6 +340,65 @@ func (a *App) importChannel(rctx request.CTX, data *imports.ChannelImportData, d
 	return nil
 }
 
+// importChannelBookmarks adds the link bookmarks of a channel. Bookmarks with the same display name
+// and link as an existing one are skipped, so that importing the same data twice doesn't duplicate them.
+func (a *App) importChannelBookmarks(rctx request.CTX, channel *model.Channel, data []imports.ChannelBookmarkImportData) *model.AppError {
+	existing, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, bookmarkData := range data {
+		alreadyExists := false
+		for _, bookmark := range existing {
+			if bookmark.DeleteAt == 0 && bookmark.DisplayName == *bookmarkData.DisplayName && bookmark.LinkUrl == *bookmarkData.LinkURL {
+				alreadyExists = true
+				break
+			}
+		}
+		if alreadyExists {
+			continue
+		}
+
+		user, nErr := a.Srv().Store().User().GetByUsername(*bookmarkData.User)
+		if nErr != nil {
+			return model.NewAppError("BulkImport", "app.import.import_channel.bookmark_user_not_found.error", map[string]any{"Username": *bookmarkData.User}, "", http.StatusBadRequest).Wrap(nErr)
+		}
+
+		bookmark := &model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			OwnerId:     user.Id,
+			DisplayName: *bookmarkData.DisplayName,
+			LinkUrl:     *bookmarkData.LinkURL,
+			Type:        model.ChannelBookmarkLink,
+		}
+		if bookmarkData.Emoji != nil {
+			bookmark.Emoji = *bookmarkData.Emoji
+		}
+		if bookmarkData.CreateAt != nil {
+			bookmark.CreateAt = *bookmarkData.CreateAt
+		}
+
+		saved, nErr := a.Srv().Store().ChannelBookmark().Save(bookmark, true)
+		if nErr != nil {
+			var appErr *model.AppError
+			var limitErr *store.ErrLimitExceeded
+			switch {
+			case errors.As(nErr, &appErr):
+				return appErr
+			case errors.As(nErr, &limitErr):
+				rctx.Logger().Warn("Skipping channel bookmark as the channel has too many bookmarks", mlog.String("channel_id", channel.Id), mlog.String("display_name", bookmark.DisplayName))
+				return nil
+			default:
+				return model.NewAppError("BulkImport", "app.channel.bookmark.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+			}
+		}
+		existing = append(existing, saved)
+	}
+
+	return nil
+}
+
 func (a *App) importUser(rctx request.CTX, data *imports.UserImportData, dryRun bool) *model.AppError {
 	var fields []mlog.Field
 	if data != nil && data.Username != nil {
@@ -399,6 +464,10 @@ func (a *App) importUser(rctx request.CTX, data *imports.UserImportData, dryRun
 	} else if data.Password != nil {
 		password = *data.Password
 		authData = nil
+	} else if user.Id != "" && data.AuthService == nil {
+		// Existing users keep their credentials when the import doesn't specify any.
+		authService = user.AuthService
+		authData = user.AuthData
 	} else {
 		var err error
 		// If no AuthData or Password is specified, we must generate a password.
@@ -996,6 +1065,7 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 		isGuestByTeamID          = map[string]bool{}
 		isUserByTeamId           = map[string]bool{}
 		isAdminByTeamID          = map[string]bool{}
+		deleteAtByTeamID         = map[string]int64{}
 	)
 
 	existingMemberships, nErr := a.Srv().Store().Team().GetTeamsForUser(rctx, user.Id, "", true)
@@ -1009,6 +1079,12 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 	for _, tdata := range *data {
 		team := allTeams[strings.ToLower(*tdata.Name)]
 
+		// Removed memberships are carried over by incremental exports.
+		if tdata.DeleteAt != nil {
+			deleteAtByTeamID[team.Id] = *tdata.DeleteAt
+			continue
+		}
+
 		// Team-specific theme Preferences.
 		if tdata.Theme != nil {
 			teamThemePreferencesByID[team.Id] = append(teamThemePreferencesByID[team.Id], model.Preference{
@@ -1119,6 +1195,13 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 	}
 
 	for _, team := range allTeams {
+		if deleteAt, ok := deleteAtByTeamID[team.Id]; ok {
+			if err := a.removeImportedUserFromTeam(rctx, user, team, existingMembershipsByTeamId[team.Id], deleteAt); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if len(teamThemePreferencesByID[team.Id]) > 0 {
 			pref := teamThemePreferencesByID[team.Id]
 			if err := a.Srv().Store().Preference().Save(pref); err != nil {
@@ -1134,6 +1217,39 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 	return nil
 }
 
+// removeImportedUserFromTeam removes the user from the team and its channels, if
+// the user is still a member.
+func (a *App) removeImportedUserFromTeam(rctx request.CTX, user *model.User, team *model.Team, member *model.TeamMember, deleteAt int64) *model.AppError {
+	if member == nil || member.DeleteAt != 0 {
+		return nil
+	}
+
+	channels, nErr := a.Srv().Store().Channel().GetChannels(team.Id, user.Id, &model.ChannelSearchOpts{
+		IncludeDeleted: true,
+		LastDeleteAt:   0,
+	})
+	var nfErr *store.ErrNotFound
+	if nErr != nil && !errors.As(nErr, &nfErr) {
+		return model.NewAppError("removeImportedUserFromTeam", "app.channel.get_channels.get.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	for _, channel := range channels {
+		if channel.IsGroupOrDirect() {
+			continue
+		}
+		if nErr = a.Srv().Store().Channel().RemoveMember(rctx, channel.Id, user.Id); nErr != nil {
+			return model.NewAppError("removeImportedUserFromTeam", "app.channel.remove_member.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+	}
+
+	member.DeleteAt = deleteAt
+	if _, nErr = a.Srv().Store().Team().UpdateMember(rctx, member); nErr != nil {
+		return model.NewAppError("removeImportedUserFromTeam", "app.team.save_member.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	return nil
+}
+
 func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model.Team, data *[]imports.UserChannelImportData) *model.AppError {
 	if data == nil {
 		return nil
@@ -1158,6 +1274,8 @@ func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model
 		isGuestByChannelId       = map[string]bool{}
 		isUserByChannelId        = map[string]bool{}
 		isAdminByChannelId       = map[string]bool{}
+		leftChannelIDs           = []string{}
+		unfavoritedChannelIDs    = []string{}
 	)
 
 	existingMemberships, nErr := a.Srv().Store().Channel().GetMembersForUser(team.Id, user.Id)
@@ -1178,6 +1296,14 @@ func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model
 			continue
 		}
 
+		// Channels left are carried over by incremental exports.
+		if cdata.DeleteAt != nil {
+			if _, ok = existingMembershipsByChannelId[channel.Id]; ok {
+				leftChannelIDs = append(leftChannelIDs, channel.Id)
+			}
+			continue
+		}
+
 		isGuestByChannelId[channel.Id] = false
 		isUserByChannelId[channel.Id] = true
 		isAdminByChannelId[channel.Id] = false
@@ -1207,6 +1333,8 @@ func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model
 				Name:     channel.Id,
 				Value:    "true",
 			})
+		} else if _, ok = existingMembershipsByChannelId[channel.Id]; ok && cdata.Favorite != nil {
+			unfavoritedChannelIDs = append(unfavoritedChannelIDs, channel.Id)
 		}
 
 		member := &model.ChannelMember{
@@ -1317,6 +1445,18 @@ func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model
 		}
 	}
 
+	for _, channelID := range unfavoritedChannelIDs {
+		if err := a.Srv().Store().Preference().Delete(user.Id, model.PreferenceCategoryFavoriteChannel, channelID); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_user_channels.save_preferences.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	for _, channelID := range leftChannelIDs {
+		if err := a.Srv().Store().Channel().RemoveMember(rctx, channelID, user.Id); err != nil {
+			return model.NewAppError("importUserChannels", "app.channel.remove_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
 	return nil
 }
 
@@ -1337,6 +1477,16 @@ func (a *App) importReaction(data *imports.ReactionImportData, post *model.Post)
 		EmojiName: *data.EmojiName,
 		CreateAt:  *data.CreateAt,
 	}
+
+	// Removed reactions are carried over by incremental exports.
+	if data.DeleteAt != nil {
+		reaction.ChannelId = post.ChannelId
+		if _, nErr = a.Srv().Store().Reaction().Delete(reaction); nErr != nil {
+			return model.NewAppError("importReaction", "app.reaction.delete_all_with_emoji_name.get_reactions.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+		return nil
+	}
+
 	if _, nErr = a.Srv().Store().Reaction().Save(reaction); nErr != nil {
 		var appErr *model.AppError
 		switch {
@@ -1350,6 +1500,64 @@ func (a *App) importReaction(data *imports.ReactionImportData, post *model.Post)
 	return nil
 }
 
+func (a *App) importPoll(data *imports.PollImportData, post *model.Post) *model.AppError {
+	if err := imports.ValidatePollImportData(data); err != nil {
+		return err
+	}
+
+	// Keep the existing poll when the post is imported again, only the votes
+	// are overwritten.
+	if _, nErr := a.Srv().Store().Poll().Get(post.Id); nErr != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(nErr, &nfErr) {
+			return model.NewAppError("importPoll", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+
+		poll := &model.Poll{
+			PostId:   post.Id,
+			UserId:   post.UserId,
+			Options:  *data.Options,
+			CreateAt: post.CreateAt,
+		}
+		if data.Anonymous != nil {
+			poll.Anonymous = *data.Anonymous
+		}
+		if data.MultipleChoice != nil {
+			poll.MultipleChoice = *data.MultipleChoice
+		}
+		if data.CloseAt != nil {
+			poll.CloseAt = *data.CloseAt
+		}
+
+		if _, nErr = a.Srv().Store().Poll().Save(poll); nErr != nil {
+			var appErr *model.AppError
+			switch {
+			case errors.As(nErr, &appErr):
+				return appErr
+			default:
+				return model.NewAppError("importPoll", "app.poll.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+			}
+		}
+	}
+
+	if data.Votes == nil {
+		return nil
+	}
+
+	for _, vote := range *data.Votes {
+		user, nErr := a.Srv().Store().User().GetByUsername(*vote.User)
+		if nErr != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]any{"Username": *vote.User}, "", http.StatusBadRequest).Wrap(nErr)
+		}
+
+		if nErr := a.Srv().Store().Poll().Vote(post.Id, user.Id, *vote.Options); nErr != nil {
+			return model.NewAppError("importPoll", "app.poll.vote.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, post *model.Post, teamID string, extractContent bool) *model.AppError {
 	var err *model.AppError
 	usernames := []string{}
@@ -1400,6 +1608,10 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 			}
 		}
 
+		if reply == nil && replyData.EditAt != nil && *replyData.EditAt > 0 {
+			reply = findEditedPost(replies, user.Id, post.Id, replyData.PreviousMessage)
+		}
+
 		if reply == nil {
 			reply = &model.Post{}
 		}
@@ -1421,6 +1633,9 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 		if replyData.EditAt != nil {
 			reply.EditAt = *replyData.EditAt
 		}
+		if replyData.DeleteAt != nil {
+			reply.DeleteAt = *replyData.DeleteAt
+		}
 		if replyData.IsPinned != nil {
 			reply.IsPinned = *replyData.IsPinned
 		}
@@ -1493,6 +1708,12 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 	for _, postWithData := range postsWithData {
 		a.updateFileInfoWithPostId(rctx, postWithData.post)
 
+		if postWithData.replyData.Poll != nil {
+			if err := a.importPoll(postWithData.replyData.Poll, postWithData.post); err != nil {
+				return err
+			}
+		}
+
 		if postWithData.replyData.FlaggedBy != nil {
 			var preferences model.Preferences
 
@@ -1756,6 +1977,33 @@ func getPostStrID(post *model.Post) string {
 	return fmt.Sprintf("%d%s%s", post.CreateAt, post.ChannelId, post.Message)
 }
 
+// findEditedPost returns the post an edited post of the import replaces among the posts
+// created at the same time. An edited post no longer has the message it was imported with
+// before, so it is matched on its author, thread and previous message. When the post was
+// edited on this server too, the previous message is found in its edit history, which points
+// to the post with its OriginalId.
+func findEditedPost(posts []*model.Post, userID, rootID string, previousMessage *string) *model.Post {
+	if previousMessage == nil {
+		return nil
+	}
+
+	for _, p := range posts {
+		if p.UserId != userID || p.RootId != rootID || p.Message != *previousMessage {
+			continue
+		}
+		if p.OriginalId == "" {
+			return p
+		}
+		for _, edited := range posts {
+			if edited.Id == p.OriginalId {
+				return edited
+			}
+		}
+	}
+
+	return nil
+}
+
 // importMultiplePostLines will return an error and the line that
 // caused it whenever possible
 func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImportWorkerData, dryRun, extractContent bool) (int, *model.AppError) {
@@ -1834,6 +2082,10 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		if post == nil && line.Post.EditAt != nil && *line.Post.EditAt > 0 {
+			post = findEditedPost(posts, user.Id, "", line.Post.PreviousMessage)
+		}
+
 		if post == nil {
 			post = &model.Post{}
 		}
@@ -1850,6 +2102,9 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 		if line.Post.EditAt != nil {
 			post.EditAt = *line.Post.EditAt
 		}
+		if line.Post.DeleteAt != nil {
+			post.DeleteAt = *line.Post.DeleteAt
+		}
 		if line.Post.Props != nil {
 			post.Props = *line.Post.Props
 		}
@@ -1997,6 +2252,12 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		if postWithData.postData.Poll != nil {
+			if err := a.importPoll(postWithData.postData.Poll, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		if postWithData.postData.Replies != nil && len(*postWithData.postData.Replies) > 0 {
 			err := a.importReplies(rctx, *postWithData.postData.Replies, postWithData.post, postWithData.team.Id, extractContent)
 			if err != nil {
@@ -2354,6 +2615,10 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		if post == nil && line.DirectPost.EditAt != nil && *line.DirectPost.EditAt > 0 {
+			post = findEditedPost(posts, user.Id, "", line.DirectPost.PreviousMessage)
+		}
+
 		if post == nil {
 			post = &model.Post{}
 		}
@@ -2370,6 +2635,9 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 		if line.DirectPost.EditAt != nil {
 			post.EditAt = *line.DirectPost.EditAt
 		}
+		if line.DirectPost.DeleteAt != nil {
+			post.DeleteAt = *line.DirectPost.DeleteAt
+		}
 		if line.DirectPost.Props != nil {
 			post.Props = *line.DirectPost.Props
 		}
@@ -2504,6 +2772,12 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		if postWithData.directPostData.Poll != nil {
+			if err := a.importPoll(postWithData.directPostData.Poll, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		if postWithData.directPostData.Replies != nil {
 			if err := a.importReplies(rctx, *postWithData.directPostData.Replies, postWithData.post, "noteam", extractContent); err != nil {
 				return postWithData.lineNumber, err
@@ -2582,6 +2856,83 @@ func (a *App) importEmoji(rctx request.CTX, data *imports.EmojiImportData, dryRu
 	return nil
 }
 
+func (a *App) importGroup(rctx request.CTX, data *imports.GroupImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Name != nil {
+		fields = append(fields, mlog.String("group_name", *data.Name))
+	}
+	rctx.Logger().Info("Validating group", fields...)
+
+	if err := imports.ValidateGroupImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing group", fields...)
+
+	var userIDs []string
+	if data.Members != nil && len(*data.Members) > 0 {
+		users, err := a.getUsersByUsernames(*data.Members)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			userIDs = append(userIDs, user.Id)
+		}
+	}
+
+	description := ""
+	if data.Description != nil {
+		description = *data.Description
+	}
+
+	group, appErr := a.GetGroupByName(*data.Name, model.GroupSearchOpts{})
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return appErr
+	}
+
+	if group == nil {
+		newGroup := &model.GroupWithUserIds{
+			Group: model.Group{
+				Name:           data.Name,
+				DisplayName:    *data.DisplayName,
+				Description:    description,
+				Source:         model.GroupSourceCustom,
+				AllowReference: true,
+			},
+			UserIds: userIDs,
+		}
+		if _, appErr = a.CreateGroupWithUserIds(newGroup); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+
+	if group.Source != model.GroupSourceCustom {
+		return model.NewAppError("BulkImport", "app.import.import_group.not_custom.error", map[string]any{"GroupName": *data.Name}, "", http.StatusBadRequest)
+	}
+
+	if group.DisplayName != *data.DisplayName || group.Description != description {
+		group.DisplayName = *data.DisplayName
+		group.Description = description
+		if _, appErr = a.UpdateGroup(group); appErr != nil {
+			return appErr
+		}
+	}
+
+	if len(userIDs) > 0 {
+		if _, appErr = a.UpsertGroupMembers(group.Id, userIDs); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
 func (a *App) extractThreadMembers(line *imports.LineImportWorkerData, users map[string]*model.User, post *model.Post) ([]*model.ThreadMembership, int, *model.AppError) {
 	threadMemberships := []*model.ThreadMembership{}
 
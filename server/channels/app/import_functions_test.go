@@ -1739,6 +1739,28 @@ func TestImportImportUser(t *testing.T) {
 	})
 }
 
+func TestImportUserKeepsExistingCredentials(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user, err := th.App.Srv().Store().User().Get(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.NotEmpty(t, user.Password)
+
+	data := imports.UserImportData{
+		Username: &user.Username,
+		Email:    &user.Email,
+		Locale:   &user.Locale,
+	}
+	appErr := th.App.importUser(th.Context, &data, false)
+	require.Nil(t, appErr)
+
+	updated, err := th.App.Srv().Store().User().Get(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Equal(t, user.Password, updated.Password, "Password should not have changed")
+	assert.Equal(t, user.LastPasswordUpdate, updated.LastPasswordUpdate)
+}
+
 func TestImportUserTeams(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -3191,6 +3213,34 @@ func TestImportimportMultiplePostLines(t *testing.T) {
 	})
 }
 
+func TestFindEditedPost(t *testing.T) {
+	userID := model.NewId()
+	rootID := model.NewId()
+
+	post := &model.Post{Id: model.NewId(), UserId: userID, RootId: rootID, Message: "first"}
+	other := &model.Post{Id: model.NewId(), UserId: model.NewId(), RootId: rootID, Message: "first"}
+	reply := &model.Post{Id: model.NewId(), UserId: userID, RootId: model.NewId(), Message: "first"}
+	posts := []*model.Post{other, reply, post}
+
+	t.Run("without a previous message", func(t *testing.T) {
+		assert.Nil(t, findEditedPost(posts, userID, rootID, nil))
+	})
+
+	t.Run("matches the author, thread and previous message", func(t *testing.T) {
+		assert.Equal(t, post, findEditedPost(posts, userID, rootID, model.NewPointer("first")))
+		assert.Nil(t, findEditedPost(posts, userID, rootID, model.NewPointer("second")))
+		assert.Nil(t, findEditedPost(posts, userID, "", model.NewPointer("first")))
+	})
+
+	t.Run("follows the edit history to the post", func(t *testing.T) {
+		edited := &model.Post{Id: post.Id, UserId: userID, RootId: rootID, Message: "edited here"}
+		history := &model.Post{Id: model.NewId(), UserId: userID, RootId: rootID, Message: "first", OriginalId: post.Id, DeleteAt: 1}
+
+		assert.Equal(t, edited, findEditedPost([]*model.Post{history, edited}, userID, rootID, model.NewPointer("first")))
+		assert.Nil(t, findEditedPost([]*model.Post{history}, userID, rootID, model.NewPointer("first")))
+	})
+}
+
 func TestImportImportPost(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -5164,6 +5214,131 @@ func TestImportImportEmoji(t *testing.T) {
 	require.ErrorIs(t, appErr.Unwrap(), utils.ErrSizeLimitExceeded)
 }
 
+func TestImportImportChannelBookmarks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	chanOpen := model.ChannelTypeOpen
+	data := imports.ChannelImportData{
+		Team:        &th.BasicTeam.Name,
+		Name:        model.NewPointer("bookmarked"),
+		DisplayName: model.NewPointer("Bookmarked"),
+		Type:        &chanOpen,
+		Bookmarks: &[]imports.ChannelBookmarkImportData{
+			{
+				User:        &th.BasicUser.Username,
+				DisplayName: model.NewPointer("Docs"),
+				LinkURL:     model.NewPointer("https://example.com/docs"),
+				Emoji:       model.NewPointer("books"),
+			},
+			{
+				User:        &th.BasicUser2.Username,
+				DisplayName: model.NewPointer("Roadmap"),
+				LinkURL:     model.NewPointer("https://example.com/roadmap"),
+				CreateAt:    model.NewPointer(int64(12345)),
+			},
+		},
+	}
+
+	appErr := th.App.importChannel(th.Context, &data, false)
+	require.Nil(t, appErr)
+
+	channel, appErr := th.App.GetChannelByName(th.Context, "bookmarked", th.BasicTeam.Id, false)
+	require.Nil(t, appErr)
+
+	bookmarks, appErr := th.App.GetChannelBookmarks(channel.Id, 0)
+	require.Nil(t, appErr)
+	require.Len(t, bookmarks, 2)
+
+	byName := map[string]*model.ChannelBookmarkWithFileInfo{}
+	for _, bookmark := range bookmarks {
+		byName[bookmark.DisplayName] = bookmark
+	}
+	require.Contains(t, byName, "Docs")
+	assert.Equal(t, model.ChannelBookmarkLink, byName["Docs"].Type)
+	assert.Equal(t, "https://example.com/docs", byName["Docs"].LinkUrl)
+	assert.Equal(t, "books", byName["Docs"].Emoji)
+	assert.Equal(t, th.BasicUser.Id, byName["Docs"].OwnerId)
+	require.Contains(t, byName, "Roadmap")
+	assert.Equal(t, int64(12345), byName["Roadmap"].CreateAt)
+	assert.Equal(t, th.BasicUser2.Id, byName["Roadmap"].OwnerId)
+
+	t.Run("importing again doesn't duplicate bookmarks", func(t *testing.T) {
+		appErr = th.App.importChannel(th.Context, &data, false)
+		require.Nil(t, appErr)
+
+		bookmarks, appErr = th.App.GetChannelBookmarks(channel.Id, 0)
+		require.Nil(t, appErr)
+		require.Len(t, bookmarks, 2)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		data.Bookmarks = &[]imports.ChannelBookmarkImportData{{
+			User:        model.NewPointer(model.NewUsername()),
+			DisplayName: model.NewPointer("Other"),
+			LinkURL:     model.NewPointer("https://example.com/other"),
+		}}
+		appErr = th.App.importChannel(th.Context, &data, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.import_channel.bookmark_user_not_found.error", appErr.Id)
+	})
+}
+
+func TestImportImportGroup(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	groupName := "group" + model.NewId()[:10]
+	data := imports.GroupImportData{
+		Name:        &groupName,
+		DisplayName: model.NewPointer("Imported Group"),
+		Description: model.NewPointer("Imported from Slack"),
+		Members:     &[]string{th.BasicUser.Username},
+	}
+
+	appErr := th.App.importGroup(th.Context, &imports.GroupImportData{}, true)
+	require.NotNil(t, appErr, "Invalid group should have failed dry run")
+
+	appErr = th.App.importGroup(th.Context, &data, true)
+	require.Nil(t, appErr, "Valid group should have passed dry run")
+
+	_, appErr = th.App.GetGroupByName(groupName, model.GroupSearchOpts{})
+	require.NotNil(t, appErr, "Group should not have been imported in dry run")
+
+	appErr = th.App.importGroup(th.Context, &data, false)
+	require.Nil(t, appErr)
+
+	group, appErr := th.App.GetGroupByName(groupName, model.GroupSearchOpts{})
+	require.Nil(t, appErr)
+	assert.Equal(t, model.GroupSourceCustom, group.Source)
+	assert.True(t, group.AllowReference)
+	assert.Equal(t, "Imported Group", group.DisplayName)
+	assert.Equal(t, "Imported from Slack", group.Description)
+
+	members, _, appErr := th.App.GetGroupMemberUsersPage(group.Id, 0, 10, nil)
+	require.Nil(t, appErr)
+	require.Len(t, members, 1)
+	assert.Equal(t, th.BasicUser.Id, members[0].Id)
+
+	// Importing again updates the group and adds the new members.
+	data.DisplayName = model.NewPointer("Renamed Group")
+	data.Members = &[]string{th.BasicUser.Username, th.BasicUser2.Username}
+	appErr = th.App.importGroup(th.Context, &data, false)
+	require.Nil(t, appErr)
+
+	group, appErr = th.App.GetGroupByName(groupName, model.GroupSearchOpts{})
+	require.Nil(t, appErr)
+	assert.Equal(t, "Renamed Group", group.DisplayName)
+
+	members, _, appErr = th.App.GetGroupMemberUsersPage(group.Id, 0, 10, nil)
+	require.Nil(t, appErr)
+	require.Len(t, members, 2)
+
+	data.Members = &[]string{model.NewUsername()}
+	appErr = th.App.importGroup(th.Context, &data, false)
+	require.NotNil(t, appErr, "Unknown members should fail the import")
+}
+
 func TestImportAttachment(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -231,6 +231,73 @@ func TestImportBulkImport(t *testing.T) {
 	})
 }
 
+func TestImportBulkImportWithOpts(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	teamName := model.NewRandomTeamName()
+	channelName := model.NewId()
+	username := model.NewUsername()
+
+	data := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "lskmw2d7a5ao7ppwqh5ljchvr4", "name": "` + teamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "xr6m6udffngark2uekvr3hoeny", "team": "` + teamName + `", "name": "` + channelName + `"}}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username + `@example.com", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + channelName + `"}]}]}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Hello World", "create_at": 123456789012}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Missing create_at"}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Hello again", "create_at": 123456789013}}
+not json`
+
+	t.Run("abort on error", func(t *testing.T) {
+		var checkpoints []int
+		err, line := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, imports.BulkImportOpts{
+			Workers:         2,
+			CheckpointLines: 2,
+			Checkpoint: func(lineNumber int, skipped []imports.SkippedLine) *model.AppError {
+				checkpoints = append(checkpoints, lineNumber)
+				assert.Empty(t, skipped)
+				return nil
+			},
+		})
+		require.NotNil(t, err)
+		assert.Equal(t, 6, line)
+		assert.Equal(t, []int{2, 4}, checkpoints)
+	})
+
+	t.Run("skip errors and resume", func(t *testing.T) {
+		var checkpoints []int
+		var skipped []imports.SkippedLine
+		err, line := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, imports.BulkImportOpts{
+			Workers:         2,
+			StartLine:       4,
+			SkipErrors:      true,
+			CheckpointLines: 2,
+			Checkpoint: func(lineNumber int, lines []imports.SkippedLine) *model.AppError {
+				checkpoints = append(checkpoints, lineNumber)
+				skipped = append(skipped, lines...)
+				return nil
+			},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 0, line)
+		assert.Equal(t, []int{6, 8}, checkpoints)
+
+		require.Len(t, skipped, 2)
+		assert.Equal(t, 6, skipped[0].LineNumber)
+		assert.Contains(t, string(skipped[0].Line), "Missing create_at")
+		assert.Equal(t, 8, skipped[1].LineNumber)
+		assert.Equal(t, `"not json"`, string(skipped[1].Line))
+
+		team, nErr := th.App.Srv().Store().Team().GetByName(teamName)
+		require.NoError(t, nErr)
+		channel, nErr := th.App.Srv().Store().Channel().GetByName(team.Id, channelName, false)
+		require.NoError(t, nErr)
+		posts, nErr := th.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, 123456789013)
+		require.NoError(t, nErr)
+		assert.Len(t, posts, 1)
+	})
+}
+
 func TestImportProcessImportDataFileVersionLine(t *testing.T) {
 	data := imports.LineImportData{
 		Type:    "version",
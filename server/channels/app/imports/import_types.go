@@ -24,6 +24,7 @@ type LineImportData struct {
 	DirectChannel *DirectChannelImportData `json:"direct_channel,omitempty"`
 	DirectPost    *DirectPostImportData    `json:"direct_post,omitempty"`
 	Emoji         *EmojiImportData         `json:"emoji,omitempty"`
+	Group         *GroupImportData         `json:"group,omitempty"`
 	Version       *int                     `json:"version,omitempty"`
 	Info          *VersionInfoImportData   `json:"info,omitempty"`
 }
@@ -33,6 +34,11 @@ type VersionInfoImportData struct {
 	Version    string          `json:"version"`
 	Created    string          `json:"created"`
 	Additional json.RawMessage `json:"additional,omitempty"`
+
+	// Since is the checkpoint an incremental export continues from, and
+	// Checkpoint the one the next incremental export can continue from.
+	Since      int64 `json:"since,omitempty"`
+	Checkpoint int64 `json:"checkpoint,omitempty"`
 }
 
 type TeamImportData struct {
@@ -53,6 +59,16 @@ type ChannelImportData struct {
 	Purpose     *string            `json:"purpose,omitempty"`
 	Scheme      *string            `json:"scheme,omitempty"`
 	DeletedAt   *int64             `json:"deleted_at,omitempty"`
+
+	Bookmarks *[]ChannelBookmarkImportData `json:"bookmarks,omitempty"`
+}
+
+type ChannelBookmarkImportData struct {
+	User        *string `json:"user"`
+	DisplayName *string `json:"display_name"`
+	LinkURL     *string `json:"link_url"`
+	Emoji       *string `json:"emoji,omitempty"`
+	CreateAt    *int64  `json:"create_at,omitempty"`
 }
 
 type Avatar struct {
@@ -130,6 +146,7 @@ type UserTeamImportData struct {
 	Roles    *string                  `json:"roles"`
 	Theme    *string                  `json:"theme,omitempty"`
 	Channels *[]UserChannelImportData `json:"channels,omitempty"`
+	DeleteAt *int64                   `json:"delete_at,omitempty"`
 }
 
 type UserChannelImportData struct {
@@ -143,6 +160,7 @@ type UserChannelImportData struct {
 	MsgCount           *int64                            `json:"msg_count,omitempty"`
 	MsgCountRoot       *int64                            `json:"msg_count_root,omitempty"`
 	LastViewedAt       *int64                            `json:"last_viewed_at,omitempty"`
+	DeleteAt           *int64                            `json:"delete_at,omitempty"`
 }
 
 type DirectChannelMemberImportData struct {
@@ -174,10 +192,31 @@ type EmojiImportData struct {
 	Data  *zip.File `json:"-"`
 }
 
+type GroupImportData struct {
+	Name        *string   `json:"name"`
+	DisplayName *string   `json:"display_name"`
+	Description *string   `json:"description,omitempty"`
+	Members     *[]string `json:"members,omitempty"`
+}
+
 type ReactionImportData struct {
 	User      *string `json:"user"`
 	CreateAt  *int64  `json:"create_at"`
 	EmojiName *string `json:"emoji_name"`
+	DeleteAt  *int64  `json:"delete_at,omitempty"`
+}
+
+type PollImportData struct {
+	Options        *[]string             `json:"options"`
+	Anonymous      *bool                 `json:"anonymous,omitempty"`
+	MultipleChoice *bool                 `json:"multiple_choice,omitempty"`
+	CloseAt        *int64                `json:"close_at,omitempty"`
+	Votes          *[]PollVoteImportData `json:"votes,omitempty"`
+}
+
+type PollVoteImportData struct {
+	User    *string `json:"user"`
+	Options *[]int  `json:"options"`
 }
 
 type ReplyImportData struct {
@@ -188,11 +227,17 @@ type ReplyImportData struct {
 	Props    *model.StringInterface `json:"props"`
 	CreateAt *int64                 `json:"create_at"`
 	EditAt   *int64                 `json:"edit_at"`
+	DeleteAt *int64                 `json:"delete_at,omitempty"`
+
+	// PreviousMessage is the message an edited post had when the previous export was taken,
+	// or its original message, so that the post is found again once its message changed.
+	PreviousMessage *string `json:"previous_message,omitempty"`
 
 	FlaggedBy   *[]string               `json:"flagged_by,omitempty"`
 	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 }
 
 type PostImportData struct {
@@ -205,12 +250,18 @@ type PostImportData struct {
 	Props    *model.StringInterface `json:"props"`
 	CreateAt *int64                 `json:"create_at"`
 	EditAt   *int64                 `json:"edit_at"`
+	DeleteAt *int64                 `json:"delete_at,omitempty"`
+
+	// PreviousMessage is the message an edited post had when the previous export was taken,
+	// or its original message, so that the post is found again once its message changed.
+	PreviousMessage *string `json:"previous_message,omitempty"`
 
 	FlaggedBy   *[]string               `json:"flagged_by,omitempty"`
 	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
 	Replies     *[]ReplyImportData      `json:"replies,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
 }
@@ -233,12 +284,18 @@ type DirectPostImportData struct {
 	Props    *model.StringInterface `json:"props"`
 	CreateAt *int64                 `json:"create_at"`
 	EditAt   *int64                 `json:"edit_at"`
+	DeleteAt *int64                 `json:"delete_at,omitempty"`
+
+	// PreviousMessage is the message an edited post had when the previous export was taken,
+	// or its original message, so that the post is found again once its message changed.
+	PreviousMessage *string `json:"previous_message,omitempty"`
 
 	FlaggedBy   *[]string               `json:"flagged_by,omitempty"`
 	Reactions   *[]ReactionImportData   `json:"reactions"`
 	Replies     *[]ReplyImportData      `json:"replies"`
 	Attachments *[]AttachmentImportData `json:"attachments"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
 }
@@ -267,6 +324,8 @@ type RoleImportData struct {
 type LineImportWorkerData struct {
 	LineImportData
 	LineNumber int
+	// Raw is the line as read from the import file, only kept when the import skips errors.
+	Raw json.RawMessage
 }
 
 type LineImportWorkerError struct {
@@ -274,6 +333,35 @@ type LineImportWorkerError struct {
 	LineNumber int
 }
 
+// BulkImportOpts configures a bulk import.
+type BulkImportOpts struct {
+	DryRun         bool
+	ExtractContent bool
+	Workers        int
+	ImportPath     string
+
+	// StartLine is the last line committed by a previous run of the same import. The lines up
+	// to it are not imported again.
+	StartLine int
+	// SkipErrors makes the import skip the lines that fail instead of aborting. The skipped
+	// lines are passed to Checkpoint.
+	SkipErrors bool
+	// CheckpointLines is the number of lines between two checkpoints. Zero means the default
+	// interval.
+	CheckpointLines int
+	// Checkpoint, if set, is called every CheckpointLines lines once all the lines up to
+	// lineNumber are committed, and at the end of the import, with the lines skipped since the
+	// previous checkpoint. An error aborts the import.
+	Checkpoint func(lineNumber int, skipped []SkippedLine) *model.AppError
+}
+
+// SkippedLine is a line of an import that failed and was skipped.
+type SkippedLine struct {
+	LineNumber int             `json:"line_number"`
+	Error      string          `json:"error"`
+	Line       json.RawMessage `json:"line"`
+}
+
 type AttachmentImportData struct {
 	Path *string   `json:"path"`
 	Data *zip.File `json:"-"`
@@ -291,3 +379,45 @@ type ThreadFollowerImportData struct {
 	LastViewed     *int64  `json:"last_viewed,omitempty"`
 	UnreadMentions *int64  `json:"unread_mentions,omitempty"`
 }
+
+const (
+	DryRunConflictEmailCollision = "email_collision"
+	DryRunConflictUsernameRemap  = "username_remap"
+	DryRunConflictArchivedTarget = "archived_target"
+	DryRunConflictMissingTarget  = "missing_target"
+)
+
+// DryRunReport describes what an import would change on the server.
+type DryRunReport struct {
+	Teams          DryRunCounts     `json:"teams"`
+	Channels       DryRunCounts     `json:"channels"`
+	Users          DryRunCounts     `json:"users"`
+	Posts          DryRunCounts     `json:"posts"`
+	DirectChannels DryRunCounts     `json:"direct_channels"`
+	DirectPosts    DryRunCounts     `json:"direct_posts"`
+	Conflicts      []DryRunConflict `json:"conflicts"`
+	Errors         []DryRunError    `json:"errors"`
+}
+
+// DryRunCounts counts the objects an import would create, update, or skip because they are
+// unchanged or can't be imported.
+type DryRunCounts struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Skip   int `json:"skip"`
+}
+
+// DryRunConflict is a line of an import that would fail, or that targets existing data in a
+// way that may not be intended.
+type DryRunConflict struct {
+	LineNumber int    `json:"line_number"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Detail     string `json:"detail"`
+}
+
+// DryRunError is a line of an import that doesn't pass validation.
+type DryRunError struct {
+	LineNumber int    `json:"line_number"`
+	Error      string `json:"error"`
+}
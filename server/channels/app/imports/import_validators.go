@@ -186,6 +186,39 @@ func ValidateChannelImportData(data *ChannelImportData) *model.AppError {
 		return model.NewAppError("BulkImport", "app.import.validate_channel_import_data.scheme_invalid.error", nil, "", http.StatusBadRequest)
 	}
 
+	if data.Bookmarks != nil {
+		if len(*data.Bookmarks) > model.MaxBookmarksPerChannel {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_import_data.bookmarks_too_many.error", map[string]any{"Max": model.MaxBookmarksPerChannel}, "", http.StatusBadRequest)
+		}
+		for i := range *data.Bookmarks {
+			if err := ValidateChannelBookmarkImportData(&(*data.Bookmarks)[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func ValidateChannelBookmarkImportData(data *ChannelBookmarkImportData) *model.AppError {
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || utf8.RuneCountInString(*data.DisplayName) == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+	} else if utf8.RuneCountInString(*data.DisplayName) > model.DisplayNameMaxRunes {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.LinkURL == nil || !model.IsValidHTTPURL(*data.LinkURL) || utf8.RuneCountInString(*data.LinkURL) > model.LinkMaxRunes {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.link_url_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.CreateAt != nil && *data.CreateAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.create_at_zero.error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -455,6 +488,38 @@ func ValidateReactionImportData(data *ReactionImportData, parentCreateAt int64)
 	return nil
 }
 
+func ValidatePollImportData(data *PollImportData) *model.AppError {
+	if data.Options == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.options_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	poll := &model.Poll{
+		PostId:         model.NewId(),
+		UserId:         model.NewId(),
+		Options:        *data.Options,
+		MultipleChoice: data.MultipleChoice != nil && *data.MultipleChoice,
+	}
+	if data.CloseAt != nil {
+		poll.CloseAt = *data.CloseAt
+	}
+	if err := poll.IsValid(); err != nil {
+		return err
+	}
+
+	if data.Votes != nil {
+		for _, vote := range *data.Votes {
+			if vote.User == nil {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_user_missing.error", nil, "", http.StatusBadRequest)
+			}
+			if vote.Options == nil || !poll.IsValidVote(*vote.Options) {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_options.error", nil, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	return nil
+}
+
 func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPostSize int) *model.AppError {
 	if data.User == nil {
 		return model.NewAppError("BulkImport", "app.import.validate_reply_import_data.user_missing.error", nil, "", http.StatusBadRequest)
@@ -487,6 +552,12 @@ func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPos
 		}
 	}
 
+	if data.Poll != nil {
+		if err := ValidatePollImportData(data.Poll); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -524,6 +595,12 @@ func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		}
 	}
 
+	if data.Poll != nil {
+		if err := ValidatePollImportData(data.Poll); err != nil {
+			return err
+		}
+	}
+
 	if data.Replies != nil {
 		for _, reply := range *data.Replies {
 			reply := reply
@@ -644,6 +721,12 @@ func ValidateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
+	if data.Poll != nil {
+		if err := ValidatePollImportData(data.Poll); err != nil {
+			return err
+		}
+	}
+
 	if data.Replies != nil {
 		for _, reply := range *data.Replies {
 			reply := reply
@@ -678,6 +761,43 @@ func ValidateEmojiImportData(data *EmojiImportData) *model.AppError {
 	return nil
 }
 
+func ValidateGroupImportData(data *GroupImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_group_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Name == nil || *data.Name == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_group_import_data.name_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || *data.DisplayName == "" {
+		data.DisplayName = data.Name
+	}
+
+	group := model.Group{
+		Name:           data.Name,
+		DisplayName:    *data.DisplayName,
+		Source:         model.GroupSourceCustom,
+		AllowReference: true,
+	}
+	if data.Description != nil {
+		group.Description = *data.Description
+	}
+	if err := group.IsValidForCreate(); err != nil {
+		return err
+	}
+
+	if data.Members != nil {
+		for _, member := range *data.Members {
+			if !model.IsValidUsername(model.NormalizeUsername(member)) {
+				return model.NewAppError("BulkImport", "app.import.validate_group_import_data.member_invalid.error", map[string]any{"Username": member}, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	return nil
+}
+
 func isValidTrueOrFalseString(value string) bool {
 	return value == "true" || value == "false"
 }
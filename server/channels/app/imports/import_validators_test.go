@@ -464,6 +464,73 @@ func TestImportValidateChannelImportData(t *testing.T) {
 	data.Scheme = model.NewPointer("abcdefg")
 	err = ValidateChannelImportData(&data)
 	require.Nil(t, err, "Should have succeeded with valid scheme name.")
+
+	// Test with bookmarks.
+	data.Bookmarks = &[]ChannelBookmarkImportData{{
+		User:        model.NewPointer("username"),
+		DisplayName: model.NewPointer("Docs"),
+		LinkURL:     model.NewPointer("https://example.com/docs"),
+	}}
+	err = ValidateChannelImportData(&data)
+	require.Nil(t, err, "Should have succeeded with a valid bookmark.")
+
+	(*data.Bookmarks)[0].LinkURL = model.NewPointer("not a url")
+	err = ValidateChannelImportData(&data)
+	require.NotNil(t, err, "Should have failed due to an invalid bookmark.")
+
+	bookmarks := make([]ChannelBookmarkImportData, model.MaxBookmarksPerChannel+1)
+	for i := range bookmarks {
+		bookmarks[i] = ChannelBookmarkImportData{
+			User:        model.NewPointer("username"),
+			DisplayName: model.NewPointer("Docs"),
+			LinkURL:     model.NewPointer("https://example.com/docs"),
+		}
+	}
+	data.Bookmarks = &bookmarks
+	err = ValidateChannelImportData(&data)
+	require.NotNil(t, err, "Should have failed due to too many bookmarks.")
+}
+
+func TestImportValidateChannelBookmarkImportData(t *testing.T) {
+	data := ChannelBookmarkImportData{
+		User:        model.NewPointer("username"),
+		DisplayName: model.NewPointer("Docs"),
+		LinkURL:     model.NewPointer("https://example.com/docs"),
+	}
+	err := ValidateChannelBookmarkImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	data.Emoji = model.NewPointer("books")
+	data.CreateAt = model.NewPointer(model.GetMillis())
+	err = ValidateChannelBookmarkImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	data.User = nil
+	err = ValidateChannelBookmarkImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing user.")
+
+	data.User = model.NewPointer("username")
+	data.DisplayName = model.NewPointer("")
+	err = ValidateChannelBookmarkImportData(&data)
+	require.NotNil(t, err, "Should have failed due to empty display name.")
+
+	data.DisplayName = model.NewPointer(strings.Repeat("abcdefghij", 7))
+	err = ValidateChannelBookmarkImportData(&data)
+	require.NotNil(t, err, "Should have failed due to too long display name.")
+
+	data.DisplayName = model.NewPointer("Docs")
+	data.LinkURL = nil
+	err = ValidateChannelBookmarkImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing link.")
+
+	data.LinkURL = model.NewPointer("ftp://example.com/docs")
+	err = ValidateChannelBookmarkImportData(&data)
+	require.NotNil(t, err, "Should have failed due to a link that isn't http.")
+
+	data.LinkURL = model.NewPointer("https://example.com/docs")
+	data.CreateAt = model.NewPointer(int64(0))
+	err = ValidateChannelBookmarkImportData(&data)
+	require.NotNil(t, err, "Should have failed due to zero create_at.")
 }
 
 func TestImportValidateUserImportData(t *testing.T) {
@@ -832,6 +899,47 @@ func TestImportValidateUserChannelsImportData(t *testing.T) {
 	require.Nil(t, err, "Should have succeeded with valid notify props.")
 }
 
+func TestImportValidatePollImportData(t *testing.T) {
+	data := PollImportData{
+		Options:        &[]string{"Pizza", "Sushi"},
+		MultipleChoice: model.NewPointer(true),
+		Votes: &[]PollVoteImportData{
+			{User: model.NewPointer("username"), Options: &[]int{0, 1}},
+		},
+	}
+	err := ValidatePollImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	data = PollImportData{}
+	err = ValidatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing options.")
+
+	data = PollImportData{Options: &[]string{"Pizza"}}
+	err = ValidatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to too few options.")
+
+	data = PollImportData{
+		Options: &[]string{"Pizza", "Sushi"},
+		Votes:   &[]PollVoteImportData{{Options: &[]int{0}}},
+	}
+	err = ValidatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing vote user.")
+
+	data = PollImportData{
+		Options: &[]string{"Pizza", "Sushi"},
+		Votes:   &[]PollVoteImportData{{User: model.NewPointer("username"), Options: &[]int{0, 1}}},
+	}
+	err = ValidatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to several options in a single choice poll.")
+
+	data = PollImportData{
+		Options: &[]string{"Pizza", "Sushi"},
+		Votes:   &[]PollVoteImportData{{User: model.NewPointer("username"), Options: &[]int{2}}},
+	}
+	err = ValidatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to unknown option.")
+}
+
 func TestImportValidateReactionImportData(t *testing.T) {
 	// Test with minimum required valid properties.
 	parentCreateAt := model.GetMillis() - 100
@@ -1480,6 +1588,48 @@ func checkNoError(t *testing.T, err *model.AppError) {
 	require.Nil(t, err, "Unexpected Error: %v", err)
 }
 
+func TestImportValidateGroupImportData(t *testing.T) {
+	var data *GroupImportData
+	err := ValidateGroupImportData(data)
+	require.NotNil(t, err, "Should have failed due to empty data.")
+
+	data = &GroupImportData{
+		Name:        model.NewPointer("engineering"),
+		DisplayName: model.NewPointer("Engineering"),
+		Description: model.NewPointer("The engineering team"),
+		Members:     &[]string{"alice", "bob"},
+	}
+	err = ValidateGroupImportData(data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	data.DisplayName = nil
+	err = ValidateGroupImportData(data)
+	require.Nil(t, err, "Should have accepted a missing display name.")
+	require.Equal(t, data.Name, data.DisplayName, "Name and DisplayName should be the same if DisplayName is missing")
+
+	data.Name = nil
+	err = ValidateGroupImportData(data)
+	require.NotNil(t, err, "Should have failed due to missing name.")
+
+	data.Name = model.NewPointer("Not A Valid Name")
+	err = ValidateGroupImportData(data)
+	require.NotNil(t, err, "Should have failed due to invalid characters in name.")
+
+	data.Name = model.NewPointer(model.UserNotifyAll)
+	err = ValidateGroupImportData(data)
+	require.NotNil(t, err, "Should have failed due to reserved name.")
+
+	data.Name = model.NewPointer("engineering")
+	data.Description = model.NewPointer(strings.Repeat("a", model.GroupDescriptionMaxLength+1))
+	err = ValidateGroupImportData(data)
+	require.NotNil(t, err, "Should have failed due to too long description.")
+
+	data.Description = nil
+	data.Members = &[]string{"alice", "not a username"}
+	err = ValidateGroupImportData(data)
+	require.NotNil(t, err, "Should have failed due to an invalid member.")
+}
+
 func TestIsValidGuestRoles(t *testing.T) {
 	var testCases = []struct {
 		name     string
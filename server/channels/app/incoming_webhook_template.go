@@ -0,0 +1,356 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// incomingWebhookTemplateTimeout bounds how long a single payload template may
+	// run before the request is rejected.
+	incomingWebhookTemplateTimeout = 2 * time.Second
+
+	// incomingWebhookTemplateMaxIterations bounds the total number of range
+	// iterations a single payload template may run.
+	incomingWebhookTemplateMaxIterations = 100000
+
+	// incomingWebhookTemplateMaxRangeDepth bounds how deeply range actions may be nested.
+	incomingWebhookTemplateMaxRangeDepth = 3
+
+	// incomingWebhookTemplateStepFunc is called at the start of every range iteration
+	// to enforce the time and iteration budgets of the template.
+	incomingWebhookTemplateStepFunc = "incomingWebhookTemplateStep"
+)
+
+var (
+	errIncomingWebhookTemplateTooLarge     = errors.New("template output exceeds the maximum allowed size")
+	errIncomingWebhookTemplateTimeout      = errors.New("template execution timed out")
+	errIncomingWebhookTemplateTooManyLoops = errors.New("template exceeds the maximum number of range iterations")
+	errIncomingWebhookTemplateNestedRanges = errors.New("template nests range actions too deeply")
+	errIncomingWebhookTemplateRangeSource  = errors.New("range actions may only iterate over fields of the payload or variables")
+	errIncomingWebhookTemplateDefinitions  = errors.New("template definitions and calls are not allowed")
+)
+
+// incomingWebhookTemplateFuncs is the complete set of functions available to
+// payload templates in addition to the text/template builtins. None of them
+// have side effects or access anything outside of their arguments, and the ones
+// that can build large strings fail instead of exceeding the output size.
+var incomingWebhookTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	},
+	"default": func(def, v any) any {
+		if v == nil {
+			return def
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return def
+		}
+		return v
+	},
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"replace":   boundedReplace,
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"join":      boundedJoin,
+	"printf":    boundedPrintf,
+	"truncate": func(length int, s string) string {
+		if length < 0 || utf8.RuneCountInString(s) <= length {
+			return s
+		}
+		return string([]rune(s)[:length])
+	},
+}
+
+// incomingWebhookTemplateVerb matches the flags, argument indexes, width and precision of a
+// formatting verb.
+var incomingWebhookTemplateVerb = regexp.MustCompile(`%[^a-zA-Z%]*`)
+
+// boundedReplace is strings.ReplaceAll, failing instead of building a result larger than the
+// output of a template may be.
+func boundedReplace(s, old, replacement string) (string, error) {
+	if len(replacement) > len(old) {
+		count := strings.Count(s, old)
+		if len(s)+count*(len(replacement)-len(old)) > MaxIntegrationResponseSize {
+			return "", errIncomingWebhookTemplateTooLarge
+		}
+	}
+	return strings.ReplaceAll(s, old, replacement), nil
+}
+
+// boundedJoin joins the items with the separator, failing instead of building a result larger
+// than the output of a template may be.
+func boundedJoin(sep string, items []any) (string, error) {
+	var b strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			if b.Len()+len(sep) > MaxIntegrationResponseSize {
+				return "", errIncomingWebhookTemplateTooLarge
+			}
+			b.WriteString(sep)
+		}
+		part := fmt.Sprint(item)
+		if b.Len()+len(part) > MaxIntegrationResponseSize {
+			return "", errIncomingWebhookTemplateTooLarge
+		}
+		b.WriteString(part)
+	}
+	return b.String(), nil
+}
+
+// boundedPrintf is fmt.Sprintf, failing instead of padding values to a width or precision that
+// would make the result larger than the output of a template may be.
+func boundedPrintf(format string, args ...any) (string, error) {
+	for _, verb := range incomingWebhookTemplateVerb.FindAllString(format, -1) {
+		for _, field := range strings.FieldsFunc(verb, func(r rune) bool { return r < '0' || r > '9' }) {
+			if n, err := strconv.Atoi(field); err != nil || n > MaxIntegrationResponseSize {
+				return "", errIncomingWebhookTemplateTooLarge
+			}
+		}
+		// Widths and precisions given as arguments must be ints.
+		if strings.Contains(verb, "*") {
+			for _, arg := range args {
+				if n, ok := arg.(int); ok && (n > MaxIntegrationResponseSize || n < -MaxIntegrationResponseSize) {
+					return "", errIncomingWebhookTemplateTooLarge
+				}
+			}
+		}
+	}
+	return fmt.Sprintf(format, args...), nil
+}
+
+// templateOutputWriter caps the amount of output a template can produce and
+// aborts execution once the surrounding context is done.
+type templateOutputWriter struct {
+	ctx context.Context
+	buf bytes.Buffer
+	max int
+}
+
+func (w *templateOutputWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, errIncomingWebhookTemplateTimeout
+	}
+	if w.buf.Len()+len(p) > w.max {
+		return 0, errIncomingWebhookTemplateTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+// templateBudget limits the work a template does during one execution. Execution is
+// stopped from within the template, so it doesn't keep running once the budget is spent.
+type templateBudget struct {
+	ctx        context.Context
+	iterations int
+}
+
+func (b *templateBudget) step() (string, error) {
+	if err := b.ctx.Err(); err != nil {
+		return "", errIncomingWebhookTemplateTimeout
+	}
+	b.iterations++
+	if b.iterations > incomingWebhookTemplateMaxIterations {
+		return "", errIncomingWebhookTemplateTooManyLoops
+	}
+	return "", nil
+}
+
+// parseIncomingWebhookTemplate parses and checks a payload template. When a budget is
+// given, every range iteration of the returned template is charged to it.
+func parseIncomingWebhookTemplate(text string, budget *templateBudget) (*template.Template, error) {
+	step := func() (string, error) { return "", nil }
+	if budget != nil {
+		step = budget.step
+	}
+	funcs := template.FuncMap{incomingWebhookTemplateStepFunc: step}
+
+	tmpl, err := template.New("incoming_webhook").Funcs(incomingWebhookTemplateFuncs).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	// Templates calling each other could recurse without ever ranging over anything.
+	if len(tmpl.Templates()) > 1 {
+		return nil, errIncomingWebhookTemplateDefinitions
+	}
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+
+	stepTmpl, err := template.New("step").Funcs(funcs).Parse("{{" + incomingWebhookTemplateStepFunc + "}}")
+	if err != nil {
+		return nil, err
+	}
+	stepNode := stepTmpl.Tree.Root.Nodes[0]
+
+	if err := instrumentIncomingWebhookTemplate(tmpl.Tree.Root, 0, stepNode); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// instrumentIncomingWebhookTemplate rejects the constructs that could make a template
+// run for much longer than the size of its payload, and makes every range iteration
+// call the step function.
+func instrumentIncomingWebhookTemplate(node parse.Node, rangeDepth int, stepNode parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := instrumentIncomingWebhookTemplate(child, rangeDepth, stepNode); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return errIncomingWebhookTemplateDefinitions
+	case *parse.IfNode:
+		return instrumentIncomingWebhookTemplateBranch(&n.BranchNode, rangeDepth, stepNode)
+	case *parse.WithNode:
+		return instrumentIncomingWebhookTemplateBranch(&n.BranchNode, rangeDepth, stepNode)
+	case *parse.RangeNode:
+		if rangeDepth+1 > incomingWebhookTemplateMaxRangeDepth {
+			return errIncomingWebhookTemplateNestedRanges
+		}
+		if !isIncomingWebhookTemplateRangeSource(n.Pipe) {
+			return errIncomingWebhookTemplateRangeSource
+		}
+		n.List.Nodes = append([]parse.Node{stepNode.Copy()}, n.List.Nodes...)
+		if err := instrumentIncomingWebhookTemplate(n.List, rangeDepth+1, stepNode); err != nil {
+			return err
+		}
+		return instrumentIncomingWebhookTemplate(n.ElseList, rangeDepth, stepNode)
+	}
+	return nil
+}
+
+func instrumentIncomingWebhookTemplateBranch(n *parse.BranchNode, rangeDepth int, stepNode parse.Node) error {
+	if err := instrumentIncomingWebhookTemplate(n.List, rangeDepth, stepNode); err != nil {
+		return err
+	}
+	return instrumentIncomingWebhookTemplate(n.ElseList, rangeDepth, stepNode)
+}
+
+// isIncomingWebhookTemplateRangeSource returns whether the ranged over pipeline is a plain
+// reference to the payload or to a variable, rather than an integer or a function result.
+func isIncomingWebhookTemplateRangeSource(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch pipe.Cmds[0].Args[0].(type) {
+	case *parse.DotNode, *parse.FieldNode, *parse.VariableNode, *parse.ChainNode:
+		return true
+	}
+	return false
+}
+
+func validateIncomingWebhookTemplate(where string, hook *model.IncomingWebhook) *model.AppError {
+	if hook.Template == "" {
+		return nil
+	}
+
+	if _, err := parseIncomingWebhookTemplate(hook.Template, nil); err != nil {
+		return model.NewAppError(where, "app.webhooks.incoming_template.parse.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return nil
+}
+
+// renderIncomingWebhookTemplate maps an arbitrary JSON payload into an
+// incoming webhook request. When the rendered output is a JSON object it is
+// decoded as a regular incoming webhook request, so templates can set
+// attachments, props and priority; any other output becomes the post text.
+func renderIncomingWebhookTemplate(text string, payload []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	ctx, cancel := context.WithTimeout(context.Background(), incomingWebhookTemplateTimeout)
+	defer cancel()
+
+	tmpl, err := parseIncomingWebhookTemplate(text, &templateBudget{ctx: ctx})
+	if err != nil {
+		return nil, model.NewAppError("renderIncomingWebhookTemplate", "app.webhooks.incoming_template.parse.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	var data any
+	if len(bytes.TrimSpace(payload)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(payload))
+		decoder.UseNumber()
+		if err = decoder.Decode(&data); err != nil {
+			return nil, model.NewAppError("renderIncomingWebhookTemplate", "app.webhooks.incoming_template.payload.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	// Ranges are charged to the budget and the output is capped, so execution stops by
+	// itself once the time, iteration or output budget is exceeded.
+	out := &templateOutputWriter{ctx: ctx, max: MaxIntegrationResponseSize}
+	if err = tmpl.Execute(out, data); err != nil {
+		return nil, model.NewAppError("renderIncomingWebhookTemplate", "app.webhooks.incoming_template.execute.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	rendered := bytes.TrimSpace(out.buf.Bytes())
+	if bytes.HasPrefix(rendered, []byte("{")) {
+		return model.IncomingWebhookRequestFromJSON(bytes.NewReader(rendered))
+	}
+
+	return &model.IncomingWebhookRequest{Text: string(rendered)}, nil
+}
+
+// DecodeIncomingWebhookPayload turns the body of an incoming webhook request
+// into an IncomingWebhookRequest, applying the webhook's payload template when
+// one is configured.
+func (a *App) DecodeIncomingWebhookPayload(hookID string, payload io.Reader) (*model.IncomingWebhookRequest, *model.AppError) {
+	hook, err := a.Srv().Store().Webhook().GetIncoming(hookID, true)
+	if err != nil || hook.Template == "" {
+		// Unknown hooks are reported by HandleIncomingWebhook.
+		return model.IncomingWebhookRequestFromJSON(payload)
+	}
+
+	body, err := io.ReadAll(payload)
+	if err != nil {
+		return nil, model.NewAppError("DecodeIncomingWebhookPayload", "app.webhooks.incoming_template.payload.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return renderIncomingWebhookTemplate(hook.Template, body)
+}
+
+// DryRunIncomingWebhookTemplate renders a sample payload through a template
+// without creating a post. An empty template falls back to the one stored on
+// the webhook.
+func (a *App) DryRunIncomingWebhookTemplate(hook *model.IncomingWebhook, req *model.IncomingWebhookTemplateDryRunRequest) (*model.IncomingWebhookRequest, *model.AppError) {
+	text := req.Template
+	if text == "" {
+		text = hook.Template
+	}
+	if text == "" {
+		return nil, model.NewAppError("DryRunIncomingWebhookTemplate", "app.webhooks.incoming_template.missing.app_error", nil, "", http.StatusBadRequest)
+	}
+	if len(text) > model.IncomingWebhookTemplateMaxLength {
+		return nil, model.NewAppError("DryRunIncomingWebhookTemplate", "model.incoming_hook.template.app_error", map[string]any{"MaxLength": model.IncomingWebhookTemplateMaxLength}, "", http.StatusBadRequest)
+	}
+
+	return renderIncomingWebhookTemplate(text, req.Payload)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestRenderIncomingWebhookTemplate(t *testing.T) {
+	payload := []byte(`{
+		"alert": {"title": "Disk full", "severity": "critical", "hosts": ["db1", "db2"], "count": 3},
+		"url": "https://monitoring.example.com/alerts/1"
+	}`)
+
+	t.Run("plain text output becomes the post text", func(t *testing.T) {
+		req, appErr := renderIncomingWebhookTemplate(`{{ .alert.severity | upper }}: {{ .alert.title }} on {{ join ", " .alert.hosts }} ({{ .alert.count }})`, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "CRITICAL: Disk full on db1, db2 (3)", req.Text)
+	})
+
+	t.Run("json output maps attachments, props and priority", func(t *testing.T) {
+		tmpl := `{
+			"text": {{ json .alert.title }},
+			"attachments": [{"title": {{ json .alert.title }}, "title_link": {{ json .url }}, "color": "#ff0000"}],
+			"props": {"severity": {{ json .alert.severity }}},
+			"priority": {"priority": "{{ if eq .alert.severity "critical" }}urgent{{ else }}important{{ end }}"}
+		}`
+		req, appErr := renderIncomingWebhookTemplate(tmpl, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Disk full", req.Text)
+		require.Len(t, req.Attachments, 1)
+		assert.Equal(t, "https://monitoring.example.com/alerts/1", req.Attachments[0].TitleLink)
+		assert.Equal(t, "critical", req.Props["severity"])
+		require.NotNil(t, req.Priority)
+		assert.Equal(t, model.PostPriorityUrgent, *req.Priority.Priority)
+	})
+
+	t.Run("missing fields fall back to defaults", func(t *testing.T) {
+		req, appErr := renderIncomingWebhookTemplate(`{{ default "untitled" .missing }} {{ truncate 4 .alert.title }}`, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "untitled Disk", req.Text)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, appErr := renderIncomingWebhookTemplate(`{{ .alert.title`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.parse.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("functions outside of the sandbox are unavailable", func(t *testing.T) {
+		_, appErr := renderIncomingWebhookTemplate(`{{ env "HOME" }}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.parse.app_error", appErr.Id)
+	})
+
+	t.Run("payload is not json", func(t *testing.T) {
+		_, appErr := renderIncomingWebhookTemplate(`{{ . }}`, []byte("payload=text"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.payload.app_error", appErr.Id)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		_, appErr := renderIncomingWebhookTemplate(`{{ index .alert.hosts 10 }}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.execute.app_error", appErr.Id)
+	})
+
+	t.Run("output is capped", func(t *testing.T) {
+		items := []byte(`{"pad": "` + strings.Repeat("x", 1024) + `", "items": [` + strings.Repeat("1,", 2047) + `1]}`)
+		_, appErr := renderIncomingWebhookTemplate(`{{ range .items }}{{ $.pad }}{{ end }}`, items)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.execute.app_error", appErr.Id)
+		assert.ErrorIs(t, appErr, errIncomingWebhookTemplateTooLarge)
+	})
+
+	for name, tmpl := range map[string]string{
+		"replacements are capped":      `{{ replace .text "a" .text }}`,
+		"joins are capped":             `{{ join .text .items }}`,
+		"padding is capped":            `{{ printf "%0999999999d" 1 }}`,
+		"padding arguments are capped": `{{ printf "%*d" 999999999 1 }}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			large := []byte(`{"text": "` + strings.Repeat("a", 2048) + `", "items": [` + strings.Repeat("1,", 1023) + `1]}`)
+			_, appErr := renderIncomingWebhookTemplate(tmpl, large)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "app.webhooks.incoming_template.execute.app_error", appErr.Id)
+			assert.ErrorIs(t, appErr, errIncomingWebhookTemplateTooLarge)
+		})
+	}
+
+	t.Run("nested ranges over the payload", func(t *testing.T) {
+		items := []byte(`{"items": [` + strings.Repeat("1,", 99) + `1]}`)
+		req, appErr := renderIncomingWebhookTemplate(`{{ range .items }}{{ range $.items }}{{ end }}{{ end }}done`, items)
+		require.Nil(t, appErr)
+		assert.Equal(t, "done", req.Text)
+	})
+
+	t.Run("iterations are capped even without output", func(t *testing.T) {
+		items := []byte(`{"items": [` + strings.Repeat("1,", 2047) + `1]}`)
+		_, appErr := renderIncomingWebhookTemplate(`{{ range .items }}{{ range $.items }}{{ end }}{{ end }}`, items)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.execute.app_error", appErr.Id)
+		assert.Contains(t, appErr.Error(), errIncomingWebhookTemplateTooManyLoops.Error())
+	})
+
+	t.Run("iterations over variables are capped", func(t *testing.T) {
+		_, appErr := renderIncomingWebhookTemplate(`{{ $n := 1000000000 }}{{ range $n }}{{ end }}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.incoming_template.execute.app_error", appErr.Id)
+		assert.Contains(t, appErr.Error(), errIncomingWebhookTemplateTooManyLoops.Error())
+	})
+
+	for name, tmpl := range map[string]string{
+		"ranging over an integer is rejected":         `{{ range 1000000000 }}{{ end }}`,
+		"ranging over a function result is rejected":  `{{ range (len .alert.hosts) }}{{ end }}`,
+		"deeply nested ranges are rejected":           `{{ range . }}{{ range . }}{{ range . }}{{ range . }}{{ end }}{{ end }}{{ end }}{{ end }}`,
+		"template definitions are rejected":           `{{ define "loop" }}{{ template "loop" . }}{{ end }}{{ template "loop" . }}`,
+		"ranges nested in conditions are checked too": `{{ if . }}{{ with . }}{{ range 10 }}{{ end }}{{ end }}{{ end }}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, appErr := renderIncomingWebhookTemplate(tmpl, payload)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "app.webhooks.incoming_template.parse.app_error", appErr.Id)
+		})
+	}
+}
+
+func TestIncomingWebhookTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+		ChannelId: th.BasicChannel.Id,
+		Template:  `{{ .alert.title }}`,
+	})
+	require.Nil(t, appErr)
+	defer th.App.DeleteIncomingWebhook(hook.Id)
+
+	t.Run("payload is mapped through the template", func(t *testing.T) {
+		req, appErr := th.App.DecodeIncomingWebhookPayload(hook.Id, bytes.NewReader([]byte(`{"alert": {"title": "Disk full"}}`)))
+		require.Nil(t, appErr)
+		assert.Equal(t, "Disk full", req.Text)
+
+		require.Nil(t, th.App.HandleIncomingWebhook(th.Context, hook.Id, req))
+	})
+
+	t.Run("dry run uses the stored template", func(t *testing.T) {
+		req, appErr := th.App.DryRunIncomingWebhookTemplate(hook, &model.IncomingWebhookTemplateDryRunRequest{Payload: []byte(`{"alert": {"title": "CPU high"}}`)})
+		require.Nil(t, appErr)
+		assert.Equal(t, "CPU high", req.Text)
+	})
+
+	t.Run("dry run with a template override", func(t *testing.T) {
+		req, appErr := th.App.DryRunIncomingWebhookTemplate(hook, &model.IncomingWebhookTemplateDryRunRequest{
+			Template: `{{ .alert.title | lower }}`,
+			Payload:  []byte(`{"alert": {"title": "CPU high"}}`),
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "cpu high", req.Text)
+	})
+
+	t.Run("invalid template on update", func(t *testing.T) {
+		updated := *hook
+		updated.Template = "{{ if }}"
+		_, appErr := th.App.UpdateIncomingWebhook(hook, &updated)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
@@ -11,8 +11,9 @@ import (
 )
 
 const (
-	mentionableUserPrefix  = "user:"
-	mentionableGroupPrefix = "group:"
+	mentionableUserPrefix   = "user:"
+	mentionableGroupPrefix  = "group:"
+	mentionableSourcePrefix = "source:"
 )
 
 // A MentionableID stores the ID of a single User/Group with information about which type of object it refers to.
@@ -26,6 +27,10 @@ func mentionableGroupID(groupID string) MentionableID {
 	return MentionableID(fmt.Sprint(mentionableGroupPrefix, groupID))
 }
 
+func mentionableSourceID(source string) MentionableID {
+	return MentionableID(fmt.Sprint(mentionableSourcePrefix, source))
+}
+
 func (id MentionableID) AsUserID() (userID string, ok bool) {
 	idString := string(id)
 	if !strings.HasPrefix(idString, mentionableUserPrefix) {
@@ -44,6 +49,15 @@ func (id MentionableID) AsGroupID() (groupID string, ok bool) {
 	return idString[len(mentionableGroupPrefix):], true
 }
 
+func (id MentionableID) AsMentionSource() (source string, ok bool) {
+	idString := string(id)
+	if !strings.HasPrefix(idString, mentionableSourcePrefix) {
+		return "", false
+	}
+
+	return idString[len(mentionableSourcePrefix):], true
+}
+
 // MentionKeywords is a collection of mention keywords and the IDs of the objects that have a given keyword.
 type MentionKeywords map[string][]MentionableID
 
@@ -108,3 +122,20 @@ func (k MentionKeywords) AddGroupsMap(groups map[string]*model.Group) MentionKey
 
 	return k
 }
+
+func (k MentionKeywords) AddMentionSource(source MentionSource) MentionKeywords {
+	mentionableID := mentionableSourceID(source.Name())
+	for _, keyword := range source.Keywords() {
+		k[keyword] = append(k[keyword], mentionableID)
+	}
+
+	return k
+}
+
+func (k MentionKeywords) AddMentionSources(sources []MentionSource) MentionKeywords {
+	for _, source := range sources {
+		k.AddMentionSource(source)
+	}
+
+	return k
+}
@@ -3,7 +3,28 @@
 
 package app
 
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
 type MentionParser interface {
 	ProcessText(text string)
 	Results() *MentionResults
 }
+
+// MentionSource resolves custom mention keywords, such as on-call rotations or
+// role tags like @team-leads, to the users they mention. The keywords of every
+// registered source are matched by the MentionParser along with usernames and
+// groups, and the resolved users are notified like members of a mentioned group.
+type MentionSource interface {
+	// Name uniquely identifies the source.
+	Name() string
+
+	// Keywords returns the lower case keywords handled by the source, including the leading @.
+	Keywords() []string
+
+	// ResolveMentions returns the ids of the users mentioned by each of the given
+	// keywords in a post.
+	ResolveMentions(c request.CTX, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error)
+}
@@ -86,8 +86,8 @@ func (p *StandardMentionParser) ProcessText(text string) {
 			}
 		}
 
-		if ids, match := isKeywordMultibyte(p.keywords, word); match {
-			p.addMentions(ids, KeywordMention)
+		if keyword, ids, match := isKeywordMultibyte(p.keywords, word); match {
+			p.addMentions(keyword, ids, KeywordMention)
 		}
 	}
 }
@@ -115,31 +115,34 @@ func (p *StandardMentionParser) checkForMention(word string) bool {
 	}
 
 	if ids, match := p.keywords[strings.ToLower(word)]; match {
-		p.addMentions(ids, mentionType)
+		p.addMentions(strings.ToLower(word), ids, mentionType)
 		return true
 	}
 
 	// Case-sensitive check for first name
 	if ids, match := p.keywords[word]; match {
-		p.addMentions(ids, mentionType)
+		p.addMentions(word, ids, mentionType)
 		return true
 	}
 
 	return false
 }
 
-func (p *StandardMentionParser) addMentions(ids []MentionableID, mentionType MentionType) {
+func (p *StandardMentionParser) addMentions(keyword string, ids []MentionableID, mentionType MentionType) {
 	for _, id := range ids {
 		if userID, ok := id.AsUserID(); ok {
 			p.results.addMention(userID, mentionType)
 		} else if groupID, ok := id.AsGroupID(); ok {
 			p.results.addGroupMention(groupID)
+		} else if source, ok := id.AsMentionSource(); ok {
+			p.results.addSourceMention(keyword, source)
 		}
 	}
 }
 
 // isKeywordMultibyte checks if a word containing a multibyte character contains a multibyte keyword
-func isKeywordMultibyte(keywords MentionKeywords, word string) ([]MentionableID, bool) {
+func isKeywordMultibyte(keywords MentionKeywords, word string) (string, []MentionableID, bool) {
+	keyword := ""
 	ids := []MentionableID{}
 	match := false
 	var multibyteKeywords []string
@@ -152,9 +155,10 @@ func isKeywordMultibyte(keywords MentionKeywords, word string) ([]MentionableID,
 	if len(word) != utf8.RuneCountInString(word) {
 		for _, key := range multibyteKeywords {
 			if strings.Contains(word, key) {
+				keyword = key
 				ids, match = keywords[key]
 			}
 		}
 	}
-	return ids, match
+	return keyword, ids, match
 }
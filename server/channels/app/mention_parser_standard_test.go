@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -431,3 +432,51 @@ func TestProcessText(t *testing.T) {
 		})
 	}
 }
+
+type testMentionSource struct {
+	name     string
+	keywords []string
+}
+
+func (s *testMentionSource) Name() string       { return s.name }
+func (s *testMentionSource) Keywords() []string { return s.keywords }
+func (s *testMentionSource) ResolveMentions(c request.CTX, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error) {
+	return nil, nil
+}
+
+func TestCheckForMentionSources(t *testing.T) {
+	source := &testMentionSource{name: "oncall", keywords: []string{"@oncall", "@team-leads"}}
+
+	for name, tc := range map[string]struct {
+		Word     string
+		Expected *MentionResults
+	}{
+		"no matching keyword": {
+			Word:     "@nothing",
+			Expected: &MentionResults{},
+		},
+		"matching keyword with no @": {
+			Word:     "oncall",
+			Expected: &MentionResults{},
+		},
+		"matching keyword with preceding @": {
+			Word: "@oncall",
+			Expected: &MentionResults{
+				SourceMentions: map[string]string{"@oncall": "oncall"},
+			},
+		},
+		"matching upper case keyword with preceding @": {
+			Word: "@Team-Leads",
+			Expected: &MentionResults{
+				SourceMentions: map[string]string{"@team-leads": "oncall"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := makeStandardMentionParser(MentionKeywords{}.AddMentionSource(source))
+			p.checkForMention(tc.Word)
+
+			assert.EqualValues(t, tc.Expected, p.Results())
+		})
+	}
+}
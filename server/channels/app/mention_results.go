@@ -40,6 +40,10 @@ type MentionResults struct {
 	// GroupMentions maps the ID of each group that was mentioned to how it was mentioned.
 	GroupMentions map[string]MentionType
 
+	// SourceMentions maps each keyword of a custom MentionSource that was mentioned to the
+	// name of the source.
+	SourceMentions map[string]string
+
 	// OtherPotentialMentions contains a list of strings that looked like mentions, but didn't have
 	// a corresponding keyword.
 	OtherPotentialMentions []string
@@ -89,3 +93,11 @@ func (m *MentionResults) addGroupMention(groupID string) {
 
 	m.GroupMentions[groupID] = GroupMention
 }
+
+func (m *MentionResults) addSourceMention(keyword, source string) {
+	if m.SourceMentions == nil {
+		m.SourceMentions = make(map[string]string)
+	}
+
+	m.SourceMentions[keyword] = source
+}
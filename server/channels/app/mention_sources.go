@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// RegisterMentionSource registers a source of custom mention keywords, replacing
+// any source previously registered with the same name.
+func (a *App) RegisterMentionSource(source MentionSource) {
+	a.ch.mentionSourcesLock.Lock()
+	defer a.ch.mentionSourcesLock.Unlock()
+
+	a.ch.mentionSources[source.Name()] = source
+}
+
+// UnregisterMentionSource removes the source of custom mention keywords with the given name.
+func (a *App) UnregisterMentionSource(name string) {
+	a.ch.unregisterMentionSource(name)
+}
+
+func (ch *Channels) unregisterMentionSource(name string) {
+	ch.mentionSourcesLock.Lock()
+	defer ch.mentionSourcesLock.Unlock()
+
+	delete(ch.mentionSources, name)
+}
+
+// getMentionSources returns the registered mention sources sorted by name.
+func (ch *Channels) getMentionSources() []MentionSource {
+	ch.mentionSourcesLock.RLock()
+	defer ch.mentionSourcesLock.RUnlock()
+
+	sources := make([]MentionSource, 0, len(ch.mentionSources))
+	for _, source := range ch.mentionSources {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Name() < sources[j].Name()
+	})
+
+	return sources
+}
+
+func (ch *Channels) getMentionSource(name string) MentionSource {
+	ch.mentionSourcesLock.RLock()
+	defer ch.mentionSourcesLock.RUnlock()
+
+	return ch.mentionSources[name]
+}
+
+// allowSourceMentions returns whether the keywords of custom mention sources are
+// matched in the post. Like group mentions, they require the permission to use group mentions.
+func (a *App) allowSourceMentions(c request.CTX, post *model.Post) bool {
+	if post.Type == model.PostTypeHeaderChange || post.Type == model.PostTypePurposeChange {
+		return false
+	}
+
+	return a.HasPermissionToChannel(c, post.UserId, post.ChannelId, model.PermissionUseGroupMentions)
+}
+
+// insertSourceMentions resolves the keywords of custom mention sources found in the post. Like
+// group members, resolved users in the channel are added to Mentions and the others are added
+// to OtherPotentialMentions. A source that fails to resolve its keywords is skipped.
+func (a *App) insertSourceMentions(c request.CTX, senderID string, post *model.Post, channel *model.Channel, profileMap map[string]*model.User, mentions *MentionResults) {
+	if len(mentions.SourceMentions) == 0 {
+		return
+	}
+
+	keywordsBySource := make(map[string][]string)
+	for keyword, name := range mentions.SourceMentions {
+		keywordsBySource[name] = append(keywordsBySource[name], keyword)
+	}
+
+	outOfChannelUserIDs := []string{}
+	seen := make(map[string]bool)
+	for name, keywords := range keywordsBySource {
+		source := a.ch.getMentionSource(name)
+		if source == nil {
+			continue
+		}
+		sort.Strings(keywords)
+
+		resolved, err := source.ResolveMentions(c, post, channel, keywords)
+		if err != nil {
+			c.Logger().Warn("Failed to resolve custom mentions",
+				mlog.String("source", name),
+				mlog.String("post_id", post.Id),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		for _, userIDs := range resolved {
+			for _, userID := range userIDs {
+				if userID == senderID || seen[userID] {
+					continue
+				}
+				seen[userID] = true
+
+				if _, ok := profileMap[userID]; ok {
+					mentions.addMention(userID, GroupMention)
+				} else if model.IsValidId(userID) {
+					outOfChannelUserIDs = append(outOfChannelUserIDs, userID)
+				}
+			}
+		}
+	}
+
+	if len(outOfChannelUserIDs) == 0 || channel.IsGroupOrDirect() {
+		return
+	}
+
+	users, err := a.Srv().Store().User().GetProfileByIds(context.Background(), outOfChannelUserIDs, &store.UserGetByIdsOpts{}, true)
+	if err != nil {
+		c.Logger().Warn("Failed to get the users of custom mentions", mlog.String("post_id", post.Id), mlog.Err(err))
+		return
+	}
+
+	for _, user := range users {
+		if user.DeleteAt == 0 && !user.IsBot {
+			mentions.OtherPotentialMentions = append(mentions.OtherPotentialMentions, user.Username)
+		}
+	}
+}
@@ -190,6 +190,9 @@ func (a *App) SendNotifications(c request.CTX, post *model.Post, team *model.Tea
 			}
 		}
 
+		// Resolve the keywords of custom mention sources, such as those registered by plugins
+		a.insertSourceMentions(c, sender.Id, post, channel, profileMap, mentions)
+
 		go func() {
 			_, err := a.sendOutOfChannelMentions(c, sender, post, channel, mentions.OtherPotentialMentions)
 			if err != nil {
@@ -1063,6 +1066,9 @@ func (a *App) getExplicitMentionsAndKeywords(c request.CTX, post *model.Post, ch
 	} else {
 		allowChannelMentions = a.allowChannelMentions(c, post, len(profileMap))
 		keywords = a.getMentionKeywordsInChannel(profileMap, allowChannelMentions, channelMemberNotifyPropsMap, groups)
+		if sources := a.ch.getMentionSources(); len(sources) > 0 && a.allowSourceMentions(c, post) {
+			keywords.AddMentionSources(sources)
+		}
 
 		mentions = getExplicitMentions(post, keywords)
 
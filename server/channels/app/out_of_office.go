@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const outOfOfficeScheduleBatchSize = 100
+
+// GetOutOfOffice returns the auto-responder settings of a user along with
+// their pending out of office period, if any.
+func (a *App) GetOutOfOffice(userID string) (*model.OutOfOffice, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	ooo := &model.OutOfOffice{
+		UserId:          user.Id,
+		Active:          user.NotifyProps[model.AutoResponderActiveNotifyProp] == "true",
+		Message:         user.NotifyProps[model.AutoResponderMessageNotifyProp],
+		RemoteMessage:   user.NotifyProps[model.AutoResponderRemoteMessageNotifyProp],
+		ReplyToMentions: user.NotifyProps[model.AutoResponderMentionsNotifyProp] == "true",
+	}
+
+	schedule, err := a.Srv().Store().OutOfOffice().Get(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetOutOfOffice", "app.out_of_office.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	} else {
+		ooo.StartAt = schedule.StartAt
+		ooo.EndAt = schedule.EndAt
+	}
+
+	return ooo, nil
+}
+
+// UpdateOutOfOffice stores the auto-responder settings of a user. When a
+// period is given the auto-responder is switched on now if the period has
+// already started, and the out of office job takes care of the rest.
+func (a *App) UpdateOutOfOffice(rctx request.CTX, userID string, ooo *model.OutOfOffice, asAdmin bool) (*model.OutOfOffice, *model.AppError) {
+	ooo.UserId = userID
+	if appErr := ooo.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	now := model.GetMillis()
+	if ooo.IsScheduled() && ooo.EndAt > 0 && ooo.EndAt <= now {
+		return nil, model.NewAppError("UpdateOutOfOffice", "model.out_of_office.is_valid.period.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	active := ooo.ActiveAt(now)
+	if ooo.IsScheduled() {
+		schedule := &model.OutOfOfficeSchedule{
+			UserId:  userID,
+			StartAt: ooo.StartAt,
+			EndAt:   ooo.EndAt,
+		}
+		if active {
+			schedule.ActivatedAt = now
+		}
+
+		// A period without an end that has already started needs no job.
+		if active && ooo.EndAt == 0 {
+			if err := a.Srv().Store().OutOfOffice().Delete(userID); err != nil {
+				return nil, model.NewAppError("UpdateOutOfOffice", "app.out_of_office.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		} else if _, err := a.Srv().Store().OutOfOffice().Save(schedule); err != nil {
+			var appErr *model.AppError
+			if errors.As(err, &appErr) {
+				return nil, appErr
+			}
+			return nil, model.NewAppError("UpdateOutOfOffice", "app.out_of_office.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	} else if err := a.Srv().Store().OutOfOffice().Delete(userID); err != nil {
+		return nil, model.NewAppError("UpdateOutOfOffice", "app.out_of_office.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.patchAutoResponder(rctx, userID, asAdmin, func(props model.StringMap) {
+		props[model.AutoResponderActiveNotifyProp] = boolToNotifyProp(active)
+		props[model.AutoResponderMessageNotifyProp] = ooo.Message
+		props[model.AutoResponderRemoteMessageNotifyProp] = ooo.RemoteMessage
+		props[model.AutoResponderMentionsNotifyProp] = boolToNotifyProp(ooo.ReplyToMentions)
+	}); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.GetOutOfOffice(userID)
+}
+
+// DeleteOutOfOffice switches the auto-responder off and cancels any pending
+// out of office period of the user.
+func (a *App) DeleteOutOfOffice(rctx request.CTX, userID string, asAdmin bool) *model.AppError {
+	if err := a.Srv().Store().OutOfOffice().Delete(userID); err != nil {
+		return model.NewAppError("DeleteOutOfOffice", "app.out_of_office.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return a.patchAutoResponder(rctx, userID, asAdmin, func(props model.StringMap) {
+		props[model.AutoResponderActiveNotifyProp] = "false"
+	})
+}
+
+// patchAutoResponder applies update to the notify props of the user and
+// switches their status to or from out of office when the auto-responder
+// is turned on or off.
+func (a *App) patchAutoResponder(rctx request.CTX, userID string, asAdmin bool, update func(props model.StringMap)) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	oldNotifyProps := model.CopyStringMap(user.NotifyProps)
+	notifyProps := model.CopyStringMap(user.NotifyProps)
+	if notifyProps == nil {
+		notifyProps = model.StringMap{}
+	}
+	update(notifyProps)
+
+	patchedUser, appErr := a.PatchUser(rctx, userID, &model.UserPatch{NotifyProps: notifyProps}, asAdmin)
+	if appErr != nil {
+		return appErr
+	}
+
+	a.SetAutoResponderStatus(rctx, patchedUser, oldNotifyProps)
+
+	return nil
+}
+
+func boolToNotifyProp(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+// ProcessOutOfOfficeSchedules starts and ends the out of office periods that
+// are due.
+func (a *App) ProcessOutOfOfficeSchedules(rctx request.CTX) error {
+	for {
+		now := model.GetMillis()
+		schedules, err := a.Srv().Store().OutOfOffice().GetDue(now, outOfOfficeScheduleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, schedule := range schedules {
+			if err := a.processOutOfOfficeSchedule(rctx, schedule, now); err != nil {
+				rctx.Logger().Warn("Failed to process out of office schedule", mlog.String("user_id", schedule.UserId), mlog.Err(err))
+				failed++
+			}
+		}
+
+		// Failed schedules stay due, so stop rather than fetch them again.
+		if len(schedules) < outOfOfficeScheduleBatchSize || failed > 0 {
+			return nil
+		}
+	}
+}
+
+func (a *App) processOutOfOfficeSchedule(rctx request.CTX, schedule *model.OutOfOfficeSchedule, now int64) error {
+	if schedule.IsEnded(now) {
+		// Periods that were missed entirely are dropped without turning anything on.
+		if schedule.ActivatedAt > 0 {
+			if appErr := a.patchAutoResponder(rctx, schedule.UserId, true, func(props model.StringMap) {
+				props[model.AutoResponderActiveNotifyProp] = "false"
+			}); appErr != nil {
+				return appErr
+			}
+		}
+
+		return a.Srv().Store().OutOfOffice().Delete(schedule.UserId)
+	}
+
+	if appErr := a.patchAutoResponder(rctx, schedule.UserId, true, func(props model.StringMap) {
+		props[model.AutoResponderActiveNotifyProp] = "true"
+	}); appErr != nil {
+		return appErr
+	}
+
+	if schedule.EndAt == 0 {
+		return a.Srv().Store().OutOfOffice().Delete(schedule.UserId)
+	}
+
+	schedule.ActivatedAt = now
+	_, err := a.Srv().Store().OutOfOffice().Save(schedule)
+	return err
+}
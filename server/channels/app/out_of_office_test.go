@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestUpdateOutOfOffice(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("turns the auto-responder on now", func(t *testing.T) {
+		ooo, appErr := th.App.UpdateOutOfOffice(th.Context, th.BasicUser.Id, &model.OutOfOffice{
+			Active:          true,
+			Message:         "Away",
+			RemoteMessage:   "Away, partner",
+			ReplyToMentions: true,
+		}, true)
+		require.Nil(t, appErr)
+		assert.True(t, ooo.Active)
+		assert.Equal(t, "Away", ooo.Message)
+		assert.Equal(t, "Away, partner", ooo.RemoteMessage)
+		assert.True(t, ooo.ReplyToMentions)
+
+		status, appErr := th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOutOfOffice, status.Status)
+
+		require.Nil(t, th.App.DeleteOutOfOffice(th.Context, th.BasicUser.Id, true))
+		ooo, appErr = th.App.GetOutOfOffice(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.False(t, ooo.Active)
+	})
+
+	t.Run("schedules a future period", func(t *testing.T) {
+		now := model.GetMillis()
+		ooo, appErr := th.App.UpdateOutOfOffice(th.Context, th.BasicUser.Id, &model.OutOfOffice{
+			Message: "Away",
+			StartAt: now + 60000,
+			EndAt:   now + 120000,
+		}, true)
+		require.Nil(t, appErr)
+		assert.False(t, ooo.Active)
+		assert.Equal(t, now+60000, ooo.StartAt)
+		assert.Equal(t, now+120000, ooo.EndAt)
+
+		require.Nil(t, th.App.DeleteOutOfOffice(th.Context, th.BasicUser.Id, true))
+		ooo, appErr = th.App.GetOutOfOffice(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.False(t, ooo.IsScheduled())
+	})
+
+	t.Run("rejects a period that already ended", func(t *testing.T) {
+		now := model.GetMillis()
+		_, appErr := th.App.UpdateOutOfOffice(th.Context, th.BasicUser.Id, &model.OutOfOffice{
+			Message: "Away",
+			StartAt: now - 120000,
+			EndAt:   now - 60000,
+		}, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
+
+func TestProcessOutOfOfficeSchedules(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	now := model.GetMillis()
+
+	// A period that has started but was never activated is turned on.
+	_, appErr := th.App.UpdateOutOfOffice(th.Context, th.BasicUser.Id, &model.OutOfOffice{
+		Message: "Away",
+		StartAt: now + 60000,
+		EndAt:   now + 120000,
+	}, true)
+	require.Nil(t, appErr)
+	_, err := th.App.Srv().Store().OutOfOffice().Save(&model.OutOfOfficeSchedule{
+		UserId:  th.BasicUser.Id,
+		StartAt: now - 60000,
+		EndAt:   now + 60000,
+	})
+	require.NoError(t, err)
+
+	// An activated period that has ended is turned off.
+	_, appErr = th.App.UpdateOutOfOffice(th.Context, th.BasicUser2.Id, &model.OutOfOffice{
+		Message: "Away",
+		StartAt: now - 60000,
+		EndAt:   now + 60000,
+	}, true)
+	require.Nil(t, appErr)
+	_, err = th.App.Srv().Store().OutOfOffice().Save(&model.OutOfOfficeSchedule{
+		UserId:      th.BasicUser2.Id,
+		StartAt:     now - 120000,
+		EndAt:       now - 1,
+		ActivatedAt: now - 120000,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, th.App.ProcessOutOfOfficeSchedules(th.Context))
+
+	ooo, appErr := th.App.GetOutOfOffice(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.True(t, ooo.Active)
+	schedule, err := th.App.Srv().Store().OutOfOffice().Get(th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.NotZero(t, schedule.ActivatedAt)
+
+	ooo, appErr = th.App.GetOutOfOffice(th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	assert.False(t, ooo.Active)
+	assert.False(t, ooo.IsScheduled())
+}
@@ -0,0 +1,394 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	outgoingWebhookRetryBaseDelay = time.Minute
+	outgoingWebhookRetryMaxDelay  = 6 * time.Hour
+
+	outgoingWebhookRetryBatchSize   = 100
+	outgoingWebhookRetryConcurrency = 10
+
+	outgoingWebhookDeliveryRetention       = 30 * 24 * time.Hour
+	outgoingWebhookDeliveryDeleteBatchSize = 1000
+)
+
+// outgoingWebhookRetryDelay returns how long to wait before retrying a delivery
+// that failed on the given attempt, doubling the delay on every attempt.
+func outgoingWebhookRetryDelay(attempt int) time.Duration {
+	delay := outgoingWebhookRetryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= outgoingWebhookRetryMaxDelay {
+			return outgoingWebhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// isRetryableOutgoingWebhookStatus returns true if a delivery answered with the
+// given status code may succeed when sent again later.
+func isRetryableOutgoingWebhookStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// deliverOutgoingWebhook sends one delivery attempt to its callback URL. Only failed attempts
+// are recorded in the delivery log, to be retried unless the delivery is a replay or to be
+// replayed later, and hooks failing too often are disabled. A successful attempt resolves the
+// earlier failures of the hook instead. The response of the callback URL is returned whenever
+// it answered, unless the attempt is going to be retried.
+func (a *App) deliverOutgoingWebhook(c request.CTX, logger mlog.LoggerIFace, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery, replay bool) *model.OutgoingWebhookResponse {
+	if delivery.Attempt == 0 {
+		delivery.Attempt = 1
+	}
+	logger = logger.With(mlog.String("callback_url", delivery.CallbackURL), mlog.Int("attempt", delivery.Attempt))
+
+	start := time.Now()
+	result, err := a.sendOutgoingWebhookDelivery(c, hook, delivery)
+	delivery.Latency = time.Since(start).Milliseconds()
+
+	retryable := false
+	if err != nil {
+		delivery.Error = err.Error()
+		retryable = true
+		if errors.Is(err, context.DeadlineExceeded) {
+			logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
+		} else {
+			logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
+		}
+	} else {
+		delivery.StatusCode = result.statusCode
+		delivery.Response = string(result.body)
+		if result.statusCode >= http.StatusBadRequest {
+			retryable = isRetryableOutgoingWebhookStatus(result.statusCode)
+			logger.Error("Outgoing Webhook POST failed", mlog.Int("status_code", result.statusCode))
+		}
+	}
+
+	failed := err != nil || result.statusCode >= http.StatusBadRequest
+	switch {
+	case !failed:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusSuccess
+	case retryable && !replay && delivery.Attempt <= *a.Config().ServiceSettings.OutgoingWebhookMaxRetries:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusRetryPending
+		delivery.NextRetryAt = model.GetMillis() + outgoingWebhookRetryDelay(delivery.Attempt).Milliseconds()
+	default:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+	}
+
+	if failed {
+		if _, saveErr := a.Srv().Store().OutgoingWebhookDelivery().Save(delivery); saveErr != nil {
+			logger.Warn("Failed to save the outgoing webhook delivery", mlog.Err(saveErr))
+		}
+		if delivery.Status == model.OutgoingWebhookDeliveryStatusFailed && !replay {
+			a.disableFailingOutgoingWebhook(logger, hook)
+		}
+	} else {
+		delivery.PreSave()
+		if resolveErr := a.Srv().Store().OutgoingWebhookDelivery().ResolveFailures(hook.Id, delivery.CreateAt); resolveErr != nil {
+			logger.Warn("Failed to resolve the failed deliveries of the outgoing webhook", mlog.Err(resolveErr))
+		}
+	}
+
+	if err != nil || delivery.Status == model.OutgoingWebhookDeliveryStatusRetryPending {
+		return nil
+	}
+
+	webhookResp, err := decodeOutgoingWebhookResponse(result.body)
+	if err != nil {
+		logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
+		return nil
+	}
+	return webhookResp
+}
+
+// withOutgoingWebhookToken returns the payload of a delivery with the token field set to
+// the given token. Deliveries are stored without the token of the hook, which is only
+// added when sending them.
+func withOutgoingWebhookToken(contentType, payload, token string) (string, error) {
+	if contentType == "application/json" {
+		var p model.OutgoingWebhookPayload
+		if err := json.Unmarshal([]byte(payload), &p); err != nil {
+			return "", err
+		}
+		p.Token = token
+		b, err := json.Marshal(p)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	values, err := url.ParseQuery(payload)
+	if err != nil {
+		return "", err
+	}
+	values.Set("token", token)
+	return values.Encode(), nil
+}
+
+func (a *App) sendOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*outgoingWebhookResult, error) {
+	var accessToken *model.OutgoingOAuthConnectionToken
+
+	// Retrieve an access token from a connection if one exists to use for the webhook request
+	if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
+		connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, delivery.CallbackURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find an outgoing oauth connection for the webhook: %w", err)
+		}
+
+		if connection != nil {
+			accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve token for outgoing oauth connection: %w", err)
+			}
+		}
+	}
+
+	payload, err := withOutgoingWebhookToken(delivery.ContentType, delivery.Payload, hook.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add the token to the webhook payload: %w", err)
+	}
+
+	var header http.Header
+	if hook.IsSigned() {
+		// Sign at send time so that retries carry a fresh timestamp and the current secret.
+		timestamp := time.Now().Unix()
+		header = http.Header{}
+		header.Set(model.OutgoingWebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+		header.Set(model.OutgoingWebhookSignatureHeader, model.ComputeOutgoingWebhookSignature(hook.SigningSecret, timestamp, []byte(payload)))
+	}
+
+	return a.sendOutgoingWebhookRequest(delivery.CallbackURL, strings.NewReader(payload), delivery.ContentType, accessToken, header)
+}
+
+// disableFailingOutgoingWebhook disables the hook once it failed more deliveries in a row
+// than allowed by ServiceSettings.OutgoingWebhookDisableAfterFailures.
+func (a *App) disableFailingOutgoingWebhook(logger mlog.LoggerIFace, hook *model.OutgoingWebhook) {
+	maxFailures := *a.Config().ServiceSettings.OutgoingWebhookDisableAfterFailures
+	if maxFailures == 0 || hook.IsDisabled() {
+		return
+	}
+
+	failures, err := a.Srv().Store().OutgoingWebhookDelivery().GetConsecutiveFailureCount(hook.Id)
+	if err != nil {
+		logger.Warn("Failed to count the failed deliveries of the outgoing webhook", mlog.Err(err))
+		return
+	}
+	if failures < int64(maxFailures) {
+		return
+	}
+
+	// Reload the hook so concurrent updates aren't overwritten.
+	current, err := a.Srv().Store().Webhook().GetOutgoing(hook.Id)
+	if err != nil {
+		logger.Warn("Failed to get the outgoing webhook to disable", mlog.Err(err))
+		return
+	}
+	if current.IsDisabled() {
+		return
+	}
+
+	current.DisabledAt = model.GetMillis()
+	if _, err := a.Srv().Store().Webhook().UpdateOutgoing(current); err != nil {
+		logger.Warn("Failed to disable the outgoing webhook", mlog.Err(err))
+		return
+	}
+	hook.DisabledAt = current.DisabledAt
+
+	logger.Warn("Outgoing webhook disabled after failing too many deliveries in a row", mlog.Int("failures", failures))
+}
+
+// ProcessOutgoingWebhookRetries retries the outgoing webhook deliveries whose retry time
+// is due, and deletes the deliveries older than the retention period from the log.
+func (a *App) ProcessOutgoingWebhookRetries(c request.CTX) error {
+	deliveries, err := a.Srv().Store().OutgoingWebhookDelivery().GetPendingRetries(model.GetMillis(), outgoingWebhookRetryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, outgoingWebhookRetryConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *model.OutgoingWebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			a.retryOutgoingWebhookDelivery(c, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	endTime := model.GetMillis() - outgoingWebhookDeliveryRetention.Milliseconds()
+	for {
+		deleted, err := a.Srv().Store().OutgoingWebhookDelivery().PermanentDeleteBatch(endTime, outgoingWebhookDeliveryDeleteBatchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < outgoingWebhookDeliveryDeleteBatchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (a *App) retryOutgoingWebhookDelivery(c request.CTX, previous *model.OutgoingWebhookDelivery) {
+	logger := c.Logger().With(mlog.String("outgoing_webhook_id", previous.HookId), mlog.String("post_id", previous.PostId), mlog.String("channel_id", previous.ChannelId), mlog.String("delivery_id", previous.Id))
+
+	giveUp := false
+	hook, err := a.Srv().Store().Webhook().GetOutgoing(previous.HookId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			logger.Warn("Failed to get the outgoing webhook to retry", mlog.Err(err))
+			return
+		}
+		// Deliveries of deleted hooks are given up.
+		giveUp = true
+	} else if hook.IsDisabled() {
+		giveUp = true
+	}
+
+	if giveUp {
+		if _, err = a.Srv().Store().OutgoingWebhookDelivery().UpdateStatus(previous.Id, model.OutgoingWebhookDeliveryStatusRetryPending, model.OutgoingWebhookDeliveryStatusFailed); err != nil {
+			logger.Warn("Failed to update the outgoing webhook delivery", mlog.Err(err))
+		}
+		return
+	}
+
+	// The retry replaces the pending delivery. Another node may have retried it already.
+	claimed, err := a.Srv().Store().OutgoingWebhookDelivery().Delete(previous.Id, model.OutgoingWebhookDeliveryStatusRetryPending)
+	if err != nil {
+		logger.Warn("Failed to claim the outgoing webhook delivery", mlog.Err(err))
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	delivery := &model.OutgoingWebhookDelivery{
+		HookId:      previous.HookId,
+		ChannelId:   previous.ChannelId,
+		PostId:      previous.PostId,
+		CallbackURL: previous.CallbackURL,
+		ContentType: previous.ContentType,
+		Payload:     previous.Payload,
+		Attempt:     previous.Attempt + 1,
+	}
+	webhookResp := a.deliverOutgoingWebhook(c, logger, hook, delivery, false)
+	if webhookResp == nil {
+		return
+	}
+
+	channel, appErr := a.GetChannel(c, delivery.ChannelId)
+	if appErr != nil {
+		logger.Warn("Failed to get the channel of the outgoing webhook delivery", mlog.Err(appErr))
+		return
+	}
+	a.handleOutgoingWebhookResponse(c, logger, hook, channel, delivery.PostId, webhookResp)
+}
+
+func (a *App) GetOutgoingWebhookDeliveries(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if opts.Status != "" && !model.IsValidOutgoingWebhookDeliveryStatus(opts.Status) {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "app.webhooks.get_outgoing_deliveries.invalid_status.app_error", nil, "status="+opts.Status, http.StatusBadRequest)
+	}
+
+	deliveries, err := a.Srv().Store().OutgoingWebhookDelivery().GetForHook(hookID, opts)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "app.webhooks.get_outgoing_deliveries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return deliveries, nil
+}
+
+func (a *App) GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("GetOutgoingWebhookDelivery", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	delivery, err := a.Srv().Store().OutgoingWebhookDelivery().Get(deliveryID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOutgoingWebhookDelivery", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetOutgoingWebhookDelivery", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return delivery, nil
+}
+
+// ReplayOutgoingWebhookDelivery sends the payload of a failed delivery of the hook again,
+// returning the delivery recording the outcome of the replay.
+func (a *App) ReplayOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if delivery.HookId != hook.Id {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.replay_outgoing_delivery.hook_mismatch.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if delivery.Status != model.OutgoingWebhookDeliveryStatusFailed {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.replay_outgoing_delivery.not_failed.app_error", nil, "status="+delivery.Status, http.StatusBadRequest)
+	}
+
+	channel, appErr := a.GetChannel(c, delivery.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// The replay replaces the failed delivery, and is recorded again only if it fails too.
+	// Another request may have replayed it already.
+	claimed, err := a.Srv().Store().OutgoingWebhookDelivery().Delete(delivery.Id, model.OutgoingWebhookDeliveryStatusFailed)
+	if err != nil {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.replay_outgoing_delivery.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if !claimed {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusNotFound)
+	}
+
+	replay := &model.OutgoingWebhookDelivery{
+		HookId:      delivery.HookId,
+		ChannelId:   delivery.ChannelId,
+		PostId:      delivery.PostId,
+		CallbackURL: delivery.CallbackURL,
+		ContentType: delivery.ContentType,
+		Payload:     delivery.Payload,
+	}
+
+	logger := c.Logger().With(mlog.String("outgoing_webhook_id", hook.Id), mlog.String("post_id", delivery.PostId), mlog.String("channel_id", delivery.ChannelId), mlog.String("replayed_delivery_id", delivery.Id))
+	webhookResp := a.deliverOutgoingWebhook(c, logger, hook, replay, true)
+	a.handleOutgoingWebhookResponse(c, logger, hook, channel, replay.PostId, webhookResp)
+
+	return replay, nil
+}
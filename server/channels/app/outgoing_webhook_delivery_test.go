@@ -0,0 +1,380 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOutgoingWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, outgoingWebhookRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, outgoingWebhookRetryDelay(2))
+	assert.Equal(t, 16*time.Minute, outgoingWebhookRetryDelay(5))
+	assert.Equal(t, outgoingWebhookRetryMaxDelay, outgoingWebhookRetryDelay(10))
+	assert.Equal(t, outgoingWebhookRetryMaxDelay, outgoingWebhookRetryDelay(1000))
+}
+
+func TestOutgoingWebhookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+		*cfg.ServiceSettings.OutgoingWebhookMaxRetries = 2
+		*cfg.ServiceSettings.OutgoingWebhookDisableAfterFailures = 0
+	})
+
+	var statusCode atomic.Int32
+	var requests atomic.Int32
+	var lastBody atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		lastBody.Store(string(body))
+		w.WriteHeader(int(statusCode.Load()))
+		io.Copy(w, strings.NewReader(`{"text": "response"}`))
+	}))
+	defer server.Close()
+
+	createHook := func(t *testing.T) *model.OutgoingWebhook {
+		t.Helper()
+		hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+			ChannelId:    th.BasicChannel.Id,
+			TeamId:       th.BasicTeam.Id,
+			CallbackURLs: []string{server.URL},
+			CreatorId:    th.BasicUser.Id,
+			TriggerWords: []string{model.NewId()},
+			ContentType:  "application/json",
+		})
+		require.Nil(t, appErr)
+		return hook
+	}
+
+	trigger := func(t *testing.T, hook *model.OutgoingWebhook) []*model.OutgoingWebhookDelivery {
+		t.Helper()
+		th.App.TriggerWebhook(th.Context, &model.OutgoingWebhookPayload{Token: hook.Token}, hook, th.BasicPost, th.BasicChannel)
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, model.OutgoingWebhookDeliveryGetOptions{PerPage: 100})
+		require.Nil(t, appErr)
+		return deliveries
+	}
+
+	// lastPostAfter returns the last post of the channel if it was created after the given time.
+	lastPostAfter := func(t *testing.T, since int64) *model.Post {
+		t.Helper()
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 1})
+		require.Nil(t, appErr)
+		for _, post := range posts.ToSlice() {
+			if post.CreateAt >= since {
+				return post
+			}
+		}
+		return nil
+	}
+
+	t.Run("successful delivery is not logged", func(t *testing.T) {
+		statusCode.Store(http.StatusOK)
+		hook := createHook(t)
+
+		since := model.GetMillis()
+		deliveries := trigger(t, hook)
+		assert.Empty(t, deliveries)
+
+		post := lastPostAfter(t, since)
+		require.NotNil(t, post)
+		assert.Equal(t, "response", post.Message)
+	})
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		statusCode.Store(http.StatusServiceUnavailable)
+		hook := createHook(t)
+
+		since := model.GetMillis()
+		deliveries := trigger(t, hook)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusRetryPending, deliveries[0].Status)
+		assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].StatusCode)
+		assert.Equal(t, `{"text": "response"}`, deliveries[0].Response)
+		assert.Equal(t, th.BasicPost.Id, deliveries[0].PostId)
+		assert.Equal(t, 1, deliveries[0].Attempt)
+		assert.NotZero(t, deliveries[0].NextRetryAt)
+
+		// The response is only posted once the delivery is no longer retried.
+		assert.Nil(t, lastPostAfter(t, since))
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		statusCode.Store(http.StatusNotFound)
+		hook := createHook(t)
+
+		since := model.GetMillis()
+		deliveries := trigger(t, hook)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, deliveries[0].Status)
+		assert.Zero(t, deliveries[0].NextRetryAt)
+
+		post := lastPostAfter(t, since)
+		require.NotNil(t, post)
+		assert.Equal(t, "response", post.Message)
+	})
+
+	t.Run("the token is sent but not logged", func(t *testing.T) {
+		statusCode.Store(http.StatusNotFound)
+		hook := createHook(t)
+
+		deliveries := trigger(t, hook)
+		require.Len(t, deliveries, 1)
+		assert.NotContains(t, deliveries[0].Payload, hook.Token)
+		assert.Contains(t, lastBody.Load(), hook.Token)
+
+		// Replays send the current token of the hook.
+		hook, appErr := th.App.RegenOutgoingWebhookToken(hook)
+		require.Nil(t, appErr)
+		_, appErr = th.App.ReplayOutgoingWebhookDelivery(th.Context, hook, deliveries[0])
+		require.Nil(t, appErr)
+		assert.Contains(t, lastBody.Load(), hook.Token)
+	})
+
+	t.Run("retries until the maximum number of retries", func(t *testing.T) {
+		statusCode.Store(http.StatusInternalServerError)
+		hook := createHook(t)
+
+		deliveries := trigger(t, hook)
+		require.Len(t, deliveries, 1)
+
+		retry := func() {
+			// Make the pending delivery due right away.
+			pending, err := th.App.Srv().Store().OutgoingWebhookDelivery().GetForHook(hook.Id, model.OutgoingWebhookDeliveryGetOptions{Status: model.OutgoingWebhookDeliveryStatusRetryPending, PerPage: 1})
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			th.App.retryOutgoingWebhookDelivery(th.Context, pending[0])
+		}
+
+		retry()
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, model.OutgoingWebhookDeliveryGetOptions{PerPage: 100})
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1, "the retry replaces the pending delivery")
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusRetryPending, deliveries[0].Status)
+		assert.Equal(t, 2, deliveries[0].Attempt)
+
+		// A delivery already retried by another node isn't sent again.
+		before := requests.Load()
+		th.App.retryOutgoingWebhookDelivery(th.Context, deliveries[0])
+		deliveries[0].Id = model.NewId()
+		th.App.retryOutgoingWebhookDelivery(th.Context, deliveries[0])
+		assert.Equal(t, before+1, requests.Load())
+
+		failed, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, model.OutgoingWebhookDeliveryGetOptions{Status: model.OutgoingWebhookDeliveryStatusFailed, PerPage: 100})
+		require.Nil(t, appErr)
+		require.Len(t, failed, 1)
+		assert.Equal(t, 3, failed[0].Attempt)
+	})
+
+	t.Run("retries of disabled hooks are given up", func(t *testing.T) {
+		statusCode.Store(http.StatusInternalServerError)
+		hook := createHook(t)
+
+		deliveries := trigger(t, hook)
+		require.Len(t, deliveries, 1)
+
+		hook.DisabledAt = model.GetMillis()
+		_, err := th.App.Srv().Store().Webhook().UpdateOutgoing(hook)
+		require.NoError(t, err)
+
+		before := requests.Load()
+		th.App.retryOutgoingWebhookDelivery(th.Context, deliveries[0])
+		assert.Equal(t, before, requests.Load())
+
+		delivery, appErr := th.App.GetOutgoingWebhookDelivery(deliveries[0].Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, delivery.Status)
+	})
+
+	t.Run("hooks are disabled after too many failures", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.OutgoingWebhookDisableAfterFailures = 2
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.OutgoingWebhookDisableAfterFailures = 0
+		})
+
+		statusCode.Store(http.StatusBadRequest)
+		hook := createHook(t)
+
+		trigger(t, hook)
+		current, appErr := th.App.GetOutgoingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		assert.False(t, current.IsDisabled())
+
+		// A successful delivery resets the count of failures.
+		statusCode.Store(http.StatusOK)
+		trigger(t, hook)
+		statusCode.Store(http.StatusBadRequest)
+		trigger(t, hook)
+		current, appErr = th.App.GetOutgoingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		assert.False(t, current.IsDisabled())
+
+		trigger(t, hook)
+		current, appErr = th.App.GetOutgoingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		assert.True(t, current.IsDisabled())
+
+		// Disabled hooks are not triggered by new posts.
+		before := requests.Load()
+		_, appErr = th.App.CreatePostAsUser(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   hook.TriggerWords[0],
+		}, "", false)
+		require.Nil(t, appErr)
+		time.Sleep(500 * time.Millisecond)
+		assert.Equal(t, before, requests.Load())
+
+		// Updating the hook doesn't re-enable it, even without the field.
+		update := *current
+		update.DisabledAt = 0
+		updated, appErr := th.App.UpdateOutgoingWebhook(th.Context, current, &update)
+		require.Nil(t, appErr)
+		assert.True(t, updated.IsDisabled())
+
+		enabled, appErr := th.App.EnableOutgoingWebhook(updated)
+		require.Nil(t, appErr)
+		assert.False(t, enabled.IsDisabled())
+	})
+
+	t.Run("replay failed delivery", func(t *testing.T) {
+		statusCode.Store(http.StatusNotFound)
+		hook := createHook(t)
+
+		deliveries := trigger(t, hook)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, deliveries[0].Status)
+
+		statusCode.Store(http.StatusOK)
+		replayed, appErr := th.App.ReplayOutgoingWebhookDelivery(th.Context, hook, deliveries[0])
+		require.Nil(t, appErr)
+		assert.NotEqual(t, deliveries[0].Id, replayed.Id)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, replayed.Status)
+		assert.Equal(t, deliveries[0].Payload, replayed.Payload)
+
+		// The replayed delivery is removed from the log, so it can't be replayed twice.
+		_, appErr = th.App.GetOutgoingWebhookDelivery(deliveries[0].Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		_, appErr = th.App.ReplayOutgoingWebhookDelivery(th.Context, hook, deliveries[0])
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		_, appErr = th.App.ReplayOutgoingWebhookDelivery(th.Context, hook, replayed)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.replay_outgoing_delivery.not_failed.app_error", appErr.Id)
+
+		statusCode.Store(http.StatusNotFound)
+		deliveries = trigger(t, hook)
+		require.Len(t, deliveries, 1)
+		otherHook := createHook(t)
+		_, appErr = th.App.ReplayOutgoingWebhookDelivery(th.Context, otherHook, deliveries[0])
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webhooks.replay_outgoing_delivery.hook_mismatch.app_error", appErr.Id)
+	})
+
+	t.Run("invalid status filter", func(t *testing.T) {
+		_, appErr := th.App.GetOutgoingWebhookDeliveries(model.NewId(), model.OutgoingWebhookDeliveryGetOptions{Status: "unknown", PerPage: 10})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
+
+func TestOutgoingWebhookSigning(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:     th.BasicChannel.Id,
+		TeamId:        th.BasicTeam.Id,
+		CallbackURLs:  []string{server.URL},
+		CreatorId:     th.BasicUser.Id,
+		TriggerWords:  []string{model.NewId()},
+		ContentType:   "application/json",
+		SigningSecret: model.NewOutgoingWebhookSigningSecret(),
+	})
+	require.Nil(t, appErr)
+	require.False(t, hook.IsSigned(), "signing secrets can't be set by clients")
+
+	t.Run("unsigned hook", func(t *testing.T) {
+		th.App.TriggerWebhook(th.Context, &model.OutgoingWebhookPayload{Token: hook.Token}, hook, th.BasicPost, th.BasicChannel)
+		r := <-requests
+		<-bodies
+		assert.Empty(t, r.Header.Get(model.OutgoingWebhookSignatureHeader))
+		assert.Empty(t, r.Header.Get(model.OutgoingWebhookTimestampHeader))
+	})
+
+	t.Run("signed hook", func(t *testing.T) {
+		signed, appErr := th.App.RegenOutgoingWebhookSigningSecret(hook)
+		require.Nil(t, appErr)
+		require.True(t, signed.IsSigned())
+
+		th.App.TriggerWebhook(th.Context, &model.OutgoingWebhookPayload{Token: hook.Token}, signed, th.BasicPost, th.BasicChannel)
+		r := <-requests
+		body := <-bodies
+		require.NoError(t, model.VerifyOutgoingWebhookSignature(signed.SigningSecret, r.Header, body, time.Now(), model.OutgoingWebhookSignatureMaxAge))
+	})
+
+	t.Run("updates keep the signing secret", func(t *testing.T) {
+		current, appErr := th.App.GetOutgoingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		secret := current.SigningSecret
+
+		updated := *current
+		updated.SigningSecret = ""
+		updated.DisplayName = "signed"
+		rhook, appErr := th.App.UpdateOutgoingWebhook(th.Context, current, &updated)
+		require.Nil(t, appErr)
+		assert.Equal(t, secret, rhook.SigningSecret)
+	})
+
+	t.Run("rotate and remove the signing secret", func(t *testing.T) {
+		current, appErr := th.App.GetOutgoingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		secret := current.SigningSecret
+
+		rotated, appErr := th.App.RegenOutgoingWebhookSigningSecret(current)
+		require.Nil(t, appErr)
+		assert.NotEqual(t, secret, rotated.SigningSecret)
+
+		removed, appErr := th.App.RemoveOutgoingWebhookSigningSecret(rotated)
+		require.Nil(t, appErr)
+		assert.False(t, removed.IsSigned())
+
+		current, appErr = th.App.GetOutgoingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		assert.False(t, current.IsSigned())
+	})
+}
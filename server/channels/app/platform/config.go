@@ -71,42 +71,102 @@ func (ps *PlatformService) IsConfigReadOnly() bool {
 // SaveConfig replaces the active configuration, optionally notifying cluster peers.
 // It returns both the previous and current configs.
 func (ps *PlatformService) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError) {
-	if ps.pluginEnv != nil {
-		var hookErr error
-		ps.pluginEnv.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
-			var cfg *model.Config
-			cfg, hookErr = hooks.ConfigurationWillBeSaved(newCfg)
-			if hookErr == nil && cfg != nil {
-				newCfg = cfg
-			}
-			return hookErr == nil
-		}, plugin.ConfigurationWillBeSavedID)
-		if hookErr != nil {
-			if appErr, ok := hookErr.(*model.AppError); ok {
-				return nil, nil, appErr
-			}
-			return nil, nil, model.NewAppError("saveConfig", "app.save_config.plugin_hook_error", nil, "", http.StatusBadRequest).Wrap(hookErr)
-		}
+	return ps.SaveConfigWithAuthor(newCfg, sendConfigChangeClusterMessage, "")
+}
+
+// SaveConfigWithAuthor is like SaveConfig, but records the given user as the author of the new
+// version of the configuration if the backing store keeps its history.
+func (ps *PlatformService) SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, author string) (*model.Config, *model.Config, *model.AppError) {
+	newCfg, appErr := ps.runConfigurationWillBeSavedHook(newCfg)
+	if appErr != nil {
+		return nil, nil, appErr
 	}
 
-	oldCfg, newCfg, err := ps.configStore.Set(newCfg)
+	oldCfg, newCfg, err := ps.configStore.SetWithAuthor(newCfg, author)
 	if errors.Is(err, config.ErrReadOnlyConfiguration) {
 		return nil, nil, model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, "", http.StatusForbidden).Wrap(err)
 	} else if err != nil {
 		return nil, nil, model.NewAppError("saveConfig", "app.save_config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
-	if ps.clusterIFace != nil {
-		err := ps.clusterIFace.ConfigChanged(ps.configStore.RemoveEnvironmentOverrides(oldCfg),
-			ps.configStore.RemoveEnvironmentOverrides(newCfg), sendConfigChangeClusterMessage)
-		if err != nil {
-			return nil, nil, err
+	if appErr := ps.notifyConfigChanged(oldCfg, newCfg, sendConfigChangeClusterMessage); appErr != nil {
+		return nil, nil, appErr
+	}
+
+	return oldCfg, newCfg, nil
+}
+
+// RollbackConfig replaces the active configuration with the given version of it, recording the
+// given user as the author of the change, and notifies cluster peers. The version is passed to
+// prepare before being saved like SaveConfig does. It returns both the previous and current configs.
+func (ps *PlatformService) RollbackConfig(versionID string, author string, prepare func(*model.Config) *model.AppError) (*model.Config, *model.Config, error) {
+	oldCfg, newCfg, err := ps.configStore.Rollback(versionID, author, func(cfg *model.Config) (*model.Config, error) {
+		if appErr := prepare(cfg); appErr != nil {
+			return nil, appErr
+		}
+
+		cfg, appErr := ps.runConfigurationWillBeSavedHook(cfg)
+		if appErr != nil {
+			return nil, appErr
 		}
+		return cfg, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if appErr := ps.notifyConfigChanged(oldCfg, newCfg, true); appErr != nil {
+		return nil, nil, appErr
 	}
 
 	return oldCfg, newCfg, nil
 }
 
+// runConfigurationWillBeSavedHook lets the plugins change or reject a configuration about to be saved.
+func (ps *PlatformService) runConfigurationWillBeSavedHook(newCfg *model.Config) (*model.Config, *model.AppError) {
+	if ps.pluginEnv == nil {
+		return newCfg, nil
+	}
+
+	var hookErr error
+	ps.pluginEnv.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		var cfg *model.Config
+		cfg, hookErr = hooks.ConfigurationWillBeSaved(newCfg)
+		if hookErr == nil && cfg != nil {
+			newCfg = cfg
+		}
+		return hookErr == nil
+	}, plugin.ConfigurationWillBeSavedID)
+	if hookErr != nil {
+		if appErr, ok := hookErr.(*model.AppError); ok {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("saveConfig", "app.save_config.plugin_hook_error", nil, "", http.StatusBadRequest).Wrap(hookErr)
+	}
+
+	return newCfg, nil
+}
+
+// notifyConfigChanged tells the cluster about a saved configuration.
+func (ps *PlatformService) notifyConfigChanged(oldCfg, newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError {
+	if ps.clusterIFace == nil {
+		return nil
+	}
+
+	return ps.clusterIFace.ConfigChanged(ps.configStore.RemoveEnvironmentOverrides(oldCfg),
+		ps.configStore.RemoveEnvironmentOverrides(newCfg), sendConfigChangeClusterMessage)
+}
+
+// GetConfigHistory returns the stored versions of the configuration, newest first.
+func (ps *PlatformService) GetConfigHistory(offset, limit int) ([]*model.ConfigVersion, error) {
+	return ps.configStore.GetHistory(offset, limit)
+}
+
+// GetConfigVersion returns the configuration of the given version.
+func (ps *PlatformService) GetConfigVersion(id string) (*model.Config, error) {
+	return ps.configStore.GetVersion(id)
+}
+
 func (ps *PlatformService) ReloadConfig() error {
 	if err := ps.configStore.Load(); err != nil {
 		return err
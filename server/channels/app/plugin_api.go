@@ -1347,3 +1347,21 @@ func (api *PluginAPI) UninviteRemoteFromChannel(channelID string, remoteID strin
 func (api *PluginAPI) GetPluginID() string {
 	return api.id
 }
+
+func (api *PluginAPI) RegisterFileContentExtractor(extensions []string) error {
+	return api.app.RegisterPluginFileContentExtractor(api.ctx, api.id, extensions)
+}
+
+func (api *PluginAPI) UnregisterFileContentExtractor() error {
+	api.app.UnregisterPluginFileContentExtractor(api.id)
+	return nil
+}
+
+func (api *PluginAPI) RegisterMentionKeywords(keywords []string) error {
+	return api.app.RegisterPluginMentionKeywords(api.id, keywords)
+}
+
+func (api *PluginAPI) UnregisterMentionKeywords() error {
+	api.app.UnregisterPluginMentionKeywords(api.id)
+	return nil
+}
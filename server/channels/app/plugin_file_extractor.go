@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
+)
+
+// pluginFileExtractorBudget is the budget every plugin file content extractor runs with.
+var pluginFileExtractorBudget = docextractor.Budget{
+	MaxFileSize: 50 * 1024 * 1024,
+	Timeout:     30 * time.Second,
+}
+
+// legacyExtractContentExtensions are the file types that were already
+// searchable before the extractor registry was introduced. Files of these
+// types don't need to be processed again.
+var legacyExtractContentExtensions = []string{"doc", "docx", "html", "odt", "pdf", "pptx", "rtf"}
+
+// pluginFileExtractor extracts file contents through the ExtractFileContent hook of a plugin.
+type pluginFileExtractor struct {
+	ch         *Channels
+	pluginID   string
+	extensions []string
+}
+
+func pluginFileExtractorName(pluginID string) string {
+	return "plugin:" + pluginID
+}
+
+func (pe *pluginFileExtractor) Name() string {
+	return pluginFileExtractorName(pe.pluginID)
+}
+
+func (pe *pluginFileExtractor) Extensions() []string {
+	return pe.extensions
+}
+
+func (pe *pluginFileExtractor) Match(filename string) bool {
+	extension := strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+	for _, ext := range pe.extensions {
+		if ext == extension {
+			return true
+		}
+	}
+	return false
+}
+
+func (pe *pluginFileExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	pluginsEnvironment := pe.ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return "", errors.New("plugins are disabled")
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(pe.pluginID)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get hooks for plugin %s", pe.pluginID)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read the file content")
+	}
+
+	return hooks.ExtractFileContent(pluginContext(request.EmptyContext(pe.ch.srv.Log())), filename, content)
+}
+
+// RegisterPluginFileContentExtractor registers a plugin as the content extractor for
+// the given file extensions, replacing any previous registration of the plugin.
+func (a *App) RegisterPluginFileContentExtractor(c request.CTX, pluginID string, extensions []string) error {
+	normalized := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+		if ext == "" {
+			continue
+		}
+		if strings.ContainsAny(ext, "/\\, ") {
+			return fmt.Errorf("invalid file extension %q", ext)
+		}
+		normalized = append(normalized, ext)
+	}
+	if len(normalized) == 0 {
+		return errors.New("at least one file extension is required")
+	}
+
+	a.ch.docExtractors.Register(&pluginFileExtractor{
+		ch:         a.ch,
+		pluginID:   pluginID,
+		extensions: normalized,
+	}, pluginFileExtractorBudget)
+
+	if err := a.Srv().scheduleContentExtraction(c, normalized); err != nil {
+		c.Logger().Warn("Failed to schedule the content extraction of the plugin file types", mlog.String("plugin_id", pluginID), mlog.Err(err))
+	}
+	return nil
+}
+
+// UnregisterPluginFileContentExtractor removes the content extractor registered by a plugin.
+func (a *App) UnregisterPluginFileContentExtractor(pluginID string) {
+	a.ch.unregisterPluginFileContentExtractor(pluginID)
+}
+
+func (ch *Channels) unregisterPluginFileContentExtractor(pluginID string) {
+	ch.docExtractors.Unregister(pluginFileExtractorName(pluginID))
+}
+
+// scheduleContentExtraction creates an extract content job limited to the given
+// file extensions that haven't been processed yet, and records them as processed.
+func (s *Server) scheduleContentExtraction(c request.CTX, extensions []string) error {
+	if !*s.Config().FileSettings.ExtractContent {
+		return nil
+	}
+
+	processed := legacyExtractContentExtensions
+	system, err := s.Store().System().GetByName(model.SystemExtractContentExtensions)
+	if err == nil && system.Value != "" {
+		processed = strings.Split(system.Value, ",")
+	}
+
+	known := make(map[string]bool, len(processed))
+	for _, ext := range processed {
+		known[ext] = true
+	}
+	var newExtensions []string
+	for _, ext := range extensions {
+		if !known[ext] {
+			known[ext] = true
+			newExtensions = append(newExtensions, ext)
+		}
+	}
+	if len(newExtensions) == 0 {
+		return nil
+	}
+	sort.Strings(newExtensions)
+
+	// Every node can get here, so only one extract content job is pending at a time. The
+	// extensions are only recorded as processed once their job is created.
+	job, appErr := s.Jobs.CreateJobOnce(c, model.JobTypeExtractContent, map[string]string{
+		"extensions": strings.Join(newExtensions, ","),
+	})
+	if appErr != nil {
+		return fmt.Errorf("failed to create the extract content job: %w", appErr)
+	}
+	if job == nil {
+		c.Logger().Info("An extract content job is already pending, the new file types will be processed later", mlog.String("extensions", strings.Join(newExtensions, ",")))
+		return nil
+	}
+
+	all := make([]string, 0, len(known))
+	for ext := range known {
+		all = append(all, ext)
+	}
+	sort.Strings(all)
+	if err := s.Store().System().SaveOrUpdate(&model.System{
+		Name:  model.SystemExtractContentExtensions,
+		Value: strings.Join(all, ","),
+	}); err != nil {
+		return fmt.Errorf("failed to save the processed file extensions: %w", err)
+	}
+
+	return nil
+}
+
+// runExtractContentNewFileTypesJob schedules the content extraction of the file types that
+// became supported since the files were uploaded. Only the cluster leader schedules it, and
+// failures don't stop the server as the next start or leader change tries again.
+func runExtractContentNewFileTypesJob(s *Server) {
+	schedule := func() {
+		if !s.IsLeader() {
+			return
+		}
+		c := request.EmptyContext(s.Log())
+		if err := s.scheduleContentExtraction(c, docextractor.SupportedExtensions(nil)); err != nil {
+			c.Logger().Warn("Failed to schedule the content extraction of new file types", mlog.Err(err))
+		}
+	}
+
+	schedule()
+	s.AddClusterLeaderChangedListener(schedule)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
+)
+
+func TestPluginFileContentExtractor(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	tearDown, pluginIDs, activationErrors := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"strings"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			return p.API.RegisterFileContentExtractor([]string{".Dwg"})
+		}
+
+		func (p *MyPlugin) ExtractFileContent(c *plugin.Context, fileName string, content []byte) (string, error) {
+			return "drawing " + strings.ToUpper(string(content)), nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+	require.Len(t, activationErrors, 1)
+	require.NoError(t, activationErrors[0])
+
+	extractors := th.App.ch.docExtractors.Extractors()
+	require.Len(t, extractors, 1)
+	assert.Equal(t, []string{"dwg"}, th.App.ch.docExtractors.Extensions())
+
+	text, err := docextractor.ExtractWithRegistry(th.Context.Logger(), "plan.dwg", bytes.NewReader([]byte("floor")), docextractor.ExtractSettings{}, th.App.ch.docExtractors)
+	require.NoError(t, err)
+	assert.Equal(t, "drawing FLOOR", text)
+
+	appErr := th.App.DisablePlugin(pluginIDs[0])
+	require.Nil(t, appErr)
+	assert.Empty(t, th.App.ch.docExtractors.Extractors())
+}
+
+func TestRegisterPluginFileContentExtractor(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("invalid extensions", func(t *testing.T) {
+		require.Error(t, th.App.RegisterPluginFileContentExtractor(th.Context, "myplugin", nil))
+		require.Error(t, th.App.RegisterPluginFileContentExtractor(th.Context, "myplugin", []string{" ", "."}))
+		require.Error(t, th.App.RegisterPluginFileContentExtractor(th.Context, "myplugin", []string{"a/b"}))
+		assert.Empty(t, th.App.ch.docExtractors.Extractors())
+	})
+
+	t.Run("register and unregister", func(t *testing.T) {
+		require.NoError(t, th.App.RegisterPluginFileContentExtractor(th.Context, "myplugin", []string{"foo"}))
+		require.NoError(t, th.App.RegisterPluginFileContentExtractor(th.Context, "myplugin", []string{"foo", "bar"}))
+		assert.Equal(t, []string{"bar", "foo"}, th.App.ch.docExtractors.Extensions())
+
+		th.App.UnregisterPluginFileContentExtractor("myplugin")
+		assert.Empty(t, th.App.ch.docExtractors.Extractors())
+	})
+}
+
+func TestScheduleContentExtraction(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	getExtensionJobs := func() []string {
+		jobs, err := th.App.Srv().Store().Job().GetAllByType(th.Context, model.JobTypeExtractContent)
+		require.NoError(t, err)
+		var extensions []string
+		for _, job := range jobs {
+			extensions = append(extensions, job.Data["extensions"])
+		}
+		return extensions
+	}
+
+	_, err := th.App.Srv().Store().System().PermanentDeleteByName(model.SystemExtractContentExtensions)
+	require.NoError(t, err)
+	initialJobs := len(getExtensionJobs())
+
+	t.Run("legacy file types are not processed again", func(t *testing.T) {
+		require.NoError(t, th.App.Srv().scheduleContentExtraction(th.Context, []string{"pdf", "docx"}))
+		assert.Len(t, getExtensionJobs(), initialJobs)
+	})
+
+	t.Run("only new file types are processed", func(t *testing.T) {
+		require.NoError(t, th.App.Srv().scheduleContentExtraction(th.Context, []string{"pdf", "xlsx", "epub"}))
+		jobs := getExtensionJobs()
+		require.Len(t, jobs, initialJobs+1)
+		assert.Contains(t, jobs, "epub,xlsx")
+
+		require.NoError(t, th.App.Srv().scheduleContentExtraction(th.Context, []string{"xlsx", "epub"}))
+		assert.Len(t, getExtensionJobs(), initialJobs+1)
+	})
+
+	t.Run("disabled content extraction", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.ExtractContent = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.ExtractContent = true })
+
+		require.NoError(t, th.App.Srv().scheduleContentExtraction(th.Context, []string{"ipynb"}))
+		assert.Len(t, getExtensionJobs(), initialJobs+1)
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// pluginMentionSource resolves mention keywords through the ResolveMentions hook of a plugin.
+type pluginMentionSource struct {
+	ch       *Channels
+	pluginID string
+	keywords []string
+}
+
+func pluginMentionSourceName(pluginID string) string {
+	return "plugin:" + pluginID
+}
+
+func (ps *pluginMentionSource) Name() string {
+	return pluginMentionSourceName(ps.pluginID)
+}
+
+func (ps *pluginMentionSource) Keywords() []string {
+	return ps.keywords
+}
+
+func (ps *pluginMentionSource) ResolveMentions(c request.CTX, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error) {
+	pluginsEnvironment := ps.ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, errors.New("plugins are disabled")
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(ps.pluginID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get hooks for plugin %s", ps.pluginID)
+	}
+
+	return hooks.ResolveMentions(pluginContext(c), post, channel, keywords)
+}
+
+// RegisterPluginMentionKeywords registers a plugin as the source of the given mention
+// keywords, replacing any previous registration of the plugin.
+func (a *App) RegisterPluginMentionKeywords(pluginID string, keywords []string) error {
+	normalized := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(keyword), "@"))
+		if keyword == "" {
+			continue
+		}
+		if strings.ContainsAny(keyword, "@ \t\n") {
+			return fmt.Errorf("invalid mention keyword %q", keyword)
+		}
+		normalized = append(normalized, "@"+keyword)
+	}
+	if len(normalized) == 0 {
+		return errors.New("at least one mention keyword is required")
+	}
+
+	a.RegisterMentionSource(&pluginMentionSource{
+		ch:       a.ch,
+		pluginID: pluginID,
+		keywords: normalized,
+	})
+	return nil
+}
+
+// UnregisterPluginMentionKeywords removes the mention keywords registered by a plugin.
+func (a *App) UnregisterPluginMentionKeywords(pluginID string) {
+	a.ch.unregisterPluginMentionKeywords(pluginID)
+}
+
+func (ch *Channels) unregisterPluginMentionKeywords(pluginID string) {
+	ch.unregisterMentionSource(pluginMentionSourceName(pluginID))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPluginMentionSource(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIDs, activationErrors := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/model"
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			return p.API.RegisterMentionKeywords([]string{"@OnCall"})
+		}
+
+		func (p *MyPlugin) ResolveMentions(c *plugin.Context, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error) {
+			return map[string][]string{"@oncall": {"` + th.BasicUser2.Id + `"}}, nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+	require.Len(t, activationErrors, 1)
+	require.NoError(t, activationErrors[0])
+
+	sources := th.App.ch.getMentionSources()
+	require.Len(t, sources, 1)
+	assert.Equal(t, []string{"@oncall"}, sources[0].Keywords())
+
+	post := &model.Post{
+		Id:        model.NewId(),
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "paging @oncall",
+	}
+	profileMap := map[string]*model.User{
+		th.BasicUser.Id:  th.BasicUser,
+		th.BasicUser2.Id: th.BasicUser2,
+	}
+	mentions := getExplicitMentions(post, MentionKeywords{}.AddMentionSources(sources))
+	th.App.insertSourceMentions(th.Context, th.BasicUser.Id, post, th.BasicChannel, profileMap, mentions)
+	assert.Equal(t, map[string]MentionType{th.BasicUser2.Id: GroupMention}, mentions.Mentions)
+
+	appErr := th.App.DisablePlugin(pluginIDs[0])
+	require.Nil(t, appErr)
+	assert.Empty(t, th.App.ch.getMentionSources())
+}
+
+func TestRegisterPluginMentionKeywords(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("invalid keywords", func(t *testing.T) {
+		require.Error(t, th.App.RegisterPluginMentionKeywords("myplugin", nil))
+		require.Error(t, th.App.RegisterPluginMentionKeywords("myplugin", []string{" ", "@"}))
+		require.Error(t, th.App.RegisterPluginMentionKeywords("myplugin", []string{"team leads"}))
+		assert.Empty(t, th.App.ch.getMentionSources())
+	})
+
+	t.Run("register and unregister", func(t *testing.T) {
+		require.NoError(t, th.App.RegisterPluginMentionKeywords("myplugin", []string{"oncall"}))
+		require.NoError(t, th.App.RegisterPluginMentionKeywords("myplugin", []string{"oncall", "@Team-Leads"}))
+		sources := th.App.ch.getMentionSources()
+		require.Len(t, sources, 1)
+		assert.Equal(t, []string{"@oncall", "@team-leads"}, sources[0].Keywords())
+
+		th.App.UnregisterPluginMentionKeywords("myplugin")
+		assert.Empty(t, th.App.ch.getMentionSources())
+	})
+}
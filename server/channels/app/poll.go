@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// CreatePoll creates a poll post on behalf of the user. The question becomes
+// the message of the post so that it is searchable like any other post.
+func (a *App) CreatePoll(rctx request.CTX, userID string, pollCreate *model.PollCreate) (*model.Post, *model.AppError) {
+	question := strings.TrimSpace(pollCreate.Question)
+	if question == "" {
+		return nil, model.NewAppError("CreatePoll", "app.poll.create.question.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if pollCreate.CloseAt != 0 && pollCreate.CloseAt <= model.GetMillis() {
+		return nil, model.NewAppError("CreatePoll", "app.poll.create.close_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	options := make([]string, 0, len(pollCreate.Options))
+	for _, option := range pollCreate.Options {
+		options = append(options, strings.TrimSpace(option))
+	}
+
+	poll := &model.Poll{
+		UserId:         userID,
+		Options:        options,
+		Anonymous:      pollCreate.Anonymous,
+		MultipleChoice: pollCreate.MultipleChoice,
+		CloseAt:        pollCreate.CloseAt,
+	}
+
+	// Validate the options before creating the post, the post id is not known yet.
+	poll.PostId = model.NewId()
+	if appErr := poll.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	post := &model.Post{
+		ChannelId: pollCreate.ChannelId,
+		RootId:    pollCreate.RootId,
+		UserId:    userID,
+		Message:   question,
+		Type:      model.PostTypePoll,
+	}
+
+	rp, appErr := a.CreatePostAsUser(rctx, post, rctx.Session().Id, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	poll.PostId = rp.Id
+	poll.CreateAt = rp.CreateAt
+	if _, err := a.Srv().Store().Poll().Save(poll); err != nil {
+		if _, delErr := a.DeletePost(rctx, rp.Id, userID); delErr != nil {
+			rctx.Logger().Warn("Failed to delete poll post after the poll could not be saved", mlog.String("post_id", rp.Id), mlog.Err(delErr))
+		}
+
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreatePoll", "app.poll.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return rp, nil
+}
+
+// GetPollResults returns the tally of the poll attached to the post, with the
+// votes of userID filled in.
+func (a *App) GetPollResults(rctx request.CTX, postID, userID string) (*model.PollResults, *model.AppError) {
+	_, poll, appErr := a.getPollForPost(rctx, postID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.buildPollResults(poll, userID)
+}
+
+// VotePoll replaces the user's vote on a poll. An empty choice withdraws the
+// vote. Members of the channel are sent the updated tally.
+func (a *App) VotePoll(rctx request.CTX, postID, userID string, options []int) (*model.PollResults, *model.AppError) {
+	post, poll, appErr := a.getPollForPost(rctx, postID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channel, appErr := a.GetChannel(rctx, post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.archived_channel.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if poll.IsClosed(model.GetMillis()) {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.closed.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !poll.IsValidVote(options) {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.invalid.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := a.Srv().Store().Poll().Vote(postID, userID, options); err != nil {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.sendPollUpdatedEvent(rctx, post, poll)
+
+	return a.buildPollResults(poll, userID)
+}
+
+// ClosePoll stops a poll from accepting votes.
+func (a *App) ClosePoll(rctx request.CTX, postID, userID string) (*model.PollResults, *model.AppError) {
+	post, poll, appErr := a.getPollForPost(rctx, postID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	now := model.GetMillis()
+	if poll.IsClosed(now) {
+		return nil, model.NewAppError("ClosePoll", "app.poll.close.closed.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := a.Srv().Store().Poll().Close(postID, now); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("ClosePoll", "app.poll.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("ClosePoll", "app.poll.close.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	poll.CloseAt = now
+
+	a.sendPollUpdatedEvent(rctx, post, poll)
+
+	return a.buildPollResults(poll, userID)
+}
+
+func (a *App) getPollForPost(rctx request.CTX, postID string) (*model.Post, *model.Poll, *model.AppError) {
+	post, appErr := a.GetSinglePost(rctx, postID, false)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	poll, err := a.Srv().Store().Poll().Get(postID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, nil, model.NewAppError("getPollForPost", "app.poll.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, nil, model.NewAppError("getPollForPost", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return post, poll, nil
+}
+
+func (a *App) buildPollResults(poll *model.Poll, userID string) (*model.PollResults, *model.AppError) {
+	votes, err := a.Srv().Store().Poll().GetVotes(poll.PostId)
+	if err != nil {
+		return nil, model.NewAppError("buildPollResults", "app.poll.get_votes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return model.NewPollResults(poll, votes, userID, model.GetMillis()), nil
+}
+
+func (a *App) sendPollUpdatedEvent(rctx request.CTX, post *model.Post, poll *model.Poll) {
+	results, appErr := a.buildPollResults(poll, "")
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to build poll results", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventPollUpdated, "", post.ChannelId, "", nil, "")
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		rctx.Logger().Warn("Failed to encode poll results to JSON", mlog.Err(err))
+	}
+	message.Add("results", string(resultsJSON))
+	a.Publish(message)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPolls(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("rejects an empty question", func(t *testing.T) {
+		_, appErr := th.App.CreatePoll(th.Context, th.BasicUser.Id, &model.PollCreate{
+			ChannelId: th.BasicChannel.Id,
+			Question:  " ",
+			Options:   []string{"Yes", "No"},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.poll.create.question.app_error", appErr.Id)
+	})
+
+	t.Run("rejects a close time in the past", func(t *testing.T) {
+		_, appErr := th.App.CreatePoll(th.Context, th.BasicUser.Id, &model.PollCreate{
+			ChannelId: th.BasicChannel.Id,
+			Question:  "Lunch?",
+			Options:   []string{"Yes", "No"},
+			CloseAt:   model.GetMillis() - 1000,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("vote, change the vote and close", func(t *testing.T) {
+		post, appErr := th.App.CreatePoll(th.Context, th.BasicUser.Id, &model.PollCreate{
+			ChannelId: th.BasicChannel.Id,
+			Question:  "Lunch?",
+			Options:   []string{"Pizza", "Sushi", "Salad"},
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PostTypePoll, post.Type)
+		assert.Equal(t, "Lunch?", post.Message)
+
+		results, appErr := th.App.VotePoll(th.Context, post.Id, th.BasicUser.Id, []int{0})
+		require.Nil(t, appErr)
+		assert.Equal(t, []int{1, 0, 0}, results.Counts)
+		assert.Equal(t, []int{0}, results.MyVotes)
+
+		_, appErr = th.App.VotePoll(th.Context, post.Id, th.BasicUser2.Id, []int{0, 1})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.poll.vote.invalid.app_error", appErr.Id)
+
+		_, appErr = th.App.VotePoll(th.Context, post.Id, th.BasicUser2.Id, []int{1})
+		require.Nil(t, appErr)
+		results, appErr = th.App.VotePoll(th.Context, post.Id, th.BasicUser.Id, []int{1})
+		require.Nil(t, appErr)
+		assert.Equal(t, []int{0, 2, 0}, results.Counts)
+		assert.Equal(t, 2, results.VoterCount)
+		assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, results.Voters[1])
+
+		results, appErr = th.App.ClosePoll(th.Context, post.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.True(t, results.Closed)
+
+		_, appErr = th.App.VotePoll(th.Context, post.Id, th.BasicUser.Id, []int{2})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.poll.vote.closed.app_error", appErr.Id)
+
+		_, appErr = th.App.ClosePoll(th.Context, post.Id, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.poll.close.closed.app_error", appErr.Id)
+	})
+
+	t.Run("anonymous poll hides voters", func(t *testing.T) {
+		post, appErr := th.App.CreatePoll(th.Context, th.BasicUser.Id, &model.PollCreate{
+			ChannelId:      th.BasicChannel.Id,
+			Question:       "Lunch?",
+			Options:        []string{"Pizza", "Sushi"},
+			Anonymous:      true,
+			MultipleChoice: true,
+		})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.VotePoll(th.Context, post.Id, th.BasicUser2.Id, []int{0, 1})
+		require.Nil(t, appErr)
+
+		results, appErr := th.App.GetPollResults(th.Context, post.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, []int{1, 1}, results.Counts)
+		assert.Nil(t, results.Voters)
+		assert.Empty(t, results.MyVotes)
+	})
+
+	t.Run("regular post has no poll", func(t *testing.T) {
+		_, appErr := th.App.GetPollResults(th.Context, th.BasicPost.Id, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
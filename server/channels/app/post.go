@@ -30,6 +30,11 @@ const (
 	PendingPostIDsCacheSize = 25000
 	PendingPostIDsCacheTTL  = 30 * time.Second
 	PageDefault             = 0
+
+	// postRemindersBatchSize is how many due reminders are sent at a time.
+	postRemindersBatchSize = 1000
+	// postReminderSnoozeWindow is how long a delivered reminder can be snoozed for.
+	postReminderSnoozeWindow = 7 * 24 * time.Hour
 )
 
 var atMentionPattern = regexp.MustCompile(`\B@`)
@@ -2154,7 +2159,7 @@ func (a *App) SetPostReminder(rctx request.CTX, postID, userID string, targetTim
 		UserId:     userID,
 		TargetTime: targetTime,
 	}
-	err := a.Srv().Store().Post().SetPostReminder(reminder)
+	err := a.Srv().Store().PostReminder().Save(reminder)
 	if err != nil {
 		return model.NewAppError("SetPostReminder", model.NoTranslation, nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -2216,12 +2221,14 @@ func (a *App) CheckPostReminders(rctx request.CTX) {
 		return
 	}
 
-	// This will return the reminders and also delete them from the DB.
-	// In case, any of the next steps fail, those reminders would be lost.
-	// Alternatively, if we delete those reminders _after_ it has been sent,
-	// then in case of any temporary failure, they would get sent in the next batch.
-	// MM-45595.
-	reminders, err := a.Srv().Store().Post().GetPostReminders(time.Now().UTC().Unix())
+	now := time.Now()
+	if _, err := a.Srv().Store().PostReminder().DeleteDeliveredBefore(now.Add(-postReminderSnoozeWindow).UnixMilli()); err != nil {
+		rctx.Logger().Warn("Failed to delete delivered post reminders", mlog.Err(err))
+	}
+
+	// A reminder is only marked as delivered once its message is posted, so that
+	// the ones that failed are sent again on the next run.
+	reminders, err := a.Srv().Store().PostReminder().GetDue(now.UTC().Unix(), postRemindersBatchSize)
 	if err != nil {
 		rctx.Logger().Error("Failed to get post reminders", mlog.Err(err))
 		return
@@ -2248,7 +2255,9 @@ func (a *App) CheckPostReminders(rctx request.CTX) {
 		for _, postID := range postIDs {
 			metadata, err := a.Srv().Store().Post().GetPostReminderMetadata(postID)
 			if err != nil {
+				// The post is most likely gone, so the reminder is not retried.
 				rctx.Logger().Error("Failed to get post reminder metadata", mlog.Err(err), mlog.String("post_id", postID))
+				a.markPostReminderDelivered(rctx, postID, userID)
 				continue
 			}
 
@@ -2272,11 +2281,57 @@ func (a *App) CheckPostReminders(rctx request.CTX) {
 
 			if _, err := a.CreatePost(request.EmptyContext(a.Log()), dm, ch, model.CreatePostFlags{SetOnline: true}); err != nil {
 				rctx.Logger().Error("Failed to post reminder message", mlog.Err(err))
+				continue
 			}
+
+			a.markPostReminderDelivered(rctx, postID, userID)
 		}
 	}
 }
 
+func (a *App) markPostReminderDelivered(rctx request.CTX, postID, userID string) {
+	if err := a.Srv().Store().PostReminder().MarkDelivered(postID, userID, model.GetMillis()); err != nil {
+		rctx.Logger().Error("Failed to mark post reminder as delivered", mlog.Err(err), mlog.String("post_id", postID))
+	}
+}
+
+// GetPostRemindersForUser returns the pending reminders of a user, soonest first.
+func (a *App) GetPostRemindersForUser(userID string) ([]*model.PostReminder, *model.AppError) {
+	reminders, err := a.Srv().Store().PostReminder().GetPendingForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetPostRemindersForUser", "app.post_reminder.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reminders, nil
+}
+
+// DeletePostReminder cancels a pending reminder of a user about a post.
+func (a *App) DeletePostReminder(postID, userID string) *model.AppError {
+	if err := a.Srv().Store().PostReminder().Delete(postID, userID); err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError("DeletePostReminder", "app.post_reminder.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return model.NewAppError("DeletePostReminder", "app.post_reminder.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// SnoozePostReminder schedules again the reminder about a post that was
+// delivered to the user, so they are reminded about the post again.
+func (a *App) SnoozePostReminder(postID, userID string, targetTime int64) *model.AppError {
+	if err := a.Srv().Store().PostReminder().Snooze(postID, userID, targetTime); err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError("SnoozePostReminder", "app.post_reminder.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return model.NewAppError("SnoozePostReminder", "app.post_reminder.snooze.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
 func (a *App) GetPostInfo(c request.CTX, postID string) (*model.PostInfo, *model.AppError) {
 	userID := c.Session().UserId
 	post, appErr := a.GetSinglePost(c, postID, false)
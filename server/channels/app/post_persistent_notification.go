@@ -182,6 +182,17 @@ func (a *App) forEachPersistentNotificationPost(posts []*model.Post, fn func(pos
 			keywords := channelKeywords[channel.Id]
 			keywords.AddGroupsMap(channelGroupMap[channel.Id])
 
+			rctx := request.EmptyContext(a.Log())
+			sources := a.ch.getMentionSources()
+			if len(sources) > 0 && a.allowSourceMentions(rctx, post) {
+				// The keywords are shared by the posts of the channel, so the sources are added to a copy.
+				postKeywords := make(MentionKeywords, len(keywords))
+				for keyword, ids := range keywords {
+					postKeywords[keyword] = ids[:len(ids):len(ids)]
+				}
+				keywords = postKeywords.AddMentionSources(sources)
+			}
+
 			mentions = getExplicitMentions(post, keywords)
 			for groupID := range mentions.GroupMentions {
 				group := channelGroupMap[channel.Id][groupID]
@@ -190,6 +201,7 @@ func (a *App) forEachPersistentNotificationPost(posts []*model.Post, fn func(pos
 					return errors.Wrapf(err, "failed to include mentions from group - %s for channel - %s", group.Id, channel.Id)
 				}
 			}
+			a.insertSourceMentions(rctx, post.UserId, post, channel, profileMap, mentions)
 		}
 
 		if err := fn(post, channel, team, mentions, profileMap, channelNotifyProps); err != nil {
@@ -629,7 +629,7 @@ func TestImageProxy(t *testing.T) {
 		*cfg.ServiceSettings.SiteURL = "http://mymattermost.com"
 	})
 
-	th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), th.Server.Log())
+	th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), nil, nil, th.Server.Log())
 
 	for name, tc := range map[string]struct {
 		ProxyType              string
@@ -797,7 +797,7 @@ func TestCreatePost(t *testing.T) {
 			*cfg.ImageProxySettings.RemoteImageProxyOptions = "foo"
 		})
 
-		th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), th.Server.Log())
+		th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), nil, nil, th.Server.Log())
 
 		imageURL := "http://mydomain.com/myimage"
 		proxiedImageURL := "http://mymattermost.com/api/v4/image?url=http%3A%2F%2Fmydomain.com%2Fmyimage"
@@ -1175,7 +1175,7 @@ func TestPatchPost(t *testing.T) {
 			*cfg.ImageProxySettings.RemoteImageProxyOptions = "foo"
 		})
 
-		th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), th.Server.Log())
+		th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), nil, nil, th.Server.Log())
 
 		imageURL := "http://mydomain.com/myimage"
 		proxiedImageURL := "http://mymattermost.com/api/v4/image?url=http%3A%2F%2Fmydomain.com%2Fmyimage"
@@ -1521,7 +1521,7 @@ func TestUpdatePost(t *testing.T) {
 			*cfg.ImageProxySettings.RemoteImageProxyOptions = "foo"
 		})
 
-		th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), th.Server.Log())
+		th.App.ch.imageProxy = imageproxy.MakeImageProxy(th.Server.platform, th.Server.HTTPService(), nil, nil, th.Server.Log())
 
 		imageURL := "http://mydomain.com/myimage"
 		proxiedImageURL := "http://mymattermost.com/api/v4/image?url=http%3A%2F%2Fmydomain.com%2Fmyimage"
@@ -3731,3 +3731,41 @@ func TestSendTestMessage(t *testing.T) {
 		assert.NotEmpty(t, post.GetProp(model.PostPropsForceNotification))
 	})
 }
+
+func TestSnoozePostReminder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// A pending reminder can't be snoozed.
+	appErr := th.App.SetPostReminder(th.Context, th.BasicPost.Id, th.BasicUser.Id, time.Now().Add(-time.Minute).Unix())
+	require.Nil(t, appErr)
+	appErr = th.App.SnoozePostReminder(th.BasicPost.Id, th.BasicUser.Id, time.Now().Add(time.Hour).Unix())
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	// Deliver the reminder that is due.
+	th.App.CheckPostReminders(th.Context)
+
+	reminders, appErr := th.App.GetPostRemindersForUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Empty(t, reminders)
+
+	appErr = th.App.SnoozePostReminder(model.NewId(), th.BasicUser.Id, time.Now().Add(time.Hour).Unix())
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	targetTime := time.Now().Add(time.Hour).Unix()
+	appErr = th.App.SnoozePostReminder(th.BasicPost.Id, th.BasicUser.Id, targetTime)
+	require.Nil(t, appErr)
+
+	reminders, appErr = th.App.GetPostRemindersForUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, th.BasicPost.Id, reminders[0].PostId)
+	assert.Equal(t, targetTime, reminders[0].TargetTime)
+
+	require.Nil(t, th.App.DeletePostReminder(th.BasicPost.Id, th.BasicUser.Id))
+	appErr = th.App.DeletePostReminder(th.BasicPost.Id, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
@@ -17,34 +17,72 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 )
 
 type RateLimiter struct {
 	throttledRateLimiter *throttled.GCRARateLimiter
+	policies             []*rateLimitPolicy
+	metrics              einterfaces.MetricsInterface
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
 }
 
+// rateLimitPolicy is a named quota from RateLimitSettings.Policies, counted
+// separately from the global one.
+type rateLimitPolicy struct {
+	name                 string
+	routes               []string
+	personalAccessTokens bool
+	bots                 bool
+	throttledRateLimiter *throttled.GCRARateLimiter
+}
+
 func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
 	store, err := memstore.New(*settings.MemoryStoreSize)
 	if err != nil {
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
 	}
 
-	quota := throttled.RateQuota{
-		MaxRate:  throttled.PerSec(*settings.PerSec),
-		MaxBurst: *settings.MaxBurst,
-	}
+	return NewRateLimiterWithStore(settings, trustedProxyIPHeader, store, nil)
+}
 
-	throttledRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
+// NewRateLimiterWithStore creates a rate limiter that keeps its counters in
+// the given store, which lets several nodes share them.
+func NewRateLimiterWithStore(settings *model.RateLimitSettings, trustedProxyIPHeader []string, store throttled.GCRAStore, metrics einterfaces.MetricsInterface) (*RateLimiter, error) {
+	throttledRateLimiter, err := newThrottledRateLimiter(store, *settings.PerSec, *settings.MaxBurst)
 	if err != nil {
-		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+		return nil, err
+	}
+
+	policies := make([]*rateLimitPolicy, 0, len(settings.Policies))
+	for _, settingsPolicy := range settings.Policies {
+		policyRateLimiter, err := newThrottledRateLimiter(store, *settingsPolicy.PerSec, *settingsPolicy.MaxBurst)
+		if err != nil {
+			return nil, err
+		}
+
+		routes := make([]string, 0, len(settingsPolicy.Routes))
+		for _, route := range settingsPolicy.Routes {
+			routes = append(routes, strings.TrimSuffix(route, "/"))
+		}
+
+		policies = append(policies, &rateLimitPolicy{
+			name:                 *settingsPolicy.Name,
+			routes:               routes,
+			personalAccessTokens: *settingsPolicy.PersonalAccessTokens,
+			bots:                 *settingsPolicy.Bots,
+			throttledRateLimiter: policyRateLimiter,
+		})
 	}
 
 	return &RateLimiter{
 		throttledRateLimiter: throttledRateLimiter,
+		policies:             policies,
+		metrics:              metrics,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -52,6 +90,89 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 	}, nil
 }
 
+func newThrottledRateLimiter(store throttled.GCRAStore, perSec, maxBurst int) (*throttled.GCRARateLimiter, error) {
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerSec(perSec),
+		MaxBurst: maxBurst,
+	}
+
+	throttledRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
+	if err != nil {
+		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+	}
+
+	return throttledRateLimiter, nil
+}
+
+// newRateLimiter creates the rate limiter of the server, keeping its counters
+// in Redis when RateLimitSettings.Store asks for it so that every node of a
+// cluster enforces the same quotas.
+func (s *Server) newRateLimiter() (*RateLimiter, error) {
+	cfg := s.platform.Config()
+	if *cfg.RateLimitSettings.Store != model.RateLimitStoreRedis {
+		store, err := memstore.New(*cfg.RateLimitSettings.MemoryStoreSize)
+		if err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
+		}
+		return NewRateLimiterWithStore(&cfg.RateLimitSettings, cfg.ServiceSettings.TrustedProxyIPHeader, store, s.GetMetrics())
+	}
+
+	provider, ok := s.platform.CacheProvider().(cache.RateLimitStoreProvider)
+	if !ok {
+		return nil, errors.New(i18n.T("api.server.start_server.rate_limiting_redis_store"))
+	}
+
+	return NewRateLimiterWithStore(&cfg.RateLimitSettings, cfg.ServiceSettings.TrustedProxyIPHeader, provider.NewRateLimitStore("ratelimit"), s.GetMetrics())
+}
+
+// matchesRoute reports whether the request path falls under one of the API
+// routes of the policy. Policies without routes match every path.
+func (p *rateLimitPolicy) matchesRoute(path string) bool {
+	if len(p.routes) == 0 {
+		return true
+	}
+
+	idx := strings.Index(path, model.APIURLSuffix)
+	if idx == -1 {
+		return false
+	}
+	path = path[idx+len(model.APIURLSuffix):]
+
+	for _, route := range p.routes {
+		if path == route || strings.HasPrefix(path, route+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *rateLimitPolicy) matchesSession(session *model.Session) bool {
+	return (p.personalAccessTokens && session.IsUserAccessToken()) || (p.bots && session.IsBotUser())
+}
+
+// routePolicy returns the first policy that targets the path regardless of
+// how the request is authenticated.
+func (rl *RateLimiter) routePolicy(path string) *rateLimitPolicy {
+	for _, policy := range rl.policies {
+		if !policy.personalAccessTokens && !policy.bots && policy.matchesRoute(path) {
+			return policy
+		}
+	}
+	return nil
+}
+
+// sessionPolicy returns the first policy that targets the session, and the
+// path when the policy is restricted to some routes.
+func (rl *RateLimiter) sessionPolicy(session *model.Session, path string) *rateLimitPolicy {
+	for _, policy := range rl.policies {
+		if policy.matchesSession(session) && policy.matchesRoute(path) {
+			return policy
+		}
+	}
+	return nil
+}
+
 func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	key := ""
 
@@ -76,16 +197,23 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 }
 
 func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimit(key, 1)
+	return rl.rateLimit(rl.throttledRateLimiter, model.RateLimitDefaultPolicyName, key, w)
+}
+
+func (rl *RateLimiter) rateLimit(throttledRateLimiter *throttled.GCRARateLimiter, policyName, key string, w http.ResponseWriter) bool {
+	limited, context, err := throttledRateLimiter.RateLimit(key, 1)
 	if err != nil {
-		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
+		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.String("policy", policyName), mlog.Err(err))
 		return false
 	}
 
-	setRateLimitHeaders(w, context)
+	setRateLimitHeaders(w, policyName, context)
 
 	if limited {
-		mlog.Debug("Denied due to throttling settings code=429", mlog.String("key", key))
+		mlog.Debug("Denied due to throttling settings code=429", mlog.String("policy", policyName), mlog.String("key", key))
+		if rl.metrics != nil {
+			rl.metrics.IncrementHTTPRateLimited(policyName)
+		}
 		http.Error(w, "limit exceeded", http.StatusTooManyRequests)
 	}
 
@@ -99,33 +227,62 @@ func (rl *RateLimiter) UserIdRateLimit(userID string, w http.ResponseWriter) boo
 	return false
 }
 
+// SessionRateLimit applies the first policy targeting the personal access
+// token or bot behind the session, counting requests per token or per bot.
+// Other sessions fall back to the per user limit of UserIdRateLimit.
+func (rl *RateLimiter) SessionRateLimit(session *model.Session, r *http.Request, w http.ResponseWriter) bool {
+	policy := rl.sessionPolicy(session, r.URL.Path)
+	if policy == nil {
+		return rl.UserIdRateLimit(session.UserId, w)
+	}
+
+	key := session.UserId
+	if tokenID := session.Props[model.SessionPropUserAccessTokenId]; tokenID != "" && !(policy.bots && session.IsBotUser()) {
+		key = tokenID
+	}
+
+	return rl.rateLimit(policy.throttledRateLimiter, policy.name, policy.name+":"+key, w)
+}
+
+// RateLimitHandler applies the global limit to every request, and then the
+// first policy targeting the route of the request, if any.
 func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		key := rl.GenerateKey(r)
 
-		if !rl.RateLimitWriter(key, w) {
+		limited := rl.RateLimitWriter(key, w)
+		if policy := rl.routePolicy(r.URL.Path); policy != nil && !limited {
+			limited = rl.rateLimit(policy.throttledRateLimiter, policy.name, policy.name+":"+key, w)
+		}
+
+		if !limited {
 			wrappedHandler.ServeHTTP(w, r)
 		}
 	})
 }
 
 // Copied from https://github.com/throttled/throttled http.go
-func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
+//
+// The headers are set rather than added so that a request checked against
+// several policies reports the one that was applied last.
+func setRateLimitHeaders(w http.ResponseWriter, policyName string, context throttled.RateLimitResult) {
+	w.Header().Set("X-RateLimit-Policy", policyName)
+
 	if v := context.Limit; v >= 0 {
-		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
-		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("Retry-After", strconv.Itoa(vi))
+		w.Header().Set("Retry-After", strconv.Itoa(vi))
 	}
 }
@@ -9,9 +9,13 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+	"github.com/throttled/throttled/store/memstore"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
 )
 
 func genRateLimitSettings(useAuth, useIP bool, header string) *model.RateLimitSettings {
@@ -103,3 +107,112 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 	key = rateLimiter.GenerateKey(req)
 	require.Equal(t, "10.10.10.5", key, "Wrong key on test without allowed trusted proxy header")
 }
+
+func TestRateLimitPolicies(t *testing.T) {
+	settings := genRateLimitSettings(true, true, "")
+	settings.MaxBurst = model.NewPointer(5)
+	settings.Policies = []*model.RateLimitPolicy{
+		{Name: model.NewPointer("posts"), Routes: []string{"/posts/"}, MaxBurst: model.NewPointer(1)},
+		{Name: model.NewPointer("bots"), Bots: model.NewPointer(true), MaxBurst: model.NewPointer(1)},
+		{Name: model.NewPointer("tokens"), PersonalAccessTokens: model.NewPointer(true), Routes: []string{"/users"}, MaxBurst: model.NewPointer(1)},
+	}
+	settings.SetDefaults()
+
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("IncrementHTTPRateLimited", mock.Anything).Return()
+
+	rateLimiter, err := NewRateLimiterWithStore(settings, nil, newTestRateLimitStore(t), metrics)
+	require.NoError(t, err)
+
+	handler := rateLimiter.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr + ":80"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("route policy", func(t *testing.T) {
+		// A max burst of 1 lets two requests through before throttling.
+		for range 2 {
+			rec := serve("/api/v4/posts/abc", "10.0.0.1")
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, "posts", rec.Header().Get("X-RateLimit-Policy"))
+			require.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+		}
+
+		rec := serve("/subpath/api/v4/posts", "10.0.0.1")
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.NotEmpty(t, rec.Header().Get("Retry-After"))
+		metrics.AssertCalled(t, "IncrementHTTPRateLimited", "posts")
+
+		// The policy is counted per client and does not affect other routes.
+		require.Equal(t, http.StatusOK, serve("/api/v4/posts", "10.0.0.2").Code)
+
+		rec = serve("/api/v4/postsearch", "10.0.0.1")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, model.RateLimitDefaultPolicyName, rec.Header().Get("X-RateLimit-Policy"))
+		require.Equal(t, "6", rec.Header().Get("X-RateLimit-Limit"))
+
+		// The global limit still applies to the routes of a policy.
+		for range 6 {
+			require.Equal(t, http.StatusOK, serve("/api/v4/users", "10.0.0.3").Code)
+		}
+		rec = serve("/api/v4/posts", "10.0.0.3")
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.Equal(t, model.RateLimitDefaultPolicyName, rec.Header().Get("X-RateLimit-Policy"))
+	})
+
+	t.Run("session policies", func(t *testing.T) {
+		bot := &model.Session{UserId: model.NewId(), Props: model.StringMap{
+			model.SessionPropIsBot:             model.SessionPropIsBotValue,
+			model.SessionPropType:              model.SessionTypeUserAccessToken,
+			model.SessionPropUserAccessTokenId: model.NewId(),
+		}}
+		token := &model.Session{UserId: model.NewId(), Props: model.StringMap{
+			model.SessionPropType:              model.SessionTypeUserAccessToken,
+			model.SessionPropUserAccessTokenId: model.NewId(),
+		}}
+		user := &model.Session{UserId: model.NewId()}
+
+		limit := func(session *model.Session, path string) (bool, string) {
+			rec := httptest.NewRecorder()
+			limited := rateLimiter.SessionRateLimit(session, httptest.NewRequest(http.MethodGet, path, nil), rec)
+			return limited, rec.Header().Get("X-RateLimit-Policy")
+		}
+
+		for range 2 {
+			limited, policy := limit(bot, "/api/v4/channels")
+			require.False(t, limited)
+			require.Equal(t, "bots", policy)
+		}
+		limited, _ := limit(bot, "/api/v4/channels")
+		require.True(t, limited)
+		metrics.AssertCalled(t, "IncrementHTTPRateLimited", "bots")
+
+		for range 2 {
+			limited, policy := limit(token, "/api/v4/users/me")
+			require.False(t, limited)
+			require.Equal(t, "tokens", policy)
+		}
+		limited, _ = limit(token, "/api/v4/users/me")
+		require.True(t, limited)
+
+		// The token policy is restricted to /users, so other routes fall back
+		// to the per user limit.
+		limited, policy := limit(token, "/api/v4/channels")
+		require.False(t, limited)
+		require.Equal(t, model.RateLimitDefaultPolicyName, policy)
+
+		limited, policy = limit(user, "/api/v4/users/me")
+		require.False(t, limited)
+		require.Equal(t, model.RateLimitDefaultPolicyName, policy)
+	})
+}
+
+func newTestRateLimitStore(t *testing.T) throttled.GCRAStore {
+	store, err := memstore.New(100)
+	require.NoError(t, err)
+	return store
+}
@@ -6,9 +6,12 @@ package app
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
@@ -18,8 +21,11 @@ import (
 
 func (a *App) SaveReportChunk(format string, prefix string, count int, reportData []model.ReportableObject) *model.AppError {
 	switch format {
-	case "csv":
+	case model.ReportExportFormatCSV, model.ReportExportFormatXLSX:
+		// XLSX reports are compiled from CSV chunks once every row is known.
 		return a.saveCSVChunk(prefix, count, reportData)
+	case model.ReportExportFormatJSONL:
+		return a.saveJSONLChunk(prefix, count, reportData)
 	}
 	return model.NewAppError("SaveReportChunk", "app.save_report_chunk.unsupported_format", nil, "unsupported report format", http.StatusBadRequest)
 }
@@ -39,20 +45,38 @@ func (a *App) saveCSVChunk(prefix string, count int, reportData []model.Reportab
 	if err := w.Error(); err != nil {
 		return model.NewAppError("saveCSVChunk", "app.save_csv_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
-	_, appErr := a.WriteFile(&buf, makeFilePath(prefix, count, "csv"))
+	_, appErr := a.WriteFile(&buf, makeFilePath(prefix, count, model.ReportExportFormatCSV))
+	return appErr
+}
+
+func (a *App) saveJSONLChunk(prefix string, count int, reportData []model.ReportableObject) *model.AppError {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	for _, report := range reportData {
+		if err := enc.Encode(report); err != nil {
+			return model.NewAppError("saveJSONLChunk", "app.save_jsonl_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	_, appErr := a.WriteFile(&buf, makeFilePath(prefix, count, model.ReportExportFormatJSONL))
 	return appErr
 }
 
 func (a *App) CompileReportChunks(format string, prefix string, numberOfChunks int, headers []string) *model.AppError {
 	switch format {
-	case "csv":
+	case model.ReportExportFormatCSV:
 		return a.compileCSVChunks(prefix, numberOfChunks, headers)
+	case model.ReportExportFormatXLSX:
+		return a.compileXLSXChunks(prefix, numberOfChunks, headers)
+	case model.ReportExportFormatJSONL:
+		return a.compileJSONLChunks(prefix, numberOfChunks)
 	}
 	return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
 }
 
 func (a *App) compileCSVChunks(prefix string, numberOfChunks int, headers []string) *model.AppError {
-	filePath := makeCompiledFilePath(prefix, "csv")
+	filePath := makeCompiledFilePath(prefix, model.ReportExportFormatCSV)
 
 	var compiledBuf bytes.Buffer
 	w := csv.NewWriter(&compiledBuf)
@@ -65,23 +89,88 @@ func (a *App) compileCSVChunks(prefix string, numberOfChunks int, headers []stri
 		return model.NewAppError("saveCSVChunk", "app.save_csv_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if appErr := a.appendReportChunks(&compiledBuf, prefix, numberOfChunks, model.ReportExportFormatCSV); appErr != nil {
+		return appErr
+	}
+
+	_, appErr := a.WriteFile(&compiledBuf, filePath)
+	if appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+// compileXLSXChunks streams the rows of the CSV chunks into the workbook while it's
+// being written, so that only one row is held in memory at a time.
+func (a *App) compileXLSXChunks(prefix string, numberOfChunks int, headers []string) *model.AppError {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(a.writeXLSXChunks(pw, prefix, numberOfChunks, headers))
+	}()
+
+	_, appErr := a.WriteFile(pr, makeCompiledFilePath(prefix, model.ReportExportFormatXLSX))
+	// Unblocks the writer if the file couldn't be written.
+	pr.Close()
+	return appErr
+}
+
+func (a *App) writeXLSXChunks(w io.Writer, prefix string, numberOfChunks int, headers []string) error {
+	xw := newXLSXWriter(w, headers)
 	for i := 0; i < numberOfChunks; i++ {
-		chunkFilePath := makeFilePath(prefix, i, "csv")
-		chunk, err := a.ReadFile(chunkFilePath)
-		if err != nil {
-			return err
+		chunk, appErr := a.FileReader(makeFilePath(prefix, i, model.ReportExportFormatCSV))
+		if appErr != nil {
+			return appErr
 		}
-		_, writeErr := compiledBuf.Write(chunk)
-		if writeErr != nil {
-			return err
+
+		r := csv.NewReader(chunk)
+		r.FieldsPerRecord = -1
+		for {
+			row, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				chunk.Close()
+				return model.NewAppError("compileXLSXChunks", "app.compile_xlsx_chunks.read_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			if err := xw.WriteRow(row); err != nil {
+				chunk.Close()
+				return model.NewAppError("compileXLSXChunks", "app.compile_xlsx_chunks.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
 		}
+		chunk.Close()
 	}
 
-	_, appErr := a.WriteFile(&compiledBuf, filePath)
-	if appErr != nil {
+	if err := xw.Close(); err != nil {
+		return model.NewAppError("compileXLSXChunks", "app.compile_xlsx_chunks.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// compileJSONLChunks concatenates the chunks. Every line is a self describing JSON object, so
+// there's no header to write.
+func (a *App) compileJSONLChunks(prefix string, numberOfChunks int) *model.AppError {
+	var compiledBuf bytes.Buffer
+	if appErr := a.appendReportChunks(&compiledBuf, prefix, numberOfChunks, model.ReportExportFormatJSONL); appErr != nil {
 		return appErr
 	}
 
+	_, appErr := a.WriteFile(&compiledBuf, makeCompiledFilePath(prefix, model.ReportExportFormatJSONL))
+	return appErr
+}
+
+func (a *App) appendReportChunks(buf *bytes.Buffer, prefix string, numberOfChunks int, extension string) *model.AppError {
+	for i := 0; i < numberOfChunks; i++ {
+		chunk, appErr := a.ReadFile(makeFilePath(prefix, i, extension))
+		if appErr != nil {
+			return appErr
+		}
+		if _, err := buf.Write(chunk); err != nil {
+			return model.NewAppError("appendReportChunks", "app.compile_report_chunks.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
 	return nil
 }
 
@@ -129,7 +218,9 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 	post := &model.Post{
 		ChannelId: channel.Id,
 		Message: T("app.report.send_report_to_user.export_finished", map[string]string{
-			"DateRange": getTranslatedDateRange(dateRange),
+			"ReportName": getTranslatedReportName(job.Type),
+			"Format":     strings.ToUpper(format),
+			"DateRange":  getTranslatedDateRange(dateRange),
 		}),
 		Type:    model.PostTypeDefault,
 		UserId:  systemBot.UserId,
@@ -142,15 +233,17 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 
 func (a *App) CleanupReportChunks(format string, prefix string, numberOfChunks int) *model.AppError {
 	switch format {
-	case "csv":
-		return a.cleanupCSVChunks(prefix, numberOfChunks)
+	case model.ReportExportFormatCSV, model.ReportExportFormatXLSX:
+		return a.cleanupReportChunks(prefix, numberOfChunks, model.ReportExportFormatCSV)
+	case model.ReportExportFormatJSONL:
+		return a.cleanupReportChunks(prefix, numberOfChunks, model.ReportExportFormatJSONL)
 	}
 	return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
 }
 
-func (a *App) cleanupCSVChunks(prefix string, numberOfChunks int) *model.AppError {
+func (a *App) cleanupReportChunks(prefix string, numberOfChunks int, extension string) *model.AppError {
 	for i := 0; i < numberOfChunks; i++ {
-		chunkFilePath := makeFilePath(prefix, i, "csv")
+		chunkFilePath := makeFilePath(prefix, i, extension)
 		if err := a.RemoveFile(chunkFilePath); err != nil {
 			return err
 		}
@@ -198,11 +291,46 @@ func (a *App) GetUserCountForReport(filter *model.UserReportOptions) (*int64, *m
 	return &count, nil
 }
 
-func (a *App) StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOptions, startAt int64, endAt int64) *model.AppError {
-	if license := a.Srv().License(); license == nil || (license.SkuShortName != model.LicenseShortSkuProfessional && license.SkuShortName != model.LicenseShortSkuEnterprise) {
-		return model.NewAppError("StartUsersBatchExport", "app.report.start_users_batch_export.license_error", nil, "", http.StatusBadRequest)
+func (a *App) GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, *model.AppError) {
+	if appErr := filter.IsValid(); appErr != nil {
+		return nil, appErr
 	}
 
+	reports, err := a.Srv().Store().Channel().GetChannelActivityReport(filter)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelActivityReport", "app.report.get_channel_activity_report.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reports, nil
+}
+
+func (a *App) GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, *model.AppError) {
+	if appErr := filter.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	reports, err := a.Srv().Store().Post().GetPostVolumeReport(filter)
+	if err != nil {
+		return nil, model.NewAppError("GetPostVolumeReport", "app.report.get_post_volume_report.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reports, nil
+}
+
+func (a *App) GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, *model.AppError) {
+	if appErr := filter.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	reports, err := a.Srv().Store().User().GetGuestAccessReport(filter)
+	if err != nil {
+		return nil, model.NewAppError("GetGuestAccessReport", "app.report.get_guest_access_report.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reports, nil
+}
+
+func (a *App) StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOptions, startAt int64, endAt int64, format string) *model.AppError {
 	options := map[string]string{
 		"requesting_user_id": rctx.Session().UserId,
 		"date_range":         ro.DateRange,
@@ -212,14 +340,47 @@ func (a *App) StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOption
 		"hide_inactive":      strconv.FormatBool(ro.HideInactive),
 		"start_at":           strconv.FormatInt(startAt, 10),
 		"end_at":             strconv.FormatInt(endAt, 10),
+		"format":             format,
+	}
+
+	return a.startBatchReportJob(rctx, model.JobTypeExportUsersToCSV, options)
+}
+
+// StartBatchReportExport starts the batch export of one of the reports that aren't about users.
+// The report is delivered to the requesting user through a direct message from the system bot.
+func (a *App) StartBatchReportExport(rctx request.CTX, reportType string, ro *model.ReportExportOptions) *model.AppError {
+	jobType, ok := batchReportJobTypes[reportType]
+	if !ok || reportType == model.ReportTypeUsers {
+		return model.NewAppError("StartBatchReportExport", "app.report.start_batch_report_export.invalid_report_type", nil, "report_type="+reportType, http.StatusBadRequest)
+	}
+
+	if appErr := ro.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	options := map[string]string{
+		"requesting_user_id": rctx.Session().UserId,
+		"date_range":         ro.DateRange,
+		"team":               ro.Team,
+		"start_at":           strconv.FormatInt(ro.StartAt, 10),
+		"end_at":             strconv.FormatInt(ro.EndAt, 10),
+		"format":             ro.Format,
+	}
+
+	return a.startBatchReportJob(rctx, jobType, options)
+}
+
+func (a *App) startBatchReportJob(rctx request.CTX, jobType string, options map[string]string) *model.AppError {
+	if license := a.Srv().License(); license == nil || (license.SkuShortName != model.LicenseShortSkuProfessional && license.SkuShortName != model.LicenseShortSkuEnterprise) {
+		return model.NewAppError("StartUsersBatchExport", "app.report.start_users_batch_export.license_error", nil, "", http.StatusBadRequest)
 	}
 
 	// Check for existing jobs
-	if err := a.checkForExistingJobs(rctx, options, model.JobTypeExportUsersToCSV); err != nil {
+	if err := a.checkForExistingJobs(rctx, options, jobType); err != nil {
 		return err
 	}
 
-	_, err := a.Srv().Jobs.CreateJob(rctx, model.JobTypeExportUsersToCSV, options)
+	_, err := a.Srv().Jobs.CreateJob(rctx, jobType, options)
 	if err != nil {
 		return err
 	}
@@ -245,9 +406,13 @@ func (a *App) StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOption
 		T := i18n.GetUserTranslations(user.Locale)
 		post := &model.Post{
 			ChannelId: channel.Id,
-			Message:   T("app.report.start_users_batch_export.started_export", map[string]string{"DateRange": getTranslatedDateRange(ro.DateRange)}),
-			Type:      model.PostTypeDefault,
-			UserId:    systemBot.UserId,
+			Message: T("app.report.start_users_batch_export.started_export", map[string]string{
+				"ReportName": getTranslatedReportName(jobType),
+				"Format":     strings.ToUpper(options["format"]),
+				"DateRange":  getTranslatedDateRange(options["date_range"]),
+			}),
+			Type:   model.PostTypeDefault,
+			UserId: systemBot.UserId,
 		}
 
 		if _, err := a.CreatePost(rctx, post, channel, model.CreatePostFlags{SetOnline: true}); err != nil {
@@ -267,7 +432,8 @@ func (a *App) checkForExistingJobs(rctx request.CTX, options map[string]string,
 				job.Data["role"] == options["role"] &&
 				job.Data["team"] == options["team"] &&
 				job.Data["hide_active"] == options["hide_active"] &&
-				job.Data["hide_inactive"] == options["hide_inactive"] {
+				job.Data["hide_inactive"] == options["hide_inactive"] &&
+				job.Data["format"] == options["format"] {
 				return true
 			}
 		}
@@ -293,6 +459,27 @@ func (a *App) checkForExistingJobs(rctx request.CTX, options map[string]string,
 	return nil
 }
 
+// batchReportJobTypes maps the report types to the job that exports them.
+var batchReportJobTypes = map[string]string{
+	model.ReportTypeUsers:           model.JobTypeExportUsersToCSV,
+	model.ReportTypeChannelActivity: model.JobTypeExportChannelActivityReport,
+	model.ReportTypePostVolume:      model.JobTypeExportPostVolumeReport,
+	model.ReportTypeGuestAccess:     model.JobTypeExportGuestAccessReport,
+}
+
+func getTranslatedReportName(jobType string) string {
+	switch jobType {
+	case model.JobTypeExportChannelActivityReport:
+		return i18n.T("app.report.report_name.channel_activity")
+	case model.JobTypeExportPostVolumeReport:
+		return i18n.T("app.report.report_name.post_volume")
+	case model.JobTypeExportGuestAccessReport:
+		return i18n.T("app.report.report_name.guest_access")
+	default:
+		return i18n.T("app.report.report_name.users")
+	}
+}
+
 func getTranslatedDateRange(dateRange string) string {
 	switch dateRange {
 	case model.ReportDurationLast30Days:
@@ -6,6 +6,7 @@ package app
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -62,6 +63,18 @@ func TestSaveReportChunk(t *testing.T) {
 		require.Equal(t, "some-name,400,2024-01-01\n", string(bytes))
 	})
 
+	t.Run("should write JSONL chunk to file", func(t *testing.T) {
+		prefix := model.NewId()
+		err := th.App.SaveReportChunk("jsonl", prefix, 999, []model.ReportableObject{testData[0]})
+		require.Nil(t, err)
+
+		filePath := fmt.Sprintf("admin_reports/batch_report_%s__999.jsonl", prefix)
+		bytes, err := th.App.ReadFile(filePath)
+		require.Nil(t, err)
+		require.Contains(t, string(bytes), `"TestField1":"some-name","TestField2":400`)
+		require.True(t, strings.HasSuffix(string(bytes), "}\n"))
+	})
+
 	t.Run("should fail if the report format is not supported", func(t *testing.T) {
 		err := th.App.SaveReportChunk("zzz", model.NewId(), 999, []model.ReportableObject{testData[0]})
 		require.NotNil(t, err)
@@ -107,6 +120,36 @@ some-other-other-name,600,2022-01-01
 		err = th.App.CompileReportChunks("csv", prefix, 4, []string{"Name", "NumPosts", "StartDate"})
 		require.NotNil(t, err)
 	})
+
+	t.Run("should compile CSV chunks into an XLSX report", func(t *testing.T) {
+		compileErr := th.App.CompileReportChunks("xlsx", prefix, 3, []string{"Name", "NumPosts", "StartDate"})
+		require.Nil(t, compileErr)
+
+		bytes, readErr := th.App.ReadFile(fmt.Sprintf("admin_reports/batch_report_%s.xlsx", prefix))
+		require.Nil(t, readErr)
+
+		sheet := readXLSXPart(t, bytes, "xl/worksheets/sheet1.xml")
+		require.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`)
+		require.Contains(t, sheet, `<c r="A4" t="inlineStr"><is><t xml:space="preserve">some-other-other-name</t></is></c>`)
+	})
+
+	t.Run("should compile JSONL chunks without headers", func(t *testing.T) {
+		jsonlPrefix := model.NewId()
+		for i, data := range testData {
+			err = th.App.SaveReportChunk("jsonl", jsonlPrefix, i, []model.ReportableObject{data})
+			require.Nil(t, err)
+		}
+
+		compileErr := th.App.CompileReportChunks("jsonl", jsonlPrefix, len(testData), []string{"Name", "NumPosts", "StartDate"})
+		require.Nil(t, compileErr)
+
+		bytes, readErr := th.App.ReadFile(fmt.Sprintf("admin_reports/batch_report_%s.jsonl", jsonlPrefix))
+		require.Nil(t, readErr)
+
+		lines := strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n")
+		require.Len(t, lines, 3)
+		require.Contains(t, lines[2], `"TestField1":"some-other-other-name"`)
+	})
 }
 
 func TestCheckForExistingJobs(t *testing.T) {
@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xlsxMaxSheetRows is the maximum number of rows of a sheet, header included.
+const xlsxMaxSheetRows = 1_048_576
+
+// xlsxWriter streams rows into an XLSX workbook. Every cell is written as an
+// inline string, and a new sheet starting with the header is added whenever
+// the current one is full.
+type xlsxWriter struct {
+	zw          *zip.Writer
+	header      []string
+	maxRows     int
+	sheets      int
+	sheet       io.Writer
+	sheetRows   int
+	sheetClosed bool
+}
+
+func newXLSXWriter(w io.Writer, header []string) *xlsxWriter {
+	return &xlsxWriter{
+		zw:      zip.NewWriter(w),
+		header:  header,
+		maxRows: xlsxMaxSheetRows,
+	}
+}
+
+// WriteRow adds a row to the current sheet, starting a new one if needed.
+func (x *xlsxWriter) WriteRow(row []string) error {
+	if x.sheet == nil || x.sheetRows >= x.maxRows {
+		if err := x.startSheet(); err != nil {
+			return err
+		}
+	}
+	return x.writeRow(row)
+}
+
+// Close finishes the last sheet and writes the parts of the workbook listing the sheets.
+func (x *xlsxWriter) Close() error {
+	if x.sheet == nil {
+		if err := x.startSheet(); err != nil {
+			return err
+		}
+	}
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	for i := 1; i <= x.sheets; i++ {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxSheetName(i), i, i)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "[Content_Types].xml",
+			content: xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+				`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+				`<Default Extension="xml" ContentType="application/xml"/>` +
+				`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+				contentTypes.String() +
+				`</Types>`,
+		},
+		{
+			name: "_rels/.rels",
+			content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+				`</Relationships>`,
+		},
+		{
+			name: "xl/workbook.xml",
+			content: xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+				`<sheets>` + workbook.String() + `</sheets>` +
+				`</workbook>`,
+		},
+		{
+			name: "xl/_rels/workbook.xml.rels",
+			content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				workbookRels.String() +
+				`</Relationships>`,
+		},
+	}
+
+	for _, part := range parts {
+		f, err := x.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	return x.zw.Close()
+}
+
+func (x *xlsxWriter) startSheet() error {
+	if x.sheet != nil {
+		if err := x.endSheet(); err != nil {
+			return err
+		}
+	}
+
+	x.sheets++
+	sheet, err := x.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", x.sheets))
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	x.sheetRows = 0
+	x.sheetClosed = false
+
+	if _, err := io.WriteString(x.sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+	return x.writeRow(x.header)
+}
+
+func (x *xlsxWriter) endSheet() error {
+	if x.sheetClosed {
+		return nil
+	}
+	x.sheetClosed = true
+	_, err := io.WriteString(x.sheet, `</sheetData></worksheet>`)
+	return err
+}
+
+func (x *xlsxWriter) writeRow(row []string) error {
+	x.sheetRows++
+	rowNumber := strconv.Itoa(x.sheetRows)
+	if _, err := fmt.Fprintf(x.sheet, `<row r="%s">`, rowNumber); err != nil {
+		return err
+	}
+	for j, value := range row {
+		if _, err := fmt.Fprintf(x.sheet, `<c r="%s%s" t="inlineStr"><is><t xml:space="preserve">`, xlsxColumnName(j), rowNumber); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(x.sheet, []byte(value)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(x.sheet, `</t></is></c>`); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(x.sheet, `</row>`)
+	return err
+}
+
+// xlsxSheetName returns the name of the one based sheet index, e.g. Report and Report 2.
+func xlsxSheetName(index int) string {
+	if index == 1 {
+		return "Report"
+	}
+	return "Report " + strconv.Itoa(index)
+}
+
+// xlsxColumnName returns the spreadsheet name of the zero based column index, e.g. 0 is A and 26 is AA.
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
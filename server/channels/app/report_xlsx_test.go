@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSXColumnName(t *testing.T) {
+	assert.Equal(t, "A", xlsxColumnName(0))
+	assert.Equal(t, "Z", xlsxColumnName(25))
+	assert.Equal(t, "AA", xlsxColumnName(26))
+	assert.Equal(t, "AZ", xlsxColumnName(51))
+	assert.Equal(t, "BA", xlsxColumnName(52))
+	assert.Equal(t, "ZZ", xlsxColumnName(701))
+	assert.Equal(t, "AAA", xlsxColumnName(702))
+}
+
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	f, err := zr.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	t.Run("single sheet", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := newXLSXWriter(&buf, []string{"Name", "Posts"})
+		require.NoError(t, w.WriteRow([]string{"<script> & co", "3"}))
+		require.NoError(t, w.Close())
+
+		for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels"} {
+			readXLSXPart(t, buf.Bytes(), name)
+		}
+		assert.Contains(t, readXLSXPart(t, buf.Bytes(), "xl/workbook.xml"), `<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>`)
+
+		sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+		assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c><c r="B1" t="inlineStr"><is><t xml:space="preserve">Posts</t></is></c></row>`)
+		assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">&lt;script&gt; &amp; co</t></is></c>`)
+	})
+
+	t.Run("only the header", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := newXLSXWriter(&buf, []string{"Name"})
+		require.NoError(t, w.Close())
+
+		sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+		assert.Contains(t, sheet, `<sheetData><row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c></row></sheetData>`)
+	})
+
+	t.Run("full sheets continue on a new sheet", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := newXLSXWriter(&buf, []string{"Name"})
+		w.maxRows = 3
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			require.NoError(t, w.WriteRow([]string{name}))
+		}
+		require.NoError(t, w.Close())
+
+		workbook := readXLSXPart(t, buf.Bytes(), "xl/workbook.xml")
+		assert.Contains(t, workbook, `<sheet name="Report" sheetId="1" r:id="rId1"/><sheet name="Report 2" sheetId="2" r:id="rId2"/><sheet name="Report 3" sheetId="3" r:id="rId3"/>`)
+		assert.Contains(t, readXLSXPart(t, buf.Bytes(), "[Content_Types].xml"), `<Override PartName="/xl/worksheets/sheet3.xml"`)
+		assert.Contains(t, readXLSXPart(t, buf.Bytes(), "xl/_rels/workbook.xml.rels"), `Target="worksheets/sheet3.xml"`)
+
+		sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet2.xml")
+		assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c></row>`)
+		assert.Contains(t, sheet, `<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">c</t></is></c></row>`)
+		assert.Contains(t, sheet, `<row r="3"><c r="A3" t="inlineStr"><is><t xml:space="preserve">d</t></is></c></row>`)
+		assert.NotContains(t, sheet, `<row r="4">`)
+
+		sheet = readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet3.xml")
+		assert.Contains(t, sheet, `<t xml:space="preserve">e</t>`)
+	})
+}
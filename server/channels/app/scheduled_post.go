@@ -4,8 +4,11 @@
 package app
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -15,6 +18,7 @@ import (
 func (a *App) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError) {
 	maxMessageLength := a.Srv().Store().ScheduledPost().GetMaxMessageSize()
 	scheduledPost.PreSave()
+	a.setScheduledPostDefaultTimezone(scheduledPost)
 	if validationErr := scheduledPost.IsValid(maxMessageLength); validationErr != nil {
 		return nil, validationErr
 	}
@@ -113,6 +117,95 @@ func (a *App) DeleteScheduledPost(rctx request.CTX, userId, scheduledPostId, con
 	return scheduledPost, nil
 }
 
+// setScheduledPostDefaultTimezone makes recurring posts follow the author's
+// time zone unless one was picked explicitly.
+func (a *App) setScheduledPostDefaultTimezone(scheduledPost *model.ScheduledPost) {
+	if !scheduledPost.IsRecurring() || scheduledPost.Timezone != "" {
+		return
+	}
+
+	user, appErr := a.GetUser(scheduledPost.UserId)
+	if appErr != nil {
+		return
+	}
+
+	if timezone := model.GetPreferredTimezone(user.Timezone); slices.Contains(a.Timezones().GetSupported(), timezone) {
+		scheduledPost.Timezone = timezone
+	}
+}
+
+func (a *App) getUserScheduledPost(where, userId, scheduledPostId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, err := a.Srv().Store().ScheduledPost().Get(scheduledPostId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewAppError(where, "app.scheduled_post.get.not_found.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError(where, "app.scheduled_post.get.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if scheduledPost.UserId != userId {
+		return nil, model.NewAppError(where, "app.scheduled_post.get.permission.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusForbidden)
+	}
+
+	return scheduledPost, nil
+}
+
+// SetScheduledPostPaused pauses or resumes a scheduled post. Paused posts are
+// skipped by the scheduled posts job. When a recurring post is resumed after
+// its next occurrence has passed, it moves on to the first upcoming one.
+func (a *App) SetScheduledPostPaused(rctx request.CTX, userId, scheduledPostId string, paused bool, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, appErr := a.getUserScheduledPost("App.SetScheduledPostPaused", userId, scheduledPostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if paused == scheduledPost.IsPaused() {
+		return scheduledPost, nil
+	}
+
+	if paused {
+		scheduledPost.PausedAt = model.GetMillis()
+	} else {
+		scheduledPost.PausedAt = 0
+
+		now := model.GetMillis()
+		if scheduledPost.IsRecurring() && scheduledPost.ScheduledAt < now {
+			next, err := scheduledPost.NextOccurrence(now)
+			if err != nil {
+				return nil, model.NewAppError("App.SetScheduledPostPaused", "model.scheduled_post.is_valid.recurrence_rule.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			}
+			if next == 0 {
+				return nil, model.NewAppError("App.SetScheduledPostPaused", "app.scheduled_post.resume.ended.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusBadRequest)
+			}
+			scheduledPost.ScheduledAt = next
+		}
+	}
+
+	if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+		return nil, model.NewAppError("App.SetScheduledPostPaused", "app.update_scheduled_post.update.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, connectionId)
+
+	return scheduledPost, nil
+}
+
+// GetScheduledPostOccurrences returns the times, in milliseconds, of the next
+// count occurrences of a scheduled post.
+func (a *App) GetScheduledPostOccurrences(rctx request.CTX, userId, scheduledPostId string, count int) ([]int64, *model.AppError) {
+	scheduledPost, appErr := a.getUserScheduledPost("App.GetScheduledPostOccurrences", userId, scheduledPostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	occurrences, err := scheduledPost.UpcomingOccurrences(count)
+	if err != nil {
+		return nil, model.NewAppError("App.GetScheduledPostOccurrences", "model.scheduled_post.is_valid.recurrence_rule.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return occurrences, nil
+}
+
 func (a *App) PublishScheduledPostEvent(rctx request.CTX, eventType model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionId string) {
 	if scheduledPost == nil {
 		rctx.Logger().Warn("publishScheduledPostEvent called with nil scheduledPost")
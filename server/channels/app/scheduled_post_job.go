@@ -21,6 +21,10 @@ import (
 const (
 	getPendingScheduledPostsPageSize = 100
 	scheduledPostBatchWaitTime       = 1 * time.Second
+
+	// recurringScheduledPostMaxDelay is how late an occurrence of a recurring
+	// post may still be sent. Older occurrences are skipped.
+	recurringScheduledPostMaxDelay = 24 * time.Hour
 )
 
 func (a *App) ProcessScheduledPosts(rctx request.CTX) {
@@ -106,8 +110,17 @@ func (a *App) ProcessScheduledPosts(rctx request.CTX) {
 func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*model.ScheduledPost) error {
 	var failedScheduledPosts []*model.ScheduledPost
 	var successfulScheduledPostIDs []string
+	var recurringScheduledPosts []*model.ScheduledPost
+
+	missedBefore := model.GetMillis() - recurringScheduledPostMaxDelay.Milliseconds()
 
 	for i := range scheduledPosts {
+		if scheduledPosts[i].IsRecurring() && scheduledPosts[i].ScheduledAt < missedBefore {
+			rctx.Logger().Debug("processScheduledPostBatch skipping missed occurrence of recurring scheduled post", mlog.String("scheduled_post_id", scheduledPosts[i].Id), mlog.Int("scheduled_at", scheduledPosts[i].ScheduledAt))
+			recurringScheduledPosts = append(recurringScheduledPosts, scheduledPosts[i])
+			continue
+		}
+
 		scheduledPost, err := a.postScheduledPost(rctx, scheduledPosts[i])
 		if err != nil {
 			rctx.Logger().Error("processScheduledPostBatch scheduled post processing failed", mlog.String("scheduled_post_id", scheduledPosts[i].Id), mlog.Err(err))
@@ -115,6 +128,11 @@ func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*mode
 			continue
 		}
 
+		if scheduledPost.IsRecurring() {
+			recurringScheduledPosts = append(recurringScheduledPosts, scheduledPost)
+			continue
+		}
+
 		successfulScheduledPostIDs = append(successfulScheduledPostIDs, scheduledPost.Id)
 	}
 
@@ -122,6 +140,10 @@ func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*mode
 		return errors.Wrap(err, "App.processScheduledPostBatch: failed to handle successfully posted scheduled posts")
 	}
 
+	if err := a.rearmRecurringScheduledPosts(rctx, recurringScheduledPosts); err != nil {
+		return errors.Wrap(err, "App.processScheduledPostBatch: failed to re-arm recurring scheduled posts")
+	}
+
 	a.handleFailedScheduledPosts(rctx, failedScheduledPosts)
 	return nil
 }
@@ -191,6 +213,24 @@ func (a *App) postScheduledPost(rctx request.CTX, scheduledPost *model.Scheduled
 		return scheduledPost, err
 	}
 
+	// Files can only be attached to a single post, so every occurrence of a recurring
+	// post gets its own copy of them.
+	if scheduledPost.IsRecurring() && len(post.FileIds) > 0 {
+		fileIDs, appErr := a.CopyFileInfos(rctx, scheduledPost.UserId, post.FileIds)
+		if appErr != nil {
+			rctx.Logger().Error(
+				"App.processScheduledPostBatch: failed to copy the files of a recurring scheduled post",
+				mlog.String("scheduled_post_id", scheduledPost.Id),
+				mlog.String("error_code", model.ScheduledPostErrorUnknownError),
+				mlog.Err(appErr),
+			)
+
+			scheduledPost.ErrorCode = model.ScheduledPostErrorUnknownError
+			return scheduledPost, appErr
+		}
+		post.FileIds = fileIDs
+	}
+
 	createPostFlags := model.CreatePostFlags{
 		TriggerWebhooks: true,
 		SetOnline:       false,
@@ -209,8 +249,11 @@ func (a *App) postScheduledPost(rctx request.CTX, scheduledPost *model.Scheduled
 		return scheduledPost, appErr
 	}
 
-	// send the WS event to delete the just posted scheduledPost from list
-	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+	// send the WS event to delete the just posted scheduledPost from list.
+	// Recurring posts stay in the list and are updated once re-armed.
+	if !scheduledPost.IsRecurring() {
+		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+	}
 
 	return scheduledPost, nil
 }
@@ -332,8 +375,92 @@ func (a *App) handleSuccessfulScheduledPosts(rctx request.CTX, successfulSchedul
 	return nil
 }
 
+// rearmRecurringScheduledPosts moves recurring scheduled posts to their next
+// upcoming occurrence, skipping any occurrences that have already passed.
+// Posts whose recurrence rule has ended are deleted.
+func (a *App) rearmRecurringScheduledPosts(rctx request.CTX, recurringScheduledPosts []*model.ScheduledPost) error {
+	var endedScheduledPosts []*model.ScheduledPost
+	var endedScheduledPostIDs []string
+
+	now := model.GetMillis()
+	for _, scheduledPost := range recurringScheduledPosts {
+		next, err := scheduledPost.NextOccurrence(now)
+		if err != nil {
+			rctx.Logger().Error(
+				"App.rearmRecurringScheduledPosts: failed to compute next occurrence of recurring scheduled post",
+				mlog.String("scheduled_post_id", scheduledPost.Id),
+				mlog.String("recurrence_rule", scheduledPost.RecurrenceRule),
+				mlog.Err(err),
+			)
+			scheduledPost.ErrorCode = model.ScheduledPostErrorInvalidPost
+		} else if next == 0 {
+			endedScheduledPosts = append(endedScheduledPosts, scheduledPost)
+			endedScheduledPostIDs = append(endedScheduledPostIDs, scheduledPost.Id)
+			continue
+		} else {
+			scheduledPost.ScheduledAt = next
+		}
+
+		if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+			// we intentionally don't stop on error as its possible to continue updating other scheduled posts
+			rctx.Logger().Error(
+				"App.rearmRecurringScheduledPosts: failed to update recurring scheduled post",
+				mlog.String("scheduled_post_id", scheduledPost.Id),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, "")
+	}
+
+	if len(endedScheduledPostIDs) > 0 {
+		if err := a.Srv().Store().ScheduledPost().PermanentlyDeleteScheduledPosts(endedScheduledPostIDs); err != nil {
+			return errors.Wrap(err, "App.rearmRecurringScheduledPosts: failed to delete ended recurring scheduled posts")
+		}
+
+		for _, scheduledPost := range endedScheduledPosts {
+			a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+		}
+	}
+
+	return nil
+}
+
+// isPermanentScheduledPostError returns true if a scheduled post failing with the
+// given error code can never be sent, so later occurrences of a recurring post
+// would fail as well.
+func isPermanentScheduledPostError(errorCode string) bool {
+	switch errorCode {
+	case model.ScheduledPostErrorCodeChannelArchived,
+		model.ScheduledPostErrorCodeChannelNotFound,
+		model.ScheduledPostErrorCodeUserDoesNotExist,
+		model.ScheduledPostErrorCodeUserDeleted,
+		model.ScheduledPostErrorThreadDeleted:
+		return true
+	}
+	return false
+}
+
 func (a *App) handleFailedScheduledPosts(rctx request.CTX, failedScheduledPosts []*model.ScheduledPost) {
+	var rearmScheduledPosts []*model.ScheduledPost
+	failedMessages := make([]*model.ScheduledPost, 0, len(failedScheduledPosts))
 	for _, failedScheduledPost := range failedScheduledPosts {
+		if failedScheduledPost.IsRecurring() && !isPermanentScheduledPostError(failedScheduledPost.ErrorCode) {
+			// Only this occurrence failed. The user is notified about it below, and
+			// the post moves on to its next occurrence without the error code.
+			failedMessages = append(failedMessages, &model.ScheduledPost{
+				Id:          failedScheduledPost.Id,
+				Draft:       model.Draft{UserId: failedScheduledPost.UserId, ChannelId: failedScheduledPost.ChannelId},
+				ScheduledAt: failedScheduledPost.ScheduledAt,
+				ErrorCode:   failedScheduledPost.ErrorCode,
+			})
+			failedScheduledPost.ErrorCode = ""
+			rearmScheduledPosts = append(rearmScheduledPosts, failedScheduledPost)
+			continue
+		}
+		failedMessages = append(failedMessages, failedScheduledPost)
+
 		err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(failedScheduledPost)
 		if err != nil {
 			// we intentionally don't stop on error as its possible to continue updating other scheduled posts
@@ -347,13 +474,21 @@ func (a *App) handleFailedScheduledPosts(rctx request.CTX, failedScheduledPosts
 		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, failedScheduledPost, "")
 	}
 
+	if err := a.rearmRecurringScheduledPosts(rctx, rearmScheduledPosts); err != nil {
+		rctx.Logger().Error(
+			"App.handleFailedScheduledPosts: failed to re-arm recurring scheduled posts",
+			mlog.Int("recurring_count", len(rearmScheduledPosts)),
+			mlog.Err(err),
+		)
+	}
+
 	if len(failedScheduledPosts) > 0 {
 		a.Srv().telemetryService.SendTelemetryForFeature(
 			telemetry.TrackScheduledPosts,
 			"scheduled_posts_failed",
 			map[string]any{"count": len(failedScheduledPosts)},
 		)
-		a.notifyUserAboutFailedScheduledMessages(rctx, failedScheduledPosts)
+		a.notifyUserAboutFailedScheduledMessages(rctx, failedMessages)
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProcessScheduledPosts(t *testing.T) {
@@ -263,6 +264,223 @@ func TestProcessScheduledPosts(t *testing.T) {
 		assert.Equal(t, model.ScheduledPostErrorCodeNoChannelPermission, scheduledPosts[1].ErrorCode)
 		assert.Greater(t, scheduledPosts[1].ProcessedAt, int64(0))
 	})
+
+	t.Run("re-arms recurring scheduled posts", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY",
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		assert.Len(t, scheduledPosts, 1)
+		assert.Equal(t, "", scheduledPosts[0].ErrorCode)
+		assert.Equal(t, scheduledAt+24*60*60*1000, scheduledPosts[0].ScheduledAt)
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, Page: 0, PerPage: 1})
+		assert.Nil(t, appErr)
+		assert.Equal(t, "this is a recurring scheduled post", posts.Posts[posts.Order[0]].Message)
+	})
+
+	t.Run("attaches copies of the files to every occurrence of recurring scheduled posts", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		fileInfo, err := th.App.Srv().Store().FileInfo().Save(th.Context, &model.FileInfo{
+			CreatorId: th.BasicUser.Id,
+			Path:      "path.txt",
+			Name:      "file.txt",
+			Extension: "txt",
+			MimeType:  "text/plain",
+			Size:      10,
+		})
+		require.NoError(t, err)
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				FileIds:   []string{fileInfo.Id},
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY",
+		}
+		_, err = th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, Page: 0, PerPage: 1})
+		require.Nil(t, appErr)
+		post := posts.Posts[posts.Order[0]]
+		require.Len(t, post.FileIds, 1)
+		assert.NotEqual(t, fileInfo.Id, post.FileIds[0])
+
+		attached, err := th.App.Srv().Store().FileInfo().Get(post.FileIds[0])
+		require.NoError(t, err)
+		assert.Equal(t, post.Id, attached.PostId)
+		assert.Equal(t, fileInfo.Path, attached.Path)
+
+		// The original stays available for the next occurrences.
+		original, err := th.App.Srv().Store().FileInfo().Get(fileInfo.Id)
+		require.NoError(t, err)
+		assert.Empty(t, original.PostId)
+	})
+
+	t.Run("skips missed occurrences of recurring scheduled posts", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		// three days and one hour ago, so the next daily occurrence is in 23 hours
+		scheduledAt := model.GetMillis() - (3*24+1)*60*60*1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a missed recurring scheduled post",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY",
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		assert.Len(t, scheduledPosts, 1)
+		assert.Equal(t, "", scheduledPosts[0].ErrorCode)
+		assert.Equal(t, scheduledAt+4*24*60*60*1000, scheduledPosts[0].ScheduledAt)
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, Page: 0, PerPage: 1})
+		assert.Nil(t, appErr)
+		assert.NotEqual(t, "this is a missed recurring scheduled post", posts.Posts[posts.Order[0]].Message)
+	})
+
+	t.Run("deletes recurring scheduled posts once their rule ends", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is the last occurrence",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY;UNTIL=" + time.UnixMilli(scheduledAt).UTC().Add(time.Hour).Format("20060102T150405Z"),
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		assert.Len(t, scheduledPosts, 0)
+	})
+
+	t.Run("keeps recurring scheduled posts after a failed occurrence", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY",
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		appErr := th.App.LeaveChannel(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
+		assert.Nil(t, appErr)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		require.Len(t, scheduledPosts, 1)
+		assert.Equal(t, "", scheduledPosts[0].ErrorCode)
+		assert.Equal(t, scheduledAt+24*60*60*1000, scheduledPosts[0].ScheduledAt)
+	})
+
+	t.Run("stops recurring scheduled posts on permanent errors", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY",
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		appErr := th.App.DeleteChannel(th.Context, th.BasicChannel, th.BasicUser.Id)
+		assert.Nil(t, appErr)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		require.Len(t, scheduledPosts, 1)
+		assert.Equal(t, model.ScheduledPostErrorCodeChannelArchived, scheduledPosts[0].ErrorCode)
+		assert.Equal(t, scheduledAt, scheduledPosts[0].ScheduledAt)
+	})
 }
 
 func TestHandleFailedScheduledPosts(t *testing.T) {
@@ -807,3 +807,75 @@ func TestPublishScheduledPostEvent(t *testing.T) {
 		}
 	})
 }
+
+func TestSetScheduledPostPaused(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("recurring post defaults to the author's time zone", func(t *testing.T) {
+		th.BasicUser.Timezone = model.StringMap{"useAutomaticTimezone": "false", "manualTimezone": "Europe/Paris"}
+		_, appErr := th.App.UpdateUser(th.Context, th.BasicUser, false)
+		require.Nil(t, appErr)
+
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt:    model.GetMillis() + 100000,
+			RecurrenceRule: "FREQ=DAILY",
+		}
+		createdScheduledPost, appErr := th.App.SaveScheduledPost(th.Context, scheduledPost, "")
+		require.Nil(t, appErr)
+		require.Equal(t, "Europe/Paris", createdScheduledPost.Timezone)
+	})
+
+	t.Run("resuming moves a recurring post to its next occurrence", func(t *testing.T) {
+		// two days and one hour ago, so the next daily occurrence is in 23 hours
+		scheduledAt := model.GetMillis() - (2*24+1)*60*60*1000
+		scheduledPost, err := th.Server.Store().ScheduledPost().CreateScheduledPost(&model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: "FREQ=DAILY",
+		})
+		require.NoError(t, err)
+
+		paused, appErr := th.App.SetScheduledPostPaused(th.Context, th.BasicUser.Id, scheduledPost.Id, true, "")
+		require.Nil(t, appErr)
+		require.NotZero(t, paused.PausedAt)
+		require.Equal(t, scheduledAt, paused.ScheduledAt)
+
+		resumed, appErr := th.App.SetScheduledPostPaused(th.Context, th.BasicUser.Id, scheduledPost.Id, false, "")
+		require.Nil(t, appErr)
+		require.Zero(t, resumed.PausedAt)
+		require.Equal(t, scheduledAt+3*24*60*60*1000, resumed.ScheduledAt)
+
+		occurrences, appErr := th.App.GetScheduledPostOccurrences(th.Context, th.BasicUser.Id, scheduledPost.Id, 2)
+		require.Nil(t, appErr)
+		require.Equal(t, []int64{resumed.ScheduledAt, resumed.ScheduledAt + 24*60*60*1000}, occurrences)
+	})
+
+	t.Run("cannot pause another user's scheduled post", func(t *testing.T) {
+		scheduledPost, err := th.Server.Store().ScheduledPost().CreateScheduledPost(&model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a scheduled post",
+			},
+			ScheduledAt: model.GetMillis() + 100000,
+		})
+		require.NoError(t, err)
+
+		_, appErr := th.App.SetScheduledPostPaused(th.Context, th.BasicUser2.Id, scheduledPost.Id, true, "")
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+}
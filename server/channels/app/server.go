@@ -40,12 +40,16 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/active_users"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/audit_log_retention"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/cleanup_desktop_tokens"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_dms_preferences_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_orphan_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/expirynotify"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_channel_activity_report"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_delete"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_guest_access_report"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_post_volume_report"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/extract_content"
@@ -57,8 +61,11 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/out_of_office"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/outgoing_webhook_retries"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_reminders"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_post_stats"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
@@ -128,6 +135,7 @@ type Server struct {
 	htmlTemplateWatcher     *templates.Container
 	seenPendingPostIdsCache cache.Cache
 	openGraphDataCache      cache.Cache
+	autoResponderCache      cache.Cache
 	clusterLeaderListenerId string
 	loggerLicenseListenerId string
 
@@ -322,6 +330,12 @@ func NewServer(options ...Option) (*Server, error) {
 	}); err != nil {
 		return nil, errors.Wrap(err, "Unable to create opengraphdata cache")
 	}
+	if s.autoResponderCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "auto_responder",
+		Size: autoResponderCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create auto-responder cache")
+	}
 
 	s.createPushNotificationsHub(request.EmptyContext(s.Log()))
 
@@ -433,7 +447,7 @@ func NewServer(options ...Option) (*Server, error) {
 	allowAdvancedLogging := license != nil && *license.Features.AdvancedLogging
 
 	if s.Audit == nil {
-		s.Audit = &audit.Audit{}
+		s.Audit = &audit.Audit{LogStore: s.Store().AuditLog()}
 		s.Audit.Init(audit.DefMaxQueueSize)
 		if err = s.configureAudit(s.Audit, allowAdvancedLogging); err != nil {
 			mlog.Error("Error configuring audit", mlog.Err(err))
@@ -510,7 +524,6 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		appInstance := New(ServerConnector(s.Channels()))
 		runDNDStatusExpireJob(appInstance)
-		runPostReminderJob(appInstance)
 		runScheduledPostJob(appInstance)
 	})
 	s.Go(func() {
@@ -543,6 +556,9 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		runCloudUserCountReportJob(s)
 	})
+	s.Go(func() {
+		runExtractContentNewFileTypesJob(s)
+	})
 
 	if complianceI := s.Channels().Compliance; complianceI != nil {
 		go complianceI.StartComplianceDailyJob()
@@ -930,7 +946,7 @@ func (s *Server) Start() error {
 	if *s.platform.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
+		rateLimiter, err2 := s.newRateLimiter()
 		if err2 != nil {
 			return err2
 		}
@@ -1633,6 +1649,30 @@ func (s *Server) initJobs() {
 		cleanup_desktop_tokens.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutgoingWebhookRetries,
+		outgoing_webhook_retries.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		outgoing_webhook_retries.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutOfOffice,
+		out_of_office.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		out_of_office.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePostReminders,
+		post_reminders.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		post_reminders.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeAuditLogRetention,
+		audit_log_retention.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		audit_log_retention.MakeScheduler(s.Jobs),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeRefreshPostStats,
 		refresh_post_stats.MakeWorker(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
@@ -1645,6 +1685,24 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeExportChannelActivityReport,
+		export_channel_activity_report.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeExportPostVolumeReport,
+		export_post_volume_report.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeExportGuestAccessReport,
+		export_guest_access_report.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeDeleteDmsPreferencesMigration,
 		delete_dms_preferences_migration.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
@@ -1790,28 +1848,6 @@ func runDNDStatusExpireJob(a *App) {
 	})
 }
 
-func runPostReminderJob(a *App) {
-	if a.IsLeader() {
-		rctx := request.EmptyContext(a.Log())
-		withMut(&a.ch.postReminderMut, func() {
-			fn := func() { a.CheckPostReminders(rctx) }
-			a.ch.postReminderTask = model.CreateRecurringTaskFromNextIntervalTime("Check Post reminders", fn, 5*time.Minute)
-		})
-	}
-	a.ch.srv.AddClusterLeaderChangedListener(func() {
-		mlog.Info("Cluster leader changed. Determining if post reminder task should be running", mlog.Bool("isLeader", a.IsLeader()))
-		if a.IsLeader() {
-			rctx := request.EmptyContext(a.Log())
-			withMut(&a.ch.postReminderMut, func() {
-				fn := func() { a.CheckPostReminders(rctx) }
-				a.ch.postReminderTask = model.CreateRecurringTaskFromNextIntervalTime("Check Post reminders", fn, 5*time.Minute)
-			})
-		} else {
-			cancelTask(&a.ch.postReminderMut, &a.ch.postReminderTask)
-		}
-	})
-}
-
 func runScheduledPostJob(a *App) {
 	if a.IsLeader() {
 		doRunScheduledPostJob(a)
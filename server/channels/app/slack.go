@@ -4,14 +4,15 @@
 package app
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"fmt"
-	"image"
+	"io"
 	"mime/multipart"
 	"regexp"
+	"runtime"
 	"strings"
-	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -21,31 +22,12 @@ import (
 
 func (a *App) SlackImport(c request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer) {
 	actions := slackimport.Actions{
-		UpdateActive: func(user *model.User, active bool) (*model.User, *model.AppError) {
-			return a.UpdateActive(c, user, active)
+		BulkImport: func(jsonlReader io.Reader, attachmentsReader *zip.Reader) (*model.AppError, int) {
+			return a.BulkImportWithPath(c, jsonlReader, attachmentsReader, false, true, runtime.NumCPU(), model.ExportDataDir)
 		},
-		AddUserToChannel: a.AddUserToChannel,
-		JoinUserToTeam: func(team *model.Team, user *model.User, userRequestorId string) (*model.TeamMember, *model.AppError) {
-			return a.JoinUserToTeam(c, team, user, userRequestorId)
-		},
-		CreateDirectChannel: a.createDirectChannel,
-		CreateGroupChannel:  a.createGroupChannel,
-		CreateChannel: func(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
-			return a.CreateChannel(c, channel, addMember)
-		},
-		DoUploadFile: func(now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte) (*model.FileInfo, *model.AppError) {
-			return a.DoUploadFile(c, now, rawTeamId, rawChannelId, rawUserId, rawFilename, data, true)
-		},
-		GenerateThumbnailImage: a.generateThumbnailImage,
-		GeneratePreviewImage:   a.generatePreviewImage,
-		InvalidateAllCaches:    func() { a.ch.srv.InvalidateAllCaches() },
-		MaxPostSize:            func() int { return a.ch.srv.platform.MaxPostSize() },
-		PrepareImage: func(fileData []byte) (image.Image, string, func(), error) {
-			img, imgType, release, err := prepareImage(c, a.ch.imgDecoder, bytes.NewReader(fileData))
-			if err != nil {
-				return nil, "", nil, err
-			}
-			return img, imgType, release, err
+		MaxPostSize: func() int { return a.ch.srv.platform.MaxPostSize() },
+		CanManageSystem: func() bool {
+			return a.SessionHasPermissionTo(*c.Session(), model.PermissionManageSystem)
 		},
 	}
 
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type OutOfOfficeProvider struct {
+}
+
+const (
+	CmdOutOfOffice = "ooo"
+
+	outOfOfficeDateLayout = "2006-01-02"
+)
+
+func init() {
+	app.RegisterCommandProvider(&OutOfOfficeProvider{})
+}
+
+func (*OutOfOfficeProvider) GetTrigger() string {
+	return CmdOutOfOffice
+}
+
+func (*OutOfOfficeProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CmdOutOfOffice,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_ooo.desc"),
+		AutoCompleteHint: T("api.command_ooo.hint"),
+		DisplayName:      T("api.command_ooo.name"),
+	}
+}
+
+func (*OutOfOfficeProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	ooo, appErr := a.GetOutOfOffice(args.UserId)
+	if appErr != nil {
+		return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.app_error"))
+	}
+
+	subcommand, rest, _ := strings.Cut(strings.TrimSpace(message), " ")
+	rest = strings.TrimSpace(rest)
+
+	switch strings.ToLower(subcommand) {
+	case "", "status":
+		return ephemeralOutOfOfficeResponse(outOfOfficeStatusText(args.T, ooo, userLocation(a, args.UserId)))
+	case "off":
+		if appErr := a.DeleteOutOfOffice(c, args.UserId, false); appErr != nil {
+			return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.app_error"))
+		}
+		return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.off.success"))
+	case "on":
+		ooo.Active = true
+		ooo.StartAt = 0
+		ooo.EndAt = 0
+		if rest != "" {
+			ooo.Message = rest
+		}
+	case "until":
+		dateArg, msg, _ := strings.Cut(rest, " ")
+		end, ok := parseOutOfOfficeDate(dateArg, userLocation(a, args.UserId))
+		if !ok {
+			return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.date.app_error", map[string]any{"Date": dateArg}))
+		}
+		ooo.StartAt = 0
+		ooo.EndAt = end.AddDate(0, 0, 1).UnixMilli()
+		if msg = strings.TrimSpace(msg); msg != "" {
+			ooo.Message = msg
+		}
+	case "from":
+		// from <date> to <date> [message]
+		fields := strings.SplitN(rest, " ", 4)
+		if len(fields) < 3 || strings.ToLower(fields[1]) != "to" {
+			return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.usage"))
+		}
+		loc := userLocation(a, args.UserId)
+		start, ok := parseOutOfOfficeDate(fields[0], loc)
+		if !ok {
+			return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.date.app_error", map[string]any{"Date": fields[0]}))
+		}
+		end, ok := parseOutOfOfficeDate(fields[2], loc)
+		if !ok {
+			return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.date.app_error", map[string]any{"Date": fields[2]}))
+		}
+		ooo.StartAt = start.UnixMilli()
+		ooo.EndAt = end.AddDate(0, 0, 1).UnixMilli()
+		if len(fields) == 4 && strings.TrimSpace(fields[3]) != "" {
+			ooo.Message = strings.TrimSpace(fields[3])
+		}
+	case "mentions":
+		switch strings.ToLower(rest) {
+		case "on":
+			ooo.ReplyToMentions = true
+		case "off":
+			ooo.ReplyToMentions = false
+		default:
+			return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.usage"))
+		}
+	case "remote":
+		ooo.RemoteMessage = rest
+	default:
+		return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.usage"))
+	}
+
+	updated, appErr := a.UpdateOutOfOffice(c, args.UserId, ooo, false)
+	if appErr != nil {
+		if appErr.StatusCode < 500 {
+			appErr.Translate(args.T)
+			return ephemeralOutOfOfficeResponse(appErr.Message)
+		}
+		return ephemeralOutOfOfficeResponse(args.T("api.command_ooo.app_error"))
+	}
+
+	return ephemeralOutOfOfficeResponse(outOfOfficeStatusText(args.T, updated, userLocation(a, args.UserId)))
+}
+
+func ephemeralOutOfOfficeResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{ResponseType: model.CommandResponseTypeEphemeral, Text: text}
+}
+
+// userLocation returns the time zone dates typed by the user are read in.
+func userLocation(a *app.App, userID string) *time.Location {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(model.GetPreferredTimezone(user.Timezone))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func parseOutOfOfficeDate(value string, loc *time.Location) (time.Time, bool) {
+	date, err := time.ParseInLocation(outOfOfficeDateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func outOfOfficeStatusText(T i18n.TranslateFunc, ooo *model.OutOfOffice, loc *time.Location) string {
+	var text string
+	switch {
+	case ooo.EndAt > 0 && ooo.StartAt > model.GetMillis():
+		text = T("api.command_ooo.status.scheduled", map[string]any{
+			"Start": time.UnixMilli(ooo.StartAt).In(loc).Format(outOfOfficeDateLayout),
+			"End":   time.UnixMilli(ooo.EndAt).In(loc).AddDate(0, 0, -1).Format(outOfOfficeDateLayout),
+		})
+	case ooo.Active && ooo.EndAt > 0:
+		text = T("api.command_ooo.status.active_until", map[string]any{
+			"End": time.UnixMilli(ooo.EndAt).In(loc).AddDate(0, 0, -1).Format(outOfOfficeDateLayout),
+		})
+	case ooo.Active:
+		text = T("api.command_ooo.status.active")
+	default:
+		return T("api.command_ooo.status.inactive")
+	}
+
+	text += "\n" + T("api.command_ooo.status.message", map[string]any{"Message": ooo.Message})
+	if ooo.RemoteMessage != "" {
+		text += "\n" + T("api.command_ooo.status.remote_message", map[string]any{"Message": ooo.RemoteMessage})
+	}
+	if ooo.ReplyToMentions {
+		text += "\n" + T("api.command_ooo.status.mentions")
+	}
+
+	return text
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+)
+
+func TestOutOfOfficeCommand(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	cmd := &OutOfOfficeProvider{}
+	args := &model.CommandArgs{
+		T:         i18n.IdentityTfunc(),
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+	}
+
+	resp := cmd.DoCommand(th.App, th.Context, args, "")
+	assert.Equal(t, "api.command_ooo.status.inactive", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "on Back on Monday")
+	assert.Contains(t, resp.Text, "api.command_ooo.status.active")
+	ooo, appErr := th.App.GetOutOfOffice(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.True(t, ooo.Active)
+	assert.Equal(t, "Back on Monday", ooo.Message)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "mentions on")
+	assert.Contains(t, resp.Text, "api.command_ooo.status.mentions")
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "from 2999-01-01 2999-01-05")
+	assert.Equal(t, "api.command_ooo.usage", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "until 2999-13-01")
+	assert.Equal(t, "api.command_ooo.date.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "from 2999-01-01 to 2999-01-05 On holiday")
+	assert.Contains(t, resp.Text, "api.command_ooo.status.scheduled")
+	ooo, appErr = th.App.GetOutOfOffice(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.False(t, ooo.Active)
+	assert.Equal(t, "On holiday", ooo.Message)
+	assert.Equal(t, time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), ooo.StartAt)
+	assert.Equal(t, time.Date(2999, 1, 6, 0, 0, 0, 0, time.UTC).UnixMilli(), ooo.EndAt)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "off")
+	assert.Equal(t, "api.command_ooo.off.success", resp.Text)
+	ooo, appErr = th.App.GetOutOfOffice(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.False(t, ooo.Active)
+	assert.False(t, ooo.IsScheduled())
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type PollProvider struct {
+}
+
+const (
+	CmdPoll = "poll"
+)
+
+func init() {
+	app.RegisterCommandProvider(&PollProvider{})
+}
+
+func (*PollProvider) GetTrigger() string {
+	return CmdPoll
+}
+
+func (*PollProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CmdPoll,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_poll.desc"),
+		AutoCompleteHint: T("api.command_poll.hint"),
+		DisplayName:      T("api.command_poll.name"),
+	}
+}
+
+func (*PollProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	pollCreate, closes, ok := parsePollArgs(message)
+	if !ok {
+		return ephemeralPollResponse(args.T("api.command_poll.usage"))
+	}
+
+	if closes != "" {
+		when, ok := parsePollCloseTime(closes, time.Now().In(userLocation(a, args.UserId)))
+		if !ok {
+			return ephemeralPollResponse(args.T("api.command_poll.close.app_error", map[string]any{"When": closes}))
+		}
+		pollCreate.CloseAt = when.UnixMilli()
+	}
+
+	pollCreate.ChannelId = args.ChannelId
+	pollCreate.RootId = args.RootId
+	if _, appErr := a.CreatePoll(c, args.UserId, pollCreate); appErr != nil {
+		appErr.Translate(args.T)
+		return ephemeralPollResponse(appErr.Message)
+	}
+
+	return &model.CommandResponse{}
+}
+
+// parsePollArgs parses `"Question" "Option 1" "Option 2" [--anonymous]
+// [--multi] [--closes <when>]`. Words that are not quoted are taken one by
+// one.
+func parsePollArgs(message string) (*model.PollCreate, string, bool) {
+	args := splitPollArgs(message)
+
+	var values []string
+	var closes string
+	pollCreate := &model.PollCreate{}
+	for i := 0; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "--anonymous":
+			pollCreate.Anonymous = true
+		case "--multi", "--multiple":
+			pollCreate.MultipleChoice = true
+		case "--closes", "--close":
+			if i+1 >= len(args) {
+				return nil, "", false
+			}
+			i++
+			closes = args[i]
+		default:
+			values = append(values, args[i])
+		}
+	}
+
+	if len(values) < 1+model.PollMinOptions {
+		return nil, "", false
+	}
+
+	pollCreate.Question = values[0]
+	pollCreate.Options = values[1:]
+	return pollCreate, closes, true
+}
+
+// splitPollArgs splits a message on spaces, keeping text between straight
+// or curly double quotes together.
+func splitPollArgs(message string) []string {
+	var args []string
+	var current strings.Builder
+	quoted, inArg := false, false
+	for _, r := range message {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			if quoted {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg || quoted {
+		args = append(args, current.String())
+	}
+
+	return args
+}
+
+// parsePollCloseTime accepts a duration such as "2h" or "90m", or any of the
+// phrases understood by /remind.
+func parsePollCloseTime(value string, now time.Time) (time.Time, bool) {
+	if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil {
+		if d <= 0 {
+			return time.Time{}, false
+		}
+		return now.Add(d), true
+	}
+
+	return parseReminderTime(value, now)
+}
+
+func ephemeralPollResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{ResponseType: model.CommandResponseTypeEphemeral, Text: text}
+}
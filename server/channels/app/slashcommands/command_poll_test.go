@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+)
+
+func TestParsePollArgs(t *testing.T) {
+	t.Run("quoted question and options", func(t *testing.T) {
+		pollCreate, closes, ok := parsePollArgs(`"Where do we eat?" "Pizza place" Sushi --multi --anonymous --closes "in 2 hours"`)
+		require.True(t, ok)
+		assert.Equal(t, "Where do we eat?", pollCreate.Question)
+		assert.Equal(t, []string{"Pizza place", "Sushi"}, pollCreate.Options)
+		assert.True(t, pollCreate.MultipleChoice)
+		assert.True(t, pollCreate.Anonymous)
+		assert.Equal(t, "in 2 hours", closes)
+	})
+
+	t.Run("curly quotes", func(t *testing.T) {
+		pollCreate, closes, ok := parsePollArgs(`“Lunch?” “Yes” “No”`)
+		require.True(t, ok)
+		assert.Equal(t, "Lunch?", pollCreate.Question)
+		assert.Equal(t, []string{"Yes", "No"}, pollCreate.Options)
+		assert.False(t, pollCreate.MultipleChoice)
+		assert.Empty(t, closes)
+	})
+
+	for _, message := range []string{
+		"",
+		`"Lunch?"`,
+		`"Lunch?" "Yes"`,
+		`"Lunch?" "Yes" "No" --closes`,
+	} {
+		t.Run(message, func(t *testing.T) {
+			_, _, ok := parsePollArgs(message)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestParsePollCloseTime(t *testing.T) {
+	now := time.Date(2024, 3, 6, 14, 30, 0, 0, time.UTC)
+
+	when, ok := parsePollCloseTime("90m", now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(90*time.Minute), when)
+
+	when, ok = parsePollCloseTime("tomorrow 5pm", now)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 3, 7, 17, 0, 0, 0, time.UTC), when)
+
+	_, ok = parsePollCloseTime("-2h", now)
+	assert.False(t, ok)
+
+	_, ok = parsePollCloseTime("whenever", now)
+	assert.False(t, ok)
+}
+
+func TestPollCommand(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	cmd := &PollProvider{}
+	args := &model.CommandArgs{
+		T:         i18n.IdentityTfunc(),
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+	}
+
+	resp := cmd.DoCommand(th.App, th.Context, args, `"Lunch?" "Yes"`)
+	assert.Equal(t, "api.command_poll.usage", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, `"Lunch?" "Yes" "No" --closes whenever`)
+	assert.Equal(t, "api.command_poll.close.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, `"Lunch?" "Yes" "yes"`)
+	assert.Equal(t, "model.poll.is_valid.option.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, `"Lunch?" "Yes" "No" --anonymous --closes 2h`)
+	assert.Empty(t, resp.Text)
+
+	posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 1})
+	require.Nil(t, appErr)
+	post := posts.Posts[posts.Order[0]]
+	assert.Equal(t, model.PostTypePoll, post.Type)
+	assert.Equal(t, "Lunch?", post.Message)
+
+	results, appErr := th.App.GetPollResults(th.Context, post.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, []string{"Yes", "No"}, []string(results.Poll.Options))
+	assert.True(t, results.Poll.Anonymous)
+	assert.InDelta(t, time.Now().Add(2*time.Hour).UnixMilli(), results.Poll.CloseAt, 60000)
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type RemindProvider struct {
+}
+
+const (
+	CmdRemind = "remind"
+
+	reminderDefaultHour  = 9
+	reminderTimeLayout   = "Mon, Jan 2 2006 at 15:04 MST"
+	reminderPermalinkSeg = "/pl/"
+)
+
+var (
+	reminderInPattern   = regexp.MustCompile(`^in (\d+|an?) ?([a-z]+)$`)
+	reminderTimePattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))? ?(am|pm)?$`)
+	reminderDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+func init() {
+	app.RegisterCommandProvider(&RemindProvider{})
+}
+
+func (*RemindProvider) GetTrigger() string {
+	return CmdRemind
+}
+
+func (*RemindProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CmdRemind,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_remind.desc"),
+		AutoCompleteHint: T("api.command_remind.hint"),
+		DisplayName:      T("api.command_remind.name"),
+	}
+}
+
+func (*RemindProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	message = strings.TrimSpace(message)
+	subcommand, rest, _ := strings.Cut(message, " ")
+	rest = strings.TrimSpace(rest)
+	loc := userLocation(a, args.UserId)
+
+	switch strings.ToLower(subcommand) {
+	case "":
+		return ephemeralReminderResponse(args.T("api.command_remind.usage"))
+	case "list":
+		return listReminders(a, args, loc)
+	case "cancel":
+		postID := parseReminderPostID(rest)
+		if postID == "" {
+			return ephemeralReminderResponse(args.T("api.command_remind.usage"))
+		}
+		if appErr := a.DeletePostReminder(postID, args.UserId); appErr != nil {
+			return reminderErrorResponse(args, appErr)
+		}
+		return ephemeralReminderResponse(args.T("api.command_remind.cancel.success", map[string]any{"Link": reminderPermalink(a, postID)}))
+	case "snooze":
+		// Either "snooze <post link or id> <when>" or, in the thread of a
+		// reminder message, just "snooze <when>".
+		target, phrase, _ := strings.Cut(rest, " ")
+		postID := parseReminderPostID(target)
+		if postID == "" {
+			postID, phrase = snoozedReminderPostID(a, c, args.RootId), rest
+		}
+		if postID == "" {
+			return ephemeralReminderResponse(args.T("api.command_remind.usage"))
+		}
+		when, ok := parseReminderTime(phrase, time.Now().In(loc))
+		if !ok {
+			return ephemeralReminderResponse(args.T("api.command_remind.time.app_error", map[string]any{"When": phrase}))
+		}
+		if appErr := a.SnoozePostReminder(postID, args.UserId, when.Unix()); appErr != nil {
+			return reminderErrorResponse(args, appErr)
+		}
+		return ephemeralReminderResponse(args.T("api.command_remind.success", map[string]any{
+			"Link": reminderPermalink(a, postID),
+			"Time": when.Format(reminderTimeLayout),
+		}))
+	}
+
+	// Either "<post link or id> <when>" or, inside a thread, just "<when>".
+	postID, phrase := parseReminderPostID(subcommand), rest
+	if postID == "" {
+		postID, phrase = args.RootId, message
+	}
+	if postID == "" {
+		return ephemeralReminderResponse(args.T("api.command_remind.usage"))
+	}
+
+	when, ok := parseReminderTime(phrase, time.Now().In(loc))
+	if !ok {
+		return ephemeralReminderResponse(args.T("api.command_remind.time.app_error", map[string]any{"When": phrase}))
+	}
+
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil || !a.HasPermissionToChannel(c, args.UserId, post.ChannelId, model.PermissionReadChannelContent) {
+		return ephemeralReminderResponse(args.T("api.command_remind.post.app_error"))
+	}
+
+	if appErr := a.SetPostReminder(c, postID, args.UserId, when.Unix()); appErr != nil {
+		return reminderErrorResponse(args, appErr)
+	}
+
+	return ephemeralReminderResponse(args.T("api.command_remind.success", map[string]any{
+		"Link": reminderPermalink(a, postID),
+		"Time": when.Format(reminderTimeLayout),
+	}))
+}
+
+func listReminders(a *app.App, args *model.CommandArgs, loc *time.Location) *model.CommandResponse {
+	reminders, appErr := a.GetPostRemindersForUser(args.UserId)
+	if appErr != nil {
+		return reminderErrorResponse(args, appErr)
+	}
+	if len(reminders) == 0 {
+		return ephemeralReminderResponse(args.T("api.command_remind.list.empty"))
+	}
+
+	lines := []string{args.T("api.command_remind.list.title")}
+	for _, reminder := range reminders {
+		lines = append(lines, args.T("api.command_remind.list.item", map[string]any{
+			"Link": reminderPermalink(a, reminder.PostId),
+			"Time": time.Unix(reminder.TargetTime, 0).In(loc).Format(reminderTimeLayout),
+		}))
+	}
+
+	return ephemeralReminderResponse(strings.Join(lines, "\n"))
+}
+
+// snoozedReminderPostID returns the post a reminder message is about, if the
+// given root post is one.
+func snoozedReminderPostID(a *app.App, c request.CTX, rootID string) string {
+	if rootID == "" {
+		return ""
+	}
+	root, appErr := a.GetSinglePost(c, rootID, false)
+	if appErr != nil || root.Type != model.PostTypeReminder {
+		return ""
+	}
+	postID, _ := root.GetProp("post_id").(string)
+	return postID
+}
+
+func ephemeralReminderResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{ResponseType: model.CommandResponseTypeEphemeral, Text: text}
+}
+
+func reminderErrorResponse(args *model.CommandArgs, appErr *model.AppError) *model.CommandResponse {
+	if appErr.StatusCode == http.StatusNotFound {
+		return ephemeralReminderResponse(args.T("api.command_remind.not_found.app_error"))
+	}
+	return ephemeralReminderResponse(args.T("api.command_remind.app_error"))
+}
+
+func reminderPermalink(a *app.App, postID string) string {
+	return a.GetSiteURL() + "/_redirect/pl/" + postID
+}
+
+// parseReminderPostID accepts a post id or a permalink to a post.
+func parseReminderPostID(value string) string {
+	if i := strings.LastIndex(value, reminderPermalinkSeg); i >= 0 {
+		value = value[i+len(reminderPermalinkSeg):]
+	}
+	if !model.IsValidId(value) {
+		return ""
+	}
+	return value
+}
+
+// parseReminderTime understands phrases such as "in 2 hours", "in a day",
+// "tomorrow", "tomorrow 9am", "friday at 14:30", "next monday", "5pm" and
+// "2025-03-01 10am", relative to now and in its location. Days given without
+// a time default to 9am. The returned time is always after now.
+func parseReminderTime(phrase string, now time.Time) (time.Time, bool) {
+	phrase = strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+	if phrase == "" {
+		return time.Time{}, false
+	}
+
+	if m := reminderInPattern.FindStringSubmatch(phrase); m != nil {
+		n := 1
+		if m[1] != "a" && m[1] != "an" {
+			var err error
+			if n, err = strconv.Atoi(m[1]); err != nil || n <= 0 {
+				return time.Time{}, false
+			}
+		}
+
+		switch m[2] {
+		case "m", "min", "mins", "minute", "minutes":
+			return now.Add(time.Duration(n) * time.Minute), true
+		case "h", "hr", "hrs", "hour", "hours":
+			return now.Add(time.Duration(n) * time.Hour), true
+		case "d", "day", "days":
+			return now.AddDate(0, 0, n), true
+		case "w", "week", "weeks":
+			return now.AddDate(0, 0, 7*n), true
+		}
+		return time.Time{}, false
+	}
+
+	words := strings.Split(phrase, " ")
+	year, month, day := now.Date()
+	dayGiven, weekdayGiven := true, false
+	switch {
+	case words[0] == "today":
+		words = words[1:]
+	case words[0] == "tomorrow":
+		day++
+		words = words[1:]
+	case reminderDatePattern.MatchString(words[0]):
+		date, err := time.ParseInLocation("2006-01-02", words[0], now.Location())
+		if err != nil {
+			return time.Time{}, false
+		}
+		year, month, day = date.Date()
+		words = words[1:]
+	case words[0] == "next" && len(words) > 1:
+		weekday, ok := parseReminderWeekday(words[1])
+		if words[1] == "week" {
+			weekday, ok = time.Monday, true
+		}
+		if !ok {
+			return time.Time{}, false
+		}
+		days := (int(weekday) - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		day += days
+		words = words[2:]
+	default:
+		weekday, ok := parseReminderWeekday(words[0])
+		if !ok {
+			dayGiven = false
+			break
+		}
+		day += (int(weekday) - int(now.Weekday()) + 7) % 7
+		weekdayGiven = true
+		words = words[1:]
+	}
+
+	if len(words) > 0 && words[0] == "at" {
+		words = words[1:]
+	}
+
+	hour, minute := reminderDefaultHour, 0
+	if len(words) > 0 {
+		var ok bool
+		if hour, minute, ok = parseReminderClock(strings.Join(words, " ")); !ok {
+			return time.Time{}, false
+		}
+	} else if !dayGiven {
+		return time.Time{}, false
+	}
+
+	target := time.Date(year, month, day, hour, minute, 0, 0, now.Location())
+	if !target.After(now) {
+		switch {
+		case !dayGiven:
+			// A bare time that has passed today means tomorrow.
+			target = target.AddDate(0, 0, 1)
+		case weekdayGiven:
+			// A weekday that is today but whose time has passed means next week.
+			target = target.AddDate(0, 0, 7)
+		default:
+			return time.Time{}, false
+		}
+	}
+
+	return target, true
+}
+
+func parseReminderWeekday(word string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if word == name || word == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func parseReminderClock(value string) (int, int, bool) {
+	switch value {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	m := reminderTimePattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, 0, false
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if minute > 59 {
+		return 0, 0, false
+	}
+
+	switch m[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, false
+		}
+	}
+
+	return hour, minute, true
+}
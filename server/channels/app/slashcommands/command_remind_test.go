@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+)
+
+func TestParseReminderTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Wednesday afternoon.
+	now := time.Date(2024, 3, 6, 14, 30, 0, 0, loc)
+
+	for phrase, expected := range map[string]time.Time{
+		"in 2 hours":          now.Add(2 * time.Hour),
+		"in 2h":               now.Add(2 * time.Hour),
+		"in an hour":          now.Add(time.Hour),
+		"in 15 minutes":       now.Add(15 * time.Minute),
+		"in 3 days":           time.Date(2024, 3, 9, 14, 30, 0, 0, loc),
+		"in a week":           time.Date(2024, 3, 13, 14, 30, 0, 0, loc),
+		"tomorrow":            time.Date(2024, 3, 7, 9, 0, 0, 0, loc),
+		"tomorrow 9am":        time.Date(2024, 3, 7, 9, 0, 0, 0, loc),
+		"Tomorrow at 5:15 PM": time.Date(2024, 3, 7, 17, 15, 0, 0, loc),
+		"today 17:00":         time.Date(2024, 3, 6, 17, 0, 0, 0, loc),
+		"5pm":                 time.Date(2024, 3, 6, 17, 0, 0, 0, loc),
+		"at 9am":              time.Date(2024, 3, 7, 9, 0, 0, 0, loc),
+		"noon":                time.Date(2024, 3, 7, 12, 0, 0, 0, loc),
+		"friday":              time.Date(2024, 3, 8, 9, 0, 0, 0, loc),
+		"mon 8:30am":          time.Date(2024, 3, 11, 8, 30, 0, 0, loc),
+		"wednesday 4pm":       time.Date(2024, 3, 6, 16, 0, 0, 0, loc),
+		"wednesday 9am":       time.Date(2024, 3, 13, 9, 0, 0, 0, loc),
+		"next wednesday":      time.Date(2024, 3, 13, 9, 0, 0, 0, loc),
+		"next week":           time.Date(2024, 3, 11, 9, 0, 0, 0, loc),
+		// Daylight saving time starts on March 10th, the wall clock is kept.
+		"2024-03-12 10am": time.Date(2024, 3, 12, 10, 0, 0, 0, loc),
+	} {
+		t.Run(phrase, func(t *testing.T) {
+			actual, ok := parseReminderTime(phrase, now)
+			require.True(t, ok)
+			assert.True(t, expected.Equal(actual), "expected %s, got %s", expected, actual)
+		})
+	}
+
+	for _, phrase := range []string{
+		"",
+		"someday",
+		"in 0 hours",
+		"in 2 fortnights",
+		"today 9am",
+		"tomorrow 25:00",
+		"tomorrow 13pm",
+		"2024-02-30",
+		"2020-01-01",
+		"next year",
+	} {
+		t.Run(phrase, func(t *testing.T) {
+			_, ok := parseReminderTime(phrase, now)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestParseReminderPostID(t *testing.T) {
+	postID := model.NewId()
+
+	assert.Equal(t, postID, parseReminderPostID(postID))
+	assert.Equal(t, postID, parseReminderPostID("https://example.com/team/pl/"+postID))
+	assert.Empty(t, parseReminderPostID("https://example.com/team/pl/junk"))
+	assert.Empty(t, parseReminderPostID("tomorrow"))
+}
+
+func TestRemindCommand(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	cmd := &RemindProvider{}
+	args := &model.CommandArgs{
+		T:         i18n.IdentityTfunc(),
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+	}
+
+	resp := cmd.DoCommand(th.App, th.Context, args, "list")
+	assert.Equal(t, "api.command_remind.list.empty", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, th.BasicPost.Id+" whenever")
+	assert.Equal(t, "api.command_remind.time.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "in 2 hours")
+	assert.Equal(t, "api.command_remind.usage", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, th.BasicPost.Id+" in 2 hours")
+	assert.Equal(t, "api.command_remind.success", resp.Text)
+
+	reminders, appErr := th.App.GetPostRemindersForUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, th.BasicPost.Id, reminders[0].PostId)
+	assert.InDelta(t, time.Now().Add(2*time.Hour).Unix(), reminders[0].TargetTime, 60)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "list")
+	assert.Contains(t, resp.Text, "api.command_remind.list.item")
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "cancel "+th.BasicPost.Id)
+	assert.Equal(t, "api.command_remind.cancel.success", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "cancel "+th.BasicPost.Id)
+	assert.Equal(t, "api.command_remind.not_found.app_error", resp.Text)
+
+	// Inside a thread the root post is used.
+	args.RootId = th.BasicPost.Id
+	resp = cmd.DoCommand(th.App, th.Context, args, "tomorrow 9am")
+	assert.Equal(t, "api.command_remind.success", resp.Text)
+
+	// Only a delivered reminder can be snoozed.
+	args.RootId = ""
+	resp = cmd.DoCommand(th.App, th.Context, args, "snooze "+th.BasicPost.Id+" in 1 hour")
+	assert.Equal(t, "api.command_remind.not_found.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, th.Context, args, "snooze in 1 hour")
+	assert.Equal(t, "api.command_remind.usage", resp.Text)
+}
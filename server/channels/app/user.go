@@ -751,33 +751,58 @@ func (a *App) GenerateMfaSecret(userID string) (*model.MfaSecret, *model.AppErro
 	return mfaSecret, nil
 }
 
-func (a *App) ActivateMfa(userID, token string) *model.AppError {
+// ActivateMfa turns on MFA for a user and returns their recovery codes.
+func (a *App) ActivateMfa(userID, token string) ([]string, *model.AppError) {
 	user, appErr := a.GetUser(userID)
 	if appErr != nil {
-		return appErr
+		return nil, appErr
 	}
 
 	if user.AuthService != "" && user.AuthService != model.UserAuthServiceLdap {
-		return model.NewAppError("ActivateMfa", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
+		return nil, model.NewAppError("ActivateMfa", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
 	}
 
 	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
-		return model.NewAppError("ActivateMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+		return nil, model.NewAppError("ActivateMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if err := a.ch.srv.userService.ActivateMfa(user, token); err != nil {
+	recoveryCodes, err := a.ch.srv.userService.ActivateMfa(user, token)
+	if err != nil {
 		switch {
 		case errors.Is(err, mfa.InvalidToken):
-			return model.NewAppError("ActivateMfa", "mfa.activate.bad_token.app_error", nil, "", http.StatusUnauthorized)
+			return nil, model.NewAppError("ActivateMfa", "mfa.activate.bad_token.app_error", nil, "", http.StatusUnauthorized)
 		default:
-			return model.NewAppError("ActivateMfa", "mfa.activate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			return nil, model.NewAppError("ActivateMfa", "mfa.activate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
 	}
 
 	// Make sure old MFA status is not cached locally or in cluster nodes.
 	a.InvalidateCacheForUser(userID)
 
-	return nil
+	return recoveryCodes, nil
+}
+
+// RegenerateMfaRecoveryCodes replaces the recovery codes of a user who has MFA turned on.
+func (a *App) RegenerateMfaRecoveryCodes(userID string) ([]string, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("RegenerateMfaRecoveryCodes", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !user.MfaActive {
+		return nil, model.NewAppError("RegenerateMfaRecoveryCodes", "app.user.mfa_recovery_codes.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	recoveryCodes, err := a.ch.srv.userService.GenerateMfaRecoveryCodes(user)
+	if err != nil {
+		return nil, model.NewAppError("RegenerateMfaRecoveryCodes", "app.user.mfa_recovery_codes.generate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return recoveryCodes, nil
 }
 
 func (a *App) DeactivateMfa(userID string) *model.AppError {
@@ -790,6 +815,11 @@ func (a *App) DeactivateMfa(userID string) *model.AppError {
 		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	// Turning MFA off removes every second factor, security keys included.
+	if err := a.Srv().Store().WebAuthnCredential().PermanentDeleteByUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "app.webauthn.delete_credentials.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	// Make sure old MFA status is not cached locally or in cluster nodes.
 	a.InvalidateCacheForUser(userID)
 
@@ -1312,6 +1342,12 @@ func (a *App) UpdateUser(c request.CTX, user *model.User, sendNotifications bool
 
 	newUser := userUpdate.New
 
+	if newUser.Username != userUpdate.Old.Username ||
+		newUser.NotifyProps[model.AutoResponderActiveNotifyProp] != userUpdate.Old.NotifyProps[model.AutoResponderActiveNotifyProp] ||
+		newUser.NotifyProps[model.AutoResponderMentionsNotifyProp] != userUpdate.Old.NotifyProps[model.AutoResponderMentionsNotifyProp] {
+		a.invalidateAutoResponderMentionUsernames()
+	}
+
 	if (newUser.Username != userUpdate.Old.Username) && (newUser.LastPictureUpdate <= 0) {
 		// When a username is updated and the profile is still using a default profile picture, generate a new one based on their username
 		if err := a.UpdateDefaultProfileImage(c, newUser); err != nil {
@@ -1392,14 +1428,18 @@ func (a *App) updateUserNotifyProps(userID string, props map[string]string) *mod
 	return nil
 }
 
-func (a *App) UpdateMfa(c request.CTX, activate bool, userID, token string) *model.AppError {
+// UpdateMfa turns MFA on or off for a user. The recovery codes of the user are returned when
+// MFA is turned on.
+func (a *App) UpdateMfa(c request.CTX, activate bool, userID, token string) ([]string, *model.AppError) {
+	var recoveryCodes []string
 	if activate {
-		if err := a.ActivateMfa(userID, token); err != nil {
-			return err
+		var err *model.AppError
+		if recoveryCodes, err = a.ActivateMfa(userID, token); err != nil {
+			return nil, err
 		}
 	} else {
 		if err := a.DeactivateMfa(userID); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -1415,7 +1455,7 @@ func (a *App) UpdateMfa(c request.CTX, activate bool, userID, token string) *mod
 		}
 	})
 
-	return nil
+	return recoveryCodes, nil
 }
 
 func (a *App) UpdatePasswordByUserIdSendEmail(c request.CTX, userID, newPassword, method string) *model.AppError {
@@ -1832,6 +1872,14 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.scheduled_post.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().OutOfOffice().Delete(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.out_of_office.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.webauthn.delete_credentials.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().Bot().PermanentDelete(user.Id); err != nil {
 		var invErr *store.ErrInvalidInput
 		switch {
@@ -0,0 +1,324 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn"
+)
+
+// webAuthnConfig returns the relying party configuration. The credentials are scoped to the
+// domain of the site URL.
+func (a *App) webAuthnConfig() (*webauthn.Config, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("webAuthnConfig", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	config, err := webauthn.NewConfig(a.GetSiteURL(), *a.Config().TeamSettings.SiteName)
+	if err != nil {
+		return nil, model.NewAppError("webAuthnConfig", "app.webauthn.site_url.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	}
+
+	return config, nil
+}
+
+// isWebAuthnAssertion tells a WebAuthn assertion, which is a JSON object, apart from a TOTP code.
+func isWebAuthnAssertion(token string) bool {
+	return strings.HasPrefix(strings.TrimSpace(token), "{")
+}
+
+func formatAAGUID(aaguid []byte) string {
+	if len(aaguid) != 16 {
+		return ""
+	}
+
+	s := hex.EncodeToString(aaguid)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// createWebAuthnChallenge starts a ceremony for a user. The token doubles as the challenge, so
+// that the ceremony can be found from the client data that the authenticator signed.
+func (a *App) createWebAuthnChallenge(tokenType, userID string) (*model.Token, *model.AppError) {
+	token := model.NewToken(tokenType, userID)
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		return nil, model.NewAppError("createWebAuthnChallenge", "app.webauthn.save_challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return token, nil
+}
+
+// consumeWebAuthnChallenge returns the ceremony of a user that the client data was signed for.
+// A challenge can only be answered once.
+func (a *App) consumeWebAuthnChallenge(tokenType, userID, clientDataJSON string) (*model.Token, error) {
+	challenge, err := webauthn.Challenge(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.Srv().Store().Token().GetByToken(string(challenge))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv().Store().Token().Delete(token.Token); err != nil {
+		return nil, err
+	}
+
+	if token.Type != tokenType || token.Extra != userID {
+		return nil, errors.New("the challenge belongs to another ceremony")
+	}
+
+	if model.GetMillis()-token.CreateAt > webauthn.DefaultTimeout.Milliseconds() {
+		return nil, errors.New("the challenge has expired")
+	}
+
+	return token, nil
+}
+
+// BeginWebAuthnRegistration returns the options to register a new security key for a user.
+func (a *App) BeginWebAuthnRegistration(c request.CTX, userID string) (*model.WebAuthnCredentialCreationOptions, *model.AppError) {
+	config, appErr := a.webAuthnConfig()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.AuthService != "" && user.AuthService != model.UserAuthServiceLdap {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(credentials) >= model.WebAuthnMaxCredentialsPerUser {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "app.webauthn.too_many_credentials.app_error", map[string]any{"Max": model.WebAuthnMaxCredentialsPerUser}, "", http.StatusBadRequest)
+	}
+
+	token, appErr := a.createWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return config.CreationOptions([]byte(token.Token), user, credentials), nil
+}
+
+// FinishWebAuthnRegistration verifies the response of the security key to the registration
+// options and saves the new credential. Registering the first credential of a user activates MFA.
+func (a *App) FinishWebAuthnRegistration(c request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError) {
+	config, appErr := a.webAuthnConfig()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if registration.Credential == nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.verify_registration.app_error", nil, "missing credential", http.StatusBadRequest)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	token, err := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, userID, registration.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_challenge.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	verified, err := config.VerifyRegistration([]byte(token.Token), registration.Credential)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.verify_registration.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	credential, err := a.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       userID,
+		CredentialId: webauthn.EncodeID(verified.ID),
+		PublicKey:    verified.PublicKey,
+		AAGUID:       formatAAGUID(verified.AAGUID),
+		SignCount:    int64(verified.SignCount),
+		Name:         strings.TrimSpace(registration.Name),
+	})
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.already_registered.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.save_credential.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if !user.MfaActive {
+		if err := a.Srv().Store().User().UpdateMfaActive(userID, true); err != nil {
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "mfa.activate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Make sure old MFA status is not cached locally or in cluster nodes.
+		a.InvalidateCacheForUser(userID)
+
+		a.Srv().Go(func() {
+			if err := a.Srv().EmailService.SendMfaChangeEmail(user.Email, true, user.Locale, a.GetSiteURL()); err != nil {
+				c.Logger().Error("Failed to send mfa change email", mlog.Err(err))
+			}
+		})
+	}
+
+	return credential, nil
+}
+
+func (a *App) GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	credentials, err := a.Srv().Store().WebAuthnCredential().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetWebAuthnCredentials", "app.webauthn.get_credentials.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return credentials, nil
+}
+
+// RevokeWebAuthnCredential removes a security key of a user. MFA is turned off once the user has
+// no second factor left.
+func (a *App) RevokeWebAuthnCredential(c request.CTX, userID, credentialID string) *model.AppError {
+	credential, err := a.Srv().Store().WebAuthnCredential().Get(credentialID)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) || (err == nil && credential.UserId != userID) {
+		return model.NewAppError("RevokeWebAuthnCredential", "app.webauthn.credential_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	} else if err != nil {
+		return model.NewAppError("RevokeWebAuthnCredential", "app.webauthn.get_credentials.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().Delete(credentialID); err != nil {
+		return model.NewAppError("RevokeWebAuthnCredential", "app.webauthn.delete_credentials.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	remaining, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if len(remaining) == 0 && user.MfaActive && user.MfaSecret == "" {
+		if err := a.Srv().Store().User().UpdateMfaActive(userID, false); err != nil {
+			return model.NewAppError("RevokeWebAuthnCredential", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Make sure old MFA status is not cached locally or in cluster nodes.
+		a.InvalidateCacheForUser(userID)
+	}
+
+	return nil
+}
+
+// BeginWebAuthnLogin checks the password of a user and returns the options to sign in with one
+// of their security keys.
+func (a *App) BeginWebAuthnLogin(c request.CTX, id, loginId, password string) (*model.WebAuthnCredentialRequestOptions, *model.AppError) {
+	config, appErr := a.webAuthnConfig()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if password == "" {
+		return nil, model.NewAppError("BeginWebAuthnLogin", "api.user.login.blank_pwd.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user, appErr := a.GetUserForLogin(c, id, loginId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Without a second factor, the authentication fails on the MFA check once the password
+	// has been accepted.
+	if _, appErr = a.authenticateUser(c, user, password, ""); appErr != nil && !isMfaError(appErr) {
+		return nil, appErr
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(user.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(credentials) == 0 {
+		return nil, model.NewAppError("BeginWebAuthnLogin", "app.webauthn.no_credentials.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	token, appErr := a.createWebAuthnChallenge(model.TokenTypeWebAuthnLogin, user.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return config.RequestOptions([]byte(token.Token), credentials), nil
+}
+
+func isMfaError(err *model.AppError) bool {
+	return err.Id == "mfa.validate_token.authenticate.app_error" || err.Id == "api.user.check_user_mfa.bad_code.app_error"
+}
+
+// checkUserWebAuthn verifies a WebAuthn assertion given as the MFA token of a user.
+func (a *App) checkUserWebAuthn(rctx request.CTX, user *model.User, assertion string) *model.AppError {
+	config, appErr := a.webAuthnConfig()
+	if appErr != nil {
+		return appErr
+	}
+
+	var response model.WebAuthnAssertionResponse
+	if err := json.Unmarshal([]byte(assertion), &response); err != nil {
+		return model.NewAppError("checkUserWebAuthn", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	token, err := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnLogin, user.Id, response.Response.ClientDataJSON)
+	if err != nil {
+		return model.NewAppError("checkUserWebAuthn", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	var credential *model.WebAuthnCredential
+	for _, c := range credentials {
+		if c.CredentialId == response.Id {
+			credential = c
+			break
+		}
+	}
+	if credential == nil {
+		return model.NewAppError("checkUserWebAuthn", "api.user.check_user_mfa.bad_code.app_error", nil, "unknown credential", http.StatusUnauthorized)
+	}
+
+	signCount, err := config.VerifyAssertion([]byte(token.Token), &response, credential)
+	if err != nil {
+		if errors.Is(err, webauthn.ErrSignCountRegressed) {
+			rctx.Logger().Warn("The signature counter of a security key did not increase, the key may have been cloned",
+				mlog.String("user_id", user.Id),
+				mlog.String("credential_id", credential.Id),
+			)
+		}
+		return model.NewAppError("checkUserWebAuthn", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().UpdateSignCount(credential.Id, int64(signCount), model.GetMillis()); err != nil {
+		return model.NewAppError("checkUserWebAuthn", "app.webauthn.update_credential.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn/webauthntest"
+)
+
+func TestFormatAAGUID(t *testing.T) {
+	assert.Equal(t, "", formatAAGUID(nil))
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f", formatAAGUID([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}))
+}
+
+func TestWebAuthnSecondFactor(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+	})
+
+	authenticator := webauthntest.NewSoftwareAuthenticator("http://localhost:8065")
+
+	options, appErr := th.App.BeginWebAuthnRegistration(th.Context, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	attestation, err := authenticator.Register(options)
+	require.NoError(t, err)
+	credential, appErr := th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, &model.WebAuthnRegistration{Name: " YubiKey ", Credential: attestation})
+	require.Nil(t, appErr)
+	assert.Equal(t, "YubiKey", credential.Name)
+
+	user, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.True(t, user.MfaActive)
+	require.Empty(t, user.MfaSecret)
+
+	login := func(t *testing.T) string {
+		t.Helper()
+
+		requestOptions, appErr := th.App.BeginWebAuthnLogin(th.Context, "", th.BasicUser.Email, th.BasicUser.Password)
+		require.Nil(t, appErr)
+		assertion, err := authenticator.Login(requestOptions)
+		require.NoError(t, err)
+		data, err := json.Marshal(assertion)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("assertion", func(t *testing.T) {
+		require.Nil(t, th.App.CheckUserMfa(th.Context, user, login(t)))
+	})
+
+	t.Run("TOTP code is rejected without a TOTP secret", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, user, "123456")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("malformed assertion", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, user, "{not json")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("deactivating MFA removes the security keys", func(t *testing.T) {
+		require.Nil(t, th.App.DeactivateMfa(th.BasicUser.Id))
+
+		credentials, appErr := th.App.GetWebAuthnCredentials(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, credentials)
+	})
+}
@@ -59,6 +59,10 @@ func (a *App) handleWebhookEvents(c request.CTX, post *model.Post, team *model.T
 
 	relevantHooks := []*model.OutgoingWebhook{}
 	for _, hook := range hooks {
+		if hook.IsDisabled() {
+			continue
+		}
+
 		if hook.ChannelId == post.ChannelId || hook.ChannelId == "" {
 			if hook.ChannelId == post.ChannelId && len(hook.TriggerWords) == 0 {
 				relevantHooks = append(relevantHooks, hook)
@@ -97,27 +101,27 @@ func (a *App) handleWebhookEvents(c request.CTX, post *model.Post, team *model.T
 func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
 	logger := c.Logger().With(mlog.String("outgoing_webhook_id", hook.Id), mlog.String("post_id", post.Id), mlog.String("channel_id", channel.Id), mlog.String("content_type", hook.ContentType))
 
-	var jsonBytes []byte
-	var err error
+	// The token of the hook is added when sending each delivery, so it's never stored with them.
+	redacted := *payload
+	redacted.Token = ""
+
+	var body string
 	contentType := "application/x-www-form-urlencoded"
 	if hook.ContentType == "application/json" {
 		contentType = "application/json"
-		jsonBytes, err = json.Marshal(payload)
+		jsonBytes, err := json.Marshal(&redacted)
 		if err != nil {
 			logger.Warn("Failed to encode to JSON", mlog.Err(err))
 			return
 		}
+		body = string(jsonBytes)
+	} else {
+		body = redacted.ToFormValues()
 	}
 
 	var wg sync.WaitGroup
 
 	for i := range hook.CallbackURLs {
-		var body io.Reader
-		if hook.ContentType == "application/json" {
-			body = bytes.NewReader(jsonBytes)
-		} else {
-			body = strings.NewReader(payload.ToFormValues())
-		}
 		wg.Add(1)
 
 		// Get the callback URL by index to properly capture it for the go func
@@ -126,71 +130,74 @@ func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayloa
 		go func() {
 			defer wg.Done()
 
-			var accessToken *model.OutgoingOAuthConnectionToken
-
-			// Retrieve an access token from a connection if one exists to use for the webhook request
-			if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
-				connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
-				if err != nil {
-					logger.Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
-					return
-				}
-
-				if connection != nil {
-					accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
-					if err != nil {
-						logger.Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
-						return
-					}
-				}
+			delivery := &model.OutgoingWebhookDelivery{
+				HookId:      hook.Id,
+				ChannelId:   channel.Id,
+				PostId:      post.Id,
+				CallbackURL: url,
+				ContentType: contentType,
+				Payload:     body,
 			}
 
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
-			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
-				} else {
-					logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
-				}
-				return
-			}
+			webhookResp := a.deliverOutgoingWebhook(c, logger, hook, delivery, false)
+			a.handleOutgoingWebhookResponse(c, logger, hook, channel, post.Id, webhookResp)
+		}()
+	}
+	wg.Wait()
+}
 
-			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
-				postRootId := ""
-				if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
-					postRootId = post.Id
-				}
-				if len(webhookResp.Props) == 0 {
-					webhookResp.Props = make(model.StringInterface)
-				}
-				webhookResp.Props["webhook_display_name"] = hook.DisplayName
+// handleOutgoingWebhookResponse creates the post requested by the response of an outgoing webhook, if any.
+func (a *App) handleOutgoingWebhookResponse(c request.CTX, logger mlog.LoggerIFace, hook *model.OutgoingWebhook, channel *model.Channel, postID string, webhookResp *model.OutgoingWebhookResponse) {
+	if webhookResp == nil || (webhookResp.Text == nil && len(webhookResp.Attachments) == 0) {
+		return
+	}
 
-				text := ""
-				if webhookResp.Text != nil {
-					text = a.ProcessSlackText(*webhookResp.Text)
-				}
-				webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
-				// attachments is in here for slack compatibility
-				if len(webhookResp.Attachments) > 0 {
-					webhookResp.Props["attachments"] = webhookResp.Attachments
-				}
-				if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
-					webhookResp.Username = hook.Username
-				}
+	postRootId := ""
+	if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
+		postRootId = postID
+	}
+	if len(webhookResp.Props) == 0 {
+		webhookResp.Props = make(model.StringInterface)
+	}
+	webhookResp.Props["webhook_display_name"] = hook.DisplayName
 
-				if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
-					webhookResp.IconURL = hook.IconURL
-				}
-				if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
-					logger.Error("Failed to create response post.", mlog.Err(err))
-				}
-			}
-		}()
+	text := ""
+	if webhookResp.Text != nil {
+		text = a.ProcessSlackText(*webhookResp.Text)
 	}
-	wg.Wait()
+	webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
+	// attachments is in here for slack compatibility
+	if len(webhookResp.Attachments) > 0 {
+		webhookResp.Props["attachments"] = webhookResp.Attachments
+	}
+	if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
+		webhookResp.Username = hook.Username
+	}
+
+	if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
+		webhookResp.IconURL = hook.IconURL
+	}
+	if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
+		logger.Error("Failed to create response post.", mlog.Err(err))
+	}
+}
+
+// outgoingWebhookResult holds the raw outcome of an outgoing webhook request.
+type outgoingWebhookResult struct {
+	statusCode int
+	body       []byte
 }
 
 func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+	result, err := a.sendOutgoingWebhookRequest(url, body, contentType, accessToken, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeOutgoingWebhookResponse(result.body)
+}
+
+func (a *App) sendOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken, header http.Header) (*outgoingWebhookResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
 	defer cancel()
 
@@ -199,6 +206,9 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 		return nil, err
 	}
 
+	for key, values := range header {
+		req.Header[key] = values
+	}
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
@@ -213,8 +223,20 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 
 	defer resp.Body.Close()
 
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, MaxIntegrationResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &outgoingWebhookResult{
+		statusCode: resp.StatusCode,
+		body:       respBody,
+	}, nil
+}
+
+func decodeOutgoingWebhookResponse(body []byte) (*model.OutgoingWebhookResponse, error) {
 	var hookResp model.OutgoingWebhookResponse
-	if jsonErr := json.NewDecoder(io.LimitReader(resp.Body, MaxIntegrationResponseSize)).Decode(&hookResp); jsonErr != nil {
+	if jsonErr := json.NewDecoder(bytes.NewReader(body)).Decode(&hookResp); jsonErr != nil {
 		if jsonErr == io.EOF {
 			return nil, nil
 		}
@@ -393,6 +415,10 @@ func (a *App) CreateIncomingWebhookForChannel(creatorId string, channel *model.C
 		return nil, model.NewAppError("CreateIncomingWebhookForChannel", "api.incoming_webhook.invalid_username.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if appErr := validateIncomingWebhookTemplate("CreateIncomingWebhookForChannel", hook); appErr != nil {
+		return nil, appErr
+	}
+
 	webhook, err := a.Srv().Store().Webhook().SaveIncoming(hook)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -426,6 +452,10 @@ func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook)
 		return nil, model.NewAppError("UpdateIncomingWebhook", "api.incoming_webhook.invalid_username.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if appErr := validateIncomingWebhookTemplate("UpdateIncomingWebhook", updatedHook); appErr != nil {
+		return nil, appErr
+	}
+
 	updatedHook.Id = oldHook.Id
 	updatedHook.UserId = oldHook.UserId
 	updatedHook.CreateAt = oldHook.CreateAt
@@ -563,6 +593,9 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		}
 	}
 
+	// Signing secrets are always generated by the server.
+	hook.SigningSecret = ""
+
 	webhook, err := a.Srv().Store().Webhook().SaveOutgoing(hook)
 	if err != nil {
 		var appErr *model.AppError
@@ -620,8 +653,13 @@ func (a *App) UpdateOutgoingWebhook(c request.CTX, oldHook, updatedHook *model.O
 	updatedHook.CreateAt = oldHook.CreateAt
 	updatedHook.DeleteAt = oldHook.DeleteAt
 	updatedHook.TeamId = oldHook.TeamId
+	updatedHook.SigningSecret = oldHook.SigningSecret
 	updatedHook.UpdateAt = model.GetMillis()
 
+	// A hook disabled after failing too many deliveries is only re-enabled through EnableOutgoingWebhook,
+	// so that clients unaware of the field don't re-enable it on every update.
+	updatedHook.DisabledAt = oldHook.DisabledAt
+
 	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(updatedHook)
 	if err != nil {
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -723,6 +761,56 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return webhook, nil
 }
 
+// RegenOutgoingWebhookSigningSecret generates a new secret to sign the requests of the hook with,
+// enabling request signing if the hook wasn't signed yet.
+func (a *App) RegenOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RegenOutgoingWebhookSigningSecret", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook.SigningSecret = model.NewOutgoingWebhookSigningSecret()
+
+	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(hook)
+	if err != nil {
+		return nil, model.NewAppError("RegenOutgoingWebhookSigningSecret", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return webhook, nil
+}
+
+// EnableOutgoingWebhook re-enables a hook that was disabled after failing too many deliveries.
+func (a *App) EnableOutgoingWebhook(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("EnableOutgoingWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook.DisabledAt = 0
+	hook.UpdateAt = model.GetMillis()
+
+	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(hook)
+	if err != nil {
+		return nil, model.NewAppError("EnableOutgoingWebhook", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return webhook, nil
+}
+
+// RemoveOutgoingWebhookSigningSecret stops signing the requests of the hook.
+func (a *App) RemoveOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RemoveOutgoingWebhookSigningSecret", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook.SigningSecret = ""
+
+	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(hook)
+	if err != nil {
+		return nil, model.NewAppError("RemoveOutgoingWebhookSigningSecret", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return webhook, nil
+}
+
 func (a *App) HandleIncomingWebhook(c request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -121,6 +121,32 @@ func TestCreateIncomingWebhookForChannel(t *testing.T) {
 				IconURL:     "http://example.com/icon",
 			},
 		},
+		"valid, with payload template": {
+			EnableIncomingHooks: true,
+			IncomingWebhook: model.IncomingWebhook{
+				DisplayName: "title",
+				ChannelId:   th.BasicChannel.Id,
+				Template:    "{{ .alert.title }}",
+			},
+
+			ExpectedError: false,
+			ExpectedIncomingWebhook: &model.IncomingWebhook{
+				DisplayName: "title",
+				ChannelId:   th.BasicChannel.Id,
+				Template:    "{{ .alert.title }}",
+			},
+		},
+		"invalid payload template": {
+			EnableIncomingHooks: true,
+			IncomingWebhook: model.IncomingWebhook{
+				DisplayName: "title",
+				ChannelId:   th.BasicChannel.Id,
+				Template:    "{{ .alert.title ",
+			},
+
+			ExpectedError:           true,
+			ExpectedIncomingWebhook: nil,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = tc.EnableIncomingHooks })
@@ -146,6 +172,7 @@ func TestCreateIncomingWebhookForChannel(t *testing.T) {
 				assert.Equal(t, tc.ExpectedIncomingWebhook.ChannelId, createdHook.ChannelId)
 				assert.Equal(t, tc.ExpectedIncomingWebhook.Username, createdHook.Username)
 				assert.Equal(t, tc.ExpectedIncomingWebhook.IconURL, createdHook.IconURL)
+				assert.Equal(t, tc.ExpectedIncomingWebhook.Template, createdHook.Template)
 			}
 		})
 	}
@@ -4,6 +4,7 @@
 package audit
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -18,6 +19,10 @@ type Audit struct {
 
 	// OnError is called when an error occurs while writing an audit record.
 	OnError func(err error)
+
+	// LogStore persists the records written to database targets. Database targets can't
+	// be configured without it.
+	LogStore LogStore
 }
 
 func (a *Audit) Init(maxQueueSize int) {
@@ -45,7 +50,15 @@ func (a *Audit) LogRecord(level mlog.Level, rec Record) {
 
 // Configure sets zero or more target to output audit logs to.
 func (a *Audit) Configure(cfg mlog.LoggerConfiguration) error {
-	return a.logger.ConfigureTargets(cfg, nil)
+	return a.logger.ConfigureTargets(cfg, &mlog.Factories{TargetFactory: a.targetFactory})
+}
+
+// targetFactory creates the targets that are specific to auditing.
+func (a *Audit) targetFactory(targetType string, options json.RawMessage) (mlog.Target, error) {
+	if targetType == DatabaseTargetType {
+		return NewDatabaseTarget(a.LogStore), nil
+	}
+	return nil, fmt.Errorf("target type %q is not supported", targetType)
 }
 
 // Flush attempts to write all queued audit records to all targets.
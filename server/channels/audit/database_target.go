@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// DatabaseTargetType is the target type of the audit target that saves records to the database.
+const DatabaseTargetType = "database"
+
+// LogStore persists the audit records written to the database target.
+type LogStore interface {
+	Save(log *model.AuditLog) (*model.AuditLog, error)
+}
+
+// DatabaseTarget is an audit target that saves every record as a model.AuditLog, so
+// records can be searched without shipping the audit logs elsewhere.
+type DatabaseTarget struct {
+	store LogStore
+}
+
+// NewDatabaseTarget creates a target saving audit records to the given store.
+func NewDatabaseTarget(store LogStore) *DatabaseTarget {
+	return &DatabaseTarget{
+		store: store,
+	}
+}
+
+// Init is called once to initialize the target.
+func (t *DatabaseTarget) Init() error {
+	if t.store == nil {
+		return errors.New("no store configured for the database audit target")
+	}
+	return nil
+}
+
+// Write saves the audit record. p is the formatted record, which is saved as is
+// alongside the searchable fields taken from the record.
+func (t *DatabaseTarget) Write(p []byte, rec *mlog.LogRec) (int, error) {
+	log := &model.AuditLog{
+		CreateAt: rec.Time().UnixMilli(),
+		Data:     string(p),
+	}
+
+	for _, field := range rec.Fields() {
+		switch field.Key {
+		case KeyEventName:
+			log.EventName = field.String
+		case KeyStatus:
+			log.Status = field.String
+		case KeyActor:
+			if actor, ok := field.Interface.(EventActor); ok {
+				log.ActorId = actor.UserId
+				log.SessionId = actor.SessionId
+				log.IpAddress = actor.IpAddress
+			}
+		case KeyEvent:
+			if data, ok := field.Interface.(EventData); ok {
+				log.ObjectType = data.ObjectType
+				log.ObjectId = objectID(data)
+			}
+		}
+	}
+
+	if _, err := t.store.Save(log); err != nil {
+		return 0, fmt.Errorf("failed to save audit record %s: %w", log.EventName, err)
+	}
+
+	return len(p), nil
+}
+
+// Shutdown is called once to free any resources. The store is owned by the server, so there's nothing to do.
+func (t *DatabaseTarget) Shutdown() error {
+	return nil
+}
+
+// objectID returns the id of the object modified by the event. The id is taken from the
+// resulting state of the object, from its prior state if it was deleted, and otherwise from
+// the "<object type>_id" parameter of the event.
+func objectID(data EventData) string {
+	for _, state := range []map[string]any{data.ResultState, data.PriorState} {
+		if id, ok := state["id"].(string); ok && id != "" {
+			return id
+		}
+	}
+
+	if data.ObjectType != "" {
+		if id, ok := data.Parameters[data.ObjectType+"_id"].(string); ok {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/logr/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testLogStore struct {
+	mut  sync.Mutex
+	logs []*model.AuditLog
+	err  error
+}
+
+func (s *testLogStore) Save(log *model.AuditLog) (*model.AuditLog, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.logs = append(s.logs, log)
+	return log, nil
+}
+
+func TestDatabaseTarget(t *testing.T) {
+	cfg := mlog.LoggerConfiguration{
+		"database": mlog.TargetCfg{
+			Type:   DatabaseTargetType,
+			Format: "json",
+			Levels: []mlog.Level{mlog.LvlAuditCLI, mlog.LvlAuditAPI, mlog.LvlAuditPerms, mlog.LvlAuditContent},
+		},
+	}
+
+	t.Run("saves the searchable fields of the record", func(t *testing.T) {
+		store := &testLogStore{}
+		audit := &Audit{LogStore: store}
+		audit.Init(100)
+		require.NoError(t, audit.Configure(cfg))
+
+		channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+
+		rec := Record{
+			EventName: "patchChannel",
+			Actor: EventActor{
+				UserId:    model.NewId(),
+				SessionId: model.NewId(),
+				IpAddress: "127.0.0.1",
+			},
+		}
+		rec.AddEventObjectType("channel")
+		rec.AddEventResultState(channel)
+		rec.Success()
+		audit.LogRecord(mlog.LvlAuditAPI, rec)
+
+		require.NoError(t, audit.Shutdown())
+
+		require.Len(t, store.logs, 1)
+		log := store.logs[0]
+		assert.Equal(t, "patchChannel", log.EventName)
+		assert.Equal(t, Success, log.Status)
+		assert.Equal(t, rec.Actor.UserId, log.ActorId)
+		assert.Equal(t, rec.Actor.SessionId, log.SessionId)
+		assert.Equal(t, "127.0.0.1", log.IpAddress)
+		assert.Equal(t, "channel", log.ObjectType)
+		assert.Equal(t, channel.Id, log.ObjectId)
+		assert.NotZero(t, log.CreateAt)
+		assert.Contains(t, log.Data, `"event_name":"patchChannel"`)
+	})
+
+	t.Run("fails without a store", func(t *testing.T) {
+		audit := &Audit{}
+		audit.Init(100)
+		defer audit.Shutdown()
+
+		require.Error(t, audit.Configure(cfg))
+	})
+
+	t.Run("store errors are reported", func(t *testing.T) {
+		store := &testLogStore{err: errors.New("database unavailable")}
+		target := NewDatabaseTarget(store)
+		require.NoError(t, target.Init())
+
+		_, err := target.Write([]byte("{}"), logr.NewLogRec(mlog.LvlAuditAPI, logr.Logger{}, "", []logr.Field{logr.String(KeyEventName, "patchChannel")}, false))
+		require.ErrorContains(t, err, "database unavailable")
+	})
+}
+
+func TestObjectID(t *testing.T) {
+	id := model.NewId()
+
+	assert.Equal(t, id, objectID(EventData{ResultState: map[string]any{"id": id}, PriorState: map[string]any{"id": model.NewId()}}))
+	assert.Equal(t, id, objectID(EventData{PriorState: map[string]any{"id": id}}))
+	assert.Equal(t, id, objectID(EventData{ObjectType: "channel", Parameters: map[string]any{"channel_id": id}}))
+	assert.Empty(t, objectID(EventData{Parameters: map[string]any{"channel_id": id}}))
+}
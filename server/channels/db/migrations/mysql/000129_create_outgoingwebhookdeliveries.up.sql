@@ -0,0 +1,63 @@
+CREATE TABLE IF NOT EXISTS OutgoingWebhookDeliveries (
+	Id varchar(26) NOT NULL,
+	HookId varchar(26) NOT NULL,
+	ChannelId varchar(26) NOT NULL,
+	PostId varchar(26),
+	CallbackURL varchar(1024) NOT NULL,
+	ContentType varchar(128),
+	Payload text,
+	Attempt int NOT NULL,
+	Status varchar(32) NOT NULL,
+	StatusCode int,
+	Latency bigint(20),
+	Response text,
+	Error text,
+	CreateAt bigint(20) NOT NULL,
+	NextRetryAt bigint(20),
+	PRIMARY KEY (Id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outgoingwebhookdeliveries_hookid_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outgoingwebhookdeliveries_hookid_createat ON OutgoingWebhookDeliveries (HookId, CreateAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outgoingwebhookdeliveries_status_nextretryat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outgoingwebhookdeliveries_status_nextretryat ON OutgoingWebhookDeliveries (Status, NextRetryAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outgoingwebhookdeliveries_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outgoingwebhookdeliveries_createat ON OutgoingWebhookDeliveries (CreateAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
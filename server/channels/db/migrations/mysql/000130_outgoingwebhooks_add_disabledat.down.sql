@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'DisabledAt'
+	) > 0,
+	'ALTER TABLE OutgoingWebhooks DROP COLUMN DisabledAt;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
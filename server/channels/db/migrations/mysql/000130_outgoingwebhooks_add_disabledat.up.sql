@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'DisabledAt'
+	) > 0,
+	'SELECT 1',
+	'ALTER TABLE OutgoingWebhooks ADD DisabledAt bigint(20) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'IncomingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'Template'
+	) > 0,
+	'ALTER TABLE IncomingWebhooks DROP COLUMN Template;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
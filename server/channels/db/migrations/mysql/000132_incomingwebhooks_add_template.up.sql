@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'IncomingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'Template'
+	) > 0,
+	'SELECT 1',
+	'ALTER TABLE IncomingWebhooks ADD Template text NOT NULL;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
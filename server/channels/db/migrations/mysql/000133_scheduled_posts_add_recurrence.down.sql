@@ -0,0 +1,44 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'ScheduledPosts'
+		AND table_schema = DATABASE()
+		AND column_name = 'PausedAt'
+	) > 0,
+	'ALTER TABLE ScheduledPosts DROP COLUMN PausedAt;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'ScheduledPosts'
+		AND table_schema = DATABASE()
+		AND column_name = 'Timezone'
+	) > 0,
+	'ALTER TABLE ScheduledPosts DROP COLUMN Timezone;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'ScheduledPosts'
+		AND table_schema = DATABASE()
+		AND column_name = 'RecurrenceRule'
+	) > 0,
+	'ALTER TABLE ScheduledPosts DROP COLUMN RecurrenceRule;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,44 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'ScheduledPosts'
+		AND table_schema = DATABASE()
+		AND column_name = 'RecurrenceRule'
+	) > 0,
+	'SELECT 1',
+	'ALTER TABLE ScheduledPosts ADD RecurrenceRule VARCHAR(256) DEFAULT '''';'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'ScheduledPosts'
+		AND table_schema = DATABASE()
+		AND column_name = 'Timezone'
+	) > 0,
+	'SELECT 1',
+	'ALTER TABLE ScheduledPosts ADD Timezone VARCHAR(64) DEFAULT '''';'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'ScheduledPosts'
+		AND table_schema = DATABASE()
+		AND column_name = 'PausedAt'
+	) > 0,
+	'SELECT 1',
+	'ALTER TABLE ScheduledPosts ADD PausedAt bigint(20) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
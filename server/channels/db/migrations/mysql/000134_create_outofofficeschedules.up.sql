@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS OutOfOfficeSchedules (
+	UserId varchar(26) NOT NULL,
+	StartAt bigint(20) NOT NULL,
+	EndAt bigint(20) NOT NULL,
+	ActivatedAt bigint(20) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	UpdateAt bigint(20) NOT NULL,
+	PRIMARY KEY (UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutOfOfficeSchedules'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outofofficeschedules_startat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outofofficeschedules_startat ON OutOfOfficeSchedules (StartAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutOfOfficeSchedules'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outofofficeschedules_endat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outofofficeschedules_endat ON OutOfOfficeSchedules (EndAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
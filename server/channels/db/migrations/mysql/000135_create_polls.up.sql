@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS Polls (
+	PostId varchar(26) NOT NULL,
+	UserId varchar(26) NOT NULL,
+	Options text NOT NULL,
+	Anonymous tinyint(1) NOT NULL DEFAULT 0,
+	MultipleChoice tinyint(1) NOT NULL DEFAULT 0,
+	CloseAt bigint(20) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	PRIMARY KEY (PostId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS PollVotes (
+	PostId varchar(26) NOT NULL,
+	UserId varchar(26) NOT NULL,
+	OptionIndex int NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	PRIMARY KEY (PostId, UserId, OptionIndex)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'PollVotes'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_pollvotes_userid'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_pollvotes_userid ON PollVotes (UserId);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS WebAuthnCredentials (
+	Id varchar(26) NOT NULL,
+	UserId varchar(26) NOT NULL,
+	CredentialId varchar(512) NOT NULL,
+	PublicKey blob NOT NULL,
+	AAGUID varchar(36) NOT NULL DEFAULT '',
+	SignCount bigint(20) NOT NULL DEFAULT 0,
+	Name varchar(64) NOT NULL DEFAULT '',
+	CreateAt bigint(20) NOT NULL,
+	LastUsedAt bigint(20) NOT NULL DEFAULT 0,
+	PRIMARY KEY (Id),
+	UNIQUE KEY CredentialId (CredentialId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'WebAuthnCredentials'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_webauthncredentials_userid'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_webauthncredentials_userid ON WebAuthnCredentials (UserId);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
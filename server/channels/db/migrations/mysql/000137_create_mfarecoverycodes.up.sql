@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS MfaRecoveryCodes (
+	UserId varchar(26) NOT NULL,
+	CodeHash varchar(64) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	PRIMARY KEY (UserId, CodeHash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
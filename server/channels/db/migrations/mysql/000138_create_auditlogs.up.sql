@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS AuditLogs (
+	Id varchar(26) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	EventName varchar(128) NOT NULL,
+	Status varchar(32),
+	ActorId varchar(128),
+	SessionId varchar(26),
+	IpAddress varchar(64),
+	ObjectType varchar(64),
+	ObjectId varchar(64),
+	Data mediumtext,
+	PRIMARY KEY (Id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'AuditLogs'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_auditlogs_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_auditlogs_createat ON AuditLogs (CreateAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'AuditLogs'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_auditlogs_actorid_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_auditlogs_actorid_createat ON AuditLogs (ActorId, CreateAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'AuditLogs'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_auditlogs_eventname_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_auditlogs_eventname_createat ON AuditLogs (EventName, CreateAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'AuditLogs'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_auditlogs_objecttype_objectid_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_auditlogs_objecttype_objectid_createat ON AuditLogs (ObjectType, ObjectId, CreateAt);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
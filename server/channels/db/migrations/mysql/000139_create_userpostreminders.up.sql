@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS UserPostReminders (
+	PostId varchar(26) NOT NULL,
+	UserId varchar(26) NOT NULL,
+	TargetTime bigint(20) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	DeliveredAt bigint(20) NOT NULL DEFAULT 0,
+	PRIMARY KEY (PostId, UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'UserPostReminders'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_userpostreminders_deliveredat_targettime'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_userpostreminders_deliveredat_targettime ON UserPostReminders (DeliveredAt, TargetTime);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'UserPostReminders'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_userpostreminders_userid_targettime'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_userpostreminders_userid_targettime ON UserPostReminders (UserId, TargetTime);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+INSERT IGNORE INTO UserPostReminders (PostId, UserId, TargetTime, CreateAt, DeliveredAt)
+	SELECT PostId, UserId, COALESCE(TargetTime, 0), 0, 0 FROM PostReminders;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND column_name = 'ResolvedAt'
+	) > 0,
+	'ALTER TABLE OutgoingWebhookDeliveries DROP COLUMN ResolvedAt;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
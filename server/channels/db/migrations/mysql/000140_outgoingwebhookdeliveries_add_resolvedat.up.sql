@@ -0,0 +1,21 @@
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND column_name = 'ResolvedAt'
+	) > 0,
+	'SELECT 1',
+	'ALTER TABLE OutgoingWebhookDeliveries ADD ResolvedAt bigint(20) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+-- Only the deliveries that can still be retried or replayed are kept, without the token of the hook.
+DELETE FROM OutgoingWebhookDeliveries WHERE Status IN ('success', 'retried');
+UPDATE OutgoingWebhookDeliveries SET Payload = JSON_SET(Payload, '$.token', '')
+	WHERE ContentType = 'application/json' AND Payload LIKE '%"token"%';
+UPDATE OutgoingWebhookDeliveries SET Payload = REGEXP_REPLACE(Payload, '(^|&)token=[^&]*', '$1token=')
+	WHERE ContentType <> 'application/json';
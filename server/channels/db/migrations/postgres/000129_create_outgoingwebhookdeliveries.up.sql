@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS outgoingwebhookdeliveries (
+	id VARCHAR(26) PRIMARY KEY,
+	hookid VARCHAR(26) NOT NULL,
+	channelid VARCHAR(26) NOT NULL,
+	postid VARCHAR(26),
+	callbackurl VARCHAR(1024) NOT NULL,
+	contenttype VARCHAR(128),
+	payload text,
+	attempt integer NOT NULL,
+	status VARCHAR(32) NOT NULL,
+	statuscode integer,
+	latency bigint,
+	response text,
+	error text,
+	createat bigint NOT NULL,
+	nextretryat bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_hookid_createat ON outgoingwebhookdeliveries (hookid, createat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_status_nextretryat ON outgoingwebhookdeliveries (status, nextretryat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_createat ON outgoingwebhookdeliveries (createat);
@@ -0,0 +1 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS disabledat bigint DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS signingsecret varchar(64) DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE incomingwebhooks ADD COLUMN IF NOT EXISTS template text DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE scheduledposts ADD COLUMN IF NOT EXISTS recurrencerule VARCHAR(256) DEFAULT '';
+ALTER TABLE scheduledposts ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT '';
+ALTER TABLE scheduledposts ADD COLUMN IF NOT EXISTS pausedat bigint DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outofofficeschedules_startat;
+DROP INDEX IF EXISTS idx_outofofficeschedules_endat;
+DROP TABLE IF EXISTS outofofficeschedules;
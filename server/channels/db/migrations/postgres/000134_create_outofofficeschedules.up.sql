@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS outofofficeschedules (
+	userid VARCHAR(26) PRIMARY KEY,
+	startat bigint NOT NULL,
+	endat bigint NOT NULL,
+	activatedat bigint NOT NULL,
+	createat bigint NOT NULL,
+	updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outofofficeschedules_startat ON outofofficeschedules (startat);
+CREATE INDEX IF NOT EXISTS idx_outofofficeschedules_endat ON outofofficeschedules (endat);
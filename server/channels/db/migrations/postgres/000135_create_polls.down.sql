@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pollvotes;
+DROP TABLE IF EXISTS polls;
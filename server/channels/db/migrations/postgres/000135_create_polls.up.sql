@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS polls (
+	postid VARCHAR(26) PRIMARY KEY,
+	userid VARCHAR(26) NOT NULL,
+	options text NOT NULL,
+	anonymous boolean NOT NULL DEFAULT false,
+	multiplechoice boolean NOT NULL DEFAULT false,
+	closeat bigint NOT NULL,
+	createat bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pollvotes (
+	postid VARCHAR(26) NOT NULL,
+	userid VARCHAR(26) NOT NULL,
+	optionindex integer NOT NULL,
+	createat bigint NOT NULL,
+	PRIMARY KEY (postid, userid, optionindex)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pollvotes_userid ON pollvotes (userid);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS webauthncredentials (
+	id VARCHAR(26) PRIMARY KEY,
+	userid VARCHAR(26) NOT NULL,
+	credentialid VARCHAR(512) NOT NULL,
+	publickey bytea NOT NULL,
+	aaguid VARCHAR(36) NOT NULL DEFAULT '',
+	signcount bigint NOT NULL DEFAULT 0,
+	name VARCHAR(64) NOT NULL DEFAULT '',
+	createat bigint NOT NULL,
+	lastusedat bigint NOT NULL DEFAULT 0,
+	UNIQUE (credentialid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthncredentials_userid ON webauthncredentials (userid);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS mfarecoverycodes (
+	userid VARCHAR(26) NOT NULL,
+	codehash VARCHAR(64) NOT NULL,
+	createat bigint NOT NULL,
+	PRIMARY KEY (userid, codehash)
+);
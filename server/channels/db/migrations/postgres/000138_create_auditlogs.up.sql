@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS auditlogs (
+	id VARCHAR(26) PRIMARY KEY,
+	createat bigint NOT NULL,
+	eventname VARCHAR(128) NOT NULL,
+	status VARCHAR(32),
+	actorid VARCHAR(128),
+	sessionid VARCHAR(26),
+	ipaddress VARCHAR(64),
+	objecttype VARCHAR(64),
+	objectid VARCHAR(64),
+	data text
+);
+
+CREATE INDEX IF NOT EXISTS idx_auditlogs_createat ON auditlogs (createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_actorid_createat ON auditlogs (actorid, createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_eventname_createat ON auditlogs (eventname, createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_objecttype_objectid_createat ON auditlogs (objecttype, objectid, createat);
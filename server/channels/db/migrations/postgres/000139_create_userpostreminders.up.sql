@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS userpostreminders (
+	postid VARCHAR(26) NOT NULL,
+	userid VARCHAR(26) NOT NULL,
+	targettime bigint NOT NULL,
+	createat bigint NOT NULL,
+	deliveredat bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (postid, userid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_userpostreminders_deliveredat_targettime ON userpostreminders (deliveredat, targettime);
+CREATE INDEX IF NOT EXISTS idx_userpostreminders_userid_targettime ON userpostreminders (userid, targettime);
+
+INSERT INTO userpostreminders (postid, userid, targettime, createat, deliveredat)
+	SELECT postid, userid, COALESCE(targettime, 0), 0, 0 FROM postreminders
+	ON CONFLICT (postid, userid) DO NOTHING;
@@ -0,0 +1 @@
+ALTER TABLE outgoingwebhookdeliveries DROP COLUMN IF EXISTS resolvedat;
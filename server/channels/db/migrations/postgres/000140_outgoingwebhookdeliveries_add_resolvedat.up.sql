@@ -0,0 +1,8 @@
+ALTER TABLE outgoingwebhookdeliveries ADD COLUMN IF NOT EXISTS resolvedat bigint DEFAULT 0;
+
+-- Only the deliveries that can still be retried or replayed are kept, without the token of the hook.
+DELETE FROM outgoingwebhookdeliveries WHERE status IN ('success', 'retried');
+UPDATE outgoingwebhookdeliveries SET payload = jsonb_set(payload::jsonb, '{token}', '""')::text
+	WHERE contenttype = 'application/json' AND payload LIKE '%"token"%';
+UPDATE outgoingwebhookdeliveries SET payload = regexp_replace(payload, '(^|&)token=[^&]*', '\1token=')
+	WHERE contenttype <> 'application/json';
@@ -0,0 +1,24 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit_log_retention
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// MakeScheduler schedules the deletion of the expired audit logs daily, at the start time of
+// the data retention job.
+func MakeScheduler(jobServer *jobs.JobServer) *jobs.DailyScheduler {
+	startTime := func(cfg *model.Config) *time.Time {
+		parsedTime, err := time.Parse("15:04", *cfg.DataRetentionSettings.DeletionJobStartTime)
+		if err == nil {
+			return &parsedTime
+		}
+		return nil
+	}
+	return jobs.NewDailyScheduler(jobServer, model.JobTypeAuditLogRetention, startTime, isEnabled)
+}
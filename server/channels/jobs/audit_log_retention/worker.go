@@ -0,0 +1,35 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit_log_retention
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const jobName = "AuditLogRetention"
+
+type AppIface interface {
+	DeleteExpiredAuditLogs(rctx request.CTX) (int64, *model.AppError)
+}
+
+func isEnabled(cfg *model.Config) bool {
+	return *cfg.DataRetentionSettings.EnableAuditLogDeletion
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		deleted, appErr := app.DeleteExpiredAuditLogs(request.EmptyContext(logger))
+		if appErr != nil {
+			return appErr
+		}
+		logger.Info("Deleted expired audit logs", mlog.Int("deleted", deleted))
+		return nil
+	}
+	return jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+}
@@ -98,15 +98,24 @@ func getFileCount(jobData model.StringMap) (int, error) {
 	return 0, nil
 }
 
+// format returns the export format requested for the job, falling back to the
+// default format of the worker for jobs that didn't request one.
+func (worker *BatchReportWorker) format(job *model.Job) string {
+	if format := job.Data["format"]; format != "" {
+		return format
+	}
+	return worker.reportFormat
+}
+
 func (worker *BatchReportWorker) processChunk(job *model.Job, reportData []model.ReportableObject) error {
 	fileCount, err := getFileCount(job.Data)
 	if err != nil {
 		return err
 	}
 
-	appErr := worker.app.SaveReportChunk(worker.reportFormat, job.Id, fileCount, reportData)
+	appErr := worker.app.SaveReportChunk(worker.format(job), job.Id, fileCount, reportData)
 	if appErr != nil {
-		return err
+		return appErr
 	}
 
 	fileCount++
@@ -121,18 +130,19 @@ func (worker *BatchReportWorker) complete(rctx request.CTX, job *model.Job) erro
 		return err
 	}
 
-	appErr := worker.app.CompileReportChunks(worker.reportFormat, job.Id, fileCount, worker.headers)
+	format := worker.format(job)
+	appErr := worker.app.CompileReportChunks(format, job.Id, fileCount, worker.headers)
 	if appErr != nil {
 		return appErr
 	}
 
 	defer func() {
-		if err := worker.app.CleanupReportChunks(worker.reportFormat, job.Id, fileCount); err != nil {
+		if err := worker.app.CleanupReportChunks(format, job.Id, fileCount); err != nil {
 			worker.logger.Error("Worker: Failed to cleanup report chunks", mlog.Err(err))
 		}
 	}()
 
-	if appErr = worker.app.SendReportToUser(rctx, job, worker.reportFormat); appErr != nil {
+	if appErr = worker.app.SendReportToUser(rctx, job, format); appErr != nil {
 		return appErr
 	}
 
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package export_channel_activity_report
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+)
+
+type ExportChannelActivityReportAppIFace interface {
+	jobs.BatchReportWorkerAppIFace
+	GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, *model.AppError)
+}
+
+// MakeWorker creates a batch report worker to generate channel activity reports.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app ExportChannelActivityReportAppIFace) model.Worker {
+	return jobs.MakeBatchReportWorker(
+		jobServer,
+		store,
+		app,
+		timeBetweenBatches,
+		model.ReportExportFormatCSV,
+		[]string{
+			"Id",
+			"Name",
+			"DisplayName",
+			"Type",
+			"TeamId",
+			"TeamName",
+			"CreateAt",
+			"LastPostAt",
+			"MemberCount",
+			"PostCount",
+			"DeletedAt",
+		},
+		getData(app),
+	)
+}
+
+// parseJobMetadata parses the opaque job metadata to return the information needed to decide which
+// batch to process next.
+func parseJobMetadata(data model.StringMap) (*model.ChannelActivityReportOptions, error) {
+	startAt, err := strconv.ParseInt(data["start_at"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	endAt, err := strconv.ParseInt(data["end_at"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ChannelActivityReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{
+			PageSize:        100,
+			FromColumnValue: data["last_column_value"],
+			FromId:          data["last_channel_id"],
+			StartAt:         startAt,
+			EndAt:           endAt,
+		},
+		Team: data["team"],
+	}, nil
+}
+
+// makeJobMetadata encodes the information needed to decide which batch to process next back into
+// the opaque job metadata.
+func makeJobMetadata(jobData model.StringMap, lastColumnValue string, channelID string) model.StringMap {
+	jobData["last_column_value"] = lastColumnValue
+	jobData["last_channel_id"] = channelID
+	return jobData
+}
+
+func getData(app ExportChannelActivityReportAppIFace) func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+	return func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+		filter, err := parseJobMetadata(jobData)
+		if err != nil {
+			return nil, nil, false, errors.Wrap(err, "failed to parse job metadata")
+		}
+
+		channels, appErr := app.GetChannelActivityReport(filter)
+		if appErr != nil {
+			return nil, nil, false, errors.Wrapf(appErr, "failed to get the next batch (column_value=%v, channel_id=%v)", filter.FromColumnValue, filter.FromId)
+		}
+
+		if len(channels) == 0 {
+			return nil, nil, true, nil
+		}
+
+		reportableObjects := make([]model.ReportableObject, len(channels))
+		for i, channel := range channels {
+			reportableObjects[i] = channel
+		}
+
+		last := channels[len(channels)-1]
+		return reportableObjects, makeJobMetadata(jobData, last.Name, last.ChannelId), false, nil
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package export_guest_access_report
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+)
+
+type ExportGuestAccessReportAppIFace interface {
+	jobs.BatchReportWorkerAppIFace
+	GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, *model.AppError)
+}
+
+// MakeWorker creates a batch report worker to generate reports of what guest accounts have access to.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app ExportGuestAccessReportAppIFace) model.Worker {
+	return jobs.MakeBatchReportWorker(
+		jobServer,
+		store,
+		app,
+		timeBetweenBatches,
+		model.ReportExportFormatCSV,
+		[]string{
+			"Id",
+			"Username",
+			"Email",
+			"CreateAt",
+			"LastActivityAt",
+			"TeamCount",
+			"ChannelCount",
+			"DeletedAt",
+		},
+		getData(app),
+	)
+}
+
+// parseJobMetadata parses the opaque job metadata to return the information needed to decide which
+// batch to process next. Guest access is a snapshot, so the date range of the job isn't used.
+func parseJobMetadata(data model.StringMap) *model.GuestAccessReportOptions {
+	return &model.GuestAccessReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{
+			PageSize:        100,
+			FromColumnValue: data["last_username"],
+		},
+		Team: data["team"],
+	}
+}
+
+// makeJobMetadata encodes the information needed to decide which batch to process next back into
+// the opaque job metadata.
+func makeJobMetadata(jobData model.StringMap, username string) model.StringMap {
+	jobData["last_username"] = username
+	return jobData
+}
+
+func getData(app ExportGuestAccessReportAppIFace) func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+	return func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+		filter := parseJobMetadata(jobData)
+
+		guests, appErr := app.GetGuestAccessReport(filter)
+		if appErr != nil {
+			return nil, nil, false, errors.Wrapf(appErr, "failed to get the next batch (username=%v)", filter.FromColumnValue)
+		}
+
+		if len(guests) == 0 {
+			return nil, nil, true, nil
+		}
+
+		reportableObjects := make([]model.ReportableObject, len(guests))
+		for i, guest := range guests {
+			reportableObjects[i] = guest
+		}
+
+		return reportableObjects, makeJobMetadata(jobData, guests[len(guests)-1].Username), false, nil
+	}
+}
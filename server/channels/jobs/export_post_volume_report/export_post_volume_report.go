@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package export_post_volume_report
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+)
+
+type ExportPostVolumeReportAppIFace interface {
+	jobs.BatchReportWorkerAppIFace
+	GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, *model.AppError)
+}
+
+// MakeWorker creates a batch report worker to generate reports of the number of posts per team and per day.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app ExportPostVolumeReportAppIFace) model.Worker {
+	return jobs.MakeBatchReportWorker(
+		jobServer,
+		store,
+		app,
+		timeBetweenBatches,
+		model.ReportExportFormatCSV,
+		[]string{
+			"Day",
+			"TeamId",
+			"TeamName",
+			"PostCount",
+		},
+		getData(app),
+	)
+}
+
+// parseJobMetadata parses the opaque job metadata to return the information needed to decide which
+// batch to process next.
+func parseJobMetadata(data model.StringMap) (*model.PostVolumeReportOptions, error) {
+	startAt, err := strconv.ParseInt(data["start_at"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	endAt, err := strconv.ParseInt(data["end_at"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PostVolumeReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{
+			PageSize:        100,
+			FromColumnValue: data["last_day"],
+			FromId:          data["last_team_id"],
+			StartAt:         startAt,
+			EndAt:           endAt,
+		},
+		Team: data["team"],
+	}, nil
+}
+
+// makeJobMetadata encodes the information needed to decide which batch to process next back into
+// the opaque job metadata.
+func makeJobMetadata(jobData model.StringMap, day string, teamID string) model.StringMap {
+	jobData["last_day"] = day
+	jobData["last_team_id"] = teamID
+	return jobData
+}
+
+func getData(app ExportPostVolumeReportAppIFace) func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+	return func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+		filter, err := parseJobMetadata(jobData)
+		if err != nil {
+			return nil, nil, false, errors.Wrap(err, "failed to parse job metadata")
+		}
+
+		volumes, appErr := app.GetPostVolumeReport(filter)
+		if appErr != nil {
+			return nil, nil, false, errors.Wrapf(appErr, "failed to get the next batch (day=%v, team_id=%v)", filter.FromColumnValue, filter.FromId)
+		}
+
+		if len(volumes) == 0 {
+			return nil, nil, true, nil
+		}
+
+		reportableObjects := make([]model.ReportableObject, len(volumes))
+		for i, volume := range volumes {
+			reportableObjects[i] = volume
+		}
+
+		last := volumes[len(volumes)-1]
+		return reportableObjects, makeJobMetadata(jobData, last.Day, last.TeamId), false, nil
+	}
+}
@@ -5,8 +5,12 @@ package export_process
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -53,6 +57,13 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		rctx := request.EmptyContext(logger)
+		filter, err := makeBulkExportFilter(rctx, jobServer, job)
+		if err != nil {
+			return err
+		}
+		opts.BulkExportFilter = filter
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
@@ -69,7 +80,7 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			}
 		}()
 
-		appErr := app.BulkExport(request.EmptyContext(logger), wr, outPath, job, opts)
+		appErr := app.BulkExport(rctx, wr, outPath, job, opts)
 		wr.Close() // Close never returns an error
 
 		if appErr != nil {
@@ -81,3 +92,92 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
 	return worker
 }
+
+// exportJobsPerPage is the number of jobs fetched at a time when looking for
+// the export an incremental job continues from.
+const exportJobsPerPage = 100
+
+// makeBulkExportFilter reads the time window and the teams and channels to
+// export from the job data. An incremental job without a "since" timestamp
+// continues from the checkpoint of the last successful incremental export of
+// the same teams and channels.
+func makeBulkExportFilter(rctx request.CTX, jobServer *jobs.JobServer, job *model.Job) (model.BulkExportFilter, error) {
+	var filter model.BulkExportFilter
+
+	for key, value := range map[string]*int64{"since": &filter.Since, "until": &filter.Until} {
+		if job.Data[key] == "" {
+			continue
+		}
+		millis, err := strconv.ParseInt(job.Data[key], 10, 64)
+		if err != nil || millis < 0 {
+			return filter, fmt.Errorf("invalid %s timestamp %q", key, job.Data[key])
+		}
+		*value = millis
+	}
+
+	if teamIDs := job.Data["team_ids"]; teamIDs != "" {
+		filter.TeamIds = strings.Split(teamIDs, ",")
+	}
+	if channelIDs := job.Data["channel_ids"]; channelIDs != "" {
+		filter.ChannelIds = strings.Split(channelIDs, ",")
+	}
+
+	if job.Data["incremental"] == "true" {
+		job.Data["scope"] = bulkExportScope(filter)
+
+		if filter.Since == 0 {
+			lastJob, err := getLastIncrementalExportJob(rctx, jobServer, job.Data["scope"])
+			if err != nil {
+				return filter, err
+			}
+			if lastJob != nil {
+				checkpoint, err := strconv.ParseInt(lastJob.Data["checkpoint"], 10, 64)
+				if err != nil {
+					return filter, fmt.Errorf("invalid checkpoint %q of job %s", lastJob.Data["checkpoint"], lastJob.Id)
+				}
+				filter.Since = checkpoint
+				job.Data["since"] = lastJob.Data["checkpoint"]
+			}
+		}
+	}
+
+	if filter.Until != 0 && filter.Until <= filter.Since {
+		return filter, fmt.Errorf("the until timestamp must be after the since timestamp")
+	}
+
+	return filter, nil
+}
+
+// bulkExportScope returns a key identifying the teams and channels covered by
+// an export, regardless of the order they were requested in.
+func bulkExportScope(filter model.BulkExportFilter) string {
+	teamIDs := slices.Clone(filter.TeamIds)
+	slices.Sort(teamIDs)
+	channelIDs := slices.Clone(filter.ChannelIds)
+	slices.Sort(channelIDs)
+
+	return strings.Join(teamIDs, ",") + ";" + strings.Join(channelIDs, ",")
+}
+
+// getLastIncrementalExportJob returns the newest successful incremental export
+// with the given scope, or nil if there is none. Full exports and incremental
+// exports of other teams and channels don't contain the changes of the scope,
+// so they can't be continued from.
+func getLastIncrementalExportJob(rctx request.CTX, jobServer *jobs.JobServer, scope string) (*model.Job, error) {
+	for page := 0; ; page++ {
+		exportJobs, err := jobServer.Store.Job().GetAllByTypeAndStatusPage(rctx, []string{model.JobTypeExportProcess}, model.JobStatusSuccess, page*exportJobsPerPage, exportJobsPerPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the previous export jobs: %w", err)
+		}
+
+		for _, job := range exportJobs {
+			if job.Data["incremental"] == "true" && job.Data["scope"] == scope && job.Data["checkpoint"] != "" {
+				return job, nil
+			}
+		}
+
+		if len(exportJobs) < exportJobsPerPage {
+			return nil, nil
+		}
+	}
+}
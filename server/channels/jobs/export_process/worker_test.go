@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package export_process
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+)
+
+func TestMakeBulkExportFilter(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	t.Run("no filter", func(t *testing.T) {
+		filter, err := makeBulkExportFilter(rctx, &jobs.JobServer{}, &model.Job{Data: model.StringMap{}})
+		require.NoError(t, err)
+		assert.True(t, filter.IsEmpty())
+	})
+
+	t.Run("window and scope", func(t *testing.T) {
+		job := &model.Job{Data: model.StringMap{
+			"since":       "100",
+			"until":       "200",
+			"team_ids":    "team1,team2",
+			"channel_ids": "channel1",
+		}}
+		filter, err := makeBulkExportFilter(rctx, &jobs.JobServer{}, job)
+		require.NoError(t, err)
+		assert.Equal(t, model.BulkExportFilter{
+			Since:      100,
+			Until:      200,
+			TeamIds:    []string{"team1", "team2"},
+			ChannelIds: []string{"channel1"},
+		}, filter)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		_, err := makeBulkExportFilter(rctx, &jobs.JobServer{}, &model.Job{Data: model.StringMap{"since": "yesterday"}})
+		require.Error(t, err)
+
+		_, err = makeBulkExportFilter(rctx, &jobs.JobServer{}, &model.Job{Data: model.StringMap{"since": "200", "until": "100"}})
+		require.Error(t, err)
+	})
+
+	t.Run("incremental continues from the last checkpoint of the same scope", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		t.Cleanup(func() { mockStore.AssertExpectations(t) })
+		mockStore.JobStore.On("GetAllByTypeAndStatusPage", rctx, []string{model.JobTypeExportProcess}, model.JobStatusSuccess, 0, exportJobsPerPage).Return([]*model.Job{
+			// A full export.
+			{Id: model.NewId(), Data: model.StringMap{"checkpoint": "4000"}},
+			// An incremental export of other teams.
+			{Id: model.NewId(), Data: model.StringMap{"checkpoint": "3000", "incremental": "true", "scope": "team3;"}},
+			{Id: model.NewId(), Data: model.StringMap{"checkpoint": "1234", "incremental": "true", "scope": "team1,team2;"}},
+		}, nil)
+
+		job := &model.Job{Data: model.StringMap{"incremental": "true", "team_ids": "team2,team1"}}
+		filter, err := makeBulkExportFilter(rctx, &jobs.JobServer{Store: mockStore}, job)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1234, filter.Since)
+		assert.Equal(t, "1234", job.Data["since"])
+		assert.Equal(t, "team1,team2;", job.Data["scope"])
+	})
+
+	t.Run("incremental looks through older exports", func(t *testing.T) {
+		fullExports := make([]*model.Job, exportJobsPerPage)
+		for i := range fullExports {
+			fullExports[i] = &model.Job{Id: model.NewId(), Data: model.StringMap{"checkpoint": "4000"}}
+		}
+
+		mockStore := &storetest.Store{}
+		t.Cleanup(func() { mockStore.AssertExpectations(t) })
+		mockStore.JobStore.On("GetAllByTypeAndStatusPage", rctx, []string{model.JobTypeExportProcess}, model.JobStatusSuccess, 0, exportJobsPerPage).Return(fullExports, nil)
+		mockStore.JobStore.On("GetAllByTypeAndStatusPage", rctx, []string{model.JobTypeExportProcess}, model.JobStatusSuccess, exportJobsPerPage, exportJobsPerPage).Return([]*model.Job{
+			{Id: model.NewId(), Data: model.StringMap{"checkpoint": "1234", "incremental": "true", "scope": ";"}},
+		}, nil)
+
+		filter, err := makeBulkExportFilter(rctx, &jobs.JobServer{Store: mockStore}, &model.Job{Data: model.StringMap{"incremental": "true"}})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1234, filter.Since)
+	})
+
+	t.Run("incremental without a previous export of the same scope", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		t.Cleanup(func() { mockStore.AssertExpectations(t) })
+		mockStore.JobStore.On("GetAllByTypeAndStatusPage", rctx, []string{model.JobTypeExportProcess}, model.JobStatusSuccess, 0, exportJobsPerPage).Return([]*model.Job{
+			{Id: model.NewId(), Data: model.StringMap{"checkpoint": "3000", "incremental": "true", "scope": "team3;"}},
+		}, nil)
+
+		filter, err := makeBulkExportFilter(rctx, &jobs.JobServer{Store: mockStore}, &model.Job{Data: model.StringMap{"incremental": "true", "channel_ids": "channel1"}})
+		require.NoError(t, err)
+		assert.False(t, filter.IsIncremental())
+	})
+}
@@ -5,6 +5,7 @@ package extract_content
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -48,6 +49,15 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *job
 			}
 			toTS *= 1000
 		}
+		// When set, only the files with the given comma separated extensions are
+		// processed, e.g. when new file types become searchable.
+		var onlyExtensions map[string]bool
+		if extStr, ok := job.Data["extensions"]; ok && extStr != "" {
+			onlyExtensions = map[string]bool{}
+			for _, ext := range strings.Split(extStr, ",") {
+				onlyExtensions[strings.ToLower(strings.TrimSpace(ext))] = true
+			}
+		}
 
 		var nFiles int
 		var nErrs int
@@ -65,6 +75,9 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *job
 				break
 			}
 			for _, fileInfo := range fileInfos {
+				if onlyExtensions != nil && !onlyExtensions[strings.ToLower(fileInfo.Extension)] {
+					continue
+				}
 				if !ignoredFiles[fileInfo.Extension] {
 					logger.Debug("Extracting file", mlog.String("filename", fileInfo.Name), mlog.String("filepath", fileInfo.Path))
 
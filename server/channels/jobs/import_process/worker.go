@@ -5,6 +5,8 @@ package import_process
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -19,17 +21,28 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
+const (
+	// ErrorPolicyAbort stops the import at the first line that fails.
+	ErrorPolicyAbort = "abort"
+	// ErrorPolicySkip skips the lines that fail, writing them to an errors file.
+	ErrorPolicySkip = "skip"
+)
+
 type AppIface interface {
 	configservice.ConfigService
 	RemoveFile(path string) *model.AppError
 	FileExists(path string) (bool, *model.AppError)
 	FileSize(path string) (int64, *model.AppError)
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
-	BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int)
+	BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*model.AppError, int)
+	BulkImportDryRun(c request.CTX, jsonlReader io.Reader) (*imports.DryRunReport, *model.AppError)
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
+	AppendFile(fr io.Reader, path string) (int64, *model.AppError)
 	Log() *mlog.Logger
 }
 
@@ -123,9 +136,36 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.missing_jsonl", nil, "jsonFile was nil", http.StatusBadRequest)
 		}
 
-		extractContent := job.Data["extract_content"] == "true"
+		// A dry run only reports what the import would change, and keeps the import file so
+		// that it can be processed afterwards.
+		if job.Data["dry_run"] == "true" {
+			report, appErr := app.BulkImportDryRun(appContext, jsonFile)
+			if appErr != nil {
+				return appErr
+			}
+			return writeDryRunReport(app, job, importFileName, report)
+		}
+
+		opts := imports.BulkImportOpts{
+			ExtractContent: job.Data["extract_content"] == "true",
+			Workers:        runtime.NumCPU(),
+			ImportPath:     model.ExportDataDir,
+			SkipErrors:     job.Data["error_policy"] == ErrorPolicySkip,
+			Checkpoint:     makeCheckpoint(jobServer, app, job, importFileName),
+		}
+
+		// A job that stopped before the end resumes after the last committed line.
+		if lastLine, ok := job.Data["last_line"]; ok {
+			if opts.StartLine, err = strconv.Atoi(lastLine); err != nil {
+				return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.invalid_checkpoint", nil, "", http.StatusBadRequest).Wrap(err)
+			}
+			delete(job.Data, "error")
+			delete(job.Data, "line_number")
+			logger.Info("Resuming import", mlog.Int("last_line", opts.StartLine))
+		}
+
 		// do the actual import.
-		appErr, lineNumber := app.BulkImportWithPath(appContext, jsonFile, importZipReader, false, extractContent, runtime.NumCPU(), model.ExportDataDir)
+		appErr, lineNumber := app.BulkImportWithOpts(appContext, jsonFile, importZipReader, opts)
 		if appErr != nil {
 			job.Data["line_number"] = strconv.Itoa(lineNumber)
 			return appErr
@@ -143,3 +183,70 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
 	return worker
 }
+
+// makeCheckpoint returns the function recording the progress of an import in the job data, so
+// that the job can resume from the last committed line. The skipped lines are appended to an
+// errors file next to the import files.
+func makeCheckpoint(jobServer *jobs.JobServer, app AppIface, job *model.Job, importFileName string) func(int, []imports.SkippedLine) *model.AppError {
+	baseName := filepath.Base(importFileName)
+	errorsFileName := strings.TrimSuffix(baseName, filepath.Ext(baseName)) + "_errors.jsonl"
+	errorsFilePath := filepath.Join(*app.Config().ImportSettings.Directory, errorsFileName)
+
+	return func(lineNumber int, skipped []imports.SkippedLine) *model.AppError {
+		if len(skipped) > 0 {
+			var buf bytes.Buffer
+			encoder := json.NewEncoder(&buf)
+			for _, line := range skipped {
+				if err := encoder.Encode(line); err != nil {
+					return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.write_errors", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+			}
+
+			write := app.WriteFile
+			if job.Data["errors_file"] != "" {
+				write = app.AppendFile
+			}
+			if _, appErr := write(&buf, errorsFilePath); appErr != nil {
+				return appErr
+			}
+
+			skippedLines, _ := strconv.Atoi(job.Data["skipped_lines"])
+			job.Data["skipped_lines"] = strconv.Itoa(skippedLines + len(skipped))
+			job.Data["errors_file"] = errorsFileName
+		}
+
+		batch, _ := strconv.Atoi(job.Data["batch"])
+		job.Data["batch"] = strconv.Itoa(batch + 1)
+		job.Data["last_line"] = strconv.Itoa(lineNumber)
+		return jobServer.UpdateInProgressJobData(job)
+	}
+}
+
+// writeDryRunReport saves the report of a dry run next to the import files, and adds a summary
+// of it to the job data.
+func writeDryRunReport(app AppIface, job *model.Job, importFileName string, report *imports.DryRunReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.write_report", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	baseName := filepath.Base(importFileName)
+	reportFileName := strings.TrimSuffix(baseName, filepath.Ext(baseName)) + "_dry_run_report.json"
+	if _, appErr := app.WriteFile(bytes.NewReader(data), filepath.Join(*app.Config().ImportSettings.Directory, reportFileName)); appErr != nil {
+		return appErr
+	}
+
+	summarize := func(counts imports.DryRunCounts) string {
+		return fmt.Sprintf("%d created, %d updated, %d skipped", counts.Create, counts.Update, counts.Skip)
+	}
+	job.Data["report_file"] = reportFileName
+	job.Data["teams"] = summarize(report.Teams)
+	job.Data["channels"] = summarize(report.Channels)
+	job.Data["users"] = summarize(report.Users)
+	job.Data["posts"] = summarize(report.Posts)
+	job.Data["direct_channels"] = summarize(report.DirectChannels)
+	job.Data["direct_posts"] = summarize(report.DirectPosts)
+	job.Data["conflicts"] = strconv.Itoa(len(report.Conflicts))
+	job.Data["errors"] = strconv.Itoa(len(report.Errors))
+	return nil
+}
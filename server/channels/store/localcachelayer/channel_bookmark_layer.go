@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// LocalCacheChannelBookmarkStore caches the bookmarks of a channel. Only the full list of
+// bookmarks is cached, requests for the bookmarks changed since a given time go to the store.
+type LocalCacheChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheChannelBookmarkStore) handleClusterInvalidateChannelBookmarks(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.channelBookmarksCache.Purge()
+	} else {
+		s.rootStore.channelBookmarksCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCacheChannelBookmarkStore) ClearCaches() {
+	s.rootStore.doClearCacheCluster(s.rootStore.channelBookmarksCache)
+
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.channelBookmarksCache.Name())
+	}
+}
+
+func (s LocalCacheChannelBookmarkStore) InvalidateBookmarksForChannel(channelID string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.channelBookmarksCache, channelID, nil)
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.channelBookmarksCache.Name())
+	}
+}
+
+func (s LocalCacheChannelBookmarkStore) GetBookmarksForChannelSince(channelID string, since int64) ([]*model.ChannelBookmarkWithFileInfo, error) {
+	if since != 0 {
+		return s.ChannelBookmarkStore.GetBookmarksForChannelSince(channelID, since)
+	}
+
+	var bookmarks []*model.ChannelBookmarkWithFileInfo
+	if err := s.rootStore.doStandardReadCache(s.rootStore.channelBookmarksCache, channelID, &bookmarks); err == nil {
+		return bookmarks, nil
+	}
+
+	bookmarks, err := s.ChannelBookmarkStore.GetBookmarksForChannelSince(channelID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelBookmarksCache, channelID, bookmarks)
+
+	return bookmarks, nil
+}
+
+func (s LocalCacheChannelBookmarkStore) Save(bookmark *model.ChannelBookmark, increaseSortOrder bool) (*model.ChannelBookmarkWithFileInfo, error) {
+	defer s.InvalidateBookmarksForChannel(bookmark.ChannelId)
+	return s.ChannelBookmarkStore.Save(bookmark, increaseSortOrder)
+}
+
+func (s LocalCacheChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+	defer s.InvalidateBookmarksForChannel(bookmark.ChannelId)
+	return s.ChannelBookmarkStore.Update(bookmark)
+}
+
+func (s LocalCacheChannelBookmarkStore) UpdateSortOrder(bookmarkID, channelID string, newIndex int64) ([]*model.ChannelBookmarkWithFileInfo, error) {
+	defer s.InvalidateBookmarksForChannel(channelID)
+	return s.ChannelBookmarkStore.UpdateSortOrder(bookmarkID, channelID, newIndex)
+}
+
+func (s LocalCacheChannelBookmarkStore) Delete(bookmarkID string, deleteFile bool) error {
+	bookmark, err := s.ChannelBookmarkStore.Get(bookmarkID, true)
+	if err != nil {
+		return s.ChannelBookmarkStore.Delete(bookmarkID, deleteFile)
+	}
+
+	defer s.InvalidateBookmarksForChannel(bookmark.ChannelId)
+	return s.ChannelBookmarkStore.Delete(bookmarkID, deleteFile)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+func TestChannelBookmarkStore(t *testing.T) {
+	StoreTestWithSqlStore(t, storetest.TestChannelBookmarkStore)
+}
+
+func TestChannelBookmarkStoreCache(t *testing.T) {
+	runWithCacheProviders(t, func(t *testing.T, cacheProvider cache.Provider) {
+		t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			bookmarks, err := cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 0)
+			require.NoError(t, err)
+			require.Len(t, bookmarks, 1)
+			mockStore.ChannelBookmark().(*mocks.ChannelBookmarkStore).AssertNumberOfCalls(t, "GetBookmarksForChannelSince", 1)
+
+			cachedBookmarks, err := cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 0)
+			require.NoError(t, err)
+			assert.Equal(t, bookmarks, cachedBookmarks)
+			mockStore.ChannelBookmark().(*mocks.ChannelBookmarkStore).AssertNumberOfCalls(t, "GetBookmarksForChannelSince", 1)
+		})
+
+		t.Run("bookmarks changed since a given time are not cached", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 1)
+			cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 1)
+			mockStore.ChannelBookmark().(*mocks.ChannelBookmarkStore).AssertNumberOfCalls(t, "GetBookmarksForChannelSince", 2)
+		})
+
+		t.Run("sorting bookmarks invalidates the channel", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 0)
+			_, err := cachedStore.ChannelBookmark().UpdateSortOrder("123", "123", 1)
+			require.NoError(t, err)
+			cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 0)
+			mockStore.ChannelBookmark().(*mocks.ChannelBookmarkStore).AssertNumberOfCalls(t, "GetBookmarksForChannelSince", 2)
+		})
+
+		t.Run("deleting a bookmark invalidates its channel", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 0)
+			require.NoError(t, cachedStore.ChannelBookmark().Delete("123", false))
+			cachedStore.ChannelBookmark().GetBookmarksForChannelSince("123", 0)
+			mockStore.ChannelBookmark().(*mocks.ChannelBookmarkStore).AssertNumberOfCalls(t, "GetBookmarksForChannelSince", 2)
+		})
+	})
+}
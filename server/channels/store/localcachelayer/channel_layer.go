@@ -544,3 +544,9 @@ func (s LocalCacheChannelStore) RemoveMembers(rctx request.CTX, channelId string
 	}
 	return nil
 }
+
+func (s LocalCacheChannelStore) UpdateSidebarCategories(userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+	// Moving channels in and out of the favorites category updates the favorite channel preferences.
+	defer s.rootStore.preference.InvalidateCategory(userID, model.PreferenceCategoryFavoriteChannel)
+	return s.ChannelStore.UpdateSidebarCategories(userID, teamID, categories)
+}
@@ -68,6 +68,20 @@ const (
 	TeamCacheSize = 20000
 	TeamCacheSec  = 30 * 60
 
+	ThreadMembershipCacheSize        = 50000
+	ThreadMembershipCacheSec         = 15 * 60
+	ThreadMembershipVersionCacheSize = model.SessionCacheSize
+	ThreadMembershipVersionCacheSec  = 15 * 60
+
+	PreferenceCategoryCacheSize = 50000
+	PreferenceCategoryCacheSec  = 30 * 60
+
+	PostPriorityCacheSize = 25000
+	PostPriorityCacheSec  = 30 * 60
+
+	ChannelBookmarksCacheSize = model.ChannelCacheSize
+	ChannelBookmarksCacheSec  = 15 * 60
+
 	ChannelCacheSec = 15 * 60 // 15 mins
 )
 
@@ -124,6 +138,21 @@ type LocalCacheStore struct {
 
 	termsOfService      LocalCacheTermsOfServiceStore
 	termsOfServiceCache cache.Cache
+
+	thread                       LocalCacheThreadStore
+	threadMembershipCache        cache.Cache
+	threadMembershipVersionCache cache.Cache
+
+	preference              LocalCachePreferenceStore
+	preferenceCategoryCache cache.Cache
+
+	postPriority      LocalCachePostPriorityStore
+	postPriorityCache cache.Cache
+
+	channelBookmark       LocalCacheChannelBookmarkStore
+	channelBookmarksCache cache.Cache
+
+	oauth LocalCacheOAuthStore
 }
 
 func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface, cacheProvider cache.Provider, logger mlog.LoggerIFace) (localCacheStore LocalCacheStore, err error) {
@@ -366,6 +395,61 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 	}
 	localCacheStore.team = LocalCacheTeamStore{TeamStore: baseStore.Team(), rootStore: &localCacheStore}
 
+	// Threads
+	if localCacheStore.threadMembershipVersionCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   ThreadMembershipVersionCacheSize,
+		Name:                   "ThreadMembershipVersion",
+		DefaultExpiry:          ThreadMembershipVersionCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForThreadMemberships,
+	}); err != nil {
+		return
+	}
+	// Memberships are keyed by the version of the memberships of their user, which is the
+	// only thing that needs to be invalidated across the cluster.
+	if localCacheStore.threadMembershipCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   ThreadMembershipCacheSize,
+		Name:                   "ThreadMembership",
+		DefaultExpiry:          ThreadMembershipCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventNone,
+	}); err != nil {
+		return
+	}
+	localCacheStore.thread = LocalCacheThreadStore{ThreadStore: baseStore.Thread(), rootStore: &localCacheStore}
+
+	// Preferences
+	if localCacheStore.preferenceCategoryCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   PreferenceCategoryCacheSize,
+		Name:                   "PreferenceCategory",
+		DefaultExpiry:          PreferenceCategoryCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForPreferences,
+	}); err != nil {
+		return
+	}
+	localCacheStore.preference = LocalCachePreferenceStore{PreferenceStore: baseStore.Preference(), rootStore: &localCacheStore}
+	localCacheStore.oauth = LocalCacheOAuthStore{OAuthStore: baseStore.OAuth(), rootStore: &localCacheStore}
+
+	// Post priorities
+	if localCacheStore.postPriorityCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   PostPriorityCacheSize,
+		Name:                   "PostPriority",
+		DefaultExpiry:          PostPriorityCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForPostPriority,
+	}); err != nil {
+		return
+	}
+	localCacheStore.postPriority = LocalCachePostPriorityStore{PostPriorityStore: baseStore.PostPriority(), rootStore: &localCacheStore}
+
+	// Channel bookmarks
+	if localCacheStore.channelBookmarksCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   ChannelBookmarksCacheSize,
+		Name:                   "ChannelBookmarks",
+		DefaultExpiry:          ChannelBookmarksCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForChannelBookmarks,
+	}); err != nil {
+		return
+	}
+	localCacheStore.channelBookmark = LocalCacheChannelBookmarkStore{ChannelBookmarkStore: baseStore.ChannelBookmark(), rootStore: &localCacheStore}
+
 	if cluster != nil {
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForReactions, localCacheStore.reaction.handleClusterInvalidateReaction)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForRoles, localCacheStore.role.handleClusterInvalidateRole)
@@ -390,6 +474,10 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForProfileInChannel, localCacheStore.user.handleClusterInvalidateProfilesInChannel)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForAllProfiles, localCacheStore.user.handleClusterInvalidateAllProfiles)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForTeams, localCacheStore.team.handleClusterInvalidateTeam)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForThreadMemberships, localCacheStore.thread.handleClusterInvalidateThreadMemberships)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForPreferences, localCacheStore.preference.handleClusterInvalidatePreferences)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForPostPriority, localCacheStore.postPriority.handleClusterInvalidatePostPriority)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForChannelBookmarks, localCacheStore.channelBookmark.handleClusterInvalidateChannelBookmarks)
 	}
 	return
 }
@@ -438,6 +526,26 @@ func (s LocalCacheStore) Team() store.TeamStore {
 	return s.team
 }
 
+func (s LocalCacheStore) Thread() store.ThreadStore {
+	return s.thread
+}
+
+func (s LocalCacheStore) Preference() store.PreferenceStore {
+	return s.preference
+}
+
+func (s LocalCacheStore) PostPriority() store.PostPriorityStore {
+	return s.postPriority
+}
+
+func (s LocalCacheStore) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.channelBookmark
+}
+
+func (s LocalCacheStore) OAuth() store.OAuthStore {
+	return s.oauth
+}
+
 func (s LocalCacheStore) DropAllTables() {
 	s.Invalidate()
 	s.Store.DropAllTables()
@@ -571,6 +679,11 @@ func (s *LocalCacheStore) Invalidate() {
 	s.doClearCacheCluster(s.profilesInChannelCache)
 	s.doClearCacheCluster(s.teamAllTeamIdsForUserCache)
 	s.doClearCacheCluster(s.rolePermissionsCache)
+	s.doClearCacheCluster(s.threadMembershipVersionCache)
+	s.doClearCacheCluster(s.threadMembershipCache)
+	s.doClearCacheCluster(s.preferenceCategoryCache)
+	s.doClearCacheCluster(s.postPriorityCache)
+	s.doClearCacheCluster(s.channelBookmarksCache)
 }
 
 // allocateCacheTargets is used to fill target value types
@@ -6,16 +6,20 @@ package localcachelayer
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
 	"github.com/mattermost/mattermost/server/v8/channels/testlib"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 	cachemocks "github.com/mattermost/mattermost/server/v8/platform/services/cache/mocks"
 )
@@ -187,9 +191,86 @@ func getMockStore(t *testing.T) *mocks.Store {
 	mockTeamStore.On("GetUserTeamIds", "123", false).Return(fakeUserTeamIds, nil)
 	mockStore.On("Team").Return(&mockTeamStore)
 
+	fakeThreadMembership := model.ThreadMembership{PostId: "123", UserId: "123", Following: true}
+	mockThreadStore := mocks.ThreadStore{}
+	mockThreadStore.On("GetMembershipForUser", "123", "123").Return(&fakeThreadMembership, nil)
+	mockThreadStore.On("GetMembershipForUser", "123", "456").Return(&fakeThreadMembership, nil)
+	mockThreadStore.On("MarkAsRead", "123", "123", mock.AnythingOfType("int64")).Return(nil)
+	mockThreadStore.On("MarkAllAsReadByTeam", "123", "123").Return(nil)
+	mockThreadStore.On("SaveMultipleMemberships", mock.Anything).Return([]*model.ThreadMembership{&fakeThreadMembership}, nil)
+	mockStore.On("Thread").Return(&mockThreadStore)
+
+	fakePreferences := model.Preferences{{UserId: "123", Category: model.PreferenceCategoryDisplaySettings, Name: "name", Value: "value"}}
+	mockPreferenceStore := mocks.PreferenceStore{}
+	mockPreferenceStore.On("GetCategory", "123", model.PreferenceCategoryDisplaySettings).Return(fakePreferences, nil)
+	mockPreferenceStore.On("GetCategory", "123", model.PreferenceCategoryFavoriteChannel).Return(model.Preferences{}, nil)
+	mockPreferenceStore.On("Save", mock.Anything).Return(nil)
+	mockPreferenceStore.On("Delete", "123", model.PreferenceCategoryDisplaySettings, "name").Return(nil)
+	mockPreferenceStore.On("PermanentDeleteByUser", "123").Return(nil)
+	mockStore.On("Preference").Return(&mockPreferenceStore)
+
+	mockOAuthStore := mocks.OAuthStore{}
+	mockOAuthStore.On("DeleteApp", "123").Return(nil)
+	mockStore.On("OAuth").Return(&mockOAuthStore)
+
+	fakePostPriorities := []*model.PostPriority{
+		{PostId: "123", Priority: model.NewPointer(model.PostPriorityUrgent)},
+		{PostId: "456", Priority: model.NewPointer(model.PostPriorityUrgent)},
+	}
+	mockPostPriorityStore := mocks.PostPriorityStore{}
+	mockPostPriorityStore.On("GetForPost", "123").Return(&model.PostPriority{Priority: model.NewPointer(model.PostPriorityUrgent)}, nil)
+	mockPostPriorityStore.On("GetForPosts", []string{"123", "456"}).Return(fakePostPriorities, nil)
+	mockPostPriorityStore.On("GetForPosts", []string{"456"}).Return(fakePostPriorities[1:], nil)
+	mockStore.On("PostPriority").Return(&mockPostPriorityStore)
+
+	fakeBookmark := model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: "123", ChannelId: "123", DisplayName: "bookmark"}}
+	mockChannelBookmarkStore := mocks.ChannelBookmarkStore{}
+	mockChannelBookmarkStore.On("GetBookmarksForChannelSince", "123", int64(0)).Return([]*model.ChannelBookmarkWithFileInfo{&fakeBookmark}, nil)
+	mockChannelBookmarkStore.On("GetBookmarksForChannelSince", "123", int64(1)).Return([]*model.ChannelBookmarkWithFileInfo{&fakeBookmark}, nil)
+	mockChannelBookmarkStore.On("Get", "123", true).Return(&fakeBookmark, nil)
+	mockChannelBookmarkStore.On("Delete", "123", false).Return(nil)
+	mockChannelBookmarkStore.On("UpdateSortOrder", "123", "123", int64(1)).Return([]*model.ChannelBookmarkWithFileInfo{&fakeBookmark}, nil)
+	mockStore.On("ChannelBookmark").Return(&mockChannelBookmarkStore)
+
 	return &mockStore
 }
 
+// runWithCacheProviders runs a test against a cache layer built with the LRU provider and, if
+// a Redis server is reachable, with the Redis provider.
+func runWithCacheProviders(t *testing.T, f func(t *testing.T, cacheProvider cache.Provider)) {
+	t.Run(model.CacheTypeLRU, func(t *testing.T) {
+		f(t, cache.NewProvider())
+	})
+
+	t.Run(model.CacheTypeRedis, func(t *testing.T) {
+		addr := os.Getenv("MM_CACHESETTINGS_REDISADDRESS")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+
+		cacheProvider, err := cache.NewRedisProvider(&cache.RedisOptions{RedisAddr: addr, DisableCache: true})
+		if err != nil {
+			t.Skipf("redis is not available at %s: %v", addr, err)
+		}
+		defer cacheProvider.Close()
+		if _, err = cacheProvider.Connect(); err != nil {
+			t.Skipf("redis is not available at %s: %v", addr, err)
+		}
+
+		f(t, cacheProvider)
+	})
+}
+
+// newTestLocalCacheLayer creates a cache layer on top of the mock store, making sure nothing
+// is left in the caches by a previous test sharing the same Redis server.
+func newTestLocalCacheLayer(t *testing.T, cacheProvider cache.Provider, metrics einterfaces.MetricsInterface) (*mocks.Store, LocalCacheStore) {
+	mockStore := getMockStore(t)
+	cachedStore, err := NewLocalCacheLayer(mockStore, metrics, nil, cacheProvider, mlog.CreateConsoleTestLogger(t))
+	require.NoError(t, err)
+	cachedStore.Invalidate()
+	return mockStore, cachedStore
+}
+
 func TestMain(m *testing.M) {
 	mainHelper = testlib.NewMainHelperWithOptions(nil)
 	defer mainHelper.Close()
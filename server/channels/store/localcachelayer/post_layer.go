@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
@@ -164,3 +165,19 @@ func (s LocalCachePostStore) AnalyticsPostCount(options *model.PostCountOptions)
 	s.rootStore.doStandardAddToCache(s.rootStore.postsUsageCache, cacheKey, count)
 	return count, nil
 }
+
+// Permanently deleting posts also deletes the memberships of the threads they started.
+func (s LocalCachePostStore) PermanentDelete(rctx request.CTX, postID string) error {
+	defer s.rootStore.thread.ClearCaches()
+	return s.PostStore.PermanentDelete(rctx, postID)
+}
+
+func (s LocalCachePostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
+	defer s.rootStore.thread.ClearCaches()
+	return s.PostStore.PermanentDeleteByUser(rctx, userID)
+}
+
+func (s LocalCachePostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
+	defer s.rootStore.thread.ClearCaches()
+	return s.PostStore.PermanentDeleteByChannel(rctx, channelID)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// LocalCachePostPriorityStore caches the priority of posts. The priority is saved along with
+// the post and never changes afterwards, so entries are never invalidated one by one.
+type LocalCachePostPriorityStore struct {
+	store.PostPriorityStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCachePostPriorityStore) handleClusterInvalidatePostPriority(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.postPriorityCache.Purge()
+	} else {
+		s.rootStore.postPriorityCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCachePostPriorityStore) ClearCaches() {
+	s.rootStore.doClearCacheCluster(s.rootStore.postPriorityCache)
+
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.postPriorityCache.Name())
+	}
+}
+
+func (s LocalCachePostPriorityStore) GetForPost(postID string) (*model.PostPriority, error) {
+	var priority *model.PostPriority
+	if err := s.rootStore.doStandardReadCache(s.rootStore.postPriorityCache, postID, &priority); err == nil {
+		return priority, nil
+	}
+
+	priority, err := s.PostPriorityStore.GetForPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.postPriorityCache, postID, priority)
+
+	return priority, nil
+}
+
+func (s LocalCachePostPriorityStore) GetForPosts(ids []string) ([]*model.PostPriority, error) {
+	if len(ids) == 0 {
+		return s.PostPriorityStore.GetForPosts(ids)
+	}
+
+	toPass := allocateCacheTargets[*model.PostPriority](len(ids))
+	var priorities []*model.PostPriority
+	var missingIDs []string
+	errs := s.rootStore.doMultiReadCache(s.rootStore.postPriorityCache, ids, toPass)
+	for i, err := range errs {
+		if err != nil {
+			missingIDs = append(missingIDs, ids[i])
+			continue
+		}
+		gotPriority := *(toPass[i].(**model.PostPriority))
+		if gotPriority == nil {
+			missingIDs = append(missingIDs, ids[i])
+			continue
+		}
+		gotPriority.PostId = ids[i]
+		priorities = append(priorities, gotPriority)
+	}
+
+	if len(missingIDs) == 0 {
+		return priorities, nil
+	}
+
+	fetched, err := s.PostPriorityStore.GetForPosts(missingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, priority := range fetched {
+		s.rootStore.doStandardAddToCache(s.rootStore.postPriorityCache, priority.PostId, priority)
+	}
+
+	return append(priorities, fetched...), nil
+}
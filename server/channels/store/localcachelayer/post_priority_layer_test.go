@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+func TestPostPriorityStore(t *testing.T) {
+	StoreTestWithSqlStore(t, storetest.TestPostPriorityStore)
+}
+
+func TestPostPriorityStoreCache(t *testing.T) {
+	runWithCacheProviders(t, func(t *testing.T, cacheProvider cache.Provider) {
+		t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			priority, err := cachedStore.PostPriority().GetForPost("123")
+			require.NoError(t, err)
+			assert.Equal(t, model.PostPriorityUrgent, *priority.Priority)
+			mockStore.PostPriority().(*mocks.PostPriorityStore).AssertNumberOfCalls(t, "GetForPost", 1)
+
+			cachedPriority, err := cachedStore.PostPriority().GetForPost("123")
+			require.NoError(t, err)
+			assert.Equal(t, priority, cachedPriority)
+			mockStore.PostPriority().(*mocks.PostPriorityStore).AssertNumberOfCalls(t, "GetForPost", 1)
+		})
+
+		t.Run("multiple posts only fetch the priorities missing from the cache", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.PostPriority().GetForPost("123")
+
+			priorities, err := cachedStore.PostPriority().GetForPosts([]string{"123", "456"})
+			require.NoError(t, err)
+			require.Len(t, priorities, 2)
+			assert.ElementsMatch(t, []string{"123", "456"}, []string{priorities[0].PostId, priorities[1].PostId})
+			mockStore.PostPriority().(*mocks.PostPriorityStore).AssertCalled(t, "GetForPosts", []string{"456"})
+
+			priorities, err = cachedStore.PostPriority().GetForPosts([]string{"123", "456"})
+			require.NoError(t, err)
+			require.Len(t, priorities, 2)
+			mockStore.PostPriority().(*mocks.PostPriorityStore).AssertNumberOfCalls(t, "GetForPosts", 1)
+		})
+
+		t.Run("first call not cached, clear caches, and then not cached again", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.PostPriority().GetForPost("123")
+			cachedStore.PostPriority().(LocalCachePostPriorityStore).ClearCaches()
+			cachedStore.PostPriority().GetForPost("123")
+			mockStore.PostPriority().(*mocks.PostPriorityStore).AssertNumberOfCalls(t, "GetForPost", 2)
+		})
+	})
+}
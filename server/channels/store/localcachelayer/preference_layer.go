@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type LocalCachePreferenceStore struct {
+	store.PreferenceStore
+	rootStore *LocalCacheStore
+}
+
+func preferenceCategoryKey(userID, category string) string {
+	return userID + ":" + category
+}
+
+func (s *LocalCachePreferenceStore) handleClusterInvalidatePreferences(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.preferenceCategoryCache.Purge()
+	} else {
+		s.rootStore.preferenceCategoryCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCachePreferenceStore) ClearCaches() {
+	s.rootStore.doClearCacheCluster(s.rootStore.preferenceCategoryCache)
+
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.preferenceCategoryCache.Name())
+	}
+}
+
+func (s LocalCachePreferenceStore) InvalidateCategory(userID, category string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.preferenceCategoryCache, preferenceCategoryKey(userID, category), nil)
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.preferenceCategoryCache.Name())
+	}
+}
+
+func (s LocalCachePreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+	key := preferenceCategoryKey(userID, category)
+
+	var preferences model.Preferences
+	if err := s.rootStore.doStandardReadCache(s.rootStore.preferenceCategoryCache, key, &preferences); err == nil {
+		return preferences, nil
+	}
+
+	preferences, err := s.PreferenceStore.GetCategory(userID, category)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.preferenceCategoryCache, key, preferences)
+
+	return preferences, nil
+}
+
+func (s LocalCachePreferenceStore) Save(preferences model.Preferences) error {
+	defer func() {
+		invalidated := make(map[string]bool, len(preferences))
+		for _, preference := range preferences {
+			key := preferenceCategoryKey(preference.UserId, preference.Category)
+			if !invalidated[key] {
+				invalidated[key] = true
+				s.InvalidateCategory(preference.UserId, preference.Category)
+			}
+		}
+	}()
+	return s.PreferenceStore.Save(preferences)
+}
+
+func (s LocalCachePreferenceStore) Delete(userID, category, name string) error {
+	defer s.InvalidateCategory(userID, category)
+	return s.PreferenceStore.Delete(userID, category, name)
+}
+
+func (s LocalCachePreferenceStore) DeleteCategory(userID string, category string) error {
+	defer s.InvalidateCategory(userID, category)
+	return s.PreferenceStore.DeleteCategory(userID, category)
+}
+
+func (s LocalCachePreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	defer s.ClearCaches()
+	return s.PreferenceStore.DeleteCategoryAndName(category, name)
+}
+
+func (s LocalCachePreferenceStore) PermanentDeleteByUser(userID string) error {
+	defer s.ClearCaches()
+	return s.PreferenceStore.PermanentDeleteByUser(userID)
+}
+
+func (s LocalCachePreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+	deleted, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+	if deleted > 0 {
+		s.ClearCaches()
+	}
+	return deleted, err
+}
+
+func (s LocalCachePreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	deleted, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	if deleted > 0 {
+		s.ClearCaches()
+	}
+	return deleted, err
+}
+
+func (s LocalCachePreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+	deleted, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+	if deleted > 0 {
+		s.ClearCaches()
+	}
+	return deleted, err
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	einterfacesmocks "github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+func TestPreferenceStore(t *testing.T) {
+	StoreTestWithSqlStore(t, storetest.TestPreferenceStore)
+}
+
+func TestPreferenceStoreCache(t *testing.T) {
+	runWithCacheProviders(t, func(t *testing.T, cacheProvider cache.Provider) {
+		t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			preferences, err := cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			require.NoError(t, err)
+			require.Len(t, preferences, 1)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 1)
+
+			cachedPreferences, err := cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			require.NoError(t, err)
+			assert.Equal(t, preferences, cachedPreferences)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 1)
+		})
+
+		t.Run("saving a preference invalidates its category", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 1)
+
+			require.NoError(t, cachedStore.Preference().Save(model.Preferences{{UserId: "123", Category: model.PreferenceCategoryDisplaySettings, Name: "name", Value: "other"}}))
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 2)
+		})
+
+		t.Run("deleting a preference invalidates its category", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			require.NoError(t, cachedStore.Preference().Delete("123", model.PreferenceCategoryDisplaySettings, "name"))
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 2)
+		})
+
+		t.Run("updating sidebar categories invalidates the favorite channels", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+			mockStore.Channel().(*mocks.ChannelStore).On("UpdateSidebarCategories", "123", "123", mock.Anything).Return(nil, nil, nil)
+
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryFavoriteChannel)
+			_, _, err := cachedStore.Channel().UpdateSidebarCategories("123", "123", []*model.SidebarCategoryWithChannels{})
+			require.NoError(t, err)
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryFavoriteChannel)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 2)
+		})
+
+		t.Run("deleting an OAuth app clears the cache", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			require.NoError(t, cachedStore.OAuth().DeleteApp("123"))
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			mockStore.Preference().(*mocks.PreferenceStore).AssertNumberOfCalls(t, "GetCategory", 2)
+		})
+
+		t.Run("records cache hits and misses", func(t *testing.T) {
+			metrics := &einterfacesmocks.MetricsInterface{}
+			metrics.On("IncrementMemCacheMissCounter", "PreferenceCategory").Return().Once()
+			metrics.On("IncrementMemCacheHitCounter", "PreferenceCategory").Return().Once()
+			metrics.On("IncrementMemCacheInvalidationCounter", mock.AnythingOfType("string")).Return().Maybe()
+			_, cachedStore := newTestLocalCacheLayer(t, cacheProvider, einterfaces.MetricsInterface(metrics))
+
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			cachedStore.Preference().GetCategory("123", model.PreferenceCategoryDisplaySettings)
+			metrics.AssertExpectations(t)
+		})
+	})
+}
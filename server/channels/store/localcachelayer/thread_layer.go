@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// LocalCacheThreadStore caches the thread memberships of users. Many writes change every
+// membership of a user in a team or a set of channels, so the memberships are cached under a
+// version of the user's memberships, and invalidating a user only drops its version. Entries
+// cached under an older version are never read again and expire on their own.
+type LocalCacheThreadStore struct {
+	store.ThreadStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheThreadStore) handleClusterInvalidateThreadMemberships(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.threadMembershipVersionCache.Purge()
+	} else {
+		s.rootStore.threadMembershipVersionCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCacheThreadStore) ClearCaches() {
+	s.rootStore.doClearCacheCluster(s.rootStore.threadMembershipVersionCache)
+	s.rootStore.doClearCacheCluster(s.rootStore.threadMembershipCache)
+
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.threadMembershipVersionCache.Name())
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.threadMembershipCache.Name())
+	}
+}
+
+func (s LocalCacheThreadStore) InvalidateMembershipsForUser(userID string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.threadMembershipVersionCache, userID, nil)
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.threadMembershipVersionCache.Name())
+	}
+}
+
+func (s LocalCacheThreadStore) invalidateMemberships(memberships []*model.ThreadMembership) {
+	userIDs := make(map[string]bool, len(memberships))
+	for _, membership := range memberships {
+		if membership != nil && !userIDs[membership.UserId] {
+			userIDs[membership.UserId] = true
+			s.InvalidateMembershipsForUser(membership.UserId)
+		}
+	}
+}
+
+// membershipsVersion returns the current version of the memberships of a user, starting a new
+// one if the user has none cached.
+func (s LocalCacheThreadStore) membershipsVersion(userID string) string {
+	var version string
+	if err := s.rootStore.doStandardReadCache(s.rootStore.threadMembershipVersionCache, userID, &version); err == nil {
+		return version
+	}
+
+	version = model.NewId()
+	s.rootStore.doStandardAddToCache(s.rootStore.threadMembershipVersionCache, userID, version)
+	return version
+}
+
+func (s LocalCacheThreadStore) GetMembershipForUser(userID, postID string) (*model.ThreadMembership, error) {
+	key := userID + ":" + s.membershipsVersion(userID) + ":" + postID
+
+	var membership *model.ThreadMembership
+	if err := s.rootStore.doStandardReadCache(s.rootStore.threadMembershipCache, key, &membership); err == nil {
+		return membership, nil
+	}
+
+	membership, err := s.ThreadStore.GetMembershipForUser(userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.threadMembershipCache, key, membership)
+
+	return membership, nil
+}
+
+func (s LocalCacheThreadStore) MarkAllAsRead(userID string, threadIds []string) error {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.MarkAllAsRead(userID, threadIds)
+}
+
+func (s LocalCacheThreadStore) MarkAllAsReadByTeam(userID, teamID string) error {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.MarkAllAsReadByTeam(userID, teamID)
+}
+
+func (s LocalCacheThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []string) error {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.MarkAllAsReadByChannels(userID, channelIDs)
+}
+
+func (s LocalCacheThreadStore) MarkAsRead(userID, threadID string, timestamp int64) error {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.MarkAsRead(userID, threadID, timestamp)
+}
+
+func (s LocalCacheThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	defer s.InvalidateMembershipsForUser(membership.UserId)
+	return s.ThreadStore.UpdateMembership(membership)
+}
+
+func (s LocalCacheThreadStore) DeleteMembershipForUser(userID, postID string) error {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.DeleteMembershipForUser(userID, postID)
+}
+
+func (s LocalCacheThreadStore) MaintainMembership(userID, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.MaintainMembership(userID, postID, opts)
+}
+
+func (s LocalCacheThreadStore) DeleteMembershipsForChannel(userID, channelID string) error {
+	defer s.InvalidateMembershipsForUser(userID)
+	return s.ThreadStore.DeleteMembershipsForChannel(userID, channelID)
+}
+
+func (s LocalCacheThreadStore) SaveMultipleMemberships(memberships []*model.ThreadMembership) ([]*model.ThreadMembership, error) {
+	defer s.invalidateMemberships(memberships)
+	return s.ThreadStore.SaveMultipleMemberships(memberships)
+}
+
+func (s LocalCacheThreadStore) MaintainMultipleFromImport(memberships []*model.ThreadMembership) ([]*model.ThreadMembership, error) {
+	defer s.invalidateMemberships(memberships)
+	return s.ThreadStore.MaintainMultipleFromImport(memberships)
+}
+
+func (s LocalCacheThreadStore) PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	deleted, cursor, err := s.ThreadStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+	if deleted > 0 {
+		s.ClearCaches()
+	}
+	return deleted, cursor, err
+}
+
+func (s LocalCacheThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	deleted, cursor, err := s.ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+	if deleted > 0 {
+		s.ClearCaches()
+	}
+	return deleted, cursor, err
+}
+
+func (s LocalCacheThreadStore) DeleteOrphanedRows(limit int) (int64, error) {
+	deleted, err := s.ThreadStore.DeleteOrphanedRows(limit)
+	if deleted > 0 {
+		s.ClearCaches()
+	}
+	return deleted, err
+}
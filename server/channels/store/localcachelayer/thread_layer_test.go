@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+func TestThreadStore(t *testing.T) {
+	StoreTestWithSqlStore(t, storetest.TestThreadStore)
+}
+
+func TestThreadStoreCache(t *testing.T) {
+	runWithCacheProviders(t, func(t *testing.T, cacheProvider cache.Provider) {
+		t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			membership, err := cachedStore.Thread().GetMembershipForUser("123", "123")
+			require.NoError(t, err)
+			assert.True(t, membership.Following)
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 1)
+
+			cachedMembership, err := cachedStore.Thread().GetMembershipForUser("123", "123")
+			require.NoError(t, err)
+			assert.Equal(t, membership, cachedMembership)
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 1)
+		})
+
+		t.Run("marking a thread as read invalidates the memberships of the user", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Thread().GetMembershipForUser("123", "123")
+			cachedStore.Thread().GetMembershipForUser("123", "456")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 2)
+
+			require.NoError(t, cachedStore.Thread().MarkAsRead("123", "123", model.GetMillis()))
+			cachedStore.Thread().GetMembershipForUser("123", "123")
+			cachedStore.Thread().GetMembershipForUser("123", "456")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 4)
+		})
+
+		t.Run("marking a team as read invalidates the memberships of the user", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Thread().GetMembershipForUser("123", "456")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 1)
+
+			require.NoError(t, cachedStore.Thread().MarkAllAsReadByTeam("123", "123"))
+			cachedStore.Thread().GetMembershipForUser("123", "456")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 2)
+		})
+
+		t.Run("saving memberships invalidates the memberships of their users", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Thread().GetMembershipForUser("123", "123")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 1)
+
+			_, err := cachedStore.Thread().SaveMultipleMemberships([]*model.ThreadMembership{{PostId: "123", UserId: "123"}})
+			require.NoError(t, err)
+			cachedStore.Thread().GetMembershipForUser("123", "123")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 2)
+		})
+
+		t.Run("first call not cached, clear caches, and then not cached again", func(t *testing.T) {
+			mockStore, cachedStore := newTestLocalCacheLayer(t, cacheProvider, nil)
+
+			cachedStore.Thread().GetMembershipForUser("123", "123")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 1)
+			cachedStore.Thread().(LocalCacheThreadStore).ClearCaches()
+			cachedStore.Thread().GetMembershipForUser("123", "123")
+			mockStore.Thread().(*mocks.ThreadStore).AssertNumberOfCalls(t, "GetMembershipForUser", 2)
+		})
+	})
+}
@@ -17,6 +17,7 @@ import (
 func genStore() *mocks.Store {
 	mock := &mocks.Store{}
 	mock.On("Audit").Return(&mocks.AuditStore{})
+	mock.On("AuditLog").Return(&mocks.AuditLogStore{})
 	mock.On("Bot").Return(&mocks.BotStore{})
 	mock.On("Channel").Return(&mocks.ChannelStore{})
 	mock.On("ChannelMemberHistory").Return(&mocks.ChannelMemberHistoryStore{})
@@ -63,6 +64,11 @@ func genStore() *mocks.Store {
 	mock.On("DesktopTokens").Return(&mocks.DesktopTokensStore{})
 	mock.On("ChannelBookmark").Return(&mocks.ChannelBookmarkStore{})
 	mock.On("ScheduledPost").Return(&mocks.ScheduledPostStore{})
+	mock.On("OutgoingWebhookDelivery").Return(&mocks.OutgoingWebhookDeliveryStore{})
+	mock.On("OutOfOffice").Return(&mocks.OutOfOfficeStore{})
+	mock.On("Poll").Return(&mocks.PollStore{})
+	mock.On("WebAuthnCredential").Return(&mocks.WebAuthnCredentialStore{})
+	mock.On("PostReminder").Return(&mocks.PostReminderStore{})
 	return mock
 }
 
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlAuditLogStore struct {
+	*SqlStore
+}
+
+func newSqlAuditLogStore(sqlStore *SqlStore) store.AuditLogStore {
+	return &SqlAuditLogStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlAuditLogStore) columns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"EventName",
+		"Status",
+		"ActorId",
+		"SessionId",
+		"IpAddress",
+		"ObjectType",
+		"ObjectId",
+		"Data",
+	}
+}
+
+func (s *SqlAuditLogStore) Save(log *model.AuditLog) (*model.AuditLog, error) {
+	log.PreSave()
+	if err := log.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("AuditLogs").
+		Columns(s.columns()...).
+		Values(
+			log.Id,
+			log.CreateAt,
+			log.EventName,
+			log.Status,
+			log.ActorId,
+			log.SessionId,
+			log.IpAddress,
+			log.ObjectType,
+			log.ObjectId,
+			log.Data,
+		)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save AuditLog with eventName=%s", log.EventName)
+	}
+
+	return log, nil
+}
+
+func (s *SqlAuditLogStore) Search(opts model.AuditLogSearchOptions) ([]*model.AuditLog, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("AuditLogs").
+		OrderBy("CreateAt DESC", "Id").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	if opts.ActorId != "" {
+		query = query.Where(sq.Eq{"ActorId": opts.ActorId})
+	}
+	if opts.EventName != "" {
+		query = query.Where(sq.Eq{"EventName": opts.EventName})
+	}
+	if opts.ObjectType != "" {
+		query = query.Where(sq.Eq{"ObjectType": opts.ObjectType})
+	}
+	if opts.ObjectId != "" {
+		query = query.Where(sq.Eq{"ObjectId": opts.ObjectId})
+	}
+	if opts.StartTime > 0 {
+		query = query.Where(sq.GtOrEq{"CreateAt": opts.StartTime})
+	}
+	if opts.EndTime > 0 {
+		query = query.Where(sq.LtOrEq{"CreateAt": opts.EndTime})
+	}
+
+	logs := []*model.AuditLog{}
+	if err := s.GetReplica().SelectBuilder(&logs, query); err != nil {
+		return nil, errors.Wrap(err, "failed to search AuditLogs")
+	}
+
+	return logs, nil
+}
+
+func (s *SqlAuditLogStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM AuditLogs WHERE Id = any (array (SELECT Id FROM AuditLogs WHERE CreateAt < ? LIMIT ?))"
+	} else {
+		query = "DELETE FROM AuditLogs WHERE CreateAt < ? LIMIT ?"
+	}
+
+	result, err := s.GetMaster().Exec(query, endTime, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete AuditLogs in batch")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
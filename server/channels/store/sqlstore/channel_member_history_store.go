@@ -267,3 +267,26 @@ func (s SqlChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since
 
 	return channelIds, nil
 }
+
+// GetChannelsLeftForExport returns the channels of the team that the user left in the
+// time window of an incremental export, and isn't a member of anymore.
+func (s SqlChannelMemberHistoryStore) GetChannelsLeftForExport(userID string, teamID string, filter model.BulkExportFilter) ([]*model.ChannelMemberHistoryForExport, error) {
+	query := s.getQueryBuilder().
+		Select("cmh.ChannelId", "c.Name AS ChannelName", "MAX(cmh.LeaveTime) AS LeaveTime").
+		From("ChannelMemberHistory cmh").
+		Join("Channels c ON c.Id = cmh.ChannelId").
+		Where(sq.Eq{"cmh.UserId": userID, "c.TeamId": teamID}).
+		Where(sq.Expr("NOT EXISTS (?)", sq.Select("1").
+			From("ChannelMembers cm").
+			Where("cm.ChannelId = cmh.ChannelId AND cm.UserId = cmh.UserId"))).
+		GroupBy("cmh.ChannelId", "c.Name").
+		Having(exportWindowCond("MAX(cmh.LeaveTime)", filter)).
+		OrderBy("cmh.ChannelId")
+
+	channels := []*model.ChannelMemberHistoryForExport{}
+	if err := s.GetReplica().SelectBuilder(&channels, query); err != nil {
+		return nil, errors.Wrapf(err, "GetChannelsLeftForExport userId=%s teamId=%s", userID, teamID)
+	}
+
+	return channels, nil
+}
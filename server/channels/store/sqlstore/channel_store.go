@@ -4373,3 +4373,57 @@ func (s SqlChannelStore) IsChannelReadOnlyScheme(schemeID string) (bool, error)
 	permissionList := strings.Split(permissions, " ")
 	return slices.Index(permissionList, model.PermissionCreatePost.Id) == -1, nil
 }
+
+func (s SqlChannelStore) GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, error) {
+	postCountQuery := sq.Select("COUNT(p.Id)").
+		From("Posts p").
+		Where("p.ChannelId = c.Id").
+		Where(sq.Eq{"p.DeleteAt": 0})
+	if filter.StartAt > 0 {
+		postCountQuery = postCountQuery.Where(sq.GtOrEq{"p.CreateAt": filter.StartAt})
+	}
+	if filter.EndAt > 0 {
+		postCountQuery = postCountQuery.Where(sq.Lt{"p.CreateAt": filter.EndAt})
+	}
+
+	query := s.getQueryBuilder().
+		Select(
+			"c.Id AS ChannelId",
+			"c.Name",
+			"c.DisplayName",
+			"c.Type",
+			"c.TeamId",
+			"COALESCE(t.DisplayName, '') AS TeamName",
+			"c.CreateAt",
+			"c.DeleteAt",
+			"c.LastPostAt",
+			"(SELECT COUNT(*) FROM ChannelMembers cm WHERE cm.ChannelId = c.Id) AS MemberCount",
+		).
+		Column(sq.Alias(postCountQuery, "PostCount")).
+		From("Channels c").
+		LeftJoin("Teams t ON t.Id = c.TeamId").
+		Where(sq.Eq{"c.Type": []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate}})
+
+	if filter.Team != "" {
+		query = query.Where(sq.Eq{"c.TeamId": filter.Team})
+	}
+
+	if filter.FromId != "" {
+		query = query.Where(sq.Or{
+			sq.Gt{"c.Name": filter.FromColumnValue},
+			sq.And{
+				sq.Eq{"c.Name": filter.FromColumnValue},
+				sq.Gt{"c.Id": filter.FromId},
+			},
+		})
+	}
+
+	query = query.OrderBy("c.Name", "c.Id").Limit(uint64(filter.PageSize))
+
+	reports := []*model.ChannelActivityReport{}
+	if err := s.GetReplica().SelectBuilder(&reports, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get channel activity report")
+	}
+
+	return reports, nil
+}
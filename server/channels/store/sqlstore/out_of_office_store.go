@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlOutOfOfficeStore struct {
+	*SqlStore
+}
+
+func newSqlOutOfOfficeStore(sqlStore *SqlStore) store.OutOfOfficeStore {
+	return &SqlOutOfOfficeStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlOutOfOfficeStore) columns() []string {
+	return []string{
+		"UserId",
+		"StartAt",
+		"EndAt",
+		"ActivatedAt",
+		"CreateAt",
+		"UpdateAt",
+	}
+}
+
+func (s *SqlOutOfOfficeStore) Save(schedule *model.OutOfOfficeSchedule) (*model.OutOfOfficeSchedule, error) {
+	schedule.PreSave()
+	if err := schedule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("OutOfOfficeSchedules").
+		Columns(s.columns()...).
+		Values(schedule.UserId, schedule.StartAt, schedule.EndAt, schedule.ActivatedAt, schedule.CreateAt, schedule.UpdateAt)
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE StartAt = ?, EndAt = ?, ActivatedAt = ?, UpdateAt = ?",
+			schedule.StartAt, schedule.EndAt, schedule.ActivatedAt, schedule.UpdateAt))
+	} else {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (userid) DO UPDATE SET StartAt = ?, EndAt = ?, ActivatedAt = ?, UpdateAt = ?",
+			schedule.StartAt, schedule.EndAt, schedule.ActivatedAt, schedule.UpdateAt))
+	}
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutOfOfficeSchedule with userId=%s", schedule.UserId)
+	}
+
+	return schedule, nil
+}
+
+func (s *SqlOutOfOfficeStore) Get(userID string) (*model.OutOfOfficeSchedule, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("OutOfOfficeSchedules").
+		Where(sq.Eq{"UserId": userID})
+
+	var schedule model.OutOfOfficeSchedule
+	if err := s.GetMaster().GetBuilder(&schedule, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutOfOfficeSchedule", userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get OutOfOfficeSchedule with userId=%s", userID)
+	}
+
+	return &schedule, nil
+}
+
+func (s *SqlOutOfOfficeStore) Delete(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("OutOfOfficeSchedules").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete OutOfOfficeSchedule with userId=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlOutOfOfficeStore) GetDue(now int64, limit int) ([]*model.OutOfOfficeSchedule, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("OutOfOfficeSchedules").
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{"ActivatedAt": 0},
+				sq.LtOrEq{"StartAt": now},
+			},
+			sq.And{
+				sq.Gt{"EndAt": 0},
+				sq.LtOrEq{"EndAt": now},
+			},
+		}).
+		OrderBy("UserId").
+		Limit(uint64(limit))
+
+	schedules := []*model.OutOfOfficeSchedule{}
+	if err := s.GetMaster().SelectBuilder(&schedules, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find due OutOfOfficeSchedules")
+	}
+
+	return schedules, nil
+}
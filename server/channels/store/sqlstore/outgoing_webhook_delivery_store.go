@@ -0,0 +1,239 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlOutgoingWebhookDeliveryStore struct {
+	*SqlStore
+}
+
+func newSqlOutgoingWebhookDeliveryStore(sqlStore *SqlStore) store.OutgoingWebhookDeliveryStore {
+	return &SqlOutgoingWebhookDeliveryStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) columns(prefix string) []string {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix = prefix + "."
+	}
+
+	return []string{
+		prefix + "Id",
+		prefix + "HookId",
+		prefix + "ChannelId",
+		prefix + "PostId",
+		prefix + "CallbackURL",
+		prefix + "ContentType",
+		prefix + "Payload",
+		prefix + "Attempt",
+		prefix + "Status",
+		prefix + "StatusCode",
+		prefix + "Latency",
+		prefix + "Response",
+		prefix + "Error",
+		prefix + "CreateAt",
+		prefix + "NextRetryAt",
+	}
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) Save(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	delivery.PreSave()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("OutgoingWebhookDeliveries").
+		Columns(s.columns("")...).
+		Values(
+			delivery.Id,
+			delivery.HookId,
+			delivery.ChannelId,
+			delivery.PostId,
+			delivery.CallbackURL,
+			delivery.ContentType,
+			delivery.Payload,
+			delivery.Attempt,
+			delivery.Status,
+			delivery.StatusCode,
+			delivery.Latency,
+			delivery.Response,
+			delivery.Error,
+			delivery.CreateAt,
+			delivery.NextRetryAt,
+		)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) Get(id string) (*model.OutgoingWebhookDelivery, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns("")...).
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"Id": id})
+
+	var delivery model.OutgoingWebhookDelivery
+	if err := s.GetReplica().GetBuilder(&delivery, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutgoingWebhookDelivery", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get OutgoingWebhookDelivery with id=%s", id)
+	}
+
+	return &delivery, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) GetForHook(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns("")...).
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"HookId": hookID}).
+		OrderBy("CreateAt DESC", "Id").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"Status": opts.Status})
+	}
+
+	deliveries := []*model.OutgoingWebhookDelivery{}
+	if err := s.GetReplica().SelectBuilder(&deliveries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to find OutgoingWebhookDeliveries with hookId=%s", hookID)
+	}
+
+	return deliveries, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) GetPendingRetries(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns("")...).
+		From("OutgoingWebhookDeliveries").
+		Where(sq.And{
+			sq.Eq{"Status": model.OutgoingWebhookDeliveryStatusRetryPending},
+			sq.LtOrEq{"NextRetryAt": now},
+		}).
+		OrderBy("NextRetryAt", "Id").
+		Limit(uint64(limit))
+
+	deliveries := []*model.OutgoingWebhookDelivery{}
+	if err := s.GetMaster().SelectBuilder(&deliveries, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find pending OutgoingWebhookDeliveries")
+	}
+
+	return deliveries, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) UpdateStatus(id, oldStatus, newStatus string) (bool, error) {
+	builder := s.getQueryBuilder().
+		Update("OutgoingWebhookDeliveries").
+		Set("Status", newStatus).
+		Where(sq.Eq{
+			"Id":     id,
+			"Status": oldStatus,
+		})
+
+	result, err := s.GetMaster().ExecBuilder(builder)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update OutgoingWebhookDelivery with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) Delete(id, status string) (bool, error) {
+	builder := s.getQueryBuilder().
+		Delete("OutgoingWebhookDeliveries").
+		Where(sq.Eq{
+			"Id":     id,
+			"Status": status,
+		})
+
+	result, err := s.GetMaster().ExecBuilder(builder)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to delete OutgoingWebhookDelivery with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) ResolveFailures(hookID string, resolvedAt int64) error {
+	builder := s.getQueryBuilder().
+		Update("OutgoingWebhookDeliveries").
+		Set("ResolvedAt", resolvedAt).
+		Where(sq.Eq{
+			"HookId":     hookID,
+			"Status":     model.OutgoingWebhookDeliveryStatusFailed,
+			"ResolvedAt": 0,
+		})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to resolve failed OutgoingWebhookDeliveries with hookId=%s", hookID)
+	}
+
+	return nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) GetConsecutiveFailureCount(hookID string) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{
+			"HookId":     hookID,
+			"Status":     model.OutgoingWebhookDeliveryStatusFailed,
+			"ResolvedAt": 0,
+		})
+
+	var count int64
+	if err := s.GetMaster().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count failed OutgoingWebhookDeliveries with hookId=%s", hookID)
+	}
+
+	return count, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM OutgoingWebhookDeliveries WHERE Id = any (array (SELECT Id FROM OutgoingWebhookDeliveries WHERE CreateAt < ? LIMIT ?))"
+	} else {
+		query = "DELETE FROM OutgoingWebhookDeliveries WHERE CreateAt < ? LIMIT ?"
+	}
+
+	result, err := s.GetMaster().Exec(query, endTime, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete OutgoingWebhookDeliveries in batch")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
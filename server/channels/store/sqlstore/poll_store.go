@@ -0,0 +1,165 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlPollStore struct {
+	*SqlStore
+}
+
+func newSqlPollStore(sqlStore *SqlStore) store.PollStore {
+	return &SqlPollStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlPollStore) columns() []string {
+	return []string{
+		"PostId",
+		"UserId",
+		"Options",
+		"Anonymous",
+		"MultipleChoice",
+		"CloseAt",
+		"CreateAt",
+	}
+}
+
+func (s *SqlPollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	poll.PreSave()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("Polls").
+		Columns(s.columns()...).
+		Values(poll.PostId, poll.UserId, poll.Options, poll.Anonymous, poll.MultipleChoice, poll.CloseAt, poll.CreateAt)
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save Poll with postId=%s", poll.PostId)
+	}
+
+	return poll, nil
+}
+
+func (s *SqlPollStore) Get(postID string) (*model.Poll, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("Polls").
+		Where(sq.Eq{"PostId": postID})
+
+	var poll model.Poll
+	if err := s.GetReplica().GetBuilder(&poll, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Poll", postID)
+		}
+		return nil, errors.Wrapf(err, "failed to get Poll with postId=%s", postID)
+	}
+
+	return &poll, nil
+}
+
+func (s *SqlPollStore) Close(postID string, closeAt int64) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	result, err := transaction.ExecBuilder(s.getQueryBuilder().
+		Update("Polls").
+		Set("CloseAt", closeAt).
+		Where(sq.Eq{"PostId": postID}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to close Poll with postId=%s", postID)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("Poll", postID)
+	}
+
+	// Touch the post so that compliance exports pick up the final results.
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().
+		Update("Posts").
+		Set("UpdateAt", closeAt).
+		Where(sq.Eq{"Id": postID})); err != nil {
+		return errors.Wrapf(err, "failed to update Post with id=%s", postID)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPollStore) Vote(postID, userID string, options []int) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().
+		Delete("PollVotes").
+		Where(sq.Eq{"PostId": postID, "UserId": userID})); err != nil {
+		return errors.Wrapf(err, "failed to delete PollVotes with postId=%s, userId=%s", postID, userID)
+	}
+
+	now := model.GetMillis()
+	if len(options) > 0 {
+		query := s.getQueryBuilder().
+			Insert("PollVotes").
+			Columns("PostId", "UserId", "OptionIndex", "CreateAt")
+		for _, option := range options {
+			query = query.Values(postID, userID, option, now)
+		}
+
+		if _, err = transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to save PollVotes with postId=%s, userId=%s", postID, userID)
+		}
+	}
+
+	// Touch the post so that compliance exports pick up the new results.
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().
+		Update("Posts").
+		Set("UpdateAt", now).
+		Where(sq.Eq{"Id": postID})); err != nil {
+		return errors.Wrapf(err, "failed to update Post with id=%s", postID)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPollStore) GetVotes(postID string) ([]*model.PollVote, error) {
+	query := s.getQueryBuilder().
+		Select("PostId", "UserId", "OptionIndex", "CreateAt").
+		From("PollVotes").
+		Where(sq.Eq{"PostId": postID}).
+		OrderBy("CreateAt", "UserId", "OptionIndex")
+
+	votes := []*model.PollVote{}
+	if err := s.GetMaster().SelectBuilder(&votes, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PollVotes with postId=%s", postID)
+	}
+
+	return votes, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlPostReminderStore struct {
+	*SqlStore
+}
+
+func newSqlPostReminderStore(sqlStore *SqlStore) store.PostReminderStore {
+	return &SqlPostReminderStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlPostReminderStore) Save(reminder *model.PostReminder) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	var exist bool
+	if err = transaction.Get(&exist, `SELECT EXISTS (SELECT 1 FROM Posts WHERE Id=?)`, reminder.PostId); err != nil {
+		return errors.Wrap(err, "failed to check for post")
+	}
+	if !exist {
+		return store.NewErrNotFound("Post", reminder.PostId)
+	}
+
+	createAt := model.GetMillis()
+	query := s.getQueryBuilder().
+		Insert("UserPostReminders").
+		Columns("PostId", "UserId", "TargetTime", "CreateAt", "DeliveredAt").
+		Values(reminder.PostId, reminder.UserId, reminder.TargetTime, createAt, 0)
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE TargetTime = ?, CreateAt = ?, DeliveredAt = 0", reminder.TargetTime, createAt))
+	} else {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (postid, userid) DO UPDATE SET TargetTime = ?, CreateAt = ?, DeliveredAt = 0", reminder.TargetTime, createAt))
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "save_tosql")
+	}
+	if _, err = transaction.Exec(queryString, args...); err != nil {
+		return errors.Wrapf(err, "failed to save post reminder for postId=%s, userId=%s", reminder.PostId, reminder.UserId)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	return nil
+}
+
+func (s *SqlPostReminderStore) GetDue(now int64, limit int) ([]*model.PostReminder, error) {
+	reminders := []*model.PostReminder{}
+	query := s.getQueryBuilder().
+		Select("PostId", "UserId", "TargetTime").
+		From("UserPostReminders").
+		Where(sq.Eq{"DeliveredAt": 0}).
+		Where(sq.LtOrEq{"TargetTime": now}).
+		OrderBy("TargetTime ASC", "PostId ASC").
+		Limit(uint64(limit))
+
+	if err := s.GetMaster().SelectBuilder(&reminders, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get due post reminders")
+	}
+
+	return reminders, nil
+}
+
+func (s *SqlPostReminderStore) MarkDelivered(postID, userID string, deliveredAt int64) error {
+	query := s.getQueryBuilder().
+		Update("UserPostReminders").
+		Set("DeliveredAt", deliveredAt).
+		Where(sq.Eq{"PostId": postID, "UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to mark post reminder as delivered for postId=%s, userId=%s", postID, userID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReminderStore) Snooze(postID, userID string, targetTime int64) error {
+	query := s.getQueryBuilder().
+		Update("UserPostReminders").
+		Set("TargetTime", targetTime).
+		Set("DeliveredAt", 0).
+		Where(sq.Eq{"PostId": postID, "UserId": userID}).
+		Where(sq.Gt{"DeliveredAt": 0})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to snooze post reminder for postId=%s, userId=%s", postID, userID)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("PostReminder", postID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReminderStore) GetPendingForUser(userID string) ([]*model.PostReminder, error) {
+	reminders := []*model.PostReminder{}
+	query := s.getQueryBuilder().
+		Select("PostId", "UserId", "TargetTime").
+		From("UserPostReminders").
+		Where(sq.Eq{"UserId": userID, "DeliveredAt": 0}).
+		OrderBy("TargetTime ASC", "PostId ASC")
+
+	if err := s.GetReplica().SelectBuilder(&reminders, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get post reminders for userId=%s", userID)
+	}
+
+	return reminders, nil
+}
+
+func (s *SqlPostReminderStore) Delete(postID, userID string) error {
+	query := s.getQueryBuilder().
+		Delete("UserPostReminders").
+		Where(sq.Eq{"PostId": postID, "UserId": userID, "DeliveredAt": 0})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete post reminder for postId=%s, userId=%s", postID, userID)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("PostReminder", postID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReminderStore) DeleteDeliveredBefore(before int64) (int64, error) {
+	query := s.getQueryBuilder().
+		Delete("UserPostReminders").
+		Where(sq.Gt{"DeliveredAt": 0}).
+		Where(sq.Lt{"DeliveredAt": before})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete delivered post reminders")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return rowsAffected, nil
+}
@@ -2418,6 +2418,113 @@ func (s *SqlPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int6
 	return v, nil
 }
 
+// postVolumeReportWindow is the span of posts aggregated by each query of the post volume
+// report, so that a page never scans more than a few days of posts at once.
+const postVolumeReportWindow = 7 * 24 * time.Hour
+
+func (s *SqlPostStore) GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, error) {
+	const dayMillis = int64(24 * time.Hour / time.Millisecond)
+
+	// Days are computed in UTC, regardless of the time zone of the database, so that
+	// they line up with the windows below.
+	dayExpr := "DATE_FORMAT(DATE_ADD('1970-01-01', INTERVAL FLOOR(p.CreateAt / 86400000) DAY), '%Y-%m-%d')"
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		dayExpr = "TO_CHAR(DATE '1970-01-01' + (p.CreateAt / 86400000)::integer, 'YYYY-MM-DD')"
+	}
+
+	startAt := filter.StartAt
+	if filter.FromColumnValue != "" {
+		fromDay, err := time.Parse(time.DateOnly, filter.FromColumnValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid day %q", filter.FromColumnValue)
+		}
+		if fromDayStart := fromDay.UnixMilli(); fromDayStart > startAt {
+			startAt = fromDayStart
+		}
+	}
+
+	endAt := filter.EndAt
+	if startAt <= 0 || endAt <= 0 {
+		var bounds struct {
+			MinCreateAt int64
+			MaxCreateAt int64
+		}
+		if err := s.GetReplica().Get(&bounds, "SELECT COALESCE(MIN(CreateAt), 0) AS MinCreateAt, COALESCE(MAX(CreateAt), 0) AS MaxCreateAt FROM Posts"); err != nil {
+			return nil, errors.Wrap(err, "failed to get the range of posts")
+		}
+		if startAt <= 0 {
+			startAt = bounds.MinCreateAt
+		}
+		if endAt <= 0 {
+			endAt = bounds.MaxCreateAt + 1
+		}
+	}
+
+	reports := []*model.PostVolumeReport{}
+	windowMillis := postVolumeReportWindow.Milliseconds()
+	windowStart := startAt - startAt%dayMillis
+	for windowStart < endAt && len(reports) < filter.PageSize {
+		windowEnd := windowStart + windowMillis
+		volumeQuery := s.getQueryBuilder().
+			Select(
+				dayExpr+" AS Day",
+				"c.TeamId",
+				"COALESCE(t.DisplayName, '') AS TeamName",
+				"COUNT(p.Id) AS PostCount",
+			).
+			From("Posts p").
+			Join("Channels c ON c.Id = p.ChannelId").
+			LeftJoin("Teams t ON t.Id = c.TeamId").
+			Where(sq.Eq{"p.DeleteAt": 0}).
+			Where(sq.GtOrEq{"p.CreateAt": max(windowStart, startAt)}).
+			Where(sq.Lt{"p.CreateAt": min(windowEnd, endAt)}).
+			GroupBy(dayExpr, "c.TeamId", "t.DisplayName")
+
+		if filter.Team != "" {
+			volumeQuery = volumeQuery.Where(sq.Eq{"c.TeamId": filter.Team})
+		}
+
+		query := s.getQueryBuilder().
+			Select("*").
+			FromSelect(volumeQuery, "data")
+
+		if filter.FromColumnValue != "" {
+			query = query.Where(sq.Or{
+				sq.Gt{"Day": filter.FromColumnValue},
+				sq.And{
+					sq.Eq{"Day": filter.FromColumnValue},
+					sq.Gt{"TeamId": filter.FromId},
+				},
+			})
+		}
+
+		query = query.OrderBy("Day", "TeamId").Limit(uint64(filter.PageSize - len(reports)))
+
+		windowReports := []*model.PostVolumeReport{}
+		if err := s.GetReplica().SelectBuilder(&windowReports, query); err != nil {
+			return nil, errors.Wrap(err, "failed to get post volume report")
+		}
+		reports = append(reports, windowReports...)
+
+		if len(windowReports) > 0 {
+			windowStart = windowEnd
+			continue
+		}
+
+		// Skip the days without posts rather than scanning them window by window.
+		var nextCreateAt int64
+		if err := s.GetReplica().Get(&nextCreateAt, "SELECT COALESCE(MIN(CreateAt), 0) FROM Posts WHERE CreateAt >= ? AND CreateAt < ?", windowEnd, endAt); err != nil {
+			return nil, errors.Wrap(err, "failed to get the next post")
+		}
+		if nextCreateAt == 0 {
+			break
+		}
+		windowStart = nextCreateAt - nextCreateAt%dayMillis
+	}
+
+	return reports, nil
+}
+
 func (s *SqlPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
 	query := `SELECT * FROM Posts WHERE CreateAt = ? AND ChannelId = ?`
 
@@ -2668,41 +2775,53 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 			return postsForExport, nil
 		}
 
-		excludeDeletedCond := sq.And{
-			sq.Eq{"Teams.DeleteAt": 0},
+		result, err := s.getParentsForExport(rootIds, includeArchivedChannel)
+		if err != nil {
+			return nil, err
 		}
-		if !includeArchivedChannel {
-			excludeDeletedCond = append(excludeDeletedCond, sq.Eq{"Channels.DeleteAt": 0})
+
+		if len(result) == 0 {
+			// All of the posts were in channels or teams that were deleted.
+			// Update the afterId and try again.
+			afterId = rootIds[len(rootIds)-1]
+			continue
 		}
 
-		aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
-		if s.DriverName() == model.DatabaseDriverMysql {
-			aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
-		}
-		result := []*model.PostForExport{}
-
-		builder := s.getQueryBuilder().
-			Select(fmt.Sprintf("%s, Users.Username as Username, Teams.Name as TeamName, Channels.Name as ChannelName, %s as FlaggedBy", strings.Join(postSliceColumnsWithName("p1"), ", "), aggFn)).
-			FromSelect(sq.Select("*").From("Posts").Where(sq.Eq{"Posts.Id": rootIds}), "p1").
-			LeftJoin("Preferences ON p1.Id = Preferences.Name").
-			LeftJoin("Users u1 ON Preferences.UserId = u1.Id").
-			InnerJoin("Channels ON p1.ChannelId = Channels.Id").
-			InnerJoin("Teams ON Channels.TeamId = Teams.Id").
-			InnerJoin("Users ON p1.UserId = Users.Id").
-			Where(excludeDeletedCond).
-			GroupBy(fmt.Sprintf("%s, Users.Username, Teams.Name, Channels.Name", strings.Join(postSliceColumnsWithName("p1"), ", "))).
-			OrderBy("p1.Id")
-
-		query, args, err := builder.ToSql()
-		if err != nil {
-			return nil, errors.Wrap(err, "postsForExport_toSql")
+		return result, nil
+	}
+}
+
+// GetParentsForFilteredExportAfter returns the root posts of the threads in the
+// scope of the filter. For an incremental export, only the threads with a post
+// that changed in the time window are returned, including deleted posts.
+func (s *SqlPostStore) GetParentsForFilteredExportAfter(limit int, afterId string, includeArchivedChannel bool, filter model.BulkExportFilter) ([]*model.PostForExport, error) {
+	for {
+		query := s.filteredRootIdsForExportQuery(limit, afterId, filter).
+			Where(sq.Expr("p.ChannelId IN (?)", s.getQueryBuilder().
+				Select("Id").
+				From("Channels").
+				Where(sq.Eq{"Type": []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate}})))
+		if filter.HasScope() {
+			query = query.Where(sq.Expr("p.ChannelId IN (?)", s.getQueryBuilder().
+				Select("Id").
+				From("Channels").
+				Where(sq.Or{sq.Eq{"TeamId": filter.TeamIds}, sq.Eq{"Id": filter.ChannelIds}})))
 		}
 
-		err = s.GetSearchReplicaX().Select(&result, query, args...)
-		if err != nil {
+		rootIds := []string{}
+		if err := s.GetReplica().SelectBuilder(&rootIds, query); err != nil {
 			return nil, errors.Wrap(err, "failed to find Posts")
 		}
 
+		if len(rootIds) == 0 {
+			return []*model.PostForExport{}, nil
+		}
+
+		result, err := s.getParentsForExport(rootIds, includeArchivedChannel)
+		if err != nil {
+			return nil, err
+		}
+
 		if len(result) == 0 {
 			// All of the posts were in channels or teams that were deleted.
 			// Update the afterId and try again.
@@ -2714,6 +2833,81 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 	}
 }
 
+// filteredRootIdsForExportQuery selects the ids of the root posts after afterId
+// whose thread changed in the time window of the filter.
+func (s *SqlPostStore) filteredRootIdsForExportQuery(limit int, afterId string, filter model.BulkExportFilter) sq.SelectBuilder {
+	query := s.getQueryBuilder().
+		Select("p.Id").
+		From("Posts p").
+		Where(sq.Gt{"p.Id": afterId}).
+		Where(sq.Eq{"p.RootId": ""}).
+		OrderBy("p.Id").
+		Limit(uint64(limit))
+
+	if !filter.IsIncremental() {
+		return query.Where(sq.Eq{"p.DeleteAt": 0})
+	}
+
+	// Deleted posts are included so that the deletions are carried over.
+	return query.Where(sq.Or{
+		exportWindowCond("p.UpdateAt", filter),
+		sq.Expr("p.Id IN (?)", s.getQueryBuilder().
+			Select("r.RootId").
+			From("Posts r").
+			Where(sq.NotEq{"r.RootId": ""}).
+			Where(exportWindowCond("r.UpdateAt", filter))),
+	})
+}
+
+// exportWindowCond matches the rows whose column falls in the time window of
+// an incremental export.
+func exportWindowCond(column string, filter model.BulkExportFilter) sq.And {
+	cond := sq.And{sq.Gt{column: filter.Since}}
+	if filter.Until > 0 {
+		cond = append(cond, sq.LtOrEq{column: filter.Until})
+	}
+	return cond
+}
+
+func (s *SqlPostStore) getParentsForExport(rootIds []string, includeArchivedChannel bool) ([]*model.PostForExport, error) {
+	excludeDeletedCond := sq.And{
+		sq.Eq{"Teams.DeleteAt": 0},
+	}
+	if !includeArchivedChannel {
+		excludeDeletedCond = append(excludeDeletedCond, sq.Eq{"Channels.DeleteAt": 0})
+	}
+
+	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
+	if s.DriverName() == model.DatabaseDriverMysql {
+		aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
+	}
+	result := []*model.PostForExport{}
+
+	builder := s.getQueryBuilder().
+		Select(fmt.Sprintf("%s, Users.Username as Username, Teams.Name as TeamName, Channels.Name as ChannelName, %s as FlaggedBy", strings.Join(postSliceColumnsWithName("p1"), ", "), aggFn)).
+		FromSelect(sq.Select("*").From("Posts").Where(sq.Eq{"Posts.Id": rootIds}), "p1").
+		LeftJoin("Preferences ON p1.Id = Preferences.Name").
+		LeftJoin("Users u1 ON Preferences.UserId = u1.Id").
+		InnerJoin("Channels ON p1.ChannelId = Channels.Id").
+		InnerJoin("Teams ON Channels.TeamId = Teams.Id").
+		InnerJoin("Users ON p1.UserId = Users.Id").
+		Where(excludeDeletedCond).
+		GroupBy(fmt.Sprintf("%s, Users.Username, Teams.Name, Channels.Name", strings.Join(postSliceColumnsWithName("p1"), ", "))).
+		OrderBy("p1.Id")
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "postsForExport_toSql")
+	}
+
+	err = s.GetSearchReplicaX().Select(&result, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find Posts")
+	}
+
+	return result, nil
+}
+
 func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExport, error) {
 	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 	if s.DriverName() == model.DatabaseDriverMysql {
@@ -2743,12 +2937,74 @@ func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExpo
 	return result, nil
 }
 
+// GetAllRepliesForExport returns the replies to a post, including the deleted ones.
+func (s *SqlPostStore) GetAllRepliesForExport(rootId string) ([]*model.ReplyForExport, error) {
+	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
+	if s.DriverName() == model.DatabaseDriverMysql {
+		aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
+	}
+	result := []*model.ReplyForExport{}
+
+	qb := s.getQueryBuilder().Select(fmt.Sprintf("Posts.*, u2.Username as Username, %s as FlaggedBy", aggFn)).
+		From("Posts").
+		LeftJoin("Preferences ON Posts.Id = Preferences.Name").
+		LeftJoin("Users u1 ON Preferences.UserId = u1.Id").
+		InnerJoin("Users u2 ON Posts.UserId = u2.Id").
+		Where(sq.Eq{"Posts.RootId": rootId}).
+		GroupBy("Posts.Id, u2.Username").
+		OrderBy("Posts.Id")
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "postsForExport_toSql")
+	}
+
+	err = s.GetSearchReplicaX().Select(&result, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find Posts")
+	}
+
+	return result, nil
+}
+
 func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+	query := s.directPostParentsForExportQuery(limit, afterId, includeArchivedChannels).
+		Where(sq.Eq{"p.DeleteAt": 0})
+
+	return s.getDirectPostParentsForExport(query)
+}
+
+// GetDirectPostParentsForFilteredExportAfter returns the root posts of the direct
+// and group message threads in the scope of the filter. For an incremental export,
+// only the threads with a post that changed in the time window are returned,
+// including deleted posts.
+func (s *SqlPostStore) GetDirectPostParentsForFilteredExportAfter(limit int, afterId string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.DirectPostForExport, error) {
+	query := s.directPostParentsForExportQuery(limit, afterId, includeArchivedChannels)
+	if filter.HasScope() {
+		query = query.Where(sq.Eq{"p.ChannelId": filter.ChannelIds})
+	}
+
+	if !filter.IsIncremental() {
+		query = query.Where(sq.Eq{"p.DeleteAt": 0})
+	} else {
+		query = query.Where(sq.Or{
+			exportWindowCond("p.UpdateAt", filter),
+			sq.Expr("p.Id IN (?)", s.getQueryBuilder().
+				Select("r.RootId").
+				From("Posts r").
+				Where(sq.NotEq{"r.RootId": ""}).
+				Where(exportWindowCond("r.UpdateAt", filter))),
+		})
+	}
+
+	return s.getDirectPostParentsForExport(query)
+}
+
+func (s *SqlPostStore) directPostParentsForExportQuery(limit int, afterId string, includeArchivedChannels bool) sq.SelectBuilder {
 	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 	if s.DriverName() == model.DatabaseDriverMysql {
 		aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
 	}
-	result := []*model.DirectPostForExport{}
 
 	query := s.getQueryBuilder().
 		Select(fmt.Sprintf("p.*, u2.Username as User, %s as FlaggedBy", aggFn)).
@@ -2760,7 +3016,6 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 		Where(sq.And{
 			sq.Gt{"p.Id": afterId},
 			sq.Eq{"p.RootId": ""},
-			sq.Eq{"p.DeleteAt": 0},
 			sq.Eq{"Channels.Type": []model.ChannelType{model.ChannelTypeDirect, model.ChannelTypeGroup}},
 		}).
 		GroupBy("p.Id, u2.Username").
@@ -2773,6 +3028,12 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 		)
 	}
 
+	return query
+}
+
+func (s *SqlPostStore) getDirectPostParentsForExport(query sq.SelectBuilder) ([]*model.DirectPostForExport, error) {
+	result := []*model.DirectPostForExport{}
+
 	queryString, args, err := query.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "post_tosql")
@@ -3203,83 +3464,6 @@ func (s *SqlPostStore) updateThreadsFromPosts(transaction *sqlxTxWrapper, posts
 	return nil
 }
 
-func (s *SqlPostStore) SetPostReminder(reminder *model.PostReminder) error {
-	transaction, err := s.GetMaster().Beginx()
-	if err != nil {
-		return errors.Wrap(err, "begin_transaction")
-	}
-	defer finalizeTransactionX(transaction, &err)
-
-	sql := `SELECT EXISTS (SELECT 1 FROM Posts	WHERE Id=?)`
-	var exist bool
-	err = transaction.Get(&exist, sql, reminder.PostId)
-	if err != nil {
-		return errors.Wrap(err, "failed to check for post")
-	}
-	if !exist {
-		return store.NewErrNotFound("Post", reminder.PostId)
-	}
-
-	query := s.getQueryBuilder().
-		Insert("PostReminders").
-		Columns("PostId", "UserId", "TargetTime").
-		Values(reminder.PostId, reminder.UserId, reminder.TargetTime)
-
-	if s.DriverName() == model.DatabaseDriverMysql {
-		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE TargetTime = ?", reminder.TargetTime))
-	} else {
-		query = query.SuffixExpr(sq.Expr("ON CONFLICT (postid, userid) DO UPDATE SET TargetTime = ?", reminder.TargetTime))
-	}
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return errors.Wrap(err, "setPostReminder_tosql")
-	}
-	if _, err2 := transaction.Exec(sql, args...); err2 != nil {
-		return errors.Wrap(err2, "failed to insert post reminder")
-	}
-	if err = transaction.Commit(); err != nil {
-		return errors.Wrap(err, "commit_transaction")
-	}
-	return nil
-}
-
-func (s *SqlPostStore) GetPostReminders(now int64) (_ []*model.PostReminder, err error) {
-	reminders := []*model.PostReminder{}
-
-	transaction, err := s.GetMaster().Beginx()
-	if err != nil {
-		return nil, errors.Wrap(err, "begin_transaction")
-	}
-	defer finalizeTransactionX(transaction, &err)
-
-	err = transaction.Select(&reminders, `SELECT PostId, UserId
-		FROM PostReminders
-		WHERE TargetTime < ?`, now)
-	if err != nil && err != sql.ErrNoRows {
-		return nil, errors.Wrap(err, "failed to get post reminders")
-	}
-
-	if err == sql.ErrNoRows {
-		// No need to execute delete statement if there's nothing to delete.
-		return reminders, nil
-	}
-
-	// Postgres supports RETURNING * in a DELETE statement, but MySQL doesn't.
-	// So we are stuck with 2 queries. Not taking separate paths for Postgres
-	// and MySQL for simplicity.
-	_, err = transaction.Exec(`DELETE from PostReminders WHERE TargetTime < ?`, now)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to delete post reminders")
-	}
-
-	if err = transaction.Commit(); err != nil {
-		return nil, errors.Wrap(err, "commit_transaction")
-	}
-
-	return reminders, nil
-}
-
 func (s *SqlPostStore) GetPostReminderMetadata(postID string) (*store.PostReminderMetadata, error) {
 	meta := &store.PostReminderMetadata{}
 	err := s.GetReplica().Get(meta, `SELECT c.id as ChannelID,
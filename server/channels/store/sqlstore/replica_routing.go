@@ -0,0 +1,327 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// errReplicationStopped is returned when a replica is not replaying the master anymore.
+var errReplicationStopped = errors.New("replication is not running")
+
+// replayRecheckInterval is how long the replicas are known to be behind a position of the
+// master before checking again.
+const replayRecheckInterval = 100 * time.Millisecond
+
+// userWrite is the last write of a user to the master.
+type userWrite struct {
+	at time.Time
+	// position is the position of the master after the write: its WAL LSN for Postgres, or
+	// its executed GTID set for MySQL. It is read when the user reads again, and stays empty if
+	// it could not be read, in which case the user reads from the master for the whole window.
+	position     string
+	positionRead bool
+}
+
+// masterPositionRead is a position of the master, and when it started being read.
+type masterPositionRead struct {
+	at       time.Time
+	position string
+}
+
+// replayCheck is whether the replicas had replayed a position of the master when last checked.
+type replayCheck struct {
+	at       time.Time
+	caughtUp bool
+}
+
+func (ss *SqlStore) readYourWritesWindow() time.Duration {
+	if ss.settings.ReadYourWritesWindowSeconds == nil {
+		return 0
+	}
+	return time.Duration(*ss.settings.ReadYourWritesWindowSeconds) * time.Second
+}
+
+func (ss *SqlStore) replicaMaxLag() float64 {
+	if ss.settings.ReplicaMaxLagSeconds == nil {
+		return 0
+	}
+	return float64(*ss.settings.ReplicaMaxLagSeconds)
+}
+
+// usesReplicas returns whether reads can be served by the replicas at all.
+func (ss *SqlStore) usesReplicas() bool {
+	return len(ss.settings.DataSourceReplicas) > 0 && !ss.lockedToMaster && ss.hasLicense()
+}
+
+// replicaInRotation returns whether the i-th replica can serve reads.
+func (ss *SqlStore) replicaInRotation(i int) bool {
+	if !ss.ReplicaXs[i].Load().Online() {
+		return false
+	}
+	return i >= len(ss.replicaLagging) || !ss.replicaLagging[i].Load()
+}
+
+// RecordUserWrite records that a user has just written to the master, so that the reads of the
+// user are served by the master until the replicas have caught up with the write, or until the
+// read-your-writes window has passed.
+//
+// The writes are only recorded by the node that served them, so in a cluster the reads of a
+// user only follow their writes on the same node, for instance with sticky sessions.
+func (ss *SqlStore) RecordUserWrite(userID string) {
+	if userID == "" || ss.readYourWritesWindow() == 0 || !ss.usesReplicas() {
+		return
+	}
+
+	ss.userWritesMut.Lock()
+	defer ss.userWritesMut.Unlock()
+	if ss.userWrites == nil {
+		ss.userWrites = make(map[string]userWrite)
+	}
+	ss.userWrites[userID] = userWrite{at: time.Now()}
+}
+
+// ShouldReadFromMaster returns whether the replicas may not have caught up yet with the last
+// write of a user.
+func (ss *SqlStore) ShouldReadFromMaster(userID string) bool {
+	if userID == "" || !ss.usesReplicas() {
+		return false
+	}
+
+	ss.userWritesMut.Lock()
+	write, ok := ss.userWrites[userID]
+	ss.userWritesMut.Unlock()
+	if !ok {
+		return false
+	}
+
+	if time.Since(write.at) <= ss.readYourWritesWindow() && !write.positionRead {
+		position, err := ss.masterPositionAfter(write.at)
+		if err != nil {
+			ss.Logger().Debug("Failed to read the position of the master", mlog.Err(err))
+		}
+		write.position = position
+		write.positionRead = true
+
+		ss.userWritesMut.Lock()
+		// Another write may have happened in the meantime.
+		if ss.userWrites[userID].at.Equal(write.at) {
+			ss.userWrites[userID] = write
+		}
+		ss.userWritesMut.Unlock()
+	}
+
+	if time.Since(write.at) > ss.readYourWritesWindow() || (write.position != "" && ss.replicasCaughtUp(write.position)) {
+		ss.userWritesMut.Lock()
+		if ss.userWrites[userID].at.Equal(write.at) {
+			delete(ss.userWrites, userID)
+		}
+		ss.userWritesMut.Unlock()
+		return false
+	}
+
+	if ss.metrics != nil {
+		ss.metrics.IncrementReadYourWritesMasterCounter()
+	}
+	return true
+}
+
+// pruneUserWrites forgets the writes, and the replay checks, that are older than the
+// read-your-writes window.
+func (ss *SqlStore) pruneUserWrites() {
+	window := ss.readYourWritesWindow()
+
+	ss.userWritesMut.Lock()
+	for userID, write := range ss.userWrites {
+		if time.Since(write.at) > window {
+			delete(ss.userWrites, userID)
+		}
+	}
+	ss.userWritesMut.Unlock()
+
+	// A position is only read after the writes it is used for, so its checks are older than
+	// the window once these writes are.
+	ss.replayChecksMut.Lock()
+	for position, check := range ss.replayChecks {
+		if time.Since(check.at) > window {
+			delete(ss.replayChecks, position)
+		}
+	}
+	ss.replayChecksMut.Unlock()
+}
+
+// masterPositionAfter returns a position of the master read after the given time. Positions
+// only move forward, so a position read after a write is past it, and a single read serves
+// every write recorded before it started.
+func (ss *SqlStore) masterPositionAfter(t time.Time) (string, error) {
+	ss.lastMasterPositionMut.Lock()
+	defer ss.lastMasterPositionMut.Unlock()
+
+	if ss.lastMasterPosition.at.After(t) {
+		return ss.lastMasterPosition.position, nil
+	}
+
+	at := time.Now()
+	position, err := ss.masterPosition()
+	if err != nil {
+		return "", err
+	}
+	ss.lastMasterPosition = masterPositionRead{at: at, position: position}
+	return position, nil
+}
+
+// masterPosition returns the current position of the master.
+func (ss *SqlStore) masterPosition() (string, error) {
+	query := `SELECT pg_current_wal_lsn()::text`
+	if ss.DriverName() == model.DatabaseDriverMysql {
+		query = `SELECT @@GLOBAL.gtid_executed`
+	}
+
+	var position string
+	if err := ss.GetMaster().Get(&position, query); err != nil {
+		return "", errors.Wrap(err, "failed to get the position of the master")
+	}
+	return position, nil
+}
+
+// replicasCaughtUp returns whether every replica in rotation has replayed the master up to the
+// given position. The replicas are checked again at most every replayRecheckInterval while they
+// are behind, and never once they have caught up.
+func (ss *SqlStore) replicasCaughtUp(position string) bool {
+	ss.replayChecksMut.Lock()
+	check, ok := ss.replayChecks[position]
+	ss.replayChecksMut.Unlock()
+	if ok && (check.caughtUp || time.Since(check.at) < replayRecheckInterval) {
+		return check.caughtUp
+	}
+
+	check = replayCheck{at: time.Now(), caughtUp: ss.replicasReplayed(position)}
+
+	ss.replayChecksMut.Lock()
+	defer ss.replayChecksMut.Unlock()
+	if ss.replayChecks == nil {
+		ss.replayChecks = make(map[string]replayCheck)
+	}
+	ss.replayChecks[position] = check
+	return check.caughtUp
+}
+
+// replicasReplayed queries whether every replica in rotation has replayed the master up to the
+// given position.
+func (ss *SqlStore) replicasReplayed(position string) bool {
+	query := `SELECT pg_last_wal_replay_lsn() >= ?::pg_lsn`
+	if ss.DriverName() == model.DatabaseDriverMysql {
+		query = `SELECT GTID_SUBSET(?, @@GLOBAL.gtid_executed)`
+	}
+
+	for i, replica := range ss.ReplicaXs {
+		if !ss.replicaInRotation(i) {
+			continue
+		}
+
+		var caughtUp sql.NullBool
+		if err := replica.Load().Get(&caughtUp, query, position); err != nil {
+			ss.Logger().Debug("Failed to compare the position of a replica", mlog.String("db", "replica-"+strconv.Itoa(i)), mlog.Err(err))
+			return false
+		}
+		if !caughtUp.Valid || !caughtUp.Bool {
+			return false
+		}
+	}
+	return true
+}
+
+// checkReplicasLag takes the replicas lagging behind the master by more than the configured
+// maximum out of rotation, and puts them back once they have caught up.
+func (ss *SqlStore) checkReplicasLag() {
+	maxLag := ss.replicaMaxLag()
+	if maxLag == 0 {
+		return
+	}
+
+	for i, replica := range ss.ReplicaXs {
+		if !replica.Load().Online() || i >= len(ss.replicaLagging) {
+			continue
+		}
+
+		name := "replica-" + strconv.Itoa(i)
+		lag, err := ss.replicaLag(replica.Load())
+		if err != nil && !errors.Is(err, errReplicationStopped) {
+			ss.Logger().Warn("Failed to get the lag of a replica", mlog.String("db", name), mlog.Err(err))
+			continue
+		}
+
+		// A replica that stopped replaying falls further behind with every write.
+		lagging := err != nil || lag > maxLag
+		if ss.replicaLagging[i].Swap(lagging) != lagging {
+			if lagging {
+				ss.Logger().Warn("Taking replica out of rotation because of its lag", mlog.String("db", name), mlog.Float("lag_seconds", lag))
+			} else {
+				ss.Logger().Info("Putting replica back in rotation", mlog.String("db", name), mlog.Float("lag_seconds", lag))
+			}
+		}
+
+		if ss.metrics != nil {
+			ss.metrics.SetReplicaLagSeconds(name, lag)
+			ss.metrics.SetReplicaInRotation(name, !lagging)
+		}
+	}
+}
+
+// replicaLag returns how many seconds a replica is behind the master.
+func (ss *SqlStore) replicaLag(replica *sqlxDBWrapper) (float64, error) {
+	if ss.DriverName() == model.DatabaseDriverMysql {
+		return ss.mysqlReplicaLag(replica)
+	}
+
+	// The replay timestamp stays the same while the master is idle, so a replica that has
+	// replayed everything it received is not lagging.
+	var lag float64
+	err := replica.Get(&lag, `SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the replay lag")
+	}
+	return lag, nil
+}
+
+func (ss *SqlStore) mysqlReplicaLag(replica *sqlxDBWrapper) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*ss.settings.QueryTimeout)*time.Second)
+	defer cancel()
+
+	rows, err := replica.DB.QueryxContext(ctx, `SHOW REPLICA STATUS`)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the replica status")
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return 0, errors.New("the database is not a replica")
+	}
+	status := map[string]any{}
+	if err := rows.MapScan(status); err != nil {
+		return 0, errors.Wrap(err, "failed to scan the replica status")
+	}
+
+	// The lag is NULL when the replication is stopped.
+	value, ok := status["Seconds_Behind_Source"].([]byte)
+	if !ok {
+		return 0, errReplicationStopped
+	}
+	lag, err := strconv.ParseFloat(string(value), 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse the replica lag")
+	}
+	return lag, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func makeReplicaRoutingStore(t *testing.T, replicas int) *SqlStore {
+	settings := &model.SqlSettings{}
+	settings.SetDefaults(false)
+	settings.ReadYourWritesWindowSeconds = model.NewPointer(5)
+	for range replicas {
+		settings.DataSourceReplicas = append(settings.DataSourceReplicas, "replica")
+	}
+
+	store := &SqlStore{
+		settings:       settings,
+		logger:         mlog.CreateConsoleTestLogger(t),
+		masterX:        &sqlxDBWrapper{isOnline: &atomic.Bool{}},
+		replicaLagging: make([]atomic.Bool, replicas),
+	}
+	store.masterX.isOnline.Store(true)
+	for range replicas {
+		replica := &atomic.Pointer[sqlxDBWrapper]{}
+		replica.Store(&sqlxDBWrapper{isOnline: &atomic.Bool{}})
+		replica.Load().isOnline.Store(true)
+		store.ReplicaXs = append(store.ReplicaXs, replica)
+	}
+	store.UpdateLicense(&model.License{})
+
+	return store
+}
+
+func TestGetReplicaSkipsLaggingReplicas(t *testing.T) {
+	store := makeReplicaRoutingStore(t, 2)
+
+	store.replicaLagging[0].Store(true)
+	for range 4 {
+		assert.Same(t, store.ReplicaXs[1].Load(), store.GetReplica())
+	}
+
+	store.replicaLagging[1].Store(true)
+	assert.Same(t, store.GetMaster(), store.GetReplica())
+
+	store.replicaLagging[0].Store(false)
+	assert.Same(t, store.ReplicaXs[0].Load(), store.GetReplica())
+}
+
+func TestShouldReadFromMaster(t *testing.T) {
+	t.Run("no write", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		assert.False(t, store.ShouldReadFromMaster(model.NewId()))
+		assert.False(t, store.ShouldReadFromMaster(""))
+	})
+
+	t.Run("write within the window", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		userID := model.NewId()
+		store.userWrites = map[string]userWrite{userID: {at: time.Now(), positionRead: true}}
+
+		assert.True(t, store.ShouldReadFromMaster(userID))
+		assert.True(t, store.ShouldReadFromMaster(userID))
+		assert.False(t, store.ShouldReadFromMaster(model.NewId()))
+	})
+
+	t.Run("write older than the window", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		userID := model.NewId()
+		store.userWrites = map[string]userWrite{userID: {at: time.Now().Add(-time.Minute)}}
+
+		assert.False(t, store.ShouldReadFromMaster(userID))
+		assert.Empty(t, store.userWrites)
+	})
+
+	t.Run("replicas caught up", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		userID := model.NewId()
+		store.userWrites = map[string]userWrite{userID: {at: time.Now(), position: "0/1", positionRead: true}}
+		store.replayChecks = map[string]replayCheck{"0/1": {at: time.Now().Add(-time.Second), caughtUp: true}}
+
+		assert.False(t, store.ShouldReadFromMaster(userID))
+		assert.Empty(t, store.userWrites)
+	})
+
+	t.Run("replicas behind", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		userID := model.NewId()
+		store.userWrites = map[string]userWrite{userID: {at: time.Now(), position: "0/1", positionRead: true}}
+		store.replayChecks = map[string]replayCheck{"0/1": {at: time.Now().Add(time.Minute), caughtUp: false}}
+
+		assert.True(t, store.ShouldReadFromMaster(userID))
+	})
+
+	t.Run("reuses a position read after the write", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		userID := model.NewId()
+		store.RecordUserWrite(userID)
+		store.lastMasterPosition = masterPositionRead{at: time.Now().Add(time.Millisecond), position: "0/1"}
+		store.replayChecks = map[string]replayCheck{"0/1": {at: time.Now().Add(time.Minute), caughtUp: false}}
+
+		assert.True(t, store.ShouldReadFromMaster(userID))
+		assert.Equal(t, userWrite{at: store.userWrites[userID].at, position: "0/1", positionRead: true}, store.userWrites[userID])
+	})
+
+	t.Run("without replicas", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 0)
+		userID := model.NewId()
+		store.userWrites = map[string]userWrite{userID: {at: time.Now()}}
+
+		assert.False(t, store.ShouldReadFromMaster(userID))
+	})
+
+	t.Run("window disabled", func(t *testing.T) {
+		store := makeReplicaRoutingStore(t, 1)
+		store.settings.ReadYourWritesWindowSeconds = model.NewPointer(0)
+		userID := model.NewId()
+
+		store.RecordUserWrite(userID)
+		assert.False(t, store.ShouldReadFromMaster(userID))
+	})
+}
+
+func TestPruneUserWrites(t *testing.T) {
+	store := makeReplicaRoutingStore(t, 1)
+	recent, old := model.NewId(), model.NewId()
+	store.userWrites = map[string]userWrite{
+		recent: {at: time.Now()},
+		old:    {at: time.Now().Add(-time.Minute)},
+	}
+
+	store.replayChecks = map[string]replayCheck{
+		"0/2": {at: time.Now(), caughtUp: true},
+		"0/1": {at: time.Now().Add(-time.Minute), caughtUp: true},
+	}
+
+	store.pruneUserWrites()
+	require.Len(t, store.userWrites, 1)
+	assert.Contains(t, store.userWrites, recent)
+	require.Len(t, store.replayChecks, 1)
+	assert.Contains(t, store.replayChecks, "0/2")
+}
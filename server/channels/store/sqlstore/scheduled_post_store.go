@@ -45,6 +45,9 @@ func (s *SqlScheduledPostStore) columns(prefix string) []string {
 		prefix + "ScheduledAt",
 		prefix + "ProcessedAt",
 		prefix + "ErrorCode",
+		prefix + "RecurrenceRule",
+		prefix + "Timezone",
+		prefix + "PausedAt",
 	}
 }
 
@@ -63,6 +66,9 @@ func (s *SqlScheduledPostStore) scheduledPostToSlice(scheduledPost *model.Schedu
 		scheduledPost.ScheduledAt,
 		scheduledPost.ProcessedAt,
 		scheduledPost.ErrorCode,
+		scheduledPost.RecurrenceRule,
+		scheduledPost.Timezone,
+		scheduledPost.PausedAt,
 	}
 }
 
@@ -136,26 +142,30 @@ func (s *SqlScheduledPostStore) GetMaxMessageSize() int {
 }
 
 func (s *SqlScheduledPostStore) GetPendingScheduledPosts(beforeTime, afterTime int64, lastScheduledPostId string, perPage uint64) ([]*model.ScheduledPost, error) {
+	// Recurring posts aren't bound by afterTime so that the job can re-arm
+	// series whose occurrences were all missed, e.g. while the server was down.
+	inWindow := sq.And{
+		sq.LtOrEq{"ScheduledAt": beforeTime},
+		sq.Or{
+			sq.GtOrEq{"ScheduledAt": afterTime},
+			sq.NotEq{"RecurrenceRule": ""},
+		},
+	}
+
 	query := s.getQueryBuilder().
 		Select(s.columns("")...).
 		From("ScheduledPosts").
-		Where(sq.Eq{"ErrorCode": ""}).
+		Where(sq.Eq{"ErrorCode": "", "PausedAt": 0}).
 		OrderBy("ScheduledAt DESC", "Id").
 		Limit(perPage)
 
 	if lastScheduledPostId == "" {
-		query = query.Where(sq.And{
-			sq.LtOrEq{"ScheduledAt": beforeTime},
-			sq.GtOrEq{"ScheduledAt": afterTime},
-		})
+		query = query.Where(inWindow)
 	}
 	if lastScheduledPostId != "" {
 		query = query.
 			Where(sq.Or{
-				sq.And{
-					sq.LtOrEq{"ScheduledAt": beforeTime},
-					sq.GtOrEq{"ScheduledAt": afterTime},
-				},
+				inWindow,
 				sq.And{
 					sq.Eq{"ScheduledAt": beforeTime},
 					sq.Gt{"Id": lastScheduledPostId},
@@ -233,14 +243,17 @@ func (s *SqlScheduledPostStore) UpdatedScheduledPost(scheduledPost *model.Schedu
 func (s *SqlScheduledPostStore) toUpdateMap(scheduledPost *model.ScheduledPost) map[string]any {
 	now := model.GetMillis()
 	return map[string]any{
-		"UpdateAt":    now,
-		"Message":     scheduledPost.Message,
-		"Props":       model.StringInterfaceToJSON(scheduledPost.GetProps()),
-		"FileIds":     model.ArrayToJSON(scheduledPost.FileIds),
-		"Priority":    model.StringInterfaceToJSON(scheduledPost.Priority),
-		"ScheduledAt": scheduledPost.ScheduledAt,
-		"ProcessedAt": now,
-		"ErrorCode":   scheduledPost.ErrorCode,
+		"UpdateAt":       now,
+		"Message":        scheduledPost.Message,
+		"Props":          model.StringInterfaceToJSON(scheduledPost.GetProps()),
+		"FileIds":        model.ArrayToJSON(scheduledPost.FileIds),
+		"Priority":       model.StringInterfaceToJSON(scheduledPost.Priority),
+		"ScheduledAt":    scheduledPost.ScheduledAt,
+		"ProcessedAt":    now,
+		"ErrorCode":      scheduledPost.ErrorCode,
+		"RecurrenceRule": scheduledPost.RecurrenceRule,
+		"Timezone":       scheduledPost.Timezone,
+		"PausedAt":       scheduledPost.PausedAt,
 	}
 }
 
@@ -269,7 +282,7 @@ func (s *SqlScheduledPostStore) UpdateOldScheduledPosts(beforeTime int64) error
 		Set("ErrorCode", model.ScheduledPostErrorUnableToSend).
 		Set("ProcessedAt", model.GetMillis()).
 		Where(sq.And{
-			sq.Eq{"ErrorCode": ""},
+			sq.Eq{"ErrorCode": "", "RecurrenceRule": "", "PausedAt": 0},
 			sq.Lt{"ScheduledAt": beforeTime},
 		})
 
@@ -75,6 +75,7 @@ type SqlStoreStores struct {
 	user                       store.UserStore
 	bot                        store.BotStore
 	audit                      store.AuditStore
+	auditLog                   store.AuditLogStore
 	cluster                    store.ClusterDiscoveryStore
 	remoteCluster              store.RemoteClusterStore
 	compliance                 store.ComplianceStore
@@ -113,6 +114,11 @@ type SqlStoreStores struct {
 	desktopTokens              store.DesktopTokensStore
 	channelBookmarks           store.ChannelBookmarkStore
 	scheduledPost              store.ScheduledPostStore
+	outgoingWebhookDelivery    store.OutgoingWebhookDeliveryStore
+	outOfOffice                store.OutOfOfficeStore
+	poll                       store.PollStore
+	webAuthnCredential         store.WebAuthnCredentialStore
+	postReminder               store.PostReminderStore
 }
 
 type SqlStore struct {
@@ -127,6 +133,23 @@ type SqlStore struct {
 
 	searchReplicaXs []*atomic.Pointer[sqlxDBWrapper]
 
+	// replicaLagging holds whether each replica was taken out of rotation because of its lag.
+	replicaLagging []atomic.Bool
+
+	// userWrites holds the last write of the users who wrote within the read-your-writes window.
+	// It is only kept in memory, so each node only knows about the writes it served.
+	userWrites    map[string]userWrite
+	userWritesMut sync.Mutex
+
+	// lastMasterPosition is the last position read from the master, shared by the writes
+	// recorded before it was read.
+	lastMasterPosition    masterPositionRead
+	lastMasterPositionMut sync.Mutex
+
+	// replayChecks caches whether the replicas have replayed the master up to a position.
+	replayChecks    map[string]replayCheck
+	replayChecksMut sync.Mutex
+
 	replicaLagHandles []*dbsql.DB
 	stores            SqlStoreStores
 	settings          *model.SqlSettings
@@ -200,6 +223,7 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.user = newSqlUserStore(store, metrics)
 	store.stores.bot = newSqlBotStore(store, metrics)
 	store.stores.audit = newSqlAuditStore(store)
+	store.stores.auditLog = newSqlAuditLogStore(store)
 	store.stores.cluster = newSqlClusterDiscoveryStore(store)
 	store.stores.remoteCluster = newSqlRemoteClusterStore(store)
 	store.stores.compliance = newSqlComplianceStore(store)
@@ -239,6 +263,11 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.desktopTokens = newSqlDesktopTokensStore(store, metrics)
 	store.stores.channelBookmarks = newSqlChannelBookmarkStore(store)
 	store.stores.scheduledPost = newScheduledPostStore(store)
+	store.stores.outgoingWebhookDelivery = newSqlOutgoingWebhookDeliveryStore(store)
+	store.stores.outOfOffice = newSqlOutOfOfficeStore(store)
+	store.stores.poll = newSqlPollStore(store)
+	store.stores.webAuthnCredential = newSqlWebAuthnCredentialStore(store)
+	store.stores.postReminder = newSqlPostReminderStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -288,6 +317,7 @@ func (ss *SqlStore) initConnection() error {
 
 	if len(ss.settings.DataSourceReplicas) > 0 {
 		ss.ReplicaXs = make([]*atomic.Pointer[sqlxDBWrapper], len(ss.settings.DataSourceReplicas))
+		ss.replicaLagging = make([]atomic.Bool, len(ss.settings.DataSourceReplicas))
 		for i, replica := range ss.settings.DataSourceReplicas {
 			ss.ReplicaXs[i] = &atomic.Pointer[sqlxDBWrapper]{}
 			handle, err = sqlUtils.SetupConnection(ss.Logger(), fmt.Sprintf("replica-%v", i), replica, ss.settings, DBReplicaPingAttempts)
@@ -446,18 +476,18 @@ func (ss *SqlStore) GetSearchReplicaX() *sqlxDBWrapper {
 }
 
 func (ss *SqlStore) GetReplica() *sqlxDBWrapper {
-	if len(ss.settings.DataSourceReplicas) == 0 || ss.lockedToMaster || !ss.hasLicense() {
+	if !ss.usesReplicas() {
 		return ss.GetMaster()
 	}
 
 	for i := 0; i < len(ss.ReplicaXs); i++ {
 		rrNum := atomic.AddInt64(&ss.rrCounter, 1) % int64(len(ss.ReplicaXs))
-		if ss.ReplicaXs[rrNum].Load().Online() {
+		if ss.replicaInRotation(int(rrNum)) {
 			return ss.ReplicaXs[rrNum].Load()
 		}
 	}
 
-	// If all replicas are down, then go with master.
+	// If all replicas are down or lagging, then go with master.
 	return ss.GetMaster()
 }
 
@@ -494,6 +524,9 @@ func (ss *SqlStore) monitorReplicas() {
 			for i, replica := range ss.searchReplicaXs {
 				setupReplica(replica, ss.settings.DataSourceSearchReplicas[i], "search-replica-"+strconv.Itoa(i))
 			}
+
+			ss.checkReplicasLag()
+			ss.pruneUserWrites()
 		}
 	}
 }
@@ -894,6 +927,10 @@ func (ss *SqlStore) Audit() store.AuditStore {
 	return ss.stores.audit
 }
 
+func (ss *SqlStore) AuditLog() store.AuditLogStore {
+	return ss.stores.auditLog
+}
+
 func (ss *SqlStore) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return ss.stores.cluster
 }
@@ -1345,3 +1382,23 @@ func (ss *SqlStore) determineMaxColumnSize(tableName, columnName string) (int, e
 func (ss *SqlStore) ScheduledPost() store.ScheduledPostStore {
 	return ss.stores.scheduledPost
 }
+
+func (ss *SqlStore) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	return ss.stores.outgoingWebhookDelivery
+}
+
+func (ss *SqlStore) OutOfOffice() store.OutOfOfficeStore {
+	return ss.stores.outOfOffice
+}
+
+func (ss *SqlStore) Poll() store.PollStore {
+	return ss.stores.poll
+}
+
+func (ss *SqlStore) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return ss.stores.webAuthnCredential
+}
+
+func (ss *SqlStore) PostReminder() store.PostReminderStore {
+	return ss.stores.postReminder
+}
@@ -451,6 +451,59 @@ func (us SqlUserStore) GetMfaUsedTimestamps(userId string) ([]int, error) {
 	return ts, nil
 }
 
+func (us SqlUserStore) StoreMfaRecoveryCodes(userId string, codeHashes []string) (err error) {
+	transaction, err := us.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.Exec("DELETE FROM MfaRecoveryCodes WHERE UserId = ?", userId); err != nil {
+		return errors.Wrapf(err, "failed to delete MFA recovery codes for user with ID %s", userId)
+	}
+
+	if len(codeHashes) > 0 {
+		createAt := model.GetMillis()
+		query := us.getQueryBuilder().Insert("MfaRecoveryCodes").Columns("UserId", "CodeHash", "CreateAt")
+		for _, codeHash := range codeHashes {
+			query = query.Values(userId, codeHash, createAt)
+		}
+
+		if _, err = transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to save MFA recovery codes for user with ID %s", userId)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (us SqlUserStore) UseMfaRecoveryCode(userId, codeHash string) (bool, error) {
+	result, err := us.GetMaster().Exec("DELETE FROM MfaRecoveryCodes WHERE UserId = ? AND CodeHash = ?", userId, codeHash)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to delete MFA recovery code for user with ID %s", userId)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (us SqlUserStore) CountMfaRecoveryCodes(userId string) (int64, error) {
+	var count int64
+	if err := us.GetMaster().Get(&count, "SELECT COUNT(*) FROM MfaRecoveryCodes WHERE UserId = ?", userId); err != nil {
+		return 0, errors.Wrapf(err, "failed to count MFA recovery codes for user with ID %s", userId)
+	}
+
+	return count, nil
+}
+
 // GetMany returns a list of users for the provided list of ids
 func (us SqlUserStore) GetMany(ctx context.Context, ids []string) ([]*model.User, error) {
 	query := us.usersQuery.Where(sq.Eq{"Id": ids})
@@ -526,6 +579,44 @@ func (us SqlUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, er
 	return users, nil
 }
 
+// GetAllForFilteredExportAfter returns the users in the scope of the filter, that is the
+// members of its teams and channels. Preferences don't record when they change, so an
+// incremental export returns every user in scope too, along with the users that left
+// its channels in the time window.
+func (us SqlUserStore) GetAllForFilteredExportAfter(limit int, afterId string, filter model.BulkExportFilter) ([]*model.User, error) {
+	query := us.usersQuery.
+		Where("u.Id > ?", afterId).
+		OrderBy("u.Id ASC").
+		Limit(uint64(limit))
+
+	if filter.HasScope() {
+		channelsInScope := sq.Or{sq.Eq{"c.TeamId": filter.TeamIds}, sq.Eq{"c.Id": filter.ChannelIds}}
+		inScope := sq.Or{
+			sq.Expr("u.Id IN (?)", sq.Select("tm.UserId").
+				From("TeamMembers tm").
+				Where(sq.Eq{"tm.TeamId": filter.TeamIds})),
+			sq.Expr("u.Id IN (?)", sq.Select("cm.UserId").
+				From("ChannelMembers cm").
+				Join("Channels c ON c.Id = cm.ChannelId").
+				Where(channelsInScope)),
+		}
+		if filter.IsIncremental() {
+			inScope = append(inScope, sq.Expr("u.Id IN (?)", sq.Select("cmh.UserId").
+				From("ChannelMemberHistory cmh").
+				Join("Channels c ON c.Id = cmh.ChannelId").
+				Where(sq.And{channelsInScope, exportWindowCond("cmh.LeaveTime", filter)})))
+		}
+		query = query.Where(inScope)
+	}
+
+	users := []*model.User{}
+	if err := us.GetReplica().SelectBuilder(&users, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find Users")
+	}
+
+	return users, nil
+}
+
 func (us SqlUserStore) GetEtagForAllProfiles() string {
 	var updateAt int64
 	err := us.GetReplica().Get(&updateAt, "SELECT UpdateAt FROM Users ORDER BY UpdateAt DESC LIMIT 1")
@@ -980,6 +1071,30 @@ func (us SqlUserStore) GetProfilesByUsernames(usernames []string, viewRestrictio
 	return users, nil
 }
 
+func (us SqlUserStore) GetAutoResponderMentionUsernames() ([]string, error) {
+	activeProp := "NotifyProps->>'" + model.AutoResponderActiveNotifyProp + "'"
+	mentionsProp := "NotifyProps->>'" + model.AutoResponderMentionsNotifyProp + "'"
+	if us.DriverName() == model.DatabaseDriverMysql {
+		activeProp = "NotifyProps->>'$." + model.AutoResponderActiveNotifyProp + "'"
+		mentionsProp = "NotifyProps->>'$." + model.AutoResponderMentionsNotifyProp + "'"
+	}
+
+	query := us.getQueryBuilder().
+		Select("Username").
+		From("Users").
+		Where(sq.Eq{"DeleteAt": 0}).
+		Where(activeProp + " = 'true'").
+		Where(mentionsProp + " = 'true'").
+		OrderBy("Username ASC")
+
+	usernames := []string{}
+	if err := us.GetReplica().SelectBuilder(&usernames, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find Users with an auto-responder answering mentions")
+	}
+
+	return usernames, nil
+}
+
 type UserWithLastActivityAt struct {
 	model.User
 	LastActivityAt int64
@@ -1320,6 +1435,9 @@ func (us SqlUserStore) PermanentDelete(rctx request.CTX, userId string) error {
 	if _, err := us.GetMaster().Exec("DELETE FROM Users WHERE Id = ?", userId); err != nil {
 		return errors.Wrapf(err, "failed to delete User with userId=%s", userId)
 	}
+	if _, err := us.GetMaster().Exec("DELETE FROM MfaRecoveryCodes WHERE UserId = ?", userId); err != nil {
+		return errors.Wrapf(err, "failed to delete MFA recovery codes for user with userId=%s", userId)
+	}
 	return nil
 }
 
@@ -2416,3 +2534,37 @@ func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.
 
 	return userResults, nil
 }
+
+func (us SqlUserStore) GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, error) {
+	query := us.getQueryBuilder().
+		Select(
+			"u.Id AS UserId",
+			"u.Username",
+			"u.Email",
+			"u.CreateAt",
+			"u.DeleteAt",
+			"COALESCE(s.LastActivityAt, 0) AS LastActivityAt",
+			"(SELECT COUNT(*) FROM TeamMembers tm WHERE tm.UserId = u.Id AND tm.DeleteAt = 0) AS TeamCount",
+			"(SELECT COUNT(*) FROM ChannelMembers cm WHERE cm.UserId = u.Id) AS ChannelCount",
+		).
+		From("Users u").
+		LeftJoin("Status s ON s.UserId = u.Id").
+		Where(sq.Like{"u.Roles": "%" + model.SystemGuestRoleId + "%"})
+
+	if filter.Team != "" {
+		query = query.Where(sq.Expr("u.Id IN (SELECT UserId FROM TeamMembers WHERE TeamId = ? AND DeleteAt = 0)", filter.Team))
+	}
+
+	if filter.FromColumnValue != "" {
+		query = query.Where(sq.Gt{"u.Username": filter.FromColumnValue})
+	}
+
+	query = query.OrderBy("u.Username").Limit(uint64(filter.PageSize))
+
+	reports := []*model.GuestAccessReport{}
+	if err := us.GetReplica().SelectBuilder(&reports, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get guest access report")
+	}
+
+	return reports, nil
+}
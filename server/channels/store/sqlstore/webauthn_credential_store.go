@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlWebAuthnCredentialStore struct {
+	*SqlStore
+}
+
+func newSqlWebAuthnCredentialStore(sqlStore *SqlStore) store.WebAuthnCredentialStore {
+	return &SqlWebAuthnCredentialStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlWebAuthnCredentialStore) columns() []string {
+	return []string{
+		"Id",
+		"UserId",
+		"CredentialId",
+		"PublicKey",
+		"AAGUID",
+		"SignCount",
+		"Name",
+		"CreateAt",
+		"LastUsedAt",
+	}
+}
+
+func (s *SqlWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	credential.PreSave()
+	if err := credential.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("WebAuthnCredentials").
+		Columns(s.columns()...).
+		Values(credential.Id, credential.UserId, credential.CredentialId, credential.PublicKey, credential.AAGUID,
+			credential.SignCount, credential.Name, credential.CreateAt, credential.LastUsedAt)
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		if IsUniqueConstraintError(err, []string{"CredentialId", "webauthncredentials_credentialid_key"}) {
+			return nil, store.NewErrConflict("WebAuthnCredential", err, "credential_id="+credential.CredentialId)
+		}
+		return nil, errors.Wrapf(err, "failed to save WebAuthnCredential with id=%s", credential.Id)
+	}
+
+	return credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("WebAuthnCredentials").
+		Where(sq.Eq{"Id": id})
+
+	var credential model.WebAuthnCredential
+	if err := s.GetReplica().GetBuilder(&credential, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("WebAuthnCredential", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredential with id=%s", id)
+	}
+
+	return &credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("WebAuthnCredentials").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt ASC", "Id ASC")
+
+	credentials := []*model.WebAuthnCredential{}
+	if err := s.GetReplica().SelectBuilder(&credentials, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredentials with userId=%s", userID)
+	}
+
+	return credentials, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) UpdateSignCount(id string, signCount int64, lastUsedAt int64) error {
+	result, err := s.GetMaster().ExecBuilder(s.getQueryBuilder().
+		Update("WebAuthnCredentials").
+		Set("SignCount", signCount).
+		Set("LastUsedAt", lastUsedAt).
+		Where(sq.Eq{"Id": id}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to update WebAuthnCredential with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("WebAuthnCredential", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Delete(id string) error {
+	result, err := s.GetMaster().ExecBuilder(s.getQueryBuilder().
+		Delete("WebAuthnCredentials").
+		Where(sq.Eq{"Id": id}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredential with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("WebAuthnCredential", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
+	if _, err := s.GetMaster().ExecBuilder(s.getQueryBuilder().
+		Delete("WebAuthnCredentials").
+		Where(sq.Eq{"UserId": userID})); err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredentials with userId=%s", userID)
+	}
+
+	return nil
+}
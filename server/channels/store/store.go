@@ -31,6 +31,7 @@ type Store interface {
 	User() UserStore
 	Bot() BotStore
 	Audit() AuditStore
+	AuditLog() AuditLogStore
 	ClusterDiscovery() ClusterDiscoveryStore
 	RemoteCluster() RemoteClusterStore
 	Compliance() ComplianceStore
@@ -81,6 +82,13 @@ type Store interface {
 	TotalSearchDbConnections() int
 	ReplicaLagTime() error
 	ReplicaLagAbs() error
+	// RecordUserWrite records that a user has just written to the master, so that the reads
+	// of the user are served by the master until the replicas have caught up with the write.
+	// The writes are only known to the node that served them.
+	RecordUserWrite(userID string)
+	// ShouldReadFromMaster returns whether the replicas may not have caught up yet with the
+	// last write of a user.
+	ShouldReadFromMaster(userID string) bool
 	CheckIntegrity() <-chan model.IntegrityCheckResult
 	SetContext(context context.Context)
 	Context() context.Context
@@ -92,6 +100,11 @@ type Store interface {
 	DesktopTokens() DesktopTokensStore
 	ChannelBookmark() ChannelBookmarkStore
 	ScheduledPost() ScheduledPostStore
+	OutgoingWebhookDelivery() OutgoingWebhookDeliveryStore
+	OutOfOffice() OutOfOfficeStore
+	Poll() PollStore
+	WebAuthnCredential() WebAuthnCredentialStore
+	PostReminder() PostReminderStore
 }
 
 type RetentionPolicyStore interface {
@@ -313,6 +326,7 @@ type ChannelStore interface {
 	GetTeamForChannel(channelID string) (*model.Team, error)
 	IsReadOnlyChannel(channelID string) (bool, error)
 	IsChannelReadOnlyScheme(schemeID string) (bool, error)
+	GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, error)
 }
 
 type ChannelMemberHistoryStore interface {
@@ -323,6 +337,7 @@ type ChannelMemberHistoryStore interface {
 	DeleteOrphanedRows(limit int) (deleted int64, err error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetChannelsLeftSince(userID string, since int64) ([]string, error)
+	GetChannelsLeftForExport(userID string, teamID string, filter model.BulkExportFilter) ([]*model.ChannelMemberHistoryForExport, error)
 }
 type ThreadStore interface {
 	GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error)
@@ -384,6 +399,7 @@ type PostStore interface {
 	AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error)
 	AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error)
 	AnalyticsPostCount(options *model.PostCountOptions) (int64, error)
+	GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, error)
 	ClearCaches()
 	InvalidateLastPostTimeCache(channelID string)
 	GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error)
@@ -399,12 +415,13 @@ type PostStore interface {
 	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
 	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error)
+	GetParentsForFilteredExportAfter(limit int, afterID string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.PostForExport, error)
+	GetDirectPostParentsForFilteredExportAfter(limit int, afterID string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.DirectPostForExport, error)
+	GetAllRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
 	SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.PostSearchResults, error)
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error)
 	GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error)
-	SetPostReminder(reminder *model.PostReminder) error
-	GetPostReminders(now int64) ([]*model.PostReminder, error)
 	GetPostReminderMetadata(postID string) (*PostReminderMetadata, error)
 	// GetNthRecentPostTime returns the CreateAt time of the nth most recent post.
 	GetNthRecentPostTime(n int64) (int64, error)
@@ -425,6 +442,12 @@ type UserStore interface {
 	UpdateMfaActive(userID string, active bool) error
 	StoreMfaUsedTimestamps(userID string, ts []int) error
 	GetMfaUsedTimestamps(userID string) ([]int, error)
+	// StoreMfaRecoveryCodes replaces the hashed MFA recovery codes of a user.
+	StoreMfaRecoveryCodes(userID string, codeHashes []string) error
+	// UseMfaRecoveryCode removes a hashed MFA recovery code of a user, and tells whether the
+	// user had it.
+	UseMfaRecoveryCode(userID, codeHash string) (bool, error)
+	CountMfaRecoveryCodes(userID string) (int64, error)
 	Get(ctx context.Context, id string) (*model.User, error)
 	GetMany(ctx context.Context, ids []string) ([]*model.User, error)
 	GetAll() ([]*model.User, error)
@@ -438,6 +461,9 @@ type UserStore interface {
 	GetProfilesNotInChannel(teamID string, channelID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error)
 	GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error)
 	GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error)
+	// GetAutoResponderMentionUsernames returns the usernames of the active users whose
+	// auto-responder is on and also answers mentions outside of direct messages.
+	GetAutoResponderMentionUsernames() ([]string, error)
 	GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error)
 	GetProfiles(options *model.UserGetOptions) ([]*model.User, error)
 	GetProfileByIds(ctx context.Context, userIds []string, options *UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error)
@@ -479,6 +505,7 @@ type UserStore interface {
 	ClearAllCustomRoleAssignments() error
 	InferSystemInstallDate() (int64, error)
 	GetAllAfter(limit int, afterID string) ([]*model.User, error)
+	GetAllForFilteredExportAfter(limit int, afterID string, filter model.BulkExportFilter) ([]*model.User, error)
 	GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error)
 	Count(options model.UserCountOptions) (int64, error)
 	GetTeamGroupUsers(teamID string) ([]*model.User, error)
@@ -494,6 +521,7 @@ type UserStore interface {
 	RefreshPostStatsForUsers() error
 	GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error)
 	GetUserCountForReport(filter *model.UserReportOptions) (int64, error)
+	GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, error)
 }
 
 type BotStore interface {
@@ -533,6 +561,13 @@ type AuditStore interface {
 	PermanentDeleteByUser(userID string) error
 }
 
+type AuditLogStore interface {
+	Save(log *model.AuditLog) (*model.AuditLog, error)
+	// Search returns the audit logs matching the options, most recent first.
+	Search(opts model.AuditLogSearchOptions) ([]*model.AuditLog, error)
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
+}
+
 type ClusterDiscoveryStore interface {
 	Save(discovery *model.ClusterDiscovery) error
 	Delete(discovery *model.ClusterDiscovery) (bool, error)
@@ -636,6 +671,69 @@ type WebhookStore interface {
 	ClearCaches()
 }
 
+type OutgoingWebhookDeliveryStore interface {
+	Save(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	Get(id string) (*model.OutgoingWebhookDelivery, error)
+	GetForHook(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, error)
+	// GetPendingRetries returns the deliveries waiting to be retried whose retry time is due at the given time.
+	GetPendingRetries(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	// UpdateStatus changes the status of a delivery only if it still has the old status,
+	// returning false otherwise.
+	UpdateStatus(id, oldStatus, newStatus string) (bool, error)
+	// Delete removes a delivery only if it still has the given status, returning false otherwise.
+	Delete(id, status string) (bool, error)
+	// ResolveFailures marks the failed deliveries of the hook as resolved by a later successful delivery.
+	ResolveFailures(hookID string, resolvedAt int64) error
+	// GetConsecutiveFailureCount returns the number of deliveries of the hook that failed
+	// for good since its last successful delivery.
+	GetConsecutiveFailureCount(hookID string) (int64, error)
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
+}
+
+type OutOfOfficeStore interface {
+	Save(schedule *model.OutOfOfficeSchedule) (*model.OutOfOfficeSchedule, error)
+	Get(userID string) (*model.OutOfOfficeSchedule, error)
+	Delete(userID string) error
+	// GetDue returns the schedules that have to be started or ended at the given time.
+	GetDue(now int64, limit int) ([]*model.OutOfOfficeSchedule, error)
+}
+
+type PollStore interface {
+	Save(poll *model.Poll) (*model.Poll, error)
+	Get(postID string) (*model.Poll, error)
+	// Close stops a poll from accepting votes and marks its post as updated.
+	Close(postID string, closeAt int64) error
+	// Vote replaces the votes of a user on a poll.
+	Vote(postID, userID string, options []int) error
+	GetVotes(postID string) ([]*model.PollVote, error)
+}
+
+type PostReminderStore interface {
+	// Save schedules the reminder of a user about a post, replacing any previous one.
+	Save(reminder *model.PostReminder) error
+	// GetDue returns the pending reminders whose target time is not after now, oldest first.
+	GetDue(now int64, limit int) ([]*model.PostReminder, error)
+	// MarkDelivered records that the reminder was sent, so that it can be snoozed.
+	MarkDelivered(postID, userID string, deliveredAt int64) error
+	// Snooze schedules again a reminder that was already delivered.
+	Snooze(postID, userID string, targetTime int64) error
+	GetPendingForUser(userID string) ([]*model.PostReminder, error)
+	// Delete cancels a pending reminder.
+	Delete(postID, userID string) error
+	// DeleteDeliveredBefore removes the reminders delivered before the given time.
+	DeleteDeliveredBefore(before int64) (int64, error)
+}
+
+type WebAuthnCredentialStore interface {
+	Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error)
+	Get(id string) (*model.WebAuthnCredential, error)
+	GetForUser(userID string) ([]*model.WebAuthnCredential, error)
+	// UpdateSignCount records a successful use of a credential.
+	UpdateSignCount(id string, signCount int64, lastUsedAt int64) error
+	Delete(id string) error
+	PermanentDeleteByUser(userID string) error
+}
+
 type CommandStore interface {
 	Save(webhook *model.Command) (*model.Command, error)
 	GetByTrigger(teamID string, trigger string) (*model.Command, error)
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestAuditLogStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("Save", func(t *testing.T) { testAuditLogSave(t, rctx, ss) })
+	t.Run("Search", func(t *testing.T) { testAuditLogSearch(t, rctx, ss) })
+	t.Run("PermanentDeleteBatch", func(t *testing.T) { testAuditLogPermanentDeleteBatch(t, rctx, ss) })
+}
+
+func makeAuditLog(actorID, eventName, objectType, objectID string, createAt int64) *model.AuditLog {
+	return &model.AuditLog{
+		CreateAt:   createAt,
+		EventName:  eventName,
+		Status:     "success",
+		ActorId:    actorID,
+		SessionId:  model.NewId(),
+		IpAddress:  "127.0.0.1",
+		ObjectType: objectType,
+		ObjectId:   objectID,
+		Data:       `{"event_name":"` + eventName + `"}`,
+	}
+}
+
+func testAuditLogSave(t *testing.T, rctx request.CTX, ss store.Store) {
+	actorID := model.NewId()
+	saved, err := ss.AuditLog().Save(makeAuditLog(actorID, "updateChannel", "channel", model.NewId(), 0))
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.Id)
+	assert.NotZero(t, saved.CreateAt)
+
+	logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ActorId: actorID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, saved, logs[0])
+
+	t.Run("invalid audit log", func(t *testing.T) {
+		_, err := ss.AuditLog().Save(makeAuditLog(actorID, "", "channel", model.NewId(), 0))
+		require.Error(t, err)
+	})
+}
+
+func testAuditLogSearch(t *testing.T, rctx request.CTX, ss store.Store) {
+	actorID := model.NewId()
+	channelID := model.NewId()
+	first, err := ss.AuditLog().Save(makeAuditLog(actorID, "updateChannelScheme", "channel", channelID, 1000))
+	require.NoError(t, err)
+	second, err := ss.AuditLog().Save(makeAuditLog(actorID, "patchChannel", "channel", channelID, 2000))
+	require.NoError(t, err)
+	third, err := ss.AuditLog().Save(makeAuditLog(actorID, "updateChannelScheme", "channel", model.NewId(), 3000))
+	require.NoError(t, err)
+	_, err = ss.AuditLog().Save(makeAuditLog(model.NewId(), "updateChannelScheme", "channel", channelID, 4000))
+	require.NoError(t, err)
+
+	t.Run("by actor, most recent first", func(t *testing.T) {
+		logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ActorId: actorID, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, logs, 3)
+		assert.Equal(t, third.Id, logs[0].Id)
+		assert.Equal(t, second.Id, logs[1].Id)
+		assert.Equal(t, first.Id, logs[2].Id)
+	})
+
+	t.Run("by event name", func(t *testing.T) {
+		logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ActorId: actorID, EventName: "updateChannelScheme", PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		assert.Equal(t, third.Id, logs[0].Id)
+		assert.Equal(t, first.Id, logs[1].Id)
+	})
+
+	t.Run("by object", func(t *testing.T) {
+		logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ObjectType: "channel", ObjectId: channelID, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, logs, 3)
+
+		logs, err = ss.AuditLog().Search(model.AuditLogSearchOptions{ObjectType: "team", ObjectId: channelID, PerPage: 10})
+		require.NoError(t, err)
+		require.Empty(t, logs)
+	})
+
+	t.Run("by time range", func(t *testing.T) {
+		logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ActorId: actorID, StartTime: 2000, EndTime: 3000, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		assert.Equal(t, third.Id, logs[0].Id)
+		assert.Equal(t, second.Id, logs[1].Id)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ActorId: actorID, Page: 1, PerPage: 2})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, first.Id, logs[0].Id)
+	})
+}
+
+func testAuditLogPermanentDeleteBatch(t *testing.T, rctx request.CTX, ss store.Store) {
+	actorID := model.NewId()
+	_, err := ss.AuditLog().Save(makeAuditLog(actorID, "login", "user", actorID, 1000))
+	require.NoError(t, err)
+	recent, err := ss.AuditLog().Save(makeAuditLog(actorID, "login", "user", actorID, 3000))
+	require.NoError(t, err)
+
+	deleted, err := ss.AuditLog().PermanentDeleteBatch(2000, 1000)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	logs, err := ss.AuditLog().Search(model.AuditLogSearchOptions{ActorId: actorID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, recent.Id, logs[0].Id)
+}
@@ -24,6 +24,7 @@ func TestChannelMemberHistoryStore(t *testing.T, rctx request.CTX, ss store.Stor
 	t.Run("TestPermanentDeleteBatch", func(t *testing.T) { testPermanentDeleteBatch(t, rctx, ss) })
 	t.Run("TestPermanentDeleteBatchForRetentionPolicies", func(t *testing.T) { testPermanentDeleteBatchForRetentionPolicies(t, rctx, ss) })
 	t.Run("TestGetChannelsLeftSince", func(t *testing.T) { testGetChannelsLeftSince(t, rctx, ss) })
+	t.Run("TestGetChannelsLeftForExport", func(t *testing.T) { testGetChannelsLeftForExport(t, rctx, ss) })
 }
 
 func testLogJoinEvent(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -445,3 +446,58 @@ func testGetChannelsLeftSince(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{channel.Id}, ids)
 }
+
+func testGetChannelsLeftForExport(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        "team" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	makeChannel := func() *model.Channel {
+		channel, err := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      team.Id,
+			DisplayName: "DisplayName",
+			Name:        "channel" + model.NewId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+		return channel
+	}
+	left := makeChannel()
+	rejoined := makeChannel()
+	leftBefore := makeChannel()
+
+	userID := model.NewId()
+
+	for _, channel := range []*model.Channel{left, rejoined, leftBefore} {
+		require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID, channel.Id, 1000))
+	}
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID, leftBefore.Id, 1500))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID, left.Id, 2500))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID, rejoined.Id, 2500))
+
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID, rejoined.Id, 2600))
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   rejoined.Id,
+		UserId:      userID,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	channels, err := ss.ChannelMemberHistory().GetChannelsLeftForExport(userID, team.Id, model.BulkExportFilter{Since: 2000, Until: 3000})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ChannelMemberHistoryForExport{
+		{ChannelId: left.Id, ChannelName: left.Name, LeaveTime: 2500},
+	}, channels)
+
+	channels, err = ss.ChannelMemberHistory().GetChannelsLeftForExport(userID, team.Id, model.BulkExportFilter{Since: 3000})
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+
+	channels, err = ss.ChannelMemberHistory().GetChannelsLeftForExport(userID, model.NewId(), model.BulkExportFilter{Since: 1000})
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+}
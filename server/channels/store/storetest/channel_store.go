@@ -153,6 +153,7 @@ func TestChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore
 	t.Run("SetShared", func(t *testing.T) { testSetShared(t, rctx, ss) })
 	t.Run("GetTeamForChannel", func(t *testing.T) { testGetTeamForChannel(t, rctx, ss) })
 	t.Run("GetChannelsWithUnreadsAndWithMentions", func(t *testing.T) { testGetChannelsWithUnreadsAndWithMentions(t, rctx, ss) })
+	t.Run("GetChannelActivityReport", func(t *testing.T) { testGetChannelActivityReport(t, rctx, ss) })
 }
 
 func testChannelStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -8234,3 +8235,84 @@ func testGetChannelsWithUnreadsAndWithMentions(t *testing.T, rctx request.CTX, s
 		require.Len(t, times, 0)
 	})
 }
+
+func testGetChannelActivityReport(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		Name:        NewTestID(),
+		DisplayName: "Activity Team",
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	openChannel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Open",
+		Name:        "a-activity-" + NewTestID(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	privateChannel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Private",
+		Name:        "b-activity-" + NewTestID(),
+		Type:        model.ChannelTypePrivate,
+	}, -1)
+	require.NoError(t, err)
+
+	userID := model.NewId()
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   openChannel.Id,
+		UserId:      userID,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	startAt := model.GetMillis() - 1000
+	for _, createAt := range []int64{startAt - 1000, startAt + 1, startAt + 2} {
+		_, err = ss.Post().Save(rctx, &model.Post{ChannelId: openChannel.Id, UserId: userID, Message: NewTestID(), CreateAt: createAt})
+		require.NoError(t, err)
+	}
+
+	t.Run("pages through the channels of a team", func(t *testing.T) {
+		filter := &model.ChannelActivityReportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{PageSize: 1, StartAt: startAt},
+			Team:                 team.Id,
+		}
+
+		reports, err := ss.Channel().GetChannelActivityReport(filter)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, openChannel.Id, reports[0].ChannelId)
+		assert.Equal(t, "Activity Team", reports[0].TeamName)
+		assert.Equal(t, model.ChannelTypeOpen, reports[0].Type)
+		assert.Equal(t, int64(1), reports[0].MemberCount)
+		assert.Equal(t, int64(2), reports[0].PostCount)
+
+		filter.FromColumnValue = reports[0].Name
+		filter.FromId = reports[0].ChannelId
+		reports, err = ss.Channel().GetChannelActivityReport(filter)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, privateChannel.Id, reports[0].ChannelId)
+		assert.Equal(t, int64(0), reports[0].MemberCount)
+		assert.Equal(t, int64(0), reports[0].PostCount)
+
+		filter.FromColumnValue = reports[0].Name
+		filter.FromId = reports[0].ChannelId
+		reports, err = ss.Channel().GetChannelActivityReport(filter)
+		require.NoError(t, err)
+		require.Empty(t, reports)
+	})
+
+	t.Run("counts every post without a date range", func(t *testing.T) {
+		reports, err := ss.Channel().GetChannelActivityReport(&model.ChannelActivityReportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{PageSize: 10},
+			Team:                 team.Id,
+		})
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, int64(3), reports[0].PostCount)
+	})
+}
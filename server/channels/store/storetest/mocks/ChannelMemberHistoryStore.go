@@ -42,6 +42,36 @@ func (_m *ChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error
 	return r0, r1
 }
 
+// GetChannelsLeftForExport provides a mock function with given fields: userID, teamID, filter
+func (_m *ChannelMemberHistoryStore) GetChannelsLeftForExport(userID string, teamID string, filter model.BulkExportFilter) ([]*model.ChannelMemberHistoryForExport, error) {
+	ret := _m.Called(userID, teamID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelsLeftForExport")
+	}
+
+	var r0 []*model.ChannelMemberHistoryForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, model.BulkExportFilter) ([]*model.ChannelMemberHistoryForExport, error)); ok {
+		return rf(userID, teamID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, model.BulkExportFilter) []*model.ChannelMemberHistoryForExport); ok {
+		r0 = rf(userID, teamID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistoryForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, model.BulkExportFilter) error); ok {
+		r1 = rf(userID, teamID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelsLeftSince provides a mock function with given fields: userID, since
 func (_m *ChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	ret := _m.Called(userID, since)
@@ -832,6 +832,36 @@ func (_m *ChannelStore) GetByNamesIncludeDeleted(teamID string, names []string,
 	return r0, r1
 }
 
+// GetChannelActivityReport provides a mock function with given fields: filter
+func (_m *ChannelStore) GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelActivityReport")
+	}
+
+	var r0 []*model.ChannelActivityReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*model.ChannelActivityReportOptions) []*model.ChannelActivityReport); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelActivityReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.ChannelActivityReportOptions) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelCounts provides a mock function with given fields: teamID, userID
 func (_m *ChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
 	ret := _m.Called(teamID, userID)
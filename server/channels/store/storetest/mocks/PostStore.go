@@ -161,6 +161,36 @@ func (_m *PostStore) Get(ctx context.Context, id string, opts model.GetPostsOpti
 	return r0, r1
 }
 
+// GetAllRepliesForExport provides a mock function with given fields: parentID
+func (_m *PostStore) GetAllRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+	ret := _m.Called(parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllRepliesForExport")
+	}
+
+	var r0 []*model.ReplyForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.ReplyForExport, error)); ok {
+		return rf(parentID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.ReplyForExport); ok {
+		r0 = rf(parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ReplyForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
 func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
 	ret := _m.Called(limit, afterID, includeArchivedChannels)
@@ -191,6 +221,36 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID strin
 	return r0, r1
 }
 
+// GetDirectPostParentsForFilteredExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels, filter
+func (_m *PostStore) GetDirectPostParentsForFilteredExportAfter(limit int, afterID string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.DirectPostForExport, error) {
+	ret := _m.Called(limit, afterID, includeArchivedChannels, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDirectPostParentsForFilteredExportAfter")
+	}
+
+	var r0 []*model.DirectPostForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, bool, model.BulkExportFilter) ([]*model.DirectPostForExport, error)); ok {
+		return rf(limit, afterID, includeArchivedChannels, filter)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, bool, model.BulkExportFilter) []*model.DirectPostForExport); ok {
+		r0 = rf(limit, afterID, includeArchivedChannels, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DirectPostForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, bool, model.BulkExportFilter) error); ok {
+		r1 = rf(limit, afterID, includeArchivedChannels, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEditHistoryForPost provides a mock function with given fields: postID
 func (_m *PostStore) GetEditHistoryForPost(postID string) ([]*model.Post, error) {
 	ret := _m.Called(postID)
@@ -463,6 +523,36 @@ func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, include
 	return r0, r1
 }
 
+// GetParentsForFilteredExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels, filter
+func (_m *PostStore) GetParentsForFilteredExportAfter(limit int, afterID string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.PostForExport, error) {
+	ret := _m.Called(limit, afterID, includeArchivedChannels, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentsForFilteredExportAfter")
+	}
+
+	var r0 []*model.PostForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, bool, model.BulkExportFilter) ([]*model.PostForExport, error)); ok {
+		return rf(limit, afterID, includeArchivedChannels, filter)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, bool, model.BulkExportFilter) []*model.PostForExport); ok {
+		r0 = rf(limit, afterID, includeArchivedChannels, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, bool, model.BulkExportFilter) error); ok {
+		r1 = rf(limit, afterID, includeArchivedChannels, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostAfterTime provides a mock function with given fields: channelID, timestamp, collapsedThreads
 func (_m *PostStore) GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error) {
 	ret := _m.Called(channelID, timestamp, collapsedThreads)
@@ -579,29 +669,29 @@ func (_m *PostStore) GetPostReminderMetadata(postID string) (*store.PostReminder
 	return r0, r1
 }
 
-// GetPostReminders provides a mock function with given fields: now
-func (_m *PostStore) GetPostReminders(now int64) ([]*model.PostReminder, error) {
-	ret := _m.Called(now)
+// GetPostVolumeReport provides a mock function with given fields: filter
+func (_m *PostStore) GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, error) {
+	ret := _m.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPostReminders")
+		panic("no return value specified for GetPostVolumeReport")
 	}
 
-	var r0 []*model.PostReminder
+	var r0 []*model.PostVolumeReport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) ([]*model.PostReminder, error)); ok {
-		return rf(now)
+	if rf, ok := ret.Get(0).(func(*model.PostVolumeReportOptions) ([]*model.PostVolumeReport, error)); ok {
+		return rf(filter)
 	}
-	if rf, ok := ret.Get(0).(func(int64) []*model.PostReminder); ok {
-		r0 = rf(now)
+	if rf, ok := ret.Get(0).(func(*model.PostVolumeReportOptions) []*model.PostVolumeReport); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.PostReminder)
+			r0 = ret.Get(0).([]*model.PostVolumeReport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(now)
+	if rf, ok := ret.Get(1).(func(*model.PostVolumeReportOptions) error); ok {
+		r1 = rf(filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1290,24 +1380,6 @@ func (_m *PostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.Se
 	return r0, r1
 }
 
-// SetPostReminder provides a mock function with given fields: reminder
-func (_m *PostStore) SetPostReminder(reminder *model.PostReminder) error {
-	ret := _m.Called(reminder)
-
-	if len(ret) == 0 {
-		panic("no return value specified for SetPostReminder")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.PostReminder) error); ok {
-		r0 = rf(reminder)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // Update provides a mock function with given fields: rctx, newPost, oldPost
 func (_m *PostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
 	ret := _m.Called(rctx, newPost, oldPost)
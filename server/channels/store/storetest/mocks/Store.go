@@ -44,6 +44,26 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditLog provides a mock function with given fields:
+func (_m *Store) AuditLog() store.AuditLogStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditLog")
+	}
+
+	var r0 store.AuditLogStore
+	if rf, ok := ret.Get(0).(func() store.AuditLogStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditLogStore)
+		}
+	}
+
+	return r0
+}
+
 // Bot provides a mock function with given fields:
 func (_m *Store) Bot() store.BotStore {
 	ret := _m.Called()
@@ -638,6 +658,26 @@ func (_m *Store) OAuth() store.OAuthStore {
 	return r0
 }
 
+// OutOfOffice provides a mock function with given fields:
+func (_m *Store) OutOfOffice() store.OutOfOfficeStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutOfOffice")
+	}
+
+	var r0 store.OutOfOfficeStore
+	if rf, ok := ret.Get(0).(func() store.OutOfOfficeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OutOfOfficeStore)
+		}
+	}
+
+	return r0
+}
+
 // OutgoingOAuthConnection provides a mock function with given fields:
 func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	ret := _m.Called()
@@ -658,6 +698,26 @@ func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return r0
 }
 
+// OutgoingWebhookDelivery provides a mock function with given fields:
+func (_m *Store) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutgoingWebhookDelivery")
+	}
+
+	var r0 store.OutgoingWebhookDeliveryStore
+	if rf, ok := ret.Get(0).(func() store.OutgoingWebhookDeliveryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OutgoingWebhookDeliveryStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -678,6 +738,26 @@ func (_m *Store) Plugin() store.PluginStore {
 	return r0
 }
 
+// Poll provides a mock function with given fields:
+func (_m *Store) Poll() store.PollStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Poll")
+	}
+
+	var r0 store.PollStore
+	if rf, ok := ret.Get(0).(func() store.PollStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PollStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *Store) Post() store.PostStore {
 	ret := _m.Called()
@@ -758,6 +838,26 @@ func (_m *Store) PostPriority() store.PostPriorityStore {
 	return r0
 }
 
+// PostReminder provides a mock function with given fields:
+func (_m *Store) PostReminder() store.PostReminderStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostReminder")
+	}
+
+	var r0 store.PostReminderStore
+	if rf, ok := ret.Get(0).(func() store.PostReminderStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReminderStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *Store) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -818,6 +918,11 @@ func (_m *Store) Reaction() store.ReactionStore {
 	return r0
 }
 
+// RecordUserWrite provides a mock function with given fields: userID
+func (_m *Store) RecordUserWrite(userID string) {
+	_m.Called(userID)
+}
+
 // RecycleDBConnections provides a mock function with given fields: d
 func (_m *Store) RecycleDBConnections(d time.Duration) {
 	_m.Called(d)
@@ -1004,6 +1109,24 @@ func (_m *Store) SharedChannel() store.SharedChannelStore {
 	return r0
 }
 
+// ShouldReadFromMaster provides a mock function with given fields: userID
+func (_m *Store) ShouldReadFromMaster(userID string) bool {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShouldReadFromMaster")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Status provides a mock function with given fields:
 func (_m *Store) Status() store.StatusStore {
 	ret := _m.Called()
@@ -1263,6 +1386,26 @@ func (_m *Store) UserTermsOfService() store.UserTermsOfServiceStore {
 	return r0
 }
 
+// WebAuthnCredential provides a mock function with given fields:
+func (_m *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebAuthnCredential")
+	}
+
+	var r0 store.WebAuthnCredentialStore
+	if rf, ok := ret.Get(0).(func() store.WebAuthnCredentialStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.WebAuthnCredentialStore)
+		}
+	}
+
+	return r0
+}
+
 // Webhook provides a mock function with given fields:
 func (_m *Store) Webhook() store.WebhookStore {
 	ret := _m.Called()
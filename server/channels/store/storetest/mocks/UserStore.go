@@ -269,6 +269,34 @@ func (_m *UserStore) Count(options model.UserCountOptions) (int64, error) {
 	return r0, r1
 }
 
+// CountMfaRecoveryCodes provides a mock function with given fields: userID
+func (_m *UserStore) CountMfaRecoveryCodes(userID string) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountMfaRecoveryCodes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeactivateGuests provides a mock function with given fields:
 func (_m *UserStore) DeactivateGuests() ([]string, error) {
 	ret := _m.Called()
@@ -419,6 +447,36 @@ func (_m *UserStore) GetAllAfter(limit int, afterID string) ([]*model.User, erro
 	return r0, r1
 }
 
+// GetAllForFilteredExportAfter provides a mock function with given fields: limit, afterID, filter
+func (_m *UserStore) GetAllForFilteredExportAfter(limit int, afterID string, filter model.BulkExportFilter) ([]*model.User, error) {
+	ret := _m.Called(limit, afterID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllForFilteredExportAfter")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, model.BulkExportFilter) ([]*model.User, error)); ok {
+		return rf(limit, afterID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, model.BulkExportFilter) []*model.User); ok {
+		r0 = rf(limit, afterID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, model.BulkExportFilter) error); ok {
+		r1 = rf(limit, afterID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllNotInAuthService provides a mock function with given fields: authServices
 func (_m *UserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 	ret := _m.Called(authServices)
@@ -567,6 +625,36 @@ func (_m *UserStore) GetAnyUnreadPostCountForChannel(userID string, channelID st
 	return r0, r1
 }
 
+// GetAutoResponderMentionUsernames provides a mock function with given fields:
+func (_m *UserStore) GetAutoResponderMentionUsernames() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAutoResponderMentionUsernames")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByAuth provides a mock function with given fields: authData, authService
 func (_m *UserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
 	ret := _m.Called(authData, authService)
@@ -801,6 +889,36 @@ func (_m *UserStore) GetForLogin(loginID string, allowSignInWithUsername bool, a
 	return r0, r1
 }
 
+// GetGuestAccessReport provides a mock function with given fields: filter
+func (_m *UserStore) GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestAccessReport")
+	}
+
+	var r0 []*model.GuestAccessReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.GuestAccessReportOptions) ([]*model.GuestAccessReport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*model.GuestAccessReportOptions) []*model.GuestAccessReport); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GuestAccessReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.GuestAccessReportOptions) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKnownUsers provides a mock function with given fields: userID
 func (_m *UserStore) GetKnownUsers(userID string) ([]string, error) {
 	ret := _m.Called(userID)
@@ -1914,6 +2032,24 @@ func (_m *UserStore) SearchWithoutTeam(term string, options *model.UserSearchOpt
 	return r0, r1
 }
 
+// StoreMfaRecoveryCodes provides a mock function with given fields: userID, codeHashes
+func (_m *UserStore) StoreMfaRecoveryCodes(userID string, codeHashes []string) error {
+	ret := _m.Called(userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreMfaRecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreMfaUsedTimestamps provides a mock function with given fields: userID, ts
 func (_m *UserStore) StoreMfaUsedTimestamps(userID string, ts []int) error {
 	ret := _m.Called(userID, ts)
@@ -2144,6 +2280,34 @@ func (_m *UserStore) UpdateUpdateAt(userID string) (int64, error) {
 	return r0, r1
 }
 
+// UseMfaRecoveryCode provides a mock function with given fields: userID, codeHash
+func (_m *UserStore) UseMfaRecoveryCode(userID string, codeHash string) (bool, error) {
+	ret := _m.Called(userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseMfaRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(userID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyEmail provides a mock function with given fields: userID, email
 func (_m *UserStore) VerifyEmail(userID string, email string) (string, error) {
 	ret := _m.Called(userID, email)
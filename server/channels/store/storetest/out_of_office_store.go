@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOutOfOfficeStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testOutOfOfficeSaveGetDelete(t, rctx, ss) })
+	t.Run("GetDue", func(t *testing.T) { testOutOfOfficeGetDue(t, rctx, ss) })
+}
+
+func testOutOfOfficeSaveGetDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	_, err := ss.OutOfOffice().Get(userID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	saved, err := ss.OutOfOffice().Save(&model.OutOfOfficeSchedule{UserId: userID, StartAt: 1000, EndAt: 2000})
+	require.NoError(t, err)
+	assert.NotZero(t, saved.CreateAt)
+
+	// Saving again replaces the period.
+	_, err = ss.OutOfOffice().Save(&model.OutOfOfficeSchedule{UserId: userID, StartAt: 3000, EndAt: 4000, ActivatedAt: 3001})
+	require.NoError(t, err)
+
+	schedule, err := ss.OutOfOffice().Get(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), schedule.StartAt)
+	assert.Equal(t, int64(4000), schedule.EndAt)
+	assert.Equal(t, int64(3001), schedule.ActivatedAt)
+
+	_, err = ss.OutOfOffice().Save(&model.OutOfOfficeSchedule{UserId: userID, StartAt: 5000, EndAt: 4000})
+	require.Error(t, err)
+
+	require.NoError(t, ss.OutOfOffice().Delete(userID))
+	_, err = ss.OutOfOffice().Get(userID)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testOutOfOfficeGetDue(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+
+	toStart := &model.OutOfOfficeSchedule{UserId: model.NewId(), StartAt: now - 1000, EndAt: now + 100000}
+	toEnd := &model.OutOfOfficeSchedule{UserId: model.NewId(), StartAt: now - 100000, EndAt: now - 1000, ActivatedAt: now - 100000}
+	running := &model.OutOfOfficeSchedule{UserId: model.NewId(), StartAt: now - 100000, EndAt: now + 100000, ActivatedAt: now - 100000}
+	future := &model.OutOfOfficeSchedule{UserId: model.NewId(), StartAt: now + 1000, EndAt: now + 100000}
+
+	for _, schedule := range []*model.OutOfOfficeSchedule{toStart, toEnd, running, future} {
+		_, err := ss.OutOfOffice().Save(schedule)
+		require.NoError(t, err)
+		defer func(userID string) {
+			require.NoError(t, ss.OutOfOffice().Delete(userID))
+		}(schedule.UserId)
+	}
+
+	due, err := ss.OutOfOffice().GetDue(now, 100)
+	require.NoError(t, err)
+
+	var dueUserIDs []string
+	for _, schedule := range due {
+		dueUserIDs = append(dueUserIDs, schedule.UserId)
+	}
+	assert.Contains(t, dueUserIDs, toStart.UserId)
+	assert.Contains(t, dueUserIDs, toEnd.UserId)
+	assert.NotContains(t, dueUserIDs, running.UserId)
+	assert.NotContains(t, dueUserIDs, future.UserId)
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOutgoingWebhookDeliveryStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testOutgoingWebhookDeliverySaveAndGet(t, rctx, ss) })
+	t.Run("GetForHook", func(t *testing.T) { testOutgoingWebhookDeliveryGetForHook(t, rctx, ss) })
+	t.Run("GetPendingRetries", func(t *testing.T) { testOutgoingWebhookDeliveryGetPendingRetries(t, rctx, ss) })
+	t.Run("UpdateStatus", func(t *testing.T) { testOutgoingWebhookDeliveryUpdateStatus(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testOutgoingWebhookDeliveryDelete(t, rctx, ss) })
+	t.Run("GetConsecutiveFailureCount", func(t *testing.T) { testOutgoingWebhookDeliveryGetConsecutiveFailureCount(t, rctx, ss) })
+	t.Run("PermanentDeleteBatch", func(t *testing.T) { testOutgoingWebhookDeliveryPermanentDeleteBatch(t, rctx, ss) })
+}
+
+func makeOutgoingWebhookDelivery(hookID, status string, createAt int64) *model.OutgoingWebhookDelivery {
+	return &model.OutgoingWebhookDelivery{
+		HookId:      hookID,
+		ChannelId:   model.NewId(),
+		PostId:      model.NewId(),
+		CallbackURL: "http://example.com/hook",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+		Status:      status,
+		StatusCode:  200,
+		CreateAt:    createAt,
+	}
+}
+
+func testOutgoingWebhookDeliverySaveAndGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	delivery := makeOutgoingWebhookDelivery(model.NewId(), model.OutgoingWebhookDeliveryStatusSuccess, 0)
+	delivery.Response = strings.Repeat("a", model.OutgoingWebhookDeliveryResponseMaxRunes+10)
+
+	saved, err := ss.OutgoingWebhookDelivery().Save(delivery)
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.Id)
+	assert.Equal(t, 1, saved.Attempt)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.OutgoingWebhookDelivery().Get(saved.Id)
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+	assert.Len(t, []rune(got.Response), model.OutgoingWebhookDeliveryResponseMaxRunes)
+
+	t.Run("invalid delivery", func(t *testing.T) {
+		_, err := ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(model.NewId(), "unknown", 0))
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.OutgoingWebhookDelivery().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testOutgoingWebhookDeliveryGetForHook(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	first, err := ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusFailed, 1000))
+	require.NoError(t, err)
+	second, err := ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusSuccess, 2000))
+	require.NoError(t, err)
+	_, err = ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(model.NewId(), model.OutgoingWebhookDeliveryStatusFailed, 3000))
+	require.NoError(t, err)
+
+	deliveries, err := ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, second.Id, deliveries[0].Id)
+	assert.Equal(t, first.Id, deliveries[1].Id)
+
+	deliveries, err = ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{Page: 1, PerPage: 1})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, first.Id, deliveries[0].Id)
+
+	deliveries, err = ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{Status: model.OutgoingWebhookDeliveryStatusFailed, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, first.Id, deliveries[0].Id)
+}
+
+func testOutgoingWebhookDeliveryGetPendingRetries(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	due := makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusRetryPending, 1000)
+	due.NextRetryAt = 5000
+	due, err := ss.OutgoingWebhookDelivery().Save(due)
+	require.NoError(t, err)
+
+	notDue := makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusRetryPending, 1000)
+	notDue.NextRetryAt = 50000
+	_, err = ss.OutgoingWebhookDelivery().Save(notDue)
+	require.NoError(t, err)
+
+	failed := makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusFailed, 1000)
+	_, err = ss.OutgoingWebhookDelivery().Save(failed)
+	require.NoError(t, err)
+
+	deliveries, err := ss.OutgoingWebhookDelivery().GetPendingRetries(10000, 100)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, delivery := range deliveries {
+		if delivery.HookId == hookID {
+			ids = append(ids, delivery.Id)
+		}
+	}
+	assert.Equal(t, []string{due.Id}, ids)
+}
+
+func testOutgoingWebhookDeliveryUpdateStatus(t *testing.T, rctx request.CTX, ss store.Store) {
+	delivery := makeOutgoingWebhookDelivery(model.NewId(), model.OutgoingWebhookDeliveryStatusRetryPending, 0)
+	delivery, err := ss.OutgoingWebhookDelivery().Save(delivery)
+	require.NoError(t, err)
+
+	updated, err := ss.OutgoingWebhookDelivery().UpdateStatus(delivery.Id, model.OutgoingWebhookDeliveryStatusRetryPending, model.OutgoingWebhookDeliveryStatusFailed)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	got, err := ss.OutgoingWebhookDelivery().Get(delivery.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, got.Status)
+
+	updated, err = ss.OutgoingWebhookDelivery().UpdateStatus(delivery.Id, model.OutgoingWebhookDeliveryStatusRetryPending, model.OutgoingWebhookDeliveryStatusFailed)
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func testOutgoingWebhookDeliveryDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	delivery := makeOutgoingWebhookDelivery(model.NewId(), model.OutgoingWebhookDeliveryStatusRetryPending, 0)
+	delivery, err := ss.OutgoingWebhookDelivery().Save(delivery)
+	require.NoError(t, err)
+
+	deleted, err := ss.OutgoingWebhookDelivery().Delete(delivery.Id, model.OutgoingWebhookDeliveryStatusFailed)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	deleted, err = ss.OutgoingWebhookDelivery().Delete(delivery.Id, model.OutgoingWebhookDeliveryStatusRetryPending)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	_, err = ss.OutgoingWebhookDelivery().Get(delivery.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	deleted, err = ss.OutgoingWebhookDelivery().Delete(delivery.Id, model.OutgoingWebhookDeliveryStatusRetryPending)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func testOutgoingWebhookDeliveryGetConsecutiveFailureCount(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	save := func(status string, createAt int64) {
+		_, err := ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(hookID, status, createAt))
+		require.NoError(t, err)
+	}
+
+	count, err := ss.OutgoingWebhookDelivery().GetConsecutiveFailureCount(hookID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	save(model.OutgoingWebhookDeliveryStatusFailed, 1000)
+	require.NoError(t, ss.OutgoingWebhookDelivery().ResolveFailures(hookID, 2000))
+	save(model.OutgoingWebhookDeliveryStatusFailed, 3000)
+	save(model.OutgoingWebhookDeliveryStatusRetryPending, 4000)
+	save(model.OutgoingWebhookDeliveryStatusFailed, 5000)
+	// Failures of other hooks aren't counted.
+	_, err = ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(model.NewId(), model.OutgoingWebhookDeliveryStatusFailed, 5000))
+	require.NoError(t, err)
+
+	count, err = ss.OutgoingWebhookDelivery().GetConsecutiveFailureCount(hookID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	require.NoError(t, ss.OutgoingWebhookDelivery().ResolveFailures(hookID, 6000))
+
+	count, err = ss.OutgoingWebhookDelivery().GetConsecutiveFailureCount(hookID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Resolved failures can still be replayed.
+	deliveries, err := ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{Status: model.OutgoingWebhookDeliveryStatusFailed, PerPage: 10})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 3)
+}
+
+func testOutgoingWebhookDeliveryPermanentDeleteBatch(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	old, err := ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusSuccess, 1000))
+	require.NoError(t, err)
+	recent, err := ss.OutgoingWebhookDelivery().Save(makeOutgoingWebhookDelivery(hookID, model.OutgoingWebhookDeliveryStatusSuccess, 3000))
+	require.NoError(t, err)
+
+	deleted, err := ss.OutgoingWebhookDelivery().PermanentDeleteBatch(2000, 1000)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = ss.OutgoingWebhookDelivery().Get(old.Id)
+	require.Error(t, err)
+
+	_, err = ss.OutgoingWebhookDelivery().Get(recent.Id)
+	require.NoError(t, err)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPollStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveGet", func(t *testing.T) { testPollSaveGet(t, rctx, ss) })
+	t.Run("Close", func(t *testing.T) { testPollClose(t, rctx, ss) })
+	t.Run("Vote", func(t *testing.T) { testPollVote(t, rctx, ss) })
+}
+
+func savePollPost(t *testing.T, rctx request.CTX, ss store.Store) *model.Post {
+	post, err := ss.Post().Save(rctx, &model.Post{
+		UserId:    model.NewId(),
+		ChannelId: model.NewId(),
+		Message:   "Lunch?",
+		Type:      model.PostTypePoll,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func testPollSaveGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePollPost(t, rctx, ss)
+
+	_, err := ss.Poll().Get(post.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	poll := &model.Poll{
+		PostId:         post.Id,
+		UserId:         post.UserId,
+		Options:        model.StringArray{"Pizza", "Sushi", "Salad"},
+		MultipleChoice: true,
+		CloseAt:        1234,
+	}
+	saved, err := ss.Poll().Save(poll)
+	require.NoError(t, err)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.Poll().Get(post.Id)
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+
+	_, err = ss.Poll().Save(&model.Poll{PostId: model.NewId(), UserId: model.NewId(), Options: model.StringArray{"Only one"}})
+	require.Error(t, err)
+}
+
+func testPollClose(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePollPost(t, rctx, ss)
+	_, err := ss.Poll().Save(&model.Poll{PostId: post.Id, UserId: post.UserId, Options: model.StringArray{"Yes", "No"}})
+	require.NoError(t, err)
+
+	closeAt := post.UpdateAt + 1000
+	require.NoError(t, ss.Poll().Close(post.Id, closeAt))
+
+	poll, err := ss.Poll().Get(post.Id)
+	require.NoError(t, err)
+	assert.Equal(t, closeAt, poll.CloseAt)
+
+	updated, err := ss.Post().GetSingle(rctx, post.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, closeAt, updated.UpdateAt)
+
+	err = ss.Poll().Close(model.NewId(), closeAt)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testPollVote(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePollPost(t, rctx, ss)
+	_, err := ss.Poll().Save(&model.Poll{PostId: post.Id, UserId: post.UserId, Options: model.StringArray{"A", "B", "C"}, MultipleChoice: true})
+	require.NoError(t, err)
+
+	user1 := model.NewId()
+	user2 := model.NewId()
+
+	require.NoError(t, ss.Poll().Vote(post.Id, user1, []int{0, 2}))
+	require.NoError(t, ss.Poll().Vote(post.Id, user2, []int{1}))
+
+	votes, err := ss.Poll().GetVotes(post.Id)
+	require.NoError(t, err)
+	require.Len(t, votes, 3)
+
+	updated, err := ss.Post().GetSingle(rctx, post.Id, false)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, updated.UpdateAt, votes[2].CreateAt)
+
+	// Voting again replaces the previous votes.
+	require.NoError(t, ss.Poll().Vote(post.Id, user1, []int{1}))
+	votes, err = ss.Poll().GetVotes(post.Id)
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+	for _, vote := range votes {
+		assert.Equal(t, 1, vote.OptionIndex)
+	}
+
+	// An empty vote withdraws it.
+	require.NoError(t, ss.Poll().Vote(post.Id, user2, nil))
+	votes, err = ss.Poll().GetVotes(post.Id)
+	require.NoError(t, err)
+	require.Len(t, votes, 1)
+	assert.Equal(t, user1, votes[0].UserId)
+}
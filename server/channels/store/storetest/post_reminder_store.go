@@ -0,0 +1,201 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPostReminderStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("Save", func(t *testing.T) { testPostReminderSave(t, rctx, ss, s) })
+	t.Run("GetDue", func(t *testing.T) { testPostReminderGetDue(t, rctx, ss) })
+	t.Run("Snooze", func(t *testing.T) { testPostReminderSnooze(t, rctx, ss) })
+	t.Run("GetPendingForUser", func(t *testing.T) { testPostReminderGetPendingForUser(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testPostReminderDelete(t, rctx, ss) })
+	t.Run("DeleteDeliveredBefore", func(t *testing.T) { testPostReminderDeleteDeliveredBefore(t, rctx, ss) })
+}
+
+func saveReminderPost(t *testing.T, rctx request.CTX, ss store.Store) *model.Post {
+	post, err := ss.Post().Save(rctx, &model.Post{
+		UserId:    NewTestID(),
+		ChannelId: NewTestID(),
+		Message:   "hi there",
+		Type:      model.PostTypeDefault,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func testPostReminderSave(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	userID := NewTestID()
+	post := saveReminderPost(t, rctx, ss)
+
+	reminder := &model.PostReminder{
+		TargetTime: 1234,
+		PostId:     post.Id,
+		UserId:     userID,
+	}
+	require.NoError(t, ss.PostReminder().Save(reminder))
+
+	out := model.PostReminder{}
+	require.NoError(t, s.GetMaster().Get(&out, `SELECT PostId, UserId, TargetTime FROM UserPostReminders WHERE PostId=? AND UserId=?`, reminder.PostId, reminder.UserId))
+	assert.Equal(t, reminder, &out)
+
+	err := ss.PostReminder().Save(&model.PostReminder{TargetTime: 1234, PostId: "notfound", UserId: userID})
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	t.Run("replaces the previous reminder, even if delivered", func(t *testing.T) {
+		require.NoError(t, ss.PostReminder().MarkDelivered(post.Id, userID, 2000))
+
+		reminder = &model.PostReminder{
+			TargetTime: 12345,
+			PostId:     post.Id,
+			UserId:     userID,
+		}
+		require.NoError(t, ss.PostReminder().Save(reminder))
+
+		reminders, err := ss.PostReminder().GetPendingForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, reminder, reminders[0])
+	})
+}
+
+func testPostReminderGetDue(t *testing.T, rctx request.CTX, ss store.Store) {
+	// Keep clear of the reminders saved by the other tests.
+	const base = int64(1_000_000)
+	userID := NewTestID()
+
+	var postIDs []string
+	for _, tt := range []int64{base + 2, base, base + 1, base + 3} {
+		post := saveReminderPost(t, rctx, ss)
+		postIDs = append(postIDs, post.Id)
+		require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: tt, PostId: post.Id, UserId: userID}))
+	}
+
+	reminders, err := ss.PostReminder().GetDue(base+2, 10)
+	require.NoError(t, err)
+	require.Len(t, reminders, 3)
+	assert.Equal(t, postIDs[1], reminders[0].PostId)
+	assert.Equal(t, postIDs[2], reminders[1].PostId)
+	assert.Equal(t, postIDs[0], reminders[2].PostId)
+
+	reminders, err = ss.PostReminder().GetDue(base+2, 2)
+	require.NoError(t, err)
+	assert.Len(t, reminders, 2)
+
+	// Delivered reminders are no longer due.
+	require.NoError(t, ss.PostReminder().MarkDelivered(postIDs[1], userID, model.GetMillis()))
+	reminders, err = ss.PostReminder().GetDue(base+2, 10)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, postIDs[2], reminders[0].PostId)
+}
+
+func testPostReminderSnooze(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := NewTestID()
+	post := saveReminderPost(t, rctx, ss)
+	require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: 100, PostId: post.Id, UserId: userID}))
+
+	// A pending reminder can't be snoozed.
+	err := ss.PostReminder().Snooze(post.Id, userID, 500)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	require.NoError(t, ss.PostReminder().MarkDelivered(post.Id, userID, model.GetMillis()))
+	require.NoError(t, ss.PostReminder().Snooze(post.Id, userID, 500))
+
+	reminders, err := ss.PostReminder().GetPendingForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, &model.PostReminder{TargetTime: 500, PostId: post.Id, UserId: userID}, reminders[0])
+
+	err = ss.PostReminder().Snooze(post.Id, NewTestID(), 500)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testPostReminderGetPendingForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := NewTestID()
+	otherUserID := NewTestID()
+
+	var postIDs []string
+	for _, tt := range []int64{300, 100, 200} {
+		post := saveReminderPost(t, rctx, ss)
+		postIDs = append(postIDs, post.Id)
+		require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: tt, PostId: post.Id, UserId: userID}))
+	}
+	require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: 100, PostId: postIDs[0], UserId: otherUserID}))
+
+	reminders, err := ss.PostReminder().GetPendingForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 3)
+	assert.Equal(t, &model.PostReminder{TargetTime: 100, PostId: postIDs[1], UserId: userID}, reminders[0])
+	assert.Equal(t, &model.PostReminder{TargetTime: 200, PostId: postIDs[2], UserId: userID}, reminders[1])
+	assert.Equal(t, &model.PostReminder{TargetTime: 300, PostId: postIDs[0], UserId: userID}, reminders[2])
+
+	require.NoError(t, ss.PostReminder().MarkDelivered(postIDs[1], userID, model.GetMillis()))
+	reminders, err = ss.PostReminder().GetPendingForUser(userID)
+	require.NoError(t, err)
+	assert.Len(t, reminders, 2)
+
+	reminders, err = ss.PostReminder().GetPendingForUser(NewTestID())
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+func testPostReminderDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := NewTestID()
+	post := saveReminderPost(t, rctx, ss)
+
+	require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: 100, PostId: post.Id, UserId: userID}))
+	require.NoError(t, ss.PostReminder().Delete(post.Id, userID))
+
+	reminders, err := ss.PostReminder().GetPendingForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	err = ss.PostReminder().Delete(post.Id, userID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	// A delivered reminder is not pending anymore.
+	require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: 100, PostId: post.Id, UserId: userID}))
+	require.NoError(t, ss.PostReminder().MarkDelivered(post.Id, userID, model.GetMillis()))
+	err = ss.PostReminder().Delete(post.Id, userID)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testPostReminderDeleteDeliveredBefore(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := NewTestID()
+	oldPost := saveReminderPost(t, rctx, ss)
+	newPost := saveReminderPost(t, rctx, ss)
+	pendingPost := saveReminderPost(t, rctx, ss)
+
+	for _, post := range []*model.Post{oldPost, newPost, pendingPost} {
+		require.NoError(t, ss.PostReminder().Save(&model.PostReminder{TargetTime: 100, PostId: post.Id, UserId: userID}))
+	}
+	require.NoError(t, ss.PostReminder().MarkDelivered(oldPost.Id, userID, 1000))
+	require.NoError(t, ss.PostReminder().MarkDelivered(newPost.Id, userID, 3000))
+
+	deleted, err := ss.PostReminder().DeleteDeliveredBefore(2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	// Only the newer delivered reminder can still be snoozed.
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, ss.PostReminder().Snooze(oldPost.Id, userID, 500), &nfErr)
+	require.NoError(t, ss.PostReminder().Snooze(newPost.Id, userID, 500))
+
+	reminders, err := ss.PostReminder().GetPendingForUser(userID)
+	require.NoError(t, err)
+	assert.Len(t, reminders, 2)
+}
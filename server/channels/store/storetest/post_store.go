@@ -5,7 +5,6 @@ package storetest
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -53,6 +52,7 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, rctx, ss) })
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, rctx, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, rctx, ss) })
+	t.Run("GetParentsForFilteredExportAfter", func(t *testing.T) { testPostStoreGetParentsForFilteredExportAfter(t, rctx, ss) })
 	t.Run("GetRepliesForExport", func(t *testing.T) { testPostStoreGetRepliesForExport(t, rctx, ss) })
 	t.Run("GetDirectPostParentsForExportAfter", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfter(t, rctx, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterDeleted", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterDeleted(t, rctx, ss, s) })
@@ -61,11 +61,10 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("HasAutoResponsePostByUserSince", func(t *testing.T) { testHasAutoResponsePostByUserSince(t, rctx, ss) })
 	t.Run("GetPostsSinceUpdateForSync", func(t *testing.T) { testGetPostsSinceUpdateForSync(t, rctx, ss, s) })
 	t.Run("GetPostsSinceCreateForSync", func(t *testing.T) { testGetPostsSinceCreateForSync(t, rctx, ss, s) })
-	t.Run("SetPostReminder", func(t *testing.T) { testSetPostReminder(t, rctx, ss, s) })
-	t.Run("GetPostReminders", func(t *testing.T) { testGetPostReminders(t, rctx, ss, s) })
 	t.Run("GetPostReminderMetadata", func(t *testing.T) { testGetPostReminderMetadata(t, rctx, ss, s) })
 	t.Run("GetNthRecentPostTime", func(t *testing.T) { testGetNthRecentPostTime(t, rctx, ss) })
 	t.Run("GetEditHistoryForPost", func(t *testing.T) { testGetEditHistoryForPost(t, rctx, ss) })
+	t.Run("GetPostVolumeReport", func(t *testing.T) { testGetPostVolumeReport(t, rctx, ss) })
 }
 
 func testPostStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4526,6 +4525,110 @@ func testPostStoreGetRepliesForExport(t *testing.T, rctx request.CTX, ss store.S
 	assert.Equal(t, reply1.Username, u1.Username)
 }
 
+func testPostStoreGetParentsForFilteredExportAfter(t *testing.T, rctx request.CTX, ss store.Store) {
+	t1, err := ss.Team().Save(&model.Team{
+		DisplayName: "Name",
+		Name:        NewTestID(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	t2, err := ss.Team().Save(&model.Team{
+		DisplayName: "Name",
+		Name:        NewTestID(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	c1, nErr := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      t1.Id,
+		DisplayName: "Channel1",
+		Name:        NewTestID(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+
+	c2, nErr := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      t2.Id,
+		DisplayName: "Channel2",
+		Name:        NewTestID(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+
+	u1, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewUsername(),
+	})
+	require.NoError(t, err)
+
+	savePost := func(channelID, rootID string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    u1.Id,
+			RootId:    rootID,
+			Message:   NewTestID(),
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	p1 := savePost(c1.Id, "", 1000)
+	p2 := savePost(c1.Id, "", 3000)
+	p3 := savePost(c2.Id, "", 3000)
+	r1 := savePost(c1.Id, p1.Id, 3000)
+	p4 := savePost(c1.Id, "", 1000)
+	require.NoError(t, ss.Post().Delete(rctx, p4.Id, 3000, u1.Id))
+
+	postIDs := func(posts []*model.PostForExport) []string {
+		ids := make([]string, 0, len(posts))
+		for _, p := range posts {
+			ids = append(ids, p.Id)
+		}
+		return ids
+	}
+
+	t.Run("scoped to a channel", func(t *testing.T) {
+		posts, err := ss.Post().GetParentsForFilteredExportAfter(10000, strings.Repeat("0", 26), false, model.BulkExportFilter{ChannelIds: []string{c2.Id}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{p3.Id}, postIDs(posts))
+	})
+
+	t.Run("changed in the window", func(t *testing.T) {
+		posts, err := ss.Post().GetParentsForFilteredExportAfter(10000, strings.Repeat("0", 26), false, model.BulkExportFilter{Since: 2000, TeamIds: []string{t1.Id}})
+		require.NoError(t, err)
+
+		ids := postIDs(posts)
+		assert.Contains(t, ids, p1.Id, "a root post with a changed reply should be returned")
+		assert.Contains(t, ids, p2.Id)
+		assert.Contains(t, ids, p4.Id, "a post deleted in the window should be returned")
+		assert.NotContains(t, ids, p3.Id)
+		assert.NotContains(t, ids, r1.Id)
+	})
+
+	t.Run("window before the changes", func(t *testing.T) {
+		posts, err := ss.Post().GetParentsForFilteredExportAfter(10000, strings.Repeat("0", 26), false, model.BulkExportFilter{Since: 500, Until: 1500, TeamIds: []string{t1.Id}})
+		require.NoError(t, err)
+
+		// Saving the reply and deleting p4 moved their UpdateAt past the window.
+		assert.Empty(t, posts)
+	})
+
+	t.Run("deleted replies", func(t *testing.T) {
+		require.NoError(t, ss.Post().Delete(rctx, r1.Id, 4000, u1.Id))
+
+		replies, err := ss.Post().GetAllRepliesForExport(p1.Id)
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		assert.Equal(t, r1.Id, replies[0].Id)
+		assert.NotZero(t, replies[0].DeleteAt)
+	})
+}
+
 func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	teamID := model.NewId()
 
@@ -4983,86 +5086,6 @@ func testGetPostsSinceCreateForSync(t *testing.T, rctx request.CTX, ss store.Sto
 	})
 }
 
-func testSetPostReminder(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
-	// Basic
-	userID := NewTestID()
-
-	p1 := &model.Post{
-		UserId:    userID,
-		ChannelId: NewTestID(),
-		Message:   "hi there",
-		Type:      model.PostTypeDefault,
-	}
-	p1, err := ss.Post().Save(rctx, p1)
-	require.NoError(t, err)
-
-	reminder := &model.PostReminder{
-		TargetTime: 1234,
-		PostId:     p1.Id,
-		UserId:     userID,
-	}
-
-	require.NoError(t, ss.Post().SetPostReminder(reminder))
-
-	out := model.PostReminder{}
-	require.NoError(t, s.GetMaster().Get(&out, `SELECT PostId, UserId, TargetTime FROM PostReminders WHERE PostId=? AND UserId=?`, reminder.PostId, reminder.UserId))
-	assert.Equal(t, reminder, &out)
-
-	reminder.PostId = "notfound"
-	err = ss.Post().SetPostReminder(reminder)
-	var nfErr *store.ErrNotFound
-	require.True(t, errors.As(err, &nfErr))
-
-	// Upsert
-	reminder = &model.PostReminder{
-		TargetTime: 12345,
-		PostId:     p1.Id,
-		UserId:     userID,
-	}
-
-	require.NoError(t, ss.Post().SetPostReminder(reminder))
-	require.NoError(t, s.GetMaster().Get(&out, `SELECT PostId, UserId, TargetTime FROM PostReminders WHERE PostId=? AND UserId=?`, reminder.PostId, reminder.UserId))
-	assert.Equal(t, reminder, &out)
-}
-
-func testGetPostReminders(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
-	times := []int64{100, 101, 102}
-	for _, tt := range times {
-		userID := NewTestID()
-
-		p1 := &model.Post{
-			UserId:    userID,
-			ChannelId: NewTestID(),
-			Message:   "hi there",
-			Type:      model.PostTypeDefault,
-		}
-		p1, err := ss.Post().Save(rctx, p1)
-		require.NoError(t, err)
-
-		reminder := &model.PostReminder{
-			TargetTime: tt,
-			PostId:     p1.Id,
-			UserId:     userID,
-		}
-
-		require.NoError(t, ss.Post().SetPostReminder(reminder))
-	}
-
-	reminders, err := ss.Post().GetPostReminders(102)
-	require.NoError(t, err)
-	require.Len(t, reminders, 2)
-
-	// assert one reminder is left
-	reminders, err = ss.Post().GetPostReminders(103)
-	require.NoError(t, err)
-	require.Len(t, reminders, 1)
-
-	// assert everything is deleted.
-	reminders, err = ss.Post().GetPostReminders(103)
-	require.NoError(t, err)
-	require.Len(t, reminders, 0)
-}
-
 func testGetPostReminderMetadata(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	team := &model.Team{
 		Name:        "teamname",
@@ -5321,3 +5344,87 @@ func testGetEditHistoryForPost(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 	})
 }
+
+func testGetPostVolumeReport(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		Name:        NewTestID(),
+		DisplayName: "Volume Team",
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Volume",
+		Name:        NewTestID(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	// Days are in UTC, so the first posts of the day are counted on that day whatever the
+	// time zone of the database. The last day is further away than a single query window.
+	firstDay := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	secondDay := firstDay.AddDate(0, 0, 1)
+	lastDay := firstDay.AddDate(0, 0, 30)
+	for _, createAt := range []time.Time{firstDay, firstDay.Add(time.Minute), secondDay, lastDay} {
+		_, err = ss.Post().Save(rctx, &model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: NewTestID(), CreateAt: createAt.UnixMilli()})
+		require.NoError(t, err)
+	}
+
+	deletedPost, err := ss.Post().Save(rctx, &model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: NewTestID(), CreateAt: secondDay.UnixMilli()})
+	require.NoError(t, err)
+	require.NoError(t, ss.Post().Delete(rctx, deletedPost.Id, model.GetMillis(), ""))
+
+	filter := &model.PostVolumeReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{PageSize: 1},
+		Team:                 team.Id,
+	}
+
+	reports, err := ss.Post().GetPostVolumeReport(filter)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, &model.PostVolumeReport{Day: "2024-03-01", TeamId: team.Id, TeamName: "Volume Team", PostCount: 2}, reports[0])
+
+	filter.FromColumnValue = reports[0].Day
+	filter.FromId = reports[0].TeamId
+	reports, err = ss.Post().GetPostVolumeReport(filter)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, &model.PostVolumeReport{Day: "2024-03-02", TeamId: team.Id, TeamName: "Volume Team", PostCount: 1}, reports[0])
+
+	filter.FromColumnValue = reports[0].Day
+	filter.FromId = reports[0].TeamId
+	reports, err = ss.Post().GetPostVolumeReport(filter)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, &model.PostVolumeReport{Day: "2024-03-31", TeamId: team.Id, TeamName: "Volume Team", PostCount: 1}, reports[0])
+
+	filter.FromColumnValue = reports[0].Day
+	filter.FromId = reports[0].TeamId
+	reports, err = ss.Post().GetPostVolumeReport(filter)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+
+	t.Run("a page spans several windows", func(t *testing.T) {
+		reports, err := ss.Post().GetPostVolumeReport(&model.PostVolumeReportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{PageSize: 10},
+			Team:                 team.Id,
+		})
+		require.NoError(t, err)
+		require.Len(t, reports, 3)
+		assert.Equal(t, "2024-03-01", reports[0].Day)
+		assert.Equal(t, "2024-03-02", reports[1].Day)
+		assert.Equal(t, "2024-03-31", reports[2].Day)
+	})
+
+	t.Run("only within the date range", func(t *testing.T) {
+		reports, err := ss.Post().GetPostVolumeReport(&model.PostVolumeReportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{PageSize: 10, StartAt: secondDay.UnixMilli(), EndAt: lastDay.UnixMilli()},
+			Team:                 team.Id,
+		})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, &model.PostVolumeReport{Day: "2024-03-02", TeamId: team.Id, TeamName: "Volume Team", PostCount: 1}, reports[0])
+	})
+}
@@ -21,6 +21,7 @@ func TestScheduledPostStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("UpdatedScheduledPost", func(t *testing.T) { testUpdatedScheduledPost(t, rctx, ss, s) })
 	t.Run("UpdateOldScheduledPosts", func(t *testing.T) { testUpdateOldScheduledPosts(t, rctx, ss, s) })
 	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPermanentDeleteScheduledPostsByUser(t, rctx, ss, s) })
+	t.Run("RecurringScheduledPosts", func(t *testing.T) { testRecurringScheduledPosts(t, rctx, ss, s) })
 }
 
 func testCreateScheduledPost(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
@@ -471,6 +472,84 @@ func testUpdateOldScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store,
 	})
 }
 
+func testRecurringScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	now := model.GetMillis()
+	twoDaysAgo := now - (2 * 24 * 60 * 60 * 1000)
+
+	newScheduledPost := func(scheduledAt int64, recurrenceRule string, pausedAt int64) *model.ScheduledPost {
+		scheduledPost, err := ss.ScheduledPost().CreateScheduledPost(&model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    model.NewId(),
+				ChannelId: model.NewId(),
+				Message:   "this is a scheduled post",
+			},
+			ScheduledAt:    scheduledAt,
+			RecurrenceRule: recurrenceRule,
+			Timezone:       "Europe/Paris",
+			PausedAt:       pausedAt,
+		})
+		require.NoError(t, err)
+		return scheduledPost
+	}
+
+	oneOff := newScheduledPost(twoDaysAgo, "", 0)
+	recurring := newScheduledPost(twoDaysAgo, "FREQ=DAILY", 0)
+	paused := newScheduledPost(now-1000, "FREQ=WEEKLY;BYDAY=MO", now)
+
+	defer func() {
+		_ = ss.ScheduledPost().PermanentlyDeleteScheduledPosts([]string{oneOff.Id, recurring.Id, paused.Id})
+	}()
+
+	t.Run("recurrence fields are persisted", func(t *testing.T) {
+		fromDB, err := ss.ScheduledPost().Get(paused.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", fromDB.RecurrenceRule)
+		assert.Equal(t, "Europe/Paris", fromDB.Timezone)
+		assert.Equal(t, now, fromDB.PausedAt)
+	})
+
+	t.Run("pending scheduled posts include missed recurring posts and exclude paused ones", func(t *testing.T) {
+		scheduledPosts, err := ss.ScheduledPost().GetPendingScheduledPosts(now, now-(24*60*60*1000), "", 100)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, scheduledPost := range scheduledPosts {
+			ids = append(ids, scheduledPost.Id)
+		}
+		assert.Contains(t, ids, recurring.Id)
+		assert.NotContains(t, ids, oneOff.Id)
+		assert.NotContains(t, ids, paused.Id)
+	})
+
+	t.Run("old recurring and paused scheduled posts are not marked as failed", func(t *testing.T) {
+		err := ss.ScheduledPost().UpdateOldScheduledPosts(now)
+		require.NoError(t, err)
+
+		fromDB, err := ss.ScheduledPost().Get(oneOff.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledPostErrorUnableToSend, fromDB.ErrorCode)
+
+		for _, id := range []string{recurring.Id, paused.Id} {
+			fromDB, err = ss.ScheduledPost().Get(id)
+			require.NoError(t, err)
+			assert.Empty(t, fromDB.ErrorCode)
+		}
+	})
+
+	t.Run("resuming a paused scheduled post", func(t *testing.T) {
+		paused.PausedAt = 0
+		paused.ScheduledAt = now + 100000
+		err := ss.ScheduledPost().UpdatedScheduledPost(paused)
+		require.NoError(t, err)
+
+		fromDB, err := ss.ScheduledPost().Get(paused.Id)
+		require.NoError(t, err)
+		assert.Zero(t, fromDB.PausedAt)
+		assert.Equal(t, now+100000, fromDB.ScheduledAt)
+	})
+}
+
 func testPermanentDeleteScheduledPostsByUser(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("should delete all scheduled posts for a given user", func(t *testing.T) {
 		userId := model.NewId()
@@ -66,6 +66,12 @@ type Store struct {
 	DesktopTokensStore              mocks.DesktopTokensStore
 	ChannelBookmarkStore            mocks.ChannelBookmarkStore
 	ScheduledPostStore              mocks.ScheduledPostStore
+	AuditLogStore                   mocks.AuditLogStore
+	OutgoingWebhookDeliveryStore    mocks.OutgoingWebhookDeliveryStore
+	OutOfOfficeStore                mocks.OutOfOfficeStore
+	PollStore                       mocks.PollStore
+	WebAuthnCredentialStore         mocks.WebAuthnCredentialStore
+	PostReminderStore               mocks.PostReminderStore
 }
 
 func (s *Store) SetContext(context context.Context)            { s.context = context }
@@ -79,6 +85,7 @@ func (s *Store) RetentionPolicy() store.RetentionPolicyStore   { return &s.Reten
 func (s *Store) Bot() store.BotStore                           { return &s.BotStore }
 func (s *Store) ProductNotices() store.ProductNoticesStore     { return &s.ProductNoticesStore }
 func (s *Store) Audit() store.AuditStore                       { return &s.AuditStore }
+func (s *Store) AuditLog() store.AuditLogStore                 { return &s.AuditLogStore }
 func (s *Store) ClusterDiscovery() store.ClusterDiscoveryStore { return &s.ClusterDiscoveryStore }
 func (s *Store) RemoteCluster() store.RemoteClusterStore       { return &s.RemoteClusterStore }
 func (s *Store) Compliance() store.ComplianceStore             { return &s.ComplianceStore }
@@ -119,6 +126,15 @@ func (s *Store) LinkMetadata() store.LinkMetadataStore       { return &s.LinkMet
 func (s *Store) SharedChannel() store.SharedChannelStore     { return &s.SharedChannelStore }
 func (s *Store) PostPriority() store.PostPriorityStore       { return &s.PostPriorityStore }
 func (s *Store) ScheduledPost() store.ScheduledPostStore     { return &s.ScheduledPostStore }
+func (s *Store) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	return &s.OutgoingWebhookDeliveryStore
+}
+func (s *Store) OutOfOffice() store.OutOfOfficeStore { return &s.OutOfOfficeStore }
+func (s *Store) Poll() store.PollStore               { return &s.PollStore }
+func (s *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return &s.WebAuthnCredentialStore
+}
+func (s *Store) PostReminder() store.PostReminderStore { return &s.PostReminderStore }
 func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
 	return &s.PostAcknowledgementStore
 }
@@ -146,8 +162,10 @@ func (s *Store) TotalSearchDbConnections() int { return 1 }
 func (s *Store) CheckIntegrity() <-chan model.IntegrityCheckResult {
 	return make(chan model.IntegrityCheckResult)
 }
-func (s *Store) ReplicaLagAbs() error  { return nil }
-func (s *Store) ReplicaLagTime() error { return nil }
+func (s *Store) ReplicaLagAbs() error             { return nil }
+func (s *Store) ReplicaLagTime() error            { return nil }
+func (s *Store) RecordUserWrite(string)           { /* do nothing */ }
+func (s *Store) ShouldReadFromMaster(string) bool { return false }
 
 func (s *Store) AssertExpectations(t mock.TestingT) bool {
 	return mock.AssertExpectationsForObjects(t,
@@ -191,5 +209,11 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.DesktopTokensStore,
 		&s.ChannelBookmarkStore,
 		&s.ScheduledPostStore,
+		&s.AuditLogStore,
+		&s.OutgoingWebhookDeliveryStore,
+		&s.OutOfOfficeStore,
+		&s.PollStore,
+		&s.WebAuthnCredentialStore,
+		&s.PostReminderStore,
 	)
 }
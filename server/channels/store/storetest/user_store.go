@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -67,6 +68,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetProfilesByIds", func(t *testing.T) { testUserStoreGetProfilesByIds(t, rctx, ss) })
 	t.Run("GetProfileByGroupChannelIdsForUser", func(t *testing.T) { testUserStoreGetProfileByGroupChannelIdsForUser(t, rctx, ss) })
 	t.Run("GetProfilesByUsernames", func(t *testing.T) { testUserStoreGetProfilesByUsernames(t, rctx, ss) })
+	t.Run("GetAutoResponderMentionUsernames", func(t *testing.T) { testUserStoreGetAutoResponderMentionUsernames(t, rctx, ss) })
 	t.Run("GetSystemAdminProfiles", func(t *testing.T) { testUserStoreGetSystemAdminProfiles(t, rctx, ss) })
 	t.Run("GetByEmail", func(t *testing.T) { testUserStoreGetByEmail(t, rctx, ss) })
 	t.Run("GetByAuthData", func(t *testing.T) { testUserStoreGetByAuthData(t, rctx, ss) })
@@ -91,6 +93,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetProfilesNotInTeam", func(t *testing.T) { testUserStoreGetProfilesNotInTeam(t, rctx, ss) })
 	t.Run("ClearAllCustomRoleAssignments", func(t *testing.T) { testUserStoreClearAllCustomRoleAssignments(t, rctx, ss) })
 	t.Run("GetAllAfter", func(t *testing.T) { testUserStoreGetAllAfter(t, rctx, ss) })
+	t.Run("GetAllForFilteredExportAfter", func(t *testing.T) { testUserStoreGetAllForFilteredExportAfter(t, rctx, ss) })
 	t.Run("GetUsersBatchForIndexing", func(t *testing.T) { testUserStoreGetUsersBatchForIndexing(t, rctx, ss) })
 	t.Run("GetTeamGroupUsers", func(t *testing.T) { testUserStoreGetTeamGroupUsers(t, rctx, ss) })
 	t.Run("GetChannelGroupUsers", func(t *testing.T) { testUserStoreGetChannelGroupUsers(t, rctx, ss) })
@@ -103,6 +106,8 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("UpdateLastLogin", func(t *testing.T) { testUpdateLastLogin(t, rctx, ss) })
 	t.Run("GetUserReport", func(t *testing.T) { testGetUserReport(t, rctx, ss, s) })
 	t.Run("MfaUsedTimestamps", func(t *testing.T) { testMfaUsedTimestamps(t, rctx, ss) })
+	t.Run("MfaRecoveryCodes", func(t *testing.T) { testMfaRecoveryCodes(t, rctx, ss) })
+	t.Run("GetGuestAccessReport", func(t *testing.T) { testGetGuestAccessReport(t, rctx, ss) })
 }
 
 func testUserStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -1846,6 +1851,38 @@ func testUserStoreGetProfileByGroupChannelIdsForUser(t *testing.T, rctx request.
 	}
 }
 
+func testUserStoreGetAutoResponderMentionUsernames(t *testing.T, rctx request.CTX, ss store.Store) {
+	save := func(active, mentions bool) *model.User {
+		user := &model.User{
+			Email:    MakeEmail(),
+			Username: "u" + model.NewId(),
+		}
+		user.SetDefaultNotifications()
+		user.NotifyProps[model.AutoResponderActiveNotifyProp] = strconv.FormatBool(active)
+		user.NotifyProps[model.AutoResponderMentionsNotifyProp] = strconv.FormatBool(mentions)
+
+		user, err := ss.User().Save(rctx, user)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, ss.User().PermanentDelete(rctx, user.Id)) })
+		return user
+	}
+
+	answering := save(true, true)
+	notActive := save(false, true)
+	noMentions := save(true, false)
+	deactivated := save(true, true)
+	deactivated.DeleteAt = model.GetMillis()
+	_, err := ss.User().Update(rctx, deactivated, true)
+	require.NoError(t, err)
+
+	usernames, err := ss.User().GetAutoResponderMentionUsernames()
+	require.NoError(t, err)
+	assert.Contains(t, usernames, answering.Username)
+	assert.NotContains(t, usernames, notActive.Username)
+	assert.NotContains(t, usernames, noMentions.Username)
+	assert.NotContains(t, usernames, deactivated.Username)
+}
+
 func testUserStoreGetProfilesByUsernames(t *testing.T, rctx request.CTX, ss store.Store) {
 	teamID := model.NewId()
 	team2Id := model.NewId()
@@ -4936,6 +4973,96 @@ func testUserStoreGetAllAfter(t *testing.T, rctx request.CTX, ss store.Store) {
 	})
 }
 
+func testUserStoreGetAllForFilteredExportAfter(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	u1, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewUsername(),
+		CreateAt: 1000,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u1.Id)) }()
+
+	u2, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewUsername(),
+		CreateAt: 3000,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u2.Id)) }()
+
+	u3, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewUsername(),
+		CreateAt: 3000,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u3.Id)) }()
+
+	for _, u := range []*model.User{u1, u2} {
+		_, nErr := ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: teamID, UserId: u.Id}, -1)
+		require.NoError(t, nErr)
+	}
+
+	userIDs := func(users []*model.User) []string {
+		ids := make([]string, 0, len(users))
+		for _, u := range users {
+			ids = append(ids, u.Id)
+		}
+		return ids
+	}
+
+	t.Run("incremental", func(t *testing.T) {
+		actual, err := ss.User().GetAllForFilteredExportAfter(10000, strings.Repeat("0", 26), model.BulkExportFilter{Since: 2000, Until: 4000})
+		require.NoError(t, err)
+
+		// Preferences changes aren't tracked, so every user is exported.
+		ids := userIDs(actual)
+		assert.Contains(t, ids, u1.Id)
+		assert.Contains(t, ids, u2.Id)
+		assert.Contains(t, ids, u3.Id)
+	})
+
+	t.Run("incremental and scoped to a team", func(t *testing.T) {
+		actual, err := ss.User().GetAllForFilteredExportAfter(10000, strings.Repeat("0", 26), model.BulkExportFilter{Since: 2000, Until: 4000, TeamIds: []string{teamID}})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{u1.Id, u2.Id}, userIDs(actual))
+	})
+
+	t.Run("scoped to a team", func(t *testing.T) {
+		actual, err := ss.User().GetAllForFilteredExportAfter(10000, strings.Repeat("0", 26), model.BulkExportFilter{TeamIds: []string{teamID}})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{u1.Id, u2.Id}, userIDs(actual))
+	})
+
+	t.Run("left a channel in the window", func(t *testing.T) {
+		channel, err := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      teamID,
+			DisplayName: "DisplayName",
+			Name:        "channel" + model.NewId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+		require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(u3.Id, channel.Id, 1000))
+		require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(u3.Id, channel.Id, 2500))
+
+		actual, err := ss.User().GetAllForFilteredExportAfter(10000, strings.Repeat("0", 26), model.BulkExportFilter{Since: 2000, ChannelIds: []string{channel.Id}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{u3.Id}, userIDs(actual))
+
+		actual, err = ss.User().GetAllForFilteredExportAfter(10000, strings.Repeat("0", 26), model.BulkExportFilter{Since: 3000, ChannelIds: []string{channel.Id}})
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+
+		actual, err = ss.User().GetAllForFilteredExportAfter(10000, strings.Repeat("0", 26), model.BulkExportFilter{ChannelIds: []string{channel.Id}})
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
 func testUserStoreGetUsersBatchForIndexing(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Set up all the objects needed
 	t1, err := ss.Team().Save(&model.Team{
@@ -6670,3 +6797,111 @@ func testMfaUsedTimestamps(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, err)
 	require.Equal(t, []int{1, 2, 3}, tss)
 }
+
+func testMfaRecoveryCodes(t *testing.T, rctx request.CTX, ss store.Store) {
+	u1, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u1.Id)) }()
+
+	count, err := ss.User().CountMfaRecoveryCodes(u1.Id)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	err = ss.User().StoreMfaRecoveryCodes(u1.Id, []string{"hash1", "hash2", "hash3"})
+	require.NoError(t, err)
+
+	count, err = ss.User().CountMfaRecoveryCodes(u1.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	used, err := ss.User().UseMfaRecoveryCode(u1.Id, "hash2")
+	require.NoError(t, err)
+	require.True(t, used)
+
+	// A code can only be used once.
+	used, err = ss.User().UseMfaRecoveryCode(u1.Id, "hash2")
+	require.NoError(t, err)
+	require.False(t, used)
+
+	used, err = ss.User().UseMfaRecoveryCode(model.NewId(), "hash1")
+	require.NoError(t, err)
+	require.False(t, used)
+
+	count, err = ss.User().CountMfaRecoveryCodes(u1.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// Storing new codes replaces the old ones.
+	err = ss.User().StoreMfaRecoveryCodes(u1.Id, []string{"hash4"})
+	require.NoError(t, err)
+
+	used, err = ss.User().UseMfaRecoveryCode(u1.Id, "hash1")
+	require.NoError(t, err)
+	require.False(t, used)
+
+	err = ss.User().StoreMfaRecoveryCodes(u1.Id, nil)
+	require.NoError(t, err)
+
+	count, err = ss.User().CountMfaRecoveryCodes(u1.Id)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func testGetGuestAccessReport(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	var guests []*model.User
+	for i := 0; i < 2; i++ {
+		guest, err := ss.User().Save(rctx, &model.User{
+			Username: fmt.Sprintf("guest_access_%d_%s", i, NewTestID()),
+			Email:    MakeEmail(),
+			Roles:    model.SystemGuestRoleId,
+		})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, guest.Id)) }()
+
+		_, err = ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: teamID, UserId: guest.Id}, -1)
+		require.NoError(t, err)
+		guests = append(guests, guest)
+	}
+
+	member, err := ss.User().Save(rctx, &model.User{Username: "guest_access_member_" + NewTestID(), Email: MakeEmail()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, member.Id)) }()
+	_, err = ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: teamID, UserId: member.Id}, -1)
+	require.NoError(t, err)
+
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   model.NewId(),
+		UserId:      guests[0].Id,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	filter := &model.GuestAccessReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{PageSize: 1},
+		Team:                 teamID,
+	}
+
+	reports, err := ss.User().GetGuestAccessReport(filter)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, guests[0].Id, reports[0].UserId)
+	assert.Equal(t, int64(1), reports[0].TeamCount)
+	assert.Equal(t, int64(1), reports[0].ChannelCount)
+
+	filter.FromColumnValue = reports[0].Username
+	reports, err = ss.User().GetGuestAccessReport(filter)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, guests[1].Id, reports[0].UserId)
+	assert.Equal(t, int64(0), reports[0].ChannelCount)
+
+	filter.FromColumnValue = reports[0].Username
+	reports, err = ss.User().GetGuestAccessReport(filter)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWebAuthnCredentialStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveGet", func(t *testing.T) { testWebAuthnCredentialSaveGet(t, rctx, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testWebAuthnCredentialGetForUser(t, rctx, ss) })
+	t.Run("UpdateSignCount", func(t *testing.T) { testWebAuthnCredentialUpdateSignCount(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testWebAuthnCredentialDelete(t, rctx, ss) })
+}
+
+func newWebAuthnCredential(userID string) *model.WebAuthnCredential {
+	return &model.WebAuthnCredential{
+		UserId:       userID,
+		CredentialId: model.NewRandomString(43),
+		PublicKey:    []byte{0xa5, 0x01, 0x02, 0x03, 0x26},
+		AAGUID:       "00000000-0000-0000-0000-000000000000",
+		Name:         "YubiKey",
+	}
+}
+
+func testWebAuthnCredentialSaveGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	credential, err := ss.WebAuthnCredential().Save(newWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+	assert.True(t, model.IsValidId(credential.Id))
+	assert.NotZero(t, credential.CreateAt)
+
+	got, err := ss.WebAuthnCredential().Get(credential.Id)
+	require.NoError(t, err)
+	assert.Equal(t, credential, got)
+
+	_, err = ss.WebAuthnCredential().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+
+	t.Run("duplicate credential ID", func(t *testing.T) {
+		duplicate := newWebAuthnCredential(model.NewId())
+		duplicate.CredentialId = credential.CredentialId
+
+		_, err := ss.WebAuthnCredential().Save(duplicate)
+		var cErr *store.ErrConflict
+		assert.ErrorAs(t, err, &cErr)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := newWebAuthnCredential(model.NewId())
+		invalid.PublicKey = nil
+
+		_, err := ss.WebAuthnCredential().Save(invalid)
+		var appErr *model.AppError
+		assert.ErrorAs(t, err, &appErr)
+	})
+}
+
+func testWebAuthnCredentialGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+
+	first := newWebAuthnCredential(userID)
+	first.CreateAt = 1000
+	_, err = ss.WebAuthnCredential().Save(first)
+	require.NoError(t, err)
+	second := newWebAuthnCredential(userID)
+	second.CreateAt = 2000
+	_, err = ss.WebAuthnCredential().Save(second)
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(newWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.WebAuthnCredential{first, second}, credentials)
+}
+
+func testWebAuthnCredentialUpdateSignCount(t *testing.T, rctx request.CTX, ss store.Store) {
+	credential, err := ss.WebAuthnCredential().Save(newWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.WebAuthnCredential().UpdateSignCount(credential.Id, 42, 1234))
+
+	got, err := ss.WebAuthnCredential().Get(credential.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), got.SignCount)
+	assert.Equal(t, int64(1234), got.LastUsedAt)
+
+	err = ss.WebAuthnCredential().UpdateSignCount(model.NewId(), 1, 1)
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+}
+
+func testWebAuthnCredentialDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	credential, err := ss.WebAuthnCredential().Save(newWebAuthnCredential(userID))
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(newWebAuthnCredential(userID))
+	require.NoError(t, err)
+	other, err := ss.WebAuthnCredential().Save(newWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.WebAuthnCredential().Delete(credential.Id))
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, ss.WebAuthnCredential().Delete(credential.Id), &nfErr)
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Len(t, credentials, 1)
+
+	require.NoError(t, ss.WebAuthnCredential().PermanentDeleteByUser(userID))
+	credentials, err = ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+
+	_, err = ss.WebAuthnCredential().Get(other.Id)
+	assert.NoError(t, err)
+}
@@ -63,6 +63,7 @@ func testWebhookStoreUpdateIncoming(t *testing.T, rctx request.CTX, ss store.Sto
 	previousUpdatedAt := o1.UpdateAt
 
 	o1.DisplayName = "TestHook"
+	o1.Template = "{{ .text }}"
 	time.Sleep(10 * time.Millisecond)
 
 	webhook, err := ss.Webhook().UpdateIncoming(o1)
@@ -71,6 +72,10 @@ func testWebhookStoreUpdateIncoming(t *testing.T, rctx request.CTX, ss store.Sto
 	require.NotEqual(t, webhook.UpdateAt, previousUpdatedAt, "should have updated the UpdatedAt of the hook")
 
 	require.Equal(t, "TestHook", webhook.DisplayName, "display name is not updated")
+
+	webhook, err = ss.Webhook().GetIncoming(o1.Id, false)
+	require.NoError(t, err)
+	require.Equal(t, "{{ .text }}", webhook.Template, "template is not updated")
 }
 
 func testWebhookStoreGetIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -564,9 +569,14 @@ func testWebhookStoreUpdateOutgoing(t *testing.T, rctx request.CTX, ss store.Sto
 
 	o1.Token = model.NewId()
 	o1.Username = "another-test-user-name"
+	o1.SigningSecret = model.NewOutgoingWebhookSigningSecret()
 
 	_, err := ss.Webhook().UpdateOutgoing(o1)
 	require.NoError(t, err)
+
+	o2, err := ss.Webhook().GetOutgoing(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, o1.SigningSecret, o2.SigningSecret)
 }
 
 func testWebhookStoreCountIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -20,6 +20,7 @@ type TimerLayer struct {
 	store.Store
 	Metrics                         einterfaces.MetricsInterface
 	AuditStore                      store.AuditStore
+	AuditLogStore                   store.AuditLogStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
@@ -38,12 +39,16 @@ type TimerLayer struct {
 	LinkMetadataStore               store.LinkMetadataStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
+	OutOfOfficeStore                store.OutOfOfficeStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	OutgoingWebhookDeliveryStore    store.OutgoingWebhookDeliveryStore
 	PluginStore                     store.PluginStore
+	PollStore                       store.PollStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
 	PostPersistentNotificationStore store.PostPersistentNotificationStore
 	PostPriorityStore               store.PostPriorityStore
+	PostReminderStore               store.PostReminderStore
 	PreferenceStore                 store.PreferenceStore
 	ProductNoticesStore             store.ProductNoticesStore
 	ReactionStore                   store.ReactionStore
@@ -64,6 +69,7 @@ type TimerLayer struct {
 	UserStore                       store.UserStore
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -71,6 +77,10 @@ func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) AuditLog() store.AuditLogStore {
+	return s.AuditLogStore
+}
+
 func (s *TimerLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -143,14 +153,26 @@ func (s *TimerLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) OutOfOffice() store.OutOfOfficeStore {
+	return s.OutOfOfficeStore
+}
+
 func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *TimerLayer) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	return s.OutgoingWebhookDeliveryStore
+}
+
 func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
 
+func (s *TimerLayer) Poll() store.PollStore {
+	return s.PollStore
+}
+
 func (s *TimerLayer) Post() store.PostStore {
 	return s.PostStore
 }
@@ -167,6 +189,10 @@ func (s *TimerLayer) PostPriority() store.PostPriorityStore {
 	return s.PostPriorityStore
 }
 
+func (s *TimerLayer) PostReminder() store.PostReminderStore {
+	return s.PostReminderStore
+}
+
 func (s *TimerLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -247,6 +273,10 @@ func (s *TimerLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
 
+func (s *TimerLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
 func (s *TimerLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -256,6 +286,11 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerAuditLogStore struct {
+	store.AuditLogStore
+	Root *TimerLayer
+}
+
 type TimerLayerBotStore struct {
 	store.BotStore
 	Root *TimerLayer
@@ -346,16 +381,31 @@ type TimerLayerOAuthStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerOutOfOfficeStore struct {
+	store.OutOfOfficeStore
+	Root *TimerLayer
+}
+
 type TimerLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *TimerLayer
 }
 
+type TimerLayerOutgoingWebhookDeliveryStore struct {
+	store.OutgoingWebhookDeliveryStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	store.PluginStore
 	Root *TimerLayer
 }
 
+type TimerLayerPollStore struct {
+	store.PollStore
+	Root *TimerLayer
+}
+
 type TimerLayerPostStore struct {
 	store.PostStore
 	Root *TimerLayer
@@ -376,6 +426,11 @@ type TimerLayerPostPriorityStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPostReminderStore struct {
+	store.PostReminderStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *TimerLayer
@@ -476,6 +531,11 @@ type TimerLayerUserTermsOfServiceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *TimerLayer
+}
+
 type TimerLayerWebhookStore struct {
 	store.WebhookStore
 	Root *TimerLayer
@@ -529,6 +589,54 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) error {
 	return err
 }
 
+func (s *TimerLayerAuditLogStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.AuditLogStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditLogStore) Save(log *model.AuditLog) (*model.AuditLog, error) {
+	start := time.Now()
+
+	result, err := s.AuditLogStore.Save(log)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditLogStore) Search(opts model.AuditLogSearchOptions) ([]*model.AuditLog, error) {
+	start := time.Now()
+
+	result, err := s.AuditLogStore.Search(opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.Search", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
 	start := time.Now()
 
@@ -1135,6 +1243,22 @@ func (s *TimerLayerChannelStore) GetByNamesIncludeDeleted(teamID string, names [
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetChannelActivityReport(filter *model.ChannelActivityReportOptions) ([]*model.ChannelActivityReport, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetChannelActivityReport(filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelActivityReport", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
 	start := time.Now()
 
@@ -2680,6 +2804,22 @@ func (s *TimerLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 	return result, err
 }
 
+func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftForExport(userID string, teamID string, filter model.BulkExportFilter) ([]*model.ChannelMemberHistoryForExport, error) {
+	start := time.Now()
+
+	result, err := s.ChannelMemberHistoryStore.GetChannelsLeftForExport(userID, teamID, filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetChannelsLeftForExport", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	start := time.Now()
 
@@ -5493,10 +5633,10 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
+func (s *TimerLayerOutOfOfficeStore) Delete(userID string) error {
 	start := time.Now()
 
-	err := s.OutgoingOAuthConnectionStore.DeleteConnection(c, id)
+	err := s.OutOfOfficeStore.Delete(userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5504,15 +5644,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.DeleteConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOutOfOfficeStore) Get(userID string) (*model.OutOfOfficeSchedule, error) {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.GetConnection(c, id)
+	result, err := s.OutOfOfficeStore.Get(userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5520,15 +5660,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOutOfOfficeStore) GetDue(now int64, limit int) ([]*model.OutOfOfficeSchedule, error) {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.GetConnections(c, filters)
+	result, err := s.OutOfOfficeStore.GetDue(now, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5536,15 +5676,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, f
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnections", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.GetDue", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOutOfOfficeStore) Save(schedule *model.OutOfOfficeSchedule) (*model.OutOfOfficeSchedule, error) {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(c, conn)
+	result, err := s.OutOfOfficeStore.Save(schedule)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5552,15 +5692,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, c
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.SaveConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(c, conn)
+	err := s.OutgoingOAuthConnectionStore.DeleteConnection(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5568,15 +5708,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.UpdateConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.DeleteConnection", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	result, err := s.OutgoingOAuthConnectionStore.GetConnection(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5584,15 +5724,15 @@ func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnection", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	result, err := s.OutgoingOAuthConnectionStore.GetConnections(c, filters)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5600,15 +5740,15 @@ func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnections", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	err := s.PluginStore.Delete(pluginID, key)
+	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(c, conn)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5616,15 +5756,15 @@ func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.SaveConnection", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) DeleteAllExpired() error {
+func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	err := s.PluginStore.DeleteAllExpired()
+	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(c, conn)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5632,15 +5772,15 @@ func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.UpdateConnection", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) Delete(id string, status string) (bool, error) {
 	start := time.Now()
 
-	err := s.PluginStore.DeleteAllForPlugin(PluginID)
+	result, err := s.OutgoingWebhookDeliveryStore.Delete(id, status)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5648,15 +5788,15 @@ func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.Delete", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) Get(id string) (*model.OutgoingWebhookDelivery, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.Get(pluginID, key)
+	result, err := s.OutgoingWebhookDeliveryStore.Get(id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5664,15 +5804,15 @@ func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) GetConsecutiveFailureCount(hookID string) (int64, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.List(pluginID, page, perPage)
+	result, err := s.OutgoingWebhookDeliveryStore.GetConsecutiveFailureCount(hookID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5680,15 +5820,15 @@ func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.GetConsecutiveFailureCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) GetForHook(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	result, err := s.OutgoingWebhookDeliveryStore.GetForHook(hookID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5696,15 +5836,15 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.GetForHook", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) GetPendingRetries(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+	result, err := s.OutgoingWebhookDeliveryStore.GetPendingRetries(now, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5712,15 +5852,15 @@ func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.GetPendingRetries", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsPostCount(options)
+	result, err := s.OutgoingWebhookDeliveryStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5728,15 +5868,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.PermanentDeleteBatch", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) ResolveFailures(hookID string, resolvedAt int64) error {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	err := s.OutgoingWebhookDeliveryStore.ResolveFailures(hookID, resolvedAt)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5744,15 +5884,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.ResolveFailures", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) Save(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+	result, err := s.OutgoingWebhookDeliveryStore.Save(delivery)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5760,30 +5900,31 @@ func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) ClearCaches() {
+func (s *TimerLayerOutgoingWebhookDeliveryStore) UpdateStatus(id string, oldStatus string, newStatus string) (bool, error) {
 	start := time.Now()
 
-	s.PostStore.ClearCaches()
+	result, err := s.OutgoingWebhookDeliveryStore.UpdateStatus(id, oldStatus, newStatus)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingWebhookDeliveryStore.UpdateStatus", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
-	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5791,15 +5932,15 @@ func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.Get(ctx, id, opts, userID, sanitizeOptions)
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5807,15 +5948,15 @@ func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.Get
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 	start := time.Now()
 
-	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	err := s.PluginStore.Delete(pluginID, key)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5823,15 +5964,15 @@ func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetEditHistoryForPost(postID string) ([]*model.Post, error) {
+func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 	start := time.Now()
 
-	result, err := s.PostStore.GetEditHistoryForPost(postID)
+	err := s.PluginStore.DeleteAllExpired()
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5839,31 +5980,31 @@ func (s *TimerLayerPostStore) GetEditHistoryForPost(postID string) ([]*model.Pos
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEditHistoryForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 	start := time.Now()
 
-	result := s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+	err := s.PluginStore.DeleteAllForPlugin(PluginID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
 	}
-	return result
+	return err
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
+	result, err := s.PluginStore.Get(pluginID, key)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5871,15 +6012,15 @@ func (s *TimerLayerPostStore) GetFlaggedPosts(userID string, offset int, limit i
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+	result, err := s.PluginStore.List(pluginID, page, perPage)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5887,15 +6028,15 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5903,31 +6044,31 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetMaxPostSize() int {
+func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	start := time.Now()
 
-	result := s.PostStore.GetMaxPostSize()
+	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
 	}
-	return result
+	return result, err
 }
 
-func (s *TimerLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
+func (s *TimerLayerPollStore) Close(postID string, closeAt int64) error {
 	start := time.Now()
 
-	result, err := s.PostStore.GetNthRecentPostTime(n)
+	err := s.PollStore.Close(postID, closeAt)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5935,15 +6076,15 @@ func (s *TimerLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetNthRecentPostTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Close", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
+func (s *TimerLayerPollStore) Get(postID string) (*model.Poll, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetOldest()
+	result, err := s.PollStore.Get(postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5951,15 +6092,15 @@ func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+func (s *TimerLayerPollStore) GetVotes(postID string) ([]*model.PollVote, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetOldestEntityCreationTime()
+	result, err := s.PollStore.GetVotes(postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5967,7 +6108,326 @@ func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldestEntityCreationTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.GetVotes", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	start := time.Now()
+
+	result, err := s.PollStore.Save(poll)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) Vote(postID string, userID string, options []int) error {
+	start := time.Now()
+
+	err := s.PollStore.Vote(postID, userID, options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Vote", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsPostCount(options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) ClearCaches() {
+	start := time.Now()
+
+	s.PostStore.ClearCaches()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
+	}
+}
+
+func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+	start := time.Now()
+
+	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.Get(ctx, id, opts, userID, sanitizeOptions)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetAllRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetAllRepliesForExport(parentID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetAllRepliesForExport", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetDirectPostParentsForFilteredExportAfter(limit int, afterID string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.DirectPostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetDirectPostParentsForFilteredExportAfter(limit, afterID, includeArchivedChannels, filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForFilteredExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetEditHistoryForPost(postID string) ([]*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetEditHistoryForPost(postID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEditHistoryForPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+	start := time.Now()
+
+	result := s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
+	}
+	return result
+}
+
+func (s *TimerLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetMaxPostSize() int {
+	start := time.Now()
+
+	result := s.PostStore.GetMaxPostSize()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
+	}
+	return result
+}
+
+func (s *TimerLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetNthRecentPostTime(n)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetNthRecentPostTime", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetOldest()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetOldestEntityCreationTime()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldestEntityCreationTime", success, elapsed)
 	}
 	return result, err
 }
@@ -5988,6 +6448,22 @@ func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetParentsForFilteredExportAfter(limit int, afterID string, includeArchivedChannels bool, filter model.BulkExportFilter) ([]*model.PostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetParentsForFilteredExportAfter(limit, afterID, includeArchivedChannels, filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForFilteredExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error) {
 	start := time.Now()
 
@@ -6052,10 +6528,10 @@ func (s *TimerLayerPostStore) GetPostReminderMetadata(postID string) (*store.Pos
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder, error) {
+func (s *TimerLayerPostStore) GetPostVolumeReport(filter *model.PostVolumeReportOptions) ([]*model.PostVolumeReport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetPostReminders(now)
+	result, err := s.PostStore.GetPostVolumeReport(filter)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6063,7 +6539,7 @@ func (s *TimerLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostReminders", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostVolumeReport", success, elapsed)
 	}
 	return result, err
 }
@@ -6422,23 +6898,7 @@ func (s *TimerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post,
 func (s *TimerLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.Search(teamID, userID, params)
-
-	elapsed := float64(time.Since(start)) / float64(time.Second)
-	if s.Root.Metrics != nil {
-		success := "false"
-		if err == nil {
-			success = "true"
-		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
-	}
-	return result, err
-}
-
-func (s *TimerLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
-	start := time.Now()
-
-	result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
+	result, err := s.PostStore.Search(teamID, userID, params)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6446,15 +6906,15 @@ func (s *TimerLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) SetPostReminder(reminder *model.PostReminder) error {
+func (s *TimerLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
 	start := time.Now()
 
-	err := s.PostStore.SetPostReminder(reminder)
+	result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6462,9 +6922,9 @@ func (s *TimerLayerPostStore) SetPostReminder(reminder *model.PostReminder) erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SetPostReminder", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostsForUser", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
 func (s *TimerLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
@@ -6707,6 +7167,118 @@ func (s *TimerLayerPostPriorityStore) GetForPosts(ids []string) ([]*model.PostPr
 	return result, err
 }
 
+func (s *TimerLayerPostReminderStore) Delete(postID string, userID string) error {
+	start := time.Now()
+
+	err := s.PostReminderStore.Delete(postID, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReminderStore) DeleteDeliveredBefore(before int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostReminderStore.DeleteDeliveredBefore(before)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.DeleteDeliveredBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReminderStore) GetDue(now int64, limit int) ([]*model.PostReminder, error) {
+	start := time.Now()
+
+	result, err := s.PostReminderStore.GetDue(now, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.GetDue", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReminderStore) GetPendingForUser(userID string) ([]*model.PostReminder, error) {
+	start := time.Now()
+
+	result, err := s.PostReminderStore.GetPendingForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.GetPendingForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReminderStore) MarkDelivered(postID string, userID string, deliveredAt int64) error {
+	start := time.Now()
+
+	err := s.PostReminderStore.MarkDelivered(postID, userID, deliveredAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.MarkDelivered", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReminderStore) Save(reminder *model.PostReminder) error {
+	start := time.Now()
+
+	err := s.PostReminderStore.Save(reminder)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.Save", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReminderStore) Snooze(postID string, userID string, targetTime int64) error {
+	start := time.Now()
+
+	err := s.PostReminderStore.Snooze(postID, userID, targetTime)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.Snooze", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
 	start := time.Now()
 
@@ -10591,6 +11163,22 @@ func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, erro
 	return result, err
 }
 
+func (s *TimerLayerUserStore) CountMfaRecoveryCodes(userID string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.CountMfaRecoveryCodes(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.CountMfaRecoveryCodes", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) DeactivateGuests() ([]string, error) {
 	start := time.Now()
 
@@ -10671,6 +11259,22 @@ func (s *TimerLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.U
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetAllForFilteredExportAfter(limit int, afterID string, filter model.BulkExportFilter) ([]*model.User, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetAllForFilteredExportAfter(limit, afterID, filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllForFilteredExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 	start := time.Now()
 
@@ -10751,6 +11355,22 @@ func (s *TimerLayerUserStore) GetAnyUnreadPostCountForChannel(userID string, cha
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetAutoResponderMentionUsernames() ([]string, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetAutoResponderMentionUsernames()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAutoResponderMentionUsernames", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
 	start := time.Now()
 
@@ -10895,6 +11515,22 @@ func (s *TimerLayerUserStore) GetForLogin(loginID string, allowSignInWithUsernam
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetGuestAccessReport(filter *model.GuestAccessReportOptions) ([]*model.GuestAccessReport, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetGuestAccessReport(filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetGuestAccessReport", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 	start := time.Now()
 
@@ -11564,6 +12200,22 @@ func (s *TimerLayerUserStore) SearchWithoutTeam(term string, options *model.User
 	return result, err
 }
 
+func (s *TimerLayerUserStore) StoreMfaRecoveryCodes(userID string, codeHashes []string) error {
+	start := time.Now()
+
+	err := s.UserStore.StoreMfaRecoveryCodes(userID, codeHashes)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.StoreMfaRecoveryCodes", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserStore) StoreMfaUsedTimestamps(userID string, ts []int) error {
 	start := time.Now()
 
@@ -11740,6 +12392,22 @@ func (s *TimerLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
 	return result, err
 }
 
+func (s *TimerLayerUserStore) UseMfaRecoveryCode(userID string, codeHash string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.UseMfaRecoveryCode(userID, codeHash)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UseMfaRecoveryCode", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
 	start := time.Now()
 
@@ -11964,6 +12632,102 @@ func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 	return result, err
 }
 
+func (s *TimerLayerWebAuthnCredentialStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Save(credential)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) UpdateSignCount(id string, signCount int64, lastUsedAt int64) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.UpdateSignCount(id, signCount, lastUsedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.UpdateSignCount", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
 	start := time.Now()
 
@@ -12437,6 +13201,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	}
 
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditLogStore = &TimerLayerAuditLogStore{AuditLogStore: childStore.AuditLog(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
@@ -12455,12 +13220,16 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutOfOfficeStore = &TimerLayerOutOfOfficeStore{OutOfOfficeStore: childStore.OutOfOffice(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.OutgoingWebhookDeliveryStore = &TimerLayerOutgoingWebhookDeliveryStore{OutgoingWebhookDeliveryStore: childStore.OutgoingWebhookDelivery(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PollStore = &TimerLayerPollStore{PollStore: childStore.Poll(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
 	newStore.PostPersistentNotificationStore = &TimerLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
 	newStore.PostPriorityStore = &TimerLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PostReminderStore = &TimerLayerPostReminderStore{PostReminderStore: childStore.PostReminder(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &TimerLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
@@ -12481,6 +13250,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.UserStore = &TimerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &TimerLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
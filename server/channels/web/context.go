@@ -641,6 +641,18 @@ func (c *Context) RequireHookId() *Context {
 	return c
 }
 
+func (c *Context) RequireDeliveryId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.DeliveryId) {
+		c.SetInvalidURLParam("delivery_id")
+	}
+
+	return c
+}
+
 func (c *Context) RequireCommandId() *Context {
 	if c.Err != nil {
 		return c
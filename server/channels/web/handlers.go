@@ -88,6 +88,9 @@ type Handler struct {
 	IsLocal                   bool
 	DisableWhenBusy           bool
 	FileAPI                   bool
+	// ReadOnly is set on the handlers of non-GET requests that do not write, such as searches,
+	// so that they do not send the next reads of the user to the master.
+	ReadOnly bool
 
 	cspShaDirective string
 }
@@ -307,9 +310,9 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			c.AppContext = c.AppContext.WithSession(session)
 		}
 
-		// Rate limit by UserID
+		// Rate limit by UserID, or by the policy targeting the token or bot of the session
 		if c.App.Srv().RateLimiter != nil {
-			rateLimitExceeded = c.App.Srv().RateLimiter.UserIdRateLimit(c.AppContext.Session().UserId, w)
+			rateLimitExceeded = c.App.Srv().RateLimiter.SessionRateLimit(c.AppContext.Session(), r, w)
 			if rateLimitExceeded {
 				return
 			}
@@ -382,8 +385,19 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Reads that follow a write by the same user are served by the master until the replicas
+	// have caught up, so that users always see their own changes.
+	readYourWrites := *c.App.Config().SqlSettings.ReadYourWritesWindowSeconds > 0 && c.AppContext.Session().UserId != ""
+	if c.Err == nil && readYourWrites && c.App.Srv().Store().ShouldReadFromMaster(c.AppContext.Session().UserId) {
+		c.AppContext = app.RequestContextWithMaster(c.AppContext)
+	}
+
 	if c.Err == nil {
 		h.HandleFunc(c, w, r)
+
+		if c.Err == nil && readYourWrites && !h.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			c.App.Srv().Store().RecordUserWrite(c.AppContext.Session().UserId)
+		}
 	}
 
 	// Handle errors that have occurred
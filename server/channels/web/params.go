@@ -40,6 +40,7 @@ type Params struct {
 	PluginId                  string
 	CommandId                 string
 	HookId                    string
+	DeliveryId                string
 	ReportId                  string
 	EmojiId                   string
 	AppId                     string
@@ -144,6 +145,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 	}
 	params.CommandId = props["command_id"]
 	params.HookId = props["hook_id"]
+	params.DeliveryId = props["delivery_id"]
 	params.ReportId = props["report_id"]
 	params.EmojiId = props["emoji_id"]
 	params.AppId = props["app_id"]
@@ -63,7 +63,7 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	if mediaType == "application/x-www-form-urlencoded" {
 		payload := strings.NewReader(r.FormValue("payload"))
 
-		incomingWebhookPayload, appErr = decodePayload(payload)
+		incomingWebhookPayload, appErr = decodePayload(c, id, payload)
 		if appErr != nil {
 			c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.decode.app_error", errCtx, "", http.StatusBadRequest).Wrap(appErr)
 			return
@@ -79,7 +79,7 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	} else {
-		incomingWebhookPayload, appErr = decodePayload(r.Body)
+		incomingWebhookPayload, appErr = decodePayload(c, id, r.Body)
 		if appErr != nil {
 			c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.decode.app_error", errCtx, "", appErr.StatusCode).Wrap(appErr)
 			return
@@ -117,8 +117,8 @@ func commandWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
-func decodePayload(payload io.Reader) (*model.IncomingWebhookRequest, *model.AppError) {
-	incomingWebhookPayload, decodeError := model.IncomingWebhookRequestFromJSON(payload)
+func decodePayload(c *Context, hookID string, payload io.Reader) (*model.IncomingWebhookRequest, *model.AppError) {
+	incomingWebhookPayload, decodeError := c.App.DecodeIncomingWebhookPayload(hookID, payload)
 
 	if decodeError != nil {
 		return nil, decodeError
@@ -57,6 +57,22 @@ var GlobalRelayZipExportCmd = &cobra.Command{
 	RunE:    buildExportCmdF("globalrelay-zip"),
 }
 
+var MboxExportCmd = &cobra.Command{
+	Use:     "mbox",
+	Short:   "Export data from Mattermost as EML messages in MBOX files",
+	Long:    "Export data from Mattermost into a zip file containing an MBOX file of EML messages for each channel",
+	Example: "export mbox --exportFrom=12345",
+	RunE:    buildExportCmdF("mbox"),
+}
+
+var JsonlExportCmd = &cobra.Command{
+	Use:     "jsonl",
+	Short:   "Export data from Mattermost in JSONL format",
+	Long:    "Export data from Mattermost as line-delimited JSON events, including channel joins and leaves",
+	Example: "export jsonl --exportFrom=12345",
+	RunE:    buildExportCmdF("jsonl"),
+}
+
 var BulkExportCmd = &cobra.Command{
 	Use:     "bulk [file]",
 	Short:   "Export bulk data.",
@@ -80,16 +96,28 @@ func init() {
 	GlobalRelayZipExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
 	GlobalRelayZipExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
 
+	MboxExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
+	MboxExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
+
+	JsonlExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
+	JsonlExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
+
 	BulkExportCmd.Flags().Bool("all-teams", true, "Export all teams from the server.")
 	BulkExportCmd.Flags().Bool("with-archived-channels", false, "Also exports archived channels.")
 	BulkExportCmd.Flags().Bool("with-profile-pictures", false, "Also exports profile pictures.")
 	BulkExportCmd.Flags().Bool("attachments", false, "Also export file attachments.")
 	BulkExportCmd.Flags().Bool("archive", false, "Outputs a single archive file.")
+	BulkExportCmd.Flags().Int64("since", 0, "Only export the data changed after this timestamp, expressed in milliseconds since the unix epoch.")
+	BulkExportCmd.Flags().Int64("until", 0, "Only export the data changed up to this timestamp, expressed in milliseconds since the unix epoch. Requires --since.")
+	BulkExportCmd.Flags().StringSlice("team", nil, "Only export the given teams, by name or ID.")
+	BulkExportCmd.Flags().StringSlice("channel", nil, "Only export the given channels, by ID.")
 
 	ExportCmd.AddCommand(ScheduleExportCmd)
 	ExportCmd.AddCommand(CsvExportCmd)
 	ExportCmd.AddCommand(ActianceExportCmd)
 	ExportCmd.AddCommand(GlobalRelayZipExportCmd)
+	ExportCmd.AddCommand(MboxExportCmd)
+	ExportCmd.AddCommand(JsonlExportCmd)
 	ExportCmd.AddCommand(BulkExportCmd)
 
 	RootCmd.AddCommand(ExportCmd)
@@ -246,6 +274,38 @@ func bulkExportCmdF(command *cobra.Command, args []string) error {
 		return errors.Wrap(err, "with-profile-pictures flag error")
 	}
 
+	since, err := command.Flags().GetInt64("since")
+	if err != nil {
+		return errors.Wrap(err, "since flag error")
+	}
+
+	until, err := command.Flags().GetInt64("until")
+	if err != nil {
+		return errors.Wrap(err, "until flag error")
+	}
+	if until != 0 && until <= since {
+		return errors.New("--until must be later than --since.")
+	}
+
+	teamArgs, err := command.Flags().GetStringSlice("team")
+	if err != nil {
+		return errors.Wrap(err, "team flag error")
+	}
+
+	channelIDs, err := command.Flags().GetStringSlice("channel")
+	if err != nil {
+		return errors.Wrap(err, "channel flag error")
+	}
+
+	teamIDs := make([]string, 0, len(teamArgs))
+	for _, teamArg := range teamArgs {
+		team := getTeamFromTeamArg(a, teamArg)
+		if team == nil {
+			return errors.Errorf("Unable to find team '%s'", teamArg)
+		}
+		teamIDs = append(teamIDs, team.Id)
+	}
+
 	fileWriter, err := os.Create(args[0])
 	if err != nil {
 		return err
@@ -262,6 +322,10 @@ func bulkExportCmdF(command *cobra.Command, args []string) error {
 	opts.CreateArchive = archive
 	opts.IncludeArchivedChannels = withArchivedChannels
 	opts.IncludeProfilePictures = includeProfilePictures
+	opts.Since = since
+	opts.Until = until
+	opts.TeamIds = teamIDs
+	opts.ChannelIds = channelIDs
 	if err := a.BulkExport(rctx, fileWriter, filepath.Dir(outPath), nil /* nil job since it's spawned from CLI */, opts); err != nil {
 		CommandPrintErrorln(err.Error())
 		return err
@@ -270,6 +334,8 @@ func bulkExportCmdF(command *cobra.Command, args []string) error {
 	auditRec := a.MakeAuditRecord(rctx, "bulkExport", audit.Success)
 	auditRec.AddMeta("all_teams", allTeams)
 	auditRec.AddMeta("file", args[0])
+	auditRec.AddMeta("since", since)
+	auditRec.AddMeta("until", until)
 	a.LogAuditRec(rctx, auditRec, nil)
 
 	return nil
@@ -80,6 +80,10 @@ type Client interface {
 	CreateUserAccessToken(ctx context.Context, userID, description string) (*model.UserAccessToken, *model.Response, error)
 	RevokeUserAccessToken(ctx context.Context, tokenID string) (*model.Response, error)
 	GetUserAccessTokensForUser(ctx context.Context, userID string, page, perPage int) ([]*model.UserAccessToken, *model.Response, error)
+	GetWebAuthnCredentials(ctx context.Context, userID string) ([]*model.WebAuthnCredential, *model.Response, error)
+	RevokeWebAuthnCredential(ctx context.Context, userID, credentialID string) (*model.Response, error)
+	SearchAuditLogs(ctx context.Context, opts model.AuditLogSearchOptions) ([]*model.AuditLog, *model.Response, error)
+	StartBatchReportExport(ctx context.Context, reportType string, options *model.ReportExportOptions) (*model.Response, error)
 	ConvertUserToBot(ctx context.Context, userID string) (*model.Bot, *model.Response, error)
 	ConvertBotToUser(ctx context.Context, userID string, userPatch *model.UserPatch, setSystemAdmin bool) (*model.User, *model.Response, error)
 	PromoteGuestToUser(ctx context.Context, userID string) (*model.Response, error)
@@ -97,6 +101,9 @@ type Client interface {
 	PatchConfig(context.Context, *model.Config) (*model.Config, *model.Response, error)
 	ReloadConfig(ctx context.Context) (*model.Response, error)
 	MigrateConfig(ctx context.Context, from, to string) (*model.Response, error)
+	GetConfigHistory(ctx context.Context, page, perPage int) ([]*model.ConfigVersion, *model.Response, error)
+	GetConfigVersionDiff(ctx context.Context, fromVersionID, toVersionID string) ([]*model.ConfigVersionDiff, *model.Response, error)
+	RollbackConfig(ctx context.Context, versionID string) (*model.Config, *model.Response, error)
 	SyncLdap(ctx context.Context, includeRemovedMembers bool) (*model.Response, error)
 	MigrateIdLdap(ctx context.Context, toAttribute string) (*model.Response, error)
 	GetUsers(ctx context.Context, page, perPage int, etag string) ([]*model.User, *model.Response, error)
@@ -140,6 +147,7 @@ type Client interface {
 	GetIncomingWebhooksForTeam(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.IncomingWebhook, *model.Response, error)
 	GetIncomingWebhook(ctx context.Context, hookID string, etag string) (*model.IncomingWebhook, *model.Response, error)
 	DeleteIncomingWebhook(ctx context.Context, hookID string) (*model.Response, error)
+	DryRunIncomingWebhookTemplate(ctx context.Context, hookID string, dryRun *model.IncomingWebhookTemplateDryRunRequest) (*model.IncomingWebhookRequest, *model.Response, error)
 	CreateOutgoingWebhook(ctx context.Context, hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.Response, error)
 	UpdateOutgoingWebhook(ctx context.Context, hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.Response, error)
 	GetOutgoingWebhooks(ctx context.Context, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
@@ -147,7 +155,12 @@ type Client interface {
 	GetOutgoingWebhooksForChannel(ctx context.Context, channelID string, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
 	GetOutgoingWebhooksForTeam(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
 	RegenOutgoingHookToken(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
+	RegenOutgoingHookSigningSecret(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
+	RemoveOutgoingHookSigningSecret(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
+	EnableOutgoingHook(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
 	DeleteOutgoingWebhook(ctx context.Context, hookID string) (*model.Response, error)
+	GetOutgoingWebhookDeliveries(ctx context.Context, hookID string, status string, page int, perPage int) ([]*model.OutgoingWebhookDelivery, *model.Response, error)
+	ReplayOutgoingWebhookDelivery(ctx context.Context, hookID string, deliveryID string) (*model.OutgoingWebhookDelivery, *model.Response, error)
 	ListExports(ctx context.Context) ([]string, *model.Response, error)
 	DeleteExport(ctx context.Context, name string) (*model.Response, error)
 	DownloadExport(ctx context.Context, name string, wr io.Writer, offset int64) (int64, *model.Response, error)
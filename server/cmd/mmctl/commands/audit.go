@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Management of audit logs",
+}
+
+var AuditSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search the audit logs",
+	Long: `Search the audit logs saved to the database, most recent first.
+Audit logs are only saved to the database when ExperimentalAuditSettings.DatabaseEnabled is set.`,
+	Example: `  audit search --actor john.doe
+  audit search --event patchChannel --object-type channel --object-id 4xp9fdt7pbgium38k5fiuzqbde
+  audit search --since 2024-01-01T00:00:00+00:00 --until 2024-01-08T00:00:00+00:00 --per-page 200`,
+	Args: cobra.NoArgs,
+	RunE: withClient(auditSearchCmdF),
+}
+
+func init() {
+	AuditSearchCmd.Flags().String("actor", "", "Only show the events of this user, by username, email or id")
+	AuditSearchCmd.Flags().String("event", "", "Only show the events with this name")
+	AuditSearchCmd.Flags().String("object-type", "", "Only show the events on this type of object")
+	AuditSearchCmd.Flags().String("object-id", "", "Only show the events on the object with this id")
+	AuditSearchCmd.Flags().String("since", "", "Only show the events that happened after this time (ISO 8601)")
+	AuditSearchCmd.Flags().String("until", "", "Only show the events that happened before this time (ISO 8601)")
+	AuditSearchCmd.Flags().Int("page", 0, "Page number to fetch")
+	AuditSearchCmd.Flags().Int("per-page", model.AuditLogSearchDefaultPerPage, fmt.Sprintf("Number of events to fetch per page, up to %d", model.AuditLogSearchMaxPerPage))
+
+	AuditCmd.AddCommand(
+		AuditSearchCmd,
+	)
+
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func auditSearchCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	opts := model.AuditLogSearchOptions{}
+	opts.EventName, _ = cmd.Flags().GetString("event")
+	opts.ObjectType, _ = cmd.Flags().GetString("object-type")
+	opts.ObjectId, _ = cmd.Flags().GetString("object-id")
+	opts.Page, _ = cmd.Flags().GetInt("page")
+	opts.PerPage, _ = cmd.Flags().GetInt("per-page")
+
+	// Actors aren't always users, so fall back to the given value when no user matches.
+	if actor, _ := cmd.Flags().GetString("actor"); actor != "" {
+		opts.ActorId = actor
+		if user := getUserFromUserArg(c, actor); user != nil {
+			opts.ActorId = user.Id
+		}
+	}
+
+	for flag, value := range map[string]*int64{"since": &opts.StartTime, "until": &opts.EndTime} {
+		arg, _ := cmd.Flags().GetString(flag)
+		if arg == "" {
+			continue
+		}
+		parsed, err := time.Parse(ISO8601Layout, arg)
+		if err != nil {
+			return fmt.Errorf("invalid %s time %q", flag, arg)
+		}
+		*value = model.GetMillisForTime(parsed)
+	}
+
+	logs, _, err := c.SearchAuditLogs(context.TODO(), opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to search the audit logs")
+	}
+
+	if len(logs) == 0 {
+		printer.Print("No audit logs found")
+		return nil
+	}
+
+	tpl := template.Must(template.New("").Funcs(template.FuncMap{"millis": formatMillis}).
+		Parse("{{millis .CreateAt}} {{.EventName}} ({{.Status}}) by {{.ActorId}}{{if .ObjectType}} on {{.ObjectType}} {{.ObjectId}}{{end}}"))
+	for _, log := range logs {
+		printer.PrintPreparedT(tpl, log)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestAuditSearchCmd() {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("actor", "", "")
+		cmd.Flags().String("event", "", "")
+		cmd.Flags().String("object-type", "", "")
+		cmd.Flags().String("object-id", "", "")
+		cmd.Flags().String("since", "", "")
+		cmd.Flags().String("until", "", "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", model.AuditLogSearchDefaultPerPage, "")
+		return cmd
+	}
+
+	s.Run("Search the audit logs with filters", func() {
+		printer.Clean()
+
+		logs := []*model.AuditLog{
+			{Id: model.NewId(), CreateAt: 1700000060000, EventName: "patchChannel", Status: "success", ActorId: model.NewId(), ObjectType: "channel", ObjectId: model.NewId()},
+			{Id: model.NewId(), CreateAt: 1700000000000, EventName: "patchChannel", Status: "fail", ActorId: model.NewId(), ObjectType: "channel", ObjectId: model.NewId()},
+		}
+
+		cmd := newCmd()
+		_ = cmd.Flags().Set("event", "patchChannel")
+		_ = cmd.Flags().Set("object-type", "channel")
+		_ = cmd.Flags().Set("since", "2023-11-14T00:00:00+00:00")
+		_ = cmd.Flags().Set("per-page", "10")
+
+		s.client.
+			EXPECT().
+			SearchAuditLogs(context.TODO(), model.AuditLogSearchOptions{
+				EventName:  "patchChannel",
+				ObjectType: "channel",
+				StartTime:  1699920000000,
+				PerPage:    10,
+			}).
+			Return(logs, &model.Response{}, nil).
+			Times(1)
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(logs[0], printer.GetLines()[0])
+		s.Require().Equal(logs[1], printer.GetLines()[1])
+		s.Require().Empty(printer.GetErrorLines())
+	})
+
+	s.Run("Search the audit logs of a user", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Email: "user@example.com"}
+
+		cmd := newCmd()
+		_ = cmd.Flags().Set("actor", user.Email)
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			SearchAuditLogs(context.TODO(), model.AuditLogSearchOptions{
+				ActorId: user.Id,
+				PerPage: model.AuditLogSearchDefaultPerPage,
+			}).
+			Return([]*model.AuditLog{}, &model.Response{}, nil).
+			Times(1)
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No audit logs found", printer.GetLines()[0])
+	})
+
+	s.Run("Invalid time", func() {
+		printer.Clean()
+
+		cmd := newCmd()
+		_ = cmd.Flags().Set("until", "yesterday")
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, `invalid until time "yesterday"`)
+	})
+
+	s.Run("Fail to search the audit logs", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			SearchAuditLogs(context.TODO(), model.AuditLogSearchOptions{PerPage: model.AuditLogSearchDefaultPerPage}).
+			Return(nil, &model.Response{StatusCode: http.StatusForbidden}, errors.New("mock error")).
+			Times(1)
+
+		err := auditSearchCmdF(s.client, newCmd(), []string{})
+		s.Require().EqualError(err, "failed to search the audit logs: mock error")
+	})
+}
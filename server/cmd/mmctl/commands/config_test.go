@@ -893,6 +893,141 @@ func (s *MmctlUnitTestSuite) TestConfigMigrateCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestConfigHistoryCmd() {
+	s.Run("Should list the configuration versions", func() {
+		printer.Clean()
+		versions := []*model.ConfigVersion{
+			{Id: model.NewId(), CreateAt: 1700000000000, Author: model.NewId(), Active: true},
+			{Id: model.NewId(), CreateAt: 1600000000000},
+		}
+
+		s.client.
+			EXPECT().
+			GetConfigHistory(context.TODO(), 1, 2).
+			Return(versions, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 2, "")
+
+		err := configHistoryCmdF(s.client, cmd, []string{})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(versions[0], printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Should fail on error when getting the history", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetConfigHistory(context.TODO(), 0, DefaultPageSize).
+			Return(nil, &model.Response{StatusCode: http.StatusNotImplemented}, errors.New("some-error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", DefaultPageSize, "")
+
+		err := configHistoryCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestConfigDiffCmd() {
+	from := model.NewId()
+	to := model.NewId()
+
+	s.Run("Should show the differences between two versions", func() {
+		printer.Clean()
+		diffs := []*model.ConfigVersionDiff{
+			{Path: "ServiceSettings.SiteURL", BaseVal: "http://old", ActualVal: "http://new"},
+		}
+
+		s.client.
+			EXPECT().
+			GetConfigVersionDiff(context.TODO(), from, to).
+			Return(diffs, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{from, to})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(diffs[0], printer.GetLines()[0])
+	})
+
+	s.Run("Should compare against the configuration in use", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetConfigVersionDiff(context.TODO(), from, "").
+			Return([]*model.ConfigVersionDiff{}, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{from})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No differences found", printer.GetLines()[0])
+	})
+
+	s.Run("Should fail on error when comparing versions", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetConfigVersionDiff(context.TODO(), from, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("some-error")).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{from})
+		s.Require().Error(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestConfigRollbackCmd() {
+	versionID := model.NewId()
+
+	s.Run("Should roll back the configuration", func() {
+		printer.Clean()
+		newConfig := &model.Config{}
+
+		s.client.
+			EXPECT().
+			RollbackConfig(context.TODO(), versionID).
+			Return(newConfig, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := configRollbackCmdF(s.client, cmd, []string{versionID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(newConfig, printer.GetLines()[0])
+	})
+
+	s.Run("Should fail on error when rolling back", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			RollbackConfig(context.TODO(), versionID).
+			Return(nil, &model.Response{StatusCode: http.StatusBadRequest}, errors.New("some-error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := configRollbackCmdF(s.client, cmd, []string{versionID})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
 func TestCloudRestricted(t *testing.T) {
 	cfg := &model.Config{
 		ServiceSettings: model.ServiceSettings{
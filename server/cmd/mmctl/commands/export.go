@@ -5,9 +5,12 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -103,6 +106,11 @@ func init() {
 	ExportCreateCmd.Flags().Bool("include-archived-channels", false, "Include archived channels in the export file.")
 	ExportCreateCmd.Flags().Bool("include-profile-pictures", false, "Include profile pictures in the export file.")
 	ExportCreateCmd.Flags().Bool("no-roles-and-schemes", false, "Exclude roles and custom permission schemes from the export file.")
+	ExportCreateCmd.Flags().Int64("since", 0, "Only export the data changed after this timestamp, expressed in milliseconds since the unix epoch.")
+	ExportCreateCmd.Flags().Int64("until", 0, "Only export the data changed up to this timestamp, expressed in milliseconds since the unix epoch.")
+	ExportCreateCmd.Flags().Bool("incremental", false, "Only export the data changed since the last successful incremental export of the same teams and channels.")
+	ExportCreateCmd.Flags().StringSlice("team", nil, "Only export the given teams, by name or ID.")
+	ExportCreateCmd.Flags().StringSlice("channel", nil, "Only export the given channels, in the team:channel or channel ID format.")
 
 	ExportDownloadCmd.Flags().Bool("resume", false, "Set to true to resume an export download.")
 	_ = ExportDownloadCmd.Flags().MarkHidden("resume")
@@ -154,6 +162,51 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 		data["include_profile_pictures"] = "true"
 	}
 
+	since, _ := command.Flags().GetInt64("since")
+	until, _ := command.Flags().GetInt64("until")
+	incremental, _ := command.Flags().GetBool("incremental")
+	if incremental && since > 0 {
+		return errors.New("the --incremental and --since flags cannot be used together")
+	}
+	if until > 0 && until <= since && !incremental {
+		return errors.New("the --until flag must be later than the --since flag")
+	}
+	if since > 0 {
+		data["since"] = strconv.FormatInt(since, 10)
+	}
+	if until > 0 {
+		data["until"] = strconv.FormatInt(until, 10)
+	}
+	if incremental {
+		data["incremental"] = "true"
+	}
+
+	teamArgs, _ := command.Flags().GetStringSlice("team")
+	teamIDs := make([]string, 0, len(teamArgs))
+	for _, teamArg := range teamArgs {
+		team := getTeamFromTeamArg(c, teamArg)
+		if team == nil {
+			return fmt.Errorf("unable to find team %q", teamArg)
+		}
+		teamIDs = append(teamIDs, team.Id)
+	}
+	if len(teamIDs) > 0 {
+		data["team_ids"] = strings.Join(teamIDs, ",")
+	}
+
+	channelArgs, _ := command.Flags().GetStringSlice("channel")
+	channelIDs := make([]string, 0, len(channelArgs))
+	for _, channelArg := range channelArgs {
+		channel := getChannelFromChannelArg(c, channelArg)
+		if channel == nil {
+			return fmt.Errorf("unable to find channel %q", channelArg)
+		}
+		channelIDs = append(channelIDs, channel.Id)
+	}
+	if len(channelIDs) > 0 {
+		data["channel_ids"] = strings.Join(channelIDs, ",")
+	}
+
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeExportProcess,
 		Data: data,
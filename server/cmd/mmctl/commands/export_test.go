@@ -5,6 +5,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -87,6 +88,86 @@ func (s *MmctlUnitTestSuite) TestExportCreateCmdF() {
 		s.Empty(printer.GetErrorLines())
 		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
 	})
+
+	s.Run("create incremental export of a team", func() {
+		printer.Clean()
+		team := &model.Team{Id: model.NewId(), Name: "team-name"}
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"incremental":               "true",
+				"team_ids":                  team.Id,
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Name, "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), team.Name, "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("incremental", true, "")
+		cmd.Flags().StringSlice("team", []string{team.Name}, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("create export with a time window", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"since":                     "1000",
+				"until":                     "2000",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int64("since", 1000, "")
+		cmd.Flags().Int64("until", 2000, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+	})
+
+	s.Run("fail to create an incremental export with since", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int64("since", 1000, "")
+		cmd.Flags().Bool("incremental", true, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Error(err)
+		s.Empty(printer.GetLines())
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestExportDeleteCmdF() {
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -54,7 +55,7 @@ var ImportListAvailableCmd = &cobra.Command{
 
 var ImportJobCmd = &cobra.Command{
 	Use:   "job",
-	Short: "List and show import jobs",
+	Short: "List, show and resume import jobs",
 }
 
 var ImportListIncompleteCmd = &cobra.Command{
@@ -82,6 +83,16 @@ var ImportJobShowCmd = &cobra.Command{
 	RunE:    withClient(importJobShowCmdF),
 }
 
+var ImportJobResumeCmd = &cobra.Command{
+	Use:   "resume [importJobID]",
+	Short: "Resume an import job",
+	Long: "Resume an import job that failed or was canceled. The import continues after the last line committed by the job, " +
+		"using the same import file. Use --force to resume a job left in progress by a server that was restarted.",
+	Example: "  import job resume f3d68qkkm7n8xgsfxwuo498rah",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(importJobResumeCmdF),
+}
+
 var ImportProcessCmd = &cobra.Command{
 	Use:     "process [importname]",
 	Example: "  import process 35uy6cwrqfnhdx3genrhqqznxc_import.zip",
@@ -100,6 +111,21 @@ var ImportValidateCmd = &cobra.Command{
 	},
 }
 
+var ImportConvertCmd = &cobra.Command{
+	Use:   "convert [source] [exportpath]",
+	Short: "Convert a third-party export to an import file",
+	Long: "Convert the export of another chat service to a bulk import file that can be validated, uploaded and processed. " +
+		"Supported sources are \"rocketchat\" for mongoexport dumps of a Rocket.Chat database, \"discord\" for Discord data packages " +
+		"and \"teams\" for Microsoft Teams data exported from the Graph API. The export can be either a directory or a zip file. " +
+		"Anything that couldn't be converted is listed in a report.",
+	Example:   "  import convert rocketchat ./rocketchat-dump --team myteam --output rocketchat_import.zip",
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: importer.ConvertSources(),
+	RunE: func(command *cobra.Command, args []string) error {
+		return importConvertCmdF(nil, command, args)
+	},
+}
+
 func init() {
 	ImportUploadCmd.Flags().Bool("resume", false, "Set to true to resume an incomplete import upload.")
 	ImportUploadCmd.Flags().String("upload", "", "The ID of the import upload to resume.")
@@ -115,6 +141,15 @@ func init() {
 
 	ImportProcessCmd.Flags().Bool("bypass-upload", false, "If this is set, the file is not processed from the server, but rather directly read from the filesystem. Works only in --local mode.")
 	ImportProcessCmd.Flags().Bool("extract-content", true, "If this is set, document attachments will be extracted and indexed during the import process. It is advised to disable it to improve performance.")
+	ImportProcessCmd.Flags().String("error-policy", "abort", "What to do with the lines that fail to import: \"abort\" stops the import, \"skip\" skips them and writes them to an errors file in the import directory.")
+	ImportProcessCmd.Flags().Bool("dry-run", false, "If this is set, nothing is imported. The job reports how many teams, channels, users and posts would be created, updated or skipped, along with conflicts with the data of the server, and writes a report file to the import directory.")
+
+	ImportJobResumeCmd.Flags().Bool("force", false, "Resume the job even if it is still in progress. Only use it when the server processing the job was restarted.")
+
+	ImportConvertCmd.Flags().String("output", "", "Path of the import file to write. Defaults to <source>_import.zip")
+	ImportConvertCmd.Flags().String("report", "", "Path of the conversion report to write. Defaults to the import file name with a _report.json suffix")
+	ImportConvertCmd.Flags().String("team", "", "Name of an existing team to import into. Required for Rocket.Chat; for Discord and Microsoft Teams exports, every channel is imported into this team instead of one new team per server or team")
+	ImportConvertCmd.Flags().Int("max-post-size", model.PostMessageMaxRunesV2, "Maximum number of characters of a post on the destination server. Longer messages are truncated")
 
 	ImportListCmd.AddCommand(
 		ImportListAvailableCmd,
@@ -123,6 +158,7 @@ func init() {
 	ImportJobCmd.AddCommand(
 		ImportJobListCmd,
 		ImportJobShowCmd,
+		ImportJobResumeCmd,
 	)
 	ImportCmd.AddCommand(
 		ImportUploadCmd,
@@ -130,6 +166,7 @@ func init() {
 		ImportProcessCmd,
 		ImportJobCmd,
 		ImportValidateCmd,
+		ImportConvertCmd,
 	)
 	RootCmd.AddCommand(ImportCmd)
 }
@@ -290,19 +327,36 @@ func importProcessCmdF(c client.Client, command *cobra.Command, args []string) e
 	}
 
 	extractContent, _ := command.Flags().GetBool("extract-content")
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	errorPolicy, _ := command.Flags().GetString("error-policy")
+	if errorPolicy != "" && errorPolicy != "abort" && errorPolicy != "skip" {
+		return fmt.Errorf("invalid error policy %q, it must be \"abort\" or \"skip\"", errorPolicy)
+	}
+
+	data := map[string]string{
+		"import_file":     importFile,
+		"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
+		"extract_content": strconv.FormatBool(extractContent),
+	}
+	if dryRun {
+		data["dry_run"] = "true"
+	}
+	if errorPolicy == "skip" {
+		data["error_policy"] = errorPolicy
+	}
 
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeImportProcess,
-		Data: map[string]string{
-			"import_file":     importFile,
-			"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
-			"extract_content": strconv.FormatBool(extractContent),
-		},
+		Data: data,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create import process job: %w", err)
 	}
 
+	if dryRun {
+		printer.PrintT("Import dry run job successfully created, ID: {{.Id}}. Use \"mmctl import job show\" to see the summary once it completes.", job)
+		return nil
+	}
 	printer.PrintT("Import process job successfully created, ID: {{.Id}}", job)
 
 	return nil
@@ -319,6 +373,40 @@ func importJobShowCmdF(c client.Client, command *cobra.Command, args []string) e
 	return nil
 }
 
+func importJobResumeCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.GetJob(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	if job.Type != model.JobTypeImportProcess {
+		return fmt.Errorf("job %s is not an import job", job.Id)
+	}
+
+	force, _ := command.Flags().GetBool("force")
+	switch job.Status {
+	case model.JobStatusError, model.JobStatusCanceled:
+	case model.JobStatusInProgress:
+		if !force {
+			return fmt.Errorf("import job %s is in progress, use --force if the server processing it was restarted", job.Id)
+		}
+	default:
+		return fmt.Errorf("import job %s can't be resumed from status %q", job.Id, job.Status)
+	}
+
+	if _, err := c.UpdateJobStatus(context.TODO(), job.Id, model.JobStatusPending, true); err != nil {
+		return fmt.Errorf("failed to resume import job: %w", err)
+	}
+
+	lastLine := job.Data["last_line"]
+	if lastLine == "" {
+		lastLine = "0"
+	}
+	printer.PrintT(fmt.Sprintf("Import job {{.Id}} resumed after line %s", lastLine), job)
+
+	return nil
+}
+
 func importJobListCmdF(c client.Client, command *cobra.Command, args []string) error {
 	return jobListCmdF(c, command, model.JobTypeImportProcess, "")
 }
@@ -520,6 +608,58 @@ func importValidateCmdF(c client.Client, command *cobra.Command, args []string)
 	return nil
 }
 
+func importConvertCmdF(_ client.Client, command *cobra.Command, args []string) error {
+	source, exportPath := args[0], args[1]
+
+	outputPath, _ := command.Flags().GetString("output")
+	if outputPath == "" {
+		outputPath = source + "_import.zip"
+	}
+	reportPath, _ := command.Flags().GetString("report")
+	if reportPath == "" {
+		reportPath = strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + "_report.json"
+	}
+	team, _ := command.Flags().GetString("team")
+	maxPostSize, _ := command.Flags().GetInt("max-post-size")
+
+	src, closer, err := importer.OpenConvertSource(exportPath)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	output, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create import file: %w", err)
+	}
+	defer output.Close()
+
+	report, err := importer.Convert(source, src, team, maxPostSize, output)
+	if err != nil {
+		os.Remove(outputPath)
+		return fmt.Errorf("failed to convert the export: %w", err)
+	}
+
+	reportData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the conversion report: %w", err)
+	}
+	if err := os.WriteFile(reportPath, reportData, 0600); err != nil {
+		return fmt.Errorf("failed to write the conversion report: %w", err)
+	}
+
+	printer.PrintT("Import file written to {{ .ImportFile }}\n"+
+		"Teams {{ .Teams }}, channels {{ .Channels }}, users {{ .Users }}, posts {{ .Posts }}, replies {{ .Replies }}, "+
+		"direct channels {{ .DirectChannels }}, direct posts {{ .DirectPosts }}, reactions {{ .Reactions }}, attachments {{ .Attachments }}\n"+
+		"{{ len .Unmapped }} items could not be converted, see {{ .ReportFile }}", struct {
+		*importer.ConversionReport
+		ImportFile string `json:"import_file"`
+		ReportFile string `json:"report_file"`
+	}{report, outputPath, reportPath})
+
+	return nil
+}
+
 func configurePrinter() {
 	// we want to manage the newlines ourselves
 	printer.SetNoNewline(true)
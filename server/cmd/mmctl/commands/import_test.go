@@ -150,6 +150,73 @@ func (s *MmctlUnitTestSuite) TestImportJobShowCmdF() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestImportJobResumeCmdF() {
+	s.Run("failed job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeImportProcess,
+			Status: model.JobStatusError,
+			Data:   map[string]string{"last_line": "4000000"},
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateJobStatus(context.TODO(), mockJob.Id, model.JobStatusPending, true).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := importJobResumeCmdF(s.client, ImportJobResumeCmd, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("job in progress", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeImportProcess,
+			Status: model.JobStatusInProgress,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := importJobResumeCmdF(s.client, ImportJobResumeCmd, []string{mockJob.Id})
+		s.Require().EqualError(err, fmt.Sprintf("import job %s is in progress, use --force if the server processing it was restarted", mockJob.Id))
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("not an import job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusError,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := importJobResumeCmdF(s.client, ImportJobResumeCmd, []string{mockJob.Id})
+		s.Require().EqualError(err, fmt.Sprintf("job %s is not an import job", mockJob.Id))
+		s.Empty(printer.GetLines())
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestImportJobListCmdF() {
 	s.Run("no import jobs", func() {
 		printer.Clean()
@@ -235,6 +302,80 @@ func (s *MmctlUnitTestSuite) TestImportProcessCmdF() {
 	s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
 }
 
+func (s *MmctlUnitTestSuite) TestImportProcessCmdFDryRun() {
+	printer.Clean()
+	importFile := "import.zip"
+	mockJob := &model.Job{
+		Type: model.JobTypeImportProcess,
+		Data: map[string]string{
+			"import_file":     importFile,
+			"local_mode":      "false",
+			"extract_content": "true",
+			"dry_run":         "true",
+		},
+	}
+
+	s.client.
+		EXPECT().
+		CreateJob(context.TODO(), mockJob).
+		Return(mockJob, &model.Response{}, nil).
+		Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("extract-content", true, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	s.Require().NoError(cmd.Flags().Set("dry-run", "true"))
+
+	err := importProcessCmdF(s.client, cmd, []string{importFile})
+	s.Require().Nil(err)
+	s.Len(printer.GetLines(), 1)
+	s.Empty(printer.GetErrorLines())
+	s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+}
+
+func (s *MmctlUnitTestSuite) TestImportProcessCmdFErrorPolicy() {
+	s.Run("skip", func() {
+		printer.Clean()
+		importFile := "import.zip"
+		mockJob := &model.Job{
+			Type: model.JobTypeImportProcess,
+			Data: map[string]string{
+				"import_file":     importFile,
+				"local_mode":      "false",
+				"extract_content": "false",
+				"error_policy":    "skip",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("error-policy", "abort", "")
+		s.Require().NoError(cmd.Flags().Set("error-policy", "skip"))
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("invalid", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("error-policy", "abort", "")
+		s.Require().NoError(cmd.Flags().Set("error-policy", "ignore"))
+
+		err := importProcessCmdF(s.client, cmd, []string{"import.zip"})
+		s.Require().EqualError(err, `invalid error policy "ignore", it must be "abort" or "skip"`)
+		s.Empty(printer.GetLines())
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
 	importFilePath := filepath.Join(os.TempDir(), "import.zip")
 
@@ -476,3 +617,50 @@ func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
 		s.Equal("Validation complete\n", printer.GetLines()[2])
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestImportConvertCmdF() {
+	exportDir := s.T().TempDir()
+	outputPath := filepath.Join(s.T().TempDir(), "rocketchat_import.zip")
+
+	s.Require().NoError(os.WriteFile(filepath.Join(exportDir, "users.json"), []byte(`{"_id":"u1","username":"alice","emails":[{"address":"alice@example.org"}]}`), 0600))
+	s.Require().NoError(os.WriteFile(filepath.Join(exportDir, "rocketchat_room.json"), []byte(`{"_id":"r1","t":"c","name":"general"}`), 0600))
+	s.Require().NoError(os.WriteFile(filepath.Join(exportDir, "rocketchat_message.json"), []byte(`{"_id":"m1","rid":"r1","msg":"hello","ts":{"$date":"2023-01-01T10:00:00.000Z"},"u":{"_id":"u1"}}`), 0600))
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("output", outputPath, "")
+		cmd.Flags().String("report", "", "")
+		cmd.Flags().String("team", "myteam", "")
+		cmd.Flags().Int("max-post-size", model.PostMessageMaxRunesV2, "")
+		return cmd
+	}
+
+	s.Run("unsupported source", func() {
+		printer.Clean()
+		err := importConvertCmdF(nil, newCmd(), []string{"irc", exportDir})
+		s.Require().Error(err)
+		s.NoFileExists(outputPath)
+	})
+
+	s.Run("rocketchat export", func() {
+		printer.Clean()
+		err := importConvertCmdF(nil, newCmd(), []string{"rocketchat", exportDir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.FileExists(outputPath)
+
+		reportData, err := os.ReadFile(strings.TrimSuffix(outputPath, ".zip") + "_report.json")
+		s.Require().NoError(err)
+		s.Contains(string(reportData), `"posts": 1`)
+
+		printer.Clean()
+		err = importValidateCmdF(nil, ImportValidateCmd, []string{outputPath})
+		s.Require().Nil(err)
+		s.Empty(printer.GetErrorLines())
+		for _, line := range printer.GetLines() {
+			if res, ok := line.(ImportValidationResult); ok {
+				s.Empty(res.Errors)
+			}
+		}
+	})
+}
@@ -0,0 +1,825 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const (
+	ConvertSourceRocketChat = "rocketchat"
+	ConvertSourceDiscord    = "discord"
+	ConvertSourceTeams      = "teams"
+
+	convertedJSONLName = "import.jsonl"
+)
+
+var (
+	invalidNameCharacters     = regexp.MustCompile(`[^a-z0-9\-_]+`)
+	invalidUsernameCharacters = regexp.MustCompile(`[^a-z0-9.\-_]+`)
+	validEmojiName            = regexp.MustCompile(`^[a-zA-Z0-9\-\+_]+$`)
+)
+
+// unicodeEmojiNames maps the most common unicode emojis to their Mattermost names, for the
+// services that store reactions as unicode characters.
+var unicodeEmojiNames = map[string]string{
+	"👍":  "+1",
+	"👎":  "-1",
+	"❤️": "heart",
+	"❤":  "heart",
+	"😂":  "joy",
+	"😄":  "smile",
+	"😆":  "laughing",
+	"😊":  "blush",
+	"😮":  "open_mouth",
+	"😢":  "cry",
+	"😠":  "angry",
+	"😡":  "rage",
+	"🎉":  "tada",
+	"👀":  "eyes",
+	"🔥":  "fire",
+	"🚀":  "rocket",
+	"🙏":  "pray",
+	"👏":  "clap",
+	"💯":  "100",
+	"✅":  "white_check_mark",
+	"❌":  "x",
+	"🤔":  "thinking_face",
+	"😍":  "heart_eyes",
+	"🙂":  "slightly_smiling_face",
+	"😉":  "wink",
+	"👋":  "wave",
+}
+
+// ConversionReport summarises the bulk import data produced by a conversion and lists the
+// source data that couldn't be mapped.
+type ConversionReport struct {
+	Source         string          `json:"source"`
+	Teams          uint64          `json:"teams"`
+	Channels       uint64          `json:"channels"`
+	Users          uint64          `json:"users"`
+	Posts          uint64          `json:"posts"`
+	Replies        uint64          `json:"replies"`
+	DirectChannels uint64          `json:"direct_channels"`
+	DirectPosts    uint64          `json:"direct_posts"`
+	Reactions      uint64          `json:"reactions"`
+	Attachments    uint64          `json:"attachments"`
+	Unmapped       []*UnmappedItem `json:"unmapped"`
+}
+
+// UnmappedItem is an object of the source export that was skipped or only partially converted.
+type UnmappedItem struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (r *ConversionReport) unmapped(kind, id, format string, args ...any) {
+	r.Unmapped = append(r.Unmapped, &UnmappedItem{
+		Kind:   kind,
+		ID:     id,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// ConvertSources returns the names of the supported export formats.
+func ConvertSources() []string {
+	return []string{ConvertSourceRocketChat, ConvertSourceDiscord, ConvertSourceTeams}
+}
+
+// OpenConvertSource opens an export either as a directory or as a zip archive.
+func OpenConvertSource(name string) (fs.FS, io.Closer, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening the export %q: %w", name, err)
+	}
+
+	if info.IsDir() {
+		return os.DirFS(name), io.NopCloser(nil), nil
+	}
+
+	z, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading the ZIP file: %w", err)
+	}
+	return z, z, nil
+}
+
+// Convert reads the export of a third-party chat service and writes it to w as a bulk import
+// archive. Conversations that don't belong to a team in the source, such as Rocket.Chat rooms
+// or large group messages, are imported into team, which must already exist on the server.
+func Convert(source string, src fs.FS, team string, maxPostSize int, w io.Writer) (*ConversionReport, error) {
+	b := newImportBuilder(source, src, team, maxPostSize)
+
+	var err error
+	switch source {
+	case ConvertSourceRocketChat:
+		err = convertRocketChat(b)
+	case ConvertSourceDiscord:
+		err = convertDiscord(b)
+	case ConvertSourceTeams:
+		err = convertTeams(b)
+	default:
+		return nil, fmt.Errorf("unsupported export source %q, must be one of: %s", source, strings.Join(ConvertSources(), ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.write(w); err != nil {
+		return nil, fmt.Errorf("error writing the import archive: %w", err)
+	}
+
+	return b.report, nil
+}
+
+// message is a source message converted to the fields shared by posts, direct posts and
+// replies of the bulk import format.
+type message struct {
+	id          string
+	parentID    string
+	user        string
+	text        string
+	createAt    int64
+	editAt      int64
+	isPinned    bool
+	reactions   []imports.ReactionImportData
+	attachments []imports.AttachmentImportData
+	replies     []*message
+}
+
+// destination is where the messages of a source conversation are imported to: a channel, or a
+// direct or group message when members is set.
+type destination struct {
+	team    string
+	channel string
+	members []string
+}
+
+// importBuilder collects the converted data of an export and writes it as a bulk import archive.
+type importBuilder struct {
+	source      fs.FS
+	team        string
+	maxPostSize int
+	report      *ConversionReport
+
+	teams    []imports.TeamImportData
+	teamIDs  map[string]string
+	channels []imports.ChannelImportData
+	names    map[string]bool
+
+	// users maps the source user ids to usernames.
+	users       map[string]string
+	emails      map[string]string
+	userData    map[string]*imports.UserImportData
+	userOrder   []string
+	memberships map[string]map[string][]string
+
+	posts          []imports.PostImportData
+	directChannels map[string]bool
+	directLines    []imports.DirectChannelImportData
+	directPosts    []imports.DirectPostImportData
+
+	// attachments maps the attachment paths in the archive to the files of the export.
+	attachments map[string]string
+}
+
+func newImportBuilder(source string, src fs.FS, team string, maxPostSize int) *importBuilder {
+	if maxPostSize == 0 {
+		maxPostSize = model.PostMessageMaxRunesV2
+	}
+
+	return &importBuilder{
+		source:         src,
+		team:           team,
+		maxPostSize:    maxPostSize,
+		report:         &ConversionReport{Source: source},
+		teamIDs:        make(map[string]string),
+		names:          make(map[string]bool),
+		users:          make(map[string]string),
+		emails:         make(map[string]string),
+		userData:       make(map[string]*imports.UserImportData),
+		memberships:    make(map[string]map[string][]string),
+		directChannels: make(map[string]bool),
+		attachments:    make(map[string]string),
+	}
+}
+
+// defaultTeam returns the team for the conversations that don't belong to a team in the source.
+func (b *importBuilder) defaultTeam() string {
+	if b.team == "" && len(b.teams) > 0 {
+		return *b.teams[0].Name
+	}
+	return b.team
+}
+
+// uniqueName returns name, or name with a numeric suffix if it's already used in scope.
+func (b *importBuilder) uniqueName(scope, name string, maxLength int) string {
+	candidate := name
+	for i := 2; b.names[scope+"/"+candidate]; i++ {
+		suffix := "-" + strconv.Itoa(i)
+		if len(name)+len(suffix) > maxLength {
+			candidate = name[:maxLength-len(suffix)] + suffix
+		} else {
+			candidate = name + suffix
+		}
+	}
+	b.names[scope+"/"+candidate] = true
+	return candidate
+}
+
+// sourceTeam returns the team a team of the source is imported to: the team given to the
+// conversion if any, or else a new team.
+func (b *importBuilder) sourceTeam(sourceID, displayName, description string) (string, bool) {
+	if b.team != "" {
+		return b.team, true
+	}
+	if name, ok := b.teamIDs[sourceID]; ok {
+		return name, name != ""
+	}
+
+	name, ok := b.addTeam(sourceID, displayName, description)
+	b.teamIDs[sourceID] = name
+	return name, ok
+}
+
+// addTeam adds a team created by the import and returns its name.
+func (b *importBuilder) addTeam(sourceID, displayName, description string) (string, bool) {
+	name := cleanName(invalidNameCharacters, strings.ReplaceAll(displayName, "_", "-"), model.TeamNameMaxLength)
+	if !model.IsValidTeamName(name) || model.IsReservedTeamName(name) {
+		name = cleanName(invalidNameCharacters, "team-"+sourceID, model.TeamNameMaxLength)
+	}
+	name = b.uniqueName("team", name, model.TeamNameMaxLength)
+
+	data := imports.TeamImportData{
+		Name:        model.NewPointer(name),
+		DisplayName: model.NewPointer(truncateRunes(displayName, model.TeamDisplayNameMaxRunes)),
+		Type:        model.NewPointer(model.TeamInvite),
+	}
+	if description != "" {
+		data.Description = model.NewPointer(truncateRunes(description, model.TeamDescriptionMaxLength))
+	}
+
+	if err := imports.ValidateTeamImportData(&data); err != nil {
+		b.report.unmapped("team", sourceID, "invalid team %q: %s", displayName, err.Error())
+		return "", false
+	}
+
+	b.teams = append(b.teams, data)
+	return name, true
+}
+
+// addChannel adds a channel to the given team and returns its name.
+func (b *importBuilder) addChannel(sourceID string, data imports.ChannelImportData) (string, bool) {
+	if data.Team == nil || *data.Team == "" {
+		b.report.unmapped("channel", sourceID, "no team to import the channel into, use the --team flag")
+		return "", false
+	}
+
+	name := cleanName(invalidNameCharacters, *data.Name, model.ChannelNameMaxLength)
+	if !model.IsValidChannelIdentifier(name) {
+		name = cleanName(invalidNameCharacters, "c-"+sourceID, model.ChannelNameMaxLength)
+	}
+	name = b.uniqueName("channel/"+*data.Team, name, model.ChannelNameMaxLength)
+
+	data.Name = model.NewPointer(name)
+	if data.DisplayName != nil {
+		data.DisplayName = model.NewPointer(truncateRunes(*data.DisplayName, model.ChannelDisplayNameMaxRunes))
+	}
+	if data.Header != nil {
+		data.Header = model.NewPointer(truncateRunes(*data.Header, model.ChannelHeaderMaxRunes))
+	}
+	if data.Purpose != nil {
+		data.Purpose = model.NewPointer(truncateRunes(*data.Purpose, model.ChannelPurposeMaxRunes))
+	}
+
+	if err := imports.ValidateChannelImportData(&data); err != nil {
+		b.report.unmapped("channel", sourceID, "invalid channel %q: %s", name, err.Error())
+		return "", false
+	}
+
+	b.channels = append(b.channels, data)
+	return name, true
+}
+
+// addUser maps a source user to a new user, or to a user of the same export that shares its
+// email address, and returns the username.
+func (b *importBuilder) addUser(sourceID string, data imports.UserImportData) (string, bool) {
+	if username, ok := b.users[sourceID]; ok {
+		return username, true
+	}
+
+	username := cleanName(invalidUsernameCharacters, model.NormalizeUsername(*data.Username), model.UserNameMaxLength)
+	if !model.IsValidUsername(username) {
+		username = cleanName(invalidUsernameCharacters, "user-"+sourceID, model.UserNameMaxLength)
+	}
+
+	email := ""
+	if data.Email != nil {
+		email = model.NormalizeEmail(*data.Email)
+	}
+	if existing, ok := b.emails[email]; ok && email != "" {
+		b.users[sourceID] = existing
+		return existing, true
+	}
+
+	username = b.uniqueName("user", username, model.UserNameMaxLength)
+	if email == "" {
+		email = username + "@example.com"
+		b.report.unmapped("user", sourceID, "no email address in the export, %q was used as a placeholder", email)
+	}
+
+	data.Username = model.NewPointer(username)
+	data.Email = model.NewPointer(email)
+	if data.Roles == nil {
+		data.Roles = model.NewPointer(model.SystemUserRoleId)
+	}
+	if data.FirstName != nil {
+		data.FirstName = model.NewPointer(truncateRunes(*data.FirstName, model.UserFirstNameMaxRunes))
+	}
+	if data.LastName != nil {
+		data.LastName = model.NewPointer(truncateRunes(*data.LastName, model.UserLastNameMaxRunes))
+	}
+	if data.Nickname != nil {
+		data.Nickname = model.NewPointer(truncateRunes(*data.Nickname, model.UserNicknameMaxRunes))
+	}
+	if data.Position != nil {
+		data.Position = model.NewPointer(truncateRunes(*data.Position, model.UserPositionMaxRunes))
+	}
+
+	if err := imports.ValidateUserImportData(&data); err != nil {
+		b.report.unmapped("user", sourceID, "invalid user %q: %s", username, err.Error())
+		return "", false
+	}
+
+	b.users[sourceID] = username
+	b.emails[email] = username
+	b.userData[username] = &data
+	b.userOrder = append(b.userOrder, username)
+	return username, true
+}
+
+// user returns the username a source user was mapped to, or an empty string.
+func (b *importBuilder) user(sourceID string) string {
+	return b.users[sourceID]
+}
+
+// joinTeam adds a user to a team, and to the channels of the team if any are given.
+func (b *importBuilder) joinTeam(username, team string, channels ...string) {
+	if username == "" || team == "" {
+		return
+	}
+
+	teams, ok := b.memberships[username]
+	if !ok {
+		teams = make(map[string][]string)
+		b.memberships[username] = teams
+	}
+
+	for _, channel := range channels {
+		if !slices.Contains(teams[team], channel) {
+			teams[team] = append(teams[team], channel)
+		}
+	}
+	if _, ok := teams[team]; !ok {
+		teams[team] = nil
+	}
+}
+
+// addConversation maps a direct or group message of the source to a direct channel. Group
+// messages with more members than Mattermost allows are imported as private channels of the
+// default team.
+func (b *importBuilder) addConversation(sourceID, displayName string, members []string) (destination, bool) {
+	var usernames []string
+	for _, member := range members {
+		if member != "" && !slices.Contains(usernames, member) {
+			usernames = append(usernames, member)
+		}
+	}
+
+	switch {
+	case len(usernames) == 0:
+		b.report.unmapped("direct_channel", sourceID, "none of the members could be mapped to a user")
+		return destination{}, false
+	case len(usernames) == 1:
+		usernames = append(usernames, usernames[0])
+	case len(usernames) > model.ChannelGroupMaxUsers:
+		team := b.defaultTeam()
+		channel, ok := b.addChannel(sourceID, imports.ChannelImportData{
+			Team:        model.NewPointer(team),
+			Name:        model.NewPointer(displayName),
+			DisplayName: model.NewPointer(displayName),
+			Type:        model.NewPointer(model.ChannelTypePrivate),
+		})
+		if !ok {
+			return destination{}, false
+		}
+		for _, username := range usernames {
+			b.joinTeam(username, team, channel)
+		}
+		return destination{team: team, channel: channel}, true
+	}
+
+	sorted := slices.Clone(usernames)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, ",")
+	if !b.directChannels[key] {
+		b.directChannels[key] = true
+		b.directLines = append(b.directLines, imports.DirectChannelImportData{
+			Members: model.NewPointer(usernames),
+		})
+	}
+
+	return destination{members: usernames}, true
+}
+
+// addAttachment adds a file of the export to the archive. Files that aren't part of the export
+// are reported and skipped.
+func (b *importBuilder) addAttachment(sourcePath, id, name string) (imports.AttachmentImportData, bool) {
+	if _, err := fs.Stat(b.source, sourcePath); err != nil {
+		b.report.unmapped("attachment", id, "file %q is not included in the export", name)
+		return imports.AttachmentImportData{}, false
+	}
+
+	archivePath := path.Join(cleanName(invalidNameCharacters, id, len(id)), path.Base(name))
+	b.attachments[archivePath] = sourcePath
+	return imports.AttachmentImportData{Path: model.NewPointer(archivePath)}, true
+}
+
+// addReaction adds a reaction to a message, skipping duplicates and emojis that can't be
+// mapped to a Mattermost emoji name.
+func (b *importBuilder) addReaction(msg *message, username, emoji string, createAt int64) {
+	if username == "" {
+		b.report.unmapped("reaction", msg.id, "the user who reacted with %q could not be mapped", emoji)
+		return
+	}
+
+	name := emojiName(emoji)
+	if name == "" {
+		b.report.unmapped("reaction", msg.id, "emoji %q has no Mattermost equivalent", emoji)
+		return
+	}
+
+	for _, reaction := range msg.reactions {
+		if *reaction.User == username && *reaction.EmojiName == name {
+			return
+		}
+	}
+
+	if createAt < msg.createAt {
+		createAt = msg.createAt
+	}
+	msg.reactions = append(msg.reactions, imports.ReactionImportData{
+		User:      model.NewPointer(username),
+		EmojiName: model.NewPointer(name),
+		CreateAt:  model.NewPointer(createAt),
+	})
+}
+
+// addMessages threads the messages of a conversation and adds them to the import.
+func (b *importBuilder) addMessages(dest destination, messages []*message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].createAt < messages[j].createAt
+	})
+
+	byID := make(map[string]*message, len(messages))
+	for _, msg := range messages {
+		if msg.id != "" {
+			byID[msg.id] = msg
+		}
+	}
+
+	var roots []*message
+	for _, msg := range messages {
+		root := byID[msg.parentID]
+		for root != nil && root.parentID != "" && byID[root.parentID] != nil && root != msg {
+			root = byID[root.parentID]
+		}
+		if root == nil || root == msg {
+			roots = append(roots, msg)
+			continue
+		}
+		root.replies = append(root.replies, msg)
+	}
+
+	for _, msg := range roots {
+		if msg.user == "" {
+			b.report.unmapped("message", msg.id, "the author could not be mapped to a user")
+			continue
+		}
+		if dest.members != nil {
+			b.addDirectPost(dest.members, msg)
+		} else {
+			b.joinTeam(msg.user, dest.team, dest.channel)
+			b.addPost(dest.team, dest.channel, msg)
+		}
+	}
+}
+
+func (b *importBuilder) addPost(team, channel string, msg *message) {
+	replies := b.replies(msg)
+	data := imports.PostImportData{
+		Team:        model.NewPointer(team),
+		Channel:     model.NewPointer(channel),
+		User:        model.NewPointer(msg.user),
+		Message:     model.NewPointer(b.text(msg)),
+		CreateAt:    model.NewPointer(msg.createAt),
+		Reactions:   nonEmpty(msg.reactions),
+		Attachments: nonEmpty(msg.attachments),
+		Replies:     nonEmpty(replies),
+	}
+	if msg.editAt != 0 {
+		data.EditAt = model.NewPointer(msg.editAt)
+	}
+	if msg.isPinned {
+		data.IsPinned = model.NewPointer(true)
+	}
+
+	if err := imports.ValidatePostImportData(&data, b.maxPostSize); err != nil {
+		b.report.unmapped("message", msg.id, "invalid post: %s", err.Error())
+		return
+	}
+	b.posts = append(b.posts, data)
+}
+
+func (b *importBuilder) addDirectPost(members []string, msg *message) {
+	replies := b.replies(msg)
+	data := imports.DirectPostImportData{
+		ChannelMembers: model.NewPointer(members),
+		User:           model.NewPointer(msg.user),
+		Message:        model.NewPointer(b.text(msg)),
+		CreateAt:       model.NewPointer(msg.createAt),
+		Reactions:      nonEmpty(msg.reactions),
+		Attachments:    nonEmpty(msg.attachments),
+		Replies:        nonEmpty(replies),
+	}
+	if msg.editAt != 0 {
+		data.EditAt = model.NewPointer(msg.editAt)
+	}
+	if msg.isPinned {
+		data.IsPinned = model.NewPointer(true)
+	}
+
+	if err := imports.ValidateDirectPostImportData(&data, b.maxPostSize); err != nil {
+		b.report.unmapped("message", msg.id, "invalid direct post: %s", err.Error())
+		return
+	}
+	b.directPosts = append(b.directPosts, data)
+}
+
+func (b *importBuilder) replies(root *message) []imports.ReplyImportData {
+	var replies []imports.ReplyImportData
+	for _, msg := range root.replies {
+		if msg.user == "" {
+			b.report.unmapped("message", msg.id, "the author could not be mapped to a user")
+			continue
+		}
+
+		reply := imports.ReplyImportData{
+			User:        model.NewPointer(msg.user),
+			Message:     model.NewPointer(b.text(msg)),
+			CreateAt:    model.NewPointer(msg.createAt),
+			Reactions:   nonEmpty(msg.reactions),
+			Attachments: nonEmpty(msg.attachments),
+		}
+		if msg.editAt != 0 {
+			reply.EditAt = model.NewPointer(msg.editAt)
+		}
+		if msg.isPinned {
+			reply.IsPinned = model.NewPointer(true)
+		}
+
+		if err := imports.ValidateReplyImportData(&reply, root.createAt, b.maxPostSize); err != nil {
+			b.report.unmapped("message", msg.id, "invalid reply: %s", err.Error())
+			continue
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+// text returns the text of a message, truncated to the maximum post size.
+func (b *importBuilder) text(msg *message) string {
+	if utf8.RuneCountInString(msg.text) <= b.maxPostSize {
+		return msg.text
+	}
+
+	b.report.unmapped("message", msg.id, "the message is longer than %d characters and was truncated", b.maxPostSize)
+	return truncateRunes(msg.text, b.maxPostSize)
+}
+
+func (b *importBuilder) userLines() []imports.LineImportData {
+	lines := make([]imports.LineImportData, 0, len(b.userOrder))
+	for _, username := range b.userOrder {
+		data := b.userData[username]
+
+		teams := b.memberships[username]
+		teamNames := make([]string, 0, len(teams))
+		for team := range teams {
+			teamNames = append(teamNames, team)
+		}
+		sort.Strings(teamNames)
+
+		var userTeams []imports.UserTeamImportData
+		for _, team := range teamNames {
+			var channels []imports.UserChannelImportData
+			for _, channel := range teams[team] {
+				channels = append(channels, imports.UserChannelImportData{
+					Name:  model.NewPointer(channel),
+					Roles: model.NewPointer(model.ChannelUserRoleId),
+				})
+			}
+			userTeams = append(userTeams, imports.UserTeamImportData{
+				Name:     model.NewPointer(team),
+				Roles:    model.NewPointer(model.TeamUserRoleId),
+				Channels: nonEmpty(channels),
+			})
+		}
+		data.Teams = nonEmpty(userTeams)
+
+		lines = append(lines, imports.LineImportData{Type: LineTypeUser, User: data})
+	}
+	return lines
+}
+
+// write writes the import archive, with the lines in the order the bulk import processes them.
+func (b *importBuilder) write(w io.Writer) error {
+	lines := []imports.LineImportData{{Type: LineTypeVersion, Version: model.NewPointer(1)}}
+	for i := range b.teams {
+		lines = append(lines, imports.LineImportData{Type: LineTypeTeam, Team: &b.teams[i]})
+	}
+	for i := range b.channels {
+		lines = append(lines, imports.LineImportData{Type: LineTypeChannel, Channel: &b.channels[i]})
+	}
+	lines = append(lines, b.userLines()...)
+	for i := range b.posts {
+		lines = append(lines, imports.LineImportData{Type: LineTypePost, Post: &b.posts[i]})
+	}
+	for i := range b.directLines {
+		lines = append(lines, imports.LineImportData{Type: LineTypeDirectChannel, DirectChannel: &b.directLines[i]})
+	}
+	for i := range b.directPosts {
+		lines = append(lines, imports.LineImportData{Type: LineTypeDirectPost, DirectPost: &b.directPosts[i]})
+	}
+
+	zipWriter := zip.NewWriter(w)
+
+	jsonlWriter, err := zipWriter.Create(convertedJSONLName)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(jsonlWriter)
+	for i := range lines {
+		if err := encoder.Encode(&lines[i]); err != nil {
+			return err
+		}
+		b.count(&lines[i])
+	}
+
+	archivePaths := make([]string, 0, len(b.attachments))
+	for archivePath := range b.attachments {
+		archivePaths = append(archivePaths, archivePath)
+	}
+	sort.Strings(archivePaths)
+
+	for _, archivePath := range archivePaths {
+		if err := b.copyAttachment(zipWriter, archivePath); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func (b *importBuilder) count(line *imports.LineImportData) {
+	var (
+		reactions   *[]imports.ReactionImportData
+		attachments *[]imports.AttachmentImportData
+		replies     *[]imports.ReplyImportData
+	)
+
+	switch line.Type {
+	case LineTypeTeam:
+		b.report.Teams++
+	case LineTypeChannel:
+		b.report.Channels++
+	case LineTypeUser:
+		b.report.Users++
+	case LineTypeDirectChannel:
+		b.report.DirectChannels++
+	case LineTypePost:
+		b.report.Posts++
+		reactions, attachments, replies = line.Post.Reactions, line.Post.Attachments, line.Post.Replies
+	case LineTypeDirectPost:
+		b.report.DirectPosts++
+		reactions, attachments, replies = line.DirectPost.Reactions, line.DirectPost.Attachments, line.DirectPost.Replies
+	default:
+		return
+	}
+
+	b.countMessage(reactions, attachments)
+	if replies != nil {
+		b.report.Replies += uint64(len(*replies))
+		for _, reply := range *replies {
+			b.countMessage(reply.Reactions, reply.Attachments)
+		}
+	}
+}
+
+func (b *importBuilder) countMessage(reactions *[]imports.ReactionImportData, attachments *[]imports.AttachmentImportData) {
+	if reactions != nil {
+		b.report.Reactions += uint64(len(*reactions))
+	}
+	if attachments != nil {
+		b.report.Attachments += uint64(len(*attachments))
+	}
+}
+
+func (b *importBuilder) copyAttachment(zipWriter *zip.Writer, archivePath string) error {
+	file, err := b.source.Open(b.attachments[archivePath])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer, err := zipWriter.Create(path.Join(model.ExportDataDir, archivePath))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, file)
+	return err
+}
+
+// readJSON decodes a JSON file of the export. Missing files are not an error, and leave v
+// unchanged.
+func readJSON(src fs.FS, name string, v any) (bool, error) {
+	data, err := fs.ReadFile(src, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error reading %q: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("error decoding %q: %w", name, err)
+	}
+	return true, nil
+}
+
+// cleanName lowercases name and replaces the characters that aren't allowed in it by dashes.
+func cleanName(invalid *regexp.Regexp, name string, maxLength int) string {
+	name = strings.Trim(invalid.ReplaceAllString(strings.ToLower(name), "-"), "-_.")
+	if len(name) > maxLength {
+		name = strings.Trim(name[:maxLength], "-_.")
+	}
+	return name
+}
+
+// emojiName returns the Mattermost name of an emoji given either as a name, with or without
+// colons, or as a unicode character. It returns an empty string for unknown emojis.
+func emojiName(emoji string) string {
+	if name, ok := unicodeEmojiNames[emoji]; ok {
+		return name
+	}
+
+	name := strings.SplitN(strings.Trim(emoji, ":"), "::", 2)[0]
+	if len(name) > model.EmojiNameMaxLength || !validEmojiName.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
+
+// nonEmpty returns a pointer to values, or nil if there are none, so that empty lists are
+// omitted from the import lines.
+func nonEmpty[T any](values []T) *[]T {
+	if len(values) == 0 {
+		return nil
+	}
+	return &values
+}
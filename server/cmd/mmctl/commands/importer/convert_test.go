@@ -0,0 +1,293 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// readConvertedArchive returns the import lines and the names of the files of an archive.
+func readConvertedArchive(t *testing.T, data []byte) ([]imports.LineImportData, []string) {
+	t.Helper()
+
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	var lines []imports.LineImportData
+	var names []string
+	for _, file := range z.File {
+		names = append(names, file.Name)
+		if file.Name != convertedJSONLName {
+			continue
+		}
+
+		f, err := file.Open()
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			var line imports.LineImportData
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.NoError(t, scanner.Err())
+		f.Close()
+	}
+
+	return lines, names
+}
+
+func linesOfType(lines []imports.LineImportData, lineType string) []imports.LineImportData {
+	var result []imports.LineImportData
+	for _, line := range lines {
+		if line.Type == lineType {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func TestConvertRocketChat(t *testing.T) {
+	src := fstest.MapFS{
+		"users.json": {Data: []byte(`{"_id":"u1","username":"alice","name":"Alice","emails":[{"address":"alice@example.org"}],"roles":["admin","user"]}
+{"_id":"u2","username":"Bob Smith","emails":[{"address":"bob@example.org"}],"active":false}
+`)},
+		"rocketchat_room.json": {Data: []byte(`[
+{"_id":"r1","t":"c","name":"general","fname":"General","topic":"Talk"},
+{"_id":"r2","t":"d","uids":["u1","u2"]},
+{"_id":"r3","t":"l","name":"livechat"}
+]`)},
+		"rocketchat_subscription.json": {Data: []byte(`{"rid":"r1","u":{"_id":"u1","username":"alice"}}
+{"rid":"r1","u":{"_id":"u2","username":"Bob Smith"}}
+`)},
+		"rocketchat_message.json": {Data: []byte(`{"_id":"m1","rid":"r1","msg":"hello","ts":{"$date":"2023-01-01T10:00:00.000Z"},"u":{"_id":"u1","username":"alice"},"pinned":true,"reactions":{":+1:":{"usernames":["Bob Smith"]},":party_parrot::skin-tone-2:":{"usernames":["alice"]}},"file":{"_id":"f1","name":"report.pdf"}}
+{"_id":"m2","rid":"r1","msg":"reply","ts":{"$date":{"$numberLong":"1672567300000"}},"u":{"_id":"u2","username":"Bob Smith"},"tmid":"m1"}
+{"_id":"m3","rid":"r1","t":"uj","msg":"alice","ts":{"$date":1672567400000},"u":{"_id":"u1","username":"alice"}}
+{"_id":"m4","rid":"r2","msg":"dm","ts":{"$date":"2023-01-01T11:00:00.000Z"},"u":{"_id":"u2","username":"Bob Smith"},"files":[{"_id":"f2","name":"missing.png"}]}
+`)},
+		"uploads/f1": {Data: []byte("pdf")},
+	}
+
+	t.Run("requires a team", func(t *testing.T) {
+		_, err := Convert(ConvertSourceRocketChat, src, "", 0, &bytes.Buffer{})
+		require.Error(t, err)
+	})
+
+	var buf bytes.Buffer
+	report, err := Convert(ConvertSourceRocketChat, src, "myteam", 0, &buf)
+	require.NoError(t, err)
+
+	lines, names := readConvertedArchive(t, buf.Bytes())
+	assert.Equal(t, []string{convertedJSONLName, "data/f1/report.pdf"}, names)
+	assert.Equal(t, LineTypeVersion, lines[0].Type)
+	assert.Empty(t, linesOfType(lines, LineTypeTeam))
+
+	channels := linesOfType(lines, LineTypeChannel)
+	require.Len(t, channels, 1)
+	assert.Equal(t, "general", *channels[0].Channel.Name)
+	assert.Equal(t, "General", *channels[0].Channel.DisplayName)
+	assert.Equal(t, "myteam", *channels[0].Channel.Team)
+
+	users := linesOfType(lines, LineTypeUser)
+	require.Len(t, users, 2)
+	assert.Equal(t, "alice", *users[0].User.Username)
+	assert.Equal(t, "system_admin system_user", *users[0].User.Roles)
+	assert.Equal(t, "myteam", *(*users[0].User.Teams)[0].Name)
+	assert.Equal(t, "general", *(*(*users[0].User.Teams)[0].Channels)[0].Name)
+	assert.Equal(t, "bob-smith", *users[1].User.Username)
+	assert.NotNil(t, users[1].User.DeleteAt)
+
+	posts := linesOfType(lines, LineTypePost)
+	require.Len(t, posts, 1)
+	post := posts[0].Post
+	assert.Equal(t, "hello", *post.Message)
+	assert.Equal(t, int64(1672567200000), *post.CreateAt)
+	assert.True(t, *post.IsPinned)
+	require.Len(t, *post.Reactions, 2)
+	assert.Equal(t, "+1", *(*post.Reactions)[0].EmojiName)
+	assert.Equal(t, "bob-smith", *(*post.Reactions)[0].User)
+	assert.Equal(t, "party_parrot", *(*post.Reactions)[1].EmojiName)
+	require.Len(t, *post.Attachments, 1)
+	assert.Equal(t, "f1/report.pdf", *(*post.Attachments)[0].Path)
+	require.Len(t, *post.Replies, 1)
+	assert.Equal(t, "reply", *(*post.Replies)[0].Message)
+
+	require.Len(t, linesOfType(lines, LineTypeDirectChannel), 1)
+	directPosts := linesOfType(lines, LineTypeDirectPost)
+	require.Len(t, directPosts, 1)
+	assert.Equal(t, []string{"alice", "bob-smith"}, *directPosts[0].DirectPost.ChannelMembers)
+
+	assert.Equal(t, uint64(1), report.Posts)
+	assert.Equal(t, uint64(1), report.Replies)
+	assert.Equal(t, uint64(2), report.Reactions)
+	assert.Equal(t, uint64(1), report.Attachments)
+	var kinds []string
+	for _, item := range report.Unmapped {
+		kinds = append(kinds, item.Kind)
+	}
+	assert.ElementsMatch(t, []string{"message", "channel", "attachment"}, kinds)
+}
+
+func TestConvertDiscord(t *testing.T) {
+	src := fstest.MapFS{
+		"account/user.json":         {Data: []byte(`{"id":"1","username":"alice","global_name":"Alice","email":"alice@example.org","relationships":[{"user":{"id":"2","username":"bob"}}]}`)},
+		"servers/index.json":        {Data: []byte(`{"100":"My Server"}`)},
+		"messages/index.json":       {Data: []byte(`{"10":"general in My Server","11":"Direct Message with bob","12":null}`)},
+		"messages/c10/channel.json": {Data: []byte(`{"id":"10","type":0,"name":"general","guild":{"id":"100","name":"My Server"}}`)},
+		"messages/c10/messages.json": {Data: []byte(`[
+{"ID":1100000000000000002,"Timestamp":"2023-01-02 10:00:00","Contents":"second","Attachments":"https://cdn.discordapp.com/attachments/10/5/image.png"},
+{"ID":1100000000000000001,"Timestamp":"2023-01-01 10:00:00","Contents":"first","Attachments":"https://cdn.discordapp.com/attachments/10/6/notes.txt"}
+]`)},
+		"messages/c10/image.png":    {Data: []byte("png")},
+		"messages/c11/channel.json": {Data: []byte(`{"id":"11","type":1,"recipients":["1","2"]}`)},
+		"messages/c11/messages.csv": {Data: []byte("ID,Timestamp,Contents,Attachments\n3,2023-01-03 10:00:00.000000+00:00,hi bob,\n")},
+		"messages/c12/channel.json": {Data: []byte(`{"id":"12","type":11,"name":"a thread","parent_id":"10","guild":{"id":"100"}}`)},
+		"messages/c12/messages.json": {Data: []byte(`[
+{"ID":"4","Timestamp":"2023-01-04 10:00:00","Contents":"thread start","Attachments":""},
+{"ID":"5","Timestamp":"2023-01-05 10:00:00","Contents":"thread reply","Attachments":""}
+]`)},
+	}
+
+	var buf bytes.Buffer
+	report, err := Convert(ConvertSourceDiscord, src, "", 0, &buf)
+	require.NoError(t, err)
+
+	lines, names := readConvertedArchive(t, buf.Bytes())
+	assert.Equal(t, []string{convertedJSONLName, "data/1100000000000000002/image.png"}, names)
+
+	teams := linesOfType(lines, LineTypeTeam)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "my-server", *teams[0].Team.Name)
+
+	channels := linesOfType(lines, LineTypeChannel)
+	require.Len(t, channels, 1)
+	assert.Equal(t, "general", *channels[0].Channel.Name)
+
+	users := linesOfType(lines, LineTypeUser)
+	require.Len(t, users, 2)
+	assert.Equal(t, "alice", *users[0].User.Username)
+	assert.Equal(t, "bob", *users[1].User.Username)
+	assert.Equal(t, "bob@example.com", *users[1].User.Email)
+
+	posts := linesOfType(lines, LineTypePost)
+	require.Len(t, posts, 3)
+	assert.Equal(t, "first\nhttps://cdn.discordapp.com/attachments/10/6/notes.txt", *posts[0].Post.Message)
+	assert.Equal(t, "second", *posts[1].Post.Message)
+	assert.Len(t, *posts[1].Post.Attachments, 1)
+	assert.Equal(t, "thread start", *posts[2].Post.Message)
+	require.Len(t, *posts[2].Post.Replies, 1)
+	assert.Equal(t, "thread reply", *(*posts[2].Post.Replies)[0].Message)
+
+	directPosts := linesOfType(lines, LineTypeDirectPost)
+	require.Len(t, directPosts, 1)
+	assert.Equal(t, "hi bob", *directPosts[0].DirectPost.Message)
+	assert.Equal(t, []string{"alice", "bob"}, *directPosts[0].DirectPost.ChannelMembers)
+
+	assert.Equal(t, uint64(1), report.Teams)
+	assert.Equal(t, uint64(1), report.Attachments)
+	assert.NotEmpty(t, report.Unmapped)
+}
+
+func TestConvertTeams(t *testing.T) {
+	src := fstest.MapFS{
+		"users.json": {Data: []byte(`{"value":[
+{"id":"u1","displayName":"Alice","givenName":"Alice","surname":"Doe","mail":"alice@example.org","userPrincipalName":"alice@example.org","jobTitle":"Engineer"},
+{"id":"u2","displayName":"Bob","mail":"bob@example.org","userPrincipalName":"bob@example.org"}
+]}`)},
+		"teams/t1/team.json":    {Data: []byte(`{"id":"t1","displayName":"Engineering","description":"The engineers"}`)},
+		"teams/t1/members.json": {Data: []byte(`{"value":[{"userId":"u1"},{"userId":"u2"}]}`)},
+		"teams/t1/channels.json": {Data: []byte(`{"value":[
+{"id":"c1","displayName":"General","membershipType":"standard"},
+{"id":"c2","displayName":"Secret Plans","membershipType":"private"}
+]}`)},
+		"teams/t1/channels/c1/messages.json": {Data: []byte(`{"value":[{
+"id":"m1","messageType":"message","createdDateTime":"2023-01-01T10:00:00Z",
+"from":{"user":{"id":"u1"}},
+"body":{"contentType":"html","content":"<p>Hi <at id=\"0\">Bob</at>, see <a href=\"https://example.org\">this</a> &amp; <b>that</b><attachment id=\"a1\"></attachment></p>"},
+"mentions":[{"id":0,"mentioned":{"user":{"id":"u2"}}}],
+"attachments":[{"id":"a1","contentType":"reference","contentUrl":"https://sharepoint/spec.docx","name":"spec.docx"}],
+"reactions":[{"reactionType":"like","createdDateTime":"2023-01-01T10:05:00Z","user":{"user":{"id":"u2"}}}],
+"replies":[{"id":"m2","replyToId":"m1","messageType":"message","createdDateTime":"2023-01-01T11:00:00Z","from":{"user":{"id":"u2"}},"body":{"contentType":"text","content":"thanks"}}]
+},{
+"id":"m3","messageType":"systemEventMessage","createdDateTime":"2023-01-01T09:00:00Z","body":{"contentType":"html","content":""}
+}]}`)},
+		"teams/t1/channels/c2/members.json": {Data: []byte(`[{"userId":"u1"}]`)},
+		"attachments/a1/spec.docx":          {Data: []byte("docx")},
+		"chats/ch1/chat.json":               {Data: []byte(`{"id":"ch1","chatType":"oneOnOne"}`)},
+		"chats/ch1/members.json":            {Data: []byte(`{"value":[{"userId":"u1"},{"userId":"u2"}]}`)},
+		"chats/ch1/messages.json": {Data: []byte(`{"value":[
+{"id":"m4","messageType":"message","createdDateTime":"2023-01-02T10:00:00Z","from":{"user":{"id":"u2"}},"body":{"contentType":"text","content":"ping"},"reactions":[{"reactionType":"🦄","createdDateTime":"2023-01-02T10:01:00Z","user":{"user":{"id":"u1"}}}]}
+]}`)},
+	}
+
+	var buf bytes.Buffer
+	report, err := Convert(ConvertSourceTeams, src, "", 0, &buf)
+	require.NoError(t, err)
+
+	lines, names := readConvertedArchive(t, buf.Bytes())
+	assert.Equal(t, []string{convertedJSONLName, "data/a1/spec.docx"}, names)
+
+	teams := linesOfType(lines, LineTypeTeam)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "engineering", *teams[0].Team.Name)
+	assert.Equal(t, "The engineers", *teams[0].Team.Description)
+
+	channels := linesOfType(lines, LineTypeChannel)
+	require.Len(t, channels, 2)
+	assert.Equal(t, "general", *channels[0].Channel.Name)
+	assert.Equal(t, "secret-plans", *channels[1].Channel.Name)
+	assert.Equal(t, "P", string(*channels[1].Channel.Type))
+
+	users := linesOfType(lines, LineTypeUser)
+	require.Len(t, users, 2)
+	assert.Equal(t, "alice", *users[0].User.Username)
+	assert.Equal(t, "Engineer", *users[0].User.Position)
+	assert.Len(t, *(*users[0].User.Teams)[0].Channels, 2)
+	assert.Len(t, *(*users[1].User.Teams)[0].Channels, 1)
+
+	posts := linesOfType(lines, LineTypePost)
+	require.Len(t, posts, 1)
+	post := posts[0].Post
+	assert.Equal(t, "Hi @bob, see [this](https://example.org) & **that**", *post.Message)
+	assert.Equal(t, "alice", *post.User)
+	require.Len(t, *post.Reactions, 1)
+	assert.Equal(t, "+1", *(*post.Reactions)[0].EmojiName)
+	require.Len(t, *post.Attachments, 1)
+	require.Len(t, *post.Replies, 1)
+	assert.Equal(t, "thanks", *(*post.Replies)[0].Message)
+
+	directPosts := linesOfType(lines, LineTypeDirectPost)
+	require.Len(t, directPosts, 1)
+	assert.Equal(t, "ping", *directPosts[0].DirectPost.Message)
+	assert.Nil(t, directPosts[0].DirectPost.Reactions)
+
+	assert.Equal(t, uint64(1), report.Replies)
+	var reasons []string
+	for _, item := range report.Unmapped {
+		reasons = append(reasons, item.Kind+": "+item.Reason)
+	}
+	assert.ElementsMatch(t, []string{
+		`message: 1 system and deleted messages of "teams/t1/channels/c1/messages.json" were skipped`,
+		`reaction: emoji "🦄" has no Mattermost equivalent`,
+	}, reasons)
+}
+
+func TestEmojiName(t *testing.T) {
+	assert.Equal(t, "+1", emojiName(":+1:"))
+	assert.Equal(t, "thumbsup", emojiName("thumbsup"))
+	assert.Equal(t, "wave", emojiName(":wave::skin-tone-3:"))
+	assert.Equal(t, "heart", emojiName("❤️"))
+	assert.Equal(t, "", emojiName("🦄"))
+	assert.Equal(t, "", emojiName("not valid"))
+}
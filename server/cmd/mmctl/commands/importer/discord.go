@@ -0,0 +1,340 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const (
+	discordChannelTypeDM            = 1
+	discordChannelTypeGroupDM       = 3
+	discordChannelTypeNewsThread    = 10
+	discordChannelTypePublicThread  = 11
+	discordChannelTypePrivateThread = 12
+)
+
+var discordTimestampLayouts = []string{
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+}
+
+type discordUserRef struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name"`
+}
+
+type discordAccount struct {
+	discordUserRef
+	Email         string `json:"email"`
+	Relationships []struct {
+		User discordUserRef `json:"user"`
+	} `json:"relationships"`
+}
+
+type discordChannel struct {
+	ID         string   `json:"id"`
+	Type       int      `json:"type"`
+	Name       string   `json:"name"`
+	Topic      string   `json:"topic"`
+	ParentID   string   `json:"parent_id"`
+	Recipients []string `json:"recipients"`
+	Guild      *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"guild"`
+
+	dir string
+}
+
+type discordMessage struct {
+	ID          json.Number `json:"ID"`
+	Timestamp   string      `json:"Timestamp"`
+	Contents    string      `json:"Contents"`
+	Attachments string      `json:"Attachments"`
+}
+
+// convertDiscord converts a Discord data package. Data packages only contain the messages sent
+// by the owner of the account, so every message is imported as sent by that user.
+func convertDiscord(b *importBuilder) error {
+	var account discordAccount
+	if ok, err := readJSON(b.source, "account/user.json", &account); err != nil {
+		return err
+	} else if !ok {
+		return errors.New("no account/user.json found in the export")
+	}
+
+	owner, ok := b.addUser(account.ID, imports.UserImportData{
+		Username: model.NewPointer(account.Username),
+		Email:    model.NewPointer(account.Email),
+		Nickname: model.NewPointer(account.GlobalName),
+	})
+	if !ok {
+		return fmt.Errorf("the owner of the data package, %q, could not be converted", account.Username)
+	}
+
+	knownUsers := make(map[string]discordUserRef, len(account.Relationships))
+	for _, relationship := range account.Relationships {
+		knownUsers[relationship.User.ID] = relationship.User
+	}
+
+	guilds := make(map[string]string)
+	if _, err := readJSON(b.source, "servers/index.json", &guilds); err != nil {
+		return err
+	}
+
+	channels, err := readDiscordChannels(b.source)
+	if err != nil {
+		return err
+	}
+
+	b.report.unmapped("reaction", "", "Discord data packages don't include reactions or the messages of other users")
+
+	channelNames := make(map[string]destination)
+	for _, channel := range channels {
+		var dest destination
+		switch channel.Type {
+		case discordChannelTypeDM, discordChannelTypeGroupDM:
+			members := []string{owner}
+			for _, recipientID := range channel.Recipients {
+				members = append(members, b.discordUser(recipientID, knownUsers))
+			}
+			if dest, ok = b.addConversation(channel.ID, channel.Name, members); !ok {
+				continue
+			}
+		case discordChannelTypeNewsThread, discordChannelTypePublicThread, discordChannelTypePrivateThread:
+			// Threads are imported once their parent channel is known.
+			continue
+		default:
+			if dest, ok = b.addDiscordChannel(channel, guilds); !ok {
+				continue
+			}
+			b.joinTeam(owner, dest.team, dest.channel)
+		}
+		channelNames[channel.ID] = dest
+
+		messages, err := b.readDiscordMessages(channel, owner)
+		if err != nil {
+			return err
+		}
+		b.addMessages(dest, messages)
+	}
+
+	for _, channel := range channels {
+		if channel.Type != discordChannelTypeNewsThread && channel.Type != discordChannelTypePublicThread && channel.Type != discordChannelTypePrivateThread {
+			continue
+		}
+
+		dest, ok := channelNames[channel.ParentID]
+		if !ok {
+			if dest, ok = b.addDiscordChannel(channel, guilds); !ok {
+				continue
+			}
+		}
+		b.joinTeam(owner, dest.team, dest.channel)
+
+		messages, err := b.readDiscordMessages(channel, owner)
+		if err != nil {
+			return err
+		}
+		// The first message of the thread becomes the root post, and the others its replies.
+		for _, msg := range messages[min(1, len(messages)):] {
+			msg.parentID = messages[0].id
+		}
+		b.addMessages(dest, messages)
+	}
+
+	return nil
+}
+
+// readDiscordChannels reads the channels listed in the index of the data package, in the
+// order of their ids.
+func readDiscordChannels(src fs.FS) ([]*discordChannel, error) {
+	index := make(map[string]*string)
+	if ok, err := readJSON(src, "messages/index.json", &index); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("no messages/index.json found in the export")
+	}
+
+	ids := make([]string, 0, len(index))
+	for id := range index {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	channels := make([]*discordChannel, 0, len(ids))
+	for _, id := range ids {
+		// Older data packages don't prefix the channel directories.
+		dir := path.Join("messages", "c"+id)
+		if _, err := fs.Stat(src, dir); err != nil {
+			dir = path.Join("messages", id)
+		}
+
+		channel := &discordChannel{ID: id, dir: dir}
+		if _, err := readJSON(src, path.Join(dir, "channel.json"), channel); err != nil {
+			return nil, err
+		}
+		if channel.Name == "" && index[id] != nil {
+			channel.Name = *index[id]
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+func (b *importBuilder) addDiscordChannel(channel *discordChannel, guilds map[string]string) (destination, bool) {
+	if channel.Guild == nil {
+		b.report.unmapped("channel", channel.ID, "the channel doesn't belong to a server")
+		return destination{}, false
+	}
+
+	guildName := channel.Guild.Name
+	if guildName == "" {
+		guildName = guilds[channel.Guild.ID]
+	}
+	team, ok := b.sourceTeam(channel.Guild.ID, guildName, "")
+	if !ok {
+		return destination{}, false
+	}
+
+	name, ok := b.addChannel(channel.ID, imports.ChannelImportData{
+		Team:        model.NewPointer(team),
+		Name:        model.NewPointer(channel.Name),
+		DisplayName: model.NewPointer(channel.Name),
+		Type:        model.NewPointer(model.ChannelTypeOpen),
+		Purpose:     model.NewPointer(channel.Topic),
+	})
+	if !ok {
+		return destination{}, false
+	}
+	return destination{team: team, channel: name}, true
+}
+
+// discordUser returns the username of a recipient of a direct message, adding it to the import
+// the first time. Only the friends of the owner have a username in the data package.
+func (b *importBuilder) discordUser(id string, knownUsers map[string]discordUserRef) string {
+	if username := b.user(id); username != "" {
+		return username
+	}
+
+	user, ok := knownUsers[id]
+	if !ok {
+		user = discordUserRef{ID: id, Username: "discord-" + id}
+	}
+	username, _ := b.addUser(id, imports.UserImportData{
+		Username: model.NewPointer(user.Username),
+		Nickname: model.NewPointer(user.GlobalName),
+	})
+	return username
+}
+
+// readDiscordMessages reads the messages of a channel, from messages.json in recent data
+// packages or messages.csv in older ones.
+func (b *importBuilder) readDiscordMessages(channel *discordChannel, owner string) ([]*message, error) {
+	var dMessages []discordMessage
+	if ok, err := readJSON(b.source, path.Join(channel.dir, "messages.json"), &dMessages); err != nil {
+		return nil, err
+	} else if !ok {
+		if dMessages, err = readDiscordCSV(b.source, path.Join(channel.dir, "messages.csv")); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := make([]*message, 0, len(dMessages))
+	for _, dMessage := range dMessages {
+		createAt, err := parseDiscordTimestamp(dMessage.Timestamp)
+		if err != nil {
+			b.report.unmapped("message", dMessage.ID.String(), "invalid timestamp %q", dMessage.Timestamp)
+			continue
+		}
+
+		msg := &message{
+			id:       dMessage.ID.String(),
+			user:     owner,
+			text:     dMessage.Contents,
+			createAt: createAt,
+		}
+
+		// Attachments are links to the Discord CDN, and are only imported as files when a copy
+		// was downloaded next to the messages.
+		for _, link := range strings.Fields(dMessage.Attachments) {
+			u, err := url.Parse(link)
+			if err != nil {
+				continue
+			}
+			name := path.Base(u.Path)
+			if _, err := fs.Stat(b.source, path.Join(channel.dir, name)); err == nil {
+				if attachment, ok := b.addAttachment(path.Join(channel.dir, name), msg.id, name); ok {
+					msg.attachments = append(msg.attachments, attachment)
+					continue
+				}
+			}
+			b.report.unmapped("attachment", msg.id, "file %q is not included in the export and was linked instead", name)
+			msg.text = strings.TrimSpace(msg.text + "\n" + link)
+		}
+
+		if msg.text == "" && len(msg.attachments) == 0 {
+			continue
+		}
+		messages = append(messages, msg)
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].createAt < messages[j].createAt
+	})
+	return messages, nil
+}
+
+func readDiscordCSV(src fs.FS, name string) ([]discordMessage, error) {
+	file, err := src.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", name, err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %q: %w", name, err)
+	}
+
+	var messages []discordMessage
+	for i, record := range records {
+		if i == 0 || len(record) < 4 {
+			continue
+		}
+		messages = append(messages, discordMessage{
+			ID:          json.Number(record[0]),
+			Timestamp:   record[1],
+			Contents:    record[2],
+			Attachments: record[3],
+		})
+	}
+	return messages, nil
+}
+
+func parseDiscordTimestamp(timestamp string) (int64, error) {
+	for _, layout := range discordTimestampLayouts {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t.UnixMilli(), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown timestamp format %q", timestamp)
+}
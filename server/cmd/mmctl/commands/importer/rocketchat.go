@@ -0,0 +1,318 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// mongoDate is a date of a mongoexport dump, in either the canonical or the relaxed extended
+// JSON format, converted to milliseconds.
+type mongoDate int64
+
+func (d *mongoDate) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if wrapper, ok := value.(map[string]any); ok {
+		value = wrapper["$date"]
+		if long, ok := value.(map[string]any); ok {
+			value = long["$numberLong"]
+			if s, ok := value.(string); ok {
+				millis, err := strconv.ParseInt(s, 10, 64)
+				*d = mongoDate(millis)
+				return err
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		*d = 0
+	case float64:
+		*d = mongoDate(v)
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", v, err)
+		}
+		*d = mongoDate(t.UnixMilli())
+	default:
+		return fmt.Errorf("invalid date %s", data)
+	}
+	return nil
+}
+
+type rocketChatUserRef struct {
+	ID       string `json:"_id"`
+	Username string `json:"username"`
+}
+
+type rocketChatUser struct {
+	ID       string `json:"_id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Emails   []struct {
+		Address string `json:"address"`
+	} `json:"emails"`
+	Active *bool    `json:"active"`
+	Roles  []string `json:"roles"`
+}
+
+type rocketChatRoom struct {
+	ID          string   `json:"_id"`
+	Type        string   `json:"t"`
+	Name        string   `json:"name"`
+	FName       string   `json:"fname"`
+	Topic       string   `json:"topic"`
+	Description string   `json:"description"`
+	UserIDs     []string `json:"uids"`
+	Usernames   []string `json:"usernames"`
+	Archived    bool     `json:"archived"`
+}
+
+type rocketChatSubscription struct {
+	RoomID string            `json:"rid"`
+	User   rocketChatUserRef `json:"u"`
+}
+
+type rocketChatFile struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+}
+
+type rocketChatMessage struct {
+	ID        string            `json:"_id"`
+	RoomID    string            `json:"rid"`
+	Text      string            `json:"msg"`
+	Type      string            `json:"t"`
+	Timestamp mongoDate         `json:"ts"`
+	EditedAt  mongoDate         `json:"editedAt"`
+	User      rocketChatUserRef `json:"u"`
+	ThreadID  string            `json:"tmid"`
+	Pinned    bool              `json:"pinned"`
+	Hidden    bool              `json:"_hidden"`
+	File      *rocketChatFile   `json:"file"`
+	Files     []rocketChatFile  `json:"files"`
+	Reactions map[string]struct {
+		Usernames []string `json:"usernames"`
+	} `json:"reactions"`
+}
+
+// readCollection reads a collection of a mongoexport dump, exported either as one document per
+// line or as a JSON array.
+func readCollection[T any](src fs.FS, name string) ([]T, error) {
+	data, err := fs.ReadFile(src, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", name, err)
+	}
+
+	var documents []T
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &documents); err != nil {
+			return nil, fmt.Errorf("error decoding %q: %w", name, err)
+		}
+		return documents, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var document T
+		if err := decoder.Decode(&document); err == io.EOF {
+			return documents, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error decoding %q: %w", name, err)
+		}
+		documents = append(documents, document)
+	}
+}
+
+// convertRocketChat converts a mongoexport dump of the users, rocketchat_room,
+// rocketchat_subscription and rocketchat_message collections of a Rocket.Chat database. Files
+// uploaded to the FileSystem storage are read from the uploads directory.
+func convertRocketChat(b *importBuilder) error {
+	if b.team == "" {
+		return errors.New("Rocket.Chat exports don't contain teams, use the --team flag to set the team to import into")
+	}
+
+	users, err := readCollection[rocketChatUser](b.source, "users.json")
+	if err != nil {
+		return err
+	}
+	rooms, err := readCollection[rocketChatRoom](b.source, "rocketchat_room.json")
+	if err != nil {
+		return err
+	}
+	subscriptions, err := readCollection[rocketChatSubscription](b.source, "rocketchat_subscription.json")
+	if err != nil {
+		return err
+	}
+	messages, err := readCollection[rocketChatMessage](b.source, "rocketchat_message.json")
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 && len(rooms) == 0 {
+		return errors.New("no users.json or rocketchat_room.json found in the export")
+	}
+
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Username] = user.ID
+
+		data := imports.UserImportData{
+			Username: model.NewPointer(user.Username),
+			Nickname: model.NewPointer(user.Name),
+		}
+		if len(user.Emails) > 0 {
+			data.Email = model.NewPointer(user.Emails[0].Address)
+		}
+		if slices.Contains(user.Roles, "admin") {
+			data.Roles = model.NewPointer(model.SystemAdminRoleId + " " + model.SystemUserRoleId)
+		}
+		if user.Active != nil && !*user.Active {
+			data.DeleteAt = model.NewPointer(model.GetMillis())
+		}
+
+		if username, ok := b.addUser(user.ID, data); ok {
+			b.joinTeam(username, b.team)
+		}
+	}
+
+	members := make(map[string][]string)
+	for _, subscription := range subscriptions {
+		members[subscription.RoomID] = append(members[subscription.RoomID], b.user(subscription.User.ID))
+	}
+
+	roomMessages := make(map[string][]rocketChatMessage)
+	var systemMessages int
+	for _, msg := range messages {
+		if msg.Type != "" || msg.Hidden {
+			systemMessages++
+			continue
+		}
+		roomMessages[msg.RoomID] = append(roomMessages[msg.RoomID], msg)
+	}
+	if systemMessages > 0 {
+		b.report.unmapped("message", "", "%d system and hidden messages were skipped", systemMessages)
+	}
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].Type < rooms[j].Type
+	})
+
+	for _, room := range rooms {
+		var dest destination
+		switch room.Type {
+		case "c", "p":
+			channelType := model.ChannelTypeOpen
+			if room.Type == "p" {
+				channelType = model.ChannelTypePrivate
+			}
+
+			displayName := room.FName
+			if displayName == "" {
+				displayName = room.Name
+			}
+
+			data := imports.ChannelImportData{
+				Team:        model.NewPointer(b.team),
+				Name:        model.NewPointer(room.Name),
+				DisplayName: model.NewPointer(displayName),
+				Type:        model.NewPointer(channelType),
+				Header:      model.NewPointer(room.Topic),
+				Purpose:     model.NewPointer(room.Description),
+			}
+			if room.Archived {
+				data.DeletedAt = model.NewPointer(model.GetMillis())
+			}
+
+			channel, ok := b.addChannel(room.ID, data)
+			if !ok {
+				continue
+			}
+			for _, username := range members[room.ID] {
+				b.joinTeam(username, b.team, channel)
+			}
+			dest = destination{team: b.team, channel: channel}
+		case "d":
+			var roomMembers []string
+			for _, userID := range room.UserIDs {
+				roomMembers = append(roomMembers, b.user(userID))
+			}
+			if len(roomMembers) == 0 {
+				for _, username := range room.Usernames {
+					roomMembers = append(roomMembers, b.user(usernames[username]))
+				}
+			}
+
+			var ok bool
+			if dest, ok = b.addConversation(room.ID, room.ID, roomMembers); !ok {
+				continue
+			}
+		default:
+			b.report.unmapped("channel", room.ID, "rooms of type %q are not supported", room.Type)
+			continue
+		}
+
+		b.addMessages(dest, b.convertRocketChatMessages(roomMessages[room.ID], usernames))
+	}
+
+	return nil
+}
+
+func (b *importBuilder) convertRocketChatMessages(rcMessages []rocketChatMessage, usernames map[string]string) []*message {
+	messages := make([]*message, 0, len(rcMessages))
+	for _, rcMessage := range rcMessages {
+		msg := &message{
+			id:       rcMessage.ID,
+			parentID: rcMessage.ThreadID,
+			user:     b.user(rcMessage.User.ID),
+			text:     rcMessage.Text,
+			createAt: int64(rcMessage.Timestamp),
+			editAt:   int64(rcMessage.EditedAt),
+			isPinned: rcMessage.Pinned,
+		}
+
+		files := rcMessage.Files
+		if len(files) == 0 && rcMessage.File != nil {
+			files = []rocketChatFile{*rcMessage.File}
+		}
+		for _, file := range files {
+			if attachment, ok := b.addAttachment(path.Join("uploads", file.ID), file.ID, file.Name); ok {
+				msg.attachments = append(msg.attachments, attachment)
+			}
+		}
+
+		emojis := make([]string, 0, len(rcMessage.Reactions))
+		for emoji := range rcMessage.Reactions {
+			emojis = append(emojis, emoji)
+		}
+		sort.Strings(emojis)
+		for _, emoji := range emojis {
+			for _, username := range rcMessage.Reactions[emoji].Usernames {
+				b.addReaction(msg, b.user(usernames[username]), emoji, msg.createAt)
+			}
+		}
+
+		messages = append(messages, msg)
+	}
+	return messages
+}
@@ -0,0 +1,475 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// teamsReactionNames maps the reaction types of Microsoft Teams to emoji names. Recent exports
+// store the other reactions as unicode emojis.
+var teamsReactionNames = map[string]string{
+	"like":      "+1",
+	"heart":     "heart",
+	"laugh":     "laughing",
+	"surprised": "open_mouth",
+	"sad":       "cry",
+	"angry":     "angry",
+}
+
+var teamsHTMLReplacements = []struct {
+	regex *regexp.Regexp
+	rpl   string
+}{
+	{regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`), "[$2]($1)"},
+	{regexp.MustCompile(`(?i)</?(b|strong)>`), "**"},
+	{regexp.MustCompile(`(?i)</?(i|em)>`), "_"},
+	{regexp.MustCompile(`(?i)</?(s|strike|del)>`), "~~"},
+	{regexp.MustCompile(`(?i)</?code>`), "`"},
+	{regexp.MustCompile(`(?i)<li[^>]*>`), "- "},
+	{regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])>`), "\n"},
+	{regexp.MustCompile(`(?s)<[^>]*>`), ""},
+	{regexp.MustCompile(`\n{3,}`), "\n\n"},
+}
+
+var teamsMentionRegex = regexp.MustCompile(`(?is)<at\s[^>]*id="(\d+)"[^>]*>.*?</at>`)
+
+type teamsIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type teamsUser struct {
+	ID                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	GivenName         string `json:"givenName"`
+	Surname           string `json:"surname"`
+	Mail              string `json:"mail"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	JobTitle          string `json:"jobTitle"`
+	AccountEnabled    *bool  `json:"accountEnabled"`
+}
+
+type teamsMember struct {
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+}
+
+type teamsTeam struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+}
+
+type teamsChannel struct {
+	ID             string `json:"id"`
+	DisplayName    string `json:"displayName"`
+	Description    string `json:"description"`
+	MembershipType string `json:"membershipType"`
+}
+
+type teamsChat struct {
+	ID       string `json:"id"`
+	Topic    string `json:"topic"`
+	ChatType string `json:"chatType"`
+}
+
+type teamsMessage struct {
+	ID                 string     `json:"id"`
+	ReplyToID          string     `json:"replyToId"`
+	MessageType        string     `json:"messageType"`
+	CreatedDateTime    time.Time  `json:"createdDateTime"`
+	LastEditedDateTime *time.Time `json:"lastEditedDateTime"`
+	DeletedDateTime    *time.Time `json:"deletedDateTime"`
+	From               *struct {
+		User *teamsIdentity `json:"user"`
+	} `json:"from"`
+	Body struct {
+		ContentType string `json:"contentType"`
+		Content     string `json:"content"`
+	} `json:"body"`
+	Attachments []struct {
+		ID          string `json:"id"`
+		ContentType string `json:"contentType"`
+		ContentURL  string `json:"contentUrl"`
+		Name        string `json:"name"`
+	} `json:"attachments"`
+	Mentions []struct {
+		ID        int `json:"id"`
+		Mentioned struct {
+			User *teamsIdentity `json:"user"`
+		} `json:"mentioned"`
+	} `json:"mentions"`
+	Reactions []struct {
+		ReactionType    string    `json:"reactionType"`
+		CreatedDateTime time.Time `json:"createdDateTime"`
+		User            struct {
+			User *teamsIdentity `json:"user"`
+		} `json:"user"`
+	} `json:"reactions"`
+	Replies []teamsMessage `json:"replies"`
+}
+
+// readGraphList reads a Microsoft Graph collection, saved either as the response of the API,
+// with the items in its value property, or as a JSON array.
+func readGraphList[T any](src fs.FS, name string) ([]T, error) {
+	var raw json.RawMessage
+	if ok, err := readJSON(src, name, &raw); err != nil || !ok {
+		return nil, err
+	}
+
+	var items []T
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("error decoding %q: %w", name, err)
+		}
+		return items, nil
+	}
+
+	var list struct {
+		Value []T `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("error decoding %q: %w", name, err)
+	}
+	return list.Value, nil
+}
+
+// readDirNames returns the names of the directories in dir, or nothing if dir doesn't exist.
+func readDirNames(src fs.FS, dir string) ([]string, error) {
+	entries, err := fs.ReadDir(src, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// convertTeams converts Microsoft Teams data exported from the Graph API, saved as:
+//
+//	users.json
+//	teams/<team id>/team.json, members.json and channels.json
+//	teams/<team id>/channels/<channel id>/members.json and messages.json
+//	chats/<chat id>/chat.json, members.json and messages.json
+//	attachments/<attachment id>/<file name>
+//
+// Messages can either list their replies or reference their parent with replyToId.
+func convertTeams(b *importBuilder) error {
+	users, err := readGraphList[teamsUser](b.source, "users.json")
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		email := user.Mail
+		if email == "" {
+			email = user.UserPrincipalName
+		}
+
+		username := strings.SplitN(user.UserPrincipalName, "@", 2)[0]
+		if username == "" {
+			username = user.DisplayName
+		}
+
+		data := imports.UserImportData{
+			Username:  model.NewPointer(username),
+			Email:     model.NewPointer(email),
+			FirstName: model.NewPointer(user.GivenName),
+			LastName:  model.NewPointer(user.Surname),
+			Position:  model.NewPointer(user.JobTitle),
+		}
+		if user.AccountEnabled != nil && !*user.AccountEnabled {
+			data.DeleteAt = model.NewPointer(model.GetMillis())
+		}
+		b.addUser(user.ID, data)
+	}
+
+	teamIDs, err := readDirNames(b.source, "teams")
+	if err != nil {
+		return err
+	}
+	chatIDs, err := readDirNames(b.source, "chats")
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 && len(teamIDs) == 0 && len(chatIDs) == 0 {
+		return errors.New("no users.json, teams or chats found in the export")
+	}
+
+	for _, teamID := range teamIDs {
+		if err := b.convertTeamsTeam(path.Join("teams", teamID), teamID); err != nil {
+			return err
+		}
+	}
+
+	for _, chatID := range chatIDs {
+		if err := b.convertTeamsChat(path.Join("chats", chatID), chatID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *importBuilder) convertTeamsTeam(dir, teamID string) error {
+	team := teamsTeam{ID: teamID, DisplayName: teamID}
+	if _, err := readJSON(b.source, path.Join(dir, "team.json"), &team); err != nil {
+		return err
+	}
+
+	teamName, ok := b.sourceTeam(team.ID, team.DisplayName, team.Description)
+	if !ok {
+		return nil
+	}
+
+	teamMembers, err := b.teamsMembers(path.Join(dir, "members.json"))
+	if err != nil {
+		return err
+	}
+	for _, username := range teamMembers {
+		b.joinTeam(username, teamName)
+	}
+
+	channels, err := readGraphList[teamsChannel](b.source, path.Join(dir, "channels.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		channelDir := path.Join(dir, "channels", channel.ID)
+
+		channelType := model.ChannelTypeOpen
+		if channel.MembershipType == "private" || channel.MembershipType == "shared" {
+			channelType = model.ChannelTypePrivate
+		}
+
+		channelName, ok := b.addChannel(channel.ID, imports.ChannelImportData{
+			Team:        model.NewPointer(teamName),
+			Name:        model.NewPointer(channel.DisplayName),
+			DisplayName: model.NewPointer(channel.DisplayName),
+			Type:        model.NewPointer(channelType),
+			Purpose:     model.NewPointer(channel.Description),
+		})
+		if !ok {
+			continue
+		}
+
+		channelMembers := teamMembers
+		if channelType == model.ChannelTypePrivate {
+			if channelMembers, err = b.teamsMembers(path.Join(channelDir, "members.json")); err != nil {
+				return err
+			}
+		}
+		for _, username := range channelMembers {
+			b.joinTeam(username, teamName, channelName)
+		}
+
+		messages, err := b.readTeamsMessages(path.Join(channelDir, "messages.json"))
+		if err != nil {
+			return err
+		}
+		b.addMessages(destination{team: teamName, channel: channelName}, messages)
+	}
+
+	return nil
+}
+
+func (b *importBuilder) convertTeamsChat(dir, chatID string) error {
+	chat := teamsChat{ID: chatID}
+	if _, err := readJSON(b.source, path.Join(dir, "chat.json"), &chat); err != nil {
+		return err
+	}
+
+	members, err := b.teamsMembers(path.Join(dir, "members.json"))
+	if err != nil {
+		return err
+	}
+
+	messages, err := b.readTeamsMessages(path.Join(dir, "messages.json"))
+	if err != nil {
+		return err
+	}
+
+	// Fall back to the authors when the members of the chat weren't exported.
+	if len(members) == 0 {
+		for _, msg := range messages {
+			members = append(members, msg.user)
+		}
+	}
+
+	displayName := chat.Topic
+	if displayName == "" {
+		displayName = chat.ID
+	}
+
+	dest, ok := b.addConversation(chat.ID, displayName, members)
+	if !ok {
+		return nil
+	}
+	b.addMessages(dest, messages)
+	return nil
+}
+
+// teamsMembers reads the members of a team, channel or chat and returns their usernames. Members
+// missing from users.json are added to the import.
+func (b *importBuilder) teamsMembers(name string) ([]string, error) {
+	members, err := readGraphList[teamsMember](b.source, name)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make([]string, 0, len(members))
+	for _, member := range members {
+		username := b.user(member.UserID)
+		if username == "" {
+			var ok bool
+			username, ok = b.addUser(member.UserID, imports.UserImportData{
+				Username: model.NewPointer(strings.SplitN(member.Email, "@", 2)[0]),
+				Email:    model.NewPointer(member.Email),
+				Nickname: model.NewPointer(member.DisplayName),
+			})
+			if !ok {
+				continue
+			}
+		}
+		usernames = append(usernames, username)
+	}
+	return usernames, nil
+}
+
+// readTeamsMessages reads the messages of a channel or chat, flattening the replies that are
+// listed inline.
+func (b *importBuilder) readTeamsMessages(name string) ([]*message, error) {
+	tMessages, err := readGraphList[teamsMessage](b.source, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*message
+	var skipped int
+	var convert func(tMessage teamsMessage, parentID string)
+	convert = func(tMessage teamsMessage, parentID string) {
+		for _, reply := range tMessage.Replies {
+			convert(reply, tMessage.ID)
+		}
+
+		if tMessage.MessageType != "message" || tMessage.DeletedDateTime != nil {
+			skipped++
+			return
+		}
+
+		if tMessage.ReplyToID != "" {
+			parentID = tMessage.ReplyToID
+		}
+		msg := b.convertTeamsMessage(tMessage)
+		msg.parentID = parentID
+		messages = append(messages, msg)
+	}
+	for _, tMessage := range tMessages {
+		convert(tMessage, "")
+	}
+
+	if skipped > 0 {
+		b.report.unmapped("message", "", "%d system and deleted messages of %q were skipped", skipped, name)
+	}
+	return messages, nil
+}
+
+func (b *importBuilder) convertTeamsMessage(tMessage teamsMessage) *message {
+	msg := &message{
+		id:       tMessage.ID,
+		createAt: tMessage.CreatedDateTime.UnixMilli(),
+	}
+	if tMessage.From != nil && tMessage.From.User != nil {
+		msg.user = b.user(tMessage.From.User.ID)
+	}
+	if tMessage.LastEditedDateTime != nil {
+		msg.editAt = tMessage.LastEditedDateTime.UnixMilli()
+	}
+
+	mentions := make(map[string]string, len(tMessage.Mentions))
+	for _, mention := range tMessage.Mentions {
+		if mention.Mentioned.User != nil {
+			if username := b.user(mention.Mentioned.User.ID); username != "" {
+				mentions[strconv.Itoa(mention.ID)] = "@" + username
+			}
+		}
+	}
+
+	msg.text = tMessage.Body.Content
+	if strings.EqualFold(tMessage.Body.ContentType, "html") {
+		msg.text = teamsHTMLToMarkdown(msg.text, mentions)
+	}
+
+	for _, tAttachment := range tMessage.Attachments {
+		if tAttachment.ContentType != "reference" {
+			b.report.unmapped("attachment", tAttachment.ID, "attachments of type %q are not supported", tAttachment.ContentType)
+			continue
+		}
+
+		sourcePath := path.Join("attachments", tAttachment.ID, tAttachment.Name)
+		if _, err := fs.Stat(b.source, sourcePath); err != nil {
+			b.report.unmapped("attachment", tAttachment.ID, "file %q is not included in the export and was linked instead", tAttachment.Name)
+			msg.text = strings.TrimSpace(msg.text + "\n" + "[" + tAttachment.Name + "](" + tAttachment.ContentURL + ")")
+			continue
+		}
+		if attachment, ok := b.addAttachment(sourcePath, tAttachment.ID, tAttachment.Name); ok {
+			msg.attachments = append(msg.attachments, attachment)
+		}
+	}
+
+	for _, reaction := range tMessage.Reactions {
+		var username string
+		if reaction.User.User != nil {
+			username = b.user(reaction.User.User.ID)
+		}
+
+		emoji := reaction.ReactionType
+		if name, ok := teamsReactionNames[emoji]; ok {
+			emoji = name
+		}
+		b.addReaction(msg, username, emoji, reaction.CreatedDateTime.UnixMilli())
+	}
+
+	return msg
+}
+
+// teamsHTMLToMarkdown converts the HTML body of a message to Markdown, replacing the mentions
+// by the usernames they were mapped to.
+func teamsHTMLToMarkdown(content string, mentions map[string]string) string {
+	content = teamsMentionRegex.ReplaceAllStringFunc(content, func(mention string) string {
+		id := teamsMentionRegex.FindStringSubmatch(mention)[1]
+		if username, ok := mentions[id]; ok {
+			return username
+		}
+		return mention
+	})
+
+	for _, replacement := range teamsHTMLReplacements {
+		content = replacement.regex.ReplaceAllString(content, replacement.rpl)
+	}
+
+	return strings.TrimSpace(html.UnescapeString(content))
+}
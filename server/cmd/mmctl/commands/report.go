@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var reportDateRanges = []string{
+	model.ReportDurationAllTime,
+	model.ReportDurationLast30Days,
+	model.ReportDurationPreviousMonth,
+	model.ReportDurationLast6Months,
+}
+
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Management of reports",
+}
+
+var ReportExportCmd = &cobra.Command{
+	Use:   "export [report-type]",
+	Short: "Export a report",
+	Long: fmt.Sprintf(`Start the export of a report. The report is delivered to you by the system bot in a direct message once it's complete.
+Report types: %s.`, strings.Join(model.BatchReportTypes, ", ")),
+	Example: `  report export users
+  report export channel_activity --date-range last_30_days --format xlsx
+  report export post_volume --team myteam --format jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(reportExportCmdF),
+}
+
+func init() {
+	ReportExportCmd.Flags().String("date-range", model.ReportDurationAllTime, fmt.Sprintf("Date range of the report. One of: %s", strings.Join(reportDateRanges, ", ")))
+	ReportExportCmd.Flags().String("format", model.ReportExportFormatCSV, fmt.Sprintf("Format of the report. One of: %s", strings.Join(model.ReportExportFormats, ", ")))
+	ReportExportCmd.Flags().String("team", "", "Only report on the given team")
+
+	ReportCmd.AddCommand(
+		ReportExportCmd,
+	)
+
+	RootCmd.AddCommand(ReportCmd)
+}
+
+func reportExportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	reportType := args[0]
+	if !model.IsValidBatchReportType(reportType) {
+		return fmt.Errorf("invalid report type %q, must be one of: %s", reportType, strings.Join(model.BatchReportTypes, ", "))
+	}
+
+	dateRange, _ := cmd.Flags().GetString("date-range")
+	if !slices.Contains(reportDateRanges, dateRange) {
+		return fmt.Errorf("invalid date range %q, must be one of: %s", dateRange, strings.Join(reportDateRanges, ", "))
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if !model.IsValidReportExportFormat(format) {
+		return fmt.Errorf("invalid format %q, must be one of: %s", format, strings.Join(model.ReportExportFormats, ", "))
+	}
+
+	options := &model.ReportExportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{DateRange: dateRange},
+		Format:               format,
+	}
+
+	if teamArg, _ := cmd.Flags().GetString("team"); teamArg != "" {
+		team := getTeamFromTeamArg(c, teamArg)
+		if team == nil {
+			return fmt.Errorf("unable to find team %q", teamArg)
+		}
+		options.Team = team.Id
+	}
+
+	if _, err := c.StartBatchReportExport(context.TODO(), reportType, options); err != nil {
+		return errors.Wrapf(err, "failed to start the export of the %s report", reportType)
+	}
+
+	printer.Print(fmt.Sprintf("Export of the %s report started. It will be sent to you in a direct message once it's complete.", reportType))
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func newReportExportTestCmd(dateRange, format, team string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("date-range", dateRange, "")
+	cmd.Flags().String("format", format, "")
+	cmd.Flags().String("team", team, "")
+	return cmd
+}
+
+func (s *MmctlUnitTestSuite) TestReportExportCmdF() {
+	s.Run("should start the export of a report", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			StartBatchReportExport(context.TODO(), model.ReportTypeChannelActivity, &model.ReportExportOptions{
+				ReportingBaseOptions: model.ReportingBaseOptions{DateRange: model.ReportDurationLast30Days},
+				Format:               model.ReportExportFormatXLSX,
+			}).
+			Return(&model.Response{StatusCode: 200}, nil).
+			Times(1)
+
+		err := reportExportCmdF(s.client, newReportExportTestCmd(model.ReportDurationLast30Days, model.ReportExportFormatXLSX, ""), []string{model.ReportTypeChannelActivity})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("Export of the channel_activity report started. It will be sent to you in a direct message once it's complete.", printer.GetLines()[0])
+	})
+
+	s.Run("should filter the report by team", func() {
+		printer.Clean()
+		team := &model.Team{Id: model.NewId(), Name: "myteam"}
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Name, "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), team.Name, "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			StartBatchReportExport(context.TODO(), model.ReportTypePostVolume, &model.ReportExportOptions{
+				ReportingBaseOptions: model.ReportingBaseOptions{DateRange: model.ReportDurationAllTime},
+				Team:                 team.Id,
+				Format:               model.ReportExportFormatCSV,
+			}).
+			Return(&model.Response{StatusCode: 200}, nil).
+			Times(1)
+
+		err := reportExportCmdF(s.client, newReportExportTestCmd(model.ReportDurationAllTime, model.ReportExportFormatCSV, team.Name), []string{model.ReportTypePostVolume})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("should fail on an unknown team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		err := reportExportCmdF(s.client, newReportExportTestCmd(model.ReportDurationAllTime, model.ReportExportFormatCSV, "unknown"), []string{model.ReportTypePostVolume})
+		s.Require().EqualError(err, `unable to find team "unknown"`)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("should fail on invalid arguments", func() {
+		printer.Clean()
+
+		err := reportExportCmdF(s.client, newReportExportTestCmd(model.ReportDurationAllTime, model.ReportExportFormatCSV, ""), []string{"invalid"})
+		s.Require().ErrorContains(err, `invalid report type "invalid"`)
+
+		err = reportExportCmdF(s.client, newReportExportTestCmd("yesterday", model.ReportExportFormatCSV, ""), []string{model.ReportTypeGuestAccess})
+		s.Require().ErrorContains(err, `invalid date range "yesterday"`)
+
+		err = reportExportCmdF(s.client, newReportExportTestCmd(model.ReportDurationAllTime, "pdf", ""), []string{model.ReportTypeGuestAccess})
+		s.Require().ErrorContains(err, `invalid format "pdf"`)
+
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("should fail when the export can't be started", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			StartBatchReportExport(context.TODO(), model.ReportTypeGuestAccess, &model.ReportExportOptions{
+				ReportingBaseOptions: model.ReportingBaseOptions{DateRange: model.ReportDurationAllTime},
+				Format:               model.ReportExportFormatCSV,
+			}).
+			Return(&model.Response{StatusCode: 500}, errors.New("mock error")).
+			Times(1)
+
+		err := reportExportCmdF(s.client, newReportExportTestCmd(model.ReportDurationAllTime, model.ReportExportFormatCSV, ""), []string{model.ReportTypeGuestAccess})
+		s.Require().EqualError(err, "failed to start the export of the guest_access report: mock error")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"text/template"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var UserWebAuthnCmd = &cobra.Command{
+	Use:   "webauthn",
+	Short: "Manage the security keys of users",
+}
+
+var UserWebAuthnListCmd = &cobra.Command{
+	Use:     "list [user]",
+	Short:   "List the security keys of a user",
+	Long:    "List the WebAuthn security keys and passkeys that a user registered as a second factor.",
+	Example: "  user webauthn list user@example.com",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(userWebAuthnListCmdF),
+}
+
+var UserWebAuthnRevokeCmd = &cobra.Command{
+	Use:   "revoke [user] [credential-ids]",
+	Short: "Revoke security keys of a user",
+	Long: `Revoke WebAuthn security keys of a user, for example when a key has been lost.
+MFA is turned off for the user once they have no second factor left.`,
+	Example: "  user webauthn revoke user@example.com w4gsj9spk7fq3ewtd4ngyr4cpy",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(userWebAuthnRevokeCmdF),
+}
+
+func init() {
+	UserWebAuthnCmd.AddCommand(
+		UserWebAuthnListCmd,
+		UserWebAuthnRevokeCmd,
+	)
+
+	UserCmd.AddCommand(UserWebAuthnCmd)
+}
+
+func formatMillis(millis int64) string {
+	if millis == 0 {
+		return "never"
+	}
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+func userWebAuthnListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	user := getUserFromUserArg(c, args[0])
+	if user == nil {
+		return errors.Errorf("could not retrieve user information of %q", args[0])
+	}
+
+	credentials, _, err := c.GetWebAuthnCredentials(context.TODO(), user.Id)
+	if err != nil {
+		return errors.Wrapf(err, "could not retrieve the security keys of %q", args[0])
+	}
+
+	if len(credentials) == 0 {
+		printer.Print("There are no security keys for " + args[0])
+		return nil
+	}
+
+	tpl := template.Must(template.New("").Funcs(template.FuncMap{"millis": formatMillis}).
+		Parse("{{.Id}}: {{.Name}} (registered: {{millis .CreateAt}}, last used: {{millis .LastUsedAt}})"))
+	for _, credential := range credentials {
+		printer.PrintPreparedT(tpl, credential)
+	}
+
+	return nil
+}
+
+func userWebAuthnRevokeCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	user := getUserFromUserArg(c, args[0])
+	if user == nil {
+		return errors.Errorf("could not retrieve user information of %q", args[0])
+	}
+
+	for _, credentialID := range args[1:] {
+		if _, err := c.RevokeWebAuthnCredential(context.TODO(), user.Id, credentialID); err != nil {
+			return errors.Wrapf(err, "could not revoke security key %q", credentialID)
+		}
+		printer.Print("Security key " + credentialID + " revoked")
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestUserWebAuthnListCmd() {
+	s.Run("List the security keys of a user", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Email: "user@example.com"}
+		credentials := []*model.WebAuthnCredential{
+			{Id: model.NewId(), UserId: user.Id, Name: "YubiKey", CreateAt: 1700000000000, LastUsedAt: 1700000060000},
+			{Id: model.NewId(), UserId: user.Id, Name: "Laptop", CreateAt: 1700000000000},
+		}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetWebAuthnCredentials(context.TODO(), user.Id).
+			Return(credentials, &model.Response{}, nil).
+			Times(1)
+
+		err := userWebAuthnListCmdF(s.client, &cobra.Command{}, []string{user.Email})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(credentials[0], printer.GetLines()[0])
+		s.Require().Equal(credentials[1], printer.GetLines()[1])
+		s.Require().Empty(printer.GetErrorLines())
+	})
+
+	s.Run("User without security keys", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Email: "user@example.com"}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetWebAuthnCredentials(context.TODO(), user.Id).
+			Return([]*model.WebAuthnCredential{}, &model.Response{}, nil).
+			Times(1)
+
+		err := userWebAuthnListCmdF(s.client, &cobra.Command{}, []string{user.Email})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("There are no security keys for user@example.com", printer.GetLines()[0])
+	})
+
+	s.Run("Fail to list the security keys", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Email: "user@example.com"}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetWebAuthnCredentials(context.TODO(), user.Id).
+			Return(nil, &model.Response{StatusCode: http.StatusForbidden}, errors.New("mock error")).
+			Times(1)
+
+		err := userWebAuthnListCmdF(s.client, &cobra.Command{}, []string{user.Email})
+		s.Require().EqualError(err, `could not retrieve the security keys of "user@example.com": mock error`)
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestUserWebAuthnRevokeCmd() {
+	s.Run("Revoke security keys", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Email: "user@example.com"}
+		credentialIDs := []string{model.NewId(), model.NewId()}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+
+		for _, credentialID := range credentialIDs {
+			s.client.
+				EXPECT().
+				RevokeWebAuthnCredential(context.TODO(), user.Id, credentialID).
+				Return(&model.Response{StatusCode: http.StatusOK}, nil).
+				Times(1)
+		}
+
+		err := userWebAuthnRevokeCmdF(s.client, &cobra.Command{}, append([]string{user.Email}, credentialIDs...))
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal("Security key "+credentialIDs[0]+" revoked", printer.GetLines()[0])
+	})
+
+	s.Run("Fail to revoke a security key", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Email: "user@example.com"}
+		credentialID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			RevokeWebAuthnCredential(context.TODO(), user.Id, credentialID).
+			Return(&model.Response{StatusCode: http.StatusNotFound}, errors.New("mock error")).
+			Times(1)
+
+		err := userWebAuthnRevokeCmdF(s.client, &cobra.Command{}, []string{user.Email, credentialID})
+		s.Require().EqualError(err, `could not revoke security key "`+credentialID+`": mock error`)
+		s.Require().Empty(printer.GetLines())
+	})
+
+	s.Run("Unknown user", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		err := userWebAuthnRevokeCmdF(s.client, &cobra.Command{}, []string{"unknown", model.NewId()})
+		s.Require().EqualError(err, `could not retrieve user information of "unknown"`)
+	})
+}
@@ -5,6 +5,10 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-multierror"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
@@ -46,7 +50,7 @@ var CreateIncomingWebhookCmd = &cobra.Command{
 	Use:     "create-incoming",
 	Short:   "Create incoming webhook",
 	Long:    "create incoming webhook which allows external posting of messages to specific channel",
-	Example: "  webhook create-incoming --channel [channelID] --user [userID] --display-name [displayName] --description [webhookDescription] --lock-to-channel --icon [iconURL]",
+	Example: "  webhook create-incoming --channel [channelID] --user [userID] --display-name [displayName] --description [webhookDescription] --lock-to-channel --icon [iconURL] --template-file [templateFile]",
 	RunE:    withClient(createIncomingWebhookCmdF),
 }
 
@@ -55,10 +59,20 @@ var ModifyIncomingWebhookCmd = &cobra.Command{
 	Short:   "Modify incoming webhook",
 	Long:    "Modify existing incoming webhook by changing its title, description, channel or icon url",
 	Args:    cobra.ExactArgs(1),
-	Example: "  webhook modify-incoming [webhookID] --channel [channelID] --display-name [displayName] --description [webhookDescription] --lock-to-channel --icon [iconURL]",
+	Example: "  webhook modify-incoming [webhookID] --channel [channelID] --display-name [displayName] --description [webhookDescription] --lock-to-channel --icon [iconURL] --template-file [templateFile]",
 	RunE:    withClient(modifyIncomingWebhookCmdF),
 }
 
+var DryRunIncomingWebhookTemplateCmd = &cobra.Command{
+	Use:   "dry-run-template [webhookID]",
+	Short: "Render a sample payload through an incoming webhook template",
+	Long: `Render the JSON payload in --payload-file through the payload template of the incoming webhook specified by [webhookID] without creating a post.
+A template can be given with --template-file to try it out before saving it. Use --format json to see the attachments, props and priority of the rendered post.`,
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook dry-run-template [webhookID] --payload-file payload.json --template-file template.tmpl",
+	RunE:    withClient(dryRunIncomingWebhookTemplateCmdF),
+}
+
 var CreateOutgoingWebhookCmd = &cobra.Command{
 	Use:   "create-outgoing",
 	Short: "Create outgoing webhook",
@@ -86,6 +100,48 @@ var DeleteWebhookCmd = &cobra.Command{
 	RunE:    withClient(deleteWebhookCmdF),
 }
 
+var RegenWebhookSigningSecretCmd = &cobra.Command{
+	Use:     "regen-signing-secret [webhookID]",
+	Short:   "Regenerate outgoing webhook signing secret",
+	Long:    "Generate a new secret to sign the requests of the outgoing webhook specified by [webhookID], enabling request signing if needed",
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook regen-signing-secret w16zb5tu3n1zkqo18goqry1je",
+	RunE:    withClient(regenWebhookSigningSecretCmdF),
+}
+
+var RemoveWebhookSigningSecretCmd = &cobra.Command{
+	Use:     "remove-signing-secret [webhookID]",
+	Short:   "Remove outgoing webhook signing secret",
+	Long:    "Stop signing the requests of the outgoing webhook specified by [webhookID]",
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook remove-signing-secret w16zb5tu3n1zkqo18goqry1je",
+	RunE:    withClient(removeWebhookSigningSecretCmdF),
+}
+
+var WebhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries",
+	Short: "Management of outgoing webhook deliveries",
+}
+
+var ListWebhookDeliveriesCmd = &cobra.Command{
+	Use:     "list [webhookID]",
+	Short:   "List outgoing webhook deliveries",
+	Long:    "List the most recent delivery attempts of the outgoing webhook specified by [webhookID]",
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook deliveries list w16zb5tu3n1zkqo18goqry1je --status failed",
+	RunE:    withClient(listWebhookDeliveriesCmdF),
+}
+
+var ReplayWebhookDeliveriesCmd = &cobra.Command{
+	Use:   "replay [webhookID] [deliveryIDs]",
+	Short: "Replay failed outgoing webhook deliveries",
+	Long:  "Send the payload of failed deliveries of the outgoing webhook specified by [webhookID] again",
+	Example: `  webhook deliveries replay w16zb5tu3n1zkqo18goqry1je 8cnbgyd5xpdhbd7tq1xf59bebh
+  webhook deliveries replay w16zb5tu3n1zkqo18goqry1je --all-failed`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: withClient(replayWebhookDeliveriesCmdF),
+}
+
 func listWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
 	var teams []*model.Team
 
@@ -164,6 +220,7 @@ func createIncomingWebhookCmdF(c client.Client, command *cobra.Command, args []s
 	description, _ := command.Flags().GetString("description")
 	iconURL, _ := command.Flags().GetString("icon")
 	channelLocked, _ := command.Flags().GetBool("lock-to-channel")
+	templateFile, _ := command.Flags().GetString("template-file")
 
 	incomingWebhook := &model.IncomingWebhook{
 		ChannelId:     channel.Id,
@@ -175,6 +232,14 @@ func createIncomingWebhookCmdF(c client.Client, command *cobra.Command, args []s
 		UserId:        user.Id,
 	}
 
+	if templateFile != "" {
+		template, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("unable to read template file %q: %w", templateFile, err)
+		}
+		incomingWebhook.Template = string(template)
+	}
+
 	createdIncoming, _, err := c.CreateIncomingWebhook(context.TODO(), incomingWebhook)
 	if err != nil {
 		printer.PrintError("Unable to create webhook")
@@ -222,6 +287,21 @@ func modifyIncomingWebhookCmdF(c client.Client, command *cobra.Command, args []s
 	}
 	channelLocked, _ := command.Flags().GetBool("lock-to-channel")
 	updatedHook.ChannelLocked = channelLocked
+	templateFile, _ := command.Flags().GetString("template-file")
+	removeTemplate, _ := command.Flags().GetBool("remove-template")
+	if templateFile != "" && removeTemplate {
+		return errors.New("the --template-file and --remove-template flags cannot be used together")
+	}
+	if templateFile != "" {
+		template, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("unable to read template file %q: %w", templateFile, err)
+		}
+		updatedHook.Template = string(template)
+	}
+	if removeTemplate {
+		updatedHook.Template = ""
+	}
 
 	var newHook *model.IncomingWebhook
 	if newHook, _, err = c.UpdateIncomingWebhook(context.TODO(), updatedHook); err != nil {
@@ -233,6 +313,36 @@ func modifyIncomingWebhookCmdF(c client.Client, command *cobra.Command, args []s
 	return nil
 }
 
+func dryRunIncomingWebhookTemplateCmdF(c client.Client, command *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	payloadFile, _ := command.Flags().GetString("payload-file")
+	payload, err := os.ReadFile(payloadFile)
+	if err != nil {
+		return fmt.Errorf("unable to read payload file %q: %w", payloadFile, err)
+	}
+
+	dryRun := &model.IncomingWebhookTemplateDryRunRequest{Payload: payload}
+
+	templateFile, _ := command.Flags().GetString("template-file")
+	if templateFile != "" {
+		template, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("unable to read template file %q: %w", templateFile, err)
+		}
+		dryRun.Template = string(template)
+	}
+
+	rendered, _, err := c.DryRunIncomingWebhookTemplate(context.TODO(), args[0], dryRun)
+	if err != nil {
+		printer.PrintError("Unable to render the webhook template")
+		return err
+	}
+
+	printer.PrintT("{{.Text}}", rendered)
+	return nil
+}
+
 func createOutgoingWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
 	printer.SetSingle(true)
 
@@ -296,6 +406,18 @@ func createOutgoingWebhookCmdF(c client.Client, command *cobra.Command, args []s
 
 	tpl := `Id: {{.Id}}
 Display Name: {{.DisplayName}}`
+
+	if sign, _ := command.Flags().GetBool("sign"); sign {
+		signedOutgoing, _, err := c.RegenOutgoingHookSigningSecret(context.TODO(), createdOutgoing.Id)
+		if err != nil {
+			printer.PrintError("Unable to generate a signing secret for outgoing webhook " + createdOutgoing.Id)
+			return err
+		}
+		createdOutgoing = signedOutgoing
+		tpl += `
+Signing Secret: {{.SigningSecret}}`
+	}
+
 	printer.PrintT(tpl, createdOutgoing)
 
 	return nil
@@ -377,6 +499,13 @@ func modifyOutgoingWebhookCmdF(c client.Client, command *cobra.Command, args []s
 		return err
 	}
 
+	if enable, _ := command.Flags().GetBool("enable"); enable {
+		if newHook, _, err = c.EnableOutgoingHook(context.TODO(), newHook.Id); err != nil {
+			printer.PrintError("Unable to enable outgoing webhook")
+			return err
+		}
+	}
+
 	printer.PrintT("Webhook {{.Id}} successfully updated", newHook)
 	return nil
 }
@@ -426,6 +555,87 @@ func showWebhookCmdF(c client.Client, command *cobra.Command, args []string) err
 	return errors.New("Webhook with id '" + webhookID + "' not found")
 }
 
+func regenWebhookSigningSecretCmdF(c client.Client, command *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	hook, _, err := c.RegenOutgoingHookSigningSecret(context.TODO(), args[0])
+	if err != nil {
+		return errors.Wrap(err, "unable to regenerate the signing secret of webhook '"+args[0]+"'")
+	}
+
+	printer.PrintT("Signing secret of webhook {{.Id}}: {{.SigningSecret}}", hook)
+	return nil
+}
+
+func removeWebhookSigningSecretCmdF(c client.Client, command *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	hook, _, err := c.RemoveOutgoingHookSigningSecret(context.TODO(), args[0])
+	if err != nil {
+		return errors.Wrap(err, "unable to remove the signing secret of webhook '"+args[0]+"'")
+	}
+
+	printer.PrintT("Requests of webhook {{.Id}} are no longer signed", hook)
+	return nil
+}
+
+func listWebhookDeliveriesCmdF(c client.Client, command *cobra.Command, args []string) error {
+	status, _ := command.Flags().GetString("status")
+	if status != "" && !model.IsValidOutgoingWebhookDeliveryStatus(status) {
+		return fmt.Errorf("invalid status %q", status)
+	}
+	page, _ := command.Flags().GetInt("page")
+	perPage, _ := command.Flags().GetInt("per-page")
+
+	deliveries, _, err := c.GetOutgoingWebhookDeliveries(context.TODO(), args[0], status, page, perPage)
+	if err != nil {
+		return errors.Wrap(err, "unable to list deliveries for webhook '"+args[0]+"'")
+	}
+
+	for _, delivery := range deliveries {
+		printer.PrintT("{{.Id}}: {{.Status}} (attempt {{.Attempt}}, status code {{.StatusCode}}, {{.Latency}}ms) {{.CallbackURL}}", delivery)
+	}
+
+	return nil
+}
+
+func replayWebhookDeliveriesCmdF(c client.Client, command *cobra.Command, args []string) error {
+	hookID := args[0]
+	deliveryIDs := args[1:]
+
+	allFailed, _ := command.Flags().GetBool("all-failed")
+	if allFailed == (len(deliveryIDs) > 0) {
+		return errors.New("either delivery IDs or --all-failed must be specified")
+	}
+
+	if allFailed {
+		// Collect every failed delivery before replaying any of them, as replays
+		// that fail again add new failed deliveries to the list.
+		failed, err := getPages(func(page, numPerPage int, etag string) ([]*model.OutgoingWebhookDelivery, *model.Response, error) {
+			return c.GetOutgoingWebhookDeliveries(context.TODO(), hookID, model.OutgoingWebhookDeliveryStatusFailed, page, numPerPage)
+		}, DefaultPageSize)
+		if err != nil {
+			return errors.Wrap(err, "unable to list failed deliveries for webhook '"+hookID+"'")
+		}
+		for _, delivery := range failed {
+			deliveryIDs = append(deliveryIDs, delivery.Id)
+		}
+	}
+
+	var errs *multierror.Error
+	for _, deliveryID := range deliveryIDs {
+		delivery, _, err := c.ReplayOutgoingWebhookDelivery(context.TODO(), hookID, deliveryID)
+		if err != nil {
+			printer.PrintError("Unable to replay delivery '" + deliveryID + "'")
+			errs = multierror.Append(errs, fmt.Errorf("unable to replay delivery %q: %w", deliveryID, err))
+			continue
+		}
+		printer.PrintT("Delivery "+deliveryID+" replayed as {{.Id}}: {{.Status}} (status code {{.StatusCode}})", delivery)
+	}
+
+	return errs.ErrorOrNil()
+}
+
 func init() {
 	CreateIncomingWebhookCmd.Flags().String("channel", "", "Channel ID (required)")
 	_ = CreateIncomingWebhookCmd.MarkFlagRequired("channel")
@@ -435,12 +645,19 @@ func init() {
 	CreateIncomingWebhookCmd.Flags().String("description", "", "Incoming webhook description")
 	CreateIncomingWebhookCmd.Flags().String("icon", "", "Icon URL")
 	CreateIncomingWebhookCmd.Flags().Bool("lock-to-channel", false, "Lock to channel")
+	CreateIncomingWebhookCmd.Flags().String("template-file", "", "File containing a Go template that maps the request payload into the post")
 
 	ModifyIncomingWebhookCmd.Flags().String("channel", "", "Channel ID")
 	ModifyIncomingWebhookCmd.Flags().String("display-name", "", "Incoming webhook display name")
 	ModifyIncomingWebhookCmd.Flags().String("description", "", "Incoming webhook description")
 	ModifyIncomingWebhookCmd.Flags().String("icon", "", "Icon URL")
 	ModifyIncomingWebhookCmd.Flags().Bool("lock-to-channel", false, "Lock to channel")
+	ModifyIncomingWebhookCmd.Flags().String("template-file", "", "File containing a Go template that maps the request payload into the post")
+	ModifyIncomingWebhookCmd.Flags().Bool("remove-template", false, "Remove the payload template of the webhook")
+
+	DryRunIncomingWebhookTemplateCmd.Flags().String("payload-file", "", "File containing the sample JSON payload (required)")
+	_ = DryRunIncomingWebhookTemplateCmd.MarkFlagRequired("payload-file")
+	DryRunIncomingWebhookTemplateCmd.Flags().String("template-file", "", "File containing the template to render instead of the one stored on the webhook")
 
 	CreateOutgoingWebhookCmd.Flags().String("team", "", "Team name or ID (required)")
 	_ = CreateOutgoingWebhookCmd.MarkFlagRequired("team")
@@ -457,6 +674,7 @@ func init() {
 	CreateOutgoingWebhookCmd.Flags().StringArray("url", []string{}, "Callback URL (required)")
 	_ = CreateOutgoingWebhookCmd.MarkFlagRequired("url")
 	CreateOutgoingWebhookCmd.Flags().String("content-type", "", "Content-type")
+	CreateOutgoingWebhookCmd.Flags().Bool("sign", false, "Sign the webhook requests with a generated secret")
 
 	ModifyOutgoingWebhookCmd.Flags().String("channel", "", "Channel name or ID")
 	ModifyOutgoingWebhookCmd.Flags().String("display-name", "", "Outgoing webhook display name")
@@ -466,15 +684,31 @@ func init() {
 	ModifyOutgoingWebhookCmd.Flags().String("icon", "", "Icon URL")
 	ModifyOutgoingWebhookCmd.Flags().StringArray("url", []string{}, "Callback URL")
 	ModifyOutgoingWebhookCmd.Flags().String("content-type", "", "Content-type")
+	ModifyOutgoingWebhookCmd.Flags().Bool("enable", false, "Re-enable a webhook that was disabled after failing repeatedly")
+
+	ListWebhookDeliveriesCmd.Flags().String("status", "", "Only list deliveries with this status (retry_pending or failed)")
+	ListWebhookDeliveriesCmd.Flags().Int("page", 0, "Page number to fetch for the list of deliveries")
+	ListWebhookDeliveriesCmd.Flags().Int("per-page", DefaultPageSize, "Number of deliveries to be fetched")
+
+	ReplayWebhookDeliveriesCmd.Flags().Bool("all-failed", false, "Replay every failed delivery of the webhook")
+
+	WebhookDeliveriesCmd.AddCommand(
+		ListWebhookDeliveriesCmd,
+		ReplayWebhookDeliveriesCmd,
+	)
 
 	WebhookCmd.AddCommand(
 		ListWebhookCmd,
 		CreateIncomingWebhookCmd,
 		ModifyIncomingWebhookCmd,
+		DryRunIncomingWebhookTemplateCmd,
 		CreateOutgoingWebhookCmd,
 		ModifyOutgoingWebhookCmd,
 		DeleteWebhookCmd,
 		ShowWebhookCmd,
+		RegenWebhookSigningSecretCmd,
+		RemoveWebhookSigningSecretCmd,
+		WebhookDeliveriesCmd,
 	)
 
 	RootCmd.AddCommand(WebhookCmd)
@@ -6,6 +6,8 @@ package commands
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 
 	gomock "github.com/golang/mock/gomock"
@@ -414,6 +416,163 @@ func (s *MmctlUnitTestSuite) TestModifyIncomingWebhookCmd() {
 		s.Len(printer.GetErrorLines(), 1)
 		s.Require().Equal("Unable to modify incoming webhook", printer.GetErrorLines()[0])
 	})
+
+	s.Run("Successfully set and remove the payload template", func() {
+		printer.Clean()
+
+		template := "{{ .alert.title }}"
+		templateFile := filepath.Join(s.T().TempDir(), "template.tmpl")
+		s.Require().NoError(os.WriteFile(templateFile, []byte(template), 0600))
+
+		mockIncomingWebhook := model.IncomingWebhook{
+			Id:        incomingWebhookID,
+			ChannelId: channelID,
+		}
+		updatedIncomingWebhook := mockIncomingWebhook
+		updatedIncomingWebhook.Template = template
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("template-file", templateFile, "")
+		cmd.Flags().Bool("remove-template", false, "")
+
+		s.client.
+			EXPECT().
+			GetIncomingWebhook(context.TODO(), incomingWebhookID, "").
+			Return(&mockIncomingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UpdateIncomingWebhook(context.TODO(), &updatedIncomingWebhook).
+			Return(&updatedIncomingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		err := modifyIncomingWebhookCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Nil(err)
+		s.Require().Equal(template, mockIncomingWebhook.Template)
+
+		cmd = &cobra.Command{}
+		cmd.Flags().String("template-file", "", "")
+		cmd.Flags().Bool("remove-template", true, "")
+
+		s.client.
+			EXPECT().
+			GetIncomingWebhook(context.TODO(), incomingWebhookID, "").
+			Return(&updatedIncomingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UpdateIncomingWebhook(context.TODO(), &model.IncomingWebhook{Id: incomingWebhookID, ChannelId: channelID}).
+			Return(&mockIncomingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		err = modifyIncomingWebhookCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Nil(err)
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Template file and remove template are mutually exclusive", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("template-file", "template.tmpl", "")
+		cmd.Flags().Bool("remove-template", true, "")
+
+		s.client.
+			EXPECT().
+			GetIncomingWebhook(context.TODO(), incomingWebhookID, "").
+			Return(&model.IncomingWebhook{Id: incomingWebhookID}, &model.Response{}, nil).
+			Times(1)
+
+		err := modifyIncomingWebhookCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Error(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestDryRunIncomingWebhookTemplateCmd() {
+	incomingWebhookID := "incomingWebhookID"
+	payload := `{"alert": {"title": "Disk full"}}`
+
+	payloadFile := filepath.Join(s.T().TempDir(), "payload.json")
+	s.Require().NoError(os.WriteFile(payloadFile, []byte(payload), 0600))
+
+	s.Run("Successfully render the stored template", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("payload-file", payloadFile, "")
+		cmd.Flags().String("template-file", "", "")
+
+		rendered := &model.IncomingWebhookRequest{Text: "Disk full"}
+
+		s.client.
+			EXPECT().
+			DryRunIncomingWebhookTemplate(context.TODO(), incomingWebhookID, &model.IncomingWebhookTemplateDryRunRequest{Payload: []byte(payload)}).
+			Return(rendered, &model.Response{}, nil).
+			Times(1)
+
+		err := dryRunIncomingWebhookTemplateCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(rendered, printer.GetLines()[0])
+	})
+
+	s.Run("Render a template from a file", func() {
+		printer.Clean()
+
+		template := "{{ .alert.title | upper }}"
+		templateFile := filepath.Join(s.T().TempDir(), "template.tmpl")
+		s.Require().NoError(os.WriteFile(templateFile, []byte(template), 0600))
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("payload-file", payloadFile, "")
+		cmd.Flags().String("template-file", templateFile, "")
+
+		rendered := &model.IncomingWebhookRequest{Text: "DISK FULL"}
+
+		s.client.
+			EXPECT().
+			DryRunIncomingWebhookTemplate(context.TODO(), incomingWebhookID, &model.IncomingWebhookTemplateDryRunRequest{Template: template, Payload: []byte(payload)}).
+			Return(rendered, &model.Response{}, nil).
+			Times(1)
+
+		err := dryRunIncomingWebhookTemplateCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Require().Equal(rendered, printer.GetLines()[0])
+	})
+
+	s.Run("Rendering error", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("payload-file", payloadFile, "")
+		cmd.Flags().String("template-file", "", "")
+
+		s.client.
+			EXPECT().
+			DryRunIncomingWebhookTemplate(context.TODO(), incomingWebhookID, gomock.Any()).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := dryRunIncomingWebhookTemplateCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+		s.Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("Unable to render the webhook template", printer.GetErrorLines()[0])
+	})
+
+	s.Run("Missing payload file", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("payload-file", filepath.Join(s.T().TempDir(), "missing.json"), "")
+
+		err := dryRunIncomingWebhookTemplateCmdF(s.client, cmd, []string{incomingWebhookID})
+		s.Require().Error(err)
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestCreateOutgoingWebhookCmd() {
@@ -477,6 +636,68 @@ func (s *MmctlUnitTestSuite) TestCreateOutgoingWebhookCmd() {
 		s.Require().Equal(&createdOutgoingWebhook, printer.GetLines()[0])
 	})
 
+	s.Run("Successfully create signed outgoing webhook", func() {
+		printer.Clean()
+
+		mockTeam := model.Team{
+			Id: teamID,
+		}
+		mockUser := model.User{
+			Id:       userID,
+			Email:    emailID,
+			Username: userName,
+		}
+		mockOutgoingWebhook := model.OutgoingWebhook{
+			CreatorId:    userID,
+			Username:     userName,
+			TeamId:       teamID,
+			TriggerWords: []string{},
+			TriggerWhen:  0,
+			CallbackURLs: []string{},
+		}
+
+		createdOutgoingWebhook := mockOutgoingWebhook
+		createdOutgoingWebhook.Id = outgoingWebhookID
+		signedOutgoingWebhook := createdOutgoingWebhook
+		signedOutgoingWebhook.SigningSecret = model.NewOutgoingWebhookSigningSecret()
+
+		signCmd := &cobra.Command{}
+		signCmd.Flags().String("team", teamID, "")
+		signCmd.Flags().String("user", emailID, "")
+		signCmd.Flags().String("trigger-when", triggerWhen, "")
+		signCmd.Flags().Bool("sign", true, "")
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), emailID, "").
+			Return(&mockUser, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			CreateOutgoingWebhook(context.TODO(), &mockOutgoingWebhook).
+			Return(&createdOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			RegenOutgoingHookSigningSecret(context.TODO(), outgoingWebhookID).
+			Return(&signedOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		err := createOutgoingWebhookCmdF(s.client, signCmd, []string{})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(&signedOutgoingWebhook, printer.GetLines()[0])
+	})
+
 	s.Run("Create outgoing webhook error", func() {
 		printer.Clean()
 
@@ -564,6 +785,49 @@ func (s *MmctlUnitTestSuite) TestModifyOutgoingWebhookCmd() {
 		s.Require().Equal(&updatedOutgoingWebhook, printer.GetLines()[0])
 	})
 
+	s.Run("Successfully modify and enable outgoing webhook", func() {
+		printer.Clean()
+
+		mockOutgoingWebhook := model.OutgoingWebhook{
+			Id:           outgoingWebhookID,
+			TriggerWords: []string{},
+			CallbackURLs: []string{},
+			DisabledAt:   model.GetMillis(),
+		}
+
+		enabledOutgoingWebhook := mockOutgoingWebhook
+		enabledOutgoingWebhook.DisabledAt = 0
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringArray("url", []string{}, "")
+		cmd.Flags().StringArray("trigger-word", []string{}, "")
+		cmd.Flags().Bool("enable", false, "")
+		_ = cmd.Flags().Set("enable", "true")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhook(context.TODO(), outgoingWebhookID).
+			Return(&mockOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UpdateOutgoingWebhook(context.TODO(), &mockOutgoingWebhook).
+			Return(&mockOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			EnableOutgoingHook(context.TODO(), outgoingWebhookID).
+			Return(&enabledOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		err := modifyOutgoingWebhookCmdF(s.client, cmd, []string{outgoingWebhookID})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Require().Equal(&enabledOutgoingWebhook, printer.GetLines()[0])
+	})
+
 	s.Run("Modify outgoing webhook error", func() {
 		printer.Clean()
 
@@ -788,3 +1052,219 @@ func (s *MmctlUnitTestSuite) TestShowWebhookCmd() {
 		s.Require().Equal("Webhook with id '"+nonExistentID+"' not found", err.Error())
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestListWebhookDeliveriesCmd() {
+	hookID := model.NewId()
+
+	s.Run("Successfully list deliveries", func() {
+		printer.Clean()
+
+		mockDeliveries := []*model.OutgoingWebhookDelivery{
+			{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusFailed, StatusCode: http.StatusInternalServerError},
+			{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusSuccess, StatusCode: http.StatusOK},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", "", "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 50, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), hookID, "", 0, 50).
+			Return(mockDeliveries, &model.Response{}, nil).
+			Times(1)
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{hookID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(mockDeliveries[0], printer.GetLines()[0])
+		s.Require().Equal(mockDeliveries[1], printer.GetLines()[1])
+	})
+
+	s.Run("Invalid status", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", "broken", "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 50, "")
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{hookID})
+		s.Require().EqualError(err, `invalid status "broken"`)
+		s.Len(printer.GetLines(), 0)
+	})
+
+	s.Run("List deliveries error", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", model.OutgoingWebhookDeliveryStatusFailed, "")
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 50, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), hookID, model.OutgoingWebhookDeliveryStatusFailed, 1, 50).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{hookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestReplayWebhookDeliveriesCmd() {
+	hookID := model.NewId()
+
+	s.Run("Replay the given deliveries", func() {
+		printer.Clean()
+
+		deliveryID := model.NewId()
+		replayed := &model.OutgoingWebhookDelivery{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusSuccess}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all-failed", false, "")
+
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), hookID, deliveryID).
+			Return(replayed, &model.Response{}, nil).
+			Times(1)
+
+		err := replayWebhookDeliveriesCmdF(s.client, cmd, []string{hookID, deliveryID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(replayed, printer.GetLines()[0])
+	})
+
+	s.Run("Replay all failed deliveries", func() {
+		printer.Clean()
+
+		failed := []*model.OutgoingWebhookDelivery{
+			{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusFailed},
+			{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusFailed},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all-failed", true, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), hookID, model.OutgoingWebhookDeliveryStatusFailed, 0, DefaultPageSize).
+			Return(failed, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), hookID, model.OutgoingWebhookDeliveryStatusFailed, 1, DefaultPageSize).
+			Return([]*model.OutgoingWebhookDelivery{}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), hookID, failed[0].Id).
+			Return(&model.OutgoingWebhookDelivery{Id: model.NewId()}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), hookID, failed[1].Id).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := replayWebhookDeliveriesCmdF(s.client, cmd, []string{hookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 1)
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("Unable to replay delivery '"+failed[1].Id+"'", printer.GetErrorLines()[0])
+	})
+
+	s.Run("Delivery IDs and all-failed are exclusive", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all-failed", true, "")
+
+		err := replayWebhookDeliveriesCmdF(s.client, cmd, []string{hookID, model.NewId()})
+		s.Require().Error(err)
+
+		cmd = &cobra.Command{}
+		cmd.Flags().Bool("all-failed", false, "")
+
+		err = replayWebhookDeliveriesCmdF(s.client, cmd, []string{hookID})
+		s.Require().Error(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestRegenWebhookSigningSecretCmd() {
+	outgoingWebhookID := "outgoingWebhookID"
+
+	s.Run("Successfully regenerate signing secret", func() {
+		printer.Clean()
+
+		mockOutgoingWebhook := model.OutgoingWebhook{Id: outgoingWebhookID, SigningSecret: model.NewOutgoingWebhookSigningSecret()}
+
+		s.client.
+			EXPECT().
+			RegenOutgoingHookSigningSecret(context.TODO(), outgoingWebhookID).
+			Return(&mockOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		err := regenWebhookSigningSecretCmdF(s.client, &cobra.Command{}, []string{outgoingWebhookID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(&mockOutgoingWebhook, printer.GetLines()[0])
+	})
+
+	s.Run("Regenerate signing secret error", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			RegenOutgoingHookSigningSecret(context.TODO(), outgoingWebhookID).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := regenWebhookSigningSecretCmdF(s.client, &cobra.Command{}, []string{outgoingWebhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestRemoveWebhookSigningSecretCmd() {
+	outgoingWebhookID := "outgoingWebhookID"
+
+	s.Run("Successfully remove signing secret", func() {
+		printer.Clean()
+
+		mockOutgoingWebhook := model.OutgoingWebhook{Id: outgoingWebhookID}
+
+		s.client.
+			EXPECT().
+			RemoveOutgoingHookSigningSecret(context.TODO(), outgoingWebhookID).
+			Return(&mockOutgoingWebhook, &model.Response{}, nil).
+			Times(1)
+
+		err := removeWebhookSigningSecretCmdF(s.client, &cobra.Command{}, []string{outgoingWebhookID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(&mockOutgoingWebhook, printer.GetLines()[0])
+	})
+
+	s.Run("Remove signing secret error", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			RemoveOutgoingHookSigningSecret(context.TODO(), outgoingWebhookID).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := removeWebhookSigningSecretCmdF(s.client, &cobra.Command{}, []string{outgoingWebhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+}
@@ -524,6 +524,22 @@ func (mr *MockClientMockRecorder) DownloadExport(arg0, arg1, arg2, arg3 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadExport", reflect.TypeOf((*MockClient)(nil).DownloadExport), arg0, arg1, arg2, arg3)
 }
 
+// DryRunIncomingWebhookTemplate mocks base method.
+func (m *MockClient) DryRunIncomingWebhookTemplate(arg0 context.Context, arg1 string, arg2 *model.IncomingWebhookTemplateDryRunRequest) (*model.IncomingWebhookRequest, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunIncomingWebhookTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.IncomingWebhookRequest)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DryRunIncomingWebhookTemplate indicates an expected call of DryRunIncomingWebhookTemplate.
+func (mr *MockClientMockRecorder) DryRunIncomingWebhookTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunIncomingWebhookTemplate", reflect.TypeOf((*MockClient)(nil).DryRunIncomingWebhookTemplate), arg0, arg1, arg2)
+}
+
 // EnableBot mocks base method.
 func (m *MockClient) EnableBot(arg0 context.Context, arg1 string) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -540,6 +556,22 @@ func (mr *MockClientMockRecorder) EnableBot(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableBot", reflect.TypeOf((*MockClient)(nil).EnableBot), arg0, arg1)
 }
 
+// EnableOutgoingHook mocks base method.
+func (m *MockClient) EnableOutgoingHook(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableOutgoingHook", arg0, arg1)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EnableOutgoingHook indicates an expected call of EnableOutgoingHook.
+func (mr *MockClientMockRecorder) EnableOutgoingHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableOutgoingHook", reflect.TypeOf((*MockClient)(nil).EnableOutgoingHook), arg0, arg1)
+}
+
 // EnablePlugin mocks base method.
 func (m *MockClient) EnablePlugin(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -763,6 +795,38 @@ func (mr *MockClientMockRecorder) GetConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockClient)(nil).GetConfig), arg0)
 }
 
+// GetConfigHistory mocks base method.
+func (m *MockClient) GetConfigHistory(arg0 context.Context, arg1, arg2 int) ([]*model.ConfigVersion, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.ConfigVersion)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConfigHistory indicates an expected call of GetConfigHistory.
+func (mr *MockClientMockRecorder) GetConfigHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigHistory", reflect.TypeOf((*MockClient)(nil).GetConfigHistory), arg0, arg1, arg2)
+}
+
+// GetConfigVersionDiff mocks base method.
+func (m *MockClient) GetConfigVersionDiff(arg0 context.Context, arg1, arg2 string) ([]*model.ConfigVersionDiff, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigVersionDiff", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.ConfigVersionDiff)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConfigVersionDiff indicates an expected call of GetConfigVersionDiff.
+func (mr *MockClientMockRecorder) GetConfigVersionDiff(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigVersionDiff", reflect.TypeOf((*MockClient)(nil).GetConfigVersionDiff), arg0, arg1, arg2)
+}
+
 // GetConfigWithOptions mocks base method.
 func (m *MockClient) GetConfigWithOptions(arg0 context.Context, arg1 model.GetConfigOptions) (map[string]interface{}, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1021,6 +1085,22 @@ func (mr *MockClientMockRecorder) GetOutgoingWebhook(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhook), arg0, arg1)
 }
 
+// GetOutgoingWebhookDeliveries mocks base method.
+func (m *MockClient) GetOutgoingWebhookDeliveries(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) ([]*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookDeliveries", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOutgoingWebhookDeliveries indicates an expected call of GetOutgoingWebhookDeliveries.
+func (mr *MockClientMockRecorder) GetOutgoingWebhookDeliveries(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookDeliveries", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhookDeliveries), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetOutgoingWebhooks mocks base method.
 func (m *MockClient) GetOutgoingWebhooks(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1485,6 +1565,22 @@ func (mr *MockClientMockRecorder) GetUsersWithCustomQueryParameters(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithCustomQueryParameters", reflect.TypeOf((*MockClient)(nil).GetUsersWithCustomQueryParameters), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetWebAuthnCredentials mocks base method.
+func (m *MockClient) GetWebAuthnCredentials(arg0 context.Context, arg1 string) ([]*model.WebAuthnCredential, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebAuthnCredentials", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebAuthnCredential)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWebAuthnCredentials indicates an expected call of GetWebAuthnCredentials.
+func (mr *MockClientMockRecorder) GetWebAuthnCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebAuthnCredentials", reflect.TypeOf((*MockClient)(nil).GetWebAuthnCredentials), arg0, arg1)
+}
+
 // InstallMarketplacePlugin mocks base method.
 func (m *MockClient) InstallMarketplacePlugin(arg0 context.Context, arg1 *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1841,6 +1937,22 @@ func (mr *MockClientMockRecorder) PromoteGuestToUser(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteGuestToUser", reflect.TypeOf((*MockClient)(nil).PromoteGuestToUser), arg0, arg1)
 }
 
+// RegenOutgoingHookSigningSecret mocks base method.
+func (m *MockClient) RegenOutgoingHookSigningSecret(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenOutgoingHookSigningSecret", arg0, arg1)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RegenOutgoingHookSigningSecret indicates an expected call of RegenOutgoingHookSigningSecret.
+func (mr *MockClientMockRecorder) RegenOutgoingHookSigningSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenOutgoingHookSigningSecret", reflect.TypeOf((*MockClient)(nil).RegenOutgoingHookSigningSecret), arg0, arg1)
+}
+
 // RegenOutgoingHookToken mocks base method.
 func (m *MockClient) RegenOutgoingHookToken(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1887,6 +1999,22 @@ func (mr *MockClientMockRecorder) RemoveLicenseFile(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLicenseFile", reflect.TypeOf((*MockClient)(nil).RemoveLicenseFile), arg0)
 }
 
+// RemoveOutgoingHookSigningSecret mocks base method.
+func (m *MockClient) RemoveOutgoingHookSigningSecret(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOutgoingHookSigningSecret", arg0, arg1)
+	ret0, _ := ret[0].(*model.OutgoingWebhook)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemoveOutgoingHookSigningSecret indicates an expected call of RemoveOutgoingHookSigningSecret.
+func (mr *MockClientMockRecorder) RemoveOutgoingHookSigningSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOutgoingHookSigningSecret", reflect.TypeOf((*MockClient)(nil).RemoveOutgoingHookSigningSecret), arg0, arg1)
+}
+
 // RemovePlugin mocks base method.
 func (m *MockClient) RemovePlugin(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1932,6 +2060,22 @@ func (mr *MockClientMockRecorder) RemoveUserFromChannel(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromChannel", reflect.TypeOf((*MockClient)(nil).RemoveUserFromChannel), arg0, arg1, arg2)
 }
 
+// ReplayOutgoingWebhookDelivery mocks base method.
+func (m *MockClient) ReplayOutgoingWebhookDelivery(arg0 context.Context, arg1, arg2 string) (*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayOutgoingWebhookDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReplayOutgoingWebhookDelivery indicates an expected call of ReplayOutgoingWebhookDelivery.
+func (mr *MockClientMockRecorder) ReplayOutgoingWebhookDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayOutgoingWebhookDelivery", reflect.TypeOf((*MockClient)(nil).ReplayOutgoingWebhookDelivery), arg0, arg1, arg2)
+}
+
 // ResetSamlAuthDataToEmail mocks base method.
 func (m *MockClient) ResetSamlAuthDataToEmail(arg0 context.Context, arg1, arg2 bool, arg3 []string) (int64, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2011,6 +2155,53 @@ func (mr *MockClientMockRecorder) RevokeUserAccessToken(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserAccessToken", reflect.TypeOf((*MockClient)(nil).RevokeUserAccessToken), arg0, arg1)
 }
 
+// RevokeWebAuthnCredential mocks base method.
+func (m *MockClient) RevokeWebAuthnCredential(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeWebAuthnCredential", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeWebAuthnCredential indicates an expected call of RevokeWebAuthnCredential.
+func (mr *MockClientMockRecorder) RevokeWebAuthnCredential(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeWebAuthnCredential", reflect.TypeOf((*MockClient)(nil).RevokeWebAuthnCredential), arg0, arg1, arg2)
+}
+
+// RollbackConfig mocks base method.
+func (m *MockClient) RollbackConfig(arg0 context.Context, arg1 string) (*model.Config, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackConfig", arg0, arg1)
+	ret0, _ := ret[0].(*model.Config)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RollbackConfig indicates an expected call of RollbackConfig.
+func (mr *MockClientMockRecorder) RollbackConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackConfig", reflect.TypeOf((*MockClient)(nil).RollbackConfig), arg0, arg1)
+}
+
+// SearchAuditLogs mocks base method.
+func (m *MockClient) SearchAuditLogs(arg0 context.Context, arg1 model.AuditLogSearchOptions) ([]*model.AuditLog, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AuditLog)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchAuditLogs indicates an expected call of SearchAuditLogs.
+func (mr *MockClientMockRecorder) SearchAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAuditLogs", reflect.TypeOf((*MockClient)(nil).SearchAuditLogs), arg0, arg1)
+}
+
 // SearchTeams mocks base method.
 func (m *MockClient) SearchTeams(arg0 context.Context, arg1 *model.TeamSearch) ([]*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2072,6 +2263,21 @@ func (mr *MockClientMockRecorder) SoftDeleteTeam(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteTeam", reflect.TypeOf((*MockClient)(nil).SoftDeleteTeam), arg0, arg1)
 }
 
+// StartBatchReportExport mocks base method.
+func (m *MockClient) StartBatchReportExport(arg0 context.Context, arg1 string, arg2 *model.ReportExportOptions) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBatchReportExport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartBatchReportExport indicates an expected call of StartBatchReportExport.
+func (mr *MockClientMockRecorder) StartBatchReportExport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBatchReportExport", reflect.TypeOf((*MockClient)(nil).StartBatchReportExport), arg0, arg1, arg2)
+}
+
 // SyncLdap mocks base method.
 func (m *MockClient) SyncLdap(arg0 context.Context, arg1 bool) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -200,7 +200,12 @@ func parseDSN(dsn string) (string, string, error) {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (ds *DatabaseStore) Set(newCfg *model.Config) error {
-	return ds.persist(newCfg)
+	return ds.persist(newCfg, "")
+}
+
+// SetWithAuthor is like Set, but records the given user as the author of the new version.
+func (ds *DatabaseStore) SetWithAuthor(newCfg *model.Config, author string) error {
+	return ds.persist(newCfg, author)
 }
 
 // maxLength identifies the maximum length of a configuration or configuration file
@@ -213,7 +218,7 @@ func (ds *DatabaseStore) checkLength(length int) error {
 }
 
 // persist writes the configuration to the configured database.
-func (ds *DatabaseStore) persist(cfg *model.Config) error {
+func (ds *DatabaseStore) persist(cfg *model.Config, author string) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
@@ -291,9 +296,10 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 		"create_at": model.GetMillis(),
 		"key":       "ConfigurationId",
 		"sha":       hex.EncodeToString(sum[0:]),
+		"author":    author,
 	}
 
-	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, SHA) VALUES (:id, :value, :create_at, TRUE, :sha)", params); err != nil {
+	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, SHA, Author) VALUES (:id, :value, :create_at, TRUE, :sha, :author)", params); err != nil {
 		return errors.Wrap(err, "failed to record new configuration")
 	}
 
@@ -324,6 +330,57 @@ func (ds *DatabaseStore) Load() ([]byte, error) {
 	return configurationData, nil
 }
 
+// GetHistory returns the stored versions of the configuration, newest first.
+func (ds *DatabaseStore) GetHistory(offset, limit int) ([]*model.ConfigVersion, error) {
+	query, args, err := sqlx.Named("SELECT Id, CreateAt, COALESCE(Author, ''), COALESCE(Active, FALSE) FROM Configurations ORDER BY CreateAt DESC, Id DESC LIMIT :limit OFFSET :offset", map[string]any{
+		"limit":  limit,
+		"offset": offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(ds.db.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query configuration history")
+	}
+	defer rows.Close()
+
+	versions := []*model.ConfigVersion{}
+	for rows.Next() {
+		var version model.ConfigVersion
+		if err := rows.Scan(&version.Id, &version.CreateAt, &version.Author, &version.Active); err != nil {
+			return nil, errors.Wrap(err, "failed to scan configuration version")
+		}
+		versions = append(versions, &version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate configuration history")
+	}
+
+	return versions, nil
+}
+
+// GetVersion returns the serialized configuration of the given version.
+func (ds *DatabaseStore) GetVersion(id string) ([]byte, error) {
+	query, args, err := sqlx.Named("SELECT Value FROM Configurations WHERE Id = :id", map[string]any{
+		"id": id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	row := ds.db.QueryRowx(ds.db.Rebind(query), args...)
+	if err = row.Scan(&data); err == sql.ErrNoRows {
+		return nil, ErrVersionNotFound
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to query configuration version %s", id)
+	}
+
+	return data, nil
+}
+
 // GetFile fetches the contents of a previously persisted configuration file.
 func (ds *DatabaseStore) GetFile(name string) ([]byte, error) {
 	query, args, err := sqlx.Named("SELECT Data FROM ConfigurationFiles WHERE Name = :name", map[string]any{
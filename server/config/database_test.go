@@ -876,7 +876,7 @@ func TestDatabaseStoreLoad(t *testing.T) {
 		newCfg := minimalConfig.Clone()
 		dbStore, ok := ds.backingStore.(*DatabaseStore)
 		require.True(t, ok)
-		err = dbStore.persist(newCfg)
+		err = dbStore.persist(newCfg, "")
 		require.NoError(t, err)
 
 		err = ds.Load()
@@ -886,6 +886,60 @@ func TestDatabaseStoreLoad(t *testing.T) {
 	})
 }
 
+func TestDatabaseStoreHistory(t *testing.T) {
+	initialID, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+	defer tearDown()
+
+	ds, err := newTestDatabaseStore(nil)
+	require.NoError(t, err)
+	defer ds.Close()
+
+	initialHistory, err := ds.GetHistory(0, 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, initialHistory)
+	assert.Equal(t, initialID, initialHistory[len(initialHistory)-1].Id)
+
+	newCfg := ds.Get().Clone()
+	newCfg.ServiceSettings.SiteURL = model.NewPointer("http://new")
+	_, _, err = ds.SetWithAuthor(newCfg, "author-id")
+	require.NoError(t, err)
+
+	history, err := ds.GetHistory(0, 100)
+	require.NoError(t, err)
+	require.Len(t, history, len(initialHistory)+1)
+	assert.Equal(t, "author-id", history[0].Author)
+	assert.True(t, history[0].Active)
+	for _, version := range history[1:] {
+		assert.False(t, version.Active)
+	}
+
+	history, err = ds.GetHistory(0, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	t.Run("get version", func(t *testing.T) {
+		cfg, err := ds.GetVersion(initialID)
+		require.NoError(t, err)
+		assert.Equal(t, "http://minimal", *cfg.ServiceSettings.SiteURL)
+
+		_, err = ds.GetVersion(model.NewId())
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		_, rolledBackCfg, err := ds.Rollback(initialID, "other-author-id", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "http://minimal", *rolledBackCfg.ServiceSettings.SiteURL)
+		assert.Equal(t, "http://minimal", *ds.Get().ServiceSettings.SiteURL)
+
+		history, err := ds.GetHistory(0, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "other-author-id", history[0].Author)
+		assert.True(t, history[0].Active)
+	})
+}
+
 func TestDatabaseGetFile(t *testing.T) {
 	_, tearDown := setupConfigDatabase(t, minimalConfig, map[string][]byte{
 		"empty-file": {},
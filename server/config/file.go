@@ -4,10 +4,15 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -16,6 +21,10 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
 
+const (
+	fileStoreHistorySuffix = ".history"
+)
+
 var (
 	// ErrReadOnlyConfiguration is returned when an attempt to modify a read-only configuration is made.
 	ErrReadOnlyConfiguration = errors.New("configuration is read-only")
@@ -107,21 +116,50 @@ func (fs *FileStore) Set(newCfg *model.Config) error {
 		return ErrReadOnlyConfiguration
 	}
 
-	return fs.persist(newCfg)
+	return fs.persist(newCfg, "")
+}
+
+// SetWithAuthor is like Set, but records the given user as the author of the new version.
+func (fs *FileStore) SetWithAuthor(newCfg *model.Config, author string) error {
+	if *newCfg.ClusterSettings.Enable && *newCfg.ClusterSettings.ReadOnlyConfig {
+		return ErrReadOnlyConfiguration
+	}
+
+	return fs.persist(newCfg, author)
 }
 
-// persist writes the configuration to the configured file.
-func (fs *FileStore) persist(cfg *model.Config) error {
+// persist writes the configuration to the configured file and keeps a snapshot of it.
+func (fs *FileStore) persist(cfg *model.Config, author string) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
 	}
 
+	// The number of snapshots kept in the history folder. Zero disables the history.
+	historySize := model.JobSettingsDefaultConfigFileHistorySize
+	if cfg.JobSettings.ConfigFileHistorySize != nil {
+		historySize = *cfg.JobSettings.ConfigFileHistorySize
+	}
+
+	if historySize > 0 {
+		// Keep the configuration that was in the file before the first snapshot was
+		// taken, so that it can be rolled back to.
+		if err = fs.snapshotInitialFile(historySize); err != nil {
+			mlog.Warn("Failed to snapshot the previous configuration file", mlog.String("path", fs.path), mlog.Err(err))
+		}
+	}
+
 	err = os.WriteFile(fs.path, b, 0600)
 	if err != nil {
 		return errors.Wrap(err, "failed to write file")
 	}
 
+	if historySize > 0 {
+		if err = fs.snapshot(b, author, model.GetMillis(), historySize); err != nil {
+			mlog.Warn("Failed to snapshot the configuration file", mlog.String("path", fs.path), mlog.Err(err))
+		}
+	}
+
 	return nil
 }
 
@@ -220,3 +258,207 @@ func (fs *FileStore) String() string {
 func (fs *FileStore) Close() error {
 	return nil
 }
+
+// fileStoreSnapshot is a previous version of the configuration, kept as a file in the
+// history folder.
+type fileStoreSnapshot struct {
+	Id       string          `json:"id"`
+	CreateAt int64           `json:"create_at"`
+	Author   string          `json:"author,omitempty"`
+	SHA      string          `json:"sha"`
+	Config   json.RawMessage `json:"config"`
+}
+
+// historyDir returns the folder containing the snapshots of the configuration file.
+func (fs *FileStore) historyDir() string {
+	return fs.path + fileStoreHistorySuffix
+}
+
+// snapshotName returns the name of a snapshot file. Names sort in the order the
+// snapshots were taken.
+func snapshotName(createAt int64, id string) string {
+	return fmt.Sprintf("%013d_%s.json", createAt, id)
+}
+
+// listSnapshots returns the names of the snapshot files, newest first.
+func (fs *FileStore) listSnapshots() ([]string, error) {
+	entries, err := os.ReadDir(fs.historyDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read history folder")
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	return names, nil
+}
+
+func (fs *FileStore) readSnapshot(name string) (*fileStoreSnapshot, error) {
+	b, err := os.ReadFile(filepath.Join(fs.historyDir(), name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snapshot %s", name)
+	}
+
+	var snapshot fileStoreSnapshot
+	if err = json.Unmarshal(b, &snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal snapshot %s", name)
+	}
+
+	return &snapshot, nil
+}
+
+// snapshotInitialFile keeps the current contents of the configuration file if no
+// snapshot was taken yet.
+func (fs *FileStore) snapshotInitialFile(historySize int) error {
+	names, err := fs.listSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		return nil
+	}
+
+	info, err := os.Stat(fs.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to stat configuration file")
+	}
+
+	b, err := os.ReadFile(fs.path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read configuration file")
+	}
+	// An empty or invalid file has nothing worth rolling back to.
+	if len(b) == 0 || !json.Valid(b) {
+		return nil
+	}
+
+	return fs.snapshot(b, "", info.ModTime().UnixMilli(), historySize)
+}
+
+// snapshot writes the given configuration to the history folder, unless it is the
+// same as the newest snapshot, and removes the snapshots over the given history size.
+func (fs *FileStore) snapshot(b []byte, author string, createAt int64, historySize int) error {
+	sum := sha256.Sum256(b)
+	sha := hex.EncodeToString(sum[0:])
+
+	names, err := fs.listSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		newest, err := fs.readSnapshot(names[0])
+		if err == nil && newest.SHA == sha {
+			return nil
+		}
+		// Keep the snapshots ordered even if saved within the same millisecond.
+		if err == nil && createAt <= newest.CreateAt {
+			createAt = newest.CreateAt + 1
+		}
+	}
+
+	if err = os.MkdirAll(fs.historyDir(), 0700); err != nil {
+		return errors.Wrap(err, "failed to create history folder")
+	}
+
+	snapshot := fileStoreSnapshot{
+		Id:       model.NewId(),
+		CreateAt: createAt,
+		Author:   author,
+		SHA:      sha,
+		Config:   json.RawMessage(b),
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize snapshot")
+	}
+
+	name := snapshotName(snapshot.CreateAt, snapshot.Id)
+	if err = os.WriteFile(filepath.Join(fs.historyDir(), name), data, 0600); err != nil {
+		return errors.Wrap(err, "failed to write snapshot")
+	}
+
+	names = append([]string{name}, names...)
+	for i := historySize; i < len(names); i++ {
+		if err = os.Remove(filepath.Join(fs.historyDir(), names[i])); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove snapshot %s", names[i])
+		}
+	}
+
+	return nil
+}
+
+// GetHistory returns the snapshots of the configuration, newest first.
+func (fs *FileStore) GetHistory(offset, limit int) ([]*model.ConfigVersion, error) {
+	names, err := fs.listSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	var activeSHA string
+	if b, err := os.ReadFile(fs.path); err == nil {
+		sum := sha256.Sum256(b)
+		activeSHA = hex.EncodeToString(sum[0:])
+	}
+
+	// A rollback makes an older snapshot match the file too, only the newest one is active.
+	versions := []*model.ConfigVersion{}
+	for i := 0; i < len(names) && len(versions) < limit; i++ {
+		snapshot, err := fs.readSnapshot(names[i])
+		if err != nil {
+			return nil, err
+		}
+
+		active := activeSHA != "" && snapshot.SHA == activeSHA
+		if active {
+			activeSHA = ""
+		}
+		if i < offset {
+			continue
+		}
+
+		versions = append(versions, &model.ConfigVersion{
+			Id:       snapshot.Id,
+			CreateAt: snapshot.CreateAt,
+			Author:   snapshot.Author,
+			Active:   active,
+		})
+	}
+
+	return versions, nil
+}
+
+// GetVersion returns the serialized configuration of the given snapshot.
+func (fs *FileStore) GetVersion(id string) ([]byte, error) {
+	if !model.IsValidId(id) {
+		return nil, ErrVersionNotFound
+	}
+
+	names, err := fs.listSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if !strings.HasSuffix(name, "_"+id+".json") {
+			continue
+		}
+
+		snapshot, err := fs.readSnapshot(name)
+		if err != nil {
+			return nil, err
+		}
+		return snapshot.Config, nil
+	}
+
+	return nil, ErrVersionNotFound
+}
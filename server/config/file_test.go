@@ -949,6 +949,164 @@ func TestFileStoreSave(t *testing.T) {
 	})
 }
 
+func TestFileStoreHistory(t *testing.T) {
+	newHistoryStore := func(t *testing.T, cfg *model.Config) *Store {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.json")
+		b, err := marshalConfig(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0600))
+
+		fs, err := NewFileStore(path, false)
+		require.NoError(t, err)
+		store, err := NewStoreFromBacking(fs, nil, false)
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+		return store
+	}
+
+	setSiteURL := func(t *testing.T, store *Store, siteURL string, author string) {
+		t.Helper()
+		newCfg := store.Get().Clone()
+		newCfg.ServiceSettings.SiteURL = model.NewPointer(siteURL)
+		_, _, err := store.SetWithAuthor(newCfg, author)
+		require.NoError(t, err)
+	}
+
+	// Loading the store fills in the defaults, so the file is already saved once: the
+	// history starts with the initial file and the saved one.
+	t.Run("keeps the initial file and the saved versions", func(t *testing.T) {
+		store := newHistoryStore(t, minimalConfig)
+
+		setSiteURL(t, store, "http://new", "author-id")
+
+		history, err := store.GetHistory(0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, "author-id", history[0].Author)
+		assert.True(t, history[0].Active)
+		assert.Empty(t, history[2].Author)
+		assert.False(t, history[1].Active)
+		assert.False(t, history[2].Active)
+		assert.Greater(t, history[0].CreateAt, history[1].CreateAt)
+
+		oldCfg, err := store.GetVersion(history[2].Id)
+		require.NoError(t, err)
+		assert.Equal(t, "http://minimal", *oldCfg.ServiceSettings.SiteURL)
+
+		history, err = store.GetHistory(1, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.False(t, history[0].Active)
+	})
+
+	t.Run("saving the same configuration doesn't add a version", func(t *testing.T) {
+		store := newHistoryStore(t, minimalConfig)
+
+		setSiteURL(t, store, "http://new", "")
+		setSiteURL(t, store, "http://new", "")
+
+		history, err := store.GetHistory(0, 10)
+		require.NoError(t, err)
+		assert.Len(t, history, 3)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		store := newHistoryStore(t, minimalConfig)
+
+		history, err := store.GetHistory(0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		setSiteURL(t, store, "http://new", "")
+
+		oldCfg, newCfg, err := store.Rollback(history[0].Id, "author-id", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "http://new", *oldCfg.ServiceSettings.SiteURL)
+		assert.Equal(t, "http://minimal", *newCfg.ServiceSettings.SiteURL)
+		assert.Equal(t, "http://minimal", *store.Get().ServiceSettings.SiteURL)
+
+		history, err = store.GetHistory(0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 4)
+		assert.Equal(t, "author-id", history[0].Author)
+		assert.True(t, history[0].Active)
+		assert.False(t, history[1].Active)
+		assert.False(t, history[2].Active, "only the newest matching version should be active")
+	})
+
+	t.Run("rollback to an invalid version", func(t *testing.T) {
+		store := newHistoryStore(t, minimalConfig)
+
+		fs := store.backingStore.(*FileStore)
+		invalidCfg := minimalConfig.Clone()
+		invalidCfg.ServiceSettings.SiteURL = model.NewPointer("invalid")
+		b, err := marshalConfig(invalidCfg)
+		require.NoError(t, err)
+		require.NoError(t, fs.snapshot(b, "", model.GetMillis(), model.JobSettingsDefaultConfigFileHistorySize))
+
+		history, err := store.GetHistory(0, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+
+		_, _, err = store.Rollback(history[0].Id, "", nil)
+		require.Error(t, err)
+		assert.Equal(t, "http://minimal", *store.Get().ServiceSettings.SiteURL)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		store := newHistoryStore(t, minimalConfig)
+
+		_, err := store.GetVersion(model.NewId())
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+
+		_, err = store.GetVersion("../config")
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+	})
+
+	t.Run("keeps at most the history size", func(t *testing.T) {
+		cfg := minimalConfig.Clone()
+		cfg.JobSettings.ConfigFileHistorySize = model.NewPointer(2)
+		store := newHistoryStore(t, cfg)
+
+		setSiteURL(t, store, "http://new1", "")
+		setSiteURL(t, store, "http://new2", "")
+		setSiteURL(t, store, "http://new3", "")
+
+		history, err := store.GetHistory(0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		version, err := store.GetVersion(history[1].Id)
+		require.NoError(t, err)
+		assert.Equal(t, "http://new2", *version.ServiceSettings.SiteURL)
+	})
+
+	t.Run("a zero history size disables the history", func(t *testing.T) {
+		cfg := minimalConfig.Clone()
+		cfg.JobSettings.ConfigFileHistorySize = model.NewPointer(0)
+		store := newHistoryStore(t, cfg)
+
+		setSiteURL(t, store, "http://new", "")
+
+		history, err := store.GetHistory(0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("not supported by the memory store", func(t *testing.T) {
+		ms, err := NewMemoryStore()
+		require.NoError(t, err)
+		store, err := NewStoreFromBacking(ms, nil, false)
+		require.NoError(t, err)
+
+		_, err = store.GetHistory(0, 10)
+		assert.ErrorIs(t, err, ErrHistoryNotSupported)
+
+		_, _, err = store.Rollback(model.NewId(), "", nil)
+		assert.ErrorIs(t, err, ErrHistoryNotSupported)
+	})
+}
+
 func TestFileGetFile(t *testing.T) {
 	path, tearDown := setupConfigFile(t, minimalConfig)
 	defer tearDown()
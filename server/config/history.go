@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var (
+	// ErrHistoryNotSupported is returned when the history of the configuration is requested
+	// from a backing store that doesn't keep it.
+	ErrHistoryNotSupported = errors.New("configuration history is not supported by the backing store")
+
+	// ErrVersionNotFound is returned when a version of the configuration doesn't exist.
+	ErrVersionNotFound = errors.New("configuration version not found")
+)
+
+// HistoryStore is implemented by backing stores that keep the previous versions of the configuration.
+type HistoryStore interface {
+	// SetWithAuthor is like Set, but records the given user as the author of the new version.
+	SetWithAuthor(cfg *model.Config, author string) error
+
+	// GetHistory returns the stored versions of the configuration, newest first.
+	GetHistory(offset, limit int) ([]*model.ConfigVersion, error)
+
+	// GetVersion returns the serialized configuration of the given version, or
+	// ErrVersionNotFound if it doesn't exist.
+	GetVersion(id string) ([]byte, error)
+}
+
+// GetHistory returns the stored versions of the configuration, newest first.
+func (s *Store) GetHistory(offset, limit int) ([]*model.ConfigVersion, error) {
+	historyStore, ok := s.backingStore.(HistoryStore)
+	if !ok {
+		return nil, ErrHistoryNotSupported
+	}
+
+	return historyStore.GetHistory(offset, limit)
+}
+
+// GetVersion returns the configuration of the given version.
+func (s *Store) GetVersion(id string) (*model.Config, error) {
+	historyStore, ok := s.backingStore.(HistoryStore)
+	if !ok {
+		return nil, ErrHistoryNotSupported
+	}
+
+	b, err := historyStore.GetVersion(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg model.Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal configuration version %s", id)
+	}
+	cfg.SetDefaults()
+
+	return &cfg, nil
+}
+
+// Rollback replaces the current configuration with the given version, recording the given
+// user as the author of the change. The version is passed to prepare, if given, which returns
+// the configuration to save in its place. Like Set, the configuration is validated first and
+// the environment overrides still apply. It returns both old and new versions of the config.
+func (s *Store) Rollback(id string, author string, prepare func(*model.Config) (*model.Config, error)) (*model.Config, *model.Config, error) {
+	cfg, err := s.GetVersion(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if prepare != nil {
+		if cfg, err = prepare(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return s.set(cfg, author)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const consulIndexHeader = "X-Consul-Index"
+
+// consulWaitTime is the longest time a blocking query waits for a change.
+var consulWaitTime = 5 * time.Minute
+
+// consulClient talks to the KV API of Consul. Changes are watched with blocking queries.
+type consulClient struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+type consulKVPair struct {
+	Key         string `json:"Key"`
+	Value       []byte `json:"Value"`
+	ModifyIndex int64  `json:"ModifyIndex"`
+}
+
+func newConsulClient(endpoint, token string, httpClient *http.Client) *consulClient {
+	return &consulClient{
+		endpoint:   endpoint,
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// do sends a request for the given key. Responses other than 200 and 404 are returned as errors.
+func (c *consulClient) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := c.endpoint + (&url.URL{Path: "/v1/kv/" + key}).EscapedPath()
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("consul request for %s failed with status %d: %s", key, resp.StatusCode, bytes.TrimSpace(b))
+	}
+
+	return resp, nil
+}
+
+func parseConsulIndex(resp *http.Response) (int64, error) {
+	index, err := strconv.ParseInt(resp.Header.Get(consulIndexHeader), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s header", consulIndexHeader)
+	}
+
+	return index, nil
+}
+
+func (c *consulClient) get(ctx context.Context, key string) (*kvEntry, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	index, err := parseConsulIndex(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &kvEntry{index: index}
+	if resp.StatusCode == http.StatusNotFound {
+		return entry, nil
+	}
+
+	var pairs []consulKVPair
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, errors.Wrap(err, "failed to decode consul response")
+	}
+	if len(pairs) > 0 {
+		entry.found = true
+		entry.value = pairs[0].Value
+		entry.revision = pairs[0].ModifyIndex
+	}
+
+	return entry, nil
+}
+
+// write puts the key and returns the boolean Consul answers with.
+func (c *consulClient) write(ctx context.Context, key string, value []byte, query url.Values) (bool, error) {
+	if value == nil {
+		value = []byte{}
+	}
+
+	resp, err := c.do(ctx, http.MethodPut, key, query, value)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, errors.Errorf("consul request for %s failed with status %d", key, resp.StatusCode)
+	}
+
+	var ok bool
+	if err := json.NewDecoder(resp.Body).Decode(&ok); err != nil {
+		return false, errors.Wrap(err, "failed to decode consul response")
+	}
+
+	return ok, nil
+}
+
+func (c *consulClient) put(ctx context.Context, key string, value []byte) error {
+	ok, err := c.write(ctx, key, value, nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("consul refused to write %s", key)
+	}
+
+	return nil
+}
+
+func (c *consulClient) compareAndSwap(ctx context.Context, key string, value []byte, revision int64) (bool, error) {
+	// A zero index only writes keys that don't exist.
+	return c.write(ctx, key, value, url.Values{"cas": []string{strconv.FormatInt(revision, 10)}})
+}
+
+func (c *consulClient) delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// watch runs a blocking query that returns once the index of the key moves past the given
+// index, or when the wait time is over.
+func (c *consulClient) watch(ctx context.Context, key string, index int64) (int64, error) {
+	query := url.Values{
+		"index": []string{strconv.FormatInt(index, 10)},
+		"wait":  []string{consulWaitTime.String()},
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, key, query, nil)
+	if err != nil {
+		return index, err
+	}
+	defer resp.Body.Close()
+
+	newIndex, err := parseConsulIndex(resp)
+	if err != nil {
+		return index, err
+	}
+
+	// Consul asks for the index to be reset when it goes backwards.
+	if newIndex < index {
+		return 0, nil
+	}
+
+	return newIndex, nil
+}
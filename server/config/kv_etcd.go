@@ -0,0 +1,316 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// etcdClient talks to etcd through the JSON gateway of its v3 API. Keys and values are
+// base64 encoded and 64 bit integers are encoded as strings.
+//
+// When a username is given, the client authenticates with it and sends the token it gets
+// with every request, authenticating again once the token has expired.
+type etcdClient struct {
+	endpoint   string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	tokenMutex sync.Mutex
+	token      string
+}
+
+type etcdAuthenticateRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type etcdAuthenticateResponse struct {
+	Token string `json:"token"`
+}
+
+type etcdHeader struct {
+	Revision int64 `json:"revision,string"`
+}
+
+type etcdKeyValue struct {
+	Key         []byte `json:"key"`
+	Value       []byte `json:"value,omitempty"`
+	ModRevision int64  `json:"mod_revision,string,omitempty"`
+}
+
+type etcdRangeResponse struct {
+	Header etcdHeader     `json:"header"`
+	Kvs    []etcdKeyValue `json:"kvs"`
+}
+
+type etcdCompare struct {
+	Key         []byte `json:"key"`
+	Target      string `json:"target"`
+	Result      string `json:"result"`
+	ModRevision int64  `json:"mod_revision,string"`
+}
+
+type etcdRequestOp struct {
+	RequestPut *etcdKeyValue `json:"request_put,omitempty"`
+}
+
+type etcdTxnRequest struct {
+	Compare []etcdCompare   `json:"compare"`
+	Success []etcdRequestOp `json:"success"`
+}
+
+type etcdTxnResponse struct {
+	Succeeded bool `json:"succeeded"`
+}
+
+type etcdWatchCreateRequest struct {
+	Key           []byte `json:"key"`
+	StartRevision int64  `json:"start_revision,string"`
+}
+
+type etcdWatchRequest struct {
+	CreateRequest etcdWatchCreateRequest `json:"create_request"`
+}
+
+type etcdEvent struct {
+	Kv etcdKeyValue `json:"kv"`
+}
+
+type etcdWatchResponse struct {
+	Result struct {
+		Header          etcdHeader  `json:"header"`
+		Created         bool        `json:"created"`
+		Canceled        bool        `json:"canceled"`
+		CompactRevision int64       `json:"compact_revision,string"`
+		CancelReason    string      `json:"cancel_reason"`
+		Events          []etcdEvent `json:"events"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newEtcdClient(endpoint, username, password string, httpClient *http.Client) *etcdClient {
+	return &etcdClient{
+		endpoint:   endpoint,
+		username:   username,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// authToken returns the token to authenticate the requests with, authenticating first if
+// there is none yet. It returns an empty token if no username is configured.
+func (c *etcdClient) authToken(ctx context.Context) (string, error) {
+	if c.username == "" {
+		return "", nil
+	}
+
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	b, err := json.Marshal(etcdAuthenticateRequest{Name: c.username, Password: c.password})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to serialize etcd authentication request")
+	}
+	resp, err := c.send(ctx, "/v3/auth/authenticate", b, "")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to authenticate to etcd")
+	}
+	defer resp.Body.Close()
+
+	var response etcdAuthenticateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", errors.Wrap(err, "failed to decode etcd authentication response")
+	}
+	c.token = response.Token
+
+	return c.token, nil
+}
+
+// resetAuthToken forgets the given token, so that the next request authenticates again.
+func (c *etcdClient) resetAuthToken(token string) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+// send posts the body to the given gateway path, with the given token if any. Responses other
+// than 200 are returned as errors, except 401 which is left to the caller.
+func (c *etcdClient) send(ctx context.Context, path string, body []byte, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && (resp.StatusCode != http.StatusUnauthorized || token == "") {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("etcd request to %s failed with status %d: %s", path, resp.StatusCode, bytes.TrimSpace(b))
+	}
+
+	return resp, nil
+}
+
+// post sends the request to the given gateway path and returns the response body, which the
+// caller must close.
+func (c *etcdClient) post(ctx context.Context, path string, request any) (io.ReadCloser, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize etcd request")
+	}
+
+	// The token is only rejected once it has expired, in which case the request is sent again
+	// with a new one.
+	for attempt := 0; ; attempt++ {
+		token, err := c.authToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.send(ctx, path, b, token)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp.Body, nil
+		}
+
+		resp.Body.Close()
+		c.resetAuthToken(token)
+		if attempt > 0 {
+			return nil, errors.Errorf("etcd request to %s failed with status %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func (c *etcdClient) call(ctx context.Context, path string, request, response any) error {
+	body, err := c.post(ctx, path, request)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if response == nil {
+		return nil
+	}
+	if err := json.NewDecoder(body).Decode(response); err != nil {
+		return errors.Wrapf(err, "failed to decode etcd response from %s", path)
+	}
+
+	return nil
+}
+
+func (c *etcdClient) get(ctx context.Context, key string) (*kvEntry, error) {
+	var response etcdRangeResponse
+	if err := c.call(ctx, "/v3/kv/range", etcdKeyValue{Key: []byte(key)}, &response); err != nil {
+		return nil, err
+	}
+
+	entry := &kvEntry{index: response.Header.Revision}
+	if len(response.Kvs) > 0 {
+		entry.found = true
+		entry.value = response.Kvs[0].Value
+		entry.revision = response.Kvs[0].ModRevision
+	}
+
+	return entry, nil
+}
+
+func (c *etcdClient) put(ctx context.Context, key string, value []byte) error {
+	return c.call(ctx, "/v3/kv/put", etcdKeyValue{Key: []byte(key), Value: value}, nil)
+}
+
+func (c *etcdClient) compareAndSwap(ctx context.Context, key string, value []byte, revision int64) (bool, error) {
+	// The modification revision of a key that doesn't exist is zero.
+	request := etcdTxnRequest{
+		Compare: []etcdCompare{{
+			Key:         []byte(key),
+			Target:      "MOD",
+			Result:      "EQUAL",
+			ModRevision: revision,
+		}},
+		Success: []etcdRequestOp{{
+			RequestPut: &etcdKeyValue{Key: []byte(key), Value: value},
+		}},
+	}
+
+	var response etcdTxnResponse
+	if err := c.call(ctx, "/v3/kv/txn", request, &response); err != nil {
+		return false, err
+	}
+
+	return response.Succeeded, nil
+}
+
+func (c *etcdClient) delete(ctx context.Context, key string) error {
+	return c.call(ctx, "/v3/kv/deleterange", etcdKeyValue{Key: []byte(key)}, nil)
+}
+
+// watch opens a watch stream from the revision following the given index and returns the
+// revision of the first change.
+func (c *etcdClient) watch(ctx context.Context, key string, index int64) (int64, error) {
+	request := etcdWatchRequest{
+		CreateRequest: etcdWatchCreateRequest{
+			Key:           []byte(key),
+			StartRevision: index + 1,
+		},
+	}
+
+	body, err := c.post(ctx, "/v3/watch", request)
+	if err != nil {
+		return index, err
+	}
+	defer body.Close()
+
+	decoder := json.NewDecoder(body)
+	for {
+		var response etcdWatchResponse
+		if err := decoder.Decode(&response); err != nil {
+			return index, errors.Wrap(err, "failed to decode etcd watch response")
+		}
+
+		if response.Error != nil {
+			return index, errors.Errorf("etcd watch failed: %s", response.Error.Message)
+		}
+
+		result := response.Result
+		if result.Canceled {
+			// The revisions we were watching from were compacted, start over from the
+			// oldest one still available after loading the configuration again.
+			if result.CompactRevision > 0 {
+				return result.CompactRevision - 1, nil
+			}
+			return index, errors.Errorf("etcd watch canceled: %s", result.CancelReason)
+		}
+
+		if len(result.Events) > 0 {
+			return result.Header.Revision, nil
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
 
@@ -85,6 +86,17 @@ func MloggerConfigFromAuditConfig(auditSettings model.ExperimentalAuditSettings,
 		cfg["_defAudit"] = targetCfg
 	}
 
+	// add the database audit target
+	if *auditSettings.DatabaseEnabled {
+		cfg["_dbAudit"] = mlog.TargetCfg{
+			Type:          audit.DatabaseTargetType,
+			Format:        "json",
+			FormatOptions: json.RawMessage(`{"disable_timestamp": false, "disable_msg": true, "disable_stacktrace": true, "disable_level": true}`),
+			Levels:        []mlog.Level{mlog.LvlAuditAPI, mlog.LvlAuditContent, mlog.LvlAuditPerms, mlog.LvlAuditCLI},
+			MaxQueueSize:  *auditSettings.FileMaxQueueSize,
+		}
+	}
+
 	if configSrc == nil {
 		return cfg, nil
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
 )
 
 func TestMloggerConfigFromAuditConfig(t *testing.T) {
@@ -23,6 +24,7 @@ func TestMloggerConfigFromAuditConfig(t *testing.T) {
 		FileMaxBackups:   model.NewPointer(5),
 		FileCompress:     model.NewPointer(true),
 		FileMaxQueueSize: model.NewPointer(5000),
+		DatabaseEnabled:  model.NewPointer(false),
 	}
 
 	t.Run("validate default audit settings", func(t *testing.T) {
@@ -49,4 +51,19 @@ func TestMloggerConfigFromAuditConfig(t *testing.T) {
 		require.NoError(t, err, "unmarshal should not fail")
 		assert.Equal(t, optionsExpected, optionsReceived)
 	})
+
+	t.Run("database target", func(t *testing.T) {
+		dbAuditSettings := auditSettings
+		dbAuditSettings.DatabaseEnabled = model.NewPointer(true)
+
+		cfg, err := MloggerConfigFromAuditConfig(dbAuditSettings, nil)
+		require.NoError(t, err, "audit config should not error")
+		require.Len(t, cfg, 2, "audit config should have the file and database targets")
+
+		targetCfg := cfg["_dbAudit"]
+		assert.Equal(t, audit.DatabaseTargetType, targetCfg.Type)
+		assert.Equal(t, "json", targetCfg.Format)
+		assert.Equal(t, 5000, targetCfg.MaxQueueSize)
+		assert.ElementsMatch(t, targetCfg.Levels, []mlog.Level{mlog.LvlAuditAPI, mlog.LvlAuditContent, mlog.LvlAuditPerms, mlog.LvlAuditCLI})
+	})
 }
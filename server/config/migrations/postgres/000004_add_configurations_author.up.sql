@@ -0,0 +1 @@
+ALTER TABLE Configurations ADD COLUMN IF NOT EXISTS Author VARCHAR(26) DEFAULT '';
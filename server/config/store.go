@@ -13,6 +13,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/utils"
 )
 
@@ -80,6 +81,16 @@ func NewStoreFromBacking(backingStore BackingStore, customDefaults *model.Config
 		return nil, errors.Wrap(err, "unable to load on store creation")
 	}
 
+	// Load the changes made by other nodes as soon as they are noticed, without writing them
+	// back.
+	if ws, ok := backingStore.(watchingStore); ok {
+		ws.watch(func() {
+			if err := store.load(false); err != nil {
+				mlog.Warn("Failed to load the changed configuration", mlog.String("store", backingStore.String()), mlog.Err(err))
+			}
+		})
+	}
+
 	return store, nil
 }
 
@@ -90,6 +101,8 @@ func NewStoreFromDSN(dsn string, readOnly bool, customDefaults *model.Config, cr
 	var backingStore BackingStore
 	if IsDatabaseDSN(dsn) {
 		backingStore, err = NewDatabaseStore(dsn)
+	} else if IsKVDSN(dsn) {
+		backingStore, err = NewKVStore(dsn)
 	} else {
 		backingStore, err = NewFileStore(dsn, createFileIfNotExist)
 	}
@@ -166,6 +179,16 @@ func (s *Store) SetReadOnlyFF(readOnly bool) {
 // Set replaces the current configuration in its entirety and updates the backing store.
 // It returns both old and new versions of the config.
 func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error) {
+	return s.set(newCfg, "")
+}
+
+// SetWithAuthor is like Set, but records the given user as the author of the new version
+// when the backing store keeps the history of the configuration.
+func (s *Store) SetWithAuthor(newCfg *model.Config, author string) (*model.Config, *model.Config, error) {
+	return s.set(newCfg, author)
+}
+
+func (s *Store) set(newCfg *model.Config, author string) (*model.Config, *model.Config, error) {
 	s.configLock.Lock()
 	defer s.configLock.Unlock()
 
@@ -210,7 +233,11 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 		newCfgNoEnv.FeatureFlags = nil
 	}
 
-	if err := s.backingStore.Set(newCfgNoEnv); err != nil {
+	if historyStore, ok := s.backingStore.(HistoryStore); ok {
+		if err := historyStore.SetWithAuthor(newCfgNoEnv, author); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to persist")
+		}
+	} else if err := s.backingStore.Set(newCfgNoEnv); err != nil {
 		return nil, nil, errors.Wrap(err, "failed to persist")
 	}
 
@@ -242,6 +269,14 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 
 // Load updates the current configuration from the backing store, possibly initializing.
 func (s *Store) Load() error {
+	return s.load(true)
+}
+
+// load updates the current configuration from the backing store. The configuration is
+// written back when it changed on load, e.g. because of new defaults, unless writeBack is
+// false, as for the changes made by other nodes, which may have different defaults and would
+// otherwise keep overwriting each other.
+func (s *Store) load(writeBack bool) error {
 	s.configLock.Lock()
 	defer s.configLock.Unlock()
 
@@ -317,7 +352,7 @@ func (s *Store) Load() error {
 
 	// We write back to the backing store only if the store is not read-only
 	// and the config has either changed or is missing.
-	if !s.readOnly && (hasChanged || len(configBytes) == 0) {
+	if writeBack && !s.readOnly && (hasChanged || len(configBytes) == 0) {
 		err := s.backingStore.Set(loadedCfgNoEnv)
 		if err != nil && !errors.Is(err, ErrReadOnlyConfiguration) {
 			return errors.Wrap(err, "failed to persist")
@@ -387,6 +422,11 @@ func (s *Store) String() string {
 
 // Close cleans up resources associated with the store.
 func (s *Store) Close() error {
+	// The watch reloads the configuration, so it has to stop before the lock is taken.
+	if ws, ok := s.backingStore.(watchingStore); ok {
+		ws.stopWatching()
+	}
+
 	s.configLock.Lock()
 	defer s.configLock.Unlock()
 	return s.backingStore.Close()
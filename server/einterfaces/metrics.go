@@ -24,6 +24,7 @@ type MetricsInterface interface {
 
 	IncrementHTTPRequest()
 	IncrementHTTPError()
+	IncrementHTTPRateLimited(policy string)
 
 	IncrementClusterRequest()
 	ObserveClusterRequestDuration(elapsed float64)
@@ -94,8 +95,16 @@ type MetricsInterface interface {
 	IncrementJobActive(jobType string)
 	DecrementJobActive(jobType string)
 
+	IncrementImageProxyCacheHitCounter()
+	IncrementImageProxyCacheMissCounter()
+	IncrementImageProxyCacheEvictionCounter()
+	SetImageProxyCacheSize(size float64)
+
 	SetReplicaLagAbsolute(node string, value float64)
 	SetReplicaLagTime(node string, value float64)
+	SetReplicaLagSeconds(node string, value float64)
+	SetReplicaInRotation(node string, inRotation bool)
+	IncrementReadYourWritesMasterCounter()
 
 	IncrementNotificationCounter(notificationType model.NotificationType, platform string)
 	IncrementNotificationAckCounter(notificationType model.NotificationType, platform string)
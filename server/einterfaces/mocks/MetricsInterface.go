@@ -65,9 +65,7 @@ func (_m *MetricsInterface) GetLoggerMetricsCollector() logr.MetricsCollector {
 	if rf, ok := ret.Get(0).(func() logr.MetricsCollector); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(logr.MetricsCollector)
-		}
+		r0 = ret.Get(0).(logr.MetricsCollector)
 	}
 
 	return r0
@@ -118,6 +116,11 @@ func (_m *MetricsInterface) IncrementHTTPError() {
 	_m.Called()
 }
 
+// IncrementHTTPRateLimited provides a mock function with given fields: policy
+func (_m *MetricsInterface) IncrementHTTPRateLimited(policy string) {
+	_m.Called(policy)
+}
+
 // IncrementHTTPRequest provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementHTTPRequest() {
 	_m.Called()
@@ -128,6 +131,21 @@ func (_m *MetricsInterface) IncrementHTTPWebSockets(originClient string) {
 	_m.Called(originClient)
 }
 
+// IncrementImageProxyCacheEvictionCounter provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementImageProxyCacheEvictionCounter() {
+	_m.Called()
+}
+
+// IncrementImageProxyCacheHitCounter provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementImageProxyCacheHitCounter() {
+	_m.Called()
+}
+
+// IncrementImageProxyCacheMissCounter provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementImageProxyCacheMissCounter() {
+	_m.Called()
+}
+
 // IncrementJobActive provides a mock function with given fields: jobType
 func (_m *MetricsInterface) IncrementJobActive(jobType string) {
 	_m.Called(jobType)
@@ -238,6 +256,11 @@ func (_m *MetricsInterface) IncrementPostsSearchCounter() {
 	_m.Called()
 }
 
+// IncrementReadYourWritesMasterCounter provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementReadYourWritesMasterCounter() {
+	_m.Called()
+}
+
 // IncrementRemoteClusterConnStateChangeCounter provides a mock function with given fields: remoteID, online
 func (_m *MetricsInterface) IncrementRemoteClusterConnStateChangeCounter(remoteID string, online bool) {
 	_m.Called(remoteID, online)
@@ -498,11 +521,26 @@ func (_m *MetricsInterface) RegisterDBCollector(db *sql.DB, name string) {
 	_m.Called(db, name)
 }
 
+// SetImageProxyCacheSize provides a mock function with given fields: size
+func (_m *MetricsInterface) SetImageProxyCacheSize(size float64) {
+	_m.Called(size)
+}
+
+// SetReplicaInRotation provides a mock function with given fields: node, inRotation
+func (_m *MetricsInterface) SetReplicaInRotation(node string, inRotation bool) {
+	_m.Called(node, inRotation)
+}
+
 // SetReplicaLagAbsolute provides a mock function with given fields: node, value
 func (_m *MetricsInterface) SetReplicaLagAbsolute(node string, value float64) {
 	_m.Called(node, value)
 }
 
+// SetReplicaLagSeconds provides a mock function with given fields: node, value
+func (_m *MetricsInterface) SetReplicaLagSeconds(node string, value float64) {
+	_m.Called(node, value)
+}
+
 // SetReplicaLagTime provides a mock function with given fields: node, value
 func (_m *MetricsInterface) SetReplicaLagTime(node string, value float64) {
 	_m.Called(node, value)
@@ -0,0 +1,336 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/common_export"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	JSONLExportFilename = "jsonl_export.jsonl"
+
+	MessageEventType = "message"
+	JoinEventType    = "join"
+	LeaveEventType   = "leave"
+)
+
+// Event is a line of the JSONL export. Messages carry the post, its attachments and the
+// members of the channel when it was sent, joins and leaves carry the user that
+// entered or left the channel.
+type Event struct {
+	Type             string   `json:"type"`
+	Time             int64    `json:"time"`
+	Team             *Team    `json:"team,omitempty"`
+	Channel          Channel  `json:"channel"`
+	User             User     `json:"user"`
+	PreviouslyJoined bool     `json:"previously_joined,omitempty"`
+	Post             *Post    `json:"post,omitempty"`
+	ChannelMembers   []string `json:"channel_members,omitempty"`
+}
+
+type Team struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+type Channel struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+}
+
+type User struct {
+	Id       string `json:"id"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	IsBot    bool   `json:"is_bot"`
+}
+
+type Post struct {
+	Id             string          `json:"id"`
+	RootId         string          `json:"root_id,omitempty"`
+	EditedByPostId string          `json:"edited_by_post_id,omitempty"`
+	PreviewsPostId string          `json:"previews_post_id,omitempty"`
+	Type           string          `json:"type,omitempty"`
+	Message        string          `json:"message"`
+	Props          json.RawMessage `json:"props,omitempty"`
+	CreateAt       int64           `json:"create_at"`
+	UpdateAt       int64           `json:"update_at,omitempty"`
+	DeleteAt       int64           `json:"delete_at,omitempty"`
+	Attachments    []Attachment    `json:"attachments,omitempty"`
+}
+
+type Attachment struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type,omitempty"`
+	DeleteAt int64  `json:"delete_at,omitempty"`
+}
+
+// JSONLExport writes the posts as a stream of line-delimited JSON events, ordered by time. The
+// join and leave events of the exported channels are interleaved with the messages, and the
+// attachments are referenced by their path in the file store.
+func JSONLExport(rctx request.CTX, posts []*model.MessageExport, db store.Store, exportBackend filestore.FileBackend, exportDirectory string) (warningCount int64, appErr *model.AppError) {
+	dest, err := os.CreateTemp("", JSONLExportFilename)
+	if err != nil {
+		return warningCount, model.NewAppError("JSONLExport", "ent.compliance.jsonl.file.creation.appError", nil, "", 0).Wrap(err)
+	}
+	defer os.Remove(dest.Name())
+
+	metadata := common_export.Metadata{
+		Channels: map[string]common_export.MetadataChannel{},
+	}
+	membersByChannel := make(common_export.MembersByChannel)
+
+	messages := make([]*Event, 0, len(posts))
+	for _, post := range posts {
+		attachments, appErr := getPostAttachments(db, post)
+		if appErr != nil {
+			return warningCount, appErr
+		}
+
+		if _, ok := membersByChannel[*post.ChannelId]; !ok {
+			membersByChannel[*post.ChannelId] = common_export.ChannelMembers{}
+		}
+		membersByChannel[*post.ChannelId][*post.UserId] = common_export.ChannelMember{
+			UserId:   *post.UserId,
+			Username: *post.Username,
+			IsBot:    post.IsBot,
+			Email:    *post.UserEmail,
+		}
+
+		metadata.Update(post, len(attachments))
+		messages = append(messages, postToEvent(post, attachments))
+	}
+
+	events, appErr := getJoinLeaveEvents(metadata.Channels, membersByChannel, db)
+	if appErr != nil {
+		return warningCount, appErr
+	}
+	// Join and leave events come first so that a message sent at the time a user
+	// joined is listed after the join.
+	events = append(events, messages...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+
+	writer := bufio.NewWriter(dest)
+	encoder := json.NewEncoder(writer)
+	membershipMap := make(common_export.MembershipMap)
+	for _, event := range events {
+		if !applyMembership(membershipMap, event) {
+			continue
+		}
+
+		if err = encoder.Encode(event); err != nil {
+			return warningCount, model.NewAppError("JSONLExport", "ent.compliance.jsonl.event.export.appError", nil, "", 0).Wrap(err)
+		}
+	}
+	if err = writer.Flush(); err != nil {
+		return warningCount, model.NewAppError("JSONLExport", "ent.compliance.jsonl.event.export.appError", nil, "", 0).Wrap(err)
+	}
+
+	if _, err = dest.Seek(0, 0); err != nil {
+		return warningCount, model.NewAppError("JSONLExport", "ent.compliance.jsonl.seek.appError", nil, "", 0).Wrap(err)
+	}
+	// Try to write the file without a timeout due to the potential size of the file.
+	_, err = filestore.TryWriteFileContext(rctx.Context(), exportBackend, dest, path.Join(exportDirectory, JSONLExportFilename))
+	if err != nil {
+		return warningCount, model.NewAppError("JSONLExport", "ent.compliance.jsonl.write_file.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return warningCount, nil
+}
+
+// applyMembership tracks the members of the channel of the event. It returns false for joins of
+// users that are already in the channel and leaves of users that are not, which are skipped. The
+// members of the channel are recorded on messages.
+func applyMembership(membershipMap common_export.MembershipMap, event *Event) bool {
+	user := common_export.MembershipMapUser{
+		UserId:   event.User.Id,
+		Email:    event.User.Email,
+		Username: event.User.Username,
+	}
+
+	switch event.Type {
+	case JoinEventType:
+		if membershipMap.IsUserInChannel(event.Channel.Id, user.Email) {
+			return false
+		}
+		membershipMap.AddUserToChannel(event.Channel.Id, user)
+	case LeaveEventType:
+		if !membershipMap.IsUserInChannel(event.Channel.Id, user.Email) {
+			return false
+		}
+		membershipMap.RemoveUserFromChannel(event.Channel.Id, user.Email)
+	case MessageEventType:
+		members := membershipMap.GetUserEmailsInChannel(event.Channel.Id)
+		sort.Strings(members)
+		event.ChannelMembers = members
+	}
+
+	return true
+}
+
+func getJoinLeaveEvents(channels map[string]common_export.MetadataChannel, membersByChannel common_export.MembersByChannel, db store.Store) ([]*Event, *model.AppError) {
+	events := []*Event{}
+	for _, channel := range channels {
+		channelMembersHistory, err := db.ChannelMemberHistory().GetUsersInChannelDuring(channel.StartTime, channel.EndTime, channel.ChannelId)
+		if err != nil {
+			return nil, model.NewAppError("getJoinLeaveEvents", "ent.get_users_in_channel_during", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		joins, leaves := common_export.GetJoinsAndLeavesForChannel(channel.StartTime, channel.EndTime, channelMembersHistory, membersByChannel[channel.ChannelId])
+
+		for _, join := range joins {
+			event := &Event{
+				Type:    JoinEventType,
+				Time:    join.Datetime,
+				Team:    metadataChannelToTeam(channel),
+				Channel: metadataChannelToChannel(channel),
+				User: User{
+					Id:       join.UserId,
+					Email:    join.Email,
+					Username: join.Username,
+					IsBot:    join.IsBot,
+				},
+			}
+			if join.Datetime <= channel.StartTime {
+				event.Time = channel.StartTime
+				event.PreviouslyJoined = true
+			}
+			events = append(events, event)
+		}
+		for _, leave := range leaves {
+			events = append(events, &Event{
+				Type:    LeaveEventType,
+				Time:    leave.Datetime,
+				Team:    metadataChannelToTeam(channel),
+				Channel: metadataChannelToChannel(channel),
+				User: User{
+					Id:       leave.UserId,
+					Email:    leave.Email,
+					Username: leave.Username,
+					IsBot:    leave.IsBot,
+				},
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+	return events, nil
+}
+
+func postToEvent(post *model.MessageExport, attachments []*model.FileInfo) *Event {
+	event := &Event{
+		Type: MessageEventType,
+		Time: *post.PostCreateAt,
+		Channel: Channel{
+			Id:          *post.ChannelId,
+			Name:        *post.ChannelName,
+			DisplayName: *post.ChannelDisplayName,
+			Type:        common_export.ChannelTypeDisplayName(*post.ChannelType),
+		},
+		User: User{
+			Id:       *post.UserId,
+			Email:    *post.UserEmail,
+			Username: *post.Username,
+			IsBot:    post.IsBot,
+		},
+		Post: &Post{
+			Id:             *post.PostId,
+			PreviewsPostId: post.PreviewID(),
+			Type:           *post.PostType,
+			Message:        *post.PostMessage,
+			CreateAt:       *post.PostCreateAt,
+		},
+	}
+
+	if post.TeamId != nil && *post.TeamId != "" {
+		event.Team = &Team{
+			Id:          *post.TeamId,
+			Name:        model.SafeDereference(post.TeamName),
+			DisplayName: model.SafeDereference(post.TeamDisplayName),
+		}
+	}
+	if post.PostRootId != nil {
+		event.Post.RootId = *post.PostRootId
+	}
+	if post.PostOriginalId != nil {
+		event.Post.EditedByPostId = *post.PostOriginalId
+	}
+	if post.PostUpdateAt != nil {
+		event.Post.UpdateAt = *post.PostUpdateAt
+	}
+	if post.PostDeleteAt != nil {
+		event.Post.DeleteAt = *post.PostDeleteAt
+	}
+	if post.PostProps != nil && json.Valid([]byte(*post.PostProps)) && *post.PostProps != "{}" {
+		event.Post.Props = json.RawMessage(*post.PostProps)
+	}
+
+	for _, attachment := range attachments {
+		event.Post.Attachments = append(event.Post.Attachments, Attachment{
+			Id:       attachment.Id,
+			Name:     attachment.Name,
+			Path:     attachment.Path,
+			Size:     attachment.Size,
+			MimeType: attachment.MimeType,
+			DeleteAt: attachment.DeleteAt,
+		})
+	}
+
+	return event
+}
+
+func metadataChannelToTeam(channel common_export.MetadataChannel) *Team {
+	if channel.TeamId == nil || *channel.TeamId == "" {
+		return nil
+	}
+
+	return &Team{
+		Id:          *channel.TeamId,
+		Name:        model.SafeDereference(channel.TeamName),
+		DisplayName: model.SafeDereference(channel.TeamDisplayName),
+	}
+}
+
+func metadataChannelToChannel(channel common_export.MetadataChannel) Channel {
+	return Channel{
+		Id:          channel.ChannelId,
+		Name:        channel.ChannelName,
+		DisplayName: channel.ChannelDisplayName,
+		Type:        common_export.ChannelTypeDisplayName(channel.ChannelType),
+	}
+}
+
+func getPostAttachments(db store.Store, post *model.MessageExport) ([]*model.FileInfo, *model.AppError) {
+	// if the post included any files, we need to reference them in the export.
+	if len(post.PostFileIds) == 0 {
+		return []*model.FileInfo{}, nil
+	}
+
+	attachments, err := db.FileInfo().GetForPost(*post.PostId, true, true, false)
+	if err != nil {
+		return nil, model.NewAppError("getPostAttachments", "ent.message_export.jsonl_export.get_attachment_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return attachments, nil
+}
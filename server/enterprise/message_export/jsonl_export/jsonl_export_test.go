@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/common_export"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func TestJSONLExport(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	exportTempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(exportTempDir)
+		assert.NoError(t, err)
+	})
+
+	exportBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  exportTempDir,
+	})
+	require.NoError(t, err)
+
+	chanTypeOpen := model.ChannelTypeOpen
+	newPost := func(id string, createAt int64, fileIds []string) *model.MessageExport {
+		return &model.MessageExport{
+			PostId:             model.NewPointer(id),
+			PostRootId:         model.NewPointer(""),
+			PostOriginalId:     model.NewPointer(""),
+			PostType:           model.NewPointer(""),
+			PostProps:          model.NewPointer(`{"from_bot":"false"}`),
+			TeamId:             model.NewPointer("team-id"),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("team-display-name"),
+			ChannelId:          model.NewPointer("channel-id"),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer("channel-display-name"),
+			ChannelType:        &chanTypeOpen,
+			PostCreateAt:       model.NewPointer(createAt),
+			PostMessage:        model.NewPointer("message " + id),
+			UserEmail:          model.NewPointer("test@test.com"),
+			UserId:             model.NewPointer("user-id"),
+			Username:           model.NewPointer("username"),
+			PostFileIds:        fileIds,
+		}
+	}
+
+	posts := []*model.MessageExport{
+		newPost("post-id-1", 1, []string{"file-id"}),
+		newPost("post-id-2", 100, []string{}),
+	}
+
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+	mockStore.FileInfoStore.On("GetForPost", "post-id-1", true, true, false).Return([]*model.FileInfo{
+		{Id: "file-id", Name: "file.txt", Path: "files/file.txt", Size: 10, MimeType: "text/plain"},
+	}, nil)
+	mockStore.ChannelMemberHistoryStore.On("GetUsersInChannelDuring", int64(1), int64(100), "channel-id").Return([]*model.ChannelMemberHistoryResult{
+		{JoinTime: 0, UserId: "user-id", UserEmail: "test@test.com", Username: "username"},
+		{JoinTime: 8, UserId: "other-id", UserEmail: "other@test.com", Username: "other", LeaveTime: model.NewPointer(int64(80))},
+	}, nil)
+
+	warningCount, appErr := JSONLExport(rctx, posts, mockStore, exportBackend, "test")
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(0), warningCount)
+
+	data, err := exportBackend.ReadFile("test/" + JSONLExportFilename)
+	require.NoError(t, err)
+
+	var events []*Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, &event)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, events, 5)
+
+	assert.Equal(t, JoinEventType, events[0].Type)
+	assert.Equal(t, "user-id", events[0].User.Id)
+	assert.Equal(t, int64(1), events[0].Time)
+	assert.True(t, events[0].PreviouslyJoined)
+	require.NotNil(t, events[0].Team)
+	assert.Equal(t, "team-name", events[0].Team.Name)
+
+	assert.Equal(t, MessageEventType, events[1].Type)
+	require.NotNil(t, events[1].Post)
+	assert.Equal(t, "post-id-1", events[1].Post.Id)
+	assert.Equal(t, "public", events[1].Channel.Type)
+	assert.JSONEq(t, `{"from_bot":"false"}`, string(events[1].Post.Props))
+	assert.Equal(t, []string{"test@test.com"}, events[1].ChannelMembers)
+	require.Len(t, events[1].Post.Attachments, 1)
+	assert.Equal(t, Attachment{Id: "file-id", Name: "file.txt", Path: "files/file.txt", Size: 10, MimeType: "text/plain"}, events[1].Post.Attachments[0])
+
+	assert.Equal(t, JoinEventType, events[2].Type)
+	assert.Equal(t, "other-id", events[2].User.Id)
+	assert.Equal(t, int64(8), events[2].Time)
+	assert.False(t, events[2].PreviouslyJoined)
+
+	assert.Equal(t, LeaveEventType, events[3].Type)
+	assert.Equal(t, "other-id", events[3].User.Id)
+	assert.Equal(t, int64(80), events[3].Time)
+
+	assert.Equal(t, MessageEventType, events[4].Type)
+	assert.Equal(t, "post-id-2", events[4].Post.Id)
+	assert.Equal(t, []string{"test@test.com"}, events[4].ChannelMembers)
+}
+
+func TestApplyMembership(t *testing.T) {
+	join := &Event{Type: JoinEventType, Channel: Channel{Id: "channel-id"}, User: User{Id: "user-id", Email: "test@test.com"}}
+	leave := &Event{Type: LeaveEventType, Channel: Channel{Id: "channel-id"}, User: User{Id: "user-id", Email: "test@test.com"}}
+	message := &Event{Type: MessageEventType, Channel: Channel{Id: "channel-id"}, User: User{Id: "user-id", Email: "test@test.com"}}
+
+	members := make(common_export.MembershipMap)
+	assert.False(t, applyMembership(members, leave), "a leave of a user that is not in the channel should be skipped")
+	assert.True(t, applyMembership(members, join))
+	assert.False(t, applyMembership(members, join), "a join of a user that is already in the channel should be skipped")
+	assert.True(t, applyMembership(members, message))
+	assert.Equal(t, []string{"test@test.com"}, message.ChannelMembers)
+	assert.True(t, applyMembership(members, leave))
+}
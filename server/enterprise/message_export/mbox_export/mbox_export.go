@@ -0,0 +1,346 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package mbox_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	gomail "gopkg.in/mail.v2"
+
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/common_export"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	MboxExportFilename  = "mbox_export.zip"
+	MboxWarningFilename = "warning.txt"
+
+	// MessageIdDomain is the right-hand side of the Message-ID of the exported posts, so that
+	// replies can reference their thread with In-Reply-To and References headers.
+	MessageIdDomain = "mattermost"
+
+	TeamNameHeader       = "X-Mattermost-TeamName"
+	ChannelIDHeader      = "X-Mattermost-ChannelID"
+	ChannelNameHeader    = "X-Mattermost-ChannelName"
+	ChannelTypeHeader    = "X-Mattermost-ChannelType"
+	PostIDHeader         = "X-Mattermost-PostID"
+	PostTypeHeader       = "X-Mattermost-PostType"
+	EditedByPostIDHeader = "X-Mattermost-EditedByPostID"
+	DeletedAtHeader      = "X-Mattermost-DeletedAt"
+	UserTypeHeader       = "X-Mattermost-UserType"
+)
+
+// MboxExport writes a zip file with one MBOX file per channel. Each post is an RFC 5322
+// message with its attachments, and replies carry threading headers pointing to their root post.
+func MboxExport(rctx request.CTX, posts []*model.MessageExport, db store.Store, exportBackend filestore.FileBackend, fileAttachmentBackend filestore.FileBackend, exportDirectory string) (warningCount int64, appErr *model.AppError) {
+	dest, err := os.CreateTemp("", MboxExportFilename)
+	if err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.file.creation.appError", nil, "", 0).Wrap(err)
+	}
+	defer os.Remove(dest.Name())
+
+	zipFile := zip.NewWriter(dest)
+
+	metadata := common_export.Metadata{
+		Channels:         map[string]common_export.MetadataChannel{},
+		MessagesCount:    0,
+		AttachmentsCount: 0,
+		StartTime:        0,
+		EndTime:          0,
+	}
+
+	membersByChannel := make(common_export.MembersByChannel)
+	postsByChannel := make(map[string][]*model.MessageExport)
+	attachmentsByPost := make(map[string][]*model.FileInfo)
+	channelIds := []string{}
+
+	for _, post := range posts {
+		attachments, appErr := getPostAttachments(db, post)
+		if appErr != nil {
+			return warningCount, appErr
+		}
+		attachmentsByPost[*post.PostId] = attachments
+
+		if _, ok := membersByChannel[*post.ChannelId]; !ok {
+			membersByChannel[*post.ChannelId] = common_export.ChannelMembers{}
+			channelIds = append(channelIds, *post.ChannelId)
+		}
+
+		membersByChannel[*post.ChannelId][*post.UserId] = common_export.ChannelMember{
+			UserId:   *post.UserId,
+			Username: *post.Username,
+			IsBot:    post.IsBot,
+			Email:    *post.UserEmail,
+		}
+		postsByChannel[*post.ChannelId] = append(postsByChannel[*post.ChannelId], post)
+
+		metadata.Update(post, len(attachments))
+	}
+
+	var missingFiles []string
+	for _, channelId := range channelIds {
+		channel := metadata.Channels[channelId]
+
+		recipients, appErr := getRecipients(db, channel, membersByChannel[channelId])
+		if appErr != nil {
+			return warningCount, appErr
+		}
+
+		mboxFile, err := zipFile.Create(channelId + ".mbox")
+		if err != nil {
+			return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.zip.creation.appError", nil, "", 0).Wrap(err)
+		}
+
+		for _, post := range postsByChannel[channelId] {
+			entry, err := newMboxEntryWriter(mboxFile, post)
+			if err != nil {
+				return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.message.export.appError", nil, "", 0).Wrap(err)
+			}
+
+			// The message, attachments included, is streamed into the MBOX file.
+			missing, appErr := writeMessage(rctx, entry, fileAttachmentBackend, channel, post, attachmentsByPost[*post.PostId], recipients)
+			if appErr != nil {
+				return warningCount, appErr
+			}
+			missingFiles = append(missingFiles, missing...)
+
+			if err := entry.Close(); err != nil {
+				return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.message.export.appError", nil, "", 0).Wrap(err)
+			}
+		}
+	}
+
+	warningCount = int64(len(missingFiles))
+	if warningCount > 0 {
+		warningFile, err := zipFile.Create(MboxWarningFilename)
+		if err != nil {
+			return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.warning.appError", nil, "", 0).Wrap(err)
+		}
+		for _, value := range missingFiles {
+			if _, err = warningFile.Write([]byte(value + "\n")); err != nil {
+				return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.warning.appError", nil, "", 0).Wrap(err)
+			}
+		}
+	}
+
+	metadataFile, err := zipFile.Create("metadata.json")
+	if err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.metadata.export.appError", nil, "", 0).Wrap(err)
+	}
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.metadata.export.appError", nil, "", 0).Wrap(err)
+	}
+	if _, err = metadataFile.Write(data); err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.metadata.export.appError", nil, "", 0).Wrap(err)
+	}
+	if err = zipFile.Close(); err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.zip.close.appError", nil, "", 0).Wrap(err)
+	}
+
+	if _, err = dest.Seek(0, 0); err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.seek.appError", nil, "", 0).Wrap(err)
+	}
+	// Try to write the file without a timeout due to the potential size of the file.
+	_, err = filestore.TryWriteFileContext(rctx.Context(), exportBackend, dest, path.Join(exportDirectory, MboxExportFilename))
+	if err != nil {
+		return warningCount, model.NewAppError("MboxExport", "ent.compliance.mbox.write_file.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return warningCount, nil
+}
+
+// getRecipients returns the addresses of the users that were in the channel during the export period.
+func getRecipients(db store.Store, channel common_export.MetadataChannel, members common_export.ChannelMembers) ([]string, *model.AppError) {
+	channelMembersHistory, err := db.ChannelMemberHistory().GetUsersInChannelDuring(channel.StartTime, channel.EndTime, channel.ChannelId)
+	if err != nil {
+		return nil, model.NewAppError("getRecipients", "ent.get_users_in_channel_during", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	joins, _ := common_export.GetJoinsAndLeavesForChannel(channel.StartTime, channel.EndTime, channelMembersHistory, members)
+
+	seen := map[string]bool{}
+	recipients := []string{}
+	for _, join := range joins {
+		if seen[join.UserId] {
+			continue
+		}
+		seen[join.UserId] = true
+		recipients = append(recipients, formatAddress(join.Username, join.Email))
+	}
+	sort.Strings(recipients)
+
+	return recipients, nil
+}
+
+func writeMessage(rctx request.CTX, w io.Writer, fileAttachmentBackend filestore.FileBackend, channel common_export.MetadataChannel, post *model.MessageExport, attachments []*model.FileInfo, recipients []string) ([]string, *model.AppError) {
+	var missingFiles []string
+
+	subject := channel.ChannelDisplayName
+	if subject == "" {
+		subject = channel.ChannelName
+	}
+
+	m := gomail.NewMessage(gomail.SetCharset("UTF-8"))
+	m.SetHeader("Message-ID", messageId(*post.PostId))
+	m.SetHeader("From", formatAddress(*post.Username, *post.UserEmail))
+	if len(recipients) > 0 {
+		m.SetHeader("To", recipients...)
+	}
+	if post.PostRootId != nil && *post.PostRootId != "" {
+		m.SetHeader("Subject", "Re: "+subject)
+		m.SetHeader("In-Reply-To", messageId(*post.PostRootId))
+		m.SetHeader("References", messageId(*post.PostRootId))
+	} else {
+		m.SetHeader("Subject", subject)
+	}
+	m.SetDateHeader("Date", time.UnixMilli(*post.PostCreateAt).UTC())
+	m.SetHeader("Auto-Submitted", "auto-generated")
+
+	if post.TeamName != nil && *post.TeamName != "" {
+		m.SetHeader(TeamNameHeader, *post.TeamName)
+	}
+	m.SetHeader(ChannelIDHeader, *post.ChannelId)
+	m.SetHeader(ChannelNameHeader, *post.ChannelName)
+	m.SetHeader(ChannelTypeHeader, common_export.ChannelTypeDisplayName(*post.ChannelType))
+	m.SetHeader(PostIDHeader, *post.PostId)
+	if *post.PostType != "" {
+		m.SetHeader(PostTypeHeader, *post.PostType)
+	}
+	if post.PostOriginalId != nil && *post.PostOriginalId != "" {
+		m.SetHeader(EditedByPostIDHeader, *post.PostOriginalId)
+	}
+	if post.PostDeleteAt != nil && *post.PostDeleteAt > 0 {
+		m.SetDateHeader(DeletedAtHeader, time.UnixMilli(*post.PostDeleteAt).UTC())
+	}
+	userType := "user"
+	if post.IsBot {
+		userType = "bot"
+	}
+	m.SetHeader(UserTypeHeader, userType)
+
+	m.SetBody("text/plain", *post.PostMessage)
+
+	for _, attachment := range attachments {
+		filePath := attachment.Path
+		m.Attach(attachment.Name, gomail.SetCopyFunc(func(writer io.Writer) error {
+			reader, appErr := fileAttachmentBackend.Reader(filePath)
+			if appErr != nil {
+				missingFiles = append(missingFiles, "Warning:"+common_export.MissingFileMessage+" - Post: "+*post.PostId+" - "+filePath)
+				rctx.Logger().Warn(common_export.MissingFileMessage, mlog.String("PostId", *post.PostId), mlog.String("FileName", filePath))
+				return nil
+			}
+			defer reader.Close()
+
+			_, err := io.Copy(writer, reader)
+			return err
+		}))
+	}
+
+	if _, err := m.WriteTo(w); err != nil {
+		return nil, model.NewAppError("MboxExport", "ent.compliance.mbox.message.export.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return missingFiles, nil
+}
+
+// mboxEntryWriter appends a message to an MBOX file using the mboxrd format: the message is
+// preceded by a "From " separator line, uses LF line endings and lines starting with any number
+// of ">" followed by "From " are quoted with an additional ">". Only the current line is kept
+// in memory, and the lines of a MIME message are at most 998 characters long.
+type mboxEntryWriter struct {
+	w    io.Writer
+	line []byte
+}
+
+func newMboxEntryWriter(w io.Writer, post *model.MessageExport) (*mboxEntryWriter, error) {
+	sender := *post.UserEmail
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+	if _, err := fmt.Fprintf(w, "From %s %s\n", sender, time.UnixMilli(*post.PostCreateAt).UTC().Format(time.ANSIC)); err != nil {
+		return nil, err
+	}
+
+	return &mboxEntryWriter{w: w}, nil
+}
+
+func (e *mboxEntryWriter) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			e.line = append(e.line, p...)
+			break
+		}
+
+		e.line = append(e.line, p[:i]...)
+		if err := e.writeLine(); err != nil {
+			return 0, err
+		}
+		p = p[i+1:]
+	}
+	return written, nil
+}
+
+// Close writes the last line of the message and the blank line ending the entry.
+func (e *mboxEntryWriter) Close() error {
+	if len(e.line) > 0 {
+		if err := e.writeLine(); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+func (e *mboxEntryWriter) writeLine() error {
+	line := bytes.TrimSuffix(e.line, []byte("\r"))
+	if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+		if _, err := io.WriteString(e.w, ">"); err != nil {
+			return err
+		}
+	}
+	if _, err := e.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	e.line = e.line[:0]
+	return nil
+}
+
+func messageId(postId string) string {
+	return fmt.Sprintf("<%s@%s>", postId, MessageIdDomain)
+}
+
+func formatAddress(username, email string) string {
+	return (&mail.Address{Name: username, Address: email}).String()
+}
+
+func getPostAttachments(db store.Store, post *model.MessageExport) ([]*model.FileInfo, *model.AppError) {
+	// if the post included any files, we need to attach them to the message.
+	if len(post.PostFileIds) == 0 {
+		return []*model.FileInfo{}, nil
+	}
+
+	attachments, err := db.FileInfo().GetForPost(*post.PostId, true, true, false)
+	if err != nil {
+		return nil, model.NewAppError("getPostAttachments", "ent.message_export.mbox_export.get_attachment_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return attachments, nil
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package mbox_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func TestMboxEntryWriter(t *testing.T) {
+	post := &model.MessageExport{
+		UserEmail:    model.NewPointer("test@test.com"),
+		PostCreateAt: model.NewPointer(int64(1700000000000)),
+	}
+
+	var b bytes.Buffer
+	entry, err := newMboxEntryWriter(&b, post)
+	require.NoError(t, err)
+
+	// The message is written in chunks that split lines and line endings.
+	for _, chunk := range []string{"Subject: test\r", "\n\r\nFr", "om the start\r\n>From quoted\r\nnot From here\r\n", "no line ending"} {
+		n, err := entry.Write([]byte(chunk))
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	require.NoError(t, entry.Close())
+
+	assert.Equal(t, strings.Join([]string{
+		"From test@test.com Tue Nov 14 22:13:20 2023",
+		"Subject: test",
+		"",
+		">From the start",
+		">>From quoted",
+		"not From here",
+		"no line ending",
+		"",
+		"",
+	}, "\n"), b.String())
+}
+
+func TestMboxExport(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	exportTempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(exportTempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  exportTempDir,
+	})
+	require.NoError(t, err)
+
+	chanTypeOpen := model.ChannelTypeOpen
+	newPost := func(id, rootId string, createAt int64, fileIds []string) *model.MessageExport {
+		return &model.MessageExport{
+			PostId:             model.NewPointer(id),
+			PostRootId:         model.NewPointer(rootId),
+			PostOriginalId:     model.NewPointer(""),
+			PostType:           model.NewPointer(""),
+			TeamId:             model.NewPointer("team-id"),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("team-display-name"),
+			ChannelId:          model.NewPointer("channel-id"),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer("Channel Display Name"),
+			ChannelType:        &chanTypeOpen,
+			PostCreateAt:       model.NewPointer(createAt),
+			PostMessage:        model.NewPointer("message " + id),
+			UserEmail:          model.NewPointer("test@test.com"),
+			UserId:             model.NewPointer("user-id"),
+			Username:           model.NewPointer("username"),
+			PostFileIds:        fileIds,
+		}
+	}
+
+	posts := []*model.MessageExport{
+		newPost("post-id-1", "", 1, []string{"file-id"}),
+		newPost("post-id-2", "post-id-1", 100, []string{}),
+	}
+
+	attachment := &model.FileInfo{
+		Id:   "file-id",
+		Name: "file.txt",
+		Path: "files/file.txt",
+	}
+	_, err = fileBackend.WriteFile(bytes.NewReader([]byte("file contents")), attachment.Path)
+	require.NoError(t, err)
+
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+	mockStore.FileInfoStore.On("GetForPost", "post-id-1", true, true, false).Return([]*model.FileInfo{attachment}, nil)
+	mockStore.ChannelMemberHistoryStore.On("GetUsersInChannelDuring", int64(1), int64(100), "channel-id").Return([]*model.ChannelMemberHistoryResult{
+		{JoinTime: 0, UserId: "other-id", UserEmail: "other@test.com", Username: "other"},
+	}, nil)
+
+	warningCount, appErr := MboxExport(rctx, posts, mockStore, fileBackend, fileBackend, "test")
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(0), warningCount)
+
+	zipBytes, err := fileBackend.ReadFile("test/" + MboxExportFilename)
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+	require.Len(t, zipReader.File, 2)
+	assert.Equal(t, "channel-id.mbox", zipReader.File[0].Name)
+	assert.Equal(t, "metadata.json", zipReader.File[1].Name)
+
+	mboxFile, err := zipReader.File[0].Open()
+	require.NoError(t, err)
+	defer mboxFile.Close()
+	mboxData, err := io.ReadAll(mboxFile)
+	require.NoError(t, err)
+
+	entries := strings.Split(string(mboxData), "\n\nFrom test@test.com ")
+	require.Len(t, entries, 2)
+
+	first, err := mail.ReadMessage(strings.NewReader(strings.SplitN(entries[0], "\n", 2)[1]))
+	require.NoError(t, err)
+	assert.Equal(t, "<post-id-1@mattermost>", first.Header.Get("Message-ID"))
+	assert.Equal(t, `"username" <test@test.com>`, first.Header.Get("From"))
+	assert.Equal(t, `"other" <other@test.com>, "username" <test@test.com>`, first.Header.Get("To"))
+	assert.Equal(t, "Channel Display Name", first.Header.Get("Subject"))
+	assert.Equal(t, "team-name", first.Header.Get(TeamNameHeader))
+	assert.Equal(t, "public", first.Header.Get(ChannelTypeHeader))
+	assert.Empty(t, first.Header.Get("In-Reply-To"))
+	assert.Contains(t, first.Header.Get("Content-Type"), "multipart/mixed")
+	assert.Contains(t, entries[0], `filename="file.txt"`)
+
+	second, err := mail.ReadMessage(strings.NewReader(strings.SplitN(entries[1], "\n", 2)[1]))
+	require.NoError(t, err)
+	assert.Equal(t, "<post-id-2@mattermost>", second.Header.Get("Message-ID"))
+	assert.Equal(t, "<post-id-1@mattermost>", second.Header.Get("In-Reply-To"))
+	assert.Equal(t, "<post-id-1@mattermost>", second.Header.Get("References"))
+	assert.Equal(t, "Re: Channel Display Name", second.Header.Get("Subject"))
+	body, err := io.ReadAll(second.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "message post-id-2", strings.TrimSpace(string(body)))
+}
+
+func TestMboxExportMissingAttachment(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	exportTempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(exportTempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  exportTempDir,
+	})
+	require.NoError(t, err)
+
+	chanTypeDirect := model.ChannelTypeDirect
+	posts := []*model.MessageExport{
+		{
+			PostId:             model.NewPointer("post-id"),
+			PostType:           model.NewPointer(""),
+			ChannelId:          model.NewPointer("channel-id"),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer(""),
+			ChannelType:        &chanTypeDirect,
+			PostCreateAt:       model.NewPointer(int64(1)),
+			PostMessage:        model.NewPointer("message"),
+			UserEmail:          model.NewPointer("test@test.com"),
+			UserId:             model.NewPointer("user-id"),
+			Username:           model.NewPointer("username"),
+			PostFileIds:        []string{"file-id"},
+		},
+	}
+
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+	mockStore.FileInfoStore.On("GetForPost", "post-id", true, true, false).Return([]*model.FileInfo{{Id: "file-id", Name: "missing.txt", Path: "files/missing.txt"}}, nil)
+	mockStore.ChannelMemberHistoryStore.On("GetUsersInChannelDuring", int64(1), int64(1), "channel-id").Return([]*model.ChannelMemberHistoryResult{}, nil)
+
+	warningCount, appErr := MboxExport(rctx, posts, mockStore, fileBackend, fileBackend, "test")
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(1), warningCount)
+
+	zipBytes, err := fileBackend.ReadFile("test/" + MboxExportFilename)
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+	require.Len(t, zipReader.File, 3)
+	assert.Equal(t, MboxWarningFilename, zipReader.File[1].Name)
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"strconv"
@@ -29,6 +30,8 @@ import (
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/actiance_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/csv_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/global_relay_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/jsonl_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/mbox_export"
 )
 
 const (
@@ -177,6 +180,17 @@ func runExportByType(rctx request.CTX, exportType string, postsToExport []*model
 			rctx.Logger().Warn("CreateAt is missing for post", mlog.String("post_id", *post.PostId))
 			post.PostCreateAt = new(int64)
 		}
+
+		// Votes are stored apart from the post, add the results to the exported message.
+		if *post.PostType == model.PostTypePoll {
+			results, err := pollResultsForExport(rctx, db, *post.PostId)
+			if err != nil {
+				rctx.Logger().Warn("Failed to get the results of poll", mlog.String("post_id", *post.PostId), mlog.Err(err))
+			} else {
+				message := *post.PostMessage + results
+				post.PostMessage = &message
+			}
+		}
 	}
 
 	switch exportType {
@@ -188,6 +202,14 @@ func runExportByType(rctx request.CTX, exportType string, postsToExport []*model
 		rctx.Logger().Debug("Exporting Actiance")
 		return actiance_export.ActianceExport(rctx, postsToExport, db, exportBackend, fileAttachmentBackend, exportDirectory)
 
+	case model.ComplianceExportTypeMbox:
+		rctx.Logger().Debug("Exporting MBOX")
+		return mbox_export.MboxExport(rctx, postsToExport, db, exportBackend, fileAttachmentBackend, exportDirectory)
+
+	case model.ComplianceExportTypeJsonl:
+		rctx.Logger().Debug("Exporting JSONL")
+		return jsonl_export.JSONLExport(rctx, postsToExport, db, exportBackend, exportDirectory)
+
 	case model.ComplianceExportTypeGlobalrelay, model.ComplianceExportTypeGlobalrelayZip:
 		rctx.Logger().Debug("Exporting GlobalRelay")
 		f, err := os.CreateTemp("", "")
@@ -232,3 +254,59 @@ func runExportByType(rctx request.CTX, exportType string, postsToExport []*model
 	}
 	return warningCount, nil
 }
+
+// pollResultsForExport formats the tally of a poll. Voters are listed by
+// username unless the poll is anonymous.
+func pollResultsForExport(rctx request.CTX, db store.Store, postID string) (string, error) {
+	poll, err := db.Poll().Get(postID)
+	if err != nil {
+		return "", err
+	}
+
+	votes, err := db.Poll().GetVotes(postID)
+	if err != nil {
+		return "", err
+	}
+
+	results := model.NewPollResults(poll, votes, "", model.GetMillis())
+
+	usernames := map[string]string{}
+	if !poll.Anonymous && len(votes) > 0 {
+		userIDs := make([]string, 0, len(votes))
+		for _, vote := range votes {
+			userIDs = append(userIDs, vote.UserId)
+		}
+		users, err := db.User().GetProfileByIds(rctx.Context(), userIDs, &store.UserGetByIdsOpts{}, false)
+		if err != nil {
+			return "", err
+		}
+		for _, user := range users {
+			usernames[user.Id] = user.Username
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\nPoll results")
+	if results.Closed {
+		sb.WriteString(" (closed)")
+	}
+	sb.WriteString(":")
+	for i, option := range poll.Options {
+		fmt.Fprintf(&sb, "\n- %s: %d", option, results.Counts[i])
+		if results.Voters == nil || len(results.Voters[i]) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(results.Voters[i]))
+		for _, userID := range results.Voters[i] {
+			if username, ok := usernames[userID]; ok {
+				names = append(names, username)
+			} else {
+				names = append(names, userID)
+			}
+		}
+		fmt.Fprintf(&sb, " (%s)", strings.Join(names, ", "))
+	}
+
+	return sb.String(), nil
+}
@@ -41,6 +41,7 @@ const (
 	MetricsSubsystemSharedChannels     = "shared_channels"
 	MetricsSubsystemSystem             = "system"
 	MetricsSubsystemJobs               = "jobs"
+	MetricsSubsystemImageProxy         = "image_proxy"
 	MetricsSubsystemNotifications      = "notifications"
 	MetricsSubsystemClientsMobileApp   = "mobileapp"
 	MetricsSubsystemClientsWeb         = "webapp"
@@ -60,6 +61,9 @@ type MetricsInterfaceImpl struct {
 	DbSearchConnectionsGauge prometheus.GaugeFunc
 	DbReplicaLagGaugeAbs     *prometheus.GaugeVec
 	DbReplicaLagGaugeTime    *prometheus.GaugeVec
+	DbReplicaLagSeconds      *prometheus.GaugeVec
+	DbReplicaInRotation      *prometheus.GaugeVec
+	DbReadYourWritesMaster   prometheus.Counter
 
 	PostCreateCounter     prometheus.Counter
 	WebhookPostCounter    prometheus.Counter
@@ -68,9 +72,10 @@ type MetricsInterfaceImpl struct {
 	PostBroadcastCounter  prometheus.Counter
 	PostFileAttachCounter prometheus.Counter
 
-	HTTPRequestsCounter prometheus.Counter
-	HTTPErrorsCounter   prometheus.Counter
-	HTTPWebsocketsGauge *prometheus.GaugeVec
+	HTTPRequestsCounter    prometheus.Counter
+	HTTPErrorsCounter      prometheus.Counter
+	HTTPRateLimitedCounter *prometheus.CounterVec
+	HTTPWebsocketsGauge    *prometheus.GaugeVec
 
 	ClusterRequestsDuration prometheus.Histogram
 	ClusterRequestsCounter  prometheus.Counter
@@ -194,6 +199,11 @@ type MetricsInterfaceImpl struct {
 
 	JobsActive *prometheus.GaugeVec
 
+	ImageProxyCacheHits      prometheus.Counter
+	ImageProxyCacheMisses    prometheus.Counter
+	ImageProxyCacheEvictions prometheus.Counter
+	ImageProxyCacheSize      prometheus.Gauge
+
 	NotificationTotalCounters       *prometheus.CounterVec
 	NotificationAckCounters         *prometheus.CounterVec
 	NotificationSuccessCounters     *prometheus.CounterVec
@@ -388,6 +398,39 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.DbReplicaLagGaugeTime)
 
+	m.DbReplicaLagSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemDB,
+			Name:        "replica_lag_seconds",
+			Help:        "The replication lag of a read replica, as measured by the replica health check.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"node"},
+	)
+	m.Registry.MustRegister(m.DbReplicaLagSeconds)
+
+	m.DbReplicaInRotation = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemDB,
+			Name:        "replica_in_rotation",
+			Help:        "Whether a read replica is serving reads (1) or was taken out of rotation because of its lag (0).",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"node"},
+	)
+	m.Registry.MustRegister(m.DbReplicaInRotation)
+
+	m.DbReadYourWritesMaster = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemDB,
+		Name:        "read_your_writes_master_requests_total",
+		Help:        "The total number of requests whose reads were routed to the master because the user had just written.",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.DbReadYourWritesMaster)
+
 	// HTTP Subsystem
 
 	m.HTTPWebsocketsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -417,6 +460,15 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	})
 	m.Registry.MustRegister(m.HTTPErrorsCounter)
 
+	m.HTTPRateLimitedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemHTTP,
+		Name:        "rate_limited_total",
+		Help:        "The total number of http requests denied by a rate limit policy.",
+		ConstLabels: additionalLabels,
+	}, []string{"policy"})
+	m.Registry.MustRegister(m.HTTPRateLimitedCounter)
+
 	// Cluster Subsystem
 
 	m.ClusterHealthGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
@@ -494,6 +546,10 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		model.ClusterEventInvalidateCacheForLastPosts,
 		model.ClusterEventInvalidateCacheForLastPostTime,
 		model.ClusterEventInvalidateCacheForPostsUsage,
+		model.ClusterEventInvalidateCacheForThreadMemberships,
+		model.ClusterEventInvalidateCacheForPreferences,
+		model.ClusterEventInvalidateCacheForPostPriority,
+		model.ClusterEventInvalidateCacheForChannelBookmarks,
 		model.ClusterEventInvalidateCacheForTeams,
 		model.ClusterEventClearSessionCacheForAllUsers,
 		model.ClusterEventInstallPlugin,
@@ -1107,6 +1163,44 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.JobsActive)
 
+	// Image Proxy Subsystem
+
+	m.ImageProxyCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemImageProxy,
+		Name:        "cache_hits_total",
+		Help:        "The total number of images served from the image proxy cache.",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ImageProxyCacheHits)
+
+	m.ImageProxyCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemImageProxy,
+		Name:        "cache_misses_total",
+		Help:        "The total number of images the image proxy had to fetch because they weren't cached.",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ImageProxyCacheMisses)
+
+	m.ImageProxyCacheEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemImageProxy,
+		Name:        "cache_evictions_total",
+		Help:        "The total number of images removed from the image proxy cache to stay within its size.",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ImageProxyCacheEvictions)
+
+	m.ImageProxyCacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemImageProxy,
+		Name:        "cache_size_bytes",
+		Help:        "The size of the images stored in the image proxy cache of this node.",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ImageProxyCacheSize)
+
 	m.NotificationTotalCounters = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace:   MetricsNamespace,
@@ -1579,6 +1673,10 @@ func (mi *MetricsInterfaceImpl) IncrementHTTPError() {
 	mi.HTTPErrorsCounter.Inc()
 }
 
+func (mi *MetricsInterfaceImpl) IncrementHTTPRateLimited(policy string) {
+	mi.HTTPRateLimitedCounter.With(prometheus.Labels{"policy": policy}).Inc()
+}
+
 func (mi *MetricsInterfaceImpl) IncrementClusterRequest() {
 	mi.ClusterRequestsCounter.Inc()
 }
@@ -1775,6 +1873,22 @@ func (mi *MetricsInterfaceImpl) DecrementJobActive(jobType string) {
 	mi.JobsActive.With(prometheus.Labels{"type": jobType}).Dec()
 }
 
+func (mi *MetricsInterfaceImpl) IncrementImageProxyCacheHitCounter() {
+	mi.ImageProxyCacheHits.Inc()
+}
+
+func (mi *MetricsInterfaceImpl) IncrementImageProxyCacheMissCounter() {
+	mi.ImageProxyCacheMisses.Inc()
+}
+
+func (mi *MetricsInterfaceImpl) IncrementImageProxyCacheEvictionCounter() {
+	mi.ImageProxyCacheEvictions.Inc()
+}
+
+func (mi *MetricsInterfaceImpl) SetImageProxyCacheSize(size float64) {
+	mi.ImageProxyCacheSize.Set(size)
+}
+
 func (mi *MetricsInterfaceImpl) IncrementRemoteClusterConnStateChangeCounter(remoteID string, online bool) {
 	mi.RemoteClusterConnStateChangeCounter.With(prometheus.Labels{
 		"remote_id": remoteID,
@@ -1832,6 +1946,26 @@ func (mi *MetricsInterfaceImpl) SetReplicaLagTime(node string, value float64) {
 	mi.DbReplicaLagGaugeTime.With(prometheus.Labels{"node": node}).Set(value)
 }
 
+// SetReplicaLagSeconds sets the replication lag of a replica measured by the health check.
+func (mi *MetricsInterfaceImpl) SetReplicaLagSeconds(node string, value float64) {
+	mi.DbReplicaLagSeconds.With(prometheus.Labels{"node": node}).Set(value)
+}
+
+// SetReplicaInRotation sets whether a replica is serving reads.
+func (mi *MetricsInterfaceImpl) SetReplicaInRotation(node string, inRotation bool) {
+	value := 0.0
+	if inRotation {
+		value = 1
+	}
+	mi.DbReplicaInRotation.With(prometheus.Labels{"node": node}).Set(value)
+}
+
+// IncrementReadYourWritesMasterCounter increments the number of requests read from the master
+// after a write by the same user.
+func (mi *MetricsInterfaceImpl) IncrementReadYourWritesMasterCounter() {
+	mi.DbReadYourWritesMaster.Inc()
+}
+
 func normalizeNotificationPlatform(platform string) string {
 	switch platform {
 	case "apple_rn-v2", "apple_rnbeta-v2", "ios":
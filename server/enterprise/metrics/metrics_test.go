@@ -54,6 +54,7 @@ func TestMetrics(t *testing.T) {
 
 	mi.IncrementHTTPRequest()
 	mi.IncrementHTTPError()
+	mi.IncrementHTTPRateLimited("default")
 
 	mi.IncrementPostFileAttachment(5)
 	mi.IncrementPostCreate()
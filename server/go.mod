@@ -1,11 +1,12 @@
 module github.com/mattermost/mattermost/server/v8
 
-go 1.22.0
+go 1.22.2
 
 toolchain go1.22.6
 
 require (
 	code.sajari.com/docconv/v2 v2.0.0-pre.4
+	github.com/HugoSmits86/nativewebp v1.2.1
 	github.com/Masterminds/semver/v3 v3.2.1
 	github.com/avct/uasurfer v0.0.0-20240501094946-ca0c4d1e541b
 	github.com/aws/aws-sdk-go v1.55.5
@@ -76,9 +77,9 @@ require (
 	github.com/xtgo/uuid v0.0.0-20140804021211-a0b114877d4c
 	github.com/yuin/goldmark v1.7.4
 	golang.org/x/crypto v0.25.0
-	golang.org/x/image v0.18.0
+	golang.org/x/image v0.24.0
 	golang.org/x/net v0.27.0
-	golang.org/x/sync v0.7.0
+	golang.org/x/sync v0.11.0
 	golang.org/x/term v0.22.0
 	golang.org/x/tools v0.23.0
 	gopkg.in/mail.v2 v2.3.1
@@ -227,7 +228,7 @@ require (
 	golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
 	golang.org/x/mod v0.19.0 // indirect
 	golang.org/x/sys v0.24.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240722135656-d784300faade // indirect
 	google.golang.org/grpc v1.65.0 // indirect
 	google.golang.org/protobuf v1.34.2 // indirect
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/redis/rueidis"
+)
+
+// RateLimitStore keeps the state of a GCRA rate limiter. Its method set
+// matches throttled.GCRAStore so that implementations can be handed to
+// throttled directly.
+type RateLimitStore interface {
+	// GetWithTime returns the value of the key, or -1 if it does not exist,
+	// along with the current time of the store.
+	GetWithTime(key string) (int64, time.Time, error)
+	// SetIfNotExistsWithTTL sets the value of the key only if it does not
+	// exist yet and reports whether it was set.
+	SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error)
+	// CompareAndSwapWithTTL atomically replaces the value of the key if it
+	// still holds old, and reports whether it was replaced.
+	CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error)
+}
+
+// RateLimitStoreProvider is implemented by cache providers that can keep rate
+// limiter state shared by every node of a cluster.
+type RateLimitStoreProvider interface {
+	// NewRateLimitStore returns a store whose keys are namespaced by name.
+	NewRateLimitStore(name string) RateLimitStore
+}
+
+// redisCASScript swaps the value of KEYS[1] from ARGV[1] to ARGV[2] and sets
+// its TTL to ARGV[3] seconds. It returns 0 when the key is missing or holds a
+// different value.
+var redisCASScript = rueidis.NewLuaScript(`
+local v = redis.call('get', KEYS[1])
+if v == false or v ~= ARGV[1] then
+  return 0
+end
+redis.call('setex', KEYS[1], ARGV[3], ARGV[2])
+return 1
+`)
+
+type redisRateLimitStore struct {
+	name    string
+	client  rueidis.Client
+	metrics einterfaces.MetricsInterface
+}
+
+// NewRateLimitStore returns a rate limiter store backed by the Redis server
+// of the provider.
+func (r *redisProvider) NewRateLimitStore(name string) RateLimitStore {
+	return &redisRateLimitStore{
+		name:    name,
+		client:  r.client,
+		metrics: r.metrics,
+	}
+}
+
+func (s *redisRateLimitStore) observe(operation string, start time.Time) {
+	if s.metrics != nil {
+		s.metrics.ObserveRedisEndpointDuration(s.name, operation, time.Since(start).Seconds())
+	}
+}
+
+// redisTTLSeconds rounds the TTL down to whole seconds, keeping at least one
+// second because an expiry of zero would delete the key right away.
+func redisTTLSeconds(ttl time.Duration) int64 {
+	seconds := int64(ttl.Seconds())
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+func (s *redisRateLimitStore) GetWithTime(key string) (int64, time.Time, error) {
+	defer s.observe("RateLimitGet", time.Now())
+
+	resps := s.client.DoMulti(context.Background(),
+		s.client.B().Time().Build(),
+		s.client.B().Get().Key(s.name+":"+key).Build(),
+	)
+
+	serverTime, err := resps[0].AsIntSlice()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(serverTime) != 2 {
+		return 0, time.Time{}, fmt.Errorf("unexpected TIME reply: %v", serverTime)
+	}
+	now := time.Unix(serverTime[0], serverTime[1]*int64(time.Microsecond))
+
+	v, err := resps[1].AsInt64()
+	if rueidis.IsRedisNil(err) {
+		return -1, now, nil
+	} else if err != nil {
+		return 0, now, err
+	}
+
+	return v, now, nil
+}
+
+func (s *redisRateLimitStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	defer s.observe("RateLimitSet", time.Now())
+
+	err := s.client.Do(context.Background(),
+		s.client.B().Set().
+			Key(s.name+":"+key).
+			Value(strconv.FormatInt(value, 10)).
+			Nx().
+			ExSeconds(redisTTLSeconds(ttl)).
+			Build(),
+	).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *redisRateLimitStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	defer s.observe("RateLimitCAS", time.Now())
+
+	swapped, err := redisCASScript.Exec(context.Background(), s.client,
+		[]string{s.name + ":" + key},
+		[]string{strconv.FormatInt(old, 10), strconv.FormatInt(new, 10), strconv.FormatInt(redisTTLSeconds(ttl), 10)},
+	).AsInt64()
+	if err != nil {
+		return false, err
+	}
+
+	return swapped == 1, nil
+}
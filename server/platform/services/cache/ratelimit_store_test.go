@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+	"github.com/throttled/throttled/store/storetest"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestRedisRateLimitStore(t *testing.T) {
+	addr := os.Getenv("MM_CACHESETTINGS_REDISADDRESS")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
+	p, err := NewRedisProvider(&RedisOptions{RedisAddr: addr, DisableCache: true})
+	if err != nil {
+		t.Skipf("redis is not available at %s: %v", addr, err)
+	}
+	defer p.Close()
+
+	provider, ok := p.(RateLimitStoreProvider)
+	require.True(t, ok, "redis provider should support rate limit stores")
+
+	var store throttled.GCRAStore = provider.NewRateLimitStore("ratelimit_test_" + model.NewId())
+	storetest.TestGCRAStore(t, store)
+	storetest.TestGCRAStoreTTL(t, store)
+}
+
+func TestLRUProviderDoesNotSupportRateLimitStores(t *testing.T) {
+	_, ok := NewProvider().(RateLimitStoreProvider)
+	require.False(t, ok)
+}
@@ -18,12 +18,35 @@ type archiveExtractor struct {
 	SubExtractor Extractor
 }
 
+// sourceArchiveFormats maps the extensions of package and source archive
+// formats that are plain zip or tarball files under the hood to the archive
+// extension understood by archiver.
+var sourceArchiveFormats = map[string]string{
+	"jar":   ".zip",
+	"war":   ".zip",
+	"ear":   ".zip",
+	"whl":   ".zip",
+	"egg":   ".zip",
+	"nupkg": ".zip",
+	"vsix":  ".zip",
+	"crate": ".tar.gz",
+}
+
+// archiveFilename returns the name under which the archive must be stored so
+// archiver is able to detect its format.
+func archiveFilename(filename string) string {
+	if format, ok := sourceArchiveFormats[fileExtension(filename)]; ok {
+		return filename + format
+	}
+	return filename
+}
+
 func (ae *archiveExtractor) Name() string {
 	return "archiveExtractor"
 }
 
 func (ae *archiveExtractor) Match(filename string) bool {
-	_, err := archiver.ByExtension(filename)
+	_, err := archiver.ByExtension(archiveFilename(filename))
 	return err == nil
 }
 
@@ -34,7 +57,7 @@ func (ae *archiveExtractor) Extract(name string, r io.ReadSeeker) (string, error
 	}
 	defer os.RemoveAll(dir)
 
-	f, err := os.Create(filepath.Join(dir, name))
+	f, err := os.Create(filepath.Join(dir, archiveFilename(name)))
 	if err != nil {
 		return "", fmt.Errorf("error copying data into temporary file: %v", err)
 	}
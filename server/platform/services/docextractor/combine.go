@@ -4,14 +4,31 @@
 package docextractor
 
 import (
+	"fmt"
 	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+// ErrExtractionTimeout is returned when an extractor exceeds its time budget.
+var ErrExtractionTimeout = errors.New("timeout extracting file content")
+
+// maxAbandonedExtractions is the maximum number of extractions still running after
+// their timeout. Extractions with a timeout are refused until some of them finish.
+const maxAbandonedExtractions = 16
+
+// abandonedExtractions counts the extractions still running after their timeout.
+var abandonedExtractions atomic.Int64
+
 type combineExtractor struct {
 	logger        mlog.LoggerIFace
 	SubExtractors []Extractor
+	budgets       map[string]Budget
 }
 
 func (ce *combineExtractor) Name() string {
@@ -22,6 +39,18 @@ func (ce *combineExtractor) Add(extractor Extractor) {
 	ce.SubExtractors = append(ce.SubExtractors, extractor)
 }
 
+// AddWithBudget adds an extractor which is only used for files within the
+// given budget.
+func (ce *combineExtractor) AddWithBudget(extractor Extractor, budget Budget) {
+	if budget != (Budget{}) {
+		if ce.budgets == nil {
+			ce.budgets = map[string]Budget{}
+		}
+		ce.budgets[extractor.Name()] = budget
+	}
+	ce.Add(extractor)
+}
+
 func (ce *combineExtractor) Match(filename string) bool {
 	for _, extractor := range ce.SubExtractors {
 		if extractor.Match(filename) {
@@ -32,10 +61,29 @@ func (ce *combineExtractor) Match(filename string) bool {
 }
 
 func (ce *combineExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	var size int64 = -1
 	for _, extractor := range ce.SubExtractors {
 		if extractor.Match(filename) {
+			budget := ce.budgets[extractor.Name()]
+			if budget.MaxFileSize > 0 {
+				if size < 0 {
+					var err error
+					if size, err = r.Seek(0, io.SeekEnd); err != nil {
+						return "", errors.Wrap(err, "unable to get the file size")
+					}
+				}
+				if size > budget.MaxFileSize {
+					ce.logger.Debug("Skipping extractor, file exceeds its size budget", mlog.String("file_name", filename), mlog.String("extractor", extractor.Name()), mlog.Int("file_size", size))
+					continue
+				}
+			}
+
 			r.Seek(0, io.SeekStart)
-			text, err := extractor.Extract(filename, r)
+			text, err := extractWithTimeout(extractor, filename, r, budget.Timeout)
+			if errors.Is(err, ErrExtractionTimeout) {
+				ce.logger.Warn("Unable to extract file content in time", mlog.String("file_name", filename), mlog.String("extractor", extractor.Name()))
+				return "", err
+			}
 			if err != nil {
 				ce.logger.Warn("Unable to extract file content", mlog.String("file_name", filename), mlog.String("extractor", extractor.Name()), mlog.Err(err))
 				continue
@@ -45,3 +93,95 @@ func (ce *combineExtractor) Extract(filename string, r io.ReadSeeker) (string, e
 	}
 	return "", nil
 }
+
+// extractWithTimeout runs the extractor for at most the given time. Go can't stop a running
+// goroutine, so an extractor that times out keeps running in the background until it's done.
+// It can no longer read from r though, so the caller is free to close or reuse r once this
+// function returns. The number of extractors left running is bounded by maxAbandonedExtractions.
+func extractWithTimeout(extractor Extractor, filename string, r io.ReadSeeker, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		return extractor.Extract(filename, r)
+	}
+
+	if abandonedExtractions.Load() >= maxAbandonedExtractions {
+		return "", errors.Wrapf(ErrExtractionTimeout, "too many extractions still running after their timeout, skipping extractor %s", extractor.Name())
+	}
+
+	sr := &stoppableReader{r: r}
+
+	type result struct {
+		text string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if sr.finish() {
+				abandonedExtractions.Add(-1)
+			}
+		}()
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- result{err: fmt.Errorf("extractor %s panicked: %v", extractor.Name(), rec)}
+			}
+		}()
+		text, err := extractor.Extract(filename, sr)
+		done <- result{text: text, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res.text, res.err
+	case <-timer.C:
+		if sr.stop() {
+			abandonedExtractions.Add(1)
+		}
+		return "", errors.Wrapf(ErrExtractionTimeout, "extractor %s exceeded %s", extractor.Name(), timeout)
+	}
+}
+
+// stoppableReader wraps the reader handed to an extractor with a timeout, so that the
+// extractor stops reading from it once it times out.
+type stoppableReader struct {
+	mut      sync.Mutex
+	r        io.ReadSeeker
+	stopped  bool
+	finished bool
+}
+
+func (sr *stoppableReader) Read(p []byte) (int, error) {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+	if sr.stopped {
+		return 0, ErrExtractionTimeout
+	}
+	return sr.r.Read(p)
+}
+
+func (sr *stoppableReader) Seek(offset int64, whence int) (int64, error) {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+	if sr.stopped {
+		return 0, ErrExtractionTimeout
+	}
+	return sr.r.Seek(offset, whence)
+}
+
+// stop prevents any further read, waiting for the one in progress if any. It returns
+// whether the extraction is still running.
+func (sr *stoppableReader) stop() bool {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+	sr.stopped = true
+	return !sr.finished
+}
+
+// finish marks the extraction as done. It returns whether it had been stopped before.
+func (sr *stoppableReader) finish() bool {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+	sr.finished = true
+	return sr.stopped
+}
@@ -5,6 +5,7 @@ package docextractor
 
 import (
 	"io"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
@@ -16,21 +17,45 @@ type ExtractSettings struct {
 	MMPreviewSecret  string
 }
 
+// builtinExtractors returns the pure Go extractors for the formats that
+// docconv doesn't handle, along with their default budgets.
+func builtinExtractors() []registeredExtractor {
+	return []registeredExtractor{
+		{extractor: &spreadsheetExtractor{}, budget: Budget{MaxFileSize: 50 * 1024 * 1024, Timeout: 30 * time.Second}},
+		{extractor: &epubExtractor{}, budget: Budget{MaxFileSize: 50 * 1024 * 1024, Timeout: 30 * time.Second}},
+		{extractor: &emlExtractor{}, budget: Budget{MaxFileSize: 25 * 1024 * 1024, Timeout: 15 * time.Second}},
+		{extractor: &notebookExtractor{}, budget: Budget{MaxFileSize: 25 * 1024 * 1024, Timeout: 15 * time.Second}},
+	}
+}
+
 // Extract extract the text from a document using the system default extractors
 func Extract(logger mlog.LoggerIFace, filename string, r io.ReadSeeker, settings ExtractSettings) (string, error) {
-	return ExtractWithExtraExtractors(logger, filename, r, settings, []Extractor{})
+	return ExtractWithRegistry(logger, filename, r, settings, nil)
 }
 
 // ExtractWithExtraExtractors extract the text from a document using the provided extractors beside the system default extractors.
 func ExtractWithExtraExtractors(logger mlog.LoggerIFace, filename string, r io.ReadSeeker, settings ExtractSettings, extraExtractors []Extractor) (string, error) {
+	registry := NewRegistry()
+	for _, extraExtractor := range extraExtractors {
+		registry.Register(extraExtractor, Budget{})
+	}
+	return ExtractWithRegistry(logger, filename, r, settings, registry)
+}
+
+// ExtractWithRegistry extract the text from a document using the extractors of the registry,
+// which take precedence over the system default extractors. The registry can be nil.
+func ExtractWithRegistry(logger mlog.LoggerIFace, filename string, r io.ReadSeeker, settings ExtractSettings, registry *Registry) (string, error) {
 	enabledExtractors := &combineExtractor{
 		logger: logger,
 	}
-	for _, extraExtractor := range extraExtractors {
-		enabledExtractors.Add(extraExtractor)
+	for _, re := range registry.registered() {
+		enabledExtractors.AddWithBudget(re.extractor, re.budget)
 	}
 	enabledExtractors.Add(&documentExtractor{})
 	enabledExtractors.Add(&pdfExtractor{})
+	for _, re := range builtinExtractors() {
+		enabledExtractors.AddWithBudget(re.extractor, re.budget)
+	}
 
 	if settings.ArchiveRecursion {
 		enabledExtractors.Add(&archiveExtractor{SubExtractor: enabledExtractors})
@@ -153,6 +153,16 @@ func TestExtract(t *testing.T) {
 		require.Equal(t, "", text)
 	})
 
+	t.Run("Source archive file", func(t *testing.T) {
+		data := makeZipDocument(t, map[string]string{
+			"com/example/Main.java": "public class Main {}",
+		})
+		text, err := Extract(logger, "library.jar", bytes.NewReader(data), ExtractSettings{ArchiveRecursion: true})
+		require.NoError(t, err)
+		assert.Contains(t, text, "Main.java")
+		assert.Contains(t, text, "public class Main")
+	})
+
 	t.Run("Wrong docx extension", func(t *testing.T) {
 		data, err := testutils.ReadTestFile("sample-doc.pdf")
 		require.NoError(t, err)
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// maxEMLNesting limits how deep nested multiparts and forwarded messages are
+// walked when extracting an email.
+const maxEMLNesting = 10
+
+// emlExtractor extracts the headers, text bodies and attachment names of
+// RFC 5322 email messages.
+type emlExtractor struct{}
+
+var emlIndexedHeaders = []string{"From", "To", "Cc", "Subject"}
+
+func (ee *emlExtractor) Name() string {
+	return "emlExtractor"
+}
+
+func (ee *emlExtractor) Extensions() []string {
+	return []string{"eml"}
+}
+
+func (ee *emlExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "eml"
+}
+
+func (ee *emlExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	var text strings.Builder
+	if err := extractEmail(r, &text, 0); err != nil {
+		return "", err
+	}
+	return text.String(), nil
+}
+
+func extractEmail(r io.Reader, text *strings.Builder, depth int) error {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return err
+	}
+
+	decoder := new(mime.WordDecoder)
+	for _, name := range emlIndexedHeaders {
+		value := msg.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		if decoded, err := decoder.DecodeHeader(value); err == nil {
+			value = decoded
+		}
+		text.WriteString(value + "\n")
+	}
+
+	return extractEmailPart(textproto.MIMEHeader(msg.Header), msg.Body, text, depth)
+}
+
+func extractEmailPart(header textproto.MIMEHeader, body io.Reader, text *strings.Builder, depth int) error {
+	if depth > maxEMLNesting {
+		return errors.New("email nesting is too deep")
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if filename := emailAttachmentName(header, params); filename != "" {
+		text.WriteString(filename + "\n")
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := extractEmailPart(part.Header, part, text, depth+1); err != nil {
+				return err
+			}
+		}
+	case mediaType == "message/rfc822":
+		return extractEmail(body, text, depth+1)
+	case mediaType == "text/html":
+		content, err := htmlToText(body)
+		if err != nil {
+			return err
+		}
+		text.WriteString(content + "\n")
+	case mediaType == "text/plain":
+		content, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		text.Write(content)
+		text.WriteString("\n")
+	}
+	return nil
+}
+
+func emailAttachmentName(header textproto.MIMEHeader, contentTypeParams map[string]string) string {
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		return params["filename"]
+	}
+	return contentTypeParams["name"]
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEML(t *testing.T) {
+	extractor := emlExtractor{}
+
+	content := strings.ReplaceAll(`From: Alice <alice@example.com>
+To: bob@example.com
+Subject: =?utf-8?q?Quarterly_r=C3=A9sum=C3=A9?=
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Plain body with a soft=
+ break
+--inner
+Content-Type: text/html
+
+<html><body><p>Html body</p></body></html>
+--inner--
+--outer
+Content-Type: text/plain
+Content-Disposition: attachment; filename="notes.txt"
+Content-Transfer-Encoding: base64
+
+QXR0YWNoZWQgbm90ZXM=
+--outer--
+`, "\n", "\r\n")
+
+	extractedText, err := extractor.Extract("mail.eml", bytes.NewReader([]byte(content)))
+	require.NoError(t, err)
+	assert.Contains(t, extractedText, "alice@example.com")
+	assert.Contains(t, extractedText, "Quarterly résumé")
+	assert.Contains(t, extractedText, "Plain body with a soft break")
+	assert.Contains(t, extractedText, "Html body")
+	assert.Contains(t, extractedText, "notes.txt")
+	assert.Contains(t, extractedText, "Attached notes")
+}
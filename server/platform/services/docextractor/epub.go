@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// epubExtractor extracts the metadata and the text of the chapters of an EPUB
+// book, following the reading order defined in its package document.
+type epubExtractor struct{}
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Titles   []string `xml:"metadata>title"`
+	Creators []string `xml:"metadata>creator"`
+	Items    []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	ItemRefs []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+func (ee *epubExtractor) Name() string {
+	return "epubExtractor"
+}
+
+func (ee *epubExtractor) Extensions() []string {
+	return []string{"epub"}
+}
+
+func (ee *epubExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "epub"
+}
+
+func (ee *epubExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	doc, err := openZipDocument(r)
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	chapters, err := epubChapters(doc, &text)
+	if err != nil {
+		return "", err
+	}
+
+	for _, chapter := range chapters {
+		rc, err := doc.open(chapter)
+		if err != nil {
+			return "", err
+		}
+		chapterText, err := htmlToText(rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(chapterText)
+		text.WriteString("\n")
+	}
+	return text.String(), nil
+}
+
+// epubChapters returns the content documents of the book in reading order,
+// writing the book metadata into text. If the package document can't be
+// found, every (X)HTML document in the archive is returned.
+func epubChapters(doc *zipDocument, text *strings.Builder) ([]*zip.File, error) {
+	pkg, pkgPath := readEPUBPackage(doc)
+	if pkg == nil {
+		var chapters []*zip.File
+		for _, f := range doc.File {
+			switch fileExtension(f.Name) {
+			case "xhtml", "html", "htm":
+				chapters = append(chapters, f)
+			}
+		}
+		if len(chapters) == 0 {
+			return nil, errors.New("no content documents found in epub file")
+		}
+		sort.Slice(chapters, func(i, j int) bool { return chapters[i].Name < chapters[j].Name })
+		return chapters, nil
+	}
+
+	for _, title := range pkg.Titles {
+		text.WriteString(strings.TrimSpace(title) + "\n")
+	}
+	for _, creator := range pkg.Creators {
+		text.WriteString(strings.TrimSpace(creator) + "\n")
+	}
+
+	hrefByID := make(map[string]string, len(pkg.Items))
+	for _, item := range pkg.Items {
+		if strings.Contains(item.MediaType, "html") {
+			hrefByID[item.ID] = item.Href
+		}
+	}
+
+	var chapters []*zip.File
+	baseDir := path.Dir(pkgPath)
+	for _, itemRef := range pkg.ItemRefs {
+		href, ok := hrefByID[itemRef.IDRef]
+		if !ok {
+			continue
+		}
+		if f := doc.find(path.Join(baseDir, href)); f != nil {
+			chapters = append(chapters, f)
+		}
+	}
+	return chapters, nil
+}
+
+func readEPUBPackage(doc *zipDocument) (*epubPackage, string) {
+	f := doc.find("META-INF/container.xml")
+	if f == nil {
+		return nil, ""
+	}
+	data, err := doc.read(f)
+	if err != nil {
+		return nil, ""
+	}
+	var container epubContainer
+	if err = xml.Unmarshal(data, &container); err != nil || len(container.Rootfiles) == 0 {
+		return nil, ""
+	}
+
+	pkgPath := container.Rootfiles[0].FullPath
+	f = doc.find(pkgPath)
+	if f == nil {
+		return nil, ""
+	}
+	data, err = doc.read(f)
+	if err != nil {
+		return nil, ""
+	}
+	var pkg epubPackage
+	if err = xml.Unmarshal(data, &pkg); err != nil {
+		return nil, ""
+	}
+	return &pkg, pkgPath
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEPUB(t *testing.T) {
+	extractor := epubExtractor{}
+
+	t.Run("follows the spine", func(t *testing.T) {
+		content := makeZipDocument(t, map[string]string{
+			"META-INF/container.xml": `<container><rootfiles><rootfile full-path="OEBPS/content.opf"/></rootfiles></container>`,
+			"OEBPS/content.opf": `<package><metadata><dc:title xmlns:dc="http://purl.org/dc/elements/1.1/">A Tale</dc:title><dc:creator xmlns:dc="http://purl.org/dc/elements/1.1/">Jane Doe</dc:creator></metadata>
+				<manifest><item id="c1" href="text/one.xhtml" media-type="application/xhtml+xml"/><item id="c2" href="text/two.xhtml" media-type="application/xhtml+xml"/></manifest>
+				<spine><itemref idref="c2"/><itemref idref="c1"/></spine></package>`,
+			"OEBPS/text/one.xhtml": `<html><head><style>p { color: red; }</style></head><body><p>First chapter</p></body></html>`,
+			"OEBPS/text/two.xhtml": `<html><body><h1>Prologue</h1><script>alert(1)</script></body></html>`,
+		})
+
+		extractedText, err := extractor.Extract("tale.epub", bytes.NewReader(content))
+		require.NoError(t, err)
+		assert.Contains(t, extractedText, "A Tale")
+		assert.Contains(t, extractedText, "Jane Doe")
+		assert.NotContains(t, extractedText, "color")
+		assert.NotContains(t, extractedText, "alert")
+		assert.Less(t, bytes.Index([]byte(extractedText), []byte("Prologue")), bytes.Index([]byte(extractedText), []byte("First chapter")))
+	})
+
+	t.Run("without package document", func(t *testing.T) {
+		content := makeZipDocument(t, map[string]string{
+			"chapter.html": `<html><body><p>Loose chapter</p></body></html>`,
+		})
+
+		extractedText, err := extractor.Extract("loose.epub", bytes.NewReader(content))
+		require.NoError(t, err)
+		assert.Contains(t, extractedText, "Loose chapter")
+	})
+
+	t.Run("not a zip file", func(t *testing.T) {
+		_, err := extractor.Extract("broken.epub", bytes.NewReader([]byte("not an epub")))
+		require.Error(t, err)
+	})
+}
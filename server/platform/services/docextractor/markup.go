@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxUncompressedDocumentSize limits how much data is read from all the entries
+// of a zip based document together, to protect against decompression bombs.
+const maxUncompressedDocumentSize = 100 * 1024 * 1024
+
+var errUncompressedSizeExceeded = errors.New("zip document exceeds the maximum uncompressed size")
+
+var htmlBlockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "td": true, "th": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"section": true, "article": true, "blockquote": true, "pre": true, "title": true,
+}
+
+// htmlToText returns the visible text of an HTML or XHTML document.
+func htmlToText(r io.Reader) (string, error) {
+	var text strings.Builder
+	skip := 0
+
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			return strings.TrimSpace(text.String()), nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "script", "style":
+				skip++
+			default:
+				if htmlBlockElements[string(name)] {
+					text.WriteString("\n")
+				}
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "script", "style":
+				if skip > 0 {
+					skip--
+				}
+			default:
+				if htmlBlockElements[string(name)] {
+					text.WriteString("\n")
+				}
+			}
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if chunk := strings.TrimSpace(string(tokenizer.Text())); chunk != "" {
+				text.WriteString(chunk)
+				text.WriteString(" ")
+			}
+		}
+	}
+}
+
+// zipDocument is a zip based document, like OOXML, ODF or EPUB files. Its entries
+// are streamed, and share a single budget of uncompressed data.
+type zipDocument struct {
+	*zip.Reader
+	remaining int64
+}
+
+// openZipDocument opens a zip based document without reading it into memory.
+func openZipDocument(r io.ReadSeeker) (*zipDocument, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	readerAt, ok := r.(io.ReaderAt)
+	if !ok {
+		readerAt = &readSeekerAt{r: r}
+	}
+	zr, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return nil, err
+	}
+	return &zipDocument{Reader: zr, remaining: maxUncompressedDocumentSize}, nil
+}
+
+// open returns the uncompressed content of a zip entry. Reading fails once the
+// entries read from the document exceed maxUncompressedDocumentSize.
+func (d *zipDocument) open(f *zip.File) (io.ReadCloser, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &zipEntryReader{ReadCloser: rc, doc: d}, nil
+}
+
+// read returns the whole uncompressed content of a zip entry, charging it to
+// the budget of the document.
+func (d *zipDocument) read(f *zip.File) ([]byte, error) {
+	rc, err := d.open(f)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func (d *zipDocument) find(name string) *zip.File {
+	for _, f := range d.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+type zipEntryReader struct {
+	io.ReadCloser
+	doc *zipDocument
+}
+
+func (r *zipEntryReader) Read(p []byte) (int, error) {
+	if r.doc.remaining <= 0 {
+		return 0, errUncompressedSizeExceeded
+	}
+	if int64(len(p)) > r.doc.remaining {
+		p = p[:r.doc.remaining]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.doc.remaining -= int64(n)
+	return n, err
+}
+
+// readSeekerAt reads a document that doesn't implement io.ReaderAt. The entries
+// of a document are read one after the other, so it doesn't need to support
+// concurrent reads.
+type readSeekerAt struct {
+	r io.ReadSeeker
+}
+
+func (ra *readSeekerAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := ra.r.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(ra.r, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// notebookExtractor extracts the cell sources and the text outputs of Jupyter
+// notebooks.
+type notebookExtractor struct{}
+
+type notebookCell struct {
+	Source  notebookText `json:"source"`
+	Input   notebookText `json:"input"`
+	Outputs []struct {
+		Text notebookText            `json:"text"`
+		Data map[string]notebookText `json:"data"`
+	} `json:"outputs"`
+}
+
+type notebook struct {
+	Cells      []notebookCell `json:"cells"`
+	Worksheets []struct {
+		Cells []notebookCell `json:"cells"`
+	} `json:"worksheets"`
+}
+
+// notebookText is a multiline string, which notebooks store either as a single
+// string or as a list of lines.
+type notebookText string
+
+func (nt *notebookText) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*nt = notebookText(strings.Join(lines, ""))
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		// Non textual outputs, like images metadata, are ignored.
+		*nt = ""
+		return nil
+	}
+	*nt = notebookText(text)
+	return nil
+}
+
+func (ne *notebookExtractor) Name() string {
+	return "notebookExtractor"
+}
+
+func (ne *notebookExtractor) Extensions() []string {
+	return []string{"ipynb"}
+}
+
+func (ne *notebookExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "ipynb"
+}
+
+func (ne *notebookExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	var nb notebook
+	if err := json.NewDecoder(r).Decode(&nb); err != nil {
+		return "", err
+	}
+
+	cells := nb.Cells
+	// nbformat 3 stores the cells inside worksheets.
+	for _, worksheet := range nb.Worksheets {
+		cells = append(cells, worksheet.Cells...)
+	}
+
+	var text strings.Builder
+	for _, cell := range cells {
+		writeNotebookText(&text, cell.Source)
+		writeNotebookText(&text, cell.Input)
+		for _, output := range cell.Outputs {
+			writeNotebookText(&text, output.Text)
+			writeNotebookText(&text, output.Data["text/plain"])
+		}
+	}
+	return text.String(), nil
+}
+
+func writeNotebookText(text *strings.Builder, nt notebookText) {
+	if content := strings.TrimSpace(string(nt)); content != "" {
+		text.WriteString(content)
+		text.WriteString("\n")
+	}
+}
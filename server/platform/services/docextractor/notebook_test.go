@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotebook(t *testing.T) {
+	extractor := notebookExtractor{}
+
+	t.Run("nbformat 4", func(t *testing.T) {
+		content := `{"cells": [
+			{"cell_type": "markdown", "source": ["# Analysis\n", "of the data"]},
+			{"cell_type": "code", "source": "print('hello')", "outputs": [
+				{"output_type": "stream", "text": ["hello\n"]},
+				{"output_type": "execute_result", "data": {"text/plain": "42", "image/png": "iVBORw0KGgo="}}
+			]}
+		], "nbformat": 4}`
+
+		extractedText, err := extractor.Extract("analysis.ipynb", bytes.NewReader([]byte(content)))
+		require.NoError(t, err)
+		assert.Equal(t, "# Analysis\nof the data\nprint('hello')\nhello\n42\n", extractedText)
+	})
+
+	t.Run("nbformat 3", func(t *testing.T) {
+		content := `{"worksheets": [{"cells": [{"cell_type": "code", "input": ["x = 1"]}]}], "nbformat": 3}`
+
+		extractedText, err := extractor.Extract("old.ipynb", bytes.NewReader([]byte(content)))
+		require.NoError(t, err)
+		assert.Equal(t, "x = 1\n", extractedText)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := extractor.Extract("broken.ipynb", bytes.NewReader([]byte("{")))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Budget limits the resources a single extractor is allowed to use for one file.
+// A zero value on any field means that there is no limit for it.
+type Budget struct {
+	// MaxFileSize is the maximum size in bytes of a file handed to the extractor.
+	// Bigger files are skipped by this extractor.
+	MaxFileSize int64
+	// Timeout is the maximum time the extractor is allowed to run for a single file.
+	Timeout time.Duration
+}
+
+// ExtensionLister is implemented by extractors that can report the file
+// extensions (without the leading dot) they are able to handle. It is used to
+// figure out which file types become searchable when an extractor is added.
+type ExtensionLister interface {
+	Extensions() []string
+}
+
+type registeredExtractor struct {
+	extractor Extractor
+	budget    Budget
+}
+
+// Registry holds additional extractors that take precedence over the system
+// default ones. It is safe for concurrent use.
+type Registry struct {
+	mut        sync.RWMutex
+	extractors []registeredExtractor
+}
+
+// NewRegistry creates an empty extractor registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds an extractor to the registry with the given budget. Registering
+// an extractor with the same name as an existing one replaces it.
+func (r *Registry) Register(extractor Extractor, budget Budget) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for i, re := range r.extractors {
+		if re.extractor.Name() == extractor.Name() {
+			r.extractors[i] = registeredExtractor{extractor: extractor, budget: budget}
+			return
+		}
+	}
+	r.extractors = append(r.extractors, registeredExtractor{extractor: extractor, budget: budget})
+}
+
+// Unregister removes the extractor with the given name, if present.
+func (r *Registry) Unregister(name string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	remaining := r.extractors[:0]
+	for _, re := range r.extractors {
+		if re.extractor.Name() != name {
+			remaining = append(remaining, re)
+		}
+	}
+	r.extractors = remaining
+}
+
+// Extractors returns the registered extractors in registration order.
+func (r *Registry) Extractors() []Extractor {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	extractors := make([]Extractor, 0, len(r.extractors))
+	for _, re := range r.extractors {
+		extractors = append(extractors, re.extractor)
+	}
+	return extractors
+}
+
+func (r *Registry) registered() []registeredExtractor {
+	if r == nil {
+		return nil
+	}
+
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	registered := make([]registeredExtractor, len(r.extractors))
+	copy(registered, r.extractors)
+	return registered
+}
+
+// Extensions returns the sorted list of file extensions handled by the
+// registered extractors that implement ExtensionLister.
+func (r *Registry) Extensions() []string {
+	var extensions []string
+	for _, re := range r.registered() {
+		if lister, ok := re.extractor.(ExtensionLister); ok {
+			extensions = append(extensions, lister.Extensions()...)
+		}
+	}
+	return normalizeExtensions(extensions)
+}
+
+// SupportedExtensions returns the sorted list of file extensions that the
+// system default extractors, plus the ones in the given registry, know how to
+// handle. Plain text and archive formats are not included as they are matched
+// by content or by the archive library respectively.
+func SupportedExtensions(registry *Registry) []string {
+	extensions := make([]string, 0, len(doconvConverterByExtensions))
+	for ext := range doconvConverterByExtensions {
+		extensions = append(extensions, ext)
+	}
+	for _, extractor := range builtinExtractors() {
+		extensions = append(extensions, extractor.extractor.(ExtensionLister).Extensions()...)
+	}
+	if registry != nil {
+		extensions = append(extensions, registry.Extensions()...)
+	}
+	return normalizeExtensions(extensions)
+}
+
+func normalizeExtensions(extensions []string) []string {
+	seen := make(map[string]bool, len(extensions))
+	result := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimPrefix(ext, "."))
+		if ext == "" || seen[ext] {
+			continue
+		}
+		seen[ext] = true
+		result = append(result, ext)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func fileExtension(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testExtensionExtractor struct {
+	name      string
+	extension string
+	text      string
+	delay     time.Duration
+	read      chan error
+}
+
+func (te *testExtensionExtractor) Name() string {
+	return te.name
+}
+
+func (te *testExtensionExtractor) Extensions() []string {
+	return []string{te.extension}
+}
+
+func (te *testExtensionExtractor) Match(filename string) bool {
+	return fileExtension(filename) == te.extension
+}
+
+func (te *testExtensionExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	time.Sleep(te.delay)
+	if te.read != nil {
+		_, err := io.ReadAll(r)
+		te.read <- err
+	}
+	return te.text, nil
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("register, replace and unregister", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(&testExtensionExtractor{name: "one", extension: "one"}, Budget{})
+		registry.Register(&testExtensionExtractor{name: "two", extension: "TWO"}, Budget{})
+		registry.Register(&testExtensionExtractor{name: "one", extension: "uno"}, Budget{})
+
+		require.Len(t, registry.Extractors(), 2)
+		assert.Equal(t, []string{"two", "uno"}, registry.Extensions())
+
+		registry.Unregister("one")
+		require.Len(t, registry.Extractors(), 1)
+		assert.Equal(t, "two", registry.Extractors()[0].Name())
+	})
+
+	t.Run("supported extensions include the registry", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(&testExtensionExtractor{name: "custom", extension: "custom"}, Budget{})
+
+		extensions := SupportedExtensions(registry)
+		for _, ext := range []string{"custom", "csv", "docx", "eml", "epub", "ipynb", "ods", "pdf", "xlsx"} {
+			assert.Contains(t, extensions, ext)
+		}
+		assert.NotContains(t, SupportedExtensions(nil), "custom")
+	})
+}
+
+func TestExtractWithRegistry(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	content := []byte(strings.Repeat("x", 100))
+
+	t.Run("registry extractor takes precedence", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(&testExtensionExtractor{name: "csv", extension: "csv", text: "custom csv"}, Budget{})
+
+		text, err := ExtractWithRegistry(logger, "data.csv", bytes.NewReader(content), ExtractSettings{}, registry)
+		require.NoError(t, err)
+		assert.Equal(t, "custom csv", text)
+	})
+
+	t.Run("files over the size budget are skipped", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(&testExtensionExtractor{name: "csv", extension: "csv", text: "custom csv"}, Budget{MaxFileSize: 10})
+
+		text, err := ExtractWithRegistry(logger, "data.csv", bytes.NewReader(content), ExtractSettings{}, registry)
+		require.NoError(t, err)
+		assert.Equal(t, string(content)+"\n", text)
+	})
+
+	t.Run("extractors over the time budget fail", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(&testExtensionExtractor{name: "slow", extension: "slow", text: "too late", delay: time.Second}, Budget{Timeout: 10 * time.Millisecond})
+
+		text, err := ExtractWithRegistry(logger, "data.slow", bytes.NewReader(content), ExtractSettings{}, registry)
+		require.ErrorIs(t, err, ErrExtractionTimeout)
+		assert.Empty(t, text)
+	})
+
+	t.Run("extractors over the time budget can't read the file anymore", func(t *testing.T) {
+		read := make(chan error, 1)
+		registry := NewRegistry()
+		registry.Register(&testExtensionExtractor{name: "slow", extension: "slow", delay: 50 * time.Millisecond, read: read}, Budget{Timeout: 10 * time.Millisecond})
+
+		// Extractors of the previous tests may still be running.
+		abandoned := abandonedExtractions.Load()
+
+		_, err := ExtractWithRegistry(logger, "data.slow", bytes.NewReader(content), ExtractSettings{}, registry)
+		require.ErrorIs(t, err, ErrExtractionTimeout)
+		assert.Equal(t, abandoned+1, abandonedExtractions.Load())
+
+		require.ErrorIs(t, <-read, ErrExtractionTimeout)
+		assert.Eventually(t, func() bool { return abandonedExtractions.Load() <= abandoned }, 2*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// spreadsheetExtractor extracts the cell contents of xlsx, ods and csv files
+// without the need of an external conversion service.
+type spreadsheetExtractor struct{}
+
+var xlsxSheetRegexp = regexp.MustCompile(`^xl/worksheets/sheet(\d+)\.xml$`)
+
+func (se *spreadsheetExtractor) Name() string {
+	return "spreadsheetExtractor"
+}
+
+func (se *spreadsheetExtractor) Extensions() []string {
+	return []string{"csv", "ods", "xlsx"}
+}
+
+func (se *spreadsheetExtractor) Match(filename string) bool {
+	switch fileExtension(filename) {
+	case "csv", "ods", "xlsx":
+		return true
+	}
+	return false
+}
+
+func (se *spreadsheetExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	switch fileExtension(filename) {
+	case "csv":
+		return extractCSV(r)
+	case "ods":
+		return extractODS(r)
+	case "xlsx":
+		return extractXLSX(r)
+	}
+	return "", errors.New("unsupported spreadsheet format")
+}
+
+func extractCSV(r io.Reader) (string, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var text strings.Builder
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		writeRow(&text, record)
+	}
+	return text.String(), nil
+}
+
+func extractXLSX(r io.ReadSeeker) (string, error) {
+	doc, err := openZipDocument(r)
+	if err != nil {
+		return "", err
+	}
+
+	var sharedStrings []string
+	if f := doc.find("xl/sharedStrings.xml"); f != nil {
+		rc, err := doc.open(f)
+		if err != nil {
+			return "", err
+		}
+		sharedStrings, err = parseXLSXSharedStrings(rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	type sheet struct {
+		index int
+		file  *zip.File
+	}
+	var sheets []sheet
+	for _, f := range doc.File {
+		matches := xlsxSheetRegexp.FindStringSubmatch(f.Name)
+		if matches == nil {
+			continue
+		}
+		index, _ := strconv.Atoi(matches[1])
+		sheets = append(sheets, sheet{index: index, file: f})
+	}
+	if len(sheets) == 0 {
+		return "", errors.New("no worksheets found in xlsx file")
+	}
+	sort.Slice(sheets, func(i, j int) bool { return sheets[i].index < sheets[j].index })
+
+	var text strings.Builder
+	if f := doc.find("xl/workbook.xml"); f != nil {
+		if data, err := doc.read(f); err == nil {
+			for _, name := range parseXLSXSheetNames(data) {
+				text.WriteString(name + "\n")
+			}
+		}
+	}
+	for _, s := range sheets {
+		rc, err := doc.open(s.file)
+		if err != nil {
+			return "", err
+		}
+		err = parseXLSXSheet(rc, sharedStrings, &text)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return text.String(), nil
+}
+
+func parseXLSXSharedStrings(r io.Reader) ([]string, error) {
+	var (
+		sharedStrings []string
+		current       strings.Builder
+		inText        bool
+	)
+
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return sharedStrings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				current.Reset()
+			case "t":
+				inText = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				sharedStrings = append(sharedStrings, current.String())
+			case "t":
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		}
+	}
+}
+
+func parseXLSXSheetNames(data []byte) []string {
+	var names []string
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return names
+		}
+		if t, ok := token.(xml.StartElement); ok && t.Name.Local == "sheet" {
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "name" {
+					names = append(names, attr.Value)
+				}
+			}
+		}
+	}
+}
+
+func parseXLSXSheet(r io.Reader, sharedStrings []string, text *strings.Builder) error {
+	var (
+		row       []string
+		cellType  string
+		cellValue strings.Builder
+		inValue   bool
+	)
+
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				row = row[:0]
+			case "c":
+				cellType = ""
+				cellValue.Reset()
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "t" {
+						cellType = attr.Value
+					}
+				}
+			case "v", "t":
+				inValue = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "row":
+				writeRow(text, row)
+			case "c":
+				value := cellValue.String()
+				if cellType == "s" {
+					index, err := strconv.Atoi(strings.TrimSpace(value))
+					if err != nil || index < 0 || index >= len(sharedStrings) {
+						value = ""
+					} else {
+						value = sharedStrings[index]
+					}
+				}
+				row = append(row, value)
+			case "v", "t":
+				inValue = false
+			}
+		case xml.CharData:
+			if inValue {
+				cellValue.Write(t)
+			}
+		}
+	}
+}
+
+func extractODS(r io.ReadSeeker) (string, error) {
+	doc, err := openZipDocument(r)
+	if err != nil {
+		return "", err
+	}
+	f := doc.find("content.xml")
+	if f == nil {
+		return "", errors.New("no content found in ods file")
+	}
+	rc, err := doc.open(f)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	var (
+		text        strings.Builder
+		row         []string
+		cellValue   strings.Builder
+		inParagraph bool
+	)
+
+	decoder := xml.NewDecoder(rc)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return text.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "table":
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "name" {
+						text.WriteString(attr.Value + "\n")
+					}
+				}
+			case "table-row":
+				row = row[:0]
+			case "table-cell":
+				cellValue.Reset()
+			case "p":
+				if cellValue.Len() > 0 {
+					cellValue.WriteString(" ")
+				}
+				inParagraph = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "table-row":
+				writeRow(&text, row)
+			case "table-cell":
+				row = append(row, cellValue.String())
+			case "p":
+				inParagraph = false
+			}
+		case xml.CharData:
+			if inParagraph {
+				cellValue.Write(t)
+			}
+		}
+	}
+}
+
+// writeRow writes the non empty cells of a row as a tab separated line.
+func writeRow(text *strings.Builder, cells []string) {
+	first := true
+	for _, cell := range cells {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		if !first {
+			text.WriteString("\t")
+		}
+		text.WriteString(cell)
+		first = false
+	}
+	if !first {
+		text.WriteString("\n")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeZipDocument(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestSpreadsheetCSV(t *testing.T) {
+	extractor := spreadsheetExtractor{}
+	content := "name,role\nalice,\"developer, backend\"\nbob,designer\n"
+	extractedText, err := extractor.Extract("people.csv", bytes.NewReader([]byte(content)))
+	require.NoError(t, err)
+	require.Equal(t, "name\trole\nalice\tdeveloper, backend\nbob\tdesigner\n", extractedText)
+}
+
+func TestSpreadsheetXLSX(t *testing.T) {
+	extractor := spreadsheetExtractor{}
+	content := makeZipDocument(t, map[string]string{
+		"xl/workbook.xml":      `<workbook><sheets><sheet name="Budget" sheetId="1"/></sheets></workbook>`,
+		"xl/sharedStrings.xml": `<sst><si><t>quarterly</t></si><si><r><t>rev</t></r><r><t>enue</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="A2"><v>42</v></c><c r="B2" t="inlineStr"><is><t>inline</t></is></c><c r="C2"><f>SUM(A2)</f><v>42</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	extractedText, err := extractor.Extract("budget.xlsx", bytes.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, "Budget\nquarterly\trevenue\n42\tinline\t42\n", extractedText)
+
+	t.Run("without worksheets", func(t *testing.T) {
+		content := makeZipDocument(t, map[string]string{"xl/workbook.xml": `<workbook/>`})
+		_, err := extractor.Extract("empty.xlsx", bytes.NewReader(content))
+		require.Error(t, err)
+	})
+
+	t.Run("from a reader without ReadAt", func(t *testing.T) {
+		extractedText, err := extractor.Extract("budget.xlsx", struct{ io.ReadSeeker }{bytes.NewReader(content)})
+		require.NoError(t, err)
+		require.Equal(t, "Budget\nquarterly\trevenue\n42\tinline\t42\n", extractedText)
+	})
+
+	t.Run("the uncompressed size of all the sheets is limited", func(t *testing.T) {
+		sheet := "<worksheet>" + strings.Repeat(" ", 1024*1024) + "</worksheet>"
+		files := map[string]string{}
+		for i := range maxUncompressedDocumentSize/len(sheet) + 1 {
+			files[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = sheet
+		}
+		content := makeZipDocument(t, files)
+		require.Less(t, len(content), maxUncompressedDocumentSize/100)
+
+		_, err := extractor.Extract("bomb.xlsx", bytes.NewReader(content))
+		require.ErrorIs(t, err, errUncompressedSizeExceeded)
+	})
+}
+
+func TestSpreadsheetODS(t *testing.T) {
+	extractor := spreadsheetExtractor{}
+	content := makeZipDocument(t, map[string]string{
+		"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+			<office:body><office:spreadsheet><table:table table:name="Inventory">
+				<table:table-row><table:table-cell><text:p>apples</text:p></table:table-cell><table:table-cell><text:p>12</text:p></table:table-cell></table:table-row>
+				<table:table-row><table:table-cell><text:p>pears</text:p><text:p>green</text:p></table:table-cell></table:table-row>
+			</table:table></office:spreadsheet></office:body></office:document-content>`,
+	})
+
+	extractedText, err := extractor.Extract("inventory.ods", bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, "Inventory\napples\t12\npears green\n", extractedText)
+}
@@ -26,15 +26,17 @@ func makeTestAtmosCamoProxy() *ImageProxy {
 				AllowedUntrustedInternalConnections: model.NewPointer("127.0.0.1"),
 			},
 			ImageProxySettings: model.ImageProxySettings{
-				Enable:                  model.NewPointer(true),
-				ImageProxyType:          model.NewPointer(model.ImageProxyTypeAtmosCamo),
-				RemoteImageProxyURL:     model.NewPointer("http://images.example.com"),
-				RemoteImageProxyOptions: model.NewPointer("7e5f3fab20b94782b43cdb022a66985ef28ba355df2c5d5da3c9a05e4b697bac"),
+				Enable:                            model.NewPointer(true),
+				ImageProxyType:                    model.NewPointer(model.ImageProxyTypeAtmosCamo),
+				RemoteImageProxyURL:               model.NewPointer("http://images.example.com"),
+				RemoteImageProxyOptions:           model.NewPointer("7e5f3fab20b94782b43cdb022a66985ef28ba355df2c5d5da3c9a05e4b697bac"),
+				LocalImageProxyCacheSizeMB:        model.NewPointer(model.ImageProxySettingsDefaultLocalCacheSizeMB),
+				LocalImageProxyMaxImageResolution: model.NewPointer(int64(7680 * 4320)),
 			},
 		},
 	}
 
-	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil)
+	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil, nil, nil)
 }
 
 func TestAtmosCamoBackend_GetImage(t *testing.T) {
@@ -9,19 +9,26 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
 	"github.com/mattermost/mattermost/server/public/shared/httpservice"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
 var ErrNotEnabled = Error{errors.New("imageproxy.ImageProxy: image proxy not enabled")}
 
 // An ImageProxy is the public interface for Mattermost's image proxy. An instance of ImageProxy should be created
-// using MakeImageProxy which requires a configService and an HTTPService provided by the server.
+// using MakeImageProxy which requires a configService and an HTTPService provided by the server. The local backend
+// caches images in the file backend when one is given, which must be local to the node.
 type ImageProxy struct {
 	ConfigService    configservice.ConfigService
 	configListenerID string
@@ -33,6 +40,13 @@ type ImageProxy struct {
 	siteURL *url.URL
 	lock    sync.RWMutex
 	backend ImageProxyBackend
+
+	cache         *localCache
+	loadCacheOnce sync.Once
+	fetches       singleflight.Group
+	fetchSlots    chan struct{}
+	decoder       *imaging.Decoder
+	encoder       *imaging.Encoder
 }
 
 // An ImageProxyBackend provides the functionality for different types of image proxies. An ImageProxy will construct
@@ -45,13 +59,22 @@ type ImageProxyBackend interface {
 	GetImageDirect(imageURL string) (io.ReadCloser, string, error)
 }
 
-func MakeImageProxy(configService configservice.ConfigService, httpService httpservice.HTTPService, logger *mlog.Logger) *ImageProxy {
+func MakeImageProxy(configService configservice.ConfigService, httpService httpservice.HTTPService, fileBackend filestore.FileBackend, metrics einterfaces.MetricsInterface, logger *mlog.Logger) *ImageProxy {
 	proxy := &ImageProxy{
 		ConfigService: configService,
 		HTTPService:   httpService,
 		Logger:        logger,
+		fetchSlots:    make(chan struct{}, localMaxConcurrentFetches),
 	}
 
+	if fileBackend != nil {
+		proxy.cache = newLocalCache(fileBackend, metrics, 0)
+	}
+
+	// The options are valid, so creating the decoder and encoder can't fail.
+	proxy.decoder, _ = imaging.NewDecoder(imaging.DecoderOptions{ConcurrencyLevel: runtime.NumCPU()})
+	proxy.encoder, _ = imaging.NewEncoder(imaging.EncoderOptions{ConcurrencyLevel: runtime.NumCPU()})
+
 	// We deliberately ignore the error because it's from config.json.
 	// The function returns a nil pointer in case of error, and we handle it when it's used.
 	siteURL, _ := url.Parse(*configService.Config().ServiceSettings.SiteURL)
@@ -72,7 +95,7 @@ func (proxy *ImageProxy) makeBackend(proxySettings model.ImageProxySettings) Ima
 
 	switch *proxySettings.ImageProxyType {
 	case model.ImageProxyTypeLocal:
-		return makeLocalBackend(proxy)
+		return makeLocalBackend(proxy, proxySettings)
 	case model.ImageProxyTypeAtmosCamo:
 		return makeAtmosCamoBackend(proxy, proxySettings)
 	default:
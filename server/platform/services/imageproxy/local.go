@@ -16,10 +16,16 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 )
 
 var imageContentTypes = []string{
@@ -39,9 +45,18 @@ var msgNotAllowed = "requested URL is not allowed"
 
 var ErrLocalRequestFailed = Error{errors.New("imageproxy.LocalBackend: failed to request proxied image")}
 
+// LocalBackend fetches the proxied images itself. Images are resized and re-encoded as requested
+// by the client, and cached in the file backend.
 type LocalBackend struct {
 	client  *http.Client
 	baseURL *url.URL
+
+	cache              *localCache
+	fetches            *singleflight.Group
+	fetchSlots         chan struct{}
+	decoder            *imaging.Decoder
+	encoder            *imaging.Encoder
+	maxImageResolution int64
 }
 
 // URLError reports a malformed URL error.
@@ -54,7 +69,7 @@ func (e URLError) Error() string {
 	return fmt.Sprintf("malformed URL %q: %s", e.URL, e.Message)
 }
 
-func makeLocalBackend(proxy *ImageProxy) *LocalBackend {
+func makeLocalBackend(proxy *ImageProxy, proxySettings model.ImageProxySettings) *LocalBackend {
 	baseURL := proxy.siteURL
 	if baseURL == nil {
 		mlog.Warn("Failed to set base URL for image proxy. Relative image links may not work.")
@@ -62,9 +77,22 @@ func makeLocalBackend(proxy *ImageProxy) *LocalBackend {
 
 	client := proxy.HTTPService.MakeClient(false)
 
+	if proxy.cache != nil {
+		proxy.cache.setMaxSize(int64(*proxySettings.LocalImageProxyCacheSizeMB) * 1024 * 1024)
+		proxy.loadCacheOnce.Do(func() {
+			go proxy.cache.load()
+		})
+	}
+
 	return &LocalBackend{
-		client:  client,
-		baseURL: baseURL,
+		client:             client,
+		baseURL:            baseURL,
+		cache:              proxy.cache,
+		fetches:            &proxy.fetches,
+		fetchSlots:         proxy.fetchSlots,
+		decoder:            proxy.decoder,
+		encoder:            proxy.encoder,
+		maxImageResolution: *proxySettings.LocalImageProxyMaxImageResolution,
 	}
 }
 
@@ -112,7 +140,7 @@ func (backend *LocalBackend) GetImage(w http.ResponseWriter, r *http.Request, im
 	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src data:; style-src 'unsafe-inline'")
 
 	rec := contentTypeRecorder{w, filepath.Base(u.Path)}
-	backend.ServeImage(&rec, req)
+	backend.serveImage(&rec, req, parseImageOptions(r))
 }
 
 func (backend *LocalBackend) GetImageDirect(imageURL string) (io.ReadCloser, string, error) {
@@ -124,7 +152,7 @@ func (backend *LocalBackend) GetImageDirect(imageURL string) (io.ReadCloser, str
 
 	recorder := httptest.NewRecorder()
 
-	backend.ServeImage(recorder, req)
+	backend.serveImage(recorder, req, imageOptions{})
 
 	if recorder.Code != http.StatusOK {
 		return nil, "", ErrLocalRequestFailed
@@ -134,16 +162,86 @@ func (backend *LocalBackend) GetImageDirect(imageURL string) (io.ReadCloser, str
 }
 
 func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request) {
+	backend.serveImage(w, req, imageOptions{})
+}
+
+func (backend *LocalBackend) serveImage(w http.ResponseWriter, req *http.Request, opts imageOptions) {
 	proxyReq, err := newProxyRequest(req, backend.baseURL)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("invalid request URL: %v", err), http.StatusBadRequest)
 		return
 	}
 
+	cacheKey := localCacheKey(proxyReq.String(), opts)
+	if cached, ok := backend.cache.get(cacheKey); ok {
+		writeImage(w, cached)
+		return
+	}
+
+	// The requests for an image that isn't cached yet wait for a single fetch of it.
+	result, _, _ := backend.fetches.Do(cacheKey, func() (any, error) {
+		return backend.fetchImage(proxyReq, opts, cacheKey), nil
+	})
+	fetched := result.(*fetchedImage)
+
+	if fetched.header == nil {
+		http.Error(w, fetched.errMessage, fetched.statusCode)
+		return
+	}
+
+	copyHeader(w.Header(), fetched.header, "Cache-Control", "Last-Modified", "Expires", "Etag", "Link")
+
+	if should304(req, fetched.header) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if fetched.errMessage != "" {
+		http.Error(w, fetched.errMessage, fetched.statusCode)
+		return
+	}
+
+	// Unsuccessful responses are passed through as they are.
+	if fetched.img == nil {
+		w.Header().Set("Content-Type", fetched.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(fetched.body)))
+		setImageHeaders(w.Header())
+
+		w.WriteHeader(fetched.statusCode)
+		if _, err := w.Write(fetched.body); err != nil {
+			mlog.Warn("error copying response", mlog.Err(err))
+		}
+		return
+	}
+
+	writeImage(w, fetched.img)
+}
+
+// fetchedImage is the outcome of fetching a remote image, shared by all the requests waiting for it.
+type fetchedImage struct {
+	// header holds the headers of the remote response, and is nil if the request failed.
+	header     http.Header
+	statusCode int
+	// errMessage is the error to respond with, if any.
+	errMessage string
+
+	// img is the image to serve when the remote response is successful.
+	img *cachedImage
+	// contentType and body are passed through when the remote response is unsuccessful.
+	contentType string
+	body        []byte
+}
+
+// fetchImage fetches and transforms a remote image, and caches it. At most
+// localMaxConcurrentFetches images are fetched at the same time, since each of them is held in
+// memory.
+func (backend *LocalBackend) fetchImage(proxyReq *proxyRequest, opts imageOptions, cacheKey string) *fetchedImage {
+	backend.fetchSlots <- struct{}{}
+	defer func() { <-backend.fetchSlots }()
+
 	actualReq, err := http.NewRequest("GET", proxyReq.String(), nil)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return &fetchedImage{statusCode: http.StatusInternalServerError, errMessage: err.Error()}
 	}
 	actualReq.Header.Set("Accept", strings.Join(imageContentTypes, ", "))
 
@@ -154,18 +252,12 @@ func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request
 		if e, ok := err.(net.Error); ok && e.Timeout() {
 			statusCode = http.StatusGatewayTimeout
 		}
-		http.Error(w, fmt.Sprintf("error fetching remote image: %v", err), statusCode)
-		return
+		return &fetchedImage{statusCode: statusCode, errMessage: fmt.Sprintf("error fetching remote image: %v", err)}
 	}
 	// close the original resp.Body, even if we wrap it in a NopCloser below
 	defer resp.Body.Close()
 
-	copyHeader(w.Header(), resp.Header, "Cache-Control", "Last-Modified", "Expires", "Etag", "Link")
-
-	if should304(req, resp) {
-		w.WriteHeader(http.StatusNotModified)
-		return
-	}
+	fetched := &fetchedImage{header: resp.Header, statusCode: resp.StatusCode}
 
 	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
 	if contentType == "" || contentType == "application/octet-stream" || contentType == "binary/octet-stream" {
@@ -175,29 +267,87 @@ func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request
 		contentType = peekContentType(b)
 	}
 	if resp.ContentLength != 0 && !contentTypeMatches(imageContentTypes, contentType) {
-		http.Error(w, msgNotAllowed, http.StatusForbidden)
-		return
+		fetched.statusCode = http.StatusForbidden
+		fetched.errMessage = msgNotAllowed
+		return fetched
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, localMaxImageSize+1))
+	if err != nil {
+		mlog.Warn("error reading remote image", mlog.Err(err))
+		fetched.statusCode = http.StatusBadGateway
+		fetched.errMessage = fmt.Sprintf("error reading remote image: %v", err)
+		return fetched
+	}
+	if len(data) > localMaxImageSize {
+		fetched.statusCode = http.StatusForbidden
+		fetched.errMessage = msgTooLarge
+		return fetched
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		fetched.contentType = contentType
+		fetched.body = data
+		return fetched
 	}
-	w.Header().Set("Content-Type", contentType)
 
-	copyHeader(w.Header(), resp.Header, "Content-Length")
+	header := http.Header{}
+	copyHeader(header, resp.Header, "Cache-Control", "Last-Modified", "Expires", "Etag", "Link")
+	header.Set("Content-Type", contentType)
+
+	img, err := backend.transformImage(&cachedImage{Header: header, data: data}, opts)
+	if errors.Is(err, errImageTooLarge) {
+		fetched.statusCode = http.StatusForbidden
+		fetched.errMessage = msgTooLarge
+		return fetched
+	} else if err != nil {
+		mlog.Debug("Failed to transform proxied image, serving it as is", mlog.String("url", proxyReq.String()), mlog.Err(err))
+		img = &cachedImage{Header: header, data: data}
+	}
+
+	if expiresAt, ok := localCacheExpiry(resp.Header, time.Now()); ok {
+		backend.cache.put(cacheKey, &cachedImage{Header: img.Header, ExpiresAt: expiresAt, data: img.data})
+	}
 
+	fetched.img = img
+	return fetched
+}
+
+// writeImage writes a successful response serving the image.
+func writeImage(w http.ResponseWriter, img *cachedImage) {
+	// The headers copied from the remote response are replaced by the ones of the image.
+	for _, key := range []string{"Cache-Control", "Last-Modified", "Expires", "Etag", "Link"} {
+		w.Header().Del(key)
+	}
+	// The image may be served to several requests at the same time.
+	for key, values := range img.Header {
+		w.Header()[key] = slices.Clone(values)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(img.data)))
+
+	// The image depends on the formats accepted by the client.
+	w.Header().Set("Vary", "Accept")
+
+	setImageHeaders(w.Header())
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(img.data); err != nil {
+		mlog.Warn("error copying response", mlog.Err(err))
+	}
+}
+
+func setImageHeaders(header http.Header) {
 	// Enable CORS for 3rd party applications
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	header.Set("Access-Control-Allow-Origin", "*")
 
 	// Add a Content-Security-Policy to prevent stored-XSS attacks via SVG files
-	w.Header().Set("Content-Security-Policy", "script-src 'none'")
+	header.Set("Content-Security-Policy", "script-src 'none'")
 
 	// Disable Content-Type sniffing
-	w.Header().Set("X-Content-Type-Options", "nosniff")
+	header.Set("X-Content-Type-Options", "nosniff")
 
 	// Block potential XSS attacks especially in legacy browsers which do not support CSP
-	w.Header().Set("X-XSS-Protection", "1; mode=block")
-
-	w.WriteHeader(resp.StatusCode)
-	if _, err := io.Copy(w, resp.Body); err != nil {
-		mlog.Warn("error copying response", mlog.Err(err))
-	}
+	header.Set("X-XSS-Protection", "1; mode=block")
 }
 
 // copyHeader copies header values from src to dst, adding to any existing
@@ -217,13 +367,13 @@ func copyHeader(dst, src http.Header, keys ...string) {
 	}
 }
 
-func should304(req *http.Request, resp *http.Response) bool {
-	etag := resp.Header.Get("Etag")
+func should304(req *http.Request, header http.Header) bool {
+	etag := header.Get("Etag")
 	if etag != "" && etag == req.Header.Get("If-None-Match") {
 		return true
 	}
 
-	lastModified, err := time.Parse(time.RFC1123, resp.Header.Get("Last-Modified"))
+	lastModified, err := time.Parse(time.RFC1123, header.Get("Last-Modified"))
 	if err != nil {
 		return false
 	}
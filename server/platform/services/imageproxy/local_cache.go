@@ -0,0 +1,358 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imageproxy
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const localCacheDirectory = "image_proxy_cache"
+
+// localCacheDefaultMaxAge is how long images whose response doesn't say how long they're fresh for
+// are cached.
+const localCacheDefaultMaxAge = 24 * time.Hour
+
+// cachedImage is an image served by the local backend, along with the response headers it's served with.
+type cachedImage struct {
+	Header http.Header `json:"header"`
+	// ExpiresAt is when the image stops being fresh, after which it's fetched again.
+	ExpiresAt time.Time `json:"expires_at"`
+	data      []byte
+}
+
+// localCacheEntry is an image stored in the local cache.
+type localCacheEntry struct {
+	key  string
+	size int64
+}
+
+// localCache stores the images served by the local backend in the file backend, and removes the least
+// recently used images once they take more space than the maximum size of the cache. The index of the
+// cache is only kept in memory, so the file backend must be storage local to the node, such as the one
+// returned by NewLocalCacheFileBackend, and the maximum size of the cache applies to every node.
+type localCache struct {
+	fileBackend filestore.FileBackend
+	metrics     einterfaces.MetricsInterface
+
+	mut     sync.Mutex
+	maxSize int64
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// NewLocalCacheFileBackend returns a file backend storing the images cached by the local backend on the
+// local disk of the node.
+func NewLocalCacheFileBackend() (filestore.FileBackend, error) {
+	return filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  filepath.Join(os.TempDir(), "mattermost-image-proxy"),
+	})
+}
+
+func newLocalCache(fileBackend filestore.FileBackend, metrics einterfaces.MetricsInterface, maxSize int64) *localCache {
+	return &localCache{
+		fileBackend: fileBackend,
+		metrics:     metrics,
+		maxSize:     maxSize,
+		lru:         list.New(),
+		entries:     map[string]*list.Element{},
+	}
+}
+
+// localCacheKey returns the key of the image fetched from imageURL and transformed with the given options.
+func localCacheKey(imageURL string, opts imageOptions) string {
+	hash := sha256.Sum256([]byte(imageURL + "\n" + opts.cacheKey()))
+	return hex.EncodeToString(hash[:])
+}
+
+// localCacheExpiry returns when an image fetched at the given time with the given response headers
+// stops being fresh, and false if the image must not be stored by a shared cache such as this one.
+func localCacheExpiry(header http.Header, now time.Time) (time.Time, bool) {
+	maxAge, sharedMaxAge := -1, -1
+	for _, directive := range strings.Split(strings.Join(header.Values("Cache-Control"), ","), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache", "private":
+			return time.Time{}, false
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				maxAge = seconds
+			}
+		case "s-maxage":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				sharedMaxAge = seconds
+			}
+		}
+	}
+
+	var lifetime time.Duration
+	switch {
+	case sharedMaxAge >= 0:
+		lifetime = time.Duration(sharedMaxAge) * time.Second
+	case maxAge >= 0:
+		lifetime = time.Duration(maxAge) * time.Second
+	case header.Get("Expires") != "":
+		// An invalid date, such as 0, means the response has already expired.
+		expires, err := http.ParseTime(header.Get("Expires"))
+		if err != nil {
+			return time.Time{}, false
+		}
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		lifetime = expires.Sub(date)
+	default:
+		lifetime = localCacheDefaultMaxAge
+	}
+
+	// The response may have been served by another cache already.
+	if age, err := strconv.Atoi(header.Get("Age")); err == nil && age > 0 {
+		lifetime -= time.Duration(age) * time.Second
+	}
+
+	if lifetime <= 0 {
+		return time.Time{}, false
+	}
+	return now.Add(lifetime), true
+}
+
+func localCachePath(key string) string {
+	return path.Join(localCacheDirectory, key[:2], key)
+}
+
+// load indexes the images stored by a previous run of the server, oldest first, so that they
+// count towards the size of the cache and are removed first.
+func (c *localCache) load() {
+	paths, err := c.fileBackend.ListDirectoryRecursively(localCacheDirectory)
+	if err != nil {
+		mlog.Debug("Failed to list the images of the image proxy cache", mlog.Err(err))
+		return
+	}
+
+	type storedImage struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+
+	images := make([]storedImage, 0, len(paths))
+	for _, p := range paths {
+		size, err := c.fileBackend.FileSize(p)
+		if err != nil {
+			continue
+		}
+		modTime, err := c.fileBackend.FileModTime(p)
+		if err != nil {
+			continue
+		}
+		images = append(images, storedImage{key: path.Base(p), size: size, modTime: modTime})
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].modTime.After(images[j].modTime)
+	})
+
+	c.mut.Lock()
+	for _, image := range images {
+		if _, ok := c.entries[image.key]; ok {
+			continue
+		}
+		c.entries[image.key] = c.lru.PushBack(&localCacheEntry{key: image.key, size: image.size})
+		c.size += image.size
+	}
+	evicted := c.evictLocked()
+	c.mut.Unlock()
+
+	c.removeFiles(evicted)
+}
+
+// get returns the cached image with the given key. Images that aren't fresh anymore are removed.
+func (c *localCache) get(key string) (*cachedImage, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mut.Lock()
+	element, ok := c.entries[key]
+	if ok {
+		c.lru.MoveToFront(element)
+	}
+	c.mut.Unlock()
+
+	if !ok {
+		c.incrementMissCounter()
+		return nil, false
+	}
+
+	image, err := c.read(key)
+	if err != nil {
+		mlog.Debug("Failed to read image from the image proxy cache", mlog.String("key", key), mlog.Err(err))
+
+		// The file may have been removed by another node sharing the file backend.
+		c.mut.Lock()
+		if element, ok := c.entries[key]; ok {
+			c.removeLocked(element)
+		}
+		c.mut.Unlock()
+
+		c.incrementMissCounter()
+		return nil, false
+	}
+
+	// Images stored before their expiry was recorded have a zero expiry, so they're fetched again.
+	if !time.Now().Before(image.ExpiresAt) {
+		c.mut.Lock()
+		if element, ok := c.entries[key]; ok {
+			c.removeLocked(element)
+		}
+		c.mut.Unlock()
+		c.removeFiles([]string{key})
+
+		c.incrementMissCounter()
+		return nil, false
+	}
+
+	if c.metrics != nil {
+		c.metrics.IncrementImageProxyCacheHitCounter()
+	}
+	return image, true
+}
+
+// put stores the image with the given key, evicting the least recently used images if needed.
+func (c *localCache) put(key string, image *cachedImage) {
+	if c == nil {
+		return
+	}
+
+	header, err := json.Marshal(image)
+	if err != nil {
+		mlog.Warn("Failed to encode the headers of an image for the image proxy cache", mlog.Err(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(header) + 1 + len(image.data))
+	buf.Write(header)
+	buf.WriteByte('\n')
+	buf.Write(image.data)
+	size := int64(buf.Len())
+
+	c.mut.Lock()
+	maxSize := c.maxSize
+	c.mut.Unlock()
+	if size > maxSize {
+		return
+	}
+
+	if _, err := c.fileBackend.WriteFile(&buf, localCachePath(key)); err != nil {
+		mlog.Warn("Failed to write image to the image proxy cache", mlog.Err(err))
+		return
+	}
+
+	c.mut.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.removeLocked(element)
+	}
+	c.entries[key] = c.lru.PushFront(&localCacheEntry{key: key, size: size})
+	c.size += size
+	evicted := c.evictLocked()
+	c.mut.Unlock()
+
+	c.removeFiles(evicted)
+}
+
+// setMaxSize changes the maximum size of the cache, evicting the least recently used images if needed.
+func (c *localCache) setMaxSize(maxSize int64) {
+	c.mut.Lock()
+	c.maxSize = maxSize
+	evicted := c.evictLocked()
+	c.mut.Unlock()
+
+	c.removeFiles(evicted)
+}
+
+func (c *localCache) read(key string) (*cachedImage, error) {
+	data, err := c.fileBackend.ReadFile(localCachePath(key))
+	if err != nil {
+		return nil, err
+	}
+
+	header, body, ok := bytes.Cut(data, []byte{'\n'})
+	if !ok {
+		return nil, fmt.Errorf("missing headers in cached image %s", key)
+	}
+
+	var image cachedImage
+	if err := json.Unmarshal(header, &image); err != nil {
+		return nil, fmt.Errorf("invalid headers in cached image %s: %w", key, err)
+	}
+	image.data = body
+
+	return &image, nil
+}
+
+// evictLocked removes the least recently used images from the index until the cache fits in its
+// maximum size, and returns the keys of the removed images. c.mut must be held.
+func (c *localCache) evictLocked() []string {
+	var evicted []string
+	for c.size > c.maxSize {
+		element := c.lru.Back()
+		if element == nil {
+			break
+		}
+		evicted = append(evicted, element.Value.(*localCacheEntry).key)
+		c.removeLocked(element)
+	}
+
+	if c.metrics != nil {
+		c.metrics.SetImageProxyCacheSize(float64(c.size))
+		for range evicted {
+			c.metrics.IncrementImageProxyCacheEvictionCounter()
+		}
+	}
+
+	return evicted
+}
+
+// removeLocked removes the element from the index. c.mut must be held.
+func (c *localCache) removeLocked(element *list.Element) {
+	entry := c.lru.Remove(element).(*localCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+func (c *localCache) removeFiles(keys []string) {
+	for _, key := range keys {
+		if err := c.fileBackend.RemoveFile(localCachePath(key)); err != nil {
+			mlog.Debug("Failed to remove image from the image proxy cache", mlog.String("key", key), mlog.Err(err))
+		}
+	}
+}
+
+func (c *localCache) incrementMissCounter() {
+	if c.metrics != nil {
+		c.metrics.IncrementImageProxyCacheMissCounter()
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imageproxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func makeTestFileBackend(t *testing.T) filestore.FileBackend {
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: "local",
+		Directory:  t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	return fileBackend
+}
+
+func makeTestCachedImage(data string) *cachedImage {
+	return &cachedImage{
+		Header: http.Header{"Content-Type": []string{"image/png"}},
+		// The expiry is rounded so that every image takes as much space.
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+		data:      []byte(data),
+	}
+}
+
+func TestLocalCache(t *testing.T) {
+	// Every stored image takes the size of its headers on top of its data.
+	entrySize := func(image *cachedImage) int64 {
+		fileBackend := makeTestFileBackend(t)
+		cache := newLocalCache(fileBackend, nil, 1024)
+		cache.put("aaaa", image)
+		return cache.size
+	}
+
+	t.Run("get a stored image", func(t *testing.T) {
+		cache := newLocalCache(makeTestFileBackend(t), nil, 1024)
+
+		image, ok := cache.get("aaaa")
+		assert.False(t, ok)
+		assert.Nil(t, image)
+
+		cache.put("aaaa", makeTestCachedImage("1111111111"))
+
+		image, ok = cache.get("aaaa")
+		require.True(t, ok)
+		assert.Equal(t, "image/png", image.Header.Get("Content-Type"))
+		assert.Equal(t, []byte("1111111111"), image.data)
+	})
+
+	t.Run("evict the least recently used images", func(t *testing.T) {
+		size := entrySize(makeTestCachedImage("1111111111"))
+		fileBackend := makeTestFileBackend(t)
+		cache := newLocalCache(fileBackend, nil, 2*size)
+
+		cache.put("aaaa", makeTestCachedImage("1111111111"))
+		cache.put("bbbb", makeTestCachedImage("2222222222"))
+
+		_, ok := cache.get("aaaa")
+		require.True(t, ok)
+
+		cache.put("cccc", makeTestCachedImage("3333333333"))
+
+		assert.Equal(t, 2*size, cache.size)
+
+		_, ok = cache.get("bbbb")
+		assert.False(t, ok)
+		exists, err := fileBackend.FileExists(localCachePath("bbbb"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, ok = cache.get("aaaa")
+		assert.True(t, ok)
+		_, ok = cache.get("cccc")
+		assert.True(t, ok)
+	})
+
+	t.Run("don't store images larger than the cache", func(t *testing.T) {
+		fileBackend := makeTestFileBackend(t)
+		cache := newLocalCache(fileBackend, nil, 10)
+
+		cache.put("aaaa", makeTestCachedImage("1111111111"))
+
+		_, ok := cache.get("aaaa")
+		assert.False(t, ok)
+		exists, err := fileBackend.FileExists(localCachePath("aaaa"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("shrink the cache", func(t *testing.T) {
+		size := entrySize(makeTestCachedImage("1111111111"))
+		cache := newLocalCache(makeTestFileBackend(t), nil, 2*size)
+
+		cache.put("aaaa", makeTestCachedImage("1111111111"))
+		cache.put("bbbb", makeTestCachedImage("2222222222"))
+
+		cache.setMaxSize(size)
+
+		_, ok := cache.get("aaaa")
+		assert.False(t, ok)
+		_, ok = cache.get("bbbb")
+		assert.True(t, ok)
+	})
+
+	t.Run("treat removed files as misses", func(t *testing.T) {
+		fileBackend := makeTestFileBackend(t)
+		cache := newLocalCache(fileBackend, nil, 1024)
+
+		cache.put("aaaa", makeTestCachedImage("1111111111"))
+		require.NoError(t, fileBackend.RemoveFile(localCachePath("aaaa")))
+
+		_, ok := cache.get("aaaa")
+		assert.False(t, ok)
+		assert.Zero(t, cache.size)
+	})
+
+	t.Run("treat expired images as misses", func(t *testing.T) {
+		fileBackend := makeTestFileBackend(t)
+		cache := newLocalCache(fileBackend, nil, 1024)
+
+		image := makeTestCachedImage("1111111111")
+		image.ExpiresAt = time.Now().Add(-time.Second)
+		cache.put("aaaa", image)
+
+		_, ok := cache.get("aaaa")
+		assert.False(t, ok)
+		assert.Zero(t, cache.size)
+		exists, err := fileBackend.FileExists(localCachePath("aaaa"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("load the images stored previously", func(t *testing.T) {
+		size := entrySize(makeTestCachedImage("1111111111"))
+		fileBackend := makeTestFileBackend(t)
+
+		previous := newLocalCache(fileBackend, nil, 1024)
+		previous.put("aaaa", makeTestCachedImage("1111111111"))
+		// Make sure the images have different modification times.
+		time.Sleep(10 * time.Millisecond)
+		previous.put("bbbb", makeTestCachedImage("2222222222"))
+
+		cache := newLocalCache(fileBackend, nil, size)
+		cache.load()
+
+		assert.Equal(t, size, cache.size)
+
+		_, ok := cache.get("aaaa")
+		assert.False(t, ok)
+		image, ok := cache.get("bbbb")
+		require.True(t, ok)
+		assert.Equal(t, []byte("2222222222"), image.data)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		size := entrySize(makeTestCachedImage("1111111111"))
+
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("IncrementImageProxyCacheMissCounter").Once()
+		metrics.On("IncrementImageProxyCacheHitCounter").Once()
+		metrics.On("IncrementImageProxyCacheEvictionCounter").Once()
+		metrics.On("SetImageProxyCacheSize", float64(size)).Times(3)
+
+		cache := newLocalCache(makeTestFileBackend(t), metrics, size)
+
+		cache.get("aaaa")
+		cache.put("aaaa", makeTestCachedImage("1111111111"))
+		cache.get("aaaa")
+		cache.put("bbbb", makeTestCachedImage("2222222222"))
+		cache.setMaxSize(size)
+
+		metrics.AssertExpectations(t)
+	})
+}
+
+func TestLocalCacheExpiry(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		header    http.Header
+		expiresAt time.Time
+		store     bool
+	}{
+		"no headers": {
+			header:    http.Header{},
+			expiresAt: now.Add(localCacheDefaultMaxAge),
+			store:     true,
+		},
+		"max-age": {
+			header:    http.Header{"Cache-Control": {"public, max-age=60"}},
+			expiresAt: now.Add(time.Minute),
+			store:     true,
+		},
+		"s-maxage takes precedence": {
+			header:    http.Header{"Cache-Control": {"max-age=60, s-maxage=120"}},
+			expiresAt: now.Add(2 * time.Minute),
+			store:     true,
+		},
+		"max-age takes precedence over expires": {
+			header:    http.Header{"Cache-Control": {"max-age=60"}, "Expires": {"Mon, 01 Jan 2024 14:00:00 GMT"}},
+			expiresAt: now.Add(time.Minute),
+			store:     true,
+		},
+		"age": {
+			header:    http.Header{"Cache-Control": {"max-age=60"}, "Age": {"20"}},
+			expiresAt: now.Add(40 * time.Second),
+			store:     true,
+		},
+		"expires": {
+			header:    http.Header{"Expires": {"Mon, 01 Jan 2024 14:00:00 GMT"}},
+			expiresAt: now.Add(2 * time.Hour),
+			store:     true,
+		},
+		"expires relative to date": {
+			header:    http.Header{"Expires": {"Mon, 01 Jan 2024 14:00:00 GMT"}, "Date": {"Mon, 01 Jan 2024 13:00:00 GMT"}},
+			expiresAt: now.Add(time.Hour),
+			store:     true,
+		},
+		"expired": {
+			header: http.Header{"Expires": {"Mon, 01 Jan 2024 11:00:00 GMT"}},
+		},
+		"invalid expires": {
+			header: http.Header{"Expires": {"0"}},
+		},
+		"max-age=0": {
+			header: http.Header{"Cache-Control": {"max-age=0"}},
+		},
+		"no-store": {
+			header: http.Header{"Cache-Control": {"no-store"}},
+		},
+		"no-cache": {
+			header: http.Header{"Cache-Control": {"max-age=60, no-cache"}},
+		},
+		"private": {
+			header: http.Header{"Cache-Control": {"max-age=60", "Private"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expiresAt, ok := localCacheExpiry(tc.header, now)
+			require.Equal(t, tc.store, ok)
+			if tc.store {
+				assert.Equal(t, tc.expiresAt, expiresAt)
+			}
+		})
+	}
+}
@@ -4,9 +4,15 @@
 package imageproxy
 
 import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,13 +32,15 @@ func makeTestLocalProxy() *ImageProxy {
 				AllowedUntrustedInternalConnections: model.NewPointer("127.0.0.1"),
 			},
 			ImageProxySettings: model.ImageProxySettings{
-				Enable:         model.NewPointer(true),
-				ImageProxyType: model.NewPointer(model.ImageProxyTypeLocal),
+				Enable:                            model.NewPointer(true),
+				ImageProxyType:                    model.NewPointer(model.ImageProxyTypeLocal),
+				LocalImageProxyCacheSizeMB:        model.NewPointer(model.ImageProxySettingsDefaultLocalCacheSizeMB),
+				LocalImageProxyMaxImageResolution: model.NewPointer(int64(7680 * 4320)),
 			},
 		},
 	}
 
-	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil)
+	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil, nil, nil)
 }
 
 func TestLocalBackend_GetImage(t *testing.T) {
@@ -353,3 +361,258 @@ func TestLocalBackend_GetImageDirect(t *testing.T) {
 		wait <- true
 	})
 }
+
+func makeTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func makeTestPNGServer(t *testing.T, data []byte) (*httptest.Server, *int) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=2592000, public")
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Etag", `"abc"`)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})
+
+	mock := httptest.NewServer(handler)
+	t.Cleanup(mock.Close)
+
+	return mock, &requests
+}
+
+func TestLocalBackend_TransformImage(t *testing.T) {
+	getImage := func(t *testing.T, proxy *ImageProxy, imageURL, query, accept string) *http.Response {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodGet, "/api/v4/image"+query, nil)
+		if accept != "" {
+			request.Header.Set("Accept", accept)
+		}
+		proxy.GetImage(recorder, request, imageURL)
+		return recorder.Result()
+	}
+
+	decodeConfig := func(t *testing.T, resp *http.Response) (image.Config, string) {
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		config, format, err := image.DecodeConfig(bytes.NewReader(body))
+		require.NoError(t, err)
+		return config, format
+	}
+
+	t.Run("resize", func(t *testing.T) {
+		mock, _ := makeTestPNGServer(t, makeTestPNG(t, 200, 100))
+		proxy := makeTestLocalProxy()
+
+		resp := getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/png")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "max-age=2592000, public", resp.Header.Get("Cache-Control"))
+		assert.Empty(t, resp.Header.Get("Etag"))
+		assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+
+		config, format := decodeConfig(t, resp)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, 50, config.Width)
+		assert.Equal(t, 25, config.Height)
+	})
+
+	t.Run("don't enlarge images", func(t *testing.T) {
+		data := makeTestPNG(t, 20, 10)
+		mock, _ := makeTestPNGServer(t, data)
+		proxy := makeTestLocalProxy()
+
+		resp := getImage(t, proxy, mock.URL+"/image.png", "?width=50", "")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `"abc"`, resp.Header.Get("Etag"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, data, body)
+	})
+
+	t.Run("encode as webp when accepted", func(t *testing.T) {
+		mock, _ := makeTestPNGServer(t, makeTestPNG(t, 200, 100))
+		proxy := makeTestLocalProxy()
+
+		resp := getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/avif,image/webp,*/*;q=0.8")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/webp", resp.Header.Get("Content-Type"))
+
+		config, format := decodeConfig(t, resp)
+		assert.Equal(t, "webp", format)
+		assert.Equal(t, 50, config.Width)
+	})
+
+	t.Run("encode as jpeg when the format isn't accepted", func(t *testing.T) {
+		mock, _ := makeTestPNGServer(t, makeTestPNG(t, 20, 10))
+		proxy := makeTestLocalProxy()
+
+		resp := getImage(t, proxy, mock.URL+"/image.png", "", "image/jpeg")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"))
+
+		_, format := decodeConfig(t, resp)
+		assert.Equal(t, "jpeg", format)
+	})
+
+	t.Run("reject images with too many pixels", func(t *testing.T) {
+		mock, _ := makeTestPNGServer(t, makeTestPNG(t, 200, 100))
+		proxy := makeTestLocalProxy()
+		proxy.backend.(*LocalBackend).maxImageResolution = 200*100 - 1
+
+		resp := getImage(t, proxy, mock.URL+"/image.png", "", "")
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("serve cached images", func(t *testing.T) {
+		mock, requests := makeTestPNGServer(t, makeTestPNG(t, 200, 100))
+		proxy := makeTestLocalProxy()
+		backend := proxy.backend.(*LocalBackend)
+		backend.cache = newLocalCache(makeTestFileBackend(t), nil, 1024*1024)
+
+		resp := getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/webp")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, *requests)
+
+		resp = getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/webp")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, *requests)
+		assert.Equal(t, "image/webp", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "max-age=2592000, public", resp.Header.Get("Cache-Control"))
+		config, _ := decodeConfig(t, resp)
+		assert.Equal(t, 50, config.Width)
+
+		// Clients negotiating the same format share the cached images.
+		resp = getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/avif, image/webp;q=0.9")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, *requests)
+		assert.Equal(t, "image/webp", resp.Header.Get("Content-Type"))
+
+		// Images served with other options are cached separately.
+		resp = getImage(t, proxy, mock.URL+"/image.png", "?width=100", "image/webp")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, *requests)
+
+		resp = getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/png")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, *requests)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("don't cache private images", func(t *testing.T) {
+		var requests int
+		data := makeTestPNG(t, 20, 10)
+		mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("Cache-Control", "max-age=2592000, private")
+			w.Header().Set("Content-Type", "image/png")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+		}))
+		t.Cleanup(mock.Close)
+		proxy := makeTestLocalProxy()
+		backend := proxy.backend.(*LocalBackend)
+		backend.cache = newLocalCache(makeTestFileBackend(t), nil, 1024*1024)
+
+		for range 2 {
+			resp := getImage(t, proxy, mock.URL+"/image.png", "", "")
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("fetch an image once for concurrent requests", func(t *testing.T) {
+		var requests atomic.Int32
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		data := makeTestPNG(t, 200, 100)
+		mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-release
+			w.Header().Set("Content-Type", "image/png")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+		}))
+		t.Cleanup(mock.Close)
+		proxy := makeTestLocalProxy()
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp := getImage(t, proxy, mock.URL+"/image.png", "?width=50", "image/png")
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+			}()
+		}
+
+		<-started
+		// Give the other requests time to wait for the fetch.
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func TestParseImageOptions(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "/api/v4/image?width=100", nil)
+	request.Header.Set("Accept", "image/webp, image/png;q=0.9, image/gif;q=0, image/webp")
+
+	opts := parseImageOptions(request)
+	assert.Equal(t, 100, opts.width)
+	assert.Equal(t, []string{"image/png", "image/webp"}, opts.accept)
+	assert.Equal(t, "webp", opts.outputFormat("gif", false))
+	assert.Equal(t, "png", opts.outputFormat("png", false))
+	assert.Equal(t, "webp", opts.outputFormat("png", true))
+	assert.Equal(t, "jpeg", opts.outputFormat("jpeg", true))
+	assert.Equal(t, "jpeg", opts.outputFormat("webp", true))
+
+	request, _ = http.NewRequest(http.MethodGet, "/api/v4/image?width=100000", nil)
+
+	opts = parseImageOptions(request)
+	assert.Zero(t, opts.width)
+	assert.True(t, opts.accepts("image/gif"))
+	assert.Equal(t, "png", opts.outputFormat("png", true))
+	assert.Equal(t, "jpeg", opts.outputFormat("bmp", true))
+}
+
+func TestImageOptionsCacheKey(t *testing.T) {
+	parse := func(query, accept string) imageOptions {
+		request, _ := http.NewRequest(http.MethodGet, "/api/v4/image"+query, nil)
+		request.Header.Set("Accept", accept)
+		return parseImageOptions(request)
+	}
+
+	firefox := parse("?width=100", "image/avif,image/webp,*/*")
+	chrome := parse("?width=100", "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8")
+	assert.Equal(t, firefox.cacheKey(), chrome.cacheKey())
+
+	assert.Equal(t, parse("", "").cacheKey(), parse("", "*/*").cacheKey())
+	assert.NotEqual(t, chrome.cacheKey(), parse("?width=50", "image/avif,image/webp,*/*").cacheKey())
+	assert.NotEqual(t, chrome.cacheKey(), parse("?width=100", "image/png").cacheKey())
+	assert.NotEqual(t, parse("", "image/png").cacheKey(), parse("", "image/jpeg").cacheKey())
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imageproxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
+)
+
+const (
+	// localMaxImageSize is the size of the largest image the local backend serves.
+	localMaxImageSize = 50 * 1024 * 1024
+
+	// localMaxImageWidth is the largest width images can be resized to.
+	localMaxImageWidth = 4096
+
+	localJPEGQuality = 90
+
+	// localMaxConcurrentFetches is the number of remote images the local backend fetches at the
+	// same time.
+	localMaxConcurrentFetches = 8
+)
+
+var msgTooLarge = "requested image is too large"
+
+var errImageTooLarge = errors.New("image exceeds the maximum resolution")
+
+// localTransformedFormats are the formats of the images that can be transformed. Animated GIFs and
+// images that can't be decoded are always served as they are.
+var localTransformedFormats = []string{"bmp", "jpeg", "png", "psd", "tiff", "webp"}
+
+// imageOptions holds how the client wants the image served by the local backend.
+type imageOptions struct {
+	// width is the width to resize the image to, keeping its aspect ratio. Images are never enlarged.
+	width int
+	// accept holds the media types the client accepts, as sent in its Accept header.
+	accept []string
+}
+
+// parseImageOptions returns the image options of a request to the image proxy. The width to
+// resize the image to is taken from the width query parameter.
+func parseImageOptions(r *http.Request) imageOptions {
+	var opts imageOptions
+
+	if width, err := strconv.Atoi(r.URL.Query().Get("width")); err == nil && width > 0 && width <= localMaxImageWidth {
+		opts.width = width
+	}
+
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		opts.accept = append(opts.accept, mediaType)
+	}
+	slices.Sort(opts.accept)
+	opts.accept = slices.Compact(opts.accept)
+
+	return opts
+}
+
+// cacheKey returns what an image served with these options depends on: the width to resize it to,
+// and the format images of every format that can be transformed are served in. Clients sending
+// different Accept headers that negotiate the same formats share their cached images.
+func (o imageOptions) cacheKey() string {
+	formats := make([]string, 0, len(localTransformedFormats))
+	for _, format := range localTransformedFormats {
+		formats = append(formats, format+"="+o.outputFormat(format, false)+"/"+o.outputFormat(format, true))
+	}
+	return fmt.Sprintf("width=%d;formats=%s", o.width, strings.Join(formats, ","))
+}
+
+// accepts returns whether the client accepts images of the given media type. Clients that don't
+// send an Accept header accept any image.
+func (o imageOptions) accepts(mediaType string) bool {
+	if len(o.accept) == 0 {
+		return true
+	}
+	return slices.Contains(o.accept, mediaType) || slices.Contains(o.accept, "image/*") || slices.Contains(o.accept, "*/*")
+}
+
+// outputFormat returns the format to serve an image of the given format in. Images are only
+// re-encoded when they're resized or when the client doesn't accept their format, in which case
+// WebP is preferred when the client explicitly accepts it, and JPEG is used otherwise.
+//
+// WebP images are encoded losslessly, so photos, which are usually JPEG or lossy WebP images, are
+// never re-encoded in WebP: they would come out much larger than as JPEG images.
+func (o imageOptions) outputFormat(format string, resize bool) string {
+	if !resize && o.accepts("image/"+format) {
+		return format
+	}
+
+	if format != "jpeg" && format != "webp" && slices.Contains(o.accept, "image/webp") {
+		return "webp"
+	}
+
+	if (format == "jpeg" || format == "png") && o.accepts("image/"+format) {
+		return format
+	}
+
+	return "jpeg"
+}
+
+// transformImage resizes the image to the requested width and re-encodes it in a format accepted by
+// the client. Images that don't need to change, and images that can't be decoded such as SVGs, are
+// returned as they are.
+func (backend *LocalBackend) transformImage(img *cachedImage, opts imageOptions) (*cachedImage, error) {
+	config, format, err := backend.decoder.DecodeConfig(bytes.NewReader(img.data))
+	if err != nil {
+		return img, nil
+	}
+
+	// A small file can decode to a huge image, so the resolution is checked before decoding anything.
+	if int64(config.Width)*int64(config.Height) > backend.maxImageResolution {
+		return nil, errImageTooLarge
+	}
+
+	// Only the first frame of animated GIFs would be kept.
+	if format == "gif" {
+		return img, nil
+	}
+
+	resize := opts.width > 0 && opts.width < config.Width
+	outputFormat := opts.outputFormat(format, resize)
+	if !resize && outputFormat == format {
+		return img, nil
+	}
+
+	decoded, _, release, err := backend.decoder.DecodeMemBounded(bytes.NewReader(img.data))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if format == "jpeg" {
+		if orientation, err := imaging.GetImageOrientation(bytes.NewReader(img.data)); err == nil {
+			decoded = imaging.MakeImageUpright(decoded, orientation)
+		}
+	}
+
+	if resize {
+		decoded = imaging.GeneratePreview(decoded, opts.width)
+	}
+
+	var buf bytes.Buffer
+	switch outputFormat {
+	case "webp":
+		err = backend.encoder.EncodeWebP(&buf, decoded)
+	case "png":
+		err = backend.encoder.EncodePNG(&buf, decoded)
+	default:
+		imaging.FillImageTransparency(decoded, color.White)
+		err = backend.encoder.EncodeJPEG(&buf, decoded, localJPEGQuality)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	header := img.Header.Clone()
+	header.Set("Content-Type", "image/"+outputFormat)
+	// The validator of the remote image doesn't apply to the transformed one.
+	header.Del("Etag")
+
+	return &cachedImage{Header: header, data: buf.Bytes()}, nil
+}
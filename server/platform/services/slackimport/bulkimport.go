@@ -0,0 +1,1006 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slackimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const (
+	slackImportJSONLName   = "import.jsonl"
+	slackImportBotUsername = "slackimportbot"
+)
+
+var linkWithTextRegex = regexp.MustCompile(`<([^<\|]+)\|([^>]+)>`)
+
+// slackConvertedPost is a Slack message converted to the fields shared by posts, direct posts
+// and replies of the bulk import format.
+type slackConvertedPost struct {
+	user        string
+	postType    *string
+	message     string
+	props       *model.StringInterface
+	createAt    int64
+	editAt      *int64
+	flaggedBy   *[]string
+	reactions   *[]imports.ReactionImportData
+	attachments *[]imports.AttachmentImportData
+	isPinned    *bool
+	replies     []*slackConvertedPost
+}
+
+// slackConverter converts a parsed Slack export into bulk import lines.
+type slackConverter struct {
+	rctx        request.CTX
+	si          *SlackImporter
+	export      *slackExport
+	team        *model.Team
+	log         *bytes.Buffer
+	maxPostSize int
+
+	// manageSystem is whether the import can write into other teams than the one it was started
+	// from, and update existing custom groups.
+	manageSystem bool
+
+	// teams holds the teams by name, with a nil value for the teams created by the import.
+	teams     map[string]*model.Team
+	teamNames map[string]string
+
+	// users maps the Slack user ids to usernames.
+	users        map[string]string
+	userData     map[string]*imports.UserImportData
+	userOrder    []string
+	userChannels map[string]map[string][]string
+	botUsername  string
+
+	// attachments maps the attachment paths to the files of the Slack export.
+	attachments map[string]*zip.File
+}
+
+func newSlackConverter(rctx request.CTX, si *SlackImporter, export *slackExport, team *model.Team, log *bytes.Buffer) *slackConverter {
+	return &slackConverter{
+		rctx:         rctx,
+		si:           si,
+		export:       export,
+		team:         team,
+		log:          log,
+		maxPostSize:  si.actions.MaxPostSize(),
+		manageSystem: si.actions.CanManageSystem != nil && si.actions.CanManageSystem(),
+		teams:        map[string]*model.Team{team.Name: team},
+		teamNames:    map[string]string{"": team.Name},
+		users:        make(map[string]string),
+		userData:     make(map[string]*imports.UserImportData),
+		userChannels: make(map[string]map[string][]string),
+		attachments:  make(map[string]*zip.File),
+	}
+}
+
+// convert returns the import lines in the order the bulk import processes them. Channels with
+// bookmarks are listed again after the users, since bookmarks are owned by users.
+func (c *slackConverter) convert() []imports.LineImportData {
+	lines := []imports.LineImportData{{Type: "version", Version: model.NewPointer(1)}}
+
+	teamLines := c.convertTeams()
+	c.convertUsers()
+	channelLines, bookmarkLines, postLines := c.convertChannels()
+	directChannelLines, directPostLines := c.convertDirectChannels()
+	groupLines := c.convertGroups()
+
+	lines = append(lines, teamLines...)
+	lines = append(lines, channelLines...)
+	lines = append(lines, c.userLines()...)
+	lines = append(lines, bookmarkLines...)
+	lines = append(lines, groupLines...)
+	lines = append(lines, postLines...)
+	lines = append(lines, directChannelLines...)
+	lines = append(lines, directPostLines...)
+
+	return lines
+}
+
+// convertTeams maps each workspace of an Enterprise Grid export to a team, creating the teams
+// that don't exist yet. Imports that can't manage the system only write into the team they were
+// started from, so every workspace is mapped to it.
+func (c *slackConverter) convertTeams() []imports.LineImportData {
+	keys := c.export.sortedWorkspaceKeys()
+	if len(keys) == 1 {
+		return nil
+	}
+
+	if !c.manageSystem {
+		for _, key := range keys[1:] {
+			c.teamNames[key] = c.team.Name
+		}
+		c.log.WriteString(i18n.T("api.slackimport.slack_add_teams.target_team", map[string]any{"DisplayName": c.team.DisplayName}))
+		return nil
+	}
+
+	c.log.WriteString(i18n.T("api.slackimport.slack_add_teams.added"))
+	c.log.WriteString("===============\r\n\r\n")
+
+	var lines []imports.LineImportData
+	for _, key := range keys[1:] {
+		workspace := c.export.workspaces[key]
+		name := slackConvertTeamName(workspace.team.Domain, workspace.team.Id)
+		if _, ok := c.teams[name]; ok {
+			c.teamNames[key] = name
+			continue
+		}
+
+		if team, err := c.si.store.Team().GetByName(name); err == nil {
+			c.teams[name] = team
+			c.teamNames[key] = name
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_teams.merge", map[string]any{"DisplayName": team.DisplayName}))
+			continue
+		}
+
+		displayName := truncateRunes(workspace.team.Name, model.TeamDisplayNameMaxRunes)
+		if displayName == "" {
+			displayName = name
+		}
+		data := &imports.TeamImportData{
+			Name:        model.NewPointer(name),
+			DisplayName: model.NewPointer(displayName),
+			Type:        model.NewPointer(model.TeamInvite),
+		}
+		if err := imports.ValidateTeamImportData(data); err != nil {
+			c.rctx.Logger().Warn("Slack Import: Unable to import Slack workspace.", mlog.String("team_name", name), mlog.Err(err))
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_teams.import_failed", map[string]any{"DisplayName": displayName}))
+			continue
+		}
+
+		c.teams[name] = nil
+		c.teamNames[key] = name
+		lines = append(lines, imports.LineImportData{Type: "team", Team: data})
+		c.log.WriteString(displayName + "\r\n")
+	}
+
+	return lines
+}
+
+func (c *slackConverter) convertUsers() {
+	c.log.WriteString(i18n.T("api.slackimport.slack_add_users.created"))
+	c.log.WriteString("===============\r\n\r\n")
+
+	for _, sUser := range c.export.users {
+		username := model.NormalizeUsername(sUser.Username)
+		email := sUser.Profile.Email
+		if email == "" {
+			email = username + "@example.com"
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_users.missing_email_address", map[string]any{"Email": email, "Username": username}))
+			c.rctx.Logger().Warn("Slack Import: User does not have an email address in the Slack export. Used username as a placeholder. The user should update their email address once logged in to the system.", mlog.String("user_email", email), mlog.String("user_name", username))
+		}
+		email = model.NormalizeEmail(email)
+
+		// Check for email conflict and use existing user if found
+		if existingUser, err := c.si.store.User().GetByEmail(email); err == nil {
+			c.addUser(sUser.Id, &imports.UserImportData{
+				Username: model.NewPointer(existingUser.Username),
+				Email:    model.NewPointer(existingUser.Email),
+				Locale:   model.NewPointer(existingUser.Locale),
+			})
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_users.merge_existing", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username}))
+			continue
+		}
+
+		if _, ok := c.userData[username]; ok {
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_users.unable_import", map[string]any{"Username": username}))
+			continue
+		}
+		if _, err := c.si.store.User().GetByUsername(username); err == nil {
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_users.unable_import", map[string]any{"Username": username}))
+			continue
+		}
+
+		data := &imports.UserImportData{
+			Username:  model.NewPointer(username),
+			Email:     model.NewPointer(email),
+			FirstName: model.NewPointer(truncateRunes(sUser.Profile.FirstName, model.UserFirstNameMaxRunes)),
+			LastName:  model.NewPointer(truncateRunes(sUser.Profile.LastName, model.UserLastNameMaxRunes)),
+		}
+		if sUser.Profile.Title != "" {
+			data.Position = model.NewPointer(truncateRunes(sUser.Profile.Title, model.UserPositionMaxRunes))
+		}
+		if sUser.Deleted {
+			data.DeleteAt = model.NewPointer(model.GetMillis())
+		}
+
+		if err := imports.ValidateUserImportData(data); err != nil {
+			c.rctx.Logger().Warn("Slack Import: Unable to import Slack user.", mlog.String("user_name", username), mlog.Err(err))
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_users.unable_import", map[string]any{"Username": username}))
+			continue
+		}
+
+		c.addUser(sUser.Id, data)
+		c.log.WriteString(i18n.T("api.slackimport.slack_add_users.email", map[string]any{"Email": email, "Username": username}))
+	}
+}
+
+// addUser maps a Slack user to the given user. All users join the team the import was started from.
+func (c *slackConverter) addUser(slackID string, data *imports.UserImportData) {
+	username := *data.Username
+	if slackID != "" {
+		c.users[slackID] = username
+	}
+	if _, ok := c.userData[username]; ok {
+		return
+	}
+
+	c.userData[username] = data
+	c.userOrder = append(c.userOrder, username)
+	c.userChannels[username] = map[string][]string{c.team.Name: nil}
+}
+
+// botUser returns the username of the deactivated user that bot messages are imported as,
+// adding it to the import the first time.
+func (c *slackConverter) botUser() string {
+	if c.botUsername != "" {
+		return c.botUsername
+	}
+
+	username := slackImportBotUsername
+	email := username + "@localhost"
+	existingUser, err := c.si.store.User().GetByUsername(username)
+	_, taken := c.userData[username]
+	if taken || (err == nil && existingUser.Email != email) {
+		username = slackImportBotUsername + "-" + model.NewId()[:6]
+		email = username + "@localhost"
+	}
+
+	c.addUser("", &imports.UserImportData{
+		Username: model.NewPointer(username),
+		Email:    model.NewPointer(email),
+		DeleteAt: model.NewPointer(model.GetMillis()),
+	})
+	c.botUsername = username
+
+	return username
+}
+
+func (c *slackConverter) joinChannel(username, teamName, channelName string) {
+	teams := c.userChannels[username]
+	if !slices.Contains(teams[teamName], channelName) {
+		teams[teamName] = append(teams[teamName], channelName)
+	}
+}
+
+func (c *slackConverter) userLines() []imports.LineImportData {
+	lines := make([]imports.LineImportData, 0, len(c.userOrder))
+	for _, username := range c.userOrder {
+		teams := c.userChannels[username]
+		if username == c.botUsername {
+			for _, teamName := range c.teamNames {
+				if _, ok := teams[teamName]; !ok {
+					teams[teamName] = nil
+				}
+			}
+		}
+
+		teamNames := make([]string, 0, len(teams))
+		for teamName := range teams {
+			teamNames = append(teamNames, teamName)
+		}
+		sort.Slice(teamNames, func(i, j int) bool {
+			if teamNames[i] == c.team.Name || teamNames[j] == c.team.Name {
+				return teamNames[i] == c.team.Name
+			}
+			return teamNames[i] < teamNames[j]
+		})
+
+		userTeams := make([]imports.UserTeamImportData, 0, len(teamNames))
+		for _, teamName := range teamNames {
+			channels := make([]imports.UserChannelImportData, 0, len(teams[teamName]))
+			for _, channelName := range teams[teamName] {
+				channels = append(channels, imports.UserChannelImportData{Name: model.NewPointer(channelName)})
+			}
+			userTeams = append(userTeams, imports.UserTeamImportData{Name: model.NewPointer(teamName), Channels: &channels})
+		}
+
+		data := c.userData[username]
+		data.Teams = &userTeams
+		lines = append(lines, imports.LineImportData{Type: "user", User: data})
+	}
+
+	return lines
+}
+
+func (c *slackConverter) convertChannels() (channelLines, bookmarkLines, postLines []imports.LineImportData) {
+	// Write Header
+	c.log.WriteString(i18n.T("api.slackimport.slack_add_channels.added"))
+	c.log.WriteString("=================\r\n\r\n")
+
+	for _, key := range c.export.sortedWorkspaceKeys() {
+		teamName, ok := c.teamNames[key]
+		if !ok {
+			continue
+		}
+		workspace := c.export.workspaces[key]
+
+		for _, sChannel := range workspace.channels {
+			channel := slackSanitiseChannelProperties(c.rctx, model.Channel{
+				Type:        sChannel.Type,
+				DisplayName: sChannel.Name,
+				Name:        slackConvertChannelName(sChannel.Name, sChannel.Id),
+				Purpose:     sChannel.Purpose.Value,
+				Header:      sChannel.Topic.Value,
+			})
+
+			merged := false
+			if team := c.teams[teamName]; team != nil {
+				if existingChannel, err := c.si.store.Channel().GetByName(team.Id, channel.Name, true); err == nil {
+					// The channel already exists as an active channel. Merge with the existing one.
+					c.log.WriteString(i18n.T("api.slackimport.slack_add_channels.merge", map[string]any{"DisplayName": channel.DisplayName}))
+					channel.Type = existingChannel.Type
+					merged = true
+				} else if _, err := c.si.store.Channel().GetDeletedByName(team.Id, channel.Name); err == nil {
+					// The channel already exists but has been deleted. Generate a random string for the handle instead.
+					channel.Name = model.NewId()
+				}
+			}
+
+			data := &imports.ChannelImportData{
+				Team:        model.NewPointer(teamName),
+				Name:        model.NewPointer(channel.Name),
+				DisplayName: model.NewPointer(channel.DisplayName),
+				Type:        model.NewPointer(channel.Type),
+				Header:      model.NewPointer(channel.Header),
+				Purpose:     model.NewPointer(channel.Purpose),
+			}
+			if sChannel.IsArchived && !merged {
+				data.DeletedAt = model.NewPointer(model.GetMillis())
+			}
+			if err := imports.ValidateChannelImportData(data); err != nil {
+				c.rctx.Logger().Warn("Slack Import: Unable to import Slack channel.", mlog.String("channel_display_name", channel.DisplayName), mlog.Err(err))
+				c.log.WriteString(i18n.T("api.slackimport.slack_add_channels.import_failed", map[string]any{"DisplayName": channel.DisplayName}))
+				continue
+			}
+			channelLines = append(channelLines, imports.LineImportData{Type: "channel", Channel: data})
+			c.log.WriteString(channel.DisplayName + "\r\n")
+
+			for _, member := range sChannel.Members {
+				if username, ok := c.users[member]; ok {
+					c.joinChannel(username, teamName, channel.Name)
+				}
+			}
+
+			if bookmarks := c.convertBookmarks(sChannel); len(bookmarks) > 0 {
+				bookmarkData := *data
+				bookmarkData.Bookmarks = &bookmarks
+				bookmarkLines = append(bookmarkLines, imports.LineImportData{Type: "channel", Channel: &bookmarkData})
+			}
+
+			posts := c.convertPosts(workspace.posts[sChannel.Name], nil)
+			if canvas := c.convertCanvas(sChannel); canvas != nil {
+				posts = append([]*slackConvertedPost{canvas}, posts...)
+			}
+			for _, post := range posts {
+				message, replies := c.splitPost(post)
+				postData := &imports.PostImportData{
+					Team:        data.Team,
+					Channel:     data.Name,
+					User:        model.NewPointer(post.user),
+					Type:        post.postType,
+					Message:     model.NewPointer(message),
+					Props:       post.props,
+					CreateAt:    model.NewPointer(post.createAt),
+					EditAt:      post.editAt,
+					FlaggedBy:   post.flaggedBy,
+					Reactions:   post.reactions,
+					Replies:     replies,
+					Attachments: post.attachments,
+					IsPinned:    post.isPinned,
+				}
+				if err := imports.ValidatePostImportData(postData, c.maxPostSize); err != nil {
+					c.rctx.Logger().Warn("Slack Import: Unable to import the message.", mlog.String("channel_name", channel.Name), mlog.Int("create_at", post.createAt), mlog.Err(err))
+					continue
+				}
+				postLines = append(postLines, imports.LineImportData{Type: "post", Post: postData})
+			}
+		}
+	}
+
+	return channelLines, bookmarkLines, postLines
+}
+
+// convertBookmarks converts the link bookmarks of a channel. Bookmarks whose author is unknown
+// are owned by the creator of the channel.
+func (c *slackConverter) convertBookmarks(sChannel slackChannel) []imports.ChannelBookmarkImportData {
+	var bookmarks []imports.ChannelBookmarkImportData
+	for _, sBookmark := range sChannel.Bookmarks {
+		if (sBookmark.Type != "" && sBookmark.Type != "link") || !model.IsValidHTTPURL(sBookmark.Link) {
+			c.rctx.Logger().Debug("Slack Import: Unable to import the bookmark as it is not a link.", mlog.String("channel_name", sChannel.Name), mlog.String("title", sBookmark.Title))
+			continue
+		}
+		if len(bookmarks) == model.MaxBookmarksPerChannel {
+			c.rctx.Logger().Warn("Slack Import: The channel has too many bookmarks. The remaining bookmarks are not imported.", mlog.String("channel_name", sChannel.Name))
+			break
+		}
+
+		owner, ok := c.users[sBookmark.CreatedBy]
+		if !ok {
+			owner, ok = c.users[sChannel.Creator]
+		}
+		if !ok {
+			owner = c.botUser()
+		}
+
+		displayName := sBookmark.Title
+		if displayName == "" {
+			displayName = sBookmark.Link
+		}
+		bookmark := imports.ChannelBookmarkImportData{
+			User:        model.NewPointer(owner),
+			DisplayName: model.NewPointer(truncateRunes(displayName, model.DisplayNameMaxRunes)),
+			LinkURL:     model.NewPointer(sBookmark.Link),
+		}
+		if emojiName := slackConvertEmojiName(sBookmark.Emoji); emojiName != "" {
+			bookmark.Emoji = model.NewPointer(emojiName)
+		}
+		if sBookmark.DateCreated > 0 {
+			bookmark.CreateAt = model.NewPointer(sBookmark.DateCreated * 1000)
+		}
+		if err := imports.ValidateChannelBookmarkImportData(&bookmark); err != nil {
+			c.rctx.Logger().Warn("Slack Import: Unable to import the bookmark.", mlog.String("channel_name", sChannel.Name), mlog.Err(err))
+			continue
+		}
+
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	return bookmarks
+}
+
+// convertCanvas imports the canvas of a channel as a pinned post with the canvas attached.
+func (c *slackConverter) convertCanvas(sChannel slackChannel) *slackConvertedPost {
+	canvas := sChannel.Properties.Canvas
+	if canvas == nil || canvas.IsEmpty || canvas.FileId == "" {
+		return nil
+	}
+
+	attachment, ok := c.convertFile(&slackFile{Id: canvas.FileId})
+	if !ok {
+		return nil
+	}
+
+	author, ok := c.users[sChannel.Creator]
+	if !ok {
+		author = c.botUser()
+	}
+
+	created, _ := sChannel.Created.Int64()
+	createAt := created * 1000
+	if createAt <= 0 {
+		createAt = model.GetMillis()
+	}
+
+	return &slackConvertedPost{
+		user:        author,
+		createAt:    createAt,
+		attachments: &[]imports.AttachmentImportData{attachment},
+		isPinned:    model.NewPointer(true),
+	}
+}
+
+func (c *slackConverter) convertDirectChannels() (channelLines, postLines []imports.LineImportData) {
+	workspace := c.export.workspaces[""]
+
+	for _, sChannel := range c.export.directChannels {
+		var members []string
+		for _, member := range sChannel.Members {
+			if username, ok := c.users[member]; ok && !slices.Contains(members, username) {
+				members = append(members, username)
+			}
+		}
+
+		data := &imports.DirectChannelImportData{
+			Members: &members,
+			Header:  model.NewPointer(truncateRunes(sChannel.Topic.Value, model.ChannelHeaderMaxRunes)),
+		}
+		if err := imports.ValidateDirectChannelImportData(data); err != nil {
+			c.rctx.Logger().Warn("Slack Import: Unable to import the direct channel.", mlog.String("channel_id", sChannel.Id), mlog.Err(err))
+			continue
+		}
+		channelLines = append(channelLines, imports.LineImportData{Type: "direct_channel", DirectChannel: data})
+
+		// Direct message channels in Slack don't have a name, their messages are stored under their id.
+		postsKey := sChannel.Name
+		if sChannel.Type == model.ChannelTypeDirect || postsKey == "" {
+			postsKey = sChannel.Id
+		}
+
+		for _, post := range c.convertPosts(workspace.posts[postsKey], members) {
+			message, replies := c.splitPost(post)
+			postData := &imports.DirectPostImportData{
+				ChannelMembers: &members,
+				User:           model.NewPointer(post.user),
+				Type:           post.postType,
+				Message:        model.NewPointer(message),
+				Props:          post.props,
+				CreateAt:       model.NewPointer(post.createAt),
+				EditAt:         post.editAt,
+				FlaggedBy:      post.flaggedBy,
+				Reactions:      post.reactions,
+				Replies:        replies,
+				Attachments:    post.attachments,
+				IsPinned:       post.isPinned,
+			}
+			if err := imports.ValidateDirectPostImportData(postData, c.maxPostSize); err != nil {
+				c.rctx.Logger().Warn("Slack Import: Unable to import the direct message.", mlog.String("channel_id", sChannel.Id), mlog.Int("create_at", post.createAt), mlog.Err(err))
+				continue
+			}
+			postLines = append(postLines, imports.LineImportData{Type: "direct_post", DirectPost: postData})
+		}
+	}
+
+	return channelLines, postLines
+}
+
+// convertGroups converts the user groups to custom groups. The same group may be listed by
+// several workspaces of an Enterprise Grid export, in which case the members are merged.
+func (c *slackConverter) convertGroups() []imports.LineImportData {
+	groups := make(map[string]*imports.GroupImportData)
+	var names []string
+	for _, key := range c.export.sortedWorkspaceKeys() {
+		for _, sGroup := range c.export.workspaces[key].userGroups {
+			if sGroup.DateDelete != 0 {
+				continue
+			}
+
+			members := []string{}
+			for _, member := range sGroup.Users {
+				if username, ok := c.users[member]; ok && !slices.Contains(members, username) {
+					members = append(members, username)
+				}
+			}
+
+			name := slackConvertGroupName(sGroup.Handle, sGroup.Id)
+			if group, ok := groups[name]; ok {
+				for _, member := range members {
+					if !slices.Contains(*group.Members, member) {
+						*group.Members = append(*group.Members, member)
+					}
+				}
+				continue
+			}
+
+			displayName := sGroup.Name
+			if displayName == "" {
+				displayName = name
+			}
+			groups[name] = &imports.GroupImportData{
+				Name:        model.NewPointer(name),
+				DisplayName: model.NewPointer(truncateRunes(displayName, model.GroupDisplayNameMaxLength)),
+				Description: model.NewPointer(truncateRunes(sGroup.Description, model.GroupDescriptionMaxLength)),
+				Members:     &members,
+			}
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	c.log.WriteString(i18n.T("api.slackimport.slack_add_groups.added"))
+	c.log.WriteString("================\r\n\r\n")
+
+	var lines []imports.LineImportData
+	for _, name := range names {
+		data := groups[name]
+
+		// Group names share their namespace with usernames, and only custom groups can be imported.
+		_, taken := c.userData[name]
+		if _, err := c.si.store.User().GetByUsername(name); err == nil {
+			taken = true
+		}
+		group, err := c.si.store.Group().GetByName(name, model.GroupSearchOpts{})
+		if err == nil && group.Source != model.GroupSourceCustom {
+			taken = true
+		}
+		if taken {
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_groups.name_taken", map[string]any{"Name": name}))
+			continue
+		}
+		// Custom groups aren't scoped to a team, so only imports that can manage the system update them.
+		if err == nil && !c.manageSystem {
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_groups.exists", map[string]any{"Name": name}))
+			continue
+		}
+
+		if err := imports.ValidateGroupImportData(data); err != nil {
+			c.rctx.Logger().Warn("Slack Import: Unable to import Slack user group.", mlog.String("group_name", name), mlog.Err(err))
+			c.log.WriteString(i18n.T("api.slackimport.slack_add_groups.import_failed", map[string]any{"Name": name}))
+			continue
+		}
+
+		lines = append(lines, imports.LineImportData{Type: "group", Group: data})
+		c.log.WriteString(*data.DisplayName + "\r\n")
+	}
+
+	return lines
+}
+
+// convertPosts converts the messages of a channel, attaching replies to their thread. The
+// members are only given for direct channels, whose posts can only be flagged by members.
+func (c *slackConverter) convertPosts(posts []slackPost, members []string) []*slackConvertedPost {
+	sort.SliceStable(posts, func(i, j int) bool {
+		return slackConvertTimeStamp(posts[i].TimeStamp) < slackConvertTimeStamp(posts[j].TimeStamp)
+	})
+
+	var roots []*slackConvertedPost
+	threads := make(map[string]*slackConvertedPost)
+	for _, sPost := range posts {
+		post := c.convertPost(sPost, members)
+		if post == nil {
+			continue
+		}
+
+		// If post in thread
+		if sPost.ThreadTS != "" && sPost.ThreadTS != sPost.TimeStamp {
+			if root, ok := threads[sPost.ThreadTS]; ok {
+				root.replies = append(root.replies, post)
+				continue
+			}
+		}
+
+		roots = append(roots, post)
+		// If post is thread starter
+		if sPost.ThreadTS == sPost.TimeStamp {
+			threads[sPost.ThreadTS] = post
+		}
+	}
+
+	return roots
+}
+
+func (c *slackConverter) convertPost(sPost slackPost, members []string) *slackConvertedPost {
+	if sPost.Type != "message" {
+		c.rctx.Logger().Warn(
+			"Slack Import: Unable to import the message as its type is not supported",
+			mlog.String("post_type", sPost.Type),
+			mlog.String("post_subtype", sPost.SubType),
+		)
+		return nil
+	}
+
+	var post *slackConvertedPost
+	switch sPost.SubType {
+	case "", "file_share", "thread_broadcast":
+		username := c.postUser(sPost.User)
+		if username == "" {
+			return nil
+		}
+		post = &slackConvertedPost{user: username, message: sPost.Text}
+
+		files := sPost.Files
+		if sPost.File != nil {
+			files = append([]*slackFile{sPost.File}, files...)
+		}
+		var attachments []imports.AttachmentImportData
+		for _, file := range files {
+			if attachment, ok := c.convertFile(file); ok {
+				attachments = append(attachments, attachment)
+			}
+		}
+		if len(attachments) > 0 {
+			post.attachments = &attachments
+		}
+	case "file_comment":
+		if sPost.Comment == nil {
+			c.rctx.Logger().Debug("Slack Import: Unable to import the message as it has no comments.")
+			return nil
+		}
+		username := c.postUser(sPost.Comment.User)
+		if username == "" {
+			return nil
+		}
+		post = &slackConvertedPost{user: username, message: sPost.Comment.Comment}
+	case "bot_message":
+		if sPost.BotId == "" {
+			c.rctx.Logger().Warn("Slack Import: Unable to import bot message as the BotId field is missing.")
+			return nil
+		}
+		post = c.convertBotPost(sPost)
+	case "channel_join", "channel_leave":
+		username := c.postUser(sPost.User)
+		if username == "" {
+			return nil
+		}
+		postType := model.PostTypeJoinChannel
+		if sPost.SubType == "channel_leave" {
+			postType = model.PostTypeLeaveChannel
+		}
+		post = &slackConvertedPost{
+			user:     username,
+			postType: model.NewPointer(postType),
+			message:  sPost.Text,
+			props:    &model.StringInterface{"username": username},
+		}
+	case "me_message":
+		username := c.postUser(sPost.User)
+		if username == "" {
+			return nil
+		}
+		post = &slackConvertedPost{user: username, message: "*" + sPost.Text + "*"}
+	case "channel_topic", "channel_purpose", "channel_name":
+		username := c.postUser(sPost.User)
+		if username == "" {
+			return nil
+		}
+		postType := model.PostTypeHeaderChange
+		switch sPost.SubType {
+		case "channel_purpose":
+			postType = model.PostTypePurposeChange
+		case "channel_name":
+			postType = model.PostTypeDisplaynameChange
+		}
+		post = &slackConvertedPost{user: username, postType: model.NewPointer(postType), message: sPost.Text}
+	default:
+		c.rctx.Logger().Warn(
+			"Slack Import: Unable to import the message as its type is not supported",
+			mlog.String("post_type", sPost.Type),
+			mlog.String("post_subtype", sPost.SubType),
+		)
+		return nil
+	}
+
+	post.createAt = slackConvertTimeStamp(sPost.TimeStamp)
+	if sPost.Edited != nil {
+		if editAt := slackConvertTimeStamp(sPost.Edited.TimeStamp); editAt > post.createAt {
+			post.editAt = model.NewPointer(editAt)
+		}
+	}
+	c.convertReactions(post, sPost.Reactions)
+	c.convertPin(post, sPost, members)
+
+	return post
+}
+
+// postUser returns the username of the author of a message, or an empty string if the author
+// wasn't imported.
+func (c *slackConverter) postUser(slackID string) string {
+	if slackID == "" {
+		c.rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
+		return ""
+	}
+
+	username, ok := c.users[slackID]
+	if !ok {
+		c.rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", slackID))
+		return ""
+	}
+
+	return username
+}
+
+// convertBotPost imports a bot message as an incoming webhook post of the bot user.
+func (c *slackConverter) convertBotPost(sPost slackPost) *slackConvertedPost {
+	post := &model.Post{
+		Message: linkWithTextRegex.ReplaceAllString(sPost.Text, "[${2}](${1})"),
+		Type:    model.PostTypeSlackAttachment,
+	}
+	post.AddProp("from_webhook", "true")
+
+	overrideUsername := sPost.BotUsername
+	if overrideUsername == "" {
+		overrideUsername = model.DefaultWebhookUsername
+	}
+	post.AddProp("override_username", overrideUsername)
+
+	if len(sPost.Attachments) > 0 {
+		model.ParseSlackAttachment(post, sPost.Attachments)
+	}
+	props := post.GetProps()
+
+	return &slackConvertedPost{
+		user:     c.botUser(),
+		postType: model.NewPointer(post.Type),
+		message:  post.Message,
+		props:    &props,
+	}
+}
+
+// convertReactions adds a reaction for each user and emoji, dropping the skin tone of the emoji.
+func (c *slackConverter) convertReactions(post *slackConvertedPost, sReactions []slackReaction) {
+	var reactions []imports.ReactionImportData
+	for _, sReaction := range sReactions {
+		emojiName := slackConvertEmojiName(sReaction.Name)
+		if emojiName == "" {
+			c.rctx.Logger().Debug("Slack Import: Unable to import the reaction as the emoji name is invalid.", mlog.String("emoji_name", sReaction.Name))
+			continue
+		}
+
+		for _, slackID := range sReaction.Users {
+			username, ok := c.users[slackID]
+			if !ok || slices.ContainsFunc(reactions, func(reaction imports.ReactionImportData) bool {
+				return *reaction.User == username && *reaction.EmojiName == emojiName
+			}) {
+				continue
+			}
+			reactions = append(reactions, imports.ReactionImportData{
+				User:      model.NewPointer(username),
+				EmojiName: model.NewPointer(emojiName),
+				CreateAt:  model.NewPointer(post.createAt),
+			})
+		}
+	}
+
+	if len(reactions) > 0 {
+		post.reactions = &reactions
+	}
+}
+
+// convertPin pins a pinned message and flags it for the users who pinned it, or for its author
+// when that isn't known.
+func (c *slackConverter) convertPin(post *slackConvertedPost, sPost slackPost, members []string) {
+	if len(sPost.PinnedTo) == 0 {
+		return
+	}
+	post.isPinned = model.NewPointer(true)
+
+	var flaggedBy []string
+	for _, channelID := range sPost.PinnedTo {
+		if info, ok := sPost.PinnedInfo[channelID]; ok {
+			if username, ok := c.users[info.PinnedBy]; ok && !slices.Contains(flaggedBy, username) {
+				flaggedBy = append(flaggedBy, username)
+			}
+		}
+	}
+	if len(flaggedBy) == 0 {
+		flaggedBy = []string{post.user}
+	}
+	if members != nil {
+		flaggedBy = slices.DeleteFunc(flaggedBy, func(username string) bool {
+			return !slices.Contains(members, username)
+		})
+	}
+
+	if len(flaggedBy) > 0 {
+		post.flaggedBy = &flaggedBy
+	}
+}
+
+// convertFile adds a file of the export to the attachments of the import.
+func (c *slackConverter) convertFile(sFile *slackFile) (imports.AttachmentImportData, bool) {
+	if sFile == nil {
+		c.rctx.Logger().Warn("Slack Import: Unable to attach the file to the post as the latter has no file section present in Slack export.")
+		return imports.AttachmentImportData{}, false
+	}
+
+	file, ok := c.export.uploads[sFile.Id]
+	if !ok {
+		c.rctx.Logger().Warn("Slack Import: Unable to import file as the file is missing from the Slack export zip file.", mlog.String("file_id", sFile.Id))
+		return imports.AttachmentImportData{}, false
+	}
+
+	// since this is an attachment, we should treat it as a file and apply according limits
+	if file.UncompressedSize64 > uint64(*c.si.config.FileSettings.MaxFileSize) {
+		c.rctx.Logger().Warn("Slack Import: Unable to import file as it exceeds the maximum file size.", mlog.String("file_id", sFile.Id))
+		return imports.AttachmentImportData{}, false
+	}
+
+	attachmentPath := sFile.Id + "/" + path.Base(file.Name)
+	c.attachments[attachmentPath] = file
+
+	return imports.AttachmentImportData{Path: model.NewPointer(attachmentPath)}, true
+}
+
+// splitPost returns the message of a post cut to the maximum post size, along with its replies.
+// The rest of a message that is too long is imported as replies.
+func (c *slackConverter) splitPost(post *slackConvertedPost) (string, *[]imports.ReplyImportData) {
+	var replies []imports.ReplyImportData
+	addRemainder := func(p *slackConvertedPost, chunks []string) {
+		for i, chunk := range chunks {
+			replies = append(replies, imports.ReplyImportData{
+				User:     model.NewPointer(p.user),
+				Message:  model.NewPointer(chunk),
+				CreateAt: model.NewPointer(p.createAt + int64(i) + 1),
+			})
+		}
+	}
+
+	chunks := splitMessage(post.message, c.maxPostSize)
+	addRemainder(post, chunks[1:])
+
+	for _, reply := range post.replies {
+		replyChunks := splitMessage(reply.message, c.maxPostSize)
+		replies = append(replies, imports.ReplyImportData{
+			User:        model.NewPointer(reply.user),
+			Type:        reply.postType,
+			Message:     model.NewPointer(replyChunks[0]),
+			Props:       reply.props,
+			CreateAt:    model.NewPointer(reply.createAt),
+			EditAt:      reply.editAt,
+			FlaggedBy:   reply.flaggedBy,
+			Reactions:   reply.reactions,
+			Attachments: reply.attachments,
+			IsPinned:    reply.isPinned,
+		})
+		addRemainder(reply, replyChunks[1:])
+	}
+
+	if len(replies) == 0 {
+		return chunks[0], nil
+	}
+	return chunks[0], &replies
+}
+
+// splitMessage cuts a message into chunks of at most maxRunes runes.
+func splitMessage(message string, maxRunes int) []string {
+	runes := []rune(message)
+	if len(runes) <= maxRunes {
+		return []string{message}
+	}
+
+	var chunks []string
+	for len(runes) > maxRunes {
+		chunks = append(chunks, string(runes[:maxRunes]))
+		runes = runes[maxRunes:]
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+
+	return chunks
+}
+
+// slackWriteImportArchive writes the import lines and the attachments as an archive in the
+// layout expected by the import_process job.
+func slackWriteImportArchive(w io.Writer, lines []imports.LineImportData, attachments map[string]*zip.File) error {
+	zipWriter := zip.NewWriter(w)
+
+	jsonlWriter, err := zipWriter.Create(slackImportJSONLName)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(jsonlWriter)
+	for i := range lines {
+		if err := encoder.Encode(&lines[i]); err != nil {
+			return err
+		}
+	}
+
+	attachmentPaths := make([]string, 0, len(attachments))
+	for attachmentPath := range attachments {
+		attachmentPaths = append(attachmentPaths, attachmentPath)
+	}
+	sort.Strings(attachmentPaths)
+
+	for _, attachmentPath := range attachmentPaths {
+		if err := slackCopyAttachment(zipWriter, attachmentPath, attachments[attachmentPath]); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func slackCopyAttachment(zipWriter *zip.Writer, attachmentPath string, file *zip.File) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := zipWriter.Create(path.Join(model.ExportDataDir, attachmentPath))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, reader)
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
@@ -34,6 +35,33 @@ func slackConvertChannelName(channelName string, channelId string) string {
 	return strings.ToLower(channelId)
 }
 
+func slackConvertTeamName(domain string, teamId string) string {
+	newName := strings.ToLower(domain)
+	if model.IsValidTeamName(newName) && !model.IsReservedTeamName(newName) {
+		return newName
+	}
+	return "slack-" + strings.ToLower(teamId)
+}
+
+func slackConvertGroupName(handle string, groupId string) string {
+	newName := strings.ToLower(handle)
+	if len(newName) <= model.GroupNameMaxLength && isValidGroupNameCharacters(newName) &&
+		newName != model.UserNotifyAll && newName != model.ChannelMentionsNotifyProp && newName != model.UserNotifyHere {
+		return newName
+	}
+	return "slack-" + strings.ToLower(groupId)
+}
+
+// slackConvertEmojiName returns the name of an emoji without colons and skin tone, or an empty
+// string if the name isn't valid in Mattermost.
+func slackConvertEmojiName(emojiName string) string {
+	newName := strings.SplitN(strings.Trim(emojiName, ":"), "::", 2)[0]
+	if len(newName) > model.EmojiNameMaxLength || !isValidEmojiNameCharacters(newName) {
+		return ""
+	}
+	return newName
+}
+
 func slackConvertUserMentions(users []slackUser, posts map[string][]slackPost) map[string][]slackPost {
 	var regexes = make(map[string]*regexp.Regexp, len(users))
 	for _, user := range users {
@@ -48,3 +48,25 @@ func slackParsePosts(data io.Reader) ([]slackPost, error) {
 	}
 	return posts, nil
 }
+
+func slackParseUserGroups(data io.Reader) ([]slackUserGroup, error) {
+	decoder := json.NewDecoder(data)
+
+	var userGroups []slackUserGroup
+	if err := decoder.Decode(&userGroups); err != nil {
+		mlog.Warn("Slack Import: Error occurred when parsing some Slack user groups. Import may work anyway.", mlog.Err(err))
+		return userGroups, err
+	}
+	return userGroups, nil
+}
+
+func slackParseTeams(data io.Reader) ([]slackTeam, error) {
+	decoder := json.NewDecoder(data)
+
+	var teams []slackTeam
+	if err := decoder.Decode(&teams); err != nil {
+		mlog.Warn("Slack Import: Error occurred when parsing the Slack workspaces. Import may work anyway.", mlog.Err(err))
+		return teams, err
+	}
+	return teams, nil
+}
@@ -6,16 +6,16 @@ package slackimport
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/json"
 	"errors"
-	"image"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"path/filepath"
+	"os"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
-	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -27,53 +27,115 @@ import (
 )
 
 type slackChannel struct {
-	Id      string          `json:"id"`
-	Name    string          `json:"name"`
-	Creator string          `json:"creator"`
-	Members []string        `json:"members"`
-	Purpose slackChannelSub `json:"purpose"`
-	Topic   slackChannelSub `json:"topic"`
-	Type    model.ChannelType
+	Id         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Creator    string                 `json:"creator"`
+	Created    json.Number            `json:"created"`
+	IsArchived bool                   `json:"is_archived"`
+	Members    []string               `json:"members"`
+	Purpose    slackChannelSub        `json:"purpose"`
+	Topic      slackChannelSub        `json:"topic"`
+	Bookmarks  []slackBookmark        `json:"bookmarks"`
+	Properties slackChannelProperties `json:"properties"`
+	Type       model.ChannelType
 }
 
 type slackChannelSub struct {
 	Value string `json:"value"`
 }
 
+type slackChannelProperties struct {
+	Canvas *slackCanvas `json:"canvas"`
+}
+
+type slackCanvas struct {
+	FileId  string `json:"file_id"`
+	IsEmpty bool   `json:"is_empty"`
+}
+
+type slackBookmark struct {
+	Title       string `json:"title"`
+	Link        string `json:"link"`
+	Emoji       string `json:"emoji"`
+	Type        string `json:"type"`
+	DateCreated int64  `json:"date_created"`
+	CreatedBy   string `json:"created_by"`
+}
+
 type slackProfile struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
+	Title     string `json:"title"`
 }
 
 type slackUser struct {
 	Id       string       `json:"id"`
 	Username string       `json:"name"`
+	Deleted  bool         `json:"deleted"`
 	Profile  slackProfile `json:"profile"`
 }
 
+type slackUserGroup struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Handle      string   `json:"handle"`
+	Description string   `json:"description"`
+	Users       []string `json:"users"`
+	DateDelete  int64    `json:"date_delete"`
+}
+
+// slackTeam is a workspace of an Enterprise Grid export, as listed in teams.json.
+type slackTeam struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+}
+
 type slackFile struct {
 	Id    string `json:"id"`
 	Title string `json:"title"`
 }
 
+type slackReaction struct {
+	Name  string   `json:"name"`
+	Users []string `json:"users"`
+}
+
+type slackPinnedInfo struct {
+	PinnedBy string `json:"pinned_by"`
+}
+
+type slackEdited struct {
+	User      string `json:"user"`
+	TimeStamp string `json:"ts"`
+}
+
 type slackPost struct {
-	User        string                   `json:"user"`
-	BotId       string                   `json:"bot_id"`
-	BotUsername string                   `json:"username"`
-	Text        string                   `json:"text"`
-	TimeStamp   string                   `json:"ts"`
-	ThreadTS    string                   `json:"thread_ts"`
-	Type        string                   `json:"type"`
-	SubType     string                   `json:"subtype"`
-	Comment     *slackComment            `json:"comment"`
-	Upload      bool                     `json:"upload"`
-	File        *slackFile               `json:"file"`
-	Files       []*slackFile             `json:"files"`
-	Attachments []*model.SlackAttachment `json:"attachments"`
+	User        string                     `json:"user"`
+	BotId       string                     `json:"bot_id"`
+	BotUsername string                     `json:"username"`
+	Text        string                     `json:"text"`
+	TimeStamp   string                     `json:"ts"`
+	ThreadTS    string                     `json:"thread_ts"`
+	Type        string                     `json:"type"`
+	SubType     string                     `json:"subtype"`
+	Comment     *slackComment              `json:"comment"`
+	Upload      bool                       `json:"upload"`
+	File        *slackFile                 `json:"file"`
+	Files       []*slackFile               `json:"files"`
+	Attachments []*model.SlackAttachment   `json:"attachments"`
+	Reactions   []slackReaction            `json:"reactions"`
+	PinnedTo    []string                   `json:"pinned_to"`
+	PinnedInfo  map[string]slackPinnedInfo `json:"pinned_info"`
+	Edited      *slackEdited               `json:"edited"`
 }
 
-var isValidChannelNameCharacters = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`).MatchString
+var (
+	isValidChannelNameCharacters = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`).MatchString
+	isValidGroupNameCharacters   = regexp.MustCompile(`^[a-z0-9\.\-_]+$`).MatchString
+	isValidEmojiNameCharacters   = regexp.MustCompile(`^[a-zA-Z0-9\-\+_]+$`).MatchString
+)
 
 const slackImportMaxFileSize = 1024 * 1024 * 70
 
@@ -82,20 +144,32 @@ type slackComment struct {
 	Comment string `json:"comment"`
 }
 
+// slackWorkspace holds the channels, messages and user groups of a workspace. Exports of a
+// single workspace have everything at the root of the archive, while Enterprise Grid exports
+// have a directory per workspace under teams/.
+type slackWorkspace struct {
+	team       slackTeam
+	channels   []slackChannel
+	posts      map[string][]slackPost
+	userGroups []slackUserGroup
+}
+
+// slackExport is the parsed content of a Slack export archive.
+type slackExport struct {
+	users          []slackUser
+	teams          []slackTeam
+	workspaces     map[string]*slackWorkspace
+	directChannels []slackChannel
+	uploads        map[string]*zip.File
+}
+
 // Actions provides the actions that needs to be used for import slack data
 type Actions struct {
-	UpdateActive           func(*model.User, bool) (*model.User, *model.AppError)
-	AddUserToChannel       func(request.CTX, *model.User, *model.Channel, bool) (*model.ChannelMember, *model.AppError)
-	JoinUserToTeam         func(*model.Team, *model.User, string) (*model.TeamMember, *model.AppError)
-	CreateDirectChannel    func(request.CTX, string, string, ...model.ChannelOption) (*model.Channel, *model.AppError)
-	CreateGroupChannel     func(request.CTX, []string) (*model.Channel, *model.AppError)
-	CreateChannel          func(*model.Channel, bool) (*model.Channel, *model.AppError)
-	DoUploadFile           func(time.Time, string, string, string, string, []byte) (*model.FileInfo, *model.AppError)
-	GenerateThumbnailImage func(request.CTX, image.Image, string, string)
-	GeneratePreviewImage   func(request.CTX, image.Image, string, string)
-	InvalidateAllCaches    func()
-	MaxPostSize            func() int
-	PrepareImage           func(fileData []byte) (image.Image, string, func(), error)
+	BulkImport  func(io.Reader, *zip.Reader) (*model.AppError, int)
+	MaxPostSize func() int
+	// CanManageSystem returns whether the import can write into other teams than the one it
+	// was started from, and update existing custom groups.
+	CanManageSystem func() bool
 }
 
 // SlackImporter is a service that allows to import slack dumps into mattermost
@@ -115,6 +189,9 @@ func New(store store.Store, actions Actions, config *model.Config) *SlackImporte
 	}
 }
 
+// SlackImport converts a Slack export into the bulk import format and imports it. Single workspace
+// exports are imported into the given team. Each workspace of an Enterprise Grid export is imported
+// into its own team when the import can manage the system, and into the given team otherwise.
 func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer) {
 	// Create log file
 	log := bytes.NewBufferString(i18n.T("api.slackimport.slack_import.log"))
@@ -125,92 +202,64 @@ func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File,
 		return model.NewAppError("SlackImport", "api.slackimport.slack_import.zip.app_error", nil, "", http.StatusBadRequest).Wrap(err), log
 	}
 
-	var channels []slackChannel
-	var publicChannels []slackChannel
-	var privateChannels []slackChannel
-	var groupChannels []slackChannel
-	var directChannels []slackChannel
+	team, err := si.store.Team().Get(teamID)
+	if err != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.team_fail"))
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.team_fail", nil, "", http.StatusBadRequest).Wrap(err), log
+	}
 
-	var users []slackUser
-	posts := make(map[string][]slackPost)
-	uploads := make(map[string]*zip.File)
-	for _, file := range zipreader.File {
-		fileReader, err := file.Open()
-		if err != nil {
-			log.WriteString(i18n.T("api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}))
-			return model.NewAppError("SlackImport", "api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}, "", http.StatusInternalServerError).Wrap(err), log
-		}
-		defer fileReader.Close()
-
-		reader := utils.NewLimitedReaderWithError(fileReader, slackImportMaxFileSize)
-		if file.Name == "channels.json" {
-			publicChannels, err = slackParseChannels(reader, model.ChannelTypeOpen)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, publicChannels...)
-		} else if file.Name == "dms.json" {
-			directChannels, err = slackParseChannels(reader, model.ChannelTypeDirect)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, directChannels...)
-		} else if file.Name == "groups.json" {
-			privateChannels, err = slackParseChannels(reader, model.ChannelTypePrivate)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, privateChannels...)
-		} else if file.Name == "mpims.json" {
-			groupChannels, err = slackParseChannels(reader, model.ChannelTypeGroup)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, groupChannels...)
-		} else if file.Name == "users.json" {
-			users, err = slackParseUsers(reader)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-		} else {
-			spl := strings.Split(file.Name, "/")
-			if len(spl) == 2 && strings.HasSuffix(spl[1], ".json") {
-				newposts, err := slackParsePosts(reader)
-				if errors.Is(err, utils.ErrSizeLimitExceeded) {
-					log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-					continue
-				}
-				channel := spl[0]
-				if _, ok := posts[channel]; !ok {
-					posts[channel] = newposts
-				} else {
-					posts[channel] = append(posts[channel], newposts...)
-				}
-			} else if len(spl) == 3 && spl[0] == "__uploads" {
-				uploads[spl[1]] = file
-			}
-		}
+	export, appErr := slackReadExport(zipreader, log)
+	if appErr != nil {
+		return appErr, log
 	}
 
-	posts = slackConvertUserMentions(users, posts)
-	posts = slackConvertChannelMentions(channels, posts)
-	posts = slackConvertPostsMarkup(posts)
+	for _, workspace := range export.workspaces {
+		channels := slices.Concat(workspace.channels, export.directChannels)
+		workspace.posts = slackConvertUserMentions(export.users, workspace.posts)
+		workspace.posts = slackConvertChannelMentions(channels, workspace.posts)
+		workspace.posts = slackConvertPostsMarkup(workspace.posts)
+	}
 
-	addedUsers := si.slackAddUsers(rctx, teamID, users, log)
-	botUser := si.slackAddBotUser(rctx, teamID, log)
+	converter := newSlackConverter(rctx, si, export, team, log)
+	lines := converter.convert()
 
-	si.slackAddChannels(rctx, teamID, channels, posts, addedUsers, uploads, botUser, log)
+	archive, err := os.CreateTemp("", "slack_import_*.zip")
+	if err != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.write.app_error"))
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err), log
+	}
+	defer func() {
+		archive.Close()
+		if err := os.Remove(archive.Name()); err != nil {
+			rctx.Logger().Warn("Slack Import: Unable to remove the temporary import file.", mlog.String("filename", archive.Name()), mlog.Err(err))
+		}
+	}()
 
-	if botUser != nil {
-		si.deactivateSlackBotUser(rctx, botUser)
+	if err := slackWriteImportArchive(archive, lines, converter.attachments); err != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.write.app_error"))
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err), log
+	}
+	info, err := archive.Stat()
+	if err != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.write.app_error"))
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err), log
+	}
+	archiveReader, err := zip.NewReader(archive, info.Size())
+	if err != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.write.app_error"))
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err), log
+	}
+	jsonlFile, err := archiveReader.Open(slackImportJSONLName)
+	if err != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.write.app_error"))
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err), log
 	}
+	defer jsonlFile.Close()
 
-	si.actions.InvalidateAllCaches()
+	if appErr, lineNumber := si.actions.BulkImport(jsonlFile, archiveReader); appErr != nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.import.app_error", map[string]any{"Line": lineNumber}))
+		return appErr, log
+	}
 
 	log.WriteString(i18n.T("api.slackimport.slack_import.notes"))
 	log.WriteString("=======\r\n\r\n")
@@ -222,349 +271,153 @@ func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File,
 	return nil, log
 }
 
-func truncateRunes(s string, i int) string {
-	runes := []rune(s)
-	if len(runes) > i {
-		return string(runes[:i])
-	}
-	return s
-}
-
-func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackusers []slackUser, importerLog *bytes.Buffer) map[string]*model.User {
-	// Log header
-	importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.created"))
-	importerLog.WriteString("===============\r\n\r\n")
-
-	addedUsers := make(map[string]*model.User)
-
-	// Need the team
-	team, err := si.store.Team().Get(teamId)
-	if err != nil {
-		importerLog.WriteString(i18n.T("api.slackimport.slack_import.team_fail"))
-		return addedUsers
+// slackReadExport parses the files of a Slack export archive.
+func slackReadExport(zipreader *zip.Reader, log *bytes.Buffer) (*slackExport, *model.AppError) {
+	export := &slackExport{
+		workspaces: map[string]*slackWorkspace{
+			"": {posts: make(map[string][]slackPost)},
+		},
+		uploads: make(map[string]*zip.File),
 	}
 
-	for _, sUser := range slackusers {
-		firstName := sUser.Profile.FirstName
-		lastName := sUser.Profile.LastName
-		email := sUser.Profile.Email
-		if email == "" {
-			email = sUser.Username + "@example.com"
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.missing_email_address", map[string]any{"Email": email, "Username": sUser.Username}))
-			rctx.Logger().Warn("Slack Import: User does not have an email address in the Slack export. Used username as a placeholder. The user should update their email address once logged in to the system.", mlog.String("user_email", email), mlog.String("user_name", sUser.Username))
-		}
-
-		password := model.NewId()
-
-		// Check for email conflict and use existing user if found
-		if existingUser, err := si.store.User().GetByEmail(email); err == nil {
-			addedUsers[sUser.Id] = existingUser
-			if _, err := si.actions.JoinUserToTeam(team, addedUsers[sUser.Id], ""); err != nil {
-				importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.merge_existing_failed", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username}))
-			} else {
-				importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.merge_existing", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username}))
+	for _, file := range zipreader.File {
+		spl := strings.Split(file.Name, "/")
+
+		workspace := export.workspaces[""]
+		if len(spl) > 2 && spl[0] == "teams" {
+			key := spl[1]
+			if _, ok := export.workspaces[key]; !ok {
+				export.workspaces[key] = &slackWorkspace{
+					team:  slackTeam{Id: key, Domain: key, Name: key},
+					posts: make(map[string][]slackPost),
+				}
 			}
-			continue
+			workspace = export.workspaces[key]
+			spl = spl[2:]
 		}
 
-		email = strings.ToLower(email)
-		newUser := model.User{
-			Username:  sUser.Username,
-			FirstName: firstName,
-			LastName:  lastName,
-			Email:     email,
-			Password:  password,
+		if len(spl) == 3 && spl[0] == "__uploads" {
+			export.uploads[spl[1]] = file
+			continue
 		}
-
-		mUser := si.oldImportUser(rctx, team, &newUser)
-		if mUser == nil {
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.unable_import", map[string]any{"Username": sUser.Username}))
+		if !strings.HasSuffix(file.Name, ".json") || len(spl) > 2 {
 			continue
 		}
-		addedUsers[sUser.Id] = mUser
-		importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.email_pwd", map[string]any{"Email": newUser.Email, "Password": password}))
-	}
 
-	return addedUsers
-}
+		fileReader, err := file.Open()
+		if err != nil {
+			log.WriteString(i18n.T("api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}))
+			return nil, model.NewAppError("SlackImport", "api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}, "", http.StatusInternalServerError).Wrap(err)
+		}
 
-func (si *SlackImporter) slackAddBotUser(rctx request.CTX, teamId string, log *bytes.Buffer) *model.User {
-	team, err := si.store.Team().Get(teamId)
-	if err != nil {
-		log.WriteString(i18n.T("api.slackimport.slack_import.team_fail"))
-		return nil
+		err = slackReadExportFile(export, workspace, spl, utils.NewLimitedReaderWithError(fileReader, slackImportMaxFileSize))
+		fileReader.Close()
+		if errors.Is(err, utils.ErrSizeLimitExceeded) {
+			log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
+		}
 	}
 
-	password := model.NewId()
-	username := "slackimportuser_" + model.NewId()
-	email := username + "@localhost"
-
-	botUser := model.User{
-		Username:  username,
-		FirstName: "",
-		LastName:  "",
-		Email:     email,
-		Password:  password,
+	// Match the workspace directories with the workspaces described in teams.json.
+	for key, workspace := range export.workspaces {
+		if key == "" {
+			continue
+		}
+		for _, team := range export.teams {
+			if team.Id == key || team.Domain == key {
+				workspace.team = team
+				break
+			}
+		}
 	}
 
-	mUser := si.oldImportUser(rctx, team, &botUser)
-	if mUser == nil {
-		log.WriteString(i18n.T("api.slackimport.slack_add_bot_user.unable_import", map[string]any{"Username": username}))
-		return nil
+	// Group messages with more members than a group message channel allows are imported as
+	// private channels, into the team the import was started from.
+	var directChannels []slackChannel
+	for _, channel := range export.directChannels {
+		if channel.Type == model.ChannelTypeGroup && len(channel.Members) > model.ChannelGroupMaxUsers {
+			channel.Type = model.ChannelTypePrivate
+			export.workspaces[""].channels = append(export.workspaces[""].channels, channel)
+			continue
+		}
+		directChannels = append(directChannels, channel)
 	}
+	export.directChannels = directChannels
 
-	log.WriteString(i18n.T("api.slackimport.slack_add_bot_user.email_pwd", map[string]any{"Email": botUser.Email, "Password": password}))
-	return mUser
+	return export, nil
 }
 
-func (si *SlackImporter) slackAddPosts(rctx request.CTX, teamId string, channel *model.Channel, posts []slackPost, users map[string]*model.User, uploads map[string]*zip.File, botUser *model.User) {
-	sort.Slice(posts, func(i, j int) bool {
-		return slackConvertTimeStamp(posts[i].TimeStamp) < slackConvertTimeStamp(posts[j].TimeStamp)
-	})
-	threads := make(map[string]string)
-	for _, sPost := range posts {
-		switch {
-		case sPost.Type == "message" && (sPost.SubType == "" || sPost.SubType == "file_share"):
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-			}
-			if sPost.Upload {
-				if sPost.File != nil {
-					if fileInfo, ok := si.slackUploadFile(rctx, sPost.File, uploads, teamId, newPost.ChannelId, newPost.UserId, sPost.TimeStamp); ok {
-						newPost.FileIds = append(newPost.FileIds, fileInfo.Id)
-					}
-				} else if sPost.Files != nil {
-					for _, file := range sPost.Files {
-						if fileInfo, ok := si.slackUploadFile(rctx, file, uploads, teamId, newPost.ChannelId, newPost.UserId, sPost.TimeStamp); ok {
-							newPost.FileIds = append(newPost.FileIds, fileInfo.Id)
-						}
-					}
-				}
-			}
-			// If post in thread
-			if sPost.ThreadTS != "" && sPost.ThreadTS != sPost.TimeStamp {
-				newPost.RootId = threads[sPost.ThreadTS]
-			}
-			postId := si.oldImportPost(rctx, &newPost)
-			// If post is thread starter
-			if sPost.ThreadTS == sPost.TimeStamp {
-				threads[sPost.ThreadTS] = postId
-			}
-		case sPost.Type == "message" && sPost.SubType == "file_comment":
-			if sPost.Comment == nil {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as it has no comments.")
-				continue
-			}
-			if sPost.Comment.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.Comment.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.Comment.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Comment.Comment,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-			}
-			si.oldImportPost(rctx, &newPost)
-		case sPost.Type == "message" && sPost.SubType == "bot_message":
-			if botUser == nil {
-				rctx.Logger().Warn("Slack Import: Unable to import the bot message as the bot user does not exist.")
-				continue
-			}
-			if sPost.BotId == "" {
-				rctx.Logger().Warn("Slack Import: Unable to import bot message as the BotId field is missing.")
-				continue
-			}
-
-			props := make(model.StringInterface)
-			props["override_username"] = sPost.BotUsername
-			if len(sPost.Attachments) > 0 {
-				props["attachments"] = sPost.Attachments
-			}
-
-			post := &model.Post{
-				UserId:    botUser.Id,
-				ChannelId: channel.Id,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Message:   sPost.Text,
-				Type:      model.PostTypeSlackAttachment,
-			}
-
-			postId := si.oldImportIncomingWebhookPost(rctx, post, props)
-			// If post is thread starter
-			if sPost.ThreadTS == sPost.TimeStamp {
-				threads[sPost.ThreadTS] = postId
-			}
-		case sPost.Type == "message" && (sPost.SubType == "channel_join" || sPost.SubType == "channel_leave"):
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-
-			var postType string
-			if sPost.SubType == "channel_join" {
-				postType = model.PostTypeJoinChannel
-			} else {
-				postType = model.PostTypeLeaveChannel
-			}
-
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      postType,
-				Props: model.StringInterface{
-					"username": users[sPost.User].Username,
-				},
-			}
-			si.oldImportPost(rctx, &newPost)
-		case sPost.Type == "message" && sPost.SubType == "me_message":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   "*" + sPost.Text + "*",
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
+func slackReadExportFile(export *slackExport, workspace *slackWorkspace, spl []string, reader io.Reader) error {
+	if len(spl) == 2 {
+		posts, err := slackParsePosts(reader)
+		channel := spl[0]
+		workspace.posts[channel] = append(workspace.posts[channel], posts...)
+		return err
+	}
+
+	var err error
+	switch spl[0] {
+	case "channels.json":
+		var channels []slackChannel
+		channels, err = slackParseChannels(reader, model.ChannelTypeOpen)
+		workspace.channels = append(workspace.channels, channels...)
+	case "groups.json":
+		var channels []slackChannel
+		channels, err = slackParseChannels(reader, model.ChannelTypePrivate)
+		workspace.channels = append(workspace.channels, channels...)
+	case "dms.json":
+		var channels []slackChannel
+		channels, err = slackParseChannels(reader, model.ChannelTypeDirect)
+		export.directChannels = append(export.directChannels, channels...)
+	case "mpims.json":
+		var channels []slackChannel
+		channels, err = slackParseChannels(reader, model.ChannelTypeGroup)
+		export.directChannels = append(export.directChannels, channels...)
+	case "users.json", "org_users.json":
+		var users []slackUser
+		users, err = slackParseUsers(reader)
+		for _, user := range users {
+			if !slackContainsUser(export.users, user.Id) {
+				export.users = append(export.users, user)
 			}
-			postId := si.oldImportPost(rctx, &newPost)
-			// If post is thread starter
-			if sPost.ThreadTS == sPost.TimeStamp {
-				threads[sPost.ThreadTS] = postId
-			}
-		case sPost.Type == "message" && sPost.SubType == "channel_topic":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      model.PostTypeHeaderChange,
-			}
-			si.oldImportPost(rctx, &newPost)
-		case sPost.Type == "message" && sPost.SubType == "channel_purpose":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      model.PostTypePurposeChange,
-			}
-			si.oldImportPost(rctx, &newPost)
-		case sPost.Type == "message" && sPost.SubType == "channel_name":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      model.PostTypeDisplaynameChange,
-			}
-			si.oldImportPost(rctx, &newPost)
-		default:
-			rctx.Logger().Warn(
-				"Slack Import: Unable to import the message as its type is not supported",
-				mlog.String("post_type", sPost.Type),
-				mlog.String("post_subtype", sPost.SubType),
-			)
 		}
+	case "usergroups.json":
+		var userGroups []slackUserGroup
+		userGroups, err = slackParseUserGroups(reader)
+		workspace.userGroups = append(workspace.userGroups, userGroups...)
+	case "teams.json":
+		var teams []slackTeam
+		teams, err = slackParseTeams(reader)
+		export.teams = append(export.teams, teams...)
 	}
-}
 
-func (si *SlackImporter) slackUploadFile(rctx request.CTX, slackPostFile *slackFile, uploads map[string]*zip.File, teamId string, channelId string, userId string, slackTimestamp string) (*model.FileInfo, bool) {
-	if slackPostFile == nil {
-		rctx.Logger().Warn("Slack Import: Unable to attach the file to the post as the latter has no file section present in Slack export.")
-		return nil, false
-	}
-	file, ok := uploads[slackPostFile.Id]
-	if !ok {
-		rctx.Logger().Warn("Slack Import: Unable to import file as the file is missing from the Slack export zip file.", mlog.String("file_id", slackPostFile.Id))
-		return nil, false
-	}
-	openFile, err := file.Open()
-	if err != nil {
-		rctx.Logger().Warn("Slack Import: Unable to open the file from the Slack export.", mlog.String("file_id", slackPostFile.Id), mlog.Err(err))
-		return nil, false
-	}
-	defer openFile.Close()
+	return err
+}
 
-	// since this is an attachment, we should treat it as a file and apply according limits
-	reader := utils.NewLimitedReaderWithError(openFile, *si.config.FileSettings.MaxFileSize)
-	timestamp := utils.TimeFromMillis(slackConvertTimeStamp(slackTimestamp))
-	uploadedFile, err := si.oldImportFile(rctx, timestamp, reader, teamId, channelId, userId, filepath.Base(file.Name))
-	if err != nil {
-		rctx.Logger().Warn("Slack Import: An error occurred when uploading file.", mlog.String("file_id", slackPostFile.Id), mlog.Err(err))
-		return nil, false
+func slackContainsUser(users []slackUser, id string) bool {
+	for _, user := range users {
+		if user.Id == id {
+			return true
+		}
 	}
-
-	return uploadedFile, true
+	return false
 }
 
-func (si *SlackImporter) deactivateSlackBotUser(rctx request.CTX, user *model.User) {
-	if _, err := si.actions.UpdateActive(user, false); err != nil {
-		rctx.Logger().Warn("Slack Import: Unable to deactivate the user account used for the bot.")
+// sortedWorkspaceKeys returns the keys of the workspaces with the root workspace first.
+func (export *slackExport) sortedWorkspaceKeys() []string {
+	keys := make([]string, 0, len(export.workspaces))
+	for key := range export.workspaces {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
 }
 
-func (si *SlackImporter) addSlackUsersToChannel(rctx request.CTX, members []string, users map[string]*model.User, channel *model.Channel, log *bytes.Buffer) {
-	for _, member := range members {
-		user, ok := users[member]
-		if !ok {
-			log.WriteString(i18n.T("api.slackimport.slack_add_channels.failed_to_add_user", map[string]any{"Username": "?"}))
-			continue
-		}
-		if _, err := si.actions.AddUserToChannel(rctx, user, channel, false); err != nil {
-			log.WriteString(i18n.T("api.slackimport.slack_add_channels.failed_to_add_user", map[string]any{"Username": user.Username}))
-		}
+func truncateRunes(s string, i int) string {
+	runes := []rune(s)
+	if len(runes) > i {
+		return string(runes[:i])
 	}
+	return s
 }
 
 func slackSanitiseChannelProperties(rctx request.CTX, channel model.Channel) model.Channel {
@@ -590,250 +443,3 @@ func slackSanitiseChannelProperties(rctx request.CTX, channel model.Channel) mod
 
 	return channel
 }
-
-func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slackchannels []slackChannel, posts map[string][]slackPost, users map[string]*model.User, uploads map[string]*zip.File, botUser *model.User, importerLog *bytes.Buffer) map[string]*model.Channel {
-	// Write Header
-	importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.added"))
-	importerLog.WriteString("=================\r\n\r\n")
-
-	addedChannels := make(map[string]*model.Channel)
-	for _, sChannel := range slackchannels {
-		newChannel := model.Channel{
-			TeamId:      teamId,
-			Type:        sChannel.Type,
-			DisplayName: sChannel.Name,
-			Name:        slackConvertChannelName(sChannel.Name, sChannel.Id),
-			Purpose:     sChannel.Purpose.Value,
-			Header:      sChannel.Topic.Value,
-		}
-
-		// Direct message channels in Slack don't have a name so we set the id as name or else the messages won't get imported.
-		if newChannel.Type == model.ChannelTypeDirect {
-			sChannel.Name = sChannel.Id
-		}
-
-		newChannel = slackSanitiseChannelProperties(rctx, newChannel)
-
-		var mChannel *model.Channel
-		var err error
-		if mChannel, err = si.store.Channel().GetByName(teamId, sChannel.Name, true); err == nil {
-			// The channel already exists as an active channel. Merge with the existing one.
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.merge", map[string]any{"DisplayName": newChannel.DisplayName}))
-		} else if _, nErr := si.store.Channel().GetDeletedByName(teamId, sChannel.Name); nErr == nil {
-			// The channel already exists but has been deleted. Generate a random string for the handle instead.
-			newChannel.Name = model.NewId()
-			newChannel = slackSanitiseChannelProperties(rctx, newChannel)
-		}
-
-		if mChannel == nil {
-			// Haven't found an existing channel to merge with. Try importing it as a new one.
-			mChannel = si.oldImportChannel(rctx, &newChannel, sChannel, users)
-			if mChannel == nil {
-				rctx.Logger().Warn("Slack Import: Unable to import Slack channel.", mlog.String("channel_display_name", newChannel.DisplayName))
-				importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.import_failed", map[string]any{"DisplayName": newChannel.DisplayName}))
-				continue
-			}
-		}
-
-		// Members for direct and group channels are added during the creation of the channel in the oldImportChannel function
-		if sChannel.Type == model.ChannelTypeOpen || sChannel.Type == model.ChannelTypePrivate {
-			si.addSlackUsersToChannel(rctx, sChannel.Members, users, mChannel, importerLog)
-		}
-		importerLog.WriteString(newChannel.DisplayName + "\r\n")
-		addedChannels[sChannel.Id] = mChannel
-		si.slackAddPosts(rctx, teamId, mChannel, posts[sChannel.Name], users, uploads, botUser)
-	}
-
-	return addedChannels
-}
-
-//
-// -- Old SlackImport Functions --
-// Import functions are suitable for entering posts and users into the database without
-// some of the usual checks. (IsValid is still run)
-//
-
-func (si *SlackImporter) oldImportPost(rctx request.CTX, post *model.Post) string {
-	// Workaround for empty messages, which may be the case if they are webhook posts.
-	firstIteration := true
-	firstPostId := ""
-	if post.RootId != "" {
-		firstPostId = post.RootId
-	}
-	maxPostSize := si.actions.MaxPostSize()
-	for messageRuneCount := utf8.RuneCountInString(post.Message); messageRuneCount > 0 || firstIteration; messageRuneCount = utf8.RuneCountInString(post.Message) {
-		var remainder string
-		if messageRuneCount > maxPostSize {
-			remainder = string(([]rune(post.Message))[maxPostSize:])
-			post.Message = truncateRunes(post.Message, maxPostSize)
-		} else {
-			remainder = ""
-		}
-
-		post.Hashtags, _ = model.ParseHashtags(post.Message)
-
-		post.RootId = firstPostId
-
-		_, err := si.store.Post().Save(rctx, post)
-		if err != nil {
-			rctx.Logger().Debug("Error saving post.", mlog.String("user_id", post.UserId), mlog.String("message", post.Message))
-		}
-
-		if firstIteration {
-			if firstPostId == "" {
-				firstPostId = post.Id
-			}
-			for _, fileId := range post.FileIds {
-				if err := si.store.FileInfo().AttachToPost(rctx, fileId, post.Id, post.ChannelId, post.UserId); err != nil {
-					rctx.Logger().Error(
-						"Error attaching files to post.",
-						mlog.String("post_id", post.Id),
-						mlog.String("file_ids", strings.Join(post.FileIds, ",")),
-						mlog.String("user_id", post.UserId),
-						mlog.Err(err),
-					)
-				}
-			}
-			post.FileIds = nil
-		}
-
-		post.Id = ""
-		post.CreateAt++
-		post.Message = remainder
-		firstIteration = false
-	}
-	return firstPostId
-}
-
-func (si *SlackImporter) oldImportUser(rctx request.CTX, team *model.Team, user *model.User) *model.User {
-	user.MakeNonNil()
-
-	user.Roles = model.SystemUserRoleId
-
-	ruser, nErr := si.store.User().Save(rctx, user)
-	if nErr != nil {
-		rctx.Logger().Debug("Error saving user.", mlog.Err(nErr))
-		return nil
-	}
-
-	if _, err := si.store.User().VerifyEmail(ruser.Id, ruser.Email); err != nil {
-		rctx.Logger().Warn("Failed to set email verified.", mlog.Err(err))
-	}
-
-	if _, err := si.actions.JoinUserToTeam(team, user, ""); err != nil {
-		rctx.Logger().Warn("Failed to join team when importing.", mlog.Err(err))
-	}
-
-	return ruser
-}
-
-func (si *SlackImporter) oldImportChannel(rctx request.CTX, channel *model.Channel, sChannel slackChannel, users map[string]*model.User) *model.Channel {
-	switch {
-	case channel.Type == model.ChannelTypeDirect:
-		if len(sChannel.Members) < 2 {
-			return nil
-		}
-		u1 := users[sChannel.Members[0]]
-		u2 := users[sChannel.Members[1]]
-		if u1 == nil || u2 == nil {
-			rctx.Logger().Warn("Either or both of user ids not found in users.json. Ignoring.", mlog.String("id1", sChannel.Members[0]), mlog.String("id2", sChannel.Members[1]))
-			return nil
-		}
-		sc, err := si.actions.CreateDirectChannel(rctx, u1.Id, u2.Id)
-		if err != nil {
-			return nil
-		}
-
-		return sc
-	// check if direct channel has less than 8 members and if not import as private channel instead
-	case channel.Type == model.ChannelTypeGroup && len(sChannel.Members) < 8:
-		members := make([]string, len(sChannel.Members))
-
-		for i := range sChannel.Members {
-			u := users[sChannel.Members[i]]
-			if u == nil {
-				rctx.Logger().Warn("User not found in users.json. Ignoring.", mlog.String("id", sChannel.Members[i]))
-				continue
-			}
-			members[i] = u.Id
-		}
-
-		creator := users[sChannel.Creator]
-		if creator == nil {
-			return nil
-		}
-		sc, err := si.actions.CreateGroupChannel(rctx, members)
-		if err != nil {
-			return nil
-		}
-
-		return sc
-	case channel.Type == model.ChannelTypeGroup:
-		channel.Type = model.ChannelTypePrivate
-		sc, err := si.actions.CreateChannel(channel, false)
-		if err != nil {
-			return nil
-		}
-
-		return sc
-	}
-
-	sc, err := si.store.Channel().Save(rctx, channel, *si.config.TeamSettings.MaxChannelsPerTeam)
-	if err != nil {
-		return nil
-	}
-
-	return sc
-}
-
-func (si *SlackImporter) oldImportFile(rctx request.CTX, timestamp time.Time, file io.Reader, teamId string, channelId string, userId string, fileName string) (*model.FileInfo, error) {
-	buf := bytes.NewBuffer(nil)
-	_, err := io.Copy(buf, file)
-	if err != nil {
-		return nil, err
-	}
-
-	data := buf.Bytes()
-
-	fileInfo, appErr := si.actions.DoUploadFile(timestamp, teamId, channelId, userId, fileName, data)
-	if appErr != nil {
-		return nil, appErr
-	}
-
-	if fileInfo.IsImage() && !fileInfo.IsSvg() {
-		img, imgType, release, err := si.actions.PrepareImage(data)
-		if err != nil {
-			return nil, err
-		}
-		defer release()
-		si.actions.GenerateThumbnailImage(rctx, img, imgType, fileInfo.ThumbnailPath)
-		si.actions.GeneratePreviewImage(rctx, img, imgType, fileInfo.PreviewPath)
-	}
-
-	return fileInfo, nil
-}
-
-func (si *SlackImporter) oldImportIncomingWebhookPost(rctx request.CTX, post *model.Post, props model.StringInterface) string {
-	linkWithTextRegex := regexp.MustCompile(`<([^<\|]+)\|([^>]+)>`)
-	post.Message = linkWithTextRegex.ReplaceAllString(post.Message, "[${2}](${1})")
-
-	post.AddProp("from_webhook", "true")
-
-	if _, ok := props["override_username"]; !ok {
-		post.AddProp("override_username", model.DefaultWebhookUsername)
-	}
-
-	if len(props) > 0 {
-		for key, val := range props {
-			if key == "attachments" {
-				if attachments, success := val.([]*model.SlackAttachment); success {
-					model.ParseSlackAttachment(post, attachments)
-				}
-			} else if key != "from_webhook" {
-				post.AddProp(key, val)
-			}
-		}
-	}
-
-	return si.oldImportPost(rctx, post)
-}
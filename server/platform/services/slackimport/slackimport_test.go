@@ -6,18 +6,24 @@ package slackimport
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
 
 func TestSlackConvertTimeStamp(t *testing.T) {
@@ -331,87 +337,71 @@ in this~.`,
 	assert.Equal(t, expectedOutput, slackConvertPostsMarkup(input))
 }
 
-func TestOldImportChannel(t *testing.T) {
-	u1 := &model.User{
-		Id:       model.NewId(),
-		Username: "test-user-1",
-	}
-	u2 := &model.User{
-		Id:       model.NewId(),
-		Username: "test-user-2",
-	}
-	store := &mocks.Store{}
+func TestSlackConvertTeamName(t *testing.T) {
+	assert.Equal(t, "acme", slackConvertTeamName("Acme", "T012AB"))
+	assert.Equal(t, "slack-t012ab", slackConvertTeamName("", "T012AB"))
+	assert.Equal(t, "slack-t012ab", slackConvertTeamName("acme corp", "T012AB"))
+	assert.Equal(t, "slack-t012ab", slackConvertTeamName("api", "T012AB"))
+}
+
+func TestSlackConvertGroupName(t *testing.T) {
+	assert.Equal(t, "backend-devs", slackConvertGroupName("Backend-Devs", "S012AB"))
+	assert.Equal(t, "team.ops_1", slackConvertGroupName("team.ops_1", "S012AB"))
+	assert.Equal(t, "slack-s012ab", slackConvertGroupName("on call", "S012AB"))
+	assert.Equal(t, "slack-s012ab", slackConvertGroupName("here", "S012AB"))
+	assert.Equal(t, "slack-s012ab", slackConvertGroupName("", "S012AB"))
+}
+
+func TestSlackConvertEmojiName(t *testing.T) {
+	assert.Equal(t, "+1", slackConvertEmojiName("+1::skin-tone-2"))
+	assert.Equal(t, "smile", slackConvertEmojiName(":smile:"))
+	assert.Equal(t, "party_parrot", slackConvertEmojiName("party_parrot"))
+	assert.Equal(t, "", slackConvertEmojiName("not an emoji"))
+	assert.Equal(t, "", slackConvertEmojiName(""))
+}
+
+func TestSlackConvertDirectChannels(t *testing.T) {
 	config := &model.Config{}
 	config.SetDefaults()
 	rctx := request.TestContext(t)
+	importer := New(&mocks.Store{}, Actions{MaxPostSize: func() int { return model.PostMessageMaxRunesV2 }}, config)
 
-	t.Run("No panic on direct channel", func(t *testing.T) {
-		// ch := th.CreateDmChannel(u1)
-		ch := &model.Channel{
-			Type: model.ChannelTypeDirect,
-			Name: model.GetDMNameFromIds(u1.Id, u2.Id),
-		}
-		users := map[string]*model.User{
-			u2.Id: u2,
-		}
-		sCh := slackChannel{
-			Id:      "someid",
-			Members: []string{u1.Id, "randomID"},
-			Creator: "randomID2",
+	newConverter := func(directChannels []slackChannel) *slackConverter {
+		export := &slackExport{
+			workspaces:     map[string]*slackWorkspace{"": {posts: map[string][]slackPost{}}},
+			directChannels: directChannels,
+			uploads:        map[string]*zip.File{},
 		}
+		c := newSlackConverter(rctx, importer, export, &model.Team{Name: "team"}, new(bytes.Buffer))
+		c.addUser("U1", &imports.UserImportData{Username: model.NewPointer("test-user-1")})
+		c.addUser("U2", &imports.UserImportData{Username: model.NewPointer("test-user-2")})
+		return c
+	}
 
-		actions := Actions{}
-
-		importer := New(store, actions, config)
-		_ = importer.oldImportChannel(rctx, ch, sCh, users)
+	t.Run("Skip direct channel with unknown member", func(t *testing.T) {
+		c := newConverter([]slackChannel{{Id: "D1", Type: model.ChannelTypeDirect, Members: []string{"U1", "randomID"}}})
+		channelLines, postLines := c.convertDirectChannels()
+		assert.Empty(t, channelLines)
+		assert.Empty(t, postLines)
 	})
 
-	t.Run("No panic on direct channel with 1 member", func(t *testing.T) {
-		ch := &model.Channel{
-			Type: model.ChannelTypeDirect,
-			Name: model.GetDMNameFromIds(u1.Id, u1.Id),
-		}
-		users := map[string]*model.User{
-			u1.Id: u1,
-		}
-		sCh := slackChannel{
-			Id:      "someid",
-			Members: []string{u1.Id},
-			Creator: "randomID2",
-		}
-
-		actions := Actions{}
-
-		importer := New(store, actions, config)
-		_ = importer.oldImportChannel(rctx, ch, sCh, users)
+	t.Run("Skip direct channel with 1 member", func(t *testing.T) {
+		c := newConverter([]slackChannel{{Id: "D1", Type: model.ChannelTypeDirect, Members: []string{"U1", "U1"}}})
+		channelLines, _ := c.convertDirectChannels()
+		assert.Empty(t, channelLines)
 	})
 
-	t.Run("No panic on group channel", func(t *testing.T) {
-		ch := &model.Channel{
-			Type: model.ChannelTypeGroup,
-			Name: "test-channel",
-		}
-		users := map[string]*model.User{
-			u1.Id: u1,
-		}
-		sCh := slackChannel{
-			Id:      "someid",
-			Members: []string{u1.Id},
-			Creator: "randomID2",
-		}
-		actions := Actions{}
-
-		importer := New(store, actions, config)
-		_ = importer.oldImportChannel(rctx, ch, sCh, users)
+	t.Run("Convert group channel", func(t *testing.T) {
+		c := newConverter([]slackChannel{{Id: "G1", Name: "mpdm-1", Type: model.ChannelTypeGroup, Members: []string{"U1", "U2", "randomID"}}})
+		channelLines, _ := c.convertDirectChannels()
+		require.Len(t, channelLines, 1)
+		assert.Equal(t, []string{"test-user-1", "test-user-2"}, *channelLines[0].DirectChannel.Members)
 	})
 }
 
-func TestSlackUploadFile(t *testing.T) {
-	store := &mocks.Store{}
+func TestSlackConvertFile(t *testing.T) {
 	config := &model.Config{}
 	config.SetDefaults()
-	defaultLimit := *config.FileSettings.MaxFileSize
-
 	rctx := request.TestContext(t)
 
 	sf := &slackFile{
@@ -419,43 +409,393 @@ func TestSlackUploadFile(t *testing.T) {
 		Title: "test-file",
 	}
 
-	buf := new(bytes.Buffer)
-	zipWriter := zip.NewWriter(buf)
-	writer, err := zipWriter.Create("testfile")
-	require.NoError(t, err)
-
-	_, err = writer.Write([]byte(strings.Repeat("a", 100)))
-	require.NoError(t, err)
-
-	err = zipWriter.Close()
-	require.NoError(t, err)
-
-	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
-	require.NoError(t, err)
+	zipReader := createTestZip(t, map[string]string{
+		"__uploads/testfile/test-file.txt": strings.Repeat("a", 100),
+	})
 
-	uploads := map[string]*zip.File{
-		"testfile": zipReader.File[0],
+	newConverter := func() *slackConverter {
+		export := &slackExport{uploads: map[string]*zip.File{"testfile": zipReader.File[0]}}
+		return newSlackConverter(rctx, New(&mocks.Store{}, Actions{MaxPostSize: func() int { return model.PostMessageMaxRunesV2 }}, config), export, &model.Team{Name: "team"}, new(bytes.Buffer))
 	}
 
 	t.Run("Should not fail when file is in limits", func(t *testing.T) {
-		importer := New(store, Actions{
-			DoUploadFile: func(_ time.Time, _, _, _, _ string, _ []byte) (*model.FileInfo, *model.AppError) {
-				return &model.FileInfo{}, nil
-			},
-		}, config)
-		_, ok := importer.slackUploadFile(rctx, sf, uploads, "team-id", "channel-id", "user-id", time.Now().String())
+		c := newConverter()
+		attachment, ok := c.convertFile(sf)
 		require.True(t, ok)
+		assert.Equal(t, "testfile/test-file.txt", *attachment.Path)
+		assert.Contains(t, c.attachments, "testfile/test-file.txt")
 	})
 
 	t.Run("Should fail when file size exceeded", func(t *testing.T) {
+		defaultLimit := *config.FileSettings.MaxFileSize
 		defer func() {
 			config.FileSettings.MaxFileSize = model.NewPointer(defaultLimit)
 		}()
 
 		config.FileSettings.MaxFileSize = model.NewPointer(int64(10))
 
-		importer := New(store, Actions{}, config)
-		_, ok := importer.slackUploadFile(rctx, sf, uploads, "team-id", "channel-id", "user-id", time.Now().String())
+		c := newConverter()
+		_, ok := c.convertFile(sf)
+		require.False(t, ok)
+		assert.Empty(t, c.attachments)
+	})
+
+	t.Run("Should fail when file is missing", func(t *testing.T) {
+		c := newConverter()
+		_, ok := c.convertFile(&slackFile{Id: "missing"})
 		require.False(t, ok)
 	})
 }
+
+func TestSlackImport(t *testing.T) {
+	require.NoError(t, utils.TranslationsPreInit())
+	config := &model.Config{}
+	config.SetDefaults()
+	rctx := request.TestContext(t)
+
+	team := &model.Team{Id: model.NewId(), Name: "target", DisplayName: "Target"}
+	existingUser := &model.User{Id: model.NewId(), Username: "bobby", Email: "bob@example.com", Locale: "fr"}
+
+	mockStore := &mocks.Store{}
+	teamStore := &mocks.TeamStore{}
+	teamStore.On("Get", team.Id).Return(team, nil)
+	teamStore.On("GetByName", "second").Return(nil, store.NewErrNotFound("Team", "second"))
+	userStore := &mocks.UserStore{}
+	userStore.On("GetByEmail", "bob@example.com").Return(existingUser, nil)
+	userStore.On("GetByEmail", mock.Anything).Return(nil, store.NewErrNotFound("User", "email"))
+	userStore.On("GetByUsername", mock.Anything).Return(nil, store.NewErrNotFound("User", "username"))
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("GetByName", team.Id, mock.Anything, true).Return(nil, store.NewErrNotFound("Channel", "name"))
+	channelStore.On("GetDeletedByName", team.Id, mock.Anything).Return(nil, store.NewErrNotFound("Channel", "name"))
+	groupStore := &mocks.GroupStore{}
+	groupStore.On("GetByName", mock.Anything, mock.Anything).Return(nil, store.NewErrNotFound("Group", "name"))
+	mockStore.On("Team").Return(teamStore)
+	mockStore.On("User").Return(userStore)
+	mockStore.On("Channel").Return(channelStore)
+	mockStore.On("Group").Return(groupStore)
+
+	var lines []imports.LineImportData
+	var files []string
+	actions := Actions{
+		BulkImport: func(jsonlReader io.Reader, attachmentsReader *zip.Reader) (*model.AppError, int) {
+			decoder := json.NewDecoder(jsonlReader)
+			for decoder.More() {
+				var line imports.LineImportData
+				require.NoError(t, decoder.Decode(&line))
+				lines = append(lines, line)
+			}
+			for _, file := range attachmentsReader.File {
+				if file.Name != slackImportJSONLName {
+					files = append(files, file.Name)
+				}
+			}
+			return nil, 0
+		},
+		MaxPostSize:     func() int { return 100 },
+		CanManageSystem: func() bool { return true },
+	}
+
+	exportFiles := map[string]string{
+		"teams.json": `[{"id": "T2", "name": "Second Workspace", "domain": "second"}]`,
+		"users.json": `[
+			{"id": "U1", "name": "alice", "profile": {"first_name": "Alice", "email": "alice@example.com", "title": "Engineer"}},
+			{"id": "U2", "name": "bob", "profile": {"email": "bob@example.com"}},
+			{"id": "U3", "name": "carol", "profile": {}},
+			{"id": "U4", "name": "dave", "deleted": true, "profile": {"email": "dave@example.com"}}
+		]`,
+		"channels.json": `[
+			{
+				"id": "C1", "name": "general", "creator": "U1", "created": 1577836000, "members": ["U1", "U2", "U3"],
+				"topic": {"value": "General topic"},
+				"bookmarks": [
+					{"title": "Docs", "link": "https://example.com/docs", "emoji": ":books:", "type": "link", "date_created": 1577836100, "created_by": "U2"},
+					{"title": "Spec", "link": "F123", "type": "file"}
+				],
+				"properties": {"canvas": {"file_id": "F2", "is_empty": false}}
+			},
+			{"id": "C2", "name": "old-stuff", "creator": "U1", "is_archived": true, "members": ["U1"]}
+		]`,
+		"dms.json": `[
+			{"id": "D1", "members": ["U1", "U2"]},
+			{"id": "D2", "members": ["U1"]}
+		]`,
+		"mpims.json":      `[{"id": "G1", "name": "mpdm-alice--bob--carol-1", "members": ["U1", "U2", "U3"]}]`,
+		"usergroups.json": `[{"id": "S1", "name": "Developers", "handle": "devs", "users": ["U1", "U3"]}, {"id": "S2", "handle": "gone", "users": ["U1"], "date_delete": 1577836000}]`,
+		"general/2020-01-01.json": `[
+			{
+				"type": "message", "user": "U1", "text": "hello <@U2>", "ts": "1577836800.000100", "thread_ts": "1577836800.000100",
+				"files": [{"id": "F1"}],
+				"reactions": [{"name": "+1::skin-tone-2", "users": ["U2", "U3"]}, {"name": "+1", "users": ["U2"]}],
+				"pinned_to": ["C1"], "pinned_info": {"C1": {"pinned_by": "U2"}},
+				"edited": {"user": "U1", "ts": "1577836900.000000"}
+			},
+			{"type": "message", "user": "U2", "text": "reply", "ts": "1577836860.000200", "thread_ts": "1577836800.000100"},
+			{"type": "message", "subtype": "bot_message", "bot_id": "B1", "username": "jira", "text": "new issue", "ts": "1577836870.000000", "attachments": [{"text": "details"}]},
+			{"type": "message", "subtype": "channel_join", "user": "U3", "text": "<@U3> has joined the channel", "ts": "1577836880.000000"}
+		]`,
+		"D1/2020-01-01.json":                       `[{"type": "message", "user": "U1", "text": "direct message", "ts": "1577836800.000000", "pinned_to": ["D1"]}]`,
+		"mpdm-alice--bob--carol-1/2020-01-01.json": `[{"type": "message", "user": "U3", "text": "group message", "ts": "1577836800.000000"}]`,
+		"__uploads/F1/file.txt":                    "file contents",
+		"__uploads/F2/Canvas.html":                 "<p>canvas</p>",
+		"teams/T2/channels.json":                   `[{"id": "C3", "name": "random", "creator": "U1", "members": ["U1"]}]`,
+		"teams/T2/usergroups.json":                 `[{"id": "S3", "name": "Developers", "handle": "devs", "users": ["U2"]}]`,
+		"teams/T2/random/2020-01-02.json":          `[{"type": "message", "user": "U1", "text": "` + strings.Repeat("a", 150) + `", "ts": "1577923200.000000"}]`,
+	}
+
+	buf := createTestZipBuffer(t, exportFiles)
+	appErr, log := New(mockStore, actions, config).SlackImport(rctx, testZipFile{bytes.NewReader(buf)}, int64(len(buf)), team.Id)
+	require.Nil(t, appErr)
+	assert.NotEmpty(t, log.String())
+
+	assert.ElementsMatch(t, []string{"data/F1/file.txt", "data/F2/Canvas.html"}, files)
+
+	var types []string
+	linesByType := map[string][]imports.LineImportData{}
+	for _, line := range lines {
+		types = append(types, line.Type)
+		linesByType[line.Type] = append(linesByType[line.Type], line)
+	}
+	assert.Equal(t, []string{
+		"version", "team",
+		"channel", "channel", "channel",
+		"user", "user", "user", "user", "user",
+		"channel", "group",
+		"post", "post", "post", "post", "post",
+		"direct_channel", "direct_channel",
+		"direct_post", "direct_post",
+	}, types)
+
+	t.Run("teams", func(t *testing.T) {
+		teamData := linesByType["team"][0].Team
+		assert.Equal(t, "second", *teamData.Name)
+		assert.Equal(t, "Second Workspace", *teamData.DisplayName)
+		assert.Equal(t, model.TeamInvite, *teamData.Type)
+	})
+
+	t.Run("channels", func(t *testing.T) {
+		channels := linesByType["channel"]
+		assert.Equal(t, "general", *channels[0].Channel.Name)
+		assert.Equal(t, "target", *channels[0].Channel.Team)
+		assert.Equal(t, "General topic", *channels[0].Channel.Header)
+		assert.Nil(t, channels[0].Channel.Bookmarks)
+		assert.Equal(t, "old-stuff", *channels[1].Channel.Name)
+		assert.NotNil(t, channels[1].Channel.DeletedAt)
+		assert.Equal(t, "random", *channels[2].Channel.Name)
+		assert.Equal(t, "second", *channels[2].Channel.Team)
+
+		// Bookmarks are imported once their owners exist.
+		assert.Equal(t, "general", *channels[3].Channel.Name)
+		require.NotNil(t, channels[3].Channel.Bookmarks)
+		require.Len(t, *channels[3].Channel.Bookmarks, 1)
+		bookmark := (*channels[3].Channel.Bookmarks)[0]
+		assert.Equal(t, "bobby", *bookmark.User)
+		assert.Equal(t, "Docs", *bookmark.DisplayName)
+		assert.Equal(t, "https://example.com/docs", *bookmark.LinkURL)
+		assert.Equal(t, "books", *bookmark.Emoji)
+		assert.Equal(t, int64(1577836100000), *bookmark.CreateAt)
+	})
+
+	t.Run("users", func(t *testing.T) {
+		users := map[string]*imports.UserImportData{}
+		for _, line := range linesByType["user"] {
+			users[*line.User.Username] = line.User
+		}
+		require.Contains(t, users, "alice")
+		assert.Equal(t, "Engineer", *users["alice"].Position)
+		assert.Nil(t, users["alice"].Password)
+		require.Len(t, *users["alice"].Teams, 2)
+		assert.Equal(t, "target", *(*users["alice"].Teams)[0].Name)
+		assert.Len(t, *(*users["alice"].Teams)[0].Channels, 2)
+		assert.Equal(t, "second", *(*users["alice"].Teams)[1].Name)
+
+		require.Contains(t, users, "bobby")
+		assert.Equal(t, "fr", *users["bobby"].Locale)
+		assert.Nil(t, users["bobby"].FirstName)
+
+		require.Contains(t, users, "carol")
+		assert.Equal(t, "carol@example.com", *users["carol"].Email)
+
+		require.Contains(t, users, "dave")
+		assert.NotNil(t, users["dave"].DeleteAt)
+
+		require.Contains(t, users, slackImportBotUsername)
+		assert.NotNil(t, users[slackImportBotUsername].DeleteAt)
+		assert.Len(t, *users[slackImportBotUsername].Teams, 2)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		group := linesByType["group"][0].Group
+		assert.Equal(t, "devs", *group.Name)
+		assert.Equal(t, "Developers", *group.DisplayName)
+		assert.Equal(t, []string{"alice", "carol", "bobby"}, *group.Members)
+	})
+
+	t.Run("posts", func(t *testing.T) {
+		posts := linesByType["post"]
+
+		canvas := posts[0].Post
+		assert.Equal(t, "alice", *canvas.User)
+		assert.Equal(t, int64(1577836000000), *canvas.CreateAt)
+		assert.True(t, *canvas.IsPinned)
+		require.Len(t, *canvas.Attachments, 1)
+		assert.Equal(t, "F2/Canvas.html", *(*canvas.Attachments)[0].Path)
+
+		root := posts[1].Post
+		assert.Equal(t, "hello @bob", *root.Message)
+		assert.Equal(t, int64(1577836800000), *root.CreateAt)
+		assert.Equal(t, int64(1577836900000), *root.EditAt)
+		assert.True(t, *root.IsPinned)
+		assert.Equal(t, []string{"bobby"}, *root.FlaggedBy)
+		require.Len(t, *root.Attachments, 1)
+		assert.Equal(t, "F1/file.txt", *(*root.Attachments)[0].Path)
+		require.Len(t, *root.Reactions, 2)
+		assert.Equal(t, "bobby", *(*root.Reactions)[0].User)
+		assert.Equal(t, "+1", *(*root.Reactions)[0].EmojiName)
+		assert.Equal(t, "carol", *(*root.Reactions)[1].User)
+		require.Len(t, *root.Replies, 1)
+		assert.Equal(t, "reply", *(*root.Replies)[0].Message)
+		assert.Equal(t, "bobby", *(*root.Replies)[0].User)
+
+		bot := posts[2].Post
+		assert.Equal(t, slackImportBotUsername, *bot.User)
+		assert.Equal(t, model.PostTypeSlackAttachment, *bot.Type)
+		assert.Equal(t, "jira", (*bot.Props)["override_username"])
+		assert.Equal(t, "true", (*bot.Props)["from_webhook"])
+		assert.NotNil(t, (*bot.Props)["attachments"])
+
+		join := posts[3].Post
+		assert.Equal(t, model.PostTypeJoinChannel, *join.Type)
+		assert.Equal(t, "carol", *join.User)
+
+		long := posts[4].Post
+		assert.Equal(t, "second", *long.Team)
+		assert.Equal(t, "random", *long.Channel)
+		assert.Equal(t, strings.Repeat("a", 100), *long.Message)
+		require.Len(t, *long.Replies, 1)
+		assert.Equal(t, strings.Repeat("a", 50), *(*long.Replies)[0].Message)
+		assert.Equal(t, *long.CreateAt+1, *(*long.Replies)[0].CreateAt)
+	})
+
+	t.Run("direct channels", func(t *testing.T) {
+		directChannels := linesByType["direct_channel"]
+		assert.Equal(t, []string{"alice", "bobby"}, *directChannels[0].DirectChannel.Members)
+		assert.Equal(t, []string{"alice", "bobby", "carol"}, *directChannels[1].DirectChannel.Members)
+
+		directPosts := linesByType["direct_post"]
+		assert.Equal(t, "direct message", *directPosts[0].DirectPost.Message)
+		assert.Equal(t, []string{"alice", "bobby"}, *directPosts[0].DirectPost.ChannelMembers)
+		assert.Equal(t, []string{"alice"}, *directPosts[0].DirectPost.FlaggedBy)
+		assert.Equal(t, "group message", *directPosts[1].DirectPost.Message)
+	})
+}
+
+func TestSlackImportWithoutManageSystem(t *testing.T) {
+	require.NoError(t, utils.TranslationsPreInit())
+	config := &model.Config{}
+	config.SetDefaults()
+	rctx := request.TestContext(t)
+
+	team := &model.Team{Id: model.NewId(), Name: "target", DisplayName: "Target"}
+
+	mockStore := &mocks.Store{}
+	teamStore := &mocks.TeamStore{}
+	teamStore.On("Get", team.Id).Return(team, nil)
+	userStore := &mocks.UserStore{}
+	userStore.On("GetByEmail", mock.Anything).Return(nil, store.NewErrNotFound("User", "email"))
+	userStore.On("GetByUsername", mock.Anything).Return(nil, store.NewErrNotFound("User", "username"))
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("GetByName", team.Id, mock.Anything, true).Return(nil, store.NewErrNotFound("Channel", "name"))
+	channelStore.On("GetDeletedByName", team.Id, mock.Anything).Return(nil, store.NewErrNotFound("Channel", "name"))
+	groupStore := &mocks.GroupStore{}
+	groupStore.On("GetByName", "devs", mock.Anything).Return(&model.Group{Name: model.NewPointer("devs"), Source: model.GroupSourceCustom}, nil)
+	groupStore.On("GetByName", mock.Anything, mock.Anything).Return(nil, store.NewErrNotFound("Group", "name"))
+	mockStore.On("Team").Return(teamStore)
+	mockStore.On("User").Return(userStore)
+	mockStore.On("Channel").Return(channelStore)
+	mockStore.On("Group").Return(groupStore)
+
+	var lines []imports.LineImportData
+	actions := Actions{
+		BulkImport: func(jsonlReader io.Reader, _ *zip.Reader) (*model.AppError, int) {
+			decoder := json.NewDecoder(jsonlReader)
+			for decoder.More() {
+				var line imports.LineImportData
+				require.NoError(t, decoder.Decode(&line))
+				lines = append(lines, line)
+			}
+			return nil, 0
+		},
+		MaxPostSize:     func() int { return 100 },
+		CanManageSystem: func() bool { return false },
+	}
+
+	buf := createTestZipBuffer(t, map[string]string{
+		"teams.json":               `[{"id": "T2", "name": "Second Workspace", "domain": "second"}]`,
+		"users.json":               `[{"id": "U1", "name": "alice", "profile": {"email": "alice@example.com"}}]`,
+		"channels.json":            `[{"id": "C1", "name": "general", "creator": "U1", "members": ["U1"]}]`,
+		"usergroups.json":          `[{"id": "S1", "name": "Developers", "handle": "devs", "users": ["U1"]}, {"id": "S2", "name": "Designers", "handle": "design", "users": ["U1"]}]`,
+		"teams/T2/channels.json":   `[{"id": "C3", "name": "random", "creator": "U1", "members": ["U1"]}]`,
+		"teams/T2/usergroups.json": `[]`,
+	})
+	appErr, _ := New(mockStore, actions, config).SlackImport(rctx, testZipFile{bytes.NewReader(buf)}, int64(len(buf)), team.Id)
+	require.Nil(t, appErr)
+
+	var groupNames []string
+	for _, line := range lines {
+		switch line.Type {
+		case "team":
+			assert.Fail(t, "no team should be imported", *line.Team.Name)
+		case "channel":
+			assert.Equal(t, "target", *line.Channel.Team)
+		case "user":
+			require.Len(t, *line.User.Teams, 1)
+			assert.Equal(t, "target", *(*line.User.Teams)[0].Name)
+			assert.Len(t, *(*line.User.Teams)[0].Channels, 2)
+		case "group":
+			groupNames = append(groupNames, *line.Group.Name)
+		}
+	}
+	assert.Equal(t, []string{"design"}, groupNames)
+	teamStore.AssertNotCalled(t, "GetByName", mock.Anything)
+}
+
+// testZipFile is an in-memory multipart.File.
+type testZipFile struct {
+	*bytes.Reader
+}
+
+func (testZipFile) Close() error {
+	return nil
+}
+
+func createTestZipBuffer(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for _, name := range names {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	return buf.Bytes()
+}
+
+func createTestZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+
+	buf := createTestZipBuffer(t, files)
+	zipReader, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+
+	return zipReader
+}
@@ -483,6 +483,8 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_outgoing_oauth_connections":                       cfg.ServiceSettings.EnableOutgoingOAuthConnections,
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"outgoing_integrations_requests_timeout":                  cfg.ServiceSettings.OutgoingIntegrationRequestsTimeout,
+		"outgoing_webhook_max_retries":                            *cfg.ServiceSettings.OutgoingWebhookMaxRetries,
+		"outgoing_webhook_disable_after_failures":                 *cfg.ServiceSettings.OutgoingWebhookDisableAfterFailures,
 		"enable_post_username_override":                           cfg.ServiceSettings.EnablePostUsernameOverride,
 		"enable_post_icon_override":                               cfg.ServiceSettings.EnablePostIconOverride,
 		"enable_user_access_tokens":                               *cfg.ServiceSettings.EnableUserAccessTokens,
@@ -614,6 +616,8 @@ func (ts *TelemetryService) trackConfig() {
 		"disable_database_search":              *cfg.SqlSettings.DisableDatabaseSearch,
 		"migrations_statement_timeout_seconds": *cfg.SqlSettings.MigrationsStatementTimeoutSeconds,
 		"replica_monitor_interval_seconds":     *cfg.SqlSettings.ReplicaMonitorIntervalSeconds,
+		"replica_max_lag_seconds":              *cfg.SqlSettings.ReplicaMaxLagSeconds,
+		"read_your_writes_window_seconds":      *cfg.SqlSettings.ReadYourWritesWindowSeconds,
 	}
 
 	configs[TrackConfigLog] = map[string]any{
@@ -635,6 +639,7 @@ func (ts *TelemetryService) trackConfig() {
 		"file_max_backups":      *cfg.ExperimentalAuditSettings.FileMaxBackups,
 		"file_compress":         *cfg.ExperimentalAuditSettings.FileCompress,
 		"file_max_queue_size":   *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
+		"database_enabled":      *cfg.ExperimentalAuditSettings.DatabaseEnabled,
 		"advanced_logging_json": len(cfg.ExperimentalAuditSettings.AdvancedLoggingJSON) != 0,
 	}
 
@@ -711,6 +716,8 @@ func (ts *TelemetryService) trackConfig() {
 		"max_burst":                *cfg.RateLimitSettings.MaxBurst,
 		"memory_store_size":        *cfg.RateLimitSettings.MemoryStoreSize,
 		"isdefault_vary_by_header": isDefault(cfg.RateLimitSettings.VaryByHeader, ""),
+		"store":                    *cfg.RateLimitSettings.Store,
+		"policies_count":           len(cfg.RateLimitSettings.Policies),
 	}
 
 	configs[TrackConfigPrivacy] = map[string]any{
@@ -904,8 +911,11 @@ func (ts *TelemetryService) trackConfig() {
 		"batch_size":                    *cfg.DataRetentionSettings.BatchSize,
 		"time_between_batches":          *cfg.DataRetentionSettings.TimeBetweenBatchesMilliseconds,
 		"retention_ids_batch_size":      *cfg.DataRetentionSettings.RetentionIdsBatchSize,
+		"enable_audit_log_deletion":     *cfg.DataRetentionSettings.EnableAuditLogDeletion,
+		"audit_log_retention_days":      *cfg.DataRetentionSettings.AuditLogRetentionDays,
 		"cleanup_jobs_threshold_days":   *cfg.JobSettings.CleanupJobsThresholdDays,
 		"cleanup_config_threshold_days": *cfg.JobSettings.CleanupConfigThresholdDays,
+		"config_file_history_size":      *cfg.JobSettings.ConfigFileHistorySize,
 	}
 
 	configs[TrackConfigMessageExport] = map[string]any{
@@ -936,10 +946,12 @@ func (ts *TelemetryService) trackConfig() {
 	}
 
 	configs[TrackConfigImageProxy] = map[string]any{
-		"enable":                               *cfg.ImageProxySettings.Enable,
-		"image_proxy_type":                     *cfg.ImageProxySettings.ImageProxyType,
-		"isdefault_remote_image_proxy_url":     isDefault(*cfg.ImageProxySettings.RemoteImageProxyURL, ""),
-		"isdefault_remote_image_proxy_options": isDefault(*cfg.ImageProxySettings.RemoteImageProxyOptions, ""),
+		"enable":                                 *cfg.ImageProxySettings.Enable,
+		"image_proxy_type":                       *cfg.ImageProxySettings.ImageProxyType,
+		"isdefault_remote_image_proxy_url":       isDefault(*cfg.ImageProxySettings.RemoteImageProxyURL, ""),
+		"isdefault_remote_image_proxy_options":   isDefault(*cfg.ImageProxySettings.RemoteImageProxyOptions, ""),
+		"local_image_proxy_cache_size_mb":        *cfg.ImageProxySettings.LocalImageProxyCacheSizeMB,
+		"local_image_proxy_max_image_resolution": *cfg.ImageProxySettings.LocalImageProxyMaxImageResolution,
 	}
 
 	configs[TrackConfigBleve] = map[string]any{
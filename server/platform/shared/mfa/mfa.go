@@ -5,7 +5,9 @@ package mfa
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base32"
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"strings"
@@ -22,6 +24,19 @@ var InvalidToken = errors.New("invalid mfa token")
 const (
 	// This will result in 160 bits of entropy (base32 encoded), as recommended by rfc4226.
 	mfaSecretSize = 20
+
+	// RecoveryCodeCount is the number of recovery codes a user gets at once.
+	RecoveryCodeCount = 10
+	// RecoveryCodesLowThreshold is the number of recovery codes left at which users are told
+	// to generate new ones.
+	RecoveryCodesLowThreshold = 3
+	// NoRecoveryCodeUsed is returned by ValidateToken as the number of recovery codes left
+	// when the token was not a recovery code.
+	NoRecoveryCodeUsed = -1
+
+	// This will result in 80 bits of entropy, so that a fast hash is enough to store the codes.
+	recoveryCodeLength   = 16
+	recoveryCodeAlphabet = "ybndrfg8ejkmcpqxot1uwisza345h769"
 )
 
 type Store interface {
@@ -29,6 +44,9 @@ type Store interface {
 	UpdateMfaSecret(userId, secret string) error
 	StoreMfaUsedTimestamps(userId string, ts []int) error
 	GetMfaUsedTimestamps(userId string) ([]int, error)
+	StoreMfaRecoveryCodes(userId string, codeHashes []string) error
+	UseMfaRecoveryCode(userId, codeHash string) (bool, error)
+	CountMfaRecoveryCodes(userId string) (int64, error)
 }
 
 type MFA struct {
@@ -83,28 +101,29 @@ func (m *MFA) GenerateSecret(siteURL, userEmail, userID string) (string, []byte,
 	return secret, img, nil
 }
 
-// Activate set the mfa as active and store it with the StoreActive function provided
-func (m *MFA) Activate(userMfaSecret, userID string, token string) error {
+// Activate set the mfa as active and store it with the StoreActive function provided. It returns
+// a new set of recovery codes for the user.
+func (m *MFA) Activate(userMfaSecret, userID string, token string) ([]string, error) {
 	usedTs, err := m.store.GetMfaUsedTimestamps(userID)
 	if err != nil {
-		return errors.Wrap(err, "unable to retrieve the DisallowReuse slice")
+		return nil, errors.Wrap(err, "unable to retrieve the DisallowReuse slice")
 	}
 
 	otpConfig, err := m.authenticate(userMfaSecret, usedTs, token)
 	if err != nil {
-		return errors.Wrap(err, "unable to authenticate the token")
+		return nil, errors.Wrap(err, "unable to authenticate the token")
 	}
 
 	if err = m.store.UpdateMfaActive(userID, true); err != nil {
-		return errors.Wrap(err, "unable to store mfa active")
+		return nil, errors.Wrap(err, "unable to store mfa active")
 	}
 
 	err = m.store.StoreMfaUsedTimestamps(userID, otpConfig.DisallowReuse)
 	if err != nil {
-		return errors.Wrap(err, "unable to store the DisallowReuse slice")
+		return nil, errors.Wrap(err, "unable to store the DisallowReuse slice")
 	}
 
-	return nil
+	return m.GenerateRecoveryCodes(userID)
 }
 
 // Deactivate set the mfa as deactivated, remove the mfa secret, store it with the StoreActive and StoreSecret functions provided
@@ -117,31 +136,102 @@ func (m *MFA) Deactivate(userId string) error {
 		return errors.Wrap(err, "unable to store mfa secret")
 	}
 
+	if err := m.store.StoreMfaRecoveryCodes(userId, nil); err != nil {
+		return errors.Wrap(err, "unable to remove the recovery codes")
+	}
+
 	return nil
 }
 
-// Validate the provide token using the secret provided
-func (m *MFA) ValidateToken(user *model.User, token string) (bool, error) {
+// ValidateToken validates the provided token using the secret provided, falling back to the
+// recovery codes of the user. When a recovery code is used up, the number of recovery codes
+// the user has left is returned, otherwise NoRecoveryCodeUsed.
+func (m *MFA) ValidateToken(user *model.User, token string) (bool, int, error) {
+	if IsRecoveryCode(token) {
+		return m.useRecoveryCode(user.Id, token)
+	}
+
 	usedTs, err := m.store.GetMfaUsedTimestamps(user.Id)
 	if err != nil {
-		return false, errors.Wrap(err, "unable to retrieve the DisallowReuse slice")
+		return false, NoRecoveryCodeUsed, errors.Wrap(err, "unable to retrieve the DisallowReuse slice")
 	}
 
 	otpConfig, err := m.authenticate(user.MfaSecret, usedTs, token)
 	if err != nil {
 		if err == InvalidToken {
-			return false, nil
+			return false, NoRecoveryCodeUsed, nil
 		}
 
-		return false, errors.Wrap(err, "unable to parse the token")
+		return false, NoRecoveryCodeUsed, errors.Wrap(err, "unable to parse the token")
 	}
 
 	err = m.store.StoreMfaUsedTimestamps(user.Id, otpConfig.DisallowReuse)
 	if err != nil {
-		return true, errors.Wrap(err, "unable to store the DisallowReuse slice")
+		return true, NoRecoveryCodeUsed, errors.Wrap(err, "unable to store the DisallowReuse slice")
 	}
 
-	return true, nil
+	return true, NoRecoveryCodeUsed, nil
+}
+
+// GenerateRecoveryCodes replaces the recovery codes of a user with new ones. Only the hashes
+// of the codes are stored, the codes themselves can only be shown to the user once.
+func (m *MFA) GenerateRecoveryCodes(userID string) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		code := model.NewRandomString(recoveryCodeLength)
+		codes[i] = code[:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:]
+		hashes[i] = hashRecoveryCode(code)
+	}
+
+	if err := m.store.StoreMfaRecoveryCodes(userID, hashes); err != nil {
+		return nil, errors.Wrap(err, "unable to store the recovery codes")
+	}
+
+	return codes, nil
+}
+
+func (m *MFA) useRecoveryCode(userID, token string) (bool, int, error) {
+	used, err := m.store.UseMfaRecoveryCode(userID, hashRecoveryCode(normalizeRecoveryCode(token)))
+	if err != nil {
+		return false, NoRecoveryCodeUsed, errors.Wrap(err, "unable to use the recovery code")
+	}
+	if !used {
+		return false, NoRecoveryCodeUsed, nil
+	}
+
+	left, err := m.store.CountMfaRecoveryCodes(userID)
+	if err != nil {
+		return true, 0, errors.Wrap(err, "unable to count the recovery codes")
+	}
+
+	return true, int(left), nil
+}
+
+// normalizeRecoveryCode drops the separators and the case that users may type a recovery code with.
+func normalizeRecoveryCode(token string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(token)))
+}
+
+// IsRecoveryCode tells a recovery code apart from a TOTP code.
+func IsRecoveryCode(token string) bool {
+	code := normalizeRecoveryCode(token)
+	if len(code) != recoveryCodeLength {
+		return false
+	}
+
+	for _, r := range code {
+		if !strings.ContainsRune(recoveryCodeAlphabet, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hashRecoveryCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
 }
 
 func (*MFA) authenticate(userMfaSecret string, usedTs []int, token string) (*dgoogauth.OTPConfig, error) {
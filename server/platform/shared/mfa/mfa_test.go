@@ -80,7 +80,7 @@ func TestActivate(t *testing.T) {
 		storeMock := mocks.UserStore{}
 		storeMock.On("GetMfaUsedTimestamps", userID).Return([]int{}, nil).Once()
 
-		err := New(&storeMock).Activate(userMfaSecret, userID, "invalid-token")
+		_, err := New(&storeMock).Activate(userMfaSecret, userID, "invalid-token")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unable to parse the token")
 	})
@@ -89,7 +89,7 @@ func TestActivate(t *testing.T) {
 		storeMock := mocks.UserStore{}
 		storeMock.On("GetMfaUsedTimestamps", userID).Return([]int{}, nil).Once()
 
-		err := New(&storeMock).Activate(userMfaSecret, userID, "000000")
+		_, err := New(&storeMock).Activate(userMfaSecret, userID, "000000")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "invalid mfa token")
 	})
@@ -101,7 +101,7 @@ func TestActivate(t *testing.T) {
 			return errors.New("failed to update mfa active")
 		})
 
-		err := New(&storeMock).Activate(userMfaSecret, userID, fmt.Sprintf("%06d", token))
+		_, err := New(&storeMock).Activate(userMfaSecret, userID, fmt.Sprintf("%06d", token))
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unable to store mfa active")
 	})
@@ -117,9 +117,14 @@ func TestActivate(t *testing.T) {
 		usMock.On("GetMfaUsedTimestamps", userID).Return([]int{}, nil).Once()
 		usMock.On("UpdateMfaActive", userID, true).Return(nil).Once()
 		usMock.On("StoreMfaUsedTimestamps", userID, mock.AnythingOfType("[]int")).Return(nil).Once()
+		usMock.On("StoreMfaRecoveryCodes", userID, mock.AnythingOfType("[]string")).Return(nil).Once()
 
-		err := New(&usMock).Activate(secret, userID, code)
+		codes, err := New(&usMock).Activate(secret, userID, code)
 		require.NoError(t, err)
+		require.Len(t, codes, RecoveryCodeCount)
+		for _, code := range codes {
+			assert.True(t, IsRecoveryCode(code), code)
+		}
 	})
 
 	t.Run("disallow reuse of totp", func(t *testing.T) {
@@ -132,7 +137,7 @@ func TestActivate(t *testing.T) {
 		usMock := mocks.UserStore{}
 		usMock.On("GetMfaUsedTimestamps", userID).Return([]int{int(t0)}, nil).Once()
 
-		err := New(&usMock).Activate(secret, userID, code)
+		_, err := New(&usMock).Activate(secret, userID, code)
 		require.Error(t, err)
 	})
 }
@@ -176,6 +181,7 @@ func TestDeactivate(t *testing.T) {
 		storeMock.On("UpdateMfaSecret", userID, "").Return(func(userId string, secret string) error {
 			return nil
 		})
+		storeMock.On("StoreMfaRecoveryCodes", userID, []string(nil)).Return(nil).Once()
 
 		err := New(&storeMock).Deactivate(userID)
 		require.NoError(t, err)
@@ -190,7 +196,7 @@ func TestValidateToken(t *testing.T) {
 
 		usMock := mocks.UserStore{}
 		usMock.On("GetMfaUsedTimestamps", u.Id).Return([]int{}, nil).Once()
-		ok, err := New(&usMock).ValidateToken(u, "invalid-token")
+		ok, _, err := New(&usMock).ValidateToken(u, "invalid-token")
 		require.Error(t, err)
 		require.False(t, ok)
 		require.Contains(t, err.Error(), "unable to parse the token")
@@ -207,9 +213,10 @@ func TestValidateToken(t *testing.T) {
 		usMock.On("GetMfaUsedTimestamps", u.Id).Return([]int{}, nil).Once()
 		usMock.On("StoreMfaUsedTimestamps", u.Id, mock.AnythingOfType("[]int")).Return(nil).Once()
 
-		ok, err := New(&usMock).ValidateToken(u, code)
+		ok, left, err := New(&usMock).ValidateToken(u, code)
 		require.NoError(t, err)
 		require.True(t, ok)
+		require.Equal(t, NoRecoveryCodeUsed, left)
 	})
 
 	t.Run("disallow reuse of totp", func(t *testing.T) {
@@ -223,12 +230,87 @@ func TestValidateToken(t *testing.T) {
 		usMock := mocks.UserStore{}
 		usMock.On("GetMfaUsedTimestamps", u.Id).Return([]int{int(t0)}, nil).Once()
 
-		ok, err := New(&usMock).ValidateToken(u, code)
+		ok, _, err := New(&usMock).ValidateToken(u, code)
 		require.False(t, ok)
 		require.NoError(t, err)
 	})
 }
 
+func TestRecoveryCodes(t *testing.T) {
+	t.Run("generate", func(t *testing.T) {
+		userID := model.NewId()
+
+		var hashes []string
+		usMock := mocks.UserStore{}
+		usMock.On("StoreMfaRecoveryCodes", userID, mock.AnythingOfType("[]string")).Run(func(args mock.Arguments) {
+			hashes = args.Get(1).([]string)
+		}).Return(nil).Once()
+
+		codes, err := New(&usMock).GenerateRecoveryCodes(userID)
+		require.NoError(t, err)
+		require.Len(t, codes, RecoveryCodeCount)
+		require.Len(t, hashes, RecoveryCodeCount)
+		for i, code := range codes {
+			assert.Regexp(t, `^[a-z0-9]{4}-[a-z0-9]{4}-[a-z0-9]{4}-[a-z0-9]{4}$`, code)
+			assert.Equal(t, hashRecoveryCode(normalizeRecoveryCode(code)), hashes[i])
+			assert.NotContains(t, hashes[i], normalizeRecoveryCode(code))
+		}
+	})
+
+	t.Run("fail on store action fail", func(t *testing.T) {
+		usMock := mocks.UserStore{}
+		usMock.On("StoreMfaRecoveryCodes", "user-id", mock.AnythingOfType("[]string")).Return(errors.New("failed")).Once()
+
+		_, err := New(&usMock).GenerateRecoveryCodes("user-id")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unable to store the recovery codes")
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		u := &model.User{Id: model.NewId(), MfaSecret: newRandomBase32String(mfaSecretSize)}
+		code := "Abcd-efgh-1345-6789"
+		hash := hashRecoveryCode("abcdefgh13456789")
+
+		usMock := mocks.UserStore{}
+		usMock.On("UseMfaRecoveryCode", u.Id, hash).Return(true, nil).Once()
+		usMock.On("CountMfaRecoveryCodes", u.Id).Return(int64(2), nil).Once()
+
+		ok, left, err := New(&usMock).ValidateToken(u, code)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, 2, left)
+
+		usMock.On("UseMfaRecoveryCode", u.Id, hash).Return(false, nil).Once()
+
+		ok, left, err = New(&usMock).ValidateToken(u, code)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Equal(t, NoRecoveryCodeUsed, left)
+	})
+
+	t.Run("without a TOTP secret", func(t *testing.T) {
+		u := &model.User{Id: model.NewId()}
+
+		usMock := mocks.UserStore{}
+		usMock.On("UseMfaRecoveryCode", u.Id, mock.AnythingOfType("string")).Return(true, nil).Once()
+		usMock.On("CountMfaRecoveryCodes", u.Id).Return(int64(0), nil).Once()
+
+		ok, left, err := New(&usMock).ValidateToken(u, "abcd efgh 1345 6789")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Zero(t, left)
+	})
+}
+
+func TestIsRecoveryCode(t *testing.T) {
+	assert.True(t, IsRecoveryCode("abcd-efgh-1345-6789"))
+	assert.True(t, IsRecoveryCode(" ABCD EFGH 1345 6789 "))
+	assert.False(t, IsRecoveryCode("123456"))
+	assert.False(t, IsRecoveryCode("abcd-efgh-1234"))
+	assert.False(t, IsRecoveryCode("abcd-efgh-1345-678l"))
+	assert.False(t, IsRecoveryCode(`{"id":"abcdefgh13456789"}`))
+}
+
 func TestRandomBase32String(t *testing.T) {
 	for i := 0; i < 1000; i++ {
 		str := newRandomBase32String(i)
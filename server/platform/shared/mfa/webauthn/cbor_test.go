@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webauthn
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	// Examples from RFC 8949, appendix A.
+	for _, tc := range []struct {
+		encoded  string
+		expected any
+	}{
+		{"00", int64(0)},
+		{"17", int64(23)},
+		{"1818", int64(24)},
+		{"1903e8", int64(1000)},
+		{"1a000f4240", int64(1000000)},
+		{"20", int64(-1)},
+		{"3903e7", int64(-1000)},
+		{"4401020304", []byte{1, 2, 3, 4}},
+		{"6449455446", "IETF"},
+		{"83010203", []any{int64(1), int64(2), int64(3)}},
+		{"a201020304", cborMap{int64(1): int64(2), int64(3): int64(4)}},
+		{"a26161016162820203", cborMap{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+		{"f4", false},
+		{"f5", true},
+		{"f6", nil},
+		{"f93c00", float64(1)},
+		{"c074323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z"},
+	} {
+		t.Run(tc.encoded, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.encoded)
+			require.NoError(t, err)
+
+			decoded, rest, err := decodeCBOR(data)
+			require.NoError(t, err)
+			assert.Empty(t, rest)
+			assert.Equal(t, tc.expected, decoded)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, encoded := range []string{
+			"",
+			"19",         // truncated argument
+			"45010203",   // truncated byte string
+			"9a7fffffff", // array longer than the data
+			"5f",         // indefinite length
+			"a2010201",   // truncated map
+			"a201020103", // duplicate key
+			"a1800102",   // array key
+		} {
+			data, err := hex.DecodeString(encoded)
+			require.NoError(t, err)
+
+			_, _, err = decodeCBOR(data)
+			assert.Error(t, err, encoded)
+		}
+	})
+
+	t.Run("too deeply nested", func(t *testing.T) {
+		data := make([]byte, cborMaxDepth+2)
+		for i := range data {
+			data[i] = 0x81
+		}
+		data[len(data)-1] = 0x00
+
+		_, _, err := decodeCBOR(data)
+		assert.Error(t, err)
+	})
+
+	t.Run("returns the remaining data", func(t *testing.T) {
+		decoded, rest, err := decodeCBOR([]byte{0x01, 0x02})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), decoded)
+		assert.Equal(t, []byte{0x02}, rest)
+	})
+}
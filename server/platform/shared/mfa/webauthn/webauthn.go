@@ -0,0 +1,459 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webauthn implements the relying party side of the WebAuthn registration and
+// authentication ceremonies, so that security keys and passkeys can be used as a second factor.
+//
+// Only the parts of the specification a second factor needs are implemented: the none and
+// packed self attestation formats, the ES256, EdDSA and RS256 algorithms, and a decoder for the
+// definite-length CBOR the authenticators produce. Attestation certificate chains, the metadata
+// service and the TPM and Android formats are not, which is what libraries such as go-webauthn
+// would bring along with their dependencies. The verification follows section 7 of the WebAuthn
+// Level 2 recommendation step by step, and webauthntest runs the ceremonies against it.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var (
+	// ErrInvalidSignature indicates that a signature did not verify.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSignCountRegressed indicates that the signature counter of an authenticator did not
+	// increase, which may mean that the credential has been cloned.
+	ErrSignCountRegressed = errors.New("the signature counter did not increase")
+)
+
+const (
+	DefaultTimeout = 5 * time.Minute
+
+	credentialType = "public-key"
+
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+
+	attestationFormatNone   = "none"
+	attestationFormatPacked = "packed"
+
+	flagUserPresent            = 0x01
+	flagAttestedCredentialData = 0x40
+	flagExtensionData          = 0x80
+
+	// The authenticator data starts with the RP ID hash, the flags and the signature counter.
+	authenticatorDataMinLength = sha256.Size + 1 + 4
+	aaguidLength               = 16
+)
+
+// Config identifies the relying party to the authenticators.
+type Config struct {
+	// RPID is the domain the credentials are scoped to.
+	RPID   string
+	RPName string
+	// Origin is the only origin the ceremonies are accepted from.
+	Origin  string
+	Timeout time.Duration
+}
+
+// NewConfig returns the configuration of a relying party served from siteURL.
+func NewConfig(siteURL, rpName string) (*Config, error) {
+	u, err := url.Parse(siteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the site URL")
+	}
+	if u.Hostname() == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, errors.Errorf("invalid site URL %q", siteURL)
+	}
+
+	return &Config{
+		RPID:    u.Hostname(),
+		RPName:  rpName,
+		Origin:  u.Scheme + "://" + u.Host,
+		Timeout: DefaultTimeout,
+	}, nil
+}
+
+// Credential is a credential whose registration has been verified.
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	AAGUID    []byte
+	SignCount uint32
+}
+
+// EncodeID returns the base64url encoding of a binary value, as used for the credential IDs.
+func EncodeID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+func credentialDescriptors(credentials []*model.WebAuthnCredential) []model.WebAuthnCredentialDescriptor {
+	descriptors := make([]model.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, model.WebAuthnCredentialDescriptor{Type: credentialType, Id: credential.CredentialId})
+	}
+	return descriptors
+}
+
+// CreationOptions returns the options to register a new credential for a user. The existing
+// credentials of the user are excluded so that an authenticator is not registered twice.
+func (c *Config) CreationOptions(challenge []byte, user *model.User, existing []*model.WebAuthnCredential) *model.WebAuthnCredentialCreationOptions {
+	params := make([]model.WebAuthnCredentialParameter, 0, len(supportedAlgorithms))
+	for _, algorithm := range supportedAlgorithms {
+		params = append(params, model.WebAuthnCredentialParameter{Type: credentialType, Alg: algorithm})
+	}
+
+	displayName := user.GetFullName()
+	if displayName == "" {
+		displayName = user.Username
+	}
+
+	return &model.WebAuthnCredentialCreationOptions{
+		Challenge: EncodeID(challenge),
+		RP:        model.WebAuthnRelyingParty{Id: c.RPID, Name: c.RPName},
+		User: model.WebAuthnUserEntity{
+			Id:          EncodeID([]byte(user.Id)),
+			Name:        user.Username,
+			DisplayName: displayName,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            c.Timeout.Milliseconds(),
+		ExcludeCredentials: credentialDescriptors(existing),
+		// The credentials are a second factor, the password already identifies the user.
+		AuthenticatorSelection: model.WebAuthnAuthenticatorSelection{
+			ResidentKey:      "discouraged",
+			UserVerification: "discouraged",
+		},
+		Attestation: attestationFormatNone,
+	}
+}
+
+// RequestOptions returns the options to sign in with one of the given credentials.
+func (c *Config) RequestOptions(challenge []byte, credentials []*model.WebAuthnCredential) *model.WebAuthnCredentialRequestOptions {
+	return &model.WebAuthnCredentialRequestOptions{
+		Challenge:        EncodeID(challenge),
+		Timeout:          c.Timeout.Milliseconds(),
+		RPId:             c.RPID,
+		AllowCredentials: credentialDescriptors(credentials),
+		UserVerification: "discouraged",
+	}
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+func parseClientData(encoded string) (*clientData, []byte, error) {
+	raw, err := decodeBase64URL(encoded)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode the client data")
+	}
+
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse the client data")
+	}
+	return &data, raw, nil
+}
+
+// Challenge returns the challenge that the client signed, so that the ceremony it belongs to
+// can be found.
+func Challenge(clientDataJSON string) ([]byte, error) {
+	data, _, err := parseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := decodeBase64URL(data.Challenge)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the challenge")
+	}
+	return challenge, nil
+}
+
+// verifyClientData checks the client data of a ceremony, and returns its hash.
+func (c *Config) verifyClientData(encoded, ceremony string, challenge []byte) ([]byte, error) {
+	data, raw, err := parseClientData(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Type != ceremony {
+		return nil, errors.Errorf("unexpected client data type %q", data.Type)
+	}
+
+	signed, err := decodeBase64URL(data.Challenge)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the challenge")
+	}
+	if subtle.ConstantTimeCompare(signed, challenge) != 1 {
+		return nil, errors.New("the challenge does not match")
+	}
+
+	if data.Origin != c.Origin || data.CrossOrigin {
+		return nil, errors.Errorf("unexpected origin %q", data.Origin)
+	}
+
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
+
+type authenticatorData struct {
+	raw          []byte
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < authenticatorDataMinLength {
+		return nil, errors.New("the authenticator data is too short")
+	}
+
+	data := &authenticatorData{
+		raw:       raw,
+		rpIDHash:  raw[:sha256.Size],
+		flags:     raw[sha256.Size],
+		signCount: binary.BigEndian.Uint32(raw[sha256.Size+1:]),
+	}
+	rest := raw[authenticatorDataMinLength:]
+
+	if data.flags&flagAttestedCredentialData != 0 {
+		if len(rest) < aaguidLength+2 {
+			return nil, errors.New("the attested credential data is too short")
+		}
+		data.aaguid = rest[:aaguidLength]
+		idLength := int(binary.BigEndian.Uint16(rest[aaguidLength:]))
+		rest = rest[aaguidLength+2:]
+		if len(rest) < idLength {
+			return nil, errors.New("the credential ID is truncated")
+		}
+		data.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		_, afterKey, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode the credential public key")
+		}
+		data.publicKey = rest[:len(rest)-len(afterKey)]
+		rest = afterKey
+	}
+
+	if data.flags&flagExtensionData != 0 {
+		var err error
+		if _, rest, err = decodeCBOR(rest); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the extensions")
+		}
+	}
+
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after the authenticator data")
+	}
+
+	return data, nil
+}
+
+func (c *Config) verifyAuthenticatorData(data *authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(c.RPID))
+	if !bytes.Equal(data.rpIDHash, rpIDHash[:]) {
+		return errors.New("the credential is scoped to another relying party")
+	}
+
+	if data.flags&flagUserPresent == 0 {
+		return errors.New("the user was not present")
+	}
+
+	return nil
+}
+
+// VerifyRegistration verifies the response of an authenticator to the creation options with
+// the given challenge, and returns the new credential.
+func (c *Config) VerifyRegistration(challenge []byte, response *model.WebAuthnAttestationResponse) (*Credential, error) {
+	if response == nil || response.Type != credentialType {
+		return nil, errors.New("unexpected credential type")
+	}
+
+	clientDataHash, err := c.verifyClientData(response.Response.ClientDataJSON, clientDataTypeCreate, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	rawAttestation, err := decodeBase64URL(response.Response.AttestationObject)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the attestation object")
+	}
+	decoded, rest, err := decodeCBOR(rawAttestation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the attestation object")
+	}
+	attestation, ok := decoded.(cborMap)
+	if !ok || len(rest) > 0 {
+		return nil, errors.New("invalid attestation object")
+	}
+	format, _ := attestation["fmt"].(string)
+	statement, _ := attestation["attStmt"].(cborMap)
+	rawAuthData, _ := attestation["authData"].([]byte)
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttestedCredentialData == 0 {
+		return nil, errors.New("the authenticator data has no credential")
+	}
+
+	if id, err := decodeBase64URL(response.Id); err != nil || !bytes.Equal(id, authData.credentialID) {
+		return nil, errors.New("the credential ID does not match the authenticator data")
+	}
+	if len(EncodeID(authData.credentialID)) > model.WebAuthnCredentialIdMaxLength {
+		return nil, errors.New("the credential ID is too long")
+	}
+
+	key, err := parsePublicKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash...)
+	if err := verifyAttestationStatement(format, statement, key, signed); err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:        authData.credentialID,
+		PublicKey: authData.publicKey,
+		AAGUID:    authData.aaguid,
+		SignCount: authData.signCount,
+	}, nil
+}
+
+// verifyAttestationStatement checks the signature of the attestation statement. Since no
+// attestation is requested, the certificate of a full attestation is not checked against a
+// list of trusted authenticators.
+func verifyAttestationStatement(format string, statement cborMap, key *publicKey, signed []byte) error {
+	switch format {
+	case attestationFormatNone:
+		if len(statement) > 0 {
+			return errors.New("unexpected attestation statement")
+		}
+		return nil
+	case attestationFormatPacked:
+		algorithm, _ := statement["alg"].(int64)
+		signature, _ := statement["sig"].([]byte)
+
+		chain, ok := statement["x5c"].([]any)
+		if !ok {
+			// A self attestation is signed with the credential itself.
+			if algorithm != key.algorithm {
+				return errors.New("the attestation algorithm does not match the credential")
+			}
+			return key.verify(signed, signature)
+		}
+
+		if len(chain) == 0 {
+			return errors.New("empty attestation certificate chain")
+		}
+		rawCert, _ := chain[0].([]byte)
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse the attestation certificate")
+		}
+		var signatureAlgorithm x509.SignatureAlgorithm
+		switch algorithm {
+		case AlgES256:
+			signatureAlgorithm = x509.ECDSAWithSHA256
+		case AlgEdDSA:
+			signatureAlgorithm = x509.PureEd25519
+		case AlgRS256:
+			signatureAlgorithm = x509.SHA256WithRSA
+		default:
+			return errors.Errorf("unsupported attestation algorithm %d", algorithm)
+		}
+		if err := cert.CheckSignature(signatureAlgorithm, signed, signature); err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported attestation format %q", format)
+	}
+}
+
+// VerifyAssertion verifies the response of an authenticator to the request options with the
+// given challenge, and returns the new signature counter of the credential.
+func (c *Config) VerifyAssertion(challenge []byte, response *model.WebAuthnAssertionResponse, credential *model.WebAuthnCredential) (uint32, error) {
+	if response == nil || response.Type != credentialType {
+		return 0, errors.New("unexpected credential type")
+	}
+
+	if response.Id != credential.CredentialId {
+		return 0, errors.New("the assertion is for another credential")
+	}
+
+	if response.Response.UserHandle != "" {
+		userHandle, err := decodeBase64URL(response.Response.UserHandle)
+		if err != nil || string(userHandle) != credential.UserId {
+			return 0, errors.New("the assertion is for another user")
+		}
+	}
+
+	clientDataHash, err := c.verifyClientData(response.Response.ClientDataJSON, clientDataTypeGet, challenge)
+	if err != nil {
+		return 0, err
+	}
+
+	rawAuthData, err := decodeBase64URL(response.Response.AuthenticatorData)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to decode the authenticator data")
+	}
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.verifyAuthenticatorData(authData); err != nil {
+		return 0, err
+	}
+
+	signature, err := decodeBase64URL(response.Response.Signature)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to decode the signature")
+	}
+	key, err := parsePublicKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash...)
+	if err := key.verify(signed, signature); err != nil {
+		return 0, err
+	}
+
+	// Authenticators that do not implement the counter always return zero.
+	if (authData.signCount != 0 || credential.SignCount != 0) && int64(authData.signCount) <= credential.SignCount {
+		return 0, ErrSignCountRegressed
+	}
+
+	return authData.signCount, nil
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webauthn_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn/webauthntest"
+)
+
+const testOrigin = "https://chat.example.com"
+
+func newTestConfig(t *testing.T) *webauthn.Config {
+	config, err := webauthn.NewConfig(testOrigin+"/subpath", "Mattermost")
+	require.NoError(t, err)
+	return config
+}
+
+// register registers a credential of the authenticator, and returns it as it would be stored.
+func register(t *testing.T, config *webauthn.Config, authenticator *webauthntest.SoftwareAuthenticator, user *model.User) *model.WebAuthnCredential {
+	challenge := []byte(model.NewRandomString(model.TokenSize))
+	response, err := authenticator.Register(config.CreationOptions(challenge, user, nil))
+	require.NoError(t, err)
+
+	credential, err := config.VerifyRegistration(challenge, response)
+	require.NoError(t, err)
+
+	return &model.WebAuthnCredential{
+		Id:           model.NewId(),
+		UserId:       user.Id,
+		CredentialId: webauthn.EncodeID(credential.ID),
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	config, err := webauthn.NewConfig("https://chat.example.com:8443/team", "Mattermost")
+	require.NoError(t, err)
+	assert.Equal(t, "chat.example.com", config.RPID)
+	assert.Equal(t, "https://chat.example.com:8443", config.Origin)
+	assert.Equal(t, "Mattermost", config.RPName)
+
+	for _, siteURL := range []string{"", "chat.example.com", "ftp://chat.example.com", "://"} {
+		_, err = webauthn.NewConfig(siteURL, "Mattermost")
+		assert.Error(t, err, siteURL)
+	}
+}
+
+func TestCeremonies(t *testing.T) {
+	config := newTestConfig(t)
+	user := &model.User{Id: model.NewId(), Username: "alice", FirstName: "Alice"}
+
+	for name, tc := range map[string]struct {
+		algorithm       int
+		selfAttestation bool
+	}{
+		"ES256":                  {algorithm: webauthn.AlgES256},
+		"EdDSA":                  {algorithm: webauthn.AlgEdDSA},
+		"RS256":                  {algorithm: webauthn.AlgRS256},
+		"ES256 self attestation": {algorithm: webauthn.AlgES256, selfAttestation: true},
+		"EdDSA self attestation": {algorithm: webauthn.AlgEdDSA, selfAttestation: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+			authenticator.Algorithm = tc.algorithm
+			authenticator.SelfAttestation = tc.selfAttestation
+
+			credential := register(t, config, authenticator, user)
+
+			for range 2 {
+				challenge := []byte(model.NewRandomString(model.TokenSize))
+				response, err := authenticator.Login(config.RequestOptions(challenge, []*model.WebAuthnCredential{credential}))
+				require.NoError(t, err)
+
+				signed, err := webauthn.Challenge(response.Response.ClientDataJSON)
+				require.NoError(t, err)
+				assert.Equal(t, challenge, signed)
+
+				signCount, err := config.VerifyAssertion(challenge, response, credential)
+				require.NoError(t, err)
+				assert.Greater(t, int64(signCount), credential.SignCount)
+				credential.SignCount = int64(signCount)
+			}
+		})
+	}
+}
+
+func TestCreationOptions(t *testing.T) {
+	config := newTestConfig(t)
+	user := &model.User{Id: model.NewId(), Username: "alice"}
+	existing := &model.WebAuthnCredential{CredentialId: "AQID"}
+
+	options := config.CreationOptions([]byte("challenge"), user, []*model.WebAuthnCredential{existing})
+	assert.Equal(t, webauthn.EncodeID([]byte("challenge")), options.Challenge)
+	assert.Equal(t, model.WebAuthnRelyingParty{Id: "chat.example.com", Name: "Mattermost"}, options.RP)
+	assert.Equal(t, webauthn.EncodeID([]byte(user.Id)), options.User.Id)
+	assert.Equal(t, "alice", options.User.DisplayName)
+	assert.Equal(t, []model.WebAuthnCredentialDescriptor{{Type: "public-key", Id: "AQID"}}, options.ExcludeCredentials)
+	assert.Len(t, options.PubKeyCredParams, 3)
+	assert.Equal(t, int64(300000), options.Timeout)
+
+	// The options are passed to the browser as they are.
+	data, err := json.Marshal(options)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"pubKeyCredParams":[{"type":"public-key","alg":-7}`)
+
+	authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+	response, err := authenticator.Register(options)
+	require.NoError(t, err)
+	_, err = authenticator.Register(config.CreationOptions([]byte("challenge"), user, []*model.WebAuthnCredential{{CredentialId: response.Id}}))
+	assert.Error(t, err, "an authenticator is not registered twice")
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	config := newTestConfig(t)
+	user := &model.User{Id: model.NewId(), Username: "alice"}
+	challenge := []byte(model.NewRandomString(model.TokenSize))
+
+	newResponse := func(t *testing.T, authenticator *webauthntest.SoftwareAuthenticator) *model.WebAuthnAttestationResponse {
+		response, err := authenticator.Register(config.CreationOptions(challenge, user, nil))
+		require.NoError(t, err)
+		return response
+	}
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		response := newResponse(t, webauthntest.NewSoftwareAuthenticator(testOrigin))
+		_, err := config.VerifyRegistration([]byte(model.NewRandomString(model.TokenSize)), response)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		response := newResponse(t, webauthntest.NewSoftwareAuthenticator("https://evil.example.com"))
+		_, err := config.VerifyRegistration(challenge, response)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong relying party", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		options := config.CreationOptions(challenge, user, nil)
+		options.RP.Id = "example.com"
+		response, err := authenticator.Register(options)
+		require.NoError(t, err)
+
+		_, err = config.VerifyRegistration(challenge, response)
+		assert.Error(t, err)
+	})
+
+	t.Run("assertion client data", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		response := newResponse(t, authenticator)
+		assertion, err := authenticator.Login(config.RequestOptions(challenge, []*model.WebAuthnCredential{{CredentialId: response.Id}}))
+		require.NoError(t, err)
+
+		response.Response.ClientDataJSON = assertion.Response.ClientDataJSON
+		_, err = config.VerifyRegistration(challenge, response)
+		assert.Error(t, err)
+	})
+
+	t.Run("mismatched credential ID", func(t *testing.T) {
+		response := newResponse(t, webauthntest.NewSoftwareAuthenticator(testOrigin))
+		response.Id = webauthn.EncodeID([]byte("another credential"))
+		_, err := config.VerifyRegistration(challenge, response)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid attestation object", func(t *testing.T) {
+		response := newResponse(t, webauthntest.NewSoftwareAuthenticator(testOrigin))
+		response.Response.AttestationObject = webauthn.EncodeID([]byte{0xa1, 0x01})
+		_, err := config.VerifyRegistration(challenge, response)
+		assert.Error(t, err)
+	})
+
+	t.Run("forged self attestation", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		authenticator.SelfAttestation = true
+		response := newResponse(t, authenticator)
+
+		// Change the client data after the authenticator signed it.
+		raw, err := base64.RawURLEncoding.DecodeString(response.Response.ClientDataJSON)
+		require.NoError(t, err)
+		var clientData map[string]any
+		require.NoError(t, json.Unmarshal(raw, &clientData))
+		clientData["tokenBinding"] = "forged"
+		data, err := json.Marshal(clientData)
+		require.NoError(t, err)
+		response.Response.ClientDataJSON = webauthn.EncodeID(data)
+
+		_, err = config.VerifyRegistration(challenge, response)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	config := newTestConfig(t)
+	user := &model.User{Id: model.NewId(), Username: "alice"}
+
+	newAssertion := func(t *testing.T, authenticator *webauthntest.SoftwareAuthenticator, credential *model.WebAuthnCredential) ([]byte, *model.WebAuthnAssertionResponse) {
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+		response, err := authenticator.Login(config.RequestOptions(challenge, []*model.WebAuthnCredential{credential}))
+		require.NoError(t, err)
+		return challenge, response
+	}
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		_, response := newAssertion(t, authenticator, credential)
+
+		_, err := config.VerifyAssertion([]byte(model.NewRandomString(model.TokenSize)), response, credential)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		authenticator.Origin = "https://chat.example.com.evil.example.com"
+		challenge, response := newAssertion(t, authenticator, credential)
+
+		_, err := config.VerifyAssertion(challenge, response, credential)
+		assert.Error(t, err)
+	})
+
+	t.Run("another credential", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		other := register(t, config, webauthntest.NewSoftwareAuthenticator(testOrigin), user)
+		challenge, response := newAssertion(t, authenticator, credential)
+
+		_, err := config.VerifyAssertion(challenge, response, other)
+		assert.Error(t, err)
+
+		// Even when the ID is replaced, the signature does not verify with the other key.
+		response.Id = other.CredentialId
+		_, err = config.VerifyAssertion(challenge, response, other)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		challenge, response := newAssertion(t, authenticator, credential)
+
+		credential.UserId = model.NewId()
+		_, err := config.VerifyAssertion(challenge, response, credential)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		challenge, response := newAssertion(t, authenticator, credential)
+
+		signature, err := base64.RawURLEncoding.DecodeString(response.Response.Signature)
+		require.NoError(t, err)
+		signature[len(signature)-1] ^= 0xff
+		response.Response.Signature = webauthn.EncodeID(signature)
+
+		_, err = config.VerifyAssertion(challenge, response, credential)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+	})
+
+	t.Run("user not present", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		challenge, response := newAssertion(t, authenticator, credential)
+
+		authData, err := base64.RawURLEncoding.DecodeString(response.Response.AuthenticatorData)
+		require.NoError(t, err)
+		// Clear the user present flag, after the 32 bytes of the RP ID hash.
+		authData[32] &^= 0x01
+		response.Response.AuthenticatorData = webauthn.EncodeID(authData)
+
+		_, err = config.VerifyAssertion(challenge, response, credential)
+		assert.Error(t, err)
+	})
+
+	t.Run("cloned authenticator", func(t *testing.T) {
+		authenticator := webauthntest.NewSoftwareAuthenticator(testOrigin)
+		credential := register(t, config, authenticator, user)
+		clone := authenticator.Clone()
+
+		challenge, response := newAssertion(t, authenticator, credential)
+		signCount, err := config.VerifyAssertion(challenge, response, credential)
+		require.NoError(t, err)
+		credential.SignCount = int64(signCount)
+
+		challenge, response = newAssertion(t, clone, credential)
+		_, err = config.VerifyAssertion(challenge, response, credential)
+		assert.ErrorIs(t, err, webauthn.ErrSignCountRegressed)
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webauthntest provides a software authenticator to run the WebAuthn ceremonies in tests.
+package webauthntest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthn"
+)
+
+// The constants of the WebAuthn and COSE specifications used by the authenticator.
+const (
+	credentialType = "public-key"
+
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+
+	attestationFormatNone   = "none"
+	attestationFormatPacked = "packed"
+
+	flagUserPresent            = 0x01
+	flagAttestedCredentialData = 0x40
+
+	aaguidLength = 16
+
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1
+	coseKeyX         = -2
+	coseKeyY         = -3
+	coseKeyRSAN      = -1
+	coseKeyRSAE      = -2
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	rsaKeyBits = 2048
+)
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// SoftwareAuthenticator is an authenticator that keeps its credentials in memory. It runs the
+// client side of the ceremonies in tests, the way a browser and a security key would.
+type SoftwareAuthenticator struct {
+	// Origin is the origin of the page running the ceremonies.
+	Origin string
+	// Algorithm is the COSE algorithm of the new credentials, ES256 by default.
+	Algorithm int
+	// SelfAttestation makes the registrations return a packed self attestation instead of none.
+	SelfAttestation bool
+	AAGUID          []byte
+
+	credentials []*softwareCredential
+}
+
+type softwareCredential struct {
+	id         []byte
+	rpID       string
+	userHandle []byte
+	algorithm  int
+	key        crypto.Signer
+	signCount  uint32
+}
+
+func NewSoftwareAuthenticator(origin string) *SoftwareAuthenticator {
+	return &SoftwareAuthenticator{
+		Origin:    origin,
+		Algorithm: webauthn.AlgES256,
+		AAGUID:    make([]byte, aaguidLength),
+	}
+}
+
+// Clone returns an authenticator holding copies of the credentials of a, as if its keys had
+// been extracted.
+func (a *SoftwareAuthenticator) Clone() *SoftwareAuthenticator {
+	clone := *a
+	clone.credentials = make([]*softwareCredential, 0, len(a.credentials))
+	for _, credential := range a.credentials {
+		copied := *credential
+		clone.credentials = append(clone.credentials, &copied)
+	}
+	return &clone
+}
+
+func (a *SoftwareAuthenticator) clientData(ceremony, challenge string) ([]byte, error) {
+	return json.Marshal(clientData{Type: ceremony, Challenge: challenge, Origin: a.Origin})
+}
+
+func (a *SoftwareAuthenticator) authenticatorData(rpID string, flags byte, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags|flagUserPresent)
+	return binary.BigEndian.AppendUint32(data, signCount)
+}
+
+// Register creates a credential from the creation options.
+func (a *SoftwareAuthenticator) Register(options *model.WebAuthnCredentialCreationOptions) (*model.WebAuthnAttestationResponse, error) {
+	if !slices.ContainsFunc(options.PubKeyCredParams, func(param model.WebAuthnCredentialParameter) bool {
+		return param.Type == credentialType && param.Alg == a.Algorithm
+	}) {
+		return nil, errors.Errorf("algorithm %d is not allowed", a.Algorithm)
+	}
+	for _, excluded := range options.ExcludeCredentials {
+		if a.credential(options.RP.Id, excluded.Id) != nil {
+			return nil, errors.New("the authenticator is already registered")
+		}
+	}
+
+	userHandle, err := base64.RawURLEncoding.DecodeString(options.User.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the user handle")
+	}
+
+	credential := &softwareCredential{
+		id:         make([]byte, 32),
+		rpID:       options.RP.Id,
+		userHandle: userHandle,
+		algorithm:  a.Algorithm,
+	}
+	if _, err := rand.Read(credential.id); err != nil {
+		return nil, errors.Wrap(err, "failed to generate the credential ID")
+	}
+
+	coseKey := cborMap{int64(coseKeyAlgorithm): int64(a.Algorithm)}
+	switch a.Algorithm {
+	case webauthn.AlgES256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate the key")
+		}
+		credential.key = key
+		coseKey[int64(coseKeyType)] = int64(coseKeyTypeEC2)
+		coseKey[int64(coseKeyCurve)] = int64(coseCurveP256)
+		coseKey[int64(coseKeyX)] = key.X.FillBytes(make([]byte, 32))
+		coseKey[int64(coseKeyY)] = key.Y.FillBytes(make([]byte, 32))
+	case webauthn.AlgEdDSA:
+		public, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate the key")
+		}
+		credential.key = key
+		coseKey[int64(coseKeyType)] = int64(coseKeyTypeOKP)
+		coseKey[int64(coseKeyCurve)] = int64(coseCurveEd25519)
+		coseKey[int64(coseKeyX)] = []byte(public)
+	case webauthn.AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate the key")
+		}
+		credential.key = key
+		coseKey[int64(coseKeyType)] = int64(coseKeyTypeRSA)
+		coseKey[int64(coseKeyRSAN)] = key.N.Bytes()
+		coseKey[int64(coseKeyRSAE)] = binary.BigEndian.AppendUint32(nil, uint32(key.E))[1:]
+	default:
+		return nil, errors.Errorf("unsupported algorithm %d", a.Algorithm)
+	}
+
+	encodedKey, err := encodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+	authData := a.authenticatorData(credential.rpID, flagAttestedCredentialData, credential.signCount)
+	authData = append(authData, a.AAGUID...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(credential.id)))
+	authData = append(authData, credential.id...)
+	authData = append(authData, encodedKey...)
+
+	clientDataJSON, err := a.clientData(clientDataTypeCreate, options.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	format, statement := attestationFormatNone, cborMap{}
+	if a.SelfAttestation {
+		signature, err := credential.sign(authData, clientDataJSON)
+		if err != nil {
+			return nil, err
+		}
+		format, statement = attestationFormatPacked, cborMap{"alg": int64(a.Algorithm), "sig": signature}
+	}
+	attestationObject, err := encodeCBOR(cborMap{"fmt": format, "attStmt": statement, "authData": authData})
+	if err != nil {
+		return nil, err
+	}
+
+	a.credentials = append(a.credentials, credential)
+
+	return &model.WebAuthnAttestationResponse{
+		Id:   webauthn.EncodeID(credential.id),
+		Type: credentialType,
+		Response: model.WebAuthnAuthenticatorAttestationResponse{
+			ClientDataJSON:    webauthn.EncodeID(clientDataJSON),
+			AttestationObject: webauthn.EncodeID(attestationObject),
+		},
+	}, nil
+}
+
+// Login signs the request options with the first allowed credential of the authenticator.
+func (a *SoftwareAuthenticator) Login(options *model.WebAuthnCredentialRequestOptions) (*model.WebAuthnAssertionResponse, error) {
+	var credential *softwareCredential
+	for _, allowed := range options.AllowCredentials {
+		if credential = a.credential(options.RPId, allowed.Id); credential != nil {
+			break
+		}
+	}
+	if credential == nil {
+		return nil, errors.New("no allowed credential on the authenticator")
+	}
+
+	credential.signCount++
+	authData := a.authenticatorData(options.RPId, 0, credential.signCount)
+
+	clientDataJSON, err := a.clientData(clientDataTypeGet, options.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := credential.sign(authData, clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebAuthnAssertionResponse{
+		Id:   webauthn.EncodeID(credential.id),
+		Type: credentialType,
+		Response: model.WebAuthnAuthenticatorAssertionResponse{
+			ClientDataJSON:    webauthn.EncodeID(clientDataJSON),
+			AuthenticatorData: webauthn.EncodeID(authData),
+			Signature:         webauthn.EncodeID(signature),
+			UserHandle:        webauthn.EncodeID(credential.userHandle),
+		},
+	}, nil
+}
+
+func (a *SoftwareAuthenticator) credential(rpID, id string) *softwareCredential {
+	for _, credential := range a.credentials {
+		if credential.rpID == rpID && webauthn.EncodeID(credential.id) == id {
+			return credential
+		}
+	}
+	return nil
+}
+
+func (c *softwareCredential) sign(authData, clientDataJSON []byte) ([]byte, error) {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	if c.algorithm == webauthn.AlgEdDSA {
+		return c.key.Sign(rand.Reader, signed, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(signed)
+	return c.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
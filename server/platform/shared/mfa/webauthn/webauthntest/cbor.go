@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webauthntest
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	cborMajorUint   = 0
+	cborMajorNegint = 1
+	cborMajorBytes  = 2
+	cborMajorText   = 3
+	cborMajorArray  = 4
+	cborMajorMap    = 5
+	cborMajorSimple = 7
+)
+
+// cborMap is a CBOR map.
+type cborMap map[any]any
+
+// encodeCBOR encodes a value with the canonical CBOR encoding. It supports the integer, byte
+// string, text string, boolean, array and map types that the authenticators use.
+func encodeCBOR(value any) ([]byte, error) {
+	return appendCBOR(nil, value)
+}
+
+func appendCBORHead(buf []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(buf, major<<5|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(buf, major<<5|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major<<5|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major<<5|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major<<5|27), arg)
+	}
+}
+
+func appendCBOR(buf []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case int:
+		return appendCBOR(buf, int64(v))
+	case int64:
+		if v < 0 {
+			return appendCBORHead(buf, cborMajorNegint, uint64(-1-v)), nil
+		}
+		return appendCBORHead(buf, cborMajorUint, uint64(v)), nil
+	case []byte:
+		return append(appendCBORHead(buf, cborMajorBytes, uint64(len(v))), v...), nil
+	case string:
+		return append(appendCBORHead(buf, cborMajorText, uint64(len(v))), v...), nil
+	case bool:
+		if v {
+			return append(buf, cborMajorSimple<<5|21), nil
+		}
+		return append(buf, cborMajorSimple<<5|20), nil
+	case []any:
+		buf = appendCBORHead(buf, cborMajorArray, uint64(len(v)))
+		for _, item := range v {
+			var err error
+			if buf, err = appendCBOR(buf, item); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case cborMap:
+		// The canonical encoding sorts the keys by their encoded bytes.
+		type entry struct {
+			key   []byte
+			value any
+		}
+		entries := make([]entry, 0, len(v))
+		for key, item := range v {
+			encodedKey, err := encodeCBOR(key)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry{encodedKey, item})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			a, b := entries[i].key, entries[j].key
+			if len(a) != len(b) {
+				return len(a) < len(b)
+			}
+			return string(a) < string(b)
+		})
+
+		buf = appendCBORHead(buf, cborMajorMap, uint64(len(v)))
+		for _, e := range entries {
+			var err error
+			buf = append(buf, e.key...)
+			if buf, err = appendCBOR(buf, e.value); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	default:
+		return nil, errors.Errorf("cbor: unsupported type %T", value)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webauthntest
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCBOR(t *testing.T) {
+	value := cborMap{
+		"fmt":     "none",
+		int64(-1): int64(1),
+		int64(3):  int64(-7),
+		"attStmt": cborMap{},
+		"a":       []any{[]byte{1, 2}, true, int64(1000000)},
+	}
+
+	encoded, err := encodeCBOR(value)
+	require.NoError(t, err)
+	// The keys are sorted by length first, then bytewise.
+	assert.Equal(t, "a503262001616183420102f51a000f424063666d74646e6f6e656761747453746d74a0", hex.EncodeToString(encoded))
+
+	_, err = encodeCBOR(1.5)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	AuditLogSearchDefaultPerPage = 60
+	AuditLogSearchMaxPerPage     = 200
+
+	AuditLogEventNameMaxLength  = 128
+	AuditLogActorIdMaxLength    = 128
+	AuditLogObjectTypeMaxLength = 64
+	AuditLogObjectIdMaxLength   = 64
+)
+
+// AuditLog is an audit record written to the database audit target. The
+// searchable parts of the record are stored in their own columns, while Data
+// holds the complete record as JSON.
+type AuditLog struct {
+	Id         string `json:"id"`
+	CreateAt   int64  `json:"create_at"`
+	EventName  string `json:"event_name"`
+	Status     string `json:"status"`
+	ActorId    string `json:"actor_id"`
+	SessionId  string `json:"session_id"`
+	IpAddress  string `json:"ip_address"`
+	ObjectType string `json:"object_type"`
+	ObjectId   string `json:"object_id"`
+	Data       string `json:"data"`
+}
+
+// AuditLogSearchOptions filters the audit logs returned by a search. Empty
+// fields don't filter, and the time range includes both of its ends.
+type AuditLogSearchOptions struct {
+	ActorId    string
+	EventName  string
+	ObjectType string
+	ObjectId   string
+	StartTime  int64
+	EndTime    int64
+	Page       int
+	PerPage    int
+}
+
+func (l *AuditLog) PreSave() {
+	if l.Id == "" {
+		l.Id = NewId()
+	}
+
+	if l.CreateAt == 0 {
+		l.CreateAt = GetMillis()
+	}
+
+	l.EventName = truncateRunes(l.EventName, AuditLogEventNameMaxLength)
+	l.ActorId = truncateRunes(l.ActorId, AuditLogActorIdMaxLength)
+	l.ObjectType = truncateRunes(l.ObjectType, AuditLogObjectTypeMaxLength)
+	l.ObjectId = truncateRunes(l.ObjectId, AuditLogObjectIdMaxLength)
+}
+
+func (l *AuditLog) IsValid() *AppError {
+	if !IsValidId(l.Id) {
+		return NewAppError("AuditLog.IsValid", "model.audit_log.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if l.CreateAt == 0 {
+		return NewAppError("AuditLog.IsValid", "model.audit_log.is_valid.create_at.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if l.EventName == "" {
+		return NewAppError("AuditLog.IsValid", "model.audit_log.is_valid.event_name.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *AuditLogSearchOptions) IsValid() *AppError {
+	if o.StartTime < 0 || o.EndTime < 0 || (o.EndTime > 0 && o.StartTime > o.EndTime) {
+		return NewAppError("AuditLogSearchOptions.IsValid", "model.audit_log_search_options.is_valid.time_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Page < 0 || o.PerPage <= 0 || o.PerPage > AuditLogSearchMaxPerPage {
+		return NewAppError("AuditLogSearchOptions.IsValid", "model.audit_log_search_options.is_valid.paging.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogPreSave(t *testing.T) {
+	log := AuditLog{
+		EventName: "patchChannel",
+		ObjectId:  strings.Repeat("a", AuditLogObjectIdMaxLength+10),
+	}
+	log.PreSave()
+
+	assert.True(t, IsValidId(log.Id))
+	assert.NotZero(t, log.CreateAt)
+	assert.Len(t, log.ObjectId, AuditLogObjectIdMaxLength)
+	require.Nil(t, log.IsValid())
+}
+
+func TestAuditLogIsValid(t *testing.T) {
+	log := AuditLog{Id: NewId(), CreateAt: GetMillis(), EventName: "patchChannel"}
+	require.Nil(t, log.IsValid())
+
+	log.EventName = ""
+	require.NotNil(t, log.IsValid())
+
+	log.EventName = "patchChannel"
+	log.CreateAt = 0
+	require.NotNil(t, log.IsValid())
+
+	log.CreateAt = GetMillis()
+	log.Id = "invalid"
+	require.NotNil(t, log.IsValid())
+}
+
+func TestAuditLogSearchOptionsIsValid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		opts  AuditLogSearchOptions
+		valid bool
+	}{
+		{"no filters", AuditLogSearchOptions{PerPage: AuditLogSearchDefaultPerPage}, true},
+		{"time range", AuditLogSearchOptions{StartTime: 1000, EndTime: 2000, PerPage: 10}, true},
+		{"open ended time range", AuditLogSearchOptions{StartTime: 1000, PerPage: 10}, true},
+		{"inverted time range", AuditLogSearchOptions{StartTime: 2000, EndTime: 1000, PerPage: 10}, false},
+		{"negative time", AuditLogSearchOptions{StartTime: -1, PerPage: 10}, false},
+		{"negative page", AuditLogSearchOptions{Page: -1, PerPage: 10}, false},
+		{"no per page", AuditLogSearchOptions{}, false},
+		{"per page too large", AuditLogSearchOptions{PerPage: AuditLogSearchMaxPerPage + 1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.valid {
+				assert.Nil(t, tc.opts.IsValid())
+			} else {
+				assert.NotNil(t, tc.opts.IsValid())
+			}
+		})
+	}
+}
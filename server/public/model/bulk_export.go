@@ -3,6 +3,8 @@
 
 package model
 
+import "slices"
+
 // ExportDataDir is the name of the directory were to store additional data
 // included with the export (e.g. file attachments).
 const ExportDataDir = "data"
@@ -13,4 +15,52 @@ type BulkExportOpts struct {
 	IncludeArchivedChannels bool
 	IncludeRolesAndSchemes  bool
 	CreateArchive           bool
+
+	BulkExportFilter
+}
+
+// BulkExportFilter restricts a bulk export to some teams and channels, and
+// to the data that changed in a time window. The zero value exports everything.
+type BulkExportFilter struct {
+	// Since and Until bound the time window of an incremental export, in
+	// milliseconds since the epoch. Since is exclusive and Until is inclusive.
+	// A zero Until leaves the window open, and Until is ignored without Since.
+	Since int64
+	Until int64
+
+	// TeamIds and ChannelIds restrict the export to the channels of the given
+	// teams and to the given channels, which can be direct or group messages.
+	TeamIds    []string
+	ChannelIds []string
+}
+
+// IsEmpty returns true if the filter doesn't restrict the export.
+func (f *BulkExportFilter) IsEmpty() bool {
+	return !f.IsIncremental() && !f.HasScope()
+}
+
+// IsIncremental returns true if only the data that changed after a previous
+// export is exported.
+func (f *BulkExportFilter) IsIncremental() bool {
+	return f.Since > 0
+}
+
+// HasScope returns true if the export is restricted to some teams or channels.
+func (f *BulkExportFilter) HasScope() bool {
+	return len(f.TeamIds) > 0 || len(f.ChannelIds) > 0
+}
+
+// InWindow returns true if a change made at the given time falls in the time
+// window of the filter.
+func (f *BulkExportFilter) InWindow(updateAt int64) bool {
+	return updateAt > f.Since && (f.Until == 0 || updateAt <= f.Until)
+}
+
+// IncludesChannel returns true if the channel is in the scope of the filter.
+func (f *BulkExportFilter) IncludesChannel(teamID, channelID string) bool {
+	if !f.HasScope() {
+		return true
+	}
+
+	return slices.Contains(f.TeamIds, teamID) || slices.Contains(f.ChannelIds, channelID)
 }
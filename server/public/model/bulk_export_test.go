@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkExportFilter(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var f BulkExportFilter
+		assert.True(t, f.IsEmpty())
+		assert.False(t, f.IsIncremental())
+		assert.False(t, f.HasScope())
+		assert.True(t, f.InWindow(1))
+		assert.True(t, f.IncludesChannel(NewId(), NewId()))
+	})
+
+	t.Run("window", func(t *testing.T) {
+		f := BulkExportFilter{Since: 100, Until: 200}
+		assert.False(t, f.IsEmpty())
+		assert.True(t, f.IsIncremental())
+		assert.False(t, f.InWindow(100))
+		assert.True(t, f.InWindow(101))
+		assert.True(t, f.InWindow(200))
+		assert.False(t, f.InWindow(201))
+
+		f = BulkExportFilter{Since: 100}
+		assert.True(t, f.InWindow(GetMillis()))
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		teamID := NewId()
+		channelID := NewId()
+		f := BulkExportFilter{TeamIds: []string{teamID}, ChannelIds: []string{channelID}}
+		assert.False(t, f.IsEmpty())
+		assert.False(t, f.IsIncremental())
+		assert.True(t, f.HasScope())
+		assert.True(t, f.IncludesChannel(teamID, NewId()))
+		assert.True(t, f.IncludesChannel(NewId(), channelID))
+		assert.False(t, f.IncludesChannel(NewId(), NewId()))
+	})
+}
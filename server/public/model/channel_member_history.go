@@ -9,3 +9,10 @@ type ChannelMemberHistory struct {
 	JoinTime  int64
 	LeaveTime *int64
 }
+
+// ChannelMemberHistoryForExport is a channel a user left and hasn't joined again.
+type ChannelMemberHistoryForExport struct {
+	ChannelId   string
+	ChannelName string
+	LeaveTime   int64
+}
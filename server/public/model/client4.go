@@ -683,6 +683,53 @@ func (c *Client4) DeleteScheduledPost(ctx context.Context, scheduledPostId strin
 	return &deletedScheduledPost, BuildResponse(r), nil
 }
 
+func (c *Client4) PauseScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/pause", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+
+	defer closeBody(r)
+	var scheduledPost ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPost); err != nil {
+		return nil, nil, NewAppError("PauseScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &scheduledPost, BuildResponse(r), nil
+}
+
+func (c *Client4) ResumeScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/resume", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+
+	defer closeBody(r)
+	var scheduledPost ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPost); err != nil {
+		return nil, nil, NewAppError("ResumeScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &scheduledPost, BuildResponse(r), nil
+}
+
+// GetScheduledPostOccurrences returns the times, in milliseconds, at which a
+// scheduled post will next be sent.
+func (c *Client4) GetScheduledPostOccurrences(ctx context.Context, scheduledPostId string, count int) ([]int64, *Response, error) {
+	query := url.Values{}
+	query.Set("count", strconv.Itoa(count))
+
+	r, err := c.DoAPIGet(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/occurrences?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+
+	defer closeBody(r)
+	var occurrences []int64
+	if err := json.NewDecoder(r.Body).Decode(&occurrences); err != nil {
+		return nil, nil, NewAppError("GetScheduledPostOccurrences", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return occurrences, BuildResponse(r), nil
+}
+
 func (c *Client4) bookmarksRoute(channelId string) string {
 	return c.channelRoute(channelId) + "/bookmarks"
 }
@@ -911,6 +958,34 @@ func (c *Client4) LoginWithMFA(ctx context.Context, loginId, password, mfaToken
 	return c.login(ctx, m)
 }
 
+// BeginWebAuthnLogin checks the password of a user and returns the options to pass to
+// navigator.credentials.get to sign in with one of their security keys.
+func (c *Client4) BeginWebAuthnLogin(ctx context.Context, loginId, password string) (*WebAuthnCredentialRequestOptions, *Response, error) {
+	m := make(map[string]string)
+	m["login_id"] = loginId
+	m["password"] = password
+	r, err := c.DoAPIPost(ctx, "/users/login/webauthn", MapToJSON(m))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var options WebAuthnCredentialRequestOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return nil, nil, NewAppError("BeginWebAuthnLogin", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &options, BuildResponse(r), nil
+}
+
+// LoginWithWebAuthn authenticates a user by login id, password and the credential
+// returned by navigator.credentials.get, which is sent in place of an MFA token.
+func (c *Client4) LoginWithWebAuthn(ctx context.Context, loginId, password string, assertion *WebAuthnAssertionResponse) (*User, *Response, error) {
+	buf, err := json.Marshal(assertion)
+	if err != nil {
+		return nil, nil, NewAppError("LoginWithWebAuthn", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return c.LoginWithMFA(ctx, loginId, password, string(buf))
+}
+
 func (c *Client4) login(ctx context.Context, m map[string]string) (*User, *Response, error) {
 	r, err := c.DoAPIPost(ctx, "/users/login", MapToJSON(m))
 	if err != nil {
@@ -1597,6 +1672,82 @@ func (c *Client4) GenerateMfaSecret(ctx context.Context, userId string) (*MfaSec
 	return &secret, BuildResponse(r), nil
 }
 
+// RegenerateMfaRecoveryCodes replaces the MFA recovery codes of the current user and returns
+// the new ones. The user confirms their identity with either their password or a current MFA code.
+func (c *Client4) RegenerateMfaRecoveryCodes(ctx context.Context, userId, password, code string) (*MfaRecoveryCodes, *Response, error) {
+	requestBody := map[string]string{"password": password, "code": code}
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/mfa/recovery_codes", MapToJSON(requestBody))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var codes MfaRecoveryCodes
+	if err := json.NewDecoder(r.Body).Decode(&codes); err != nil {
+		return nil, nil, NewAppError("RegenerateMfaRecoveryCodes", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &codes, BuildResponse(r), nil
+}
+
+// BeginWebAuthnRegistration returns the options to pass to navigator.credentials.create
+// to register a security key for the current user.
+func (c *Client4) BeginWebAuthnRegistration(ctx context.Context, userId string) (*WebAuthnCredentialCreationOptions, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/webauthn/registration", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var options WebAuthnCredentialCreationOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return nil, nil, NewAppError("BeginWebAuthnRegistration", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &options, BuildResponse(r), nil
+}
+
+// RegisterWebAuthnCredential completes the registration of a security key with the
+// credential returned by navigator.credentials.create. The registration must include the
+// password of the user or a current MFA token.
+func (c *Client4) RegisterWebAuthnCredential(ctx context.Context, userId string, registration *WebAuthnRegistration) (*WebAuthnCredential, *Response, error) {
+	buf, err := json.Marshal(registration)
+	if err != nil {
+		return nil, nil, NewAppError("RegisterWebAuthnCredential", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/webauthn/credentials", string(buf))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var registered WebAuthnCredential
+	if err := json.NewDecoder(r.Body).Decode(&registered); err != nil {
+		return nil, nil, NewAppError("RegisterWebAuthnCredential", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &registered, BuildResponse(r), nil
+}
+
+// GetWebAuthnCredentials returns the security keys registered by a user.
+func (c *Client4) GetWebAuthnCredentials(ctx context.Context, userId string) ([]*WebAuthnCredential, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/webauthn/credentials", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var credentials []*WebAuthnCredential
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		return nil, nil, NewAppError("GetWebAuthnCredentials", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return credentials, BuildResponse(r), nil
+}
+
+// RevokeWebAuthnCredential removes a security key of a user. Removing the last second
+// factor of a user turns MFA off for them.
+func (c *Client4) RevokeWebAuthnCredential(ctx context.Context, userId, credentialId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+"/webauthn/credentials/"+credentialId)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(ctx context.Context, userId, currentPassword, newPassword string) (*Response, error) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -2119,6 +2270,28 @@ func (c *Client4) GetUsersForReporting(ctx context.Context, options *UserReportO
 	return list, BuildResponse(r), nil
 }
 
+// StartBatchReportExport starts a job exporting the given report type. The system bot sends the
+// report to the requesting user in a direct message once it's complete.
+func (c *Client4) StartBatchReportExport(ctx context.Context, reportType string, options *ReportExportOptions) (*Response, error) {
+	values := url.Values{}
+	if options.DateRange != "" {
+		values.Set("date_range", options.DateRange)
+	}
+	if options.Team != "" {
+		values.Set("team_filter", options.Team)
+	}
+	if options.Format != "" {
+		values.Set("format", options.Format)
+	}
+
+	r, err := c.DoAPIPost(ctx, c.reportsRoute()+"/"+url.PathEscape(reportType)+"/export?"+values.Encode(), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Bots section
 
 // CreateBot creates a bot in the system based on the provided bot struct.
@@ -4026,6 +4199,95 @@ func (c *Client4) SetPostReminder(ctx context.Context, reminder *PostReminder) (
 	return BuildResponse(r), nil
 }
 
+// GetPostReminders returns the pending post reminders of a user, soonest first.
+func (c *Client4) GetPostReminders(ctx context.Context, userId string) ([]*PostReminder, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/posts/reminders", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reminders []*PostReminder
+	if err := json.NewDecoder(r.Body).Decode(&reminders); err != nil {
+		return nil, nil, NewAppError("GetPostReminders", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return reminders, BuildResponse(r), nil
+}
+
+// DeletePostReminder cancels the pending reminder of a user about a post.
+func (c *Client4) DeletePostReminder(ctx context.Context, userId, postId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+c.postRoute(postId)+"/reminder")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// CreatePoll creates a poll post.
+func (c *Client4) CreatePoll(ctx context.Context, pollCreate *PollCreate) (*Post, *Response, error) {
+	buf, err := json.Marshal(pollCreate)
+	if err != nil {
+		return nil, nil, NewAppError("CreatePoll", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/polls", string(buf))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var p Post
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return nil, nil, NewAppError("CreatePoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &p, BuildResponse(r), nil
+}
+
+// GetPollResults gets the tally of the poll attached to a post.
+func (c *Client4) GetPollResults(ctx context.Context, postId string) (*PollResults, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/poll", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var results PollResults
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, nil, NewAppError("GetPollResults", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &results, BuildResponse(r), nil
+}
+
+// VotePoll replaces the vote of the current user on a poll. Voting for no
+// options withdraws the vote.
+func (c *Client4) VotePoll(ctx context.Context, postId string, options []int) (*PollResults, *Response, error) {
+	buf, err := json.Marshal(&PollVoteRequest{Options: options})
+	if err != nil {
+		return nil, nil, NewAppError("VotePoll", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPost(ctx, c.postRoute(postId)+"/poll/votes", string(buf))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var results PollResults
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, nil, NewAppError("VotePoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &results, BuildResponse(r), nil
+}
+
+// ClosePoll stops a poll from accepting votes.
+func (c *Client4) ClosePoll(ctx context.Context, postId string) (*PollResults, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postRoute(postId)+"/poll/close", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var results PollResults
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, nil, NewAppError("ClosePoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &results, BuildResponse(r), nil
+}
+
 // PinPost pin a post based on provided post id string.
 func (c *Client4) PinPost(ctx context.Context, postId string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.postRoute(postId)+"/pin", "")
@@ -4943,6 +5205,57 @@ func (c *Client4) GetEnvironmentConfig(ctx context.Context) (map[string]any, *Re
 	return StringInterfaceFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetConfigHistory returns a page of the stored versions of the server configuration, newest first.
+func (c *Client4) GetConfigHistory(ctx context.Context, page, perPage int) ([]*ConfigVersion, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.configRoute()+"/history"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var versions []*ConfigVersion
+	if err := json.NewDecoder(r.Body).Decode(&versions); err != nil {
+		return nil, nil, NewAppError("GetConfigHistory", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return versions, BuildResponse(r), nil
+}
+
+// GetConfigVersionDiff returns the settings that changed between two versions of the server
+// configuration, with the sensitive values masked. An empty toVersionID compares against the
+// configuration in use.
+func (c *Client4) GetConfigVersionDiff(ctx context.Context, fromVersionID, toVersionID string) ([]*ConfigVersionDiff, *Response, error) {
+	values := url.Values{}
+	values.Set("from", fromVersionID)
+	if toVersionID != "" {
+		values.Set("to", toVersionID)
+	}
+	r, err := c.DoAPIGet(ctx, c.configRoute()+"/history/diff?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var diffs []*ConfigVersionDiff
+	if err := json.NewDecoder(r.Body).Decode(&diffs); err != nil {
+		return nil, nil, NewAppError("GetConfigVersionDiff", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return diffs, BuildResponse(r), nil
+}
+
+// RollbackConfig replaces the server configuration with the given version and returns the
+// new configuration.
+func (c *Client4) RollbackConfig(ctx context.Context, versionID string) (*Config, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.configRoute()+"/history/rollback", MapToJSON(map[string]string{"version_id": versionID}))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var cfg *Config
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		return nil, nil, NewAppError("RollbackConfig", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return cfg, BuildResponse(r), nil
+}
+
 // GetOldClientLicense will retrieve the parts of the server license needed by the
 // client, formatted in the old format.
 func (c *Client4) GetOldClientLicense(ctx context.Context, etag string) (map[string]string, *Response, error) {
@@ -5200,6 +5513,25 @@ func (c *Client4) DeleteIncomingWebhook(ctx context.Context, hookID string) (*Re
 	return BuildResponse(r), nil
 }
 
+// DryRunIncomingWebhookTemplate renders a sample payload through the payload
+// template of an incoming webhook without creating a post.
+func (c *Client4) DryRunIncomingWebhookTemplate(ctx context.Context, hookID string, dryRun *IncomingWebhookTemplateDryRunRequest) (*IncomingWebhookRequest, *Response, error) {
+	buf, err := json.Marshal(dryRun)
+	if err != nil {
+		return nil, nil, NewAppError("DryRunIncomingWebhookTemplate", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.incomingWebhookRoute(hookID)+"/template/dry_run", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var iwr IncomingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&iwr); err != nil {
+		return nil, nil, NewAppError("DryRunIncomingWebhookTemplate", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &iwr, BuildResponse(r), nil
+}
+
 // CreateOutgoingWebhook creates an outgoing webhook for a team or channel.
 func (c *Client4) CreateOutgoingWebhook(ctx context.Context, hook *OutgoingWebhook) (*OutgoingWebhook, *Response, error) {
 	buf, err := json.Marshal(hook)
@@ -5318,6 +5650,83 @@ func (c *Client4) RegenOutgoingHookToken(ctx context.Context, hookId string) (*O
 	return &ow, BuildResponse(r), nil
 }
 
+// RegenOutgoingHookSigningSecret generates a new secret to sign the requests of the outgoing webhook with.
+func (c *Client4) RegenOutgoingHookSigningSecret(ctx context.Context, hookId string) (*OutgoingWebhook, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/signing_secret/regen", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ow OutgoingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&ow); err != nil {
+		return nil, nil, NewAppError("RegenOutgoingHookSigningSecret", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &ow, BuildResponse(r), nil
+}
+
+// RemoveOutgoingHookSigningSecret stops signing the requests of the outgoing webhook.
+func (c *Client4) RemoveOutgoingHookSigningSecret(ctx context.Context, hookId string) (*OutgoingWebhook, *Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.outgoingWebhookRoute(hookId)+"/signing_secret")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ow OutgoingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&ow); err != nil {
+		return nil, nil, NewAppError("RemoveOutgoingHookSigningSecret", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &ow, BuildResponse(r), nil
+}
+
+// EnableOutgoingHook re-enables an outgoing webhook that was disabled after failing too many deliveries.
+func (c *Client4) EnableOutgoingHook(ctx context.Context, hookId string) (*OutgoingWebhook, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/enable", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ow OutgoingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&ow); err != nil {
+		return nil, nil, NewAppError("EnableOutgoingHook", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &ow, BuildResponse(r), nil
+}
+
+// GetOutgoingWebhookDeliveries returns a page of delivery attempts for an outgoing webhook,
+// optionally filtered by status.
+func (c *Client4) GetOutgoingWebhookDeliveries(ctx context.Context, hookId string, status string, page int, perPage int) ([]*OutgoingWebhookDelivery, *Response, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+	if status != "" {
+		query.Set("status", status)
+	}
+	r, err := c.DoAPIGet(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var deliveries []*OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&deliveries); err != nil {
+		return nil, nil, NewAppError("GetOutgoingWebhookDeliveries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return deliveries, BuildResponse(r), nil
+}
+
+// ReplayOutgoingWebhookDelivery sends the payload of a failed delivery again and returns the new attempt.
+func (c *Client4) ReplayOutgoingWebhookDelivery(ctx context.Context, hookId string, deliveryId string) (*OutgoingWebhookDelivery, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries/"+deliveryId+"/replay", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var delivery OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&delivery); err != nil {
+		return nil, nil, NewAppError("ReplayOutgoingWebhookDelivery", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &delivery, BuildResponse(r), nil
+}
+
 // DeleteOutgoingWebhook delete the outgoing webhook on the system requested by Hook Id.
 func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Response, error) {
 	r, err := c.DoAPIDelete(ctx, c.outgoingWebhookRoute(hookId))
@@ -5970,6 +6379,43 @@ func (c *Client4) GetAudits(ctx context.Context, page int, perPage int, etag str
 	return audits, BuildResponse(r), nil
 }
 
+// SearchAuditLogs returns the audit records saved by the database audit target that match the options.
+func (c *Client4) SearchAuditLogs(ctx context.Context, opts AuditLogSearchOptions) ([]*AuditLog, *Response, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(opts.Page))
+	query.Set("per_page", strconv.Itoa(opts.PerPage))
+	if opts.ActorId != "" {
+		query.Set("actor_id", opts.ActorId)
+	}
+	if opts.EventName != "" {
+		query.Set("event_name", opts.EventName)
+	}
+	if opts.ObjectType != "" {
+		query.Set("object_type", opts.ObjectType)
+	}
+	if opts.ObjectId != "" {
+		query.Set("object_id", opts.ObjectId)
+	}
+	if opts.StartTime > 0 {
+		query.Set("start_time", strconv.FormatInt(opts.StartTime, 10))
+	}
+	if opts.EndTime > 0 {
+		query.Set("end_time", strconv.FormatInt(opts.EndTime, 10))
+	}
+
+	r, err := c.DoAPIGet(ctx, "/audit_logs?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var logs []*AuditLog
+	if err := json.NewDecoder(r.Body).Decode(&logs); err != nil {
+		return nil, BuildResponse(r), NewAppError("SearchAuditLogs", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return logs, BuildResponse(r), nil
+}
+
 // Brand Section
 
 // GetBrandImage retrieves the previously uploaded brand image.
@@ -6923,6 +7369,48 @@ func (c *Client4) UpdateUserStatus(ctx context.Context, userId string, userStatu
 	return &s, BuildResponse(r), nil
 }
 
+// GetOutOfOffice returns the auto-responder settings and out of office period of a user.
+func (c *Client4) GetOutOfOffice(ctx context.Context, userId string) (*OutOfOffice, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/out_of_office", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ooo OutOfOffice
+	if err := json.NewDecoder(r.Body).Decode(&ooo); err != nil {
+		return nil, nil, NewAppError("GetOutOfOffice", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &ooo, BuildResponse(r), nil
+}
+
+// UpdateOutOfOffice sets the auto-responder settings and out of office period of a user.
+func (c *Client4) UpdateOutOfOffice(ctx context.Context, userId string, ooo *OutOfOffice) (*OutOfOffice, *Response, error) {
+	buf, err := json.Marshal(ooo)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateOutOfOffice", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPutBytes(ctx, c.userRoute(userId)+"/out_of_office", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var updated OutOfOffice
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, nil, NewAppError("UpdateOutOfOffice", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &updated, BuildResponse(r), nil
+}
+
+// DeleteOutOfOffice turns off the auto-responder of a user and cancels their out of office period.
+func (c *Client4) DeleteOutOfOffice(ctx context.Context, userId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+"/out_of_office")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // UpdateUserCustomStatus sets a user's custom status based on the provided user id string.
 // The returned CustomStatus object is the same as the one passed, and it should be just
 // ignored. It's only kept to maintain compatibility.
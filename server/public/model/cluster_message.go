@@ -35,6 +35,10 @@ const (
 	ClusterEventInvalidateCacheForLastPosts                 ClusterEvent = "inv_last_posts"
 	ClusterEventInvalidateCacheForLastPostTime              ClusterEvent = "inv_last_post_time"
 	ClusterEventInvalidateCacheForPostsUsage                ClusterEvent = "inv_posts_usage"
+	ClusterEventInvalidateCacheForThreadMemberships         ClusterEvent = "inv_thread_memberships"
+	ClusterEventInvalidateCacheForPreferences               ClusterEvent = "inv_preferences"
+	ClusterEventInvalidateCacheForPostPriority              ClusterEvent = "inv_post_priority"
+	ClusterEventInvalidateCacheForChannelBookmarks          ClusterEvent = "inv_channel_bookmarks"
 	ClusterEventInvalidateCacheForTeams                     ClusterEvent = "inv_teams"
 	ClusterEventClearSessionCacheForAllUsers                ClusterEvent = "inv_all_user_sessions"
 	ClusterEventInstallPlugin                               ClusterEvent = "install_plugin"
@@ -102,6 +102,10 @@ const (
 	CacheTypeLRU   = "lru"
 	CacheTypeRedis = "redis"
 
+	RateLimitStoreMemory       = "memory"
+	RateLimitStoreRedis        = "redis"
+	RateLimitDefaultPolicyName = "default"
+
 	SitenameMaxLength = 30
 
 	ServiceSettingsDefaultSiteURL                = "http://localhost:8065"
@@ -225,9 +229,16 @@ const (
 	DataRetentionSettingsDefaultBatchSize                      = 3000
 	DataRetentionSettingsDefaultTimeBetweenBatchesMilliseconds = 100
 	DataRetentionSettingsDefaultRetentionIdsBatchSize          = 100
+	DataRetentionSettingsDefaultAuditLogRetentionDays          = 365
+
+	JobSettingsDefaultConfigFileHistorySize = 10
 
 	OutgoingIntegrationRequestsDefaultTimeout = 30
 
+	OutgoingWebhookDefaultMaxRetries           = 5
+	OutgoingWebhookMaxRetriesLimit             = 10
+	OutgoingWebhookDefaultDisableAfterFailures = 50
+
 	PluginSettingsDefaultDirectory         = "./plugins"
 	PluginSettingsDefaultClientDirectory   = "./client/plugins"
 	PluginSettingsDefaultEnableMarketplace = true
@@ -238,6 +249,8 @@ const (
 	ComplianceExportTypeActiance       = "actiance"
 	ComplianceExportTypeGlobalrelay    = "globalrelay"
 	ComplianceExportTypeGlobalrelayZip = "globalrelay-zip"
+	ComplianceExportTypeMbox           = "mbox"
+	ComplianceExportTypeJsonl          = "jsonl"
 	GlobalrelayCustomerTypeA9          = "A9"
 	GlobalrelayCustomerTypeA10         = "A10"
 	GlobalrelayCustomerTypeCustom      = "CUSTOM"
@@ -248,6 +261,8 @@ const (
 	ImageProxyTypeLocal     = "local"
 	ImageProxyTypeAtmosCamo = "atmos/camo"
 
+	ImageProxySettingsDefaultLocalCacheSizeMB = 1024
+
 	GoogleSettingsDefaultScope           = "profile email"
 	GoogleSettingsDefaultAuthEndpoint    = "https://accounts.google.com/o/oauth2/v2/auth"
 	GoogleSettingsDefaultTokenEndpoint   = "https://www.googleapis.com/oauth2/v4/token"
@@ -340,6 +355,8 @@ type ServiceSettings struct {
 	EnableOutgoingOAuthConnections      *bool    `access:"integrations_integration_management"`
 	EnableCommands                      *bool    `access:"integrations_integration_management"`
 	OutgoingIntegrationRequestsTimeout  *int64   `access:"integrations_integration_management"` // In seconds.
+	OutgoingWebhookMaxRetries           *int     `access:"integrations_integration_management"`
+	OutgoingWebhookDisableAfterFailures *int     `access:"integrations_integration_management"`
 	EnablePostUsernameOverride          *bool    `access:"integrations_integration_management"`
 	EnablePostIconOverride              *bool    `access:"integrations_integration_management"`
 	GoogleDeveloperKey                  *string  `access:"site_posts,write_restrictable,cloud_restrictable"`
@@ -552,6 +569,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.OutgoingIntegrationRequestsTimeout = NewPointer(int64(OutgoingIntegrationRequestsDefaultTimeout))
 	}
 
+	if s.OutgoingWebhookMaxRetries == nil {
+		s.OutgoingWebhookMaxRetries = NewPointer(OutgoingWebhookDefaultMaxRetries)
+	}
+
+	if s.OutgoingWebhookDisableAfterFailures == nil {
+		s.OutgoingWebhookDisableAfterFailures = NewPointer(OutgoingWebhookDefaultDisableAfterFailures)
+	}
+
 	if s.ConnectionSecurity == nil {
 		s.ConnectionSecurity = NewPointer("")
 	}
@@ -1314,6 +1339,8 @@ type SqlSettings struct {
 	MigrationsStatementTimeoutSeconds *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
 	ReplicaLagSettings                []*ReplicaLagSettings `access:"environment_database,write_restrictable,cloud_restrictable"` // telemetry: none
 	ReplicaMonitorIntervalSeconds     *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
+	ReplicaMaxLagSeconds              *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
+	ReadYourWritesWindowSeconds       *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
 }
 
 func (s *SqlSettings) SetDefaults(isUpdate bool) {
@@ -1382,6 +1409,16 @@ func (s *SqlSettings) SetDefaults(isUpdate bool) {
 	if s.ReplicaMonitorIntervalSeconds == nil {
 		s.ReplicaMonitorIntervalSeconds = NewPointer(5)
 	}
+
+	// Replicas are never taken out of rotation because of their lag by default.
+	if s.ReplicaMaxLagSeconds == nil {
+		s.ReplicaMaxLagSeconds = NewPointer(0)
+	}
+
+	// Reads are never routed to the master after a write by default.
+	if s.ReadYourWritesWindowSeconds == nil {
+		s.ReadYourWritesWindowSeconds = NewPointer(0)
+	}
 }
 
 type LogSettings struct {
@@ -1497,6 +1534,7 @@ type ExperimentalAuditSettings struct {
 	FileMaxBackups      *int            `access:"experimental_features,write_restrictable,cloud_restrictable"`
 	FileCompress        *bool           `access:"experimental_features,write_restrictable,cloud_restrictable"`
 	FileMaxQueueSize    *int            `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	DatabaseEnabled     *bool           `access:"experimental_features,write_restrictable,cloud_restrictable"`
 	AdvancedLoggingJSON json.RawMessage `access:"experimental_features"`
 }
 
@@ -1529,6 +1567,10 @@ func (s *ExperimentalAuditSettings) SetDefaults() {
 		s.FileMaxQueueSize = NewPointer(1000)
 	}
 
+	if s.DatabaseEnabled == nil {
+		s.DatabaseEnabled = NewPointer(false)
+	}
+
 	if utils.IsEmptyJSON(s.AdvancedLoggingJSON) {
 		s.AdvancedLoggingJSON = []byte("{}")
 	}
@@ -2045,13 +2087,63 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 }
 
 type RateLimitSettings struct {
-	Enable           *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	PerSec           *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	MaxBurst         *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	MemoryStoreSize  *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	VaryByRemoteAddr *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	VaryByUser       *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	VaryByHeader     string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Enable           *bool              `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PerSec           *int               `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MaxBurst         *int               `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MemoryStoreSize  *int               `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	VaryByRemoteAddr *bool              `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	VaryByUser       *bool              `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	VaryByHeader     string             `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Store            *string            `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Policies         []*RateLimitPolicy `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+}
+
+var rateLimitPolicyNameRegex = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// RateLimitPolicy is a named quota for the requests it targets. Routes are
+// API route prefixes relative to /api/v4, such as "/posts", and are limited
+// in addition to the global quota. When PersonalAccessTokens or Bots is set,
+// the policy only applies to requests authenticated that way and is counted
+// per token or per bot instead of per user.
+type RateLimitPolicy struct {
+	Name                 *string  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Routes               []string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PersonalAccessTokens *bool    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Bots                 *bool    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PerSec               *int     `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MaxBurst             *int     `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+}
+
+func (p *RateLimitPolicy) SetDefaults() {
+	if p.Name == nil {
+		p.Name = NewPointer("")
+	}
+
+	if p.Routes == nil {
+		p.Routes = []string{}
+	}
+
+	if p.PersonalAccessTokens == nil {
+		p.PersonalAccessTokens = NewPointer(false)
+	}
+
+	if p.Bots == nil {
+		p.Bots = NewPointer(false)
+	}
+
+	if p.PerSec == nil {
+		p.PerSec = NewPointer(10)
+	}
+
+	if p.MaxBurst == nil {
+		p.MaxBurst = NewPointer(100)
+	}
+}
+
+// TargetsSessions reports whether the policy depends on how the request was
+// authenticated, in which case it can only be applied once the session is known.
+func (p *RateLimitPolicy) TargetsSessions() bool {
+	return *p.PersonalAccessTokens || *p.Bots
 }
 
 func (s *RateLimitSettings) SetDefaults() {
@@ -2078,6 +2170,18 @@ func (s *RateLimitSettings) SetDefaults() {
 	if s.VaryByUser == nil {
 		s.VaryByUser = NewPointer(false)
 	}
+
+	if s.Store == nil {
+		s.Store = NewPointer(RateLimitStoreMemory)
+	}
+
+	if s.Policies == nil {
+		s.Policies = []*RateLimitPolicy{}
+	}
+
+	for _, policy := range s.Policies {
+		policy.SetDefaults()
+	}
 }
 
 type PrivacySettings struct {
@@ -3018,6 +3122,8 @@ type DataRetentionSettings struct {
 	BatchSize                      *int    `access:"compliance_data_retention_policy"`
 	TimeBetweenBatchesMilliseconds *int    `access:"compliance_data_retention_policy"`
 	RetentionIdsBatchSize          *int    `access:"compliance_data_retention_policy"`
+	EnableAuditLogDeletion         *bool   `access:"compliance_data_retention_policy"`
+	AuditLogRetentionDays          *int    `access:"compliance_data_retention_policy"`
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -3067,6 +3173,14 @@ func (s *DataRetentionSettings) SetDefaults() {
 	if s.RetentionIdsBatchSize == nil {
 		s.RetentionIdsBatchSize = NewPointer(DataRetentionSettingsDefaultRetentionIdsBatchSize)
 	}
+
+	if s.EnableAuditLogDeletion == nil {
+		s.EnableAuditLogDeletion = NewPointer(false)
+	}
+
+	if s.AuditLogRetentionDays == nil {
+		s.AuditLogRetentionDays = NewPointer(DataRetentionSettingsDefaultAuditLogRetentionDays)
+	}
 }
 
 // GetMessageRetentionHours returns the message retention time as an int.
@@ -3098,6 +3212,7 @@ type JobSettings struct {
 	RunScheduler               *bool `access:"write_restrictable,cloud_restrictable"` // telemetry: none
 	CleanupJobsThresholdDays   *int  `access:"write_restrictable,cloud_restrictable"`
 	CleanupConfigThresholdDays *int  `access:"write_restrictable,cloud_restrictable"`
+	ConfigFileHistorySize      *int  `access:"write_restrictable,cloud_restrictable"`
 }
 
 func (s *JobSettings) SetDefaults() {
@@ -3116,6 +3231,18 @@ func (s *JobSettings) SetDefaults() {
 	if s.CleanupConfigThresholdDays == nil {
 		s.CleanupConfigThresholdDays = NewPointer(-1)
 	}
+
+	if s.ConfigFileHistorySize == nil {
+		s.ConfigFileHistorySize = NewPointer(JobSettingsDefaultConfigFileHistorySize)
+	}
+}
+
+func (s *JobSettings) isValid() *AppError {
+	if *s.ConfigFileHistorySize < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.job_settings.config_file_history_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type CloudSettings struct {
@@ -3492,10 +3619,12 @@ func (s *GuestAccountsSettings) SetDefaults() {
 }
 
 type ImageProxySettings struct {
-	Enable                  *bool   `access:"environment_image_proxy"`
-	ImageProxyType          *string `access:"environment_image_proxy"`
-	RemoteImageProxyURL     *string `access:"environment_image_proxy"`
-	RemoteImageProxyOptions *string `access:"environment_image_proxy"`
+	Enable                            *bool   `access:"environment_image_proxy"`
+	ImageProxyType                    *string `access:"environment_image_proxy"`
+	RemoteImageProxyURL               *string `access:"environment_image_proxy"`
+	RemoteImageProxyOptions           *string `access:"environment_image_proxy"`
+	LocalImageProxyCacheSizeMB        *int    `access:"environment_image_proxy"`
+	LocalImageProxyMaxImageResolution *int64  `access:"environment_image_proxy"`
 }
 
 func (s *ImageProxySettings) SetDefaults() {
@@ -3514,6 +3643,14 @@ func (s *ImageProxySettings) SetDefaults() {
 	if s.RemoteImageProxyOptions == nil {
 		s.RemoteImageProxyOptions = NewPointer("")
 	}
+
+	if s.LocalImageProxyCacheSizeMB == nil {
+		s.LocalImageProxyCacheSizeMB = NewPointer(ImageProxySettingsDefaultLocalCacheSizeMB)
+	}
+
+	if s.LocalImageProxyMaxImageResolution == nil {
+		s.LocalImageProxyMaxImageResolution = NewPointer(int64(7680 * 4320)) // 8K, ~33MPX
+	}
 }
 
 // ImportSettings defines configuration settings for file imports.
@@ -3839,6 +3976,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if *o.RateLimitSettings.Store == RateLimitStoreRedis && *o.CacheSettings.CacheType != CacheTypeRedis {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_store_redis.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if appErr := o.ServiceSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -3879,6 +4020,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.JobSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.WranglerSettings.IsValid(); appErr != nil {
 		return appErr
 	}
@@ -3959,6 +4104,14 @@ func (s *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_max_conn.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.ReplicaMaxLagSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_replica_max_lag_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.ReadYourWritesWindowSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_read_your_writes_window_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -4031,6 +4184,36 @@ func (s *RateLimitSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_burst.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.Store != RateLimitStoreMemory && *s.Store != RateLimitStoreRedis {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_store.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	names := make(map[string]bool, len(s.Policies))
+	for _, policy := range s.Policies {
+		if !rateLimitPolicyNameRegex.MatchString(*policy.Name) || *policy.Name == RateLimitDefaultPolicyName {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy.name.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+
+		if names[*policy.Name] {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy.duplicate.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+		names[*policy.Name] = true
+
+		if len(policy.Routes) == 0 && !policy.TargetsSessions() {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy.target.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+
+		for _, route := range policy.Routes {
+			if !strings.HasPrefix(route, "/") {
+				return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy.route.app_error", map[string]any{"Name": *policy.Name, "Route": route}, "", http.StatusBadRequest)
+			}
+		}
+
+		if *policy.PerSec <= 0 || *policy.MaxBurst <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy.quota.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -4252,6 +4435,14 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_integrations_request_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.OutgoingWebhookMaxRetries < 0 || *s.OutgoingWebhookMaxRetries > OutgoingWebhookMaxRetriesLimit {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_max_retries.app_error", map[string]any{"MaxRetries": OutgoingWebhookMaxRetriesLimit}, "", http.StatusBadRequest)
+	}
+
+	if *s.OutgoingWebhookDisableAfterFailures < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_disable_after_failures.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDisabled &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOn &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOff {
@@ -4402,6 +4593,10 @@ func (s *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 	}
 
+	if s.AuditLogRetentionDays == nil || *s.AuditLogRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.audit_log_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -4428,7 +4623,7 @@ func (s *MessageExportSettings) isValid() *AppError {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.daily_runtime.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 		} else if s.BatchSize == nil || *s.BatchSize < 0 {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.batch_size.app_error", nil, "", http.StatusBadRequest)
-		} else if s.ExportFormat == nil || (*s.ExportFormat != ComplianceExportTypeActiance && *s.ExportFormat != ComplianceExportTypeGlobalrelay && *s.ExportFormat != ComplianceExportTypeCsv) {
+		} else if s.ExportFormat == nil || (*s.ExportFormat != ComplianceExportTypeActiance && *s.ExportFormat != ComplianceExportTypeGlobalrelay && *s.ExportFormat != ComplianceExportTypeCsv && *s.ExportFormat != ComplianceExportTypeMbox && *s.ExportFormat != ComplianceExportTypeJsonl) {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.export_type.app_error", nil, "", http.StatusBadRequest)
 		}
 
@@ -4477,7 +4672,13 @@ func (s *ImageProxySettings) isValid() *AppError {
 	if *s.Enable {
 		switch *s.ImageProxyType {
 		case ImageProxyTypeLocal:
-			// No other settings to validate
+			if *s.LocalImageProxyCacheSizeMB < 0 {
+				return NewAppError("Config.IsValid", "model.config.is_valid.local_image_proxy_cache_size.app_error", nil, "", http.StatusBadRequest)
+			}
+
+			if *s.LocalImageProxyMaxImageResolution <= 0 {
+				return NewAppError("Config.IsValid", "model.config.is_valid.local_image_proxy_max_image_resolution.app_error", nil, "", http.StatusBadRequest)
+			}
 		case ImageProxyTypeAtmosCamo:
 			if *s.RemoteImageProxyURL == "" {
 				return NewAppError("Config.IsValid", "model.config.is_valid.atmos_camo_image_proxy_url.app_error", nil, "", http.StatusBadRequest)
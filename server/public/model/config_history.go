@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ConfigVersion describes a stored version of the configuration.
+type ConfigVersion struct {
+	Id       string `json:"id"`
+	CreateAt int64  `json:"create_at"`
+	// Author is the id of the user that saved the version, if known.
+	Author string `json:"author,omitempty"`
+	// Active is true for the version currently in use.
+	Active bool `json:"active"`
+}
+
+// ConfigVersionDiff is a setting that differs between two versions of the configuration.
+// Sensitive values are masked.
+type ConfigVersionDiff struct {
+	Path      string `json:"path"`
+	BaseVal   any    `json:"base_val"`
+	ActualVal any    `json:"actual_val"`
+}
@@ -202,6 +202,83 @@ func TestWranglerSettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestRateLimitSettingsIsValid(t *testing.T) {
+	newSettings := func(policies ...*RateLimitPolicy) *RateLimitSettings {
+		s := &RateLimitSettings{Policies: policies}
+		s.SetDefaults()
+		return s
+	}
+
+	t.Run("defaults are valid", func(t *testing.T) {
+		require.Nil(t, newSettings().isValid())
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		s := newSettings()
+		s.Store = NewPointer("memcached")
+		require.NotNil(t, s.isValid())
+	})
+
+	for name, tc := range map[string]struct {
+		policies []*RateLimitPolicy
+		valid    bool
+	}{
+		"route policy": {
+			policies: []*RateLimitPolicy{{Name: NewPointer("posts"), Routes: []string{"/posts"}}},
+			valid:    true,
+		},
+		"token and bot policies": {
+			policies: []*RateLimitPolicy{
+				{Name: NewPointer("bots"), Bots: NewPointer(true)},
+				{Name: NewPointer("tokens"), PersonalAccessTokens: NewPointer(true), Routes: []string{"/users"}},
+			},
+			valid: true,
+		},
+		"missing name": {
+			policies: []*RateLimitPolicy{{Routes: []string{"/posts"}}},
+		},
+		"reserved name": {
+			policies: []*RateLimitPolicy{{Name: NewPointer(RateLimitDefaultPolicyName), Routes: []string{"/posts"}}},
+		},
+		"invalid name": {
+			policies: []*RateLimitPolicy{{Name: NewPointer("Posts API"), Routes: []string{"/posts"}}},
+		},
+		"duplicate name": {
+			policies: []*RateLimitPolicy{
+				{Name: NewPointer("posts"), Routes: []string{"/posts"}},
+				{Name: NewPointer("posts"), Bots: NewPointer(true)},
+			},
+		},
+		"no target": {
+			policies: []*RateLimitPolicy{{Name: NewPointer("posts")}},
+		},
+		"relative route": {
+			policies: []*RateLimitPolicy{{Name: NewPointer("posts"), Routes: []string{"posts"}}},
+		},
+		"invalid quota": {
+			policies: []*RateLimitPolicy{{Name: NewPointer("posts"), Routes: []string{"/posts"}, PerSec: NewPointer(0)}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr := newSettings(tc.policies...).isValid()
+			if tc.valid {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+			}
+		})
+	}
+
+	t.Run("redis store requires the redis cache", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.RateLimitSettings.Store = NewPointer(RateLimitStoreRedis)
+		appErr := c.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.rate_limit_store_redis.app_error", appErr.Id)
+	})
+}
+
 func TestConfigIsValidDefaultAlgorithms(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -500,6 +577,23 @@ func TestMessageExportSettingsIsValidActiance(t *testing.T) {
 	require.Nil(t, mes.isValid())
 }
 
+func TestMessageExportSettingsIsValidMboxAndJsonl(t *testing.T) {
+	for _, exportFormat := range []string{ComplianceExportTypeMbox, ComplianceExportTypeJsonl} {
+		t.Run(exportFormat, func(t *testing.T) {
+			mes := &MessageExportSettings{
+				EnableExport:        NewPointer(true),
+				ExportFormat:        NewPointer(exportFormat),
+				ExportFromTimestamp: NewPointer(int64(0)),
+				DailyRunTime:        NewPointer("15:04"),
+				BatchSize:           NewPointer(100),
+			}
+
+			// should pass because everything is valid
+			require.Nil(t, mes.isValid())
+		})
+	}
+}
+
 func TestMessageExportSettingsIsValidGlobalRelaySettingsMissing(t *testing.T) {
 	mes := &MessageExportSettings{
 		EnableExport:        NewPointer(true),
@@ -839,12 +933,14 @@ func TestImageProxySettingsSetDefaults(t *testing.T) {
 
 func TestImageProxySettingsIsValid(t *testing.T) {
 	for _, test := range []struct {
-		Name                    string
-		Enable                  bool
-		ImageProxyType          string
-		RemoteImageProxyURL     string
-		RemoteImageProxyOptions string
-		ExpectError             bool
+		Name                              string
+		Enable                            bool
+		ImageProxyType                    string
+		RemoteImageProxyURL               string
+		RemoteImageProxyOptions           string
+		LocalImageProxyCacheSizeMB        int
+		LocalImageProxyMaxImageResolution int64
+		ExpectError                       bool
 	}{
 		{
 			Name:        "disabled",
@@ -866,12 +962,38 @@ func TestImageProxySettingsIsValid(t *testing.T) {
 			ExpectError:    true,
 		},
 		{
-			Name:                    "local",
-			Enable:                  true,
-			ImageProxyType:          "local",
-			RemoteImageProxyURL:     "garbage",
-			RemoteImageProxyOptions: "garbage",
-			ExpectError:             false,
+			Name:                              "local",
+			Enable:                            true,
+			ImageProxyType:                    "local",
+			RemoteImageProxyURL:               "garbage",
+			RemoteImageProxyOptions:           "garbage",
+			LocalImageProxyCacheSizeMB:        ImageProxySettingsDefaultLocalCacheSizeMB,
+			LocalImageProxyMaxImageResolution: 7680 * 4320,
+			ExpectError:                       false,
+		},
+		{
+			Name:                              "local, cache disabled",
+			Enable:                            true,
+			ImageProxyType:                    "local",
+			LocalImageProxyCacheSizeMB:        0,
+			LocalImageProxyMaxImageResolution: 7680 * 4320,
+			ExpectError:                       false,
+		},
+		{
+			Name:                              "local, negative cache size",
+			Enable:                            true,
+			ImageProxyType:                    "local",
+			LocalImageProxyCacheSizeMB:        -1,
+			LocalImageProxyMaxImageResolution: 7680 * 4320,
+			ExpectError:                       true,
+		},
+		{
+			Name:                              "local, missing max image resolution",
+			Enable:                            true,
+			ImageProxyType:                    "local",
+			LocalImageProxyCacheSizeMB:        ImageProxySettingsDefaultLocalCacheSizeMB,
+			LocalImageProxyMaxImageResolution: 0,
+			ExpectError:                       true,
 		},
 		{
 			Name:                    "atmos/camo",
@@ -900,10 +1022,12 @@ func TestImageProxySettingsIsValid(t *testing.T) {
 	} {
 		t.Run(test.Name, func(t *testing.T) {
 			ips := &ImageProxySettings{
-				Enable:                  &test.Enable,
-				ImageProxyType:          &test.ImageProxyType,
-				RemoteImageProxyURL:     &test.RemoteImageProxyURL,
-				RemoteImageProxyOptions: &test.RemoteImageProxyOptions,
+				Enable:                            &test.Enable,
+				ImageProxyType:                    &test.ImageProxyType,
+				RemoteImageProxyURL:               &test.RemoteImageProxyURL,
+				RemoteImageProxyOptions:           &test.RemoteImageProxyOptions,
+				LocalImageProxyCacheSizeMB:        &test.LocalImageProxyCacheSizeMB,
+				LocalImageProxyMaxImageResolution: &test.LocalImageProxyMaxImageResolution,
 			}
 
 			appErr := ips.isValid()
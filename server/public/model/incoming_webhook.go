@@ -13,6 +13,10 @@ import (
 
 const (
 	DefaultWebhookUsername = "webhook"
+
+	// IncomingWebhookTemplateMaxLength is the maximum size, in bytes, of the
+	// payload template an incoming webhook can carry.
+	IncomingWebhookTemplateMaxLength = 16384
 )
 
 type IncomingWebhook struct {
@@ -28,6 +32,7 @@ type IncomingWebhook struct {
 	Username      string `json:"username"`
 	IconURL       string `json:"icon_url"`
 	ChannelLocked bool   `json:"channel_locked"`
+	Template      string `json:"template"`
 }
 
 func (o *IncomingWebhook) Auditable() map[string]interface{} {
@@ -44,6 +49,7 @@ func (o *IncomingWebhook) Auditable() map[string]interface{} {
 		"username":       o.Username,
 		"icon_url:":      o.IconURL,
 		"channel_locked": o.ChannelLocked,
+		"template":       o.Template,
 	}
 }
 
@@ -59,6 +65,14 @@ type IncomingWebhookRequest struct {
 	Priority    *PostPriority      `json:"priority"`
 }
 
+// IncomingWebhookTemplateDryRunRequest asks the server to render a sample
+// payload through an incoming webhook template without creating a post. When
+// Template is empty, the template stored on the webhook is used.
+type IncomingWebhookTemplateDryRunRequest struct {
+	Template string          `json:"template"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
 type IncomingWebhooksWithCount struct {
 	Webhooks   []*IncomingWebhook `json:"incoming_webhooks"`
 	TotalCount int64              `json:"total_count"`
@@ -105,6 +119,10 @@ func (o *IncomingWebhook) IsValid() *AppError {
 		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if len(o.Template) > IncomingWebhookTemplateMaxLength {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.template.app_error", map[string]any{"MaxLength": IncomingWebhookTemplateMaxLength}, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -65,6 +65,12 @@ func TestIncomingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	require.Nil(t, o.IsValid())
+
+	o.Template = strings.Repeat("1", IncomingWebhookTemplateMaxLength+1)
+	require.NotNil(t, o.IsValid())
+
+	o.Template = strings.Repeat("1", IncomingWebhookTemplateMaxLength)
+	require.Nil(t, o.IsValid())
 }
 
 func TestIncomingWebhookPreSave(t *testing.T) {
@@ -39,8 +39,15 @@ const (
 	JobTypeRefreshPostStats              = "refresh_post_stats"
 	JobTypeDeleteOrphanDraftsMigration   = "delete_orphan_drafts_migration"
 	JobTypeExportUsersToCSV              = "export_users_to_csv"
+	JobTypeExportChannelActivityReport   = "export_channel_activity_report"
+	JobTypeExportPostVolumeReport        = "export_post_volume_report"
+	JobTypeExportGuestAccessReport       = "export_guest_access_report"
 	JobTypeDeleteDmsPreferencesMigration = "delete_dms_preferences_migration"
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
+	JobTypeOutgoingWebhookRetries        = "outgoing_webhook_retries"
+	JobTypeOutOfOffice                   = "out_of_office"
+	JobTypePostReminders                 = "post_reminders"
+	JobTypeAuditLogRetention             = "audit_log_retention"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -74,6 +81,10 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshPostStats,
 	JobTypeMobileSessionMetadata,
+	JobTypeOutgoingWebhookRetries,
+	JobTypeOutOfOffice,
+	JobTypePostReminders,
+	JobTypeAuditLogRetention,
 }
 
 type Job struct {
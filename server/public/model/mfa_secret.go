@@ -7,3 +7,9 @@ type MfaSecret struct {
 	Secret string `json:"secret"`
 	QRCode string `json:"qr_code"`
 }
+
+// MfaRecoveryCodes are one-time codes that can be used instead of a TOTP code when the
+// authenticator is lost. They can only be shown to the user when they are generated.
+type MfaRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
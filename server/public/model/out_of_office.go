@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	// AutoResponderRemoteMessageNotifyProp is the auto-response sent to users
+	// of remote clusters in shared channels. It defaults to the regular message.
+	AutoResponderRemoteMessageNotifyProp = "auto_responder_remote_message"
+	// AutoResponderMentionsNotifyProp makes the auto-responder also reply to
+	// @mentions in channels, not only to direct messages.
+	AutoResponderMentionsNotifyProp = "auto_responder_mentions"
+
+	OutOfOfficeMessageMaxRunes = 1024
+)
+
+// OutOfOffice describes a user's auto-responder. When StartAt or EndAt are
+// set, the auto-responder and the out of office status are switched on and
+// off at those times.
+type OutOfOffice struct {
+	UserId          string `json:"user_id"`
+	Active          bool   `json:"active"`
+	Message         string `json:"message"`
+	RemoteMessage   string `json:"remote_message"`
+	ReplyToMentions bool   `json:"reply_to_mentions"`
+	StartAt         int64  `json:"start_at"`
+	EndAt           int64  `json:"end_at"`
+}
+
+func (o *OutOfOffice) IsScheduled() bool {
+	return o.StartAt > 0 || o.EndAt > 0
+}
+
+func (o *OutOfOffice) IsValid() *AppError {
+	if o.Message == "" && (o.Active || o.IsScheduled()) {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.message.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Message) > OutOfOfficeMessageMaxRunes || utf8.RuneCountInString(o.RemoteMessage) > OutOfOfficeMessageMaxRunes {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.message_length.app_error", map[string]any{"MaxRunes": OutOfOfficeMessageMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	if o.StartAt < 0 || o.EndAt < 0 || (o.EndAt > 0 && o.EndAt <= o.StartAt) {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.period.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ActiveAt reports whether the auto-responder should be on at the given time.
+// Outside of a scheduled period this is the Active flag.
+func (o *OutOfOffice) ActiveAt(now int64) bool {
+	if !o.IsScheduled() {
+		return o.Active
+	}
+	return o.StartAt <= now && (o.EndAt == 0 || now < o.EndAt)
+}
+
+// OutOfOfficeSchedule is a pending out of office period processed by the
+// out of office job. ActivatedAt is set once the period has started.
+type OutOfOfficeSchedule struct {
+	UserId      string `json:"user_id"`
+	StartAt     int64  `json:"start_at"`
+	EndAt       int64  `json:"end_at"`
+	ActivatedAt int64  `json:"activated_at"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+}
+
+func (s *OutOfOfficeSchedule) PreSave() {
+	if s.CreateAt == 0 {
+		s.CreateAt = GetMillis()
+	}
+	s.UpdateAt = GetMillis()
+}
+
+func (s *OutOfOfficeSchedule) IsValid() *AppError {
+	if !IsValidId(s.UserId) {
+		return NewAppError("OutOfOfficeSchedule.IsValid", "model.out_of_office.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if s.StartAt < 0 || s.EndAt < 0 || (s.EndAt > 0 && s.EndAt <= s.StartAt) {
+		return NewAppError("OutOfOfficeSchedule.IsValid", "model.out_of_office.is_valid.period.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsEnded reports whether the end of the period has passed.
+func (s *OutOfOfficeSchedule) IsEnded(now int64) bool {
+	return s.EndAt > 0 && s.EndAt <= now
+}
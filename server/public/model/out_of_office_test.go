@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutOfOfficeIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ooo     OutOfOffice
+		errorID string
+	}{
+		"inactive without message":  {OutOfOffice{}, ""},
+		"active":                    {OutOfOffice{Active: true, Message: "Away"}, ""},
+		"scheduled":                 {OutOfOffice{Message: "Away", StartAt: 1000, EndAt: 2000}, ""},
+		"open ended":                {OutOfOffice{Message: "Away", StartAt: 1000}, ""},
+		"active without message":    {OutOfOffice{Active: true}, "model.out_of_office.is_valid.message.app_error"},
+		"scheduled without message": {OutOfOffice{StartAt: 1000}, "model.out_of_office.is_valid.message.app_error"},
+		"message too long":          {OutOfOffice{Active: true, Message: strings.Repeat("a", OutOfOfficeMessageMaxRunes+1)}, "model.out_of_office.is_valid.message_length.app_error"},
+		"remote message too long":   {OutOfOffice{Active: true, Message: "Away", RemoteMessage: strings.Repeat("a", OutOfOfficeMessageMaxRunes+1)}, "model.out_of_office.is_valid.message_length.app_error"},
+		"end before start":          {OutOfOffice{Message: "Away", StartAt: 2000, EndAt: 1000}, "model.out_of_office.is_valid.period.app_error"},
+		"negative start":            {OutOfOffice{Message: "Away", StartAt: -1}, "model.out_of_office.is_valid.period.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr := tc.ooo.IsValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+				return
+			}
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errorID, appErr.Id)
+		})
+	}
+}
+
+func TestOutOfOfficeActiveAt(t *testing.T) {
+	assert.True(t, (&OutOfOffice{Active: true}).ActiveAt(1500))
+	assert.False(t, (&OutOfOffice{}).ActiveAt(1500))
+
+	// A scheduled period ignores the Active flag.
+	scheduled := &OutOfOffice{Active: true, StartAt: 1000, EndAt: 2000}
+	assert.False(t, scheduled.ActiveAt(999))
+	assert.True(t, scheduled.ActiveAt(1000))
+	assert.True(t, scheduled.ActiveAt(1999))
+	assert.False(t, scheduled.ActiveAt(2000))
+
+	assert.True(t, (&OutOfOffice{StartAt: 1000}).ActiveAt(5000))
+	assert.True(t, (&OutOfOffice{EndAt: 2000}).ActiveAt(1500))
+}
+
+func TestOutOfOfficeScheduleIsValid(t *testing.T) {
+	assert.Nil(t, (&OutOfOfficeSchedule{UserId: NewId(), StartAt: 1000, EndAt: 2000}).IsValid())
+	assert.NotNil(t, (&OutOfOfficeSchedule{UserId: "junk", StartAt: 1000}).IsValid())
+	assert.NotNil(t, (&OutOfOfficeSchedule{UserId: NewId(), StartAt: 2000, EndAt: 2000}).IsValid())
+}
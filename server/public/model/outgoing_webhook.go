@@ -4,30 +4,49 @@
 package model
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	// OutgoingWebhookSignatureHeader holds the HMAC-SHA256 signature of signed outgoing webhook requests.
+	OutgoingWebhookSignatureHeader = "X-Mattermost-Signature"
+	// OutgoingWebhookTimestampHeader holds the time, in seconds since epoch, at which a signed
+	// outgoing webhook request was sent. It is part of the signed content to prevent replays.
+	OutgoingWebhookTimestampHeader = "X-Mattermost-Request-Timestamp"
+
+	OutgoingWebhookSignatureVersion    = "v0"
+	OutgoingWebhookSigningSecretLength = 32
+	OutgoingWebhookSignatureMaxAge     = 5 * time.Minute
 )
 
 type OutgoingWebhook struct {
-	Id           string      `json:"id"`
-	Token        string      `json:"token"`
-	CreateAt     int64       `json:"create_at"`
-	UpdateAt     int64       `json:"update_at"`
-	DeleteAt     int64       `json:"delete_at"`
-	CreatorId    string      `json:"creator_id"`
-	ChannelId    string      `json:"channel_id"`
-	TeamId       string      `json:"team_id"`
-	TriggerWords StringArray `json:"trigger_words"`
-	TriggerWhen  int         `json:"trigger_when"`
-	CallbackURLs StringArray `json:"callback_urls"`
-	DisplayName  string      `json:"display_name"`
-	Description  string      `json:"description"`
-	ContentType  string      `json:"content_type"`
-	Username     string      `json:"username"`
-	IconURL      string      `json:"icon_url"`
+	Id            string      `json:"id"`
+	Token         string      `json:"token"`
+	CreateAt      int64       `json:"create_at"`
+	UpdateAt      int64       `json:"update_at"`
+	DeleteAt      int64       `json:"delete_at"`
+	CreatorId     string      `json:"creator_id"`
+	ChannelId     string      `json:"channel_id"`
+	TeamId        string      `json:"team_id"`
+	TriggerWords  StringArray `json:"trigger_words"`
+	TriggerWhen   int         `json:"trigger_when"`
+	CallbackURLs  StringArray `json:"callback_urls"`
+	DisplayName   string      `json:"display_name"`
+	Description   string      `json:"description"`
+	ContentType   string      `json:"content_type"`
+	Username      string      `json:"username"`
+	IconURL       string      `json:"icon_url"`
+	DisabledAt    int64       `json:"disabled_at"`
+	SigningSecret string      `json:"signing_secret"`
 }
 
 func (o *OutgoingWebhook) Auditable() map[string]interface{} {
@@ -47,6 +66,7 @@ func (o *OutgoingWebhook) Auditable() map[string]interface{} {
 		"content_type":  o.ContentType,
 		"username":      o.Username,
 		"icon_url":      o.IconURL,
+		"disabled_at":   o.DisabledAt,
 	}
 }
 
@@ -171,6 +191,10 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if o.SigningSecret != "" && len(o.SigningSecret) != OutgoingWebhookSigningSecretLength {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.signing_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -191,6 +215,57 @@ func (o *OutgoingWebhook) PreUpdate() {
 	o.UpdateAt = GetMillis()
 }
 
+// IsSigned returns true if the requests sent to the callback URLs are signed.
+func (o *OutgoingWebhook) IsSigned() bool {
+	return o.SigningSecret != ""
+}
+
+// NewOutgoingWebhookSigningSecret generates a new secret to sign outgoing webhook requests with.
+func NewOutgoingWebhookSigningSecret() string {
+	return NewRandomString(OutgoingWebhookSigningSecretLength)
+}
+
+// ComputeOutgoingWebhookSignature returns the value of the OutgoingWebhookSignatureHeader for
+// a request body sent at the given time, in seconds since epoch. The signature is the hex
+// encoded HMAC-SHA256 of "v0:<timestamp>:<body>" keyed with the signing secret of the hook.
+func ComputeOutgoingWebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s:%d:", OutgoingWebhookSignatureVersion, timestamp)
+	mac.Write(body)
+	return OutgoingWebhookSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyOutgoingWebhookSignature checks the signature headers of an outgoing webhook request
+// received at now against its body. Requests sent more than maxAge away from now are rejected
+// so that captured requests can't be replayed later.
+func VerifyOutgoingWebhookSignature(secret string, header http.Header, body []byte, now time.Time, maxAge time.Duration) error {
+	signature := header.Get(OutgoingWebhookSignatureHeader)
+	if signature == "" {
+		return errors.New("missing signature header")
+	}
+
+	timestamp, err := strconv.ParseInt(header.Get(OutgoingWebhookTimestampHeader), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp header: %w", err)
+	}
+
+	if age := now.Sub(time.Unix(timestamp, 0)); age > maxAge || age < -maxAge {
+		return errors.New("timestamp is too far from the current time")
+	}
+
+	expected := ComputeOutgoingWebhookSignature(secret, timestamp, body)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+// IsDisabled returns true if the hook was disabled after failing too many deliveries in a row.
+func (o *OutgoingWebhook) IsDisabled() bool {
+	return o.DisabledAt != 0
+}
+
 func (o *OutgoingWebhook) TriggerWordExactMatch(word string) bool {
 	if word == "" {
 		return false
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	// OutgoingWebhookDeliveryStatusSuccess marks an attempt that the callback URL accepted.
+	OutgoingWebhookDeliveryStatusSuccess = "success"
+	// OutgoingWebhookDeliveryStatusRetryPending marks a failed attempt waiting to be retried.
+	OutgoingWebhookDeliveryStatusRetryPending = "retry_pending"
+	// OutgoingWebhookDeliveryStatusFailed marks a failed attempt that won't be retried.
+	OutgoingWebhookDeliveryStatusFailed = "failed"
+
+	OutgoingWebhookDeliveryResponseMaxRunes = 1024
+	OutgoingWebhookDeliveryErrorMaxRunes    = 1024
+)
+
+// OutgoingWebhookDelivery records a single attempt to deliver an outgoing
+// webhook payload to one of the callback URLs of the hook.
+type OutgoingWebhookDelivery struct {
+	Id          string `json:"id"`
+	HookId      string `json:"hook_id"`
+	ChannelId   string `json:"channel_id"`
+	PostId      string `json:"post_id"`
+	CallbackURL string `json:"callback_url"`
+	ContentType string `json:"content_type"`
+	Payload     string `json:"payload"`
+	Attempt     int    `json:"attempt"`
+	Status      string `json:"status"`
+	StatusCode  int    `json:"status_code"`
+	Latency     int64  `json:"latency"` // In milliseconds.
+	Response    string `json:"response"`
+	Error       string `json:"error"`
+	CreateAt    int64  `json:"create_at"`
+	NextRetryAt int64  `json:"next_retry_at"`
+}
+
+// OutgoingWebhookDeliveryGetOptions filters the deliveries returned for a hook.
+type OutgoingWebhookDeliveryGetOptions struct {
+	// Status only returns the deliveries with the given status when set.
+	Status  string
+	Page    int
+	PerPage int
+}
+
+func (d *OutgoingWebhookDelivery) Auditable() map[string]any {
+	return map[string]any{
+		"id":            d.Id,
+		"hook_id":       d.HookId,
+		"channel_id":    d.ChannelId,
+		"post_id":       d.PostId,
+		"callback_url":  d.CallbackURL,
+		"attempt":       d.Attempt,
+		"status":        d.Status,
+		"status_code":   d.StatusCode,
+		"create_at":     d.CreateAt,
+		"next_retry_at": d.NextRetryAt,
+	}
+}
+
+func (d *OutgoingWebhookDelivery) PreSave() {
+	if d.Id == "" {
+		d.Id = NewId()
+	}
+
+	if d.CreateAt == 0 {
+		d.CreateAt = GetMillis()
+	}
+
+	if d.Attempt == 0 {
+		d.Attempt = 1
+	}
+
+	d.Response = truncateRunes(d.Response, OutgoingWebhookDeliveryResponseMaxRunes)
+	d.Error = truncateRunes(d.Error, OutgoingWebhookDeliveryErrorMaxRunes)
+}
+
+func (d *OutgoingWebhookDelivery) IsValid() *AppError {
+	if !IsValidId(d.Id) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(d.HookId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.hook_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(d.ChannelId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.channel_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if d.PostId != "" && !IsValidId(d.PostId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.post_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(d.CallbackURL) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.callback_url.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if d.Attempt < 1 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.attempt.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidOutgoingWebhookDeliveryStatus(d.Status) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.status.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if d.CreateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.create_at.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsFailed returns true if the attempt didn't reach the callback URL successfully.
+func (d *OutgoingWebhookDelivery) IsFailed() bool {
+	return d.Status != OutgoingWebhookDeliveryStatusSuccess
+}
+
+func IsValidOutgoingWebhookDeliveryStatus(status string) bool {
+	switch status {
+	case OutgoingWebhookDeliveryStatusSuccess,
+		OutgoingWebhookDeliveryStatusRetryPending,
+		OutgoingWebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
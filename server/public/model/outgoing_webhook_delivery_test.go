@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebhookDeliveryIsValid(t *testing.T) {
+	d := OutgoingWebhookDelivery{}
+	assert.NotNil(t, d.IsValid(), "empty declaration should be invalid")
+
+	d.Id = NewId()
+	assert.NotNil(t, d.IsValid(), "missing HookId should be invalid")
+
+	d.HookId = NewId()
+	assert.NotNil(t, d.IsValid(), "missing ChannelId should be invalid")
+
+	d.ChannelId = NewId()
+	d.PostId = "123"
+	assert.NotNilf(t, d.IsValid(), "PostId %s should be invalid", d.PostId)
+
+	d.PostId = ""
+	assert.NotNil(t, d.IsValid(), "missing CallbackURL should be invalid")
+
+	d.CallbackURL = "nowhere.com/"
+	assert.NotNilf(t, d.IsValid(), "CallbackURL %s should be invalid", d.CallbackURL)
+
+	d.CallbackURL = "http://nowhere.com/"
+	assert.NotNil(t, d.IsValid(), "Attempt 0 should be invalid")
+
+	d.Attempt = 1
+	d.Status = "unknown"
+	assert.NotNilf(t, d.IsValid(), "Status %s should be invalid", d.Status)
+
+	d.Status = OutgoingWebhookDeliveryStatusRetryPending
+	assert.NotNil(t, d.IsValid(), "missing CreateAt should be invalid")
+
+	d.CreateAt = GetMillis()
+	assert.Nil(t, d.IsValid())
+
+	d.PostId = NewId()
+	assert.Nil(t, d.IsValid())
+}
+
+func TestOutgoingWebhookDeliveryPreSave(t *testing.T) {
+	d := OutgoingWebhookDelivery{
+		Response: strings.Repeat("é", OutgoingWebhookDeliveryResponseMaxRunes+1),
+		Error:    "connection refused",
+	}
+	d.PreSave()
+
+	require.True(t, IsValidId(d.Id))
+	assert.NotZero(t, d.CreateAt)
+	assert.Equal(t, 1, d.Attempt)
+	assert.Equal(t, strings.Repeat("é", OutgoingWebhookDeliveryResponseMaxRunes), d.Response)
+	assert.Equal(t, "connection refused", d.Error)
+
+	d = OutgoingWebhookDelivery{Id: "id", CreateAt: 1, Attempt: 3}
+	d.PreSave()
+
+	assert.Equal(t, "id", d.Id)
+	assert.Equal(t, int64(1), d.CreateAt)
+	assert.Equal(t, 3, d.Attempt)
+}
+
+func TestOutgoingWebhookDeliveryIsFailed(t *testing.T) {
+	for status, failed := range map[string]bool{
+		OutgoingWebhookDeliveryStatusSuccess:      false,
+		OutgoingWebhookDeliveryStatusRetryPending: true,
+		OutgoingWebhookDeliveryStatusFailed:       true,
+	} {
+		d := OutgoingWebhookDelivery{Status: status}
+		assert.Equal(t, failed, d.IsFailed(), status)
+	}
+}
@@ -4,11 +4,15 @@
 package model
 
 import (
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOutgoingWebhookIsValid(t *testing.T) {
@@ -83,6 +87,12 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	assert.Nilf(t, o.IsValid(), "IconURL length %d should be valid", len(o.IconURL))
+
+	o.SigningSecret = "secret"
+	assert.NotNilf(t, o.IsValid(), "SigningSecret %s should be invalid", o.SigningSecret)
+
+	o.SigningSecret = NewOutgoingWebhookSigningSecret()
+	assert.Nilf(t, o.IsValid(), "SigningSecret %s should be valid", o.SigningSecret)
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
@@ -134,3 +144,54 @@ func TestOutgoingWebhookTriggerWordStartsWith(t *testing.T) {
 	assert.True(t, o.TriggerWordStartsWith("foobar"), "Should return true")
 	assert.False(t, o.TriggerWordStartsWith("barfoo"), "Should return false")
 }
+
+func TestComputeOutgoingWebhookSignature(t *testing.T) {
+	// Computed with: printf 'v0:1700000000:{"text":"hello"}' | openssl dgst -sha256 -hmac secret
+	signature := ComputeOutgoingWebhookSignature("secret", 1700000000, []byte(`{"text":"hello"}`))
+	assert.Equal(t, "v0=f7dd38a1c4380cc7fe51e26eb658cf0f0f8ceeb334691ee4dfb4e5adc8415267", signature)
+
+	assert.NotEqual(t, signature, ComputeOutgoingWebhookSignature("other", 1700000000, []byte(`{"text":"hello"}`)))
+	assert.NotEqual(t, signature, ComputeOutgoingWebhookSignature("secret", 1700000001, []byte(`{"text":"hello"}`)))
+	assert.NotEqual(t, signature, ComputeOutgoingWebhookSignature("secret", 1700000000, []byte(`{"text":"hellO"}`)))
+}
+
+func TestVerifyOutgoingWebhookSignature(t *testing.T) {
+	secret := NewOutgoingWebhookSigningSecret()
+	body := []byte("token=abc&text=hello")
+	now := time.Now()
+
+	signedHeader := func(timestamp int64) http.Header {
+		header := http.Header{}
+		header.Set(OutgoingWebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+		header.Set(OutgoingWebhookSignatureHeader, ComputeOutgoingWebhookSignature(secret, timestamp, body))
+		return header
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		require.NoError(t, VerifyOutgoingWebhookSignature(secret, signedHeader(now.Unix()), body, now, OutgoingWebhookSignatureMaxAge))
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		require.Error(t, VerifyOutgoingWebhookSignature(secret, http.Header{}, body, now, OutgoingWebhookSignatureMaxAge))
+
+		header := signedHeader(now.Unix())
+		header.Del(OutgoingWebhookTimestampHeader)
+		require.Error(t, VerifyOutgoingWebhookSignature(secret, header, body, now, OutgoingWebhookSignatureMaxAge))
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		require.Error(t, VerifyOutgoingWebhookSignature(secret, signedHeader(now.Unix()), []byte("token=abc&text=bye"), now, OutgoingWebhookSignatureMaxAge))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		require.Error(t, VerifyOutgoingWebhookSignature(NewOutgoingWebhookSigningSecret(), signedHeader(now.Unix()), body, now, OutgoingWebhookSignatureMaxAge))
+	})
+
+	t.Run("replayed request", func(t *testing.T) {
+		old := now.Add(-OutgoingWebhookSignatureMaxAge - time.Second).Unix()
+		require.Error(t, VerifyOutgoingWebhookSignature(secret, signedHeader(old), body, now, OutgoingWebhookSignatureMaxAge))
+
+		future := now.Add(OutgoingWebhookSignatureMaxAge + time.Second).Unix()
+		require.Error(t, VerifyOutgoingWebhookSignature(secret, signedHeader(future), body, now, OutgoingWebhookSignatureMaxAge))
+	})
+}
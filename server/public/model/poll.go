@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	PollMinOptions     = 2
+	PollMaxOptions     = 20
+	PollOptionMaxRunes = 256
+)
+
+// Poll holds the options and settings of a poll post. The question is the
+// message of the post.
+type Poll struct {
+	PostId         string      `json:"post_id"`
+	UserId         string      `json:"user_id"`
+	Options        StringArray `json:"options"`
+	Anonymous      bool        `json:"anonymous"`
+	MultipleChoice bool        `json:"multiple_choice"`
+	CloseAt        int64       `json:"close_at"`
+	CreateAt       int64       `json:"create_at"`
+}
+
+func (p *Poll) PreSave() {
+	if p.CreateAt == 0 {
+		p.CreateAt = GetMillis()
+	}
+}
+
+func (p *Poll) IsValid() *AppError {
+	if !IsValidId(p.PostId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.post_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.UserId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(p.Options) < PollMinOptions || len(p.Options) > PollMaxOptions {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.options.app_error", map[string]any{"Min": PollMinOptions, "Max": PollMaxOptions}, "", http.StatusBadRequest)
+	}
+
+	seen := make(map[string]bool, len(p.Options))
+	for _, option := range p.Options {
+		key := strings.ToLower(strings.TrimSpace(option))
+		if key == "" || utf8.RuneCountInString(option) > PollOptionMaxRunes || seen[key] {
+			return NewAppError("Poll.IsValid", "model.poll.is_valid.option.app_error", map[string]any{"MaxLength": PollOptionMaxRunes}, "", http.StatusBadRequest)
+		}
+		seen[key] = true
+	}
+
+	if p.CloseAt < 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.close_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsClosed reports whether votes are no longer accepted at the given time.
+func (p *Poll) IsClosed(now int64) bool {
+	return p.CloseAt > 0 && p.CloseAt <= now
+}
+
+// IsValidVote checks a user's choice of options, given as indexes into
+// Options. An empty choice withdraws the user's vote.
+func (p *Poll) IsValidVote(options []int) bool {
+	if len(options) > 1 && !p.MultipleChoice {
+		return false
+	}
+
+	seen := make(map[int]bool, len(options))
+	for _, option := range options {
+		if option < 0 || option >= len(p.Options) || seen[option] {
+			return false
+		}
+		seen[option] = true
+	}
+
+	return true
+}
+
+type PollVote struct {
+	PostId      string `json:"post_id"`
+	UserId      string `json:"user_id"`
+	OptionIndex int    `json:"option_index"`
+	CreateAt    int64  `json:"create_at"`
+}
+
+// PollCreate is the request to create a poll post.
+type PollCreate struct {
+	ChannelId      string   `json:"channel_id"`
+	RootId         string   `json:"root_id"`
+	Question       string   `json:"question"`
+	Options        []string `json:"options"`
+	Anonymous      bool     `json:"anonymous"`
+	MultipleChoice bool     `json:"multiple_choice"`
+	CloseAt        int64    `json:"close_at"`
+}
+
+// PollVoteRequest holds the options a user votes for.
+type PollVoteRequest struct {
+	Options []int `json:"options"`
+}
+
+// PollResults is the tally of a poll. Voters lists the ids of the users that
+// voted for each option and is left out for anonymous polls. MyVotes holds
+// the options chosen by the user the results were built for.
+type PollResults struct {
+	Poll       *Poll      `json:"poll"`
+	Closed     bool       `json:"closed"`
+	Counts     []int      `json:"counts"`
+	VoterCount int        `json:"voter_count"`
+	Voters     [][]string `json:"voters,omitempty"`
+	MyVotes    []int      `json:"my_votes"`
+}
+
+// NewPollResults tallies votes. userID may be empty when the results are
+// broadcast to every member of the channel.
+func NewPollResults(poll *Poll, votes []*PollVote, userID string, now int64) *PollResults {
+	results := &PollResults{
+		Poll:    poll,
+		Closed:  poll.IsClosed(now),
+		Counts:  make([]int, len(poll.Options)),
+		MyVotes: []int{},
+	}
+	if !poll.Anonymous {
+		results.Voters = make([][]string, len(poll.Options))
+		for i := range results.Voters {
+			results.Voters[i] = []string{}
+		}
+	}
+
+	voters := map[string]bool{}
+	for _, vote := range votes {
+		if vote.OptionIndex < 0 || vote.OptionIndex >= len(poll.Options) {
+			continue
+		}
+
+		results.Counts[vote.OptionIndex]++
+		voters[vote.UserId] = true
+		if results.Voters != nil {
+			results.Voters[vote.OptionIndex] = append(results.Voters[vote.OptionIndex], vote.UserId)
+		}
+		if userID != "" && vote.UserId == userID {
+			results.MyVotes = append(results.MyVotes, vote.OptionIndex)
+		}
+	}
+	results.VoterCount = len(voters)
+
+	return results
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollIsValid(t *testing.T) {
+	postID, userID := NewId(), NewId()
+	tooMany := make([]string, PollMaxOptions+1)
+	for i := range tooMany {
+		tooMany[i] = NewId()
+	}
+
+	for name, tc := range map[string]struct {
+		poll    Poll
+		errorID string
+	}{
+		"valid":             {Poll{PostId: postID, UserId: userID, Options: []string{"Yes", "No"}}, ""},
+		"invalid post id":   {Poll{PostId: "nope", UserId: userID, Options: []string{"Yes", "No"}}, "model.poll.is_valid.post_id.app_error"},
+		"invalid user id":   {Poll{PostId: postID, Options: []string{"Yes", "No"}}, "model.poll.is_valid.user_id.app_error"},
+		"too few options":   {Poll{PostId: postID, UserId: userID, Options: []string{"Yes"}}, "model.poll.is_valid.options.app_error"},
+		"too many options":  {Poll{PostId: postID, UserId: userID, Options: tooMany}, "model.poll.is_valid.options.app_error"},
+		"empty option":      {Poll{PostId: postID, UserId: userID, Options: []string{"Yes", " "}}, "model.poll.is_valid.option.app_error"},
+		"duplicate option":  {Poll{PostId: postID, UserId: userID, Options: []string{"Yes", "yes "}}, "model.poll.is_valid.option.app_error"},
+		"option too long":   {Poll{PostId: postID, UserId: userID, Options: []string{"Yes", strings.Repeat("a", PollOptionMaxRunes+1)}}, "model.poll.is_valid.option.app_error"},
+		"negative close at": {Poll{PostId: postID, UserId: userID, Options: []string{"Yes", "No"}, CloseAt: -1}, "model.poll.is_valid.close_at.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr := tc.poll.IsValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+				return
+			}
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errorID, appErr.Id)
+		})
+	}
+}
+
+func TestPollIsValidVote(t *testing.T) {
+	single := &Poll{Options: []string{"A", "B", "C"}}
+	multi := &Poll{Options: []string{"A", "B", "C"}, MultipleChoice: true}
+
+	assert.True(t, single.IsValidVote([]int{1}))
+	assert.True(t, single.IsValidVote(nil), "an empty vote withdraws it")
+	assert.False(t, single.IsValidVote([]int{0, 1}))
+	assert.False(t, single.IsValidVote([]int{3}))
+	assert.False(t, single.IsValidVote([]int{-1}))
+
+	assert.True(t, multi.IsValidVote([]int{0, 2}))
+	assert.False(t, multi.IsValidVote([]int{0, 0}))
+}
+
+func TestPollIsClosed(t *testing.T) {
+	assert.False(t, (&Poll{}).IsClosed(1000))
+	assert.False(t, (&Poll{CloseAt: 2000}).IsClosed(1000))
+	assert.True(t, (&Poll{CloseAt: 1000}).IsClosed(1000))
+}
+
+func TestNewPollResults(t *testing.T) {
+	user1, user2 := NewId(), NewId()
+	votes := []*PollVote{
+		{UserId: user1, OptionIndex: 0},
+		{UserId: user1, OptionIndex: 2},
+		{UserId: user2, OptionIndex: 2},
+	}
+
+	t.Run("lists voters", func(t *testing.T) {
+		poll := &Poll{Options: []string{"A", "B", "C"}, MultipleChoice: true}
+		results := NewPollResults(poll, votes, user1, 1000)
+		assert.False(t, results.Closed)
+		assert.Equal(t, []int{1, 0, 2}, results.Counts)
+		assert.Equal(t, 2, results.VoterCount)
+		assert.Equal(t, [][]string{{user1}, {}, {user1, user2}}, results.Voters)
+		assert.Equal(t, []int{0, 2}, results.MyVotes)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		poll := &Poll{Options: []string{"A", "B", "C"}, MultipleChoice: true, Anonymous: true, CloseAt: 500}
+		results := NewPollResults(poll, votes, "", 1000)
+		assert.True(t, results.Closed)
+		assert.Equal(t, []int{1, 0, 2}, results.Counts)
+		assert.Nil(t, results.Voters)
+		assert.Empty(t, results.MyVotes)
+	})
+}
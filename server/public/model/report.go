@@ -17,10 +17,22 @@ const (
 	ReportDurationLast6Months   = "last_6_months"
 
 	ReportingMaxPageSize = 100
+
+	ReportExportFormatCSV   = "csv"
+	ReportExportFormatXLSX  = "xlsx"
+	ReportExportFormatJSONL = "jsonl"
+
+	ReportTypeUsers           = "users"
+	ReportTypeChannelActivity = "channel_activity"
+	ReportTypePostVolume      = "post_volume"
+	ReportTypeGuestAccess     = "guest_access"
 )
 
 var (
-	ReportExportFormats = []string{"csv"}
+	ReportExportFormats = []string{ReportExportFormatCSV, ReportExportFormatXLSX, ReportExportFormatJSONL}
+
+	// BatchReportTypes are the reports that can be exported through the batch report worker.
+	BatchReportTypes = []string{ReportTypeUsers, ReportTypeChannelActivity, ReportTypePostVolume, ReportTypeGuestAccess}
 
 	UserReportSortColumns = []string{"CreateAt", "Username", "FirstName", "LastName", "Nickname", "Email", "Roles"}
 )
@@ -166,3 +178,174 @@ func IsValidReportExportFormat(format string) bool {
 
 	return false
 }
+
+func IsValidBatchReportType(reportType string) bool {
+	return slices.Contains(BatchReportTypes, reportType)
+}
+
+// ReportExportOptions are the options used to start the batch export of a
+// report other than the user report.
+type ReportExportOptions struct {
+	ReportingBaseOptions
+	Team   string
+	Format string
+}
+
+func (o *ReportExportOptions) IsValid() *AppError {
+	if appErr := o.ReportingBaseOptions.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	if o.Team != "" && !IsValidId(o.Team) {
+		return NewAppError("ReportExportOptions.IsValid", "model.report_export_options.is_valid.invalid_team", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidReportExportFormat(o.Format) {
+		return NewAppError("ReportExportOptions.IsValid", "model.report_export_options.is_valid.invalid_format", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func formatReportMillis(millis int64) string {
+	if millis <= 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).String()
+}
+
+// ChannelActivityReportOptions pages through public and private channels ordered by name.
+// FromColumnValue holds the name and FromId the id of the last channel of the previous page.
+type ChannelActivityReportOptions struct {
+	ReportingBaseOptions
+	Team string
+}
+
+func (o *ChannelActivityReportOptions) IsValid() *AppError {
+	if appErr := o.ReportingBaseOptions.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	if o.PageSize <= 0 || o.PageSize > ReportingMaxPageSize {
+		return NewAppError("ChannelActivityReportOptions.IsValid", "model.reporting_base_options.is_valid.invalid_page_size", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+type ChannelActivityReport struct {
+	ChannelId   string      `json:"channel_id"`
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	Type        ChannelType `json:"type"`
+	TeamId      string      `json:"team_id"`
+	TeamName    string      `json:"team_name"`
+	CreateAt    int64       `json:"create_at"`
+	DeleteAt    int64       `json:"delete_at"`
+	LastPostAt  int64       `json:"last_post_at"`
+	MemberCount int64       `json:"member_count"`
+	PostCount   int64       `json:"post_count"`
+}
+
+func (c *ChannelActivityReport) ToReport() []string {
+	return []string{
+		c.ChannelId,
+		c.Name,
+		c.DisplayName,
+		string(c.Type),
+		c.TeamId,
+		c.TeamName,
+		formatReportMillis(c.CreateAt),
+		formatReportMillis(c.LastPostAt),
+		strconv.FormatInt(c.MemberCount, 10),
+		strconv.FormatInt(c.PostCount, 10),
+		formatReportMillis(c.DeleteAt),
+	}
+}
+
+// PostVolumeReportOptions pages through the number of posts per team and per day.
+// FromColumnValue holds the day (YYYY-MM-DD) and FromId the team id of the last row of the previous page.
+type PostVolumeReportOptions struct {
+	ReportingBaseOptions
+	Team string
+}
+
+func (o *PostVolumeReportOptions) IsValid() *AppError {
+	if appErr := o.ReportingBaseOptions.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	if o.PageSize <= 0 || o.PageSize > ReportingMaxPageSize {
+		return NewAppError("PostVolumeReportOptions.IsValid", "model.reporting_base_options.is_valid.invalid_page_size", nil, "", http.StatusBadRequest)
+	}
+
+	if o.FromColumnValue != "" {
+		if _, err := time.Parse(time.DateOnly, o.FromColumnValue); err != nil {
+			return NewAppError("PostVolumeReportOptions.IsValid", "model.post_volume_report_options.is_valid.invalid_day", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// PostVolumeReport is the number of posts made in a team on a given day, in UTC. Posts
+// in direct and group messages are reported with an empty team.
+type PostVolumeReport struct {
+	Day       string `json:"day"`
+	TeamId    string `json:"team_id"`
+	TeamName  string `json:"team_name"`
+	PostCount int64  `json:"post_count"`
+}
+
+func (p *PostVolumeReport) ToReport() []string {
+	return []string{
+		p.Day,
+		p.TeamId,
+		p.TeamName,
+		strconv.FormatInt(p.PostCount, 10),
+	}
+}
+
+// GuestAccessReportOptions pages through guest accounts ordered by username.
+// FromColumnValue holds the username of the last guest of the previous page.
+type GuestAccessReportOptions struct {
+	ReportingBaseOptions
+	Team string
+}
+
+func (o *GuestAccessReportOptions) IsValid() *AppError {
+	if appErr := o.ReportingBaseOptions.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	if o.PageSize <= 0 || o.PageSize > ReportingMaxPageSize {
+		return NewAppError("GuestAccessReportOptions.IsValid", "model.reporting_base_options.is_valid.invalid_page_size", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// GuestAccessReport describes what a guest account has access to.
+type GuestAccessReport struct {
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	CreateAt       int64  `json:"create_at"`
+	DeleteAt       int64  `json:"delete_at"`
+	LastActivityAt int64  `json:"last_activity_at"`
+	TeamCount      int64  `json:"team_count"`
+	ChannelCount   int64  `json:"channel_count"`
+}
+
+func (g *GuestAccessReport) ToReport() []string {
+	return []string{
+		g.UserId,
+		g.Username,
+		g.Email,
+		formatReportMillis(g.CreateAt),
+		formatReportMillis(g.LastActivityAt),
+		strconv.FormatInt(g.TeamCount, 10),
+		strconv.FormatInt(g.ChannelCount, 10),
+		formatReportMillis(g.DeleteAt),
+	}
+}
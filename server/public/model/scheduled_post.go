@@ -6,6 +6,10 @@ package model
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/shared/timezones"
 )
 
 const (
@@ -32,6 +36,14 @@ type ScheduledPost struct {
 	ScheduledAt int64  `json:"scheduled_at"`
 	ProcessedAt int64  `json:"processed_at"`
 	ErrorCode   string `json:"error_code"`
+
+	// RecurrenceRule makes the post repeat, see ParseScheduledPostRecurrence.
+	// After each occurrence ScheduledAt moves to the next one.
+	RecurrenceRule string `json:"recurrence_rule"`
+	// Timezone is the IANA time zone occurrences are computed in. An empty
+	// value means UTC.
+	Timezone string `json:"timezone"`
+	PausedAt int64  `json:"paused_at"`
 }
 
 func (s *ScheduledPost) IsValid(maxMessageSize int) *AppError {
@@ -64,6 +76,19 @@ func (s *ScheduledPost) BaseIsValid() *AppError {
 		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.processed_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
 	}
 
+	if s.Timezone != "" && !slices.Contains(timezones.DefaultSupportedTimezones, s.Timezone) {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.timezone.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.RecurrenceRule != "" {
+		if len(s.RecurrenceRule) > ScheduledPostRecurrenceRuleMaxLength {
+			return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.recurrence_rule.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+		}
+		if _, err := ParseScheduledPostRecurrence(s.RecurrenceRule); err != nil {
+			return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.recurrence_rule.app_error", nil, "id="+s.Id, http.StatusBadRequest).Wrap(err)
+		}
+	}
+
 	return nil
 }
 
@@ -78,6 +103,70 @@ func (s *ScheduledPost) PreSave() {
 	s.Draft.PreSave()
 }
 
+func (s *ScheduledPost) IsRecurring() bool {
+	return s.RecurrenceRule != ""
+}
+
+func (s *ScheduledPost) IsPaused() bool {
+	return s.PausedAt > 0
+}
+
+func (s *ScheduledPost) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// NextOccurrence returns the first occurrence of a recurring post after the
+// given time, skipping any occurrences that were missed in between. It
+// returns 0 when the post doesn't recur or its rule has no further
+// occurrences.
+func (s *ScheduledPost) NextOccurrence(after int64) (int64, error) {
+	if !s.IsRecurring() {
+		return 0, nil
+	}
+
+	recurrence, err := ParseScheduledPostRecurrence(s.RecurrenceRule)
+	if err != nil {
+		return 0, err
+	}
+
+	loc, err := s.location()
+	if err != nil {
+		return 0, err
+	}
+
+	next, ok := recurrence.Next(time.UnixMilli(s.ScheduledAt).In(loc), time.UnixMilli(after))
+	if !ok {
+		return 0, nil
+	}
+	return next.UnixMilli(), nil
+}
+
+// UpcomingOccurrences returns up to count times, in milliseconds, at which the
+// post will be sent, starting with ScheduledAt.
+func (s *ScheduledPost) UpcomingOccurrences(count int) ([]int64, error) {
+	occurrences := []int64{}
+	if count <= 0 {
+		return occurrences, nil
+	}
+
+	occurrences = append(occurrences, s.ScheduledAt)
+	for len(occurrences) < count {
+		next, err := s.NextOccurrence(occurrences[len(occurrences)-1])
+		if err != nil {
+			return nil, err
+		}
+		if next == 0 {
+			break
+		}
+		occurrences = append(occurrences, next)
+	}
+
+	return occurrences, nil
+}
+
 func (s *ScheduledPost) PreUpdate() {
 	s.Draft.UpdateAt = GetMillis()
 	s.Draft.PreCommit()
@@ -135,15 +224,18 @@ func (s *ScheduledPost) Auditable() map[string]interface{} {
 	}
 
 	return map[string]interface{}{
-		"id":         s.Id,
-		"create_at":  s.CreateAt,
-		"update_at":  s.UpdateAt,
-		"user_id":    s.UserId,
-		"channel_id": s.ChannelId,
-		"root_id":    s.RootId,
-		"props":      s.GetProps(),
-		"file_ids":   s.FileIds,
-		"metadata":   metaData,
+		"id":              s.Id,
+		"create_at":       s.CreateAt,
+		"update_at":       s.UpdateAt,
+		"user_id":         s.UserId,
+		"channel_id":      s.ChannelId,
+		"root_id":         s.RootId,
+		"props":           s.GetProps(),
+		"file_ids":        s.FileIds,
+		"metadata":        metaData,
+		"recurrence_rule": s.RecurrenceRule,
+		"timezone":        s.Timezone,
+		"paused_at":       s.PausedAt,
 	}
 }
 
@@ -153,10 +245,12 @@ func (s *ScheduledPost) RestoreNonUpdatableFields(originalScheduledPost *Schedul
 	s.UserId = originalScheduledPost.UserId
 	s.ChannelId = originalScheduledPost.ChannelId
 	s.RootId = originalScheduledPost.RootId
+	s.PausedAt = originalScheduledPost.PausedAt
 }
 
 func (s *ScheduledPost) SanitizeInput() {
 	s.CreateAt = 0
+	s.PausedAt = 0
 
 	if s.Metadata != nil {
 		s.Metadata.Embeds = nil
@@ -0,0 +1,217 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ScheduledPostRecurrenceDaily   = "DAILY"
+	ScheduledPostRecurrenceWeekly  = "WEEKLY"
+	ScheduledPostRecurrenceMonthly = "MONTHLY"
+
+	ScheduledPostRecurrenceRuleMaxLength = 256
+	scheduledPostRecurrenceMaxInterval   = 366
+
+	// scheduledPostRecurrenceMaxPeriods bounds how many days, weeks or months
+	// are scanned when looking for the next occurrence of a rule.
+	scheduledPostRecurrenceMaxPeriods = 10000
+)
+
+var scheduledPostRecurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ScheduledPostRecurrence is the parsed form of the RRULE subset supported by
+// scheduled posts: FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY for weekly
+// rules, BYMONTHDAY for monthly rules and UNTIL.
+type ScheduledPostRecurrence struct {
+	Frequency  string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Until      time.Time
+}
+
+// ParseScheduledPostRecurrence parses a rule such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH". An optional "RRULE:" prefix is
+// accepted.
+func ParseScheduledPostRecurrence(rule string) (*ScheduledPostRecurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+
+	r := &ScheduledPostRecurrence{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		key = strings.ToUpper(key)
+		value = strings.ToUpper(value)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate recurrence rule part %q", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case ScheduledPostRecurrenceDaily, ScheduledPostRecurrenceWeekly, ScheduledPostRecurrenceMonthly:
+				r.Frequency = value
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > scheduledPostRecurrenceMaxInterval {
+				return nil, fmt.Errorf("invalid interval %q", value)
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := scheduledPostRecurrenceWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("invalid weekday %q", day)
+				}
+				if !slices.Contains(r.ByDay, weekday) {
+					r.ByDay = append(r.ByDay, weekday)
+				}
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, fmt.Errorf("invalid month day %q", day)
+				}
+				if !slices.Contains(r.ByMonthDay, monthDay) {
+					r.ByMonthDay = append(r.ByMonthDay, monthDay)
+				}
+			}
+		case "UNTIL":
+			until, err := parseScheduledPostRecurrenceUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = until
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if r.Frequency == "" {
+		return nil, fmt.Errorf("recurrence rule is missing FREQ")
+	}
+	if len(r.ByDay) > 0 && r.Frequency != ScheduledPostRecurrenceWeekly {
+		return nil, fmt.Errorf("BYDAY is only supported for weekly rules")
+	}
+	if len(r.ByMonthDay) > 0 && r.Frequency != ScheduledPostRecurrenceMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY is only supported for monthly rules")
+	}
+
+	return r, nil
+}
+
+func parseScheduledPostRecurrenceUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+
+	// A date-only UNTIL includes the whole day.
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+	}
+	return until.Add(24*time.Hour - time.Second), nil
+}
+
+// Next returns the first occurrence of the rule strictly after both anchor and
+// after, keeping the wall clock time of anchor in its location. It returns
+// false once the rule has no further occurrences.
+func (r *ScheduledPostRecurrence) Next(anchor, after time.Time) (time.Time, bool) {
+	if after.Before(anchor) {
+		after = anchor
+	}
+
+	year, month, day := anchor.Date()
+	hour, minute, sec := anchor.Clock()
+	loc := anchor.Location()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, 0, loc)
+	}
+
+	var weekdays []int
+	if r.Frequency == ScheduledPostRecurrenceWeekly {
+		// Weeks start on Monday, so offsets are counted from there.
+		for _, weekday := range r.ByDay {
+			weekdays = append(weekdays, (int(weekday)+6)%7)
+		}
+		if len(weekdays) == 0 {
+			weekdays = append(weekdays, (int(anchor.Weekday())+6)%7)
+		}
+		slices.Sort(weekdays)
+	}
+
+	monthDays := r.ByMonthDay
+	if len(monthDays) == 0 {
+		monthDays = []int{day}
+	}
+
+	for period := 0; period < scheduledPostRecurrenceMaxPeriods; period++ {
+		var candidates []time.Time
+
+		switch r.Frequency {
+		case ScheduledPostRecurrenceDaily:
+			candidates = append(candidates, at(year, month, day+period*r.Interval))
+		case ScheduledPostRecurrenceWeekly:
+			weekStart := day - (int(anchor.Weekday())+6)%7 + period*7*r.Interval
+			for _, offset := range weekdays {
+				candidates = append(candidates, at(year, month, weekStart+offset))
+			}
+		case ScheduledPostRecurrenceMonthly:
+			first := time.Date(year, month+time.Month(period*r.Interval), 1, 0, 0, 0, 0, loc)
+			daysInMonth := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, loc).Day()
+			var days []int
+			for _, monthDay := range monthDays {
+				if monthDay < 0 {
+					monthDay = daysInMonth + monthDay + 1
+				}
+				// Days that don't exist in this month are skipped.
+				if monthDay >= 1 && monthDay <= daysInMonth {
+					days = append(days, monthDay)
+				}
+			}
+			slices.Sort(days)
+			for _, monthDay := range days {
+				candidates = append(candidates, at(first.Year(), first.Month(), monthDay))
+			}
+		default:
+			return time.Time{}, false
+		}
+
+		for _, candidate := range candidates {
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return time.Time{}, false
+			}
+			if candidate.After(after) {
+				return candidate, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduledPostRecurrence(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		r, err := ParseScheduledPostRecurrence("FREQ=DAILY")
+		require.NoError(t, err)
+		assert.Equal(t, ScheduledPostRecurrenceDaily, r.Frequency)
+		assert.Equal(t, 1, r.Interval)
+
+		r, err = ParseScheduledPostRecurrence("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,th")
+		require.NoError(t, err)
+		assert.Equal(t, ScheduledPostRecurrenceWeekly, r.Frequency)
+		assert.Equal(t, 2, r.Interval)
+		assert.Equal(t, []time.Weekday{time.Monday, time.Thursday}, r.ByDay)
+
+		r, err = ParseScheduledPostRecurrence("FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20250131")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, -1}, r.ByMonthDay)
+		assert.Equal(t, time.Date(2025, time.January, 31, 23, 59, 59, 0, time.UTC), r.Until)
+
+		r, err = ParseScheduledPostRecurrence("FREQ=DAILY;UNTIL=20250131T120000Z")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.January, 31, 12, 0, 0, 0, time.UTC), r.Until)
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, rule := range []string{
+			"",
+			"INTERVAL=2",
+			"FREQ=YEARLY",
+			"FREQ=HOURLY",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;INTERVAL=abc",
+			"FREQ=DAILY;FREQ=WEEKLY",
+			"FREQ=DAILY;BYDAY=MO",
+			"FREQ=WEEKLY;BYDAY=XX",
+			"FREQ=WEEKLY;BYDAY=1MO",
+			"FREQ=WEEKLY;BYMONTHDAY=1",
+			"FREQ=MONTHLY;BYMONTHDAY=0",
+			"FREQ=MONTHLY;BYMONTHDAY=32",
+			"FREQ=DAILY;COUNT=3",
+			"FREQ=DAILY;UNTIL=tomorrow",
+			"FREQ=DAILY;",
+		} {
+			_, err := ParseScheduledPostRecurrence(rule)
+			assert.Error(t, err, rule)
+		}
+	})
+}
+
+func TestScheduledPostRecurrenceNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	next := func(t *testing.T, rule string, anchor, after time.Time) time.Time {
+		t.Helper()
+		r, err := ParseScheduledPostRecurrence(rule)
+		require.NoError(t, err)
+		n, ok := r.Next(anchor, after)
+		require.True(t, ok)
+		return n
+	}
+
+	// Monday, 3 March 2025 at 09:30 in New York.
+	anchor := time.Date(2025, time.March, 3, 9, 30, 0, 0, newYork)
+
+	t.Run("daily", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, time.March, 4, 9, 30, 0, 0, newYork), next(t, "FREQ=DAILY", anchor, anchor))
+		assert.Equal(t, time.Date(2025, time.March, 6, 9, 30, 0, 0, newYork), next(t, "FREQ=DAILY;INTERVAL=3", anchor, anchor))
+	})
+
+	t.Run("daily keeps the wall clock across daylight saving changes", func(t *testing.T) {
+		n := next(t, "FREQ=DAILY", time.Date(2025, time.March, 8, 9, 30, 0, 0, newYork), anchor)
+		assert.Equal(t, time.Date(2025, time.March, 9, 9, 30, 0, 0, newYork), n)
+		assert.Equal(t, 13, n.UTC().Hour())
+	})
+
+	t.Run("weekly", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, time.March, 10, 9, 30, 0, 0, newYork), next(t, "FREQ=WEEKLY", anchor, anchor))
+		assert.Equal(t, time.Date(2025, time.March, 6, 9, 30, 0, 0, newYork), next(t, "FREQ=WEEKLY;BYDAY=MO,TH", anchor, anchor))
+
+		thursday := time.Date(2025, time.March, 6, 9, 30, 0, 0, newYork)
+		assert.Equal(t, time.Date(2025, time.March, 17, 9, 30, 0, 0, newYork), next(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", thursday, thursday))
+		assert.Equal(t, time.Date(2025, time.March, 9, 9, 30, 0, 0, newYork), next(t, "FREQ=WEEKLY;BYDAY=SU", thursday, thursday))
+	})
+
+	t.Run("monthly", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, time.April, 3, 9, 30, 0, 0, newYork), next(t, "FREQ=MONTHLY", anchor, anchor))
+		assert.Equal(t, time.Date(2025, time.March, 31, 9, 30, 0, 0, newYork), next(t, "FREQ=MONTHLY;BYMONTHDAY=-1", anchor, anchor))
+
+		// Months without a 31st are skipped.
+		jan31 := time.Date(2025, time.January, 31, 9, 30, 0, 0, newYork)
+		assert.Equal(t, time.Date(2025, time.March, 31, 9, 30, 0, 0, newYork), next(t, "FREQ=MONTHLY", jan31, jan31))
+	})
+
+	t.Run("skips missed occurrences", func(t *testing.T) {
+		after := time.Date(2025, time.March, 20, 12, 0, 0, 0, newYork)
+		assert.Equal(t, time.Date(2025, time.March, 21, 9, 30, 0, 0, newYork), next(t, "FREQ=DAILY", anchor, after))
+		assert.Equal(t, time.Date(2025, time.March, 24, 9, 30, 0, 0, newYork), next(t, "FREQ=WEEKLY", anchor, after))
+	})
+
+	t.Run("stops after until", func(t *testing.T) {
+		r, err := ParseScheduledPostRecurrence("FREQ=DAILY;UNTIL=20250304")
+		require.NoError(t, err)
+
+		n, ok := r.Next(anchor, anchor)
+		require.True(t, ok)
+		_, ok = r.Next(anchor, n)
+		require.False(t, ok)
+	})
+}
+
+func TestScheduledPostOccurrences(t *testing.T) {
+	scheduledAt := time.Date(2025, time.March, 3, 9, 30, 0, 0, time.UTC).UnixMilli()
+
+	t.Run("one-off post", func(t *testing.T) {
+		s := &ScheduledPost{ScheduledAt: scheduledAt}
+
+		next, err := s.NextOccurrence(scheduledAt)
+		require.NoError(t, err)
+		assert.Zero(t, next)
+
+		occurrences, err := s.UpcomingOccurrences(3)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{scheduledAt}, occurrences)
+	})
+
+	t.Run("recurring post", func(t *testing.T) {
+		s := &ScheduledPost{ScheduledAt: scheduledAt, RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO,WE", Timezone: "Europe/Paris"}
+
+		occurrences, err := s.UpcomingOccurrences(3)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{
+			scheduledAt,
+			time.Date(2025, time.March, 5, 9, 30, 0, 0, time.UTC).UnixMilli(),
+			time.Date(2025, time.March, 10, 9, 30, 0, 0, time.UTC).UnixMilli(),
+		}, occurrences)
+
+		next, err := s.NextOccurrence(time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC).UnixMilli())
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.March, 12, 9, 30, 0, 0, time.UTC).UnixMilli(), next)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		s := &ScheduledPost{
+			Draft:       Draft{UserId: NewId(), ChannelId: NewId(), Message: "standup"},
+			Id:          NewId(),
+			ScheduledAt: GetMillis() + 60000,
+		}
+		s.CreateAt = GetMillis()
+		s.UpdateAt = s.CreateAt
+		require.Nil(t, s.BaseIsValid())
+
+		s.RecurrenceRule = "FREQ=DAILY"
+		s.Timezone = "Europe/Paris"
+		require.Nil(t, s.BaseIsValid())
+
+		s.Timezone = "Mars/Olympus_Mons"
+		appErr := s.BaseIsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.scheduled_post.is_valid.timezone.app_error", appErr.Id)
+
+		s.Timezone = ""
+		s.RecurrenceRule = "FREQ=HOURLY"
+		appErr = s.BaseIsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.scheduled_post.is_valid.recurrence_rule.app_error", appErr.Id)
+	})
+}
@@ -8,10 +8,12 @@ import (
 )
 
 const (
-	TokenSize          = 64
-	MaxTokenExipryTime = 1000 * 60 * 60 * 48 // 48 hour
-	TokenTypeOAuth     = "oauth"
-	TokenTypeSaml      = "saml"
+	TokenSize                     = 64
+	MaxTokenExipryTime            = 1000 * 60 * 60 * 48 // 48 hour
+	TokenTypeOAuth                = "oauth"
+	TokenTypeSaml                 = "saml"
+	TokenTypeWebAuthnRegistration = "webauthn_registration"
+	TokenTypeWebAuthnLogin        = "webauthn_login"
 )
 
 type Token struct {
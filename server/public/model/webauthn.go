@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	WebAuthnCredentialNameMaxRunes = 64
+	// WebAuthnCredentialIdMaxLength is the maximum length of a base64url encoded credential ID.
+	WebAuthnCredentialIdMaxLength = 512
+	WebAuthnMaxCredentialsPerUser = 20
+)
+
+// WebAuthnCredential is a security key or passkey registered by a user as a second factor.
+type WebAuthnCredential struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	// CredentialId is the base64url encoded ID of the credential on the authenticator.
+	CredentialId string `json:"credential_id"`
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey  []byte `json:"-"`
+	AAGUID     string `json:"aaguid"`
+	SignCount  int64  `json:"sign_count"`
+	Name       string `json:"name"`
+	CreateAt   int64  `json:"create_at"`
+	LastUsedAt int64  `json:"last_used_at"`
+}
+
+func (c *WebAuthnCredential) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	if c.CreateAt == 0 {
+		c.CreateAt = GetMillis()
+	}
+}
+
+func (c *WebAuthnCredential) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(c.UserId) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.user_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CredentialId == "" || len(c.CredentialId) > WebAuthnCredentialIdMaxLength {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.credential_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if len(c.PublicKey) == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.public_key.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(c.Name) > WebAuthnCredentialNameMaxRunes {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.name.app_error", map[string]any{"MaxLength": WebAuthnCredentialNameMaxRunes}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// The types below follow the JSON serialization of the WebAuthn Level 3 options and responses,
+// where the binary values are base64url encoded without padding.
+
+type WebAuthnRelyingParty struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCredentialCreationOptions are the options to pass to navigator.credentials.create
+// to register a credential.
+type WebAuthnCredentialCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RP                     WebAuthnRelyingParty           `json:"rp"`
+	User                   WebAuthnUserEntity             `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnCredentialRequestOptions are the options to pass to navigator.credentials.get to
+// sign in with a credential.
+type WebAuthnCredentialRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RPId             string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+type WebAuthnAuthenticatorAttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+// WebAuthnAttestationResponse is the credential returned by navigator.credentials.create.
+type WebAuthnAttestationResponse struct {
+	Id       string                                   `json:"id"`
+	Type     string                                   `json:"type"`
+	Response WebAuthnAuthenticatorAttestationResponse `json:"response"`
+}
+
+type WebAuthnAuthenticatorAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// WebAuthnAssertionResponse is the credential returned by navigator.credentials.get. It is
+// sent as the MFA token when signing in.
+type WebAuthnAssertionResponse struct {
+	Id       string                                 `json:"id"`
+	Type     string                                 `json:"type"`
+	Response WebAuthnAuthenticatorAssertionResponse `json:"response"`
+}
+
+// WebAuthnRegistration completes the registration of a credential. The user confirms their
+// identity with either their password or a current MFA token.
+type WebAuthnRegistration struct {
+	Name       string                       `json:"name"`
+	Credential *WebAuthnAttestationResponse `json:"credential"`
+	Password   string                       `json:"password,omitempty"`
+	MfaToken   string                       `json:"mfa_token,omitempty"`
+}
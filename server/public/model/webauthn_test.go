@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnCredentialIsValid(t *testing.T) {
+	newCredential := func() *WebAuthnCredential {
+		credential := &WebAuthnCredential{
+			UserId:       NewId(),
+			CredentialId: "AQIDBA",
+			PublicKey:    []byte{1, 2, 3},
+			Name:         "YubiKey",
+		}
+		credential.PreSave()
+		return credential
+	}
+
+	require.Nil(t, newCredential().IsValid())
+
+	for name, mutate := range map[string]func(*WebAuthnCredential){
+		"id":            func(c *WebAuthnCredential) { c.Id = "" },
+		"user id":       func(c *WebAuthnCredential) { c.UserId = "invalid" },
+		"credential id": func(c *WebAuthnCredential) { c.CredentialId = "" },
+		"long credential id": func(c *WebAuthnCredential) {
+			c.CredentialId = strings.Repeat("a", WebAuthnCredentialIdMaxLength+1)
+		},
+		"public key": func(c *WebAuthnCredential) { c.PublicKey = nil },
+		"name":       func(c *WebAuthnCredential) { c.Name = strings.Repeat("é", WebAuthnCredentialNameMaxRunes+1) },
+		"create at":  func(c *WebAuthnCredential) { c.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			credential := newCredential()
+			mutate(credential)
+			assert.NotNil(t, credential.IsValid())
+		})
+	}
+}
+
+func TestWebAuthnCredentialJSON(t *testing.T) {
+	credential := &WebAuthnCredential{Id: NewId(), PublicKey: []byte{1, 2, 3}}
+
+	data, err := json.Marshal(credential)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "public")
+}
@@ -1312,6 +1312,46 @@ type API interface {
 	// @tag Plugin
 	// Minimum server version: 10.1
 	GetPluginID() string
+
+	// RegisterFileContentExtractor registers the plugin to extract the searchable text
+	// of files with the given extensions (without the leading dot). When the content of
+	// such a file needs to be extracted, the ExtractFileContent hook is invoked, and the
+	// plugin takes precedence over the server's built-in extractors.
+	//
+	// Registering again replaces the previous set of extensions.
+	//
+	// @tag File
+	// @tag Plugin
+	// Minimum server version: 10.5
+	RegisterFileContentExtractor(extensions []string) error
+
+	// UnregisterFileContentExtractor unregisters the extractor previously registered via
+	// RegisterFileContentExtractor.
+	//
+	// @tag File
+	// @tag Plugin
+	// Minimum server version: 10.5
+	UnregisterFileContentExtractor() error
+
+	// RegisterMentionKeywords registers the plugin to resolve the given mention
+	// keywords, such as "@team-leads" or "@oncall", to users. When a post mentions
+	// one of them, the ResolveMentions hook is invoked. Keywords are case
+	// insensitive and the leading @ is optional.
+	//
+	// Registering again replaces the previous set of keywords.
+	//
+	// @tag Post
+	// @tag Plugin
+	// Minimum server version: 10.5
+	RegisterMentionKeywords(keywords []string) error
+
+	// UnregisterMentionKeywords unregisters the keywords previously registered via
+	// RegisterMentionKeywords.
+	//
+	// @tag Post
+	// @tag Plugin
+	// Minimum server version: 10.5
+	UnregisterMentionKeywords() error
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -1385,3 +1385,31 @@ func (api *apiTimerLayer) GetPluginID() string {
 	api.recordTime(startTime, "GetPluginID", true)
 	return _returnsA
 }
+
+func (api *apiTimerLayer) RegisterFileContentExtractor(extensions []string) error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterFileContentExtractor(extensions)
+	api.recordTime(startTime, "RegisterFileContentExtractor", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterFileContentExtractor() error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterFileContentExtractor()
+	api.recordTime(startTime, "UnregisterFileContentExtractor", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) RegisterMentionKeywords(keywords []string) error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterMentionKeywords(keywords)
+	api.recordTime(startTime, "RegisterMentionKeywords", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterMentionKeywords() error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterMentionKeywords()
+	api.recordTime(startTime, "UnregisterMentionKeywords", _returnsA == nil)
+	return _returnsA
+}
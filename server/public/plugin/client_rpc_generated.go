@@ -1161,6 +1161,83 @@ func (s *hooksRPCServer) GenerateSupportData(args *Z_GenerateSupportDataArgs, re
 	return nil
 }
 
+func init() {
+	hookNameToId["ExtractFileContent"] = ExtractFileContentID
+}
+
+type Z_ExtractFileContentArgs struct {
+	A *Context
+	B string
+	C []byte
+}
+
+type Z_ExtractFileContentReturns struct {
+	A string
+	B error
+}
+
+func (g *hooksRPCClient) ExtractFileContent(c *Context, fileName string, content []byte) (string, error) {
+	_args := &Z_ExtractFileContentArgs{c, fileName, content}
+	_returns := &Z_ExtractFileContentReturns{}
+	if g.implemented[ExtractFileContentID] {
+		if err := g.client.Call("Plugin.ExtractFileContent", _args, _returns); err != nil {
+			g.log.Error("RPC call ExtractFileContent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ExtractFileContent(args *Z_ExtractFileContentArgs, returns *Z_ExtractFileContentReturns) error {
+	if hook, ok := s.impl.(interface {
+		ExtractFileContent(c *Context, fileName string, content []byte) (string, error)
+	}); ok {
+		returns.A, returns.B = hook.ExtractFileContent(args.A, args.B, args.C)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ExtractFileContent called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ResolveMentions"] = ResolveMentionsID
+}
+
+type Z_ResolveMentionsArgs struct {
+	A *Context
+	B *model.Post
+	C *model.Channel
+	D []string
+}
+
+type Z_ResolveMentionsReturns struct {
+	A map[string][]string
+	B error
+}
+
+func (g *hooksRPCClient) ResolveMentions(c *Context, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error) {
+	_args := &Z_ResolveMentionsArgs{c, post, channel, keywords}
+	_returns := &Z_ResolveMentionsReturns{}
+	if g.implemented[ResolveMentionsID] {
+		if err := g.client.Call("Plugin.ResolveMentions", _args, _returns); err != nil {
+			g.log.Error("RPC call ResolveMentions to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ResolveMentions(args *Z_ResolveMentionsArgs, returns *Z_ResolveMentionsReturns) error {
+	if hook, ok := s.impl.(interface {
+		ResolveMentions(c *Context, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error)
+	}); ok {
+		returns.A, returns.B = hook.ResolveMentions(args.A, args.B, args.C, args.D)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ResolveMentions called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -6657,3 +6734,117 @@ func (s *apiRPCServer) GetPluginID(args *Z_GetPluginIDArgs, returns *Z_GetPlugin
 	}
 	return nil
 }
+
+type Z_RegisterFileContentExtractorArgs struct {
+	A []string
+}
+
+type Z_RegisterFileContentExtractorReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) RegisterFileContentExtractor(extensions []string) error {
+	_args := &Z_RegisterFileContentExtractorArgs{extensions}
+	_returns := &Z_RegisterFileContentExtractorReturns{}
+	if err := g.client.Call("Plugin.RegisterFileContentExtractor", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterFileContentExtractor API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterFileContentExtractor(args *Z_RegisterFileContentExtractorArgs, returns *Z_RegisterFileContentExtractorReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterFileContentExtractor(extensions []string) error
+	}); ok {
+		returns.A = hook.RegisterFileContentExtractor(args.A)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterFileContentExtractor called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterFileContentExtractorArgs struct {
+}
+
+type Z_UnregisterFileContentExtractorReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) UnregisterFileContentExtractor() error {
+	_args := &Z_UnregisterFileContentExtractorArgs{}
+	_returns := &Z_UnregisterFileContentExtractorReturns{}
+	if err := g.client.Call("Plugin.UnregisterFileContentExtractor", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterFileContentExtractor API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterFileContentExtractor(args *Z_UnregisterFileContentExtractorArgs, returns *Z_UnregisterFileContentExtractorReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterFileContentExtractor() error
+	}); ok {
+		returns.A = hook.UnregisterFileContentExtractor()
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterFileContentExtractor called but not implemented."))
+	}
+	return nil
+}
+
+type Z_RegisterMentionKeywordsArgs struct {
+	A []string
+}
+
+type Z_RegisterMentionKeywordsReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) RegisterMentionKeywords(keywords []string) error {
+	_args := &Z_RegisterMentionKeywordsArgs{keywords}
+	_returns := &Z_RegisterMentionKeywordsReturns{}
+	if err := g.client.Call("Plugin.RegisterMentionKeywords", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterMentionKeywords API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterMentionKeywords(args *Z_RegisterMentionKeywordsArgs, returns *Z_RegisterMentionKeywordsReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterMentionKeywords(keywords []string) error
+	}); ok {
+		returns.A = hook.RegisterMentionKeywords(args.A)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterMentionKeywords called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterMentionKeywordsArgs struct {
+}
+
+type Z_UnregisterMentionKeywordsReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) UnregisterMentionKeywords() error {
+	_args := &Z_UnregisterMentionKeywordsArgs{}
+	_returns := &Z_UnregisterMentionKeywordsReturns{}
+	if err := g.client.Call("Plugin.UnregisterMentionKeywords", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterMentionKeywords API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterMentionKeywords(args *Z_UnregisterMentionKeywordsArgs, returns *Z_UnregisterMentionKeywordsReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterMentionKeywords() error
+	}); ok {
+		returns.A = hook.UnregisterMentionKeywords()
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterMentionKeywords called but not implemented."))
+	}
+	return nil
+}
@@ -61,6 +61,8 @@ const (
 	OnSharedChannelsAttachmentSyncMsgID       = 43
 	OnSharedChannelsProfileImageSyncMsgID     = 44
 	GenerateSupportDataID                     = 45
+	ExtractFileContentID                      = 46
+	ResolveMentionsID                         = 47
 	TotalHooksID                              = iota
 )
 
@@ -395,4 +397,25 @@ type Hooks interface {
 	//
 	// Minimum server version: 9.8
 	GenerateSupportData(c *Context) ([]*model.FileData, error)
+
+	// ExtractFileContent is invoked for plugins that registered themselves via
+	// API.RegisterFileContentExtractor when the searchable text of a file with one of
+	// the registered extensions needs to be extracted.
+	//
+	// Return the extracted text, or an error to let the server fall back to its
+	// built-in extractors.
+	//
+	// Minimum server version: 10.5
+	ExtractFileContent(c *Context, fileName string, content []byte) (string, error)
+
+	// ResolveMentions is invoked for plugins that registered mention keywords via
+	// API.RegisterMentionKeywords when a post in a channel mentions some of them.
+	//
+	// Return the ids of the users mentioned by each keyword. Users that are members
+	// of the channel are notified as if a group they belong to was mentioned, the
+	// others are suggested to be added to the channel. Keywords left out of the
+	// result don't mention anyone.
+	//
+	// Minimum server version: 10.5
+	ResolveMentions(c *Context, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error)
 }
@@ -291,3 +291,17 @@ func (hooks *hooksTimerLayer) GenerateSupportData(c *Context) ([]*model.FileData
 	hooks.recordTime(startTime, "GenerateSupportData", _returnsB == nil)
 	return _returnsA, _returnsB
 }
+
+func (hooks *hooksTimerLayer) ExtractFileContent(c *Context, fileName string, content []byte) (string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.ExtractFileContent(c, fileName, content)
+	hooks.recordTime(startTime, "ExtractFileContent", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) ResolveMentions(c *Context, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.ResolveMentions(c, post, channel, keywords)
+	hooks.recordTime(startTime, "ResolveMentions", _returnsB == nil)
+	return _returnsA, _returnsB
+}
@@ -3959,6 +3959,42 @@ func (_m *API) RegisterCommand(command *model.Command) error {
 	return r0
 }
 
+// RegisterFileContentExtractor provides a mock function with given fields: extensions
+func (_m *API) RegisterFileContentExtractor(extensions []string) error {
+	ret := _m.Called(extensions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterFileContentExtractor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(extensions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterMentionKeywords provides a mock function with given fields: keywords
+func (_m *API) RegisterMentionKeywords(keywords []string) error {
+	ret := _m.Called(keywords)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterMentionKeywords")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(keywords)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RegisterPluginForSharedChannels provides a mock function with given fields: opts
 func (_m *API) RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (string, error) {
 	ret := _m.Called(opts)
@@ -4581,6 +4617,42 @@ func (_m *API) UnregisterCommand(teamID string, trigger string) error {
 	return r0
 }
 
+// UnregisterFileContentExtractor provides a mock function with given fields:
+func (_m *API) UnregisterFileContentExtractor() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterFileContentExtractor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnregisterMentionKeywords provides a mock function with given fields:
+func (_m *API) UnregisterMentionKeywords() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterMentionKeywords")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnregisterPluginForSharedChannels provides a mock function with given fields: pluginID
 func (_m *API) UnregisterPluginForSharedChannels(pluginID string) error {
 	ret := _m.Called(pluginID)
@@ -87,6 +87,34 @@ func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 	return r0, r1
 }
 
+// ExtractFileContent provides a mock function with given fields: c, fileName, content
+func (_m *Hooks) ExtractFileContent(c *plugin.Context, fileName string, content []byte) (string, error) {
+	ret := _m.Called(c, fileName, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtractFileContent")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, []byte) (string, error)); ok {
+		return rf(c, fileName, content)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, []byte) string); ok {
+		r0 = rf(c, fileName, content)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, []byte) error); ok {
+		r1 = rf(c, fileName, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FileWillBeUploaded provides a mock function with given fields: c, info, file, output
 func (_m *Hooks) FileWillBeUploaded(c *plugin.Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
 	ret := _m.Called(c, info, file, output)
@@ -496,6 +524,36 @@ func (_m *Hooks) ReactionHasBeenRemoved(c *plugin.Context, reaction *model.React
 	_m.Called(c, reaction)
 }
 
+// ResolveMentions provides a mock function with given fields: c, post, channel, keywords
+func (_m *Hooks) ResolveMentions(c *plugin.Context, post *model.Post, channel *model.Channel, keywords []string) (map[string][]string, error) {
+	ret := _m.Called(c, post, channel, keywords)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveMentions")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post, *model.Channel, []string) (map[string][]string, error)); ok {
+		return rf(c, post, channel, keywords)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post, *model.Channel, []string) map[string][]string); ok {
+		r0 = rf(c, post, channel, keywords)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.Post, *model.Channel, []string) error); ok {
+		r1 = rf(c, post, channel, keywords)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RunDataRetention provides a mock function with given fields: nowTime, batchSize
 func (_m *Hooks) RunDataRetention(nowTime int64, batchSize int64) (int64, error) {
 	ret := _m.Called(nowTime, batchSize)
@@ -54,6 +54,21 @@ func (f *FileService) SetSearchableContent(id string, content string) error {
 	return normalizeAppErr(appErr)
 }
 
+// RegisterContentExtractor registers the plugin to extract the searchable text of files
+// with the given extensions. The ExtractFileContent hook is invoked to do the extraction.
+//
+// Minimum server version: 10.5
+func (f *FileService) RegisterContentExtractor(extensions ...string) error {
+	return f.api.RegisterFileContentExtractor(extensions)
+}
+
+// UnregisterContentExtractor unregisters the plugin as a file content extractor.
+//
+// Minimum server version: 10.5
+func (f *FileService) UnregisterContentExtractor() error {
+	return f.api.UnregisterFileContentExtractor()
+}
+
 // GetLink gets the public link of a file by id.
 //
 // Minimum server version: 5.6
@@ -182,6 +182,21 @@ func (p *PostService) RemoveReaction(reaction *model.Reaction) error {
 	return normalizeAppErr(p.api.RemoveReaction(reaction))
 }
 
+// RegisterMentionKeywords registers the plugin to resolve the given mention keywords
+// to users. The ResolveMentions hook is invoked when a post mentions one of them.
+//
+// Minimum server version: 10.5
+func (p *PostService) RegisterMentionKeywords(keywords ...string) error {
+	return p.api.RegisterMentionKeywords(keywords)
+}
+
+// UnregisterMentionKeywords unregisters the mention keywords of the plugin.
+//
+// Minimum server version: 10.5
+func (p *PostService) UnregisterMentionKeywords() error {
+	return p.api.UnregisterMentionKeywords()
+}
+
 type ShouldProcessMessageOption func(*shouldProcessMessageOptions)
 
 type shouldProcessMessageOptions struct {
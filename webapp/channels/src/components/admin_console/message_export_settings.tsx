@@ -55,7 +55,7 @@ const messages = defineMessages({
         defaultMessage: 'Format of the compliance export. Corresponds to the system that you want to import the data into.'},
     exportFormat_description_details: {
         id: 'admin.complianceExport.exportFormatDetail.details',
-        defaultMessage: 'For Actiance XML, compliance export files are written to the exports subdirectory of the configured <a>Local Storage Directory</a>. For Global Relay EML, they are emailed to the configured email address.'},
+        defaultMessage: 'For Actiance XML, CSV, EML in MBOX and JSON Lines, compliance export files are written to the exports subdirectory of the configured <a>Local Storage Directory</a>. For Global Relay EML, they are emailed to the configured email address.'},
     createJob_title: {id: 'admin.complianceExport.createJob.title', defaultMessage: 'Run Compliance Export Job Now'},
     createJob_help: {id: 'admin.complianceExport.createJob.help', defaultMessage: 'Initiates a Compliance Export job immediately.'},
 });
@@ -189,6 +189,8 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
             {value: exportFormats.EXPORT_FORMAT_ACTIANCE, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.actiance', defaultMessage: 'Actiance XML'})},
             {value: exportFormats.EXPORT_FORMAT_CSV, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.csv', defaultMessage: 'CSV'})},
             {value: exportFormats.EXPORT_FORMAT_GLOBALRELAY, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.globalrelay', defaultMessage: 'GlobalRelay EML'})},
+            {value: exportFormats.EXPORT_FORMAT_MBOX, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.mbox', defaultMessage: 'EML in MBOX'})},
+            {value: exportFormats.EXPORT_FORMAT_JSONL, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.jsonl', defaultMessage: 'JSON Lines'})},
         ];
 
         // if the export format is globalrelay, the user needs to set some additional parameters
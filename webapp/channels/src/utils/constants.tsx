@@ -657,6 +657,7 @@ export const SocketEvents = {
     THREAD_READ_CHANGED: 'thread_read_changed',
     POST_ACKNOWLEDGEMENT_ADDED: 'post_acknowledgement_added',
     POST_ACKNOWLEDGEMENT_REMOVED: 'post_acknowledgement_removed',
+    POLL_UPDATED: 'poll_updated',
     DRAFT_CREATED: 'draft_created',
     DRAFT_UPDATED: 'draft_updated',
     DRAFT_DELETED: 'draft_deleted',
@@ -1446,6 +1447,8 @@ export const exportFormats = {
     EXPORT_FORMAT_CSV: 'csv',
     EXPORT_FORMAT_ACTIANCE: 'actiance',
     EXPORT_FORMAT_GLOBALRELAY: 'globalrelay',
+    EXPORT_FORMAT_MBOX: 'mbox',
+    EXPORT_FORMAT_JSONL: 'jsonl',
 };
 
 export const CacheTypes = {
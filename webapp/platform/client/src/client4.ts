@@ -103,14 +103,22 @@ import type {
     MarketplaceApp,
     MarketplacePlugin,
 } from '@mattermost/types/marketplace';
-import type {MfaSecret} from '@mattermost/types/mfa';
+import type {
+    MfaRecoveryCodes,
+    MfaSecret,
+    WebAuthnAssertionResponse,
+    WebAuthnAttestationResponse,
+    WebAuthnCredential,
+    WebAuthnCredentialCreationOptions,
+    WebAuthnCredentialRequestOptions,
+} from '@mattermost/types/mfa';
 import type {
     ClientPluginManifest,
     PluginManifest,
     PluginsResponse,
     PluginStatus,
 } from '@mattermost/types/plugins';
-import type {Post, PostList, PostSearchResults, PostsUsageResponse, TeamsUsageResponse, PaginatedPostList, FilesUsageResponse, PostAcknowledgement, PostAnalytics, PostInfo} from '@mattermost/types/posts';
+import type {Post, PostList, PostSearchResults, PostsUsageResponse, TeamsUsageResponse, PaginatedPostList, FilesUsageResponse, PostAcknowledgement, PostAnalytics, PostInfo, PostReminder, PollCreate, PollResults} from '@mattermost/types/posts';
 import type {PreferenceType} from '@mattermost/types/preferences';
 import type {ProductNotices} from '@mattermost/types/product_notices';
 import type {Reaction} from '@mattermost/types/reactions';
@@ -647,7 +655,8 @@ export default class Client4 {
             body.code = code;
         }
 
-        return this.doFetch<StatusOK>(
+        // The recovery codes are only returned when MFA is activated.
+        return this.doFetch<StatusOK & Partial<MfaRecoveryCodes>>(
             `${this.getUserRoute(userId)}/mfa`,
             {method: 'put', body: JSON.stringify(body)},
         );
@@ -783,6 +792,17 @@ export default class Client4 {
         );
     };
 
+    beginWebAuthnLogin = (loginId: string, password: string) => {
+        return this.doFetch<WebAuthnCredentialRequestOptions>(
+            `${this.getUsersRoute()}/login/webauthn`,
+            {method: 'post', body: JSON.stringify({login_id: loginId, password})},
+        );
+    };
+
+    loginWithWebAuthn = (loginId: string, password: string, assertion: WebAuthnAssertionResponse) => {
+        return this.login(loginId, password, JSON.stringify(assertion));
+    };
+
     loginById = (id: string, password: string, token = '') => {
         const body: any = {
             id,
@@ -1032,6 +1052,41 @@ export default class Client4 {
         );
     };
 
+    regenerateMfaRecoveryCodes = (userId: string, password = '', code = '') => {
+        return this.doFetch<MfaRecoveryCodes>(
+            `${this.getUserRoute(userId)}/mfa/recovery_codes`,
+            {method: 'post', body: JSON.stringify({password, code})},
+        );
+    };
+
+    beginWebAuthnRegistration = (userId: string) => {
+        return this.doFetch<WebAuthnCredentialCreationOptions>(
+            `${this.getUserRoute(userId)}/webauthn/registration`,
+            {method: 'post'},
+        );
+    };
+
+    registerWebAuthnCredential = (userId: string, name: string, credential: WebAuthnAttestationResponse, password = '', mfaToken = '') => {
+        return this.doFetch<WebAuthnCredential>(
+            `${this.getUserRoute(userId)}/webauthn/credentials`,
+            {method: 'post', body: JSON.stringify({name, credential, password, mfa_token: mfaToken})},
+        );
+    };
+
+    getWebAuthnCredentials = (userId: string) => {
+        return this.doFetch<WebAuthnCredential[]>(
+            `${this.getUserRoute(userId)}/webauthn/credentials`,
+            {method: 'get'},
+        );
+    };
+
+    revokeWebAuthnCredential = (userId: string, credentialId: string) => {
+        return this.doFetch<StatusOK>(
+            `${this.getUserRoute(userId)}/webauthn/credentials/${credentialId}`,
+            {method: 'delete'},
+        );
+    };
+
     searchUsers = (term: string, options: any) => {
         return this.doFetch<UserProfile[]>(
             `${this.getUsersRoute()}/search`,
@@ -2259,6 +2314,48 @@ export default class Client4 {
         );
     };
 
+    getPostReminders = (userId: string) => {
+        return this.doFetch<PostReminder[]>(
+            `${this.getUserRoute(userId)}/posts/reminders`,
+            {method: 'get'},
+        );
+    };
+
+    deletePostReminder = (userId: string, postId: string) => {
+        return this.doFetch<StatusOK>(
+            `${this.getUserRoute(userId)}/posts/${postId}/reminder`,
+            {method: 'delete'},
+        );
+    };
+
+    createPoll = (pollCreate: PollCreate) => {
+        return this.doFetch<Post>(
+            `${this.getPostsRoute()}/polls`,
+            {method: 'post', body: JSON.stringify(pollCreate)},
+        );
+    };
+
+    getPollResults = (postId: string) => {
+        return this.doFetch<PollResults>(
+            `${this.getPostRoute(postId)}/poll`,
+            {method: 'get'},
+        );
+    };
+
+    votePoll = (postId: string, options: number[]) => {
+        return this.doFetch<PollResults>(
+            `${this.getPostRoute(postId)}/poll/votes`,
+            {method: 'post', body: JSON.stringify({options})},
+        );
+    };
+
+    closePoll = (postId: string) => {
+        return this.doFetch<PollResults>(
+            `${this.getPostRoute(postId)}/poll/close`,
+            {method: 'post'},
+        );
+    };
+
     pinPost = (postId: string) => {
         return this.doFetch<StatusOK>(
             `${this.getPostRoute(postId)}/pin`,
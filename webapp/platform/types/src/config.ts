@@ -313,6 +313,8 @@ export type ServiceSettings = {
     EnableOutgoingOAuthConnections: boolean;
     EnableCommands: boolean;
     OutgoingIntegrationRequestsTimeout: number;
+    OutgoingWebhookMaxRetries: number;
+    OutgoingWebhookDisableAfterFailures: number;
     EnablePostUsernameOverride: boolean;
     EnablePostIconOverride: boolean;
     EnableLinkPreviews: boolean;
@@ -452,6 +454,8 @@ export type SqlSettings = {
     MigrationsStatementTimeoutSeconds: number;
     ReplicaLagSettings: ReplicaLagSetting[];
     ReplicaMonitorIntervalSeconds: number;
+    ReplicaMaxLagSeconds: number;
+    ReadYourWritesWindowSeconds: number;
 };
 
 export type LogSettings = {
@@ -479,6 +483,7 @@ export type ExperimentalAuditSettings = {
     FileMaxBackups: number;
     FileCompress: boolean;
     FileMaxQueueSize: number;
+    DatabaseEnabled: boolean;
     AdvancedLoggingJSON: Record<string, any>;
 };
 
@@ -609,6 +614,17 @@ export type RateLimitSettings = {
     VaryByRemoteAddr: boolean;
     VaryByUser: boolean;
     VaryByHeader: string;
+    Store: string;
+    Policies: RateLimitPolicy[];
+};
+
+export type RateLimitPolicy = {
+    Name: string;
+    Routes: string[];
+    PersonalAccessTokens: boolean;
+    Bots: boolean;
+    PerSec: number;
+    MaxBurst: number;
 };
 
 export type PrivacySettings = {
@@ -869,6 +885,8 @@ export type DataRetentionSettings = {
     BoardsRetentionDays: number;
     TimeBetweenBatchesMilliseconds: number;
     RetentionIdsBatchSize: number;
+    EnableAuditLogDeletion: boolean;
+    AuditLogRetentionDays: number;
 };
 
 export type MessageExportSettings = {
@@ -894,6 +912,7 @@ export type JobSettings = {
     RunScheduler: boolean;
     CleanupJobsThresholdDays: number;
     CleanupConfigThresholdDays: number;
+    ConfigFileHistorySize: number;
 };
 
 export type PluginSettings = {
@@ -932,6 +951,8 @@ export type ImageProxySettings = {
     ImageProxyType: string;
     RemoteImageProxyURL: string;
     RemoteImageProxyOptions: string;
+    LocalImageProxyCacheSizeMB: number;
+    LocalImageProxyMaxImageResolution: number;
 };
 
 export type CloudSettings = {
@@ -5,3 +5,64 @@ export type MfaSecret = {
     secret: string;
     qr_code: string;
 };
+
+export type MfaRecoveryCodes = {
+    recovery_codes: string[];
+};
+
+export type WebAuthnCredential = {
+    id: string;
+    user_id: string;
+    credential_id: string;
+    aaguid: string;
+    sign_count: number;
+    name: string;
+    create_at: number;
+    last_used_at: number;
+};
+
+export type WebAuthnCredentialDescriptor = {
+    type: 'public-key';
+    id: string;
+};
+
+export type WebAuthnCredentialCreationOptions = {
+    challenge: string;
+    rp: {id: string; name: string};
+    user: {id: string; name: string; displayName: string};
+    pubKeyCredParams: Array<{type: 'public-key'; alg: number}>;
+    timeout: number;
+    excludeCredentials: WebAuthnCredentialDescriptor[];
+    authenticatorSelection: {residentKey: string; userVerification: string};
+    attestation: string;
+};
+
+export type WebAuthnCredentialRequestOptions = {
+    challenge: string;
+    timeout: number;
+    rpId: string;
+    allowCredentials: WebAuthnCredentialDescriptor[];
+    userVerification: string;
+};
+
+// WebAuthnAttestationResponse is the result of navigator.credentials.create with binary fields encoded as base64url.
+export type WebAuthnAttestationResponse = {
+    id: string;
+    type: 'public-key';
+    response: {
+        clientDataJSON: string;
+        attestationObject: string;
+    };
+};
+
+// WebAuthnAssertionResponse is the result of navigator.credentials.get with binary fields encoded as base64url.
+export type WebAuthnAssertionResponse = {
+    id: string;
+    type: 'public-key';
+    response: {
+        clientDataJSON: string;
+        authenticatorData: string;
+        signature: string;
+        userHandle?: string;
+    };
+};
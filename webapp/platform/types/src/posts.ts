@@ -33,6 +33,7 @@ export type PostType = 'system_add_remove' |
 'system_fake_parent_deleted' |
 'system_generic' |
 'reminder' |
+'poll' |
 'system_wrangler' |
 '';
 
@@ -57,6 +58,41 @@ export type PostAcknowledgement = {
     acknowledged_at: number;
 }
 
+export type PostReminder = {
+    PostId: Post['id'];
+    UserId: UserProfile['id'];
+    target_time: number;
+}
+
+export type Poll = {
+    post_id: Post['id'];
+    user_id: UserProfile['id'];
+    options: string[];
+    anonymous: boolean;
+    multiple_choice: boolean;
+    close_at: number;
+    create_at: number;
+}
+
+export type PollCreate = {
+    channel_id: string;
+    root_id?: string;
+    question: string;
+    options: string[];
+    anonymous?: boolean;
+    multiple_choice?: boolean;
+    close_at?: number;
+}
+
+export type PollResults = {
+    poll: Poll;
+    closed: boolean;
+    counts: number[];
+    voter_count: number;
+    voters?: Array<Array<UserProfile['id']>>;
+    my_votes: number[];
+}
+
 export type PostPriorityMetadata = {
     priority: PostPriority|'';
     requested_ack?: boolean;
@@ -10,6 +10,9 @@ export type SchedulingInfo = {
     scheduled_at: number;
     processed_at?: number;
     error_code?: ScheduledPostErrorCode;
+    recurrence_rule?: string;
+    timezone?: string;
+    paused_at?: number;
 }
 
 export type ScheduledPost = Omit<Draft, 'delete_at'> & SchedulingInfo & {
@@ -28,10 +28,22 @@ export type UserNotifyProps = {
     push_threads?: 'default' | 'all' | 'mention' | 'none';
     auto_responder_active?: 'true' | 'false';
     auto_responder_message?: string;
+    auto_responder_remote_message?: string;
+    auto_responder_mentions?: 'true' | 'false';
     calls_mobile_sound?: 'true' | 'false' | '';
     calls_mobile_notification_sound?: 'Dynamic' | 'Calm' | 'Urgent' | 'Cheerful' | '';
 };
 
+export type OutOfOffice = {
+    user_id: string;
+    active: boolean;
+    message: string;
+    remote_message: string;
+    reply_to_mentions: boolean;
+    start_at: number;
+    end_at: number;
+};
+
 export type UserProfile = {
     id: string;
     create_at: number;